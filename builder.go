@@ -27,6 +27,8 @@ package docx
 
 import (
 	"fmt"
+	"io"
+	"io/fs"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
 	"github.com/mmonterroca/docxgo/v2/pkg/errors"
@@ -211,6 +213,12 @@ func NewDocumentBuilder(opts ...Option) *DocumentBuilder {
 		}
 	}
 
+	if config.ImageCompression != (domain.ImageCompression{}) {
+		if err := doc.SetImageCompression(config.ImageCompression); err != nil {
+			builder.errors = append(builder.errors, err)
+		}
+	}
+
 	// Apply theme if provided
 	if config.Theme != nil {
 		// Use type assertion to get Theme interface
@@ -529,6 +537,65 @@ func (pb *ParagraphBuilder) AddImageWithPosition(path string, size domain.ImageS
 	return pb
 }
 
+// AddImageFromBytes adds an image from in-memory data (e.g. a rendered chart).
+// An empty format is detected from the data; zero size and position keep the
+// natural size and place the image inline.
+func (pb *ParagraphBuilder) AddImageFromBytes(data []byte, format domain.ImageFormat, size domain.ImageSize, pos domain.ImagePosition) *ParagraphBuilder {
+	if pb.err != nil {
+		return pb
+	}
+
+	if _, err := pb.para.AddImageFromBytes(data, format, size, pos); err != nil {
+		pb.err = err
+		pb.parent.errors = append(pb.parent.errors, err)
+	}
+
+	return pb
+}
+
+// AddImageFromReader adds an image read from r.
+func (pb *ParagraphBuilder) AddImageFromReader(r io.Reader, format domain.ImageFormat, size domain.ImageSize, pos domain.ImagePosition) *ParagraphBuilder {
+	if pb.err != nil {
+		return pb
+	}
+
+	if _, err := pb.para.AddImageFromReader(r, format, size, pos); err != nil {
+		pb.err = err
+		pb.parent.errors = append(pb.parent.errors, err)
+	}
+
+	return pb
+}
+
+// AddImageFromFS adds an image loaded from fsys, such as an embed.FS.
+func (pb *ParagraphBuilder) AddImageFromFS(fsys fs.FS, name string, size domain.ImageSize, pos domain.ImagePosition) *ParagraphBuilder {
+	if pb.err != nil {
+		return pb
+	}
+
+	if _, err := pb.para.AddImageFromFS(fsys, name, size, pos); err != nil {
+		pb.err = err
+		pb.parent.errors = append(pb.parent.errors, err)
+	}
+
+	return pb
+}
+
+// AddSVG adds an SVG image. A nil fallback is rendered to PNG from the SVG
+// for viewers that cannot display SVG.
+func (pb *ParagraphBuilder) AddSVG(svg, fallback []byte, size domain.ImageSize, pos domain.ImagePosition) *ParagraphBuilder {
+	if pb.err != nil {
+		return pb
+	}
+
+	if _, err := pb.para.AddSVG(svg, fallback, size, pos); err != nil {
+		pb.err = err
+		pb.parent.errors = append(pb.parent.errors, err)
+	}
+
+	return pb
+}
+
 // End returns to the DocumentBuilder for further operations.
 func (pb *ParagraphBuilder) End() *DocumentBuilder {
 	return pb.parent
@@ -602,6 +669,34 @@ func (tb *TableBuilder) Style(style domain.TableStyle) *TableBuilder {
 	return tb
 }
 
+// Position makes the table float at the given position.
+func (tb *TableBuilder) Position(pos domain.TablePosition) *TableBuilder {
+	if tb.err != nil {
+		return tb
+	}
+
+	if err := tb.table.SetPosition(pos); err != nil {
+		tb.err = err
+		tb.parent.errors = append(tb.parent.errors, err)
+	}
+
+	return tb
+}
+
+// Caption adds a numbered caption above or below the table.
+func (tb *TableBuilder) Caption(label, text string, position domain.CaptionPosition) *TableBuilder {
+	if tb.err != nil {
+		return tb
+	}
+
+	if _, err := tb.table.AddCaption(label, text, position); err != nil {
+		tb.err = err
+		tb.parent.errors = append(tb.parent.errors, err)
+	}
+
+	return tb
+}
+
 // End returns to the DocumentBuilder.
 func (tb *TableBuilder) End() *DocumentBuilder {
 	return tb.parent
@@ -773,6 +868,55 @@ func (cb *CellBuilder) Merge(colspan, rowspan int) *CellBuilder {
 	return cb
 }
 
+// AddImageFromBytes adds a paragraph holding an image from in-memory data.
+func (cb *CellBuilder) AddImageFromBytes(data []byte, format domain.ImageFormat, size domain.ImageSize, pos domain.ImagePosition) *CellBuilder {
+	return cb.addImage(func(para domain.Paragraph) error {
+		_, err := para.AddImageFromBytes(data, format, size, pos)
+		return err
+	})
+}
+
+// AddImageFromReader adds a paragraph holding an image read from r.
+func (cb *CellBuilder) AddImageFromReader(r io.Reader, format domain.ImageFormat, size domain.ImageSize, pos domain.ImagePosition) *CellBuilder {
+	return cb.addImage(func(para domain.Paragraph) error {
+		_, err := para.AddImageFromReader(r, format, size, pos)
+		return err
+	})
+}
+
+// AddImageFromFS adds a paragraph holding an image loaded from fsys.
+func (cb *CellBuilder) AddImageFromFS(fsys fs.FS, name string, size domain.ImageSize, pos domain.ImagePosition) *CellBuilder {
+	return cb.addImage(func(para domain.Paragraph) error {
+		_, err := para.AddImageFromFS(fsys, name, size, pos)
+		return err
+	})
+}
+
+// AddSVG adds a paragraph holding an SVG image with a bitmap fallback.
+func (cb *CellBuilder) AddSVG(svg, fallback []byte, size domain.ImageSize, pos domain.ImagePosition) *CellBuilder {
+	return cb.addImage(func(para domain.Paragraph) error {
+		_, err := para.AddSVG(svg, fallback, size, pos)
+		return err
+	})
+}
+
+func (cb *CellBuilder) addImage(add func(domain.Paragraph) error) *CellBuilder {
+	if cb.err != nil {
+		return cb
+	}
+
+	para, err := cb.cell.AddParagraph()
+	if err == nil {
+		err = add(para)
+	}
+	if err != nil {
+		cb.err = err
+		cb.parent.parent.parent.errors = append(cb.parent.parent.parent.errors, err)
+	}
+
+	return cb
+}
+
 // End returns to the RowBuilder.
 func (cb *CellBuilder) End() *RowBuilder {
 	return cb.parent
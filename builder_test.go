@@ -1,7 +1,11 @@
 package docx
 
 import (
+	"bytes"
+	"image"
+	"image/png"
 	"testing"
+	"testing/fstest"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
 	"github.com/mmonterroca/docxgo/v2/pkg/errors"
@@ -1034,3 +1038,41 @@ func TestParagraphBuilder_AddImage(t *testing.T) {
 		}
 	})
 }
+
+func TestBuilder_AddImageFromMemory(t *testing.T) {
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, image.NewRGBA(image.Rect(0, 0, 16, 16))); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+	data := buf.Bytes()
+	assets := fstest.MapFS{"logo.png": &fstest.MapFile{Data: data}}
+
+	builder := NewDocumentBuilder()
+	builder.AddParagraph().
+		AddImageFromBytes(data, domain.ImageFormatPNG, domain.ImageSize{}, domain.ImagePosition{}).
+		AddImageFromReader(bytes.NewReader(data), "", domain.NewImageSize(32, 32), domain.ImagePosition{}).
+		AddImageFromFS(assets, "logo.png", domain.ImageSize{}, domain.ImagePosition{}).
+		End()
+	builder.AddTable(1, 1).
+		Row(0).Cell(0).AddImageFromFS(assets, "logo.png", domain.ImageSize{}, domain.ImagePosition{}).End().End().
+		End()
+
+	doc, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if got := len(doc.Paragraphs()[0].Images()); got != 3 {
+		t.Errorf("paragraph images = %d, want 3", got)
+	}
+	row, _ := doc.Tables()[0].Row(0)
+	cell, _ := row.Cell(0)
+	if got := len(cell.Paragraphs()); got != 1 || len(cell.Paragraphs()[0].Images()) != 1 {
+		t.Error("expected one image paragraph in the cell")
+	}
+
+	failing := NewDocumentBuilder()
+	failing.AddParagraph().AddImageFromBytes([]byte("not an image"), "", domain.ImageSize{}, domain.ImagePosition{}).End()
+	if _, err := failing.Build(); err == nil {
+		t.Error("expected error for invalid image data")
+	}
+}
@@ -30,11 +30,28 @@ import (
 
 	"github.com/mmonterroca/docxgo/v2/domain"
 	"github.com/mmonterroca/docxgo/v2/internal/core"
+	"github.com/mmonterroca/docxgo/v2/internal/layout"
 	"github.com/mmonterroca/docxgo/v2/internal/reader"
 	"github.com/mmonterroca/docxgo/v2/pkg/color"
 	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+	"github.com/mmonterroca/docxgo/v2/render/pdf"
 )
 
+// Documents lay themselves out with the PDF renderer for PageCount and
+// UpdateFields. The renderer depends on core, so it is installed here.
+func init() {
+	core.SetPaginator(paginate)
+}
+
+// paginate lays out doc with the PDF renderer's metrics.
+func paginate(doc domain.Document) (layout.Pagination, error) {
+	pages, err := pdf.Paginate(doc, pdf.Options{})
+	if err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
 // NewDocument creates a new empty Word document.
 // The document is created with default settings and an empty body.
 //
@@ -189,6 +206,23 @@ func NewPageCountField() domain.Field {
 	return core.NewPageCountField()
 }
 
+// NewSectionPagesField creates a field that displays the number of pages
+// in the current section.
+func NewSectionPagesField() domain.Field {
+	return core.NewSectionPagesField()
+}
+
+// NewPageRefField creates a field that displays the page of a bookmark,
+// such as the _Toc bookmarks given to headings.
+//
+// Example:
+//
+//	run.AddText("See page ")
+//	run.AddField(docx.NewPageRefField("_Toc0"))
+func NewPageRefField(bookmark string) domain.Field {
+	return core.NewPageRefField(bookmark)
+}
+
 // NewTOCField creates a Table of Contents field.
 // The switches map accepts standard Word TOC switches:
 //   - "levels": Heading levels to include (e.g., "1-3")
@@ -1,8 +1,14 @@
 package docx
 
 import (
+	"archive/zip"
 	"bytes"
+	"image"
+	"image/png"
+	"io"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
@@ -62,3 +68,264 @@ func assertParagraphText(t *testing.T, doc domain.Document, expected string) {
 		t.Fatalf("unexpected paragraph text: %q", got)
 	}
 }
+
+func TestSVGImageRoundTrip(t *testing.T) {
+	logo := []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="64" height="32" viewBox="0 0 64 32">` +
+		`<rect width="64" height="32" fill="#1f77b4"/><circle cx="32" cy="16" r="10" fill="white"/></svg>`)
+
+	builder := NewDocumentBuilder()
+	builder.AddParagraph().AddSVG(logo, nil, domain.ImageSize{}, domain.ImagePosition{}).End()
+	doc, err := builder.Build()
+	if err != nil {
+		t.Fatalf("Build: %v", err)
+	}
+
+	img := doc.Paragraphs()[0].Images()[0]
+	if img.Format() != domain.ImageFormatSVG || img.Size().WidthPx != 64 || img.Size().HeightPx != 32 {
+		t.Fatalf("unexpected SVG image %s %+v", img.Format(), img.Size())
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	parts := readZipParts(t, buf.Bytes())
+	body := string(parts["word/document.xml"])
+	if !strings.Contains(body, "asvg:svgBlip") || !strings.Contains(body, "{96DAC541-7B7A-43D3-8B79-37D633B846F1}") {
+		t.Error("document should reference the SVG through the svgBlip extension")
+	}
+	if !strings.Contains(string(parts["[Content_Types].xml"]), `Extension="svg" ContentType="image/svg+xml"`) {
+		t.Error("content types should declare svg")
+	}
+
+	var fallback []byte
+	for name, data := range parts {
+		if strings.HasPrefix(name, "word/media/") && strings.HasSuffix(name, ".png") {
+			fallback = data
+		}
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(fallback))
+	if err != nil {
+		t.Fatalf("fallback PNG: %v", err)
+	}
+	if cfg.Width != 128 || cfg.Height != 64 {
+		t.Errorf("fallback should be rendered at 2x, got %dx%d", cfg.Width, cfg.Height)
+	}
+
+	reopened, err := OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("OpenDocumentFromBytes: %v", err)
+	}
+	images := reopened.Paragraphs()[0].Images()
+	if len(images) != 1 || images[0].Format() != domain.ImageFormatSVG {
+		t.Fatalf("expected the SVG image after reading, got %v", images)
+	}
+	withFallback, ok := images[0].(interface{ Fallback() []byte })
+	if !ok || !bytes.Equal(withFallback.Fallback(), fallback) {
+		t.Error("fallback bitmap should be restored")
+	}
+
+	var again bytes.Buffer
+	if _, err := reopened.WriteTo(&again); err != nil {
+		t.Fatalf("WriteTo after reading: %v", err)
+	}
+	if !strings.Contains(string(readZipParts(t, again.Bytes())["word/document.xml"]), "asvg:svgBlip") {
+		t.Error("svgBlip should survive a round trip")
+	}
+
+	bad := NewDocumentBuilder()
+	bad.AddParagraph().AddSVG([]byte("plain text"), nil, domain.ImageSize{}, domain.ImagePosition{}).End()
+	if _, err := bad.Build(); err == nil {
+		t.Error("expected error for non-SVG data")
+	}
+}
+
+func readZipParts(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader: %v", err)
+	}
+	parts := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatalf("open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatalf("read %s: %v", f.Name, err)
+		}
+		parts[f.Name] = content
+	}
+	return parts
+}
+
+func TestImageFormattingRoundTrip(t *testing.T) {
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, image.NewRGBA(image.Rect(0, 0, 40, 20))); err != nil {
+		t.Fatalf("png.Encode: %v", err)
+	}
+
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	img, err := para.AddImageFromBytes(pngData.Bytes(), "", domain.ImageSize{}, domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddImageFromBytes: %v", err)
+	}
+	crop := domain.ImageCrop{Left: 10, Top: 5, Right: 12.5, Bottom: 0}
+	border := domain.ImageBorder{Color: domain.Color{R: 0x33, G: 0x66, B: 0x99}, Width: 2}
+	effects := domain.ImageEffects{
+		Shadow:       domain.ShadowCentered,
+		Reflection:   domain.ReflectionTight,
+		Brightness:   15,
+		Contrast:     -20,
+		Grayscale:    true,
+		Transparency: 25,
+	}
+	if err := img.SetCrop(crop); err != nil {
+		t.Fatalf("SetCrop: %v", err)
+	}
+	_ = img.SetRotation(-90)
+	_ = img.SetFlip(false, true)
+	_ = img.SetBorder(border)
+	if err := img.SetEffects(effects); err != nil {
+		t.Fatalf("SetEffects: %v", err)
+	}
+	if err := img.SetHyperlink("https://example.com/catalog"); err != nil {
+		t.Fatalf("SetHyperlink: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	parts := readZipParts(t, buf.Bytes())
+	if !strings.Contains(string(parts["word/_rels/document.xml.rels"]), `Target="https://example.com/catalog" TargetMode="External"`) {
+		t.Error("picture hyperlink should be an external relationship")
+	}
+
+	reopened, err := OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("OpenDocumentFromBytes: %v", err)
+	}
+	got := reopened.Paragraphs()[0].Images()[0]
+	if got.Crop() != crop {
+		t.Errorf("Crop() = %+v, want %+v", got.Crop(), crop)
+	}
+	if got.Rotation() != 270 {
+		t.Errorf("Rotation() = %v, want 270", got.Rotation())
+	}
+	if h, v := got.Flip(); h || !v {
+		t.Errorf("Flip() = %v, %v, want false, true", h, v)
+	}
+	if got.Border() != border {
+		t.Errorf("Border() = %+v, want %+v", got.Border(), border)
+	}
+	if got.Effects() != effects {
+		t.Errorf("Effects() = %+v, want %+v", got.Effects(), effects)
+	}
+	if got.Hyperlink() != "https://example.com/catalog" {
+		t.Errorf("Hyperlink() = %q", got.Hyperlink())
+	}
+
+	var again bytes.Buffer
+	if _, err := reopened.WriteTo(&again); err != nil {
+		t.Fatalf("WriteTo after reading: %v", err)
+	}
+	if !strings.Contains(string(readZipParts(t, again.Bytes())["word/document.xml"]), "a:hlinkClick") {
+		t.Error("picture hyperlink should survive a round trip")
+	}
+}
+
+func TestReplaceImageInOpenedDocument(t *testing.T) {
+	encode := func(w, h int) []byte {
+		var buf bytes.Buffer
+		if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+			t.Fatalf("png.Encode: %v", err)
+		}
+		return buf.Bytes()
+	}
+
+	doc := NewDocument()
+	section, _ := doc.DefaultSection()
+	header, _ := section.Header(domain.HeaderDefault)
+	headerPara, _ := header.AddParagraph()
+	if _, err := headerPara.AddImageFromBytes(encode(30, 30), "", domain.NewImageSize(60, 60), domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddImageFromBytes: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	opened, err := OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("OpenDocumentFromBytes: %v", err)
+	}
+	images := opened.Images()
+	if len(images) != 1 || images[0].Part != domain.ImagePartHeader {
+		t.Fatalf("expected the header logo, got %+v", images)
+	}
+	logo := encode(80, 40)
+	if err := images[0].Image.ReplaceData(logo, true); err != nil {
+		t.Fatalf("ReplaceData: %v", err)
+	}
+
+	var saved bytes.Buffer
+	if _, err := opened.WriteTo(&saved); err != nil {
+		t.Fatalf("WriteTo after replacing: %v", err)
+	}
+	reopened, err := OpenDocumentFromBytes(saved.Bytes())
+	if err != nil {
+		t.Fatalf("OpenDocumentFromBytes: %v", err)
+	}
+	images = reopened.Images()
+	if len(images) != 1 || !bytes.Equal(images[0].Image.Data(), logo) {
+		t.Fatal("replaced logo should be saved")
+	}
+	parts := readZipParts(t, saved.Bytes())
+	embed := regexp.MustCompile(`r:embed="([^"]+)"`).FindSubmatch(parts["word/header1.xml"])
+	if embed == nil {
+		t.Fatalf("header has no picture:\n%s", parts["word/header1.xml"])
+	}
+	target := regexp.MustCompile(`Id="` + string(embed[1]) + `"[^>]*Target="([^"]+)"`).FindSubmatch(parts["word/_rels/header1.xml.rels"])
+	if target == nil || !bytes.Equal(parts["word/"+string(target[1])], logo) {
+		t.Errorf("header r:embed %s does not resolve to the logo in word/_rels/header1.xml.rels:\n%s", embed[1], parts["word/_rels/header1.xml.rels"])
+	}
+	if size := images[0].Image.Size(); size.WidthPx != 60 || size.HeightPx != 30 {
+		t.Errorf("Size() = %+v, want 60x30", size)
+	}
+
+	paths, err := reopened.ExtractMedia(t.TempDir())
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("ExtractMedia: %v, %v", paths, err)
+	}
+}
+
+func TestPageCountOfOpenedDocument(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	run, _ := para.AddRun()
+	_ = run.SetText("First page")
+	_ = doc.AddPageBreak()
+	para, _ = doc.AddParagraph()
+	run, _ = para.AddRun()
+	_ = run.SetText("Second page")
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	opened, err := OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("OpenDocumentFromBytes: %v", err)
+	}
+	for _, d := range []domain.Document{doc, opened} {
+		if pages, err := d.PageCount(); err != nil || pages != 2 {
+			t.Errorf("PageCount() = %d, %v; want 2", pages, err)
+		}
+	}
+}
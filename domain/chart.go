@@ -0,0 +1,116 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package domain
+
+// ChartType selects the kind of chart.
+type ChartType string
+
+// Supported chart types.
+const (
+	ChartColumn  ChartType = "column"  // Vertical bars
+	ChartBar     ChartType = "bar"     // Horizontal bars
+	ChartLine    ChartType = "line"    // Lines through category values
+	ChartPie     ChartType = "pie"     // Slices of a single series
+	ChartScatter ChartType = "scatter" // Points at X/Y values
+)
+
+// LegendPosition places the chart legend.
+type LegendPosition string
+
+// Legend positions.
+const (
+	LegendRight  LegendPosition = ""       // Right of the plot area (default)
+	LegendTop    LegendPosition = "top"    // Above the plot area
+	LegendBottom LegendPosition = "bottom" // Below the plot area
+	LegendLeft   LegendPosition = "left"   // Left of the plot area
+	LegendNone   LegendPosition = "none"   // No legend
+)
+
+// ChartSpec describes a chart and its data. The data is stored in a
+// workbook embedded in the document, so it can be edited in Word.
+type ChartSpec struct {
+	Type       ChartType
+	Title      string
+	Categories []string      // Category labels; not used by scatter charts
+	Series     []ChartSeries // Data series; pie charts take exactly one
+	XAxis      ChartAxis     // Category axis, or the X value axis of scatter charts
+	YAxis      ChartAxis     // Value axis
+	Legend     LegendPosition
+	DataLabels bool    // Show values next to bars, points and slices
+	Colors     []Color // Series colors (slice colors for pie charts); document chart colors when empty
+}
+
+// ChartSeries is a named set of values.
+type ChartSeries struct {
+	Name    string
+	Values  []float64 // One value per category, or the Y values of scatter charts
+	XValues []float64 // X values of scatter charts
+}
+
+// ChartAxis configures a chart axis.
+type ChartAxis struct {
+	Title        string
+	Min          float64 // Fixed minimum; Min and Max are automatic when equal
+	Max          float64 // Fixed maximum
+	NumberFormat string  // Excel number format for labels, e.g. "#,##0" or "0%"
+	Gridlines    bool    // Draw major gridlines across the plot area
+	Hidden       bool    // Hide the axis labels and line
+}
+
+// Chart is a native chart anchored in a paragraph.
+type Chart interface {
+	// ID returns the unique chart ID.
+	ID() string
+
+	// Spec returns the chart definition.
+	Spec() ChartSpec
+
+	// SetSpec replaces the chart definition, e.g. to update its data.
+	SetSpec(spec ChartSpec) error
+
+	// Size returns the chart dimensions.
+	Size() ImageSize
+
+	// SetSize sets the chart dimensions.
+	SetSize(size ImageSize) error
+
+	// Position returns the chart position settings.
+	Position() ImagePosition
+
+	// SetPosition places the chart inline or floating, as for images.
+	SetPosition(pos ImagePosition) error
+
+	// Description returns the alt text description.
+	Description() string
+
+	// SetDescription sets the alt text description.
+	SetDescription(desc string) error
+
+	// RelationshipID returns the relationship ID of the chart part.
+	RelationshipID() string
+
+	// Target returns the chart part path (e.g., "charts/chart1.xml").
+	Target() string
+}
@@ -132,6 +132,11 @@ type Document interface {
 	// The returned slice is a copy and modifications won't affect the document.
 	Blocks() []Block
 
+	// MoveBlocks moves the top-level blocks from index start up to end
+	// (exclusive) so that they begin at index at once moved. Content added
+	// at the end of the document can be placed anywhere this way.
+	MoveBlocks(start, end, at int) error
+
 	// WriteTo writes the document to the provided writer in .docx format.
 	// Returns the number of bytes written and any error encountered.
 	WriteTo(w io.Writer) (int64, error)
@@ -159,6 +164,70 @@ type Document interface {
 	// BackgroundColor returns the configured page background color.
 	// The boolean result indicates whether a background color is explicitly set.
 	BackgroundColor() (Color, bool)
+
+	// SetImageCompression enables image downsampling and JPEG re-encoding
+	// when the document is written. The zero value disables it.
+	SetImageCompression(opts ImageCompression) error
+
+	// ImageCompression returns the image optimization applied on save.
+	ImageCompression() ImageCompression
+
+	// SetChartColors sets the series colors of charts that do not specify
+	// their own. Themes set them when applied; nil restores the defaults.
+	SetChartColors(colors []Color) error
+
+	// ChartColors returns the default chart series colors.
+	ChartColors() []Color
+
+	// SetTheme sets the document theme written to word/theme/theme1.xml.
+	SetTheme(theme DocumentTheme) error
+
+	// Theme returns the document theme; the Office theme by default.
+	Theme() DocumentTheme
+
+	// AddList creates a list numbering and returns its ID for
+	// Paragraph.SetNumbering. Each list numbers from its own start.
+	AddList(def ListDefinition) (int, error)
+
+	// ListLevel returns the markers of a list level referenced by
+	// Paragraph.Numbering, for lists added with AddList or read from the
+	// document's numbering part.
+	ListLevel(ref NumberingReference) (ListLevel, bool)
+
+	// Footnotes returns every footnote in document order.
+	Footnotes() []Footnote
+
+	// Images returns every image in the body, tables, headers and footers,
+	// in document order, with its location.
+	Images() []DocumentImage
+
+	// EmbeddedObjects returns every embedded object in the body, tables,
+	// headers and footers, in document order.
+	EmbeddedObjects() []EmbeddedObject
+
+	// InsertAltChunk appends HTML, RTF, DOCX or plain text content that
+	// Word imports in place when it opens the document. The format is
+	// detected from data.
+	InsertAltChunk(data []byte) error
+
+	// ExtractMedia writes each media part of the document to dir, creating
+	// it if needed, and returns the written file paths.
+	ExtractMedia(dir string) ([]string, error)
+
+	// SetThumbnail sets the JPEG preview of the first page that file
+	// browsers show. Nil removes it.
+	SetThumbnail(jpeg []byte) error
+
+	// Thumbnail returns the JPEG preview of the document, or nil.
+	Thumbnail() []byte
+
+	// PageCount lays out the document and returns its number of pages.
+	PageCount() (int, error)
+
+	// UpdateFields lays out the document and sets the results of its page
+	// fields (PAGE, NUMPAGES, SECTIONPAGES and PAGEREF) and of its TOC
+	// fields from the pages, for viewers that do not update fields.
+	UpdateFields() error
 }
 
 // Metadata contains document properties like title, author, etc.
@@ -0,0 +1,88 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package domain
+
+// EmbedOptions controls how an embedded file is shown.
+type EmbedOptions struct {
+	Icon []byte    // PNG or JPEG preview; nil draws a file icon colored by type
+	Size ImageSize // Displayed size; zero uses the icon size at 96 DPI
+}
+
+// EmbeddedObject is a file embedded in a paragraph as an OLE object and
+// shown as an icon. Word opens it with the application registered for
+// its ProgID. Office files are stored as they are; other files are
+// wrapped in an OLE Package.
+type EmbeddedObject interface {
+	// ID returns the unique object ID.
+	ID() string
+
+	// FileName returns the name of the embedded file.
+	FileName() string
+
+	// ProgID returns the OLE program ID, such as "Excel.Sheet.12" or
+	// "Package".
+	ProgID() string
+
+	// Data returns the embedded file. Objects read from a document that
+	// are neither Office files nor packages return the raw OLE storage.
+	Data() []byte
+
+	// Icon returns the preview image shown in the document.
+	Icon() []byte
+
+	// Size returns the displayed size of the icon.
+	Size() ImageSize
+
+	// SetSize sets the displayed size of the icon.
+	SetSize(size ImageSize) error
+
+	// RelationshipID returns the relationship ID of the embedded part.
+	RelationshipID() string
+
+	// Target returns the embedded part path relative to word/.
+	Target() string
+}
+
+// AltChunkFormat identifies the content of an alternative format chunk.
+type AltChunkFormat string
+
+// Alternative format chunk contents.
+const (
+	AltChunkHTML AltChunkFormat = "html" // HTML document or fragment
+	AltChunkMHT  AltChunkFormat = "mht"  // MIME HTML web archive
+	AltChunkRTF  AltChunkFormat = "rtf"  // Rich Text Format
+	AltChunkDOCX AltChunkFormat = "docx" // Word document
+	AltChunkText AltChunkFormat = "text" // Plain text
+)
+
+// AltChunk is external content that Word converts and merges into the
+// document where the chunk appears when the file is opened. Other
+// applications may ignore it.
+type AltChunk struct {
+	Format         AltChunkFormat
+	Data           []byte
+	RelationshipID string
+	Target         string // Part path relative to word/
+}
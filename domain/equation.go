@@ -0,0 +1,124 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package domain
+
+// EquationMode selects how an equation is laid out.
+type EquationMode string
+
+// Equation modes.
+const (
+	EquationInline  EquationMode = "inline"  // Within the line of text
+	EquationDisplay EquationMode = "display" // Centered on a line of its own
+)
+
+// MathNode is a part of an equation. Build equations from the Math types
+// below, or write them in LaTeX with MathLaTeX.
+type MathNode interface {
+	mathNode()
+}
+
+// MathText is a run of variables, numbers and operators. Letters are set
+// in math italic.
+type MathText string
+
+// MathPlainText is upright text such as words, units and function names.
+type MathPlainText string
+
+// MathRow is a sequence of nodes.
+type MathRow []MathNode
+
+// MathLaTeX is an equation in a LaTeX subset, converted when the equation
+// is added. Supported: ^ and _, \frac, \sqrt (with an optional degree),
+// \sum, \prod, \int and other large operators with limits, \left ...
+// \right, matrix environments (matrix, pmatrix, bmatrix, vmatrix, cases),
+// \text, function names such as \sin, Greek letters and common symbols.
+type MathLaTeX string
+
+// MathFraction is a stacked fraction.
+type MathFraction struct {
+	Num MathNode
+	Den MathNode
+}
+
+// MathRadical is a root. A nil Degree is a square root.
+type MathRadical struct {
+	Degree MathNode
+	Base   MathNode
+}
+
+// MathScript attaches a subscript, a superscript or both to Base.
+type MathScript struct {
+	Base MathNode
+	Sub  MathNode
+	Sup  MathNode
+}
+
+// MathNary is a large operator such as a sum or an integral, with optional
+// limits, applied to Body.
+type MathNary struct {
+	Operator string // e.g. "∑", "∏", "∫"; defaults to "∫"
+	Sub      MathNode
+	Sup      MathNode
+	Body     MathNode
+}
+
+// MathMatrix is a grid of cells. All rows should have the same length.
+type MathMatrix struct {
+	Rows [][]MathNode
+}
+
+// MathDelimiter encloses Body in brackets that grow with its height.
+type MathDelimiter struct {
+	Open  string // Opening character; "(" when both are empty
+	Close string // Closing character; ")" when both are empty
+	Body  MathNode
+}
+
+func (MathText) mathNode()      {}
+func (MathPlainText) mathNode() {}
+func (MathRow) mathNode()       {}
+func (MathLaTeX) mathNode()     {}
+func (MathFraction) mathNode()  {}
+func (MathRadical) mathNode()   {}
+func (MathScript) mathNode()    {}
+func (MathNary) mathNode()      {}
+func (MathMatrix) mathNode()    {}
+func (MathDelimiter) mathNode() {}
+
+// Equation is an Office Math equation in a paragraph.
+type Equation interface {
+	// Mode returns whether the equation is inline or on its own line.
+	Mode() EquationMode
+
+	// SetMode switches between inline and display layout.
+	SetMode(mode EquationMode) error
+
+	// Content returns the equation tree. LaTeX input is returned converted.
+	// Equations read from a document return nil; they are kept verbatim.
+	Content() MathNode
+
+	// OMML returns the equation as an m:oMath element.
+	OMML() string
+}
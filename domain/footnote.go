@@ -0,0 +1,41 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package domain
+
+// Footnote is a note shown at the bottom of the page, referenced by a
+// number in the text.
+type Footnote interface {
+	// ID returns the footnote number used in footnotes.xml.
+	ID() int
+
+	// AddParagraph adds a paragraph to the footnote text.
+	AddParagraph() (Paragraph, error)
+
+	// Paragraphs returns the paragraphs of the footnote text.
+	Paragraphs() []Paragraph
+
+	// Text returns the plain text of the footnote.
+	Text() string
+}
@@ -24,6 +24,8 @@ SOFTWARE.
 
 package domain
 
+import "math"
+
 // ImageFormat represents supported image file formats.
 type ImageFormat string
 
@@ -38,6 +40,8 @@ const (
 	ImageFormatTIF  ImageFormat = "tif"  // TIFF format (short name)
 	ImageFormatSVG  ImageFormat = "svg"  // SVG format
 	ImageFormatWEBP ImageFormat = "webp" // WebP format
+	ImageFormatEMF  ImageFormat = "emf"  // Enhanced Metafile format
+	ImageFormatWMF  ImageFormat = "wmf"  // Windows Metafile format
 )
 
 // ImageSize represents image dimensions.
@@ -60,6 +64,24 @@ func NewImageSize(widthPx, heightPx int) ImageSize {
 	}
 }
 
+// NewImageSizeDPI creates an ImageSize from pixel dimensions at the given
+// resolution. A non-positive DPI falls back to 96.
+func NewImageSizeDPI(widthPx, heightPx int, dpiX, dpiY float64) ImageSize {
+	const emuPerInch = 914400
+	if dpiX <= 0 {
+		dpiX = 96
+	}
+	if dpiY <= 0 {
+		dpiY = 96
+	}
+	return ImageSize{
+		WidthPx:   widthPx,
+		HeightPx:  heightPx,
+		WidthEMU:  int(math.Round(float64(widthPx) * emuPerInch / dpiX)),
+		HeightEMU: int(math.Round(float64(heightPx) * emuPerInch / dpiY)),
+	}
+}
+
 // NewImageSizeInches creates an ImageSize from inch dimensions.
 func NewImageSizeInches(widthInches, heightInches float64) ImageSize {
 	const emuPerInch = 914400
@@ -104,8 +126,129 @@ type Image interface {
 
 	// Position returns the image position settings.
 	Position() ImagePosition
+
+	// Crop returns the picture cropping.
+	Crop() ImageCrop
+
+	// SetCrop trims the picture edges. The displayed size is unchanged, so
+	// call SetSize to keep the original scale.
+	SetCrop(crop ImageCrop) error
+
+	// Rotation returns the clockwise rotation in degrees.
+	Rotation() float64
+
+	// SetRotation rotates the picture clockwise by the given degrees.
+	SetRotation(degrees float64) error
+
+	// Flip reports whether the picture is mirrored.
+	Flip() (horizontal, vertical bool)
+
+	// SetFlip mirrors the picture horizontally and/or vertically.
+	SetFlip(horizontal, vertical bool) error
+
+	// Border returns the picture outline.
+	Border() ImageBorder
+
+	// SetBorder draws an outline around the picture. A zero width removes it;
+	// widths over 1584 points, the largest Word supports, are clamped.
+	SetBorder(border ImageBorder) error
+
+	// Effects returns the picture effects.
+	Effects() ImageEffects
+
+	// SetEffects applies shadow, reflection and color adjustments.
+	SetEffects(effects ImageEffects) error
+
+	// Hyperlink returns the URL opened when the picture is clicked.
+	Hyperlink() string
+
+	// SetHyperlink makes the picture a link to url. An empty url removes it.
+	SetHyperlink(url string) error
+
+	// ReplaceData swaps the picture for new image data, keeping its position
+	// and formatting. The displayed size is kept; with keepAspect the new
+	// image is fitted inside it without distortion.
+	ReplaceData(data []byte, keepAspect bool) error
 }
 
+// ImagePart identifies the document part that holds an image.
+type ImagePart string
+
+// Document parts that can hold images.
+const (
+	ImagePartBody   ImagePart = "body"   // Main document body
+	ImagePartHeader ImagePart = "header" // Section header
+	ImagePartFooter ImagePart = "footer" // Section footer
+)
+
+// DocumentImage is an image together with its location in a document.
+type DocumentImage struct {
+	Image     Image
+	Paragraph Paragraph // Paragraph containing the image
+	Table     Table     // Innermost table containing the paragraph, if any
+	Part      ImagePart // Body, header or footer
+	Section   Section   // Section owning the header or footer; nil in the body
+}
+
+// ImageCompression controls how images are optimized when a document is
+// saved. The stored image data is not modified.
+type ImageCompression struct {
+	// TargetDPI downsamples images whose pixels exceed their displayed size
+	// at this resolution. Zero keeps the original pixels.
+	TargetDPI float64
+
+	// JPEGQuality re-encodes JPEG images at this quality (1-100). Zero keeps
+	// JPEGs that are not downsampled unchanged.
+	JPEGQuality int
+}
+
+// ImageCrop trims the edges of a picture. Each value is the percentage of
+// the original image (0-100) removed from that side.
+type ImageCrop struct {
+	Left   float64
+	Top    float64
+	Right  float64
+	Bottom float64
+}
+
+// ImageBorder is the outline drawn around a picture.
+type ImageBorder struct {
+	Color Color
+	Width float64 // Line width in points
+}
+
+// ImageEffects holds picture effects and color adjustments.
+type ImageEffects struct {
+	Shadow       ImageShadow     // Shadow preset
+	Reflection   ImageReflection // Reflection preset
+	Brightness   float64         // -100 to 100 percent
+	Contrast     float64         // -100 to 100 percent
+	Grayscale    bool            // Render in shades of gray
+	Transparency float64         // 0 (opaque) to 100 (invisible) percent
+}
+
+// ImageShadow selects a picture shadow preset.
+type ImageShadow string
+
+// Picture shadow presets.
+const (
+	ShadowNone     ImageShadow = ""         // No shadow
+	ShadowOuter    ImageShadow = "outer"    // Drop shadow offset to the bottom right
+	ShadowCentered ImageShadow = "centered" // Soft shadow around all sides
+	ShadowInner    ImageShadow = "inner"    // Inner shadow from the top left
+)
+
+// ImageReflection selects a picture reflection preset.
+type ImageReflection string
+
+// Picture reflection presets.
+const (
+	ReflectionNone  ImageReflection = ""      // No reflection
+	ReflectionTight ImageReflection = "tight" // Short reflection touching the picture
+	ReflectionHalf  ImageReflection = "half"  // Reflection of half the picture
+	ReflectionFull  ImageReflection = "full"  // Reflection of the whole picture
+)
+
 // ImagePosition represents image positioning options.
 type ImagePosition struct {
 	Type       ImagePositionType // Inline or Floating
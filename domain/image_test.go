@@ -77,6 +77,32 @@ func TestNewImageSize(t *testing.T) {
 	}
 }
 
+func TestNewImageSizeDPI(t *testing.T) {
+	tests := []struct {
+		name       string
+		dpiX, dpiY float64
+		wantEMUW   int
+		wantEMUH   int
+	}{
+		{"96 DPI", 96, 96, 2857500, 1905000},
+		{"300 DPI", 300, 300, 914400, 609600},
+		{"anisotropic", 72, 144, 3810000, 1270000},
+		{"unknown falls back to 96", 0, -1, 2857500, 1905000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			size := NewImageSizeDPI(300, 200, tt.dpiX, tt.dpiY)
+			if size.WidthPx != 300 || size.HeightPx != 200 {
+				t.Errorf("pixels = %dx%d; want 300x200", size.WidthPx, size.HeightPx)
+			}
+			if size.WidthEMU != tt.wantEMUW || size.HeightEMU != tt.wantEMUH {
+				t.Errorf("EMU = %dx%d; want %dx%d", size.WidthEMU, size.HeightEMU, tt.wantEMUW, tt.wantEMUH)
+			}
+		})
+	}
+}
+
 func TestNewImageSizeInches(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -168,6 +194,8 @@ func TestImageFormatConstants(t *testing.T) {
 		{"TIF", ImageFormatTIF, "tif"},
 		{"SVG", ImageFormatSVG, "svg"},
 		{"WEBP", ImageFormatWEBP, "webp"},
+		{"EMF", ImageFormatEMF, "emf"},
+		{"WMF", ImageFormatWMF, "wmf"},
 	}
 
 	for _, tt := range tests {
@@ -25,6 +25,11 @@ SOFTWARE.
 
 package domain
 
+import (
+	"io"
+	"io/fs"
+)
+
 // Paragraph represents a paragraph in a document.
 // A paragraph contains one or more runs of formatted text.
 type Paragraph interface {
@@ -48,6 +53,61 @@ type Paragraph interface {
 	// AddImageWithPosition adds an image with custom positioning.
 	AddImageWithPosition(path string, size ImageSize, pos ImagePosition) (Image, error)
 
+	// AddImageFromBytes adds an image from in-memory data. An empty format is
+	// detected from the data; a zero size keeps the natural size and a zero
+	// position places the image inline.
+	AddImageFromBytes(data []byte, format ImageFormat, size ImageSize, pos ImagePosition) (Image, error)
+
+	// AddImageFromReader adds an image read from r. See AddImageFromBytes.
+	AddImageFromReader(r io.Reader, format ImageFormat, size ImageSize, pos ImagePosition) (Image, error)
+
+	// AddImageFromFS adds an image loaded from fsys (e.g. an embed.FS). The
+	// format is taken from the file extension or detected from the data.
+	AddImageFromFS(fsys fs.FS, name string, size ImageSize, pos ImagePosition) (Image, error)
+
+	// AddSVG adds an SVG image. Word stores a bitmap next to the SVG for
+	// viewers without SVG support; fallback supplies it (PNG or JPEG), and a
+	// nil fallback is rendered from the SVG.
+	AddSVG(svg, fallback []byte, size ImageSize, pos ImagePosition) (Image, error)
+
+	// AddTextBox adds a text box whose content is added with
+	// Shape.AddParagraph. A zero position places it inline.
+	AddTextBox(size ImageSize, pos ImagePosition) (Shape, error)
+
+	// AddShape adds a drawing shape such as a rectangle, line or callout.
+	AddShape(shapeType ShapeType, size ImageSize, pos ImagePosition) (Shape, error)
+
+	// Shapes returns all shapes and text boxes in this paragraph.
+	Shapes() []Shape
+
+	// AddChart adds a native chart built from spec. A zero size uses Word's
+	// default chart size and a zero position places it inline.
+	AddChart(spec ChartSpec, size ImageSize, pos ImagePosition) (Chart, error)
+
+	// Charts returns all charts in this paragraph.
+	Charts() []Chart
+
+	// AddEquation adds an Office Math equation built from Math nodes or a
+	// MathLaTeX string. Display equations are centered on their own line.
+	AddEquation(content MathNode, mode EquationMode) (Equation, error)
+
+	// Equations returns all equations in this paragraph.
+	Equations() []Equation
+
+	// AddEmbeddedObject embeds a file, such as a spreadsheet or PDF, as an
+	// OLE object shown as an icon. Double-clicking it in Word opens the file.
+	AddEmbeddedObject(fileName string, data []byte, opts EmbedOptions) (EmbeddedObject, error)
+
+	// EmbeddedObjects returns all embedded objects in this paragraph.
+	EmbeddedObjects() []EmbeddedObject
+
+	// AddFootnote adds a footnote reference at the end of the paragraph. The
+	// note text is added with Footnote.AddParagraph.
+	AddFootnote() (Footnote, error)
+
+	// Footnotes returns the footnotes referenced from this paragraph.
+	Footnotes() []Footnote
+
 	// Images returns all images in this paragraph.
 	Images() []Image
 
@@ -164,6 +224,28 @@ type NumberingReference struct {
 	Level int // ilvl value (0-8)
 }
 
+// ListKind selects the markers of a list.
+type ListKind int
+
+// List kinds.
+const (
+	ListBullet  ListKind = iota // Bullets (•, ◦, ▪ by level)
+	ListDecimal                 // Numbers (1., a., i. by level)
+)
+
+// ListDefinition describes a list created with Document.AddList.
+type ListDefinition struct {
+	Kind  ListKind
+	Start int // First number of the top level; 0 starts at 1
+}
+
+// ListLevel describes the markers of one level of a list.
+type ListLevel struct {
+	Kind   ListKind
+	Format string // Word number format, e.g. "bullet", "decimal" or "lowerRoman"
+	Start  int    // First number of the level
+}
+
 // Numbering level bounds supported by Word numbering definitions.
 const (
 	NumberingLevelMin = 0
@@ -185,15 +267,17 @@ type FieldType int
 
 // Field type constants for dynamic document fields.
 const (
-	FieldTypeTOC        FieldType = iota // Table of Contents
-	FieldTypePageNumber                  // Current page number
-	FieldTypeNumPages                    // Total number of pages
-	FieldTypePageCount                   // Alias for NumPages
-	FieldTypeDate                        // Current date
-	FieldTypeTime                        // Current time
-	FieldTypeStyleRef                    // Style reference
-	FieldTypeRef                         // Cross-reference
-	FieldTypeSeq                         // Sequence number
-	FieldTypeHyperlink                   // Hyperlink field
-	FieldTypeCustom                      // Custom field with user-defined code
+	FieldTypeTOC          FieldType = iota // Table of Contents
+	FieldTypePageNumber                    // Current page number
+	FieldTypeNumPages                      // Total number of pages
+	FieldTypePageCount                     // Alias for NumPages
+	FieldTypeDate                          // Current date
+	FieldTypeTime                          // Current time
+	FieldTypeStyleRef                      // Style reference
+	FieldTypeRef                           // Cross-reference
+	FieldTypeSeq                           // Sequence number
+	FieldTypeHyperlink                     // Hyperlink field
+	FieldTypeCustom                        // Custom field with user-defined code
+	FieldTypeSectionPages                  // Number of pages in the section
+	FieldTypePageRef                       // Page of a bookmark
 )
@@ -46,6 +46,13 @@ type Run interface {
 	// SetColor sets the text color.
 	SetColor(color Color) error
 
+	// ThemeColor returns the theme color of the text, if one is set.
+	ThemeColor() (ThemeColorRef, bool)
+
+	// SetThemeColor colors the text with a theme color, so it follows the
+	// document theme when it is changed in Word. SetColor clears it.
+	SetThemeColor(ref ThemeColorRef) error
+
 	// Size returns the font size in half-points (e.g., 24 = 12pt).
 	Size() int
 
@@ -82,6 +89,12 @@ type Run interface {
 	// SetHighlight sets the highlight color.
 	SetHighlight(color HighlightColor) error
 
+	// Script returns whether the text is superscript or subscript.
+	Script() Script
+
+	// SetScript raises the text as superscript or lowers it as subscript.
+	SetScript(script Script) error
+
 	// AddText is a convenience method that appends text to the run.
 	AddText(text string) error
 
@@ -96,7 +109,8 @@ type Run interface {
 type Font struct {
 	Name     string
 	EastAsia string
-	CS       string // Complex script
+	CS       string    // Complex script
+	Theme    ThemeFont // Theme font used instead of Name when set
 }
 
 // Color represents an RGB color.
@@ -139,6 +153,16 @@ const (
 	UnderlineWave                         // Wavy line underline
 )
 
+// Script represents the vertical position of text relative to the baseline.
+type Script int
+
+// Script constants.
+const (
+	ScriptBaseline    Script = iota // Normal text
+	ScriptSuperscript               // Raised, smaller text
+	ScriptSubscript                 // Lowered, smaller text
+)
+
 // HighlightColor represents text highlight/background colors.
 type HighlightColor int
 
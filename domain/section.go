@@ -83,11 +83,12 @@ type SectionBreak struct {
 }
 
 // Block represents a top-level document element in insertion order.
-// Exactly one of Paragraph, Table, or SectionBreak will be non-nil.
+// Exactly one of Paragraph, Table, SectionBreak, or AltChunk will be non-nil.
 type Block struct {
 	Paragraph    Paragraph
 	Table        Table
 	SectionBreak *SectionBreak
+	AltChunk     *AltChunk
 }
 
 // PageSize represents page dimensions in twips.
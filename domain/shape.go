@@ -0,0 +1,101 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package domain
+
+// ShapeType selects the geometry of a drawing shape.
+type ShapeType string
+
+// Supported shape types.
+const (
+	ShapeTextBox          ShapeType = "textBox"          // Rectangle holding text (Word text box)
+	ShapeRectangle        ShapeType = "rectangle"        // Rectangle
+	ShapeRoundedRectangle ShapeType = "roundedRectangle" // Rectangle with rounded corners
+	ShapeEllipse          ShapeType = "ellipse"          // Ellipse or circle
+	ShapeLine             ShapeType = "line"             // Straight line from top left to bottom right
+	ShapeArrow            ShapeType = "arrow"            // Line with an arrowhead at the end
+	ShapeCallout          ShapeType = "callout"          // Rounded speech bubble
+)
+
+// ShapeOutline is the line drawn around a shape, or the line itself for
+// line and arrow shapes.
+type ShapeOutline struct {
+	Color Color
+	Width float64 // Line width in points; zero hides the outline
+}
+
+// Shape is a drawing shape anchored in a paragraph. Shapes other than lines
+// and arrows can hold text.
+type Shape interface {
+	// ID returns the unique shape ID.
+	ID() string
+
+	// Type returns the shape geometry.
+	Type() ShapeType
+
+	// Size returns the shape dimensions.
+	Size() ImageSize
+
+	// SetSize sets the shape dimensions.
+	SetSize(size ImageSize) error
+
+	// Position returns the shape position settings.
+	Position() ImagePosition
+
+	// SetPosition places the shape inline or floating, as for images.
+	SetPosition(pos ImagePosition) error
+
+	// Fill returns the fill color; ok is false when the shape is not filled.
+	Fill() (color Color, ok bool)
+
+	// SetFill fills the shape with a solid color.
+	SetFill(color Color) error
+
+	// ClearFill makes the shape transparent.
+	ClearFill()
+
+	// Outline returns the shape outline.
+	Outline() ShapeOutline
+
+	// SetOutline sets the shape outline. A zero width removes it.
+	SetOutline(outline ShapeOutline) error
+
+	// TextAnchor returns the vertical alignment of the text.
+	TextAnchor() VerticalAlign
+
+	// SetTextAnchor aligns the text to the top, center or bottom.
+	SetTextAnchor(align VerticalAlign) error
+
+	// Description returns the alt text description.
+	Description() string
+
+	// SetDescription sets the alt text description.
+	SetDescription(desc string) error
+
+	// AddParagraph adds a paragraph to the shape text.
+	AddParagraph() (Paragraph, error)
+
+	// Paragraphs returns the paragraphs of the shape text.
+	Paragraphs() []Paragraph
+}
@@ -203,6 +203,13 @@ type ParagraphStyle interface {
 	// SetColor sets the default run color.
 	SetColor(color Color) error
 
+	// ThemeColor returns the default run theme color, if one is set.
+	ThemeColor() (ThemeColorRef, bool)
+
+	// SetThemeColor sets a theme color for the default run. SetColor
+	// clears it.
+	SetThemeColor(ref ThemeColorRef) error
+
 	// Size returns the default run font size in half-points.
 	Size() int
 
@@ -238,6 +245,12 @@ type CharacterStyle interface {
 	// SetColor sets the text color.
 	SetColor(color Color) error
 
+	// ThemeColor returns the theme color of the text, if one is set.
+	ThemeColor() (ThemeColorRef, bool)
+
+	// SetThemeColor sets a theme color for the text. SetColor clears it.
+	SetThemeColor(ref ThemeColorRef) error
+
 	// Size returns the font size in half-points.
 	Size() int
 
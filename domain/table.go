@@ -25,6 +25,11 @@ SOFTWARE.
 
 package domain
 
+import (
+	"strconv"
+	"strings"
+)
+
 // Table represents a table in a document.
 type Table interface {
 	// Row returns the row at the specified index.
@@ -42,6 +47,14 @@ type Table interface {
 	// DeleteRow deletes the row at the specified index.
 	DeleteRow(index int) error
 
+	// InsertColumn inserts an empty column before index; index == ColumnCount()
+	// appends. Merged regions that straddle index grow to cover the new column.
+	InsertColumn(index int) error
+
+	// DeleteColumn deletes the column at index. Merged regions covering it
+	// shrink; their content is kept unless the region lies entirely in the column.
+	DeleteColumn(index int) error
+
 	// RowCount returns the number of rows in the table.
 	RowCount() int
 
@@ -65,6 +78,44 @@ type Table interface {
 
 	// SetStyle sets the table style.
 	SetStyle(style TableStyle) error
+
+	// Position returns the floating position of the table and whether one is set.
+	Position() (TablePosition, bool)
+
+	// SetPosition turns the table into a floating table anchored as described.
+	SetPosition(pos TablePosition) error
+
+	// ClearPosition removes floating positioning so the table flows inline again.
+	ClearPosition()
+
+	// Grid returns the logical layout of the table, indexed [row][column].
+	// Every grid position reports the merged region covering it, so cells
+	// hidden by a merge can be told apart from the region's top-left cell.
+	Grid() [][]CellMergeInfo
+
+	// SplitAt moves rows index..RowCount()-1 into a new table placed right
+	// after this one (separated by an empty paragraph, as Word does) and
+	// returns it. Vertical merges crossing the split are divided in two.
+	SplitAt(index int) (Table, error)
+
+	// MergeWith appends the rows of next, which must have the same number of
+	// columns, and removes next (and an empty paragraph separating the two
+	// tables) from the document or cell that contained it.
+	MergeWith(next Table) error
+
+	// SortRows stably sorts the rows below the first headerRows rows by the
+	// cells in column col. A nil compare uses CompareCellText. Rows that take
+	// part in a vertical merge cannot be sorted.
+	SortRows(col int, compare func(a, b TableCell) int, headerRows int) error
+
+	// Transpose swaps rows and columns, including merged regions. Cell widths
+	// and row heights are cleared because they no longer apply.
+	Transpose() error
+
+	// AddCaption inserts a Caption-styled paragraph above or below the table.
+	// The caption reads "<label> <n>: <text>", where n is a SEQ field numbering
+	// captions that share the same label (e.g. "Table").
+	AddCaption(label, text string, position CaptionPosition) (Paragraph, error)
 }
 
 // TableRow represents a row in a table.
@@ -157,6 +208,41 @@ const (
 	WidthPct                   // Percentage width (value = percentage * 50)
 )
 
+// TablePosition describes a floating (text-wrapped) table.
+// All distances are expressed in twips.
+type TablePosition struct {
+	HorizontalAnchor TableAnchor // Reference frame for X
+	VerticalAnchor   TableAnchor // Reference frame for Y
+	X                int         // Horizontal offset from the horizontal anchor
+	Y                int         // Vertical offset from the vertical anchor
+
+	LeftFromText   int // Distance between the table and surrounding text on the left
+	RightFromText  int // Distance between the table and surrounding text on the right
+	TopFromText    int // Distance between the table and surrounding text above
+	BottomFromText int // Distance between the table and surrounding text below
+
+	AllowOverlap bool // Whether the table may overlap other floating tables
+}
+
+// TableAnchor identifies the reference frame for a floating table offset.
+type TableAnchor int
+
+// Table anchor constants for floating tables.
+const (
+	TableAnchorText   TableAnchor = iota // Relative to the surrounding text
+	TableAnchorMargin                    // Relative to the page margins
+	TableAnchorPage                      // Relative to the page edge
+)
+
+// CaptionPosition controls where a table caption is placed.
+type CaptionPosition int
+
+// Caption position constants for table captions.
+const (
+	CaptionAbove CaptionPosition = iota // Caption precedes the table
+	CaptionBelow                        // Caption follows the table
+)
+
 // VerticalAlignment represents vertical alignment of content within a table cell.
 type VerticalAlignment int
 
@@ -226,8 +312,51 @@ const (
 
 // CellMergeInfo represents cell merge information.
 type CellMergeInfo struct {
-	GridSpan int               // Horizontal span (number of columns)
-	VMerge   VerticalMergeType // Vertical merge type
-	RowSpan  int               // Vertical span (number of rows) - calculated
-	ColSpan  int               // Horizontal span (number of columns) - same as GridSpan
+	GridSpan  int               // Horizontal span (number of columns)
+	VMerge    VerticalMergeType // Vertical merge type
+	RowSpan   int               // Vertical span (number of rows) - calculated
+	ColSpan   int               // Horizontal span (number of columns) - same as GridSpan
+	OriginRow int               // Row of the top-left cell of the merged region
+	OriginCol int               // Column of the top-left cell of the merged region
+}
+
+// IsOrigin reports whether the grid position at (row, col) is the top-left
+// cell of its merged region, i.e. the cell that carries the content.
+func (m CellMergeInfo) IsOrigin(row, col int) bool {
+	return m.OriginRow == row && m.OriginCol == col
+}
+
+// CellText returns the text of a table cell, one line per paragraph.
+func CellText(cell TableCell) string {
+	if cell == nil {
+		return ""
+	}
+	paragraphs := cell.Paragraphs()
+	lines := make([]string, len(paragraphs))
+	for i, para := range paragraphs {
+		lines[i] = para.Text()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CompareCellText orders cells by their text. When both cells hold numbers
+// they are compared numerically, otherwise lexically.
+func CompareCellText(a, b TableCell) int {
+	textA := strings.TrimSpace(CellText(a))
+	textB := strings.TrimSpace(CellText(b))
+
+	numA, errA := strconv.ParseFloat(textA, 64)
+	numB, errB := strconv.ParseFloat(textB, 64)
+	if errA == nil && errB == nil {
+		switch {
+		case numA < numB:
+			return -1
+		case numA > numB:
+			return 1
+		default:
+			return 0
+		}
+	}
+
+	return strings.Compare(textA, textB)
 }
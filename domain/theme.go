@@ -0,0 +1,144 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package domain
+
+// ThemeColor names a color of the document theme. Text and background
+// colors are the dark and light theme colors.
+type ThemeColor string
+
+// Theme colors.
+const (
+	ThemeColorText1             ThemeColor = "text1"       // Dark 1
+	ThemeColorBackground1       ThemeColor = "background1" // Light 1
+	ThemeColorText2             ThemeColor = "text2"       // Dark 2
+	ThemeColorBackground2       ThemeColor = "background2" // Light 2
+	ThemeColorAccent1           ThemeColor = "accent1"
+	ThemeColorAccent2           ThemeColor = "accent2"
+	ThemeColorAccent3           ThemeColor = "accent3"
+	ThemeColorAccent4           ThemeColor = "accent4"
+	ThemeColorAccent5           ThemeColor = "accent5"
+	ThemeColorAccent6           ThemeColor = "accent6"
+	ThemeColorHyperlink         ThemeColor = "hyperlink"
+	ThemeColorFollowedHyperlink ThemeColor = "followedHyperlink"
+)
+
+// ThemeColorRef refers to a theme color, optionally darkened or lightened.
+// Shade keeps that fraction of the luminance (0xBF keeps 75%); Tint moves
+// the color that far from white (0x99 is 60% of the color). Zero leaves
+// the color unchanged.
+type ThemeColorRef struct {
+	Color ThemeColor
+	Shade uint8
+	Tint  uint8
+}
+
+// ThemeFont selects a font of the document theme.
+type ThemeFont string
+
+// Theme fonts.
+const (
+	ThemeFontMajor ThemeFont = "major" // Headings font
+	ThemeFontMinor ThemeFont = "minor" // Body font
+)
+
+// ThemeColorScheme holds the twelve colors of a document theme.
+type ThemeColorScheme struct {
+	Dark1             Color // Usually the text color
+	Light1            Color // Usually the background color
+	Dark2             Color
+	Light2            Color
+	Accent1           Color
+	Accent2           Color
+	Accent3           Color
+	Accent4           Color
+	Accent5           Color
+	Accent6           Color
+	Hyperlink         Color
+	FollowedHyperlink Color
+}
+
+// Color returns the scheme color a theme color refers to.
+func (s ThemeColorScheme) Color(c ThemeColor) (Color, bool) {
+	switch c {
+	case ThemeColorText1:
+		return s.Dark1, true
+	case ThemeColorBackground1:
+		return s.Light1, true
+	case ThemeColorText2:
+		return s.Dark2, true
+	case ThemeColorBackground2:
+		return s.Light2, true
+	case ThemeColorAccent1:
+		return s.Accent1, true
+	case ThemeColorAccent2:
+		return s.Accent2, true
+	case ThemeColorAccent3:
+		return s.Accent3, true
+	case ThemeColorAccent4:
+		return s.Accent4, true
+	case ThemeColorAccent5:
+		return s.Accent5, true
+	case ThemeColorAccent6:
+		return s.Accent6, true
+	case ThemeColorHyperlink:
+		return s.Hyperlink, true
+	case ThemeColorFollowedHyperlink:
+		return s.FollowedHyperlink, true
+	}
+	return Color{}, false
+}
+
+// DocumentTheme is the theme part of a document (word/theme/theme1.xml).
+// Word's Design tab, charts, SmartArt and theme references in runs and
+// styles resolve against it.
+type DocumentTheme struct {
+	Name      string
+	Colors    ThemeColorScheme
+	MajorFont string // Headings
+	MinorFont string // Body text
+}
+
+// DefaultDocumentTheme returns the Office theme.
+func DefaultDocumentTheme() DocumentTheme {
+	return DocumentTheme{
+		Name: "Office Theme",
+		Colors: ThemeColorScheme{
+			Dark1:             Color{R: 0x00, G: 0x00, B: 0x00},
+			Light1:            Color{R: 0xFF, G: 0xFF, B: 0xFF},
+			Dark2:             Color{R: 0x44, G: 0x54, B: 0x6A},
+			Light2:            Color{R: 0xE7, G: 0xE6, B: 0xE6},
+			Accent1:           Color{R: 0x44, G: 0x72, B: 0xC4},
+			Accent2:           Color{R: 0xED, G: 0x7D, B: 0x31},
+			Accent3:           Color{R: 0xA5, G: 0xA5, B: 0xA5},
+			Accent4:           Color{R: 0xFF, G: 0xC0, B: 0x00},
+			Accent5:           Color{R: 0x5B, G: 0x9B, B: 0xD5},
+			Accent6:           Color{R: 0x70, G: 0xAD, B: 0x47},
+			Hyperlink:         Color{R: 0x05, G: 0x63, B: 0xC1},
+			FollowedHyperlink: Color{R: 0x95, G: 0x4F, B: 0x72},
+		},
+		MajorFont: "Calibri Light",
+		MinorFont: "Calibri",
+	}
+}
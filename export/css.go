@@ -0,0 +1,330 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package export
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/layout"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// baseCSS resets browser defaults toward Word's layout.
+const baseCSS = `p, h1, h2, h3, h4, h5, h6, blockquote, pre { margin: 0; }
+ul, ol { margin: 0; padding-left: 36pt; }
+li[class] { margin-left: 0; }
+table { border-collapse: collapse; }
+td { vertical-align: top; padding: 0 5.4pt; }
+table.TableGrid td { border: 0.5pt solid #000000; }
+img { max-width: 100%; }
+.footnotes { margin-top: 2em; border-top: 0.5pt solid #BFBFBF; }
+`
+
+// highlightColors are the CSS colors of Word's highlight palette.
+var highlightColors = map[domain.HighlightColor]string{
+	domain.HighlightYellow:      "#FFFF00",
+	domain.HighlightGreen:       "#00FF00",
+	domain.HighlightCyan:        "#00FFFF",
+	domain.HighlightMagenta:     "#FF00FF",
+	domain.HighlightBlue:        "#0000FF",
+	domain.HighlightRed:         "#FF0000",
+	domain.HighlightDarkBlue:    "#000080",
+	domain.HighlightDarkCyan:    "#008080",
+	domain.HighlightDarkGreen:   "#008000",
+	domain.HighlightDarkMagenta: "#800080",
+	domain.HighlightDarkRed:     "#800000",
+	domain.HighlightDarkYellow:  "#808000",
+	domain.HighlightDarkGray:    "#808080",
+	domain.HighlightLightGray:   "#C0C0C0",
+}
+
+// declarations is an ordered list of CSS declarations.
+type declarations struct {
+	names  []string
+	values map[string]string
+}
+
+// set adds or replaces a declaration, keeping its first position.
+func (d *declarations) set(name, value string) {
+	if d.values == nil {
+		d.values = map[string]string{}
+	}
+	if _, ok := d.values[name]; !ok {
+		d.names = append(d.names, name)
+	}
+	d.values[name] = value
+}
+
+func (d *declarations) String() string {
+	parts := make([]string, 0, len(d.names))
+	for _, name := range d.names {
+		parts = append(parts, name+": "+d.values[name])
+	}
+	return strings.Join(parts, "; ")
+}
+
+// stylesheet returns the base rules, the page width of the first section
+// and a rule per paragraph style used, with based-on styles resolved.
+func (w *htmlWriter) stylesheet() string {
+	var b strings.Builder
+	var section domain.Section
+	if sections := w.doc.Sections(); len(sections) > 0 {
+		section = sections[0]
+	}
+	b.WriteString("body { max-width: " + points(layout.SectionTextWidth(section)) + "; margin: 2em auto; }\n")
+	b.WriteString(baseCSS)
+
+	for _, class := range slices.Sorted(maps.Keys(w.classes)) {
+		if rule := w.styleRule(w.classes[class]); rule != "" {
+			b.WriteString("." + class + " { " + rule + "; }\n")
+		}
+	}
+	return b.String()
+}
+
+// styleRule returns the declarations of a paragraph style, applying the
+// styles it is based on first.
+func (w *htmlWriter) styleRule(id string) string {
+	var chain []domain.ParagraphStyle
+	manager := w.doc.StyleManager()
+	for id != "" && len(chain) < 10 {
+		style, err := manager.GetStyle(id)
+		if err != nil {
+			break
+		}
+		ps, ok := style.(domain.ParagraphStyle)
+		if !ok {
+			break
+		}
+		chain = append(chain, ps)
+		id = ps.BasedOn()
+	}
+
+	var d declarations
+	for _, style := range slices.Backward(chain) {
+		if family := w.fontFamily(style.Font()); family != "" {
+			d.set("font-family", family)
+		}
+		if size := style.Size(); size > 0 {
+			d.set("font-size", halfPoints(size))
+		}
+		if style.Bold() {
+			d.set("font-weight", "bold")
+		}
+		if style.Italic() {
+			d.set("font-style", "italic")
+		}
+		if style.Underline() != domain.UnderlineNone {
+			d.set("text-decoration", "underline")
+		}
+		if ref, ok := style.ThemeColor(); ok {
+			d.set("color", "#"+color.ToHex(w.themeColor(ref)))
+		} else if c := style.Color(); c != domain.ColorBlack {
+			d.set("color", "#"+color.ToHex(c))
+		}
+		if align := cssAlignment(style.Alignment()); align != "" {
+			d.set("text-align", align)
+		}
+		if before := style.SpacingBefore(); before > 0 {
+			d.set("margin-top", points(before))
+		}
+		if after := style.SpacingAfter(); after > 0 {
+			d.set("margin-bottom", points(after))
+		}
+		if line := style.LineSpacing(); line > 0 && line != constants.DefaultLineSpacing {
+			d.set("line-height", strconv.FormatFloat(float64(line)/constants.DefaultLineSpacing, 'f', 2, 64))
+		}
+		setIndentation(&d, style.Indentation())
+	}
+	if len(d.names) > 0 {
+		if _, ok := d.values["font-weight"]; !ok {
+			d.set("font-weight", "normal")
+		}
+	}
+	return d.String()
+}
+
+// paragraphCSS returns the direct formatting of a paragraph. List items
+// leave indentation to their list.
+func (w *htmlWriter) paragraphCSS(p domain.Paragraph, listItem bool) string {
+	var d declarations
+	if p.Alignment() != domain.AlignmentLeft {
+		d.set("text-align", cssAlignment(p.Alignment()))
+	}
+	if before := p.SpacingBefore(); before > 0 {
+		d.set("margin-top", points(before))
+	}
+	if after := p.SpacingAfter(); after > 0 {
+		d.set("margin-bottom", points(after))
+	}
+	switch spacing := p.LineSpacing(); {
+	case spacing.Value <= 0:
+	case spacing.Rule == domain.LineSpacingAuto && spacing.Value != constants.DefaultLineSpacing:
+		d.set("line-height", strconv.FormatFloat(float64(spacing.Value)/constants.DefaultLineSpacing, 'f', 2, 64))
+	case spacing.Rule != domain.LineSpacingAuto:
+		d.set("line-height", points(spacing.Value))
+	}
+	if !listItem {
+		setIndentation(&d, p.Indent())
+	}
+	borders := p.Borders()
+	setBorder(&d, "border-top", borders.Top)
+	setBorder(&d, "border-right", borders.Right)
+	setBorder(&d, "border-bottom", borders.Bottom)
+	setBorder(&d, "border-left", borders.Left)
+	return d.String()
+}
+
+// runCSS returns the direct formatting of a run that has no HTML element.
+// Default font, size and color are left to the paragraph style.
+func (w *htmlWriter) runCSS(run domain.Run, code bool) string {
+	var d declarations
+	if font := run.Font(); !code && (font.Theme != "" || font.Name != constants.DefaultFontName) {
+		if family := w.fontFamily(font); family != "" {
+			d.set("font-family", family)
+		}
+	}
+	if size := run.Size(); size > 0 && size != constants.DefaultFontSize {
+		d.set("font-size", halfPoints(size))
+	}
+	if ref, ok := run.ThemeColor(); ok {
+		d.set("color", "#"+color.ToHex(w.themeColor(ref)))
+	} else if c := run.Color(); c != domain.ColorBlack {
+		d.set("color", "#"+color.ToHex(c))
+	}
+	if highlight := highlightColors[run.Highlight()]; highlight != "" {
+		d.set("background-color", highlight)
+	}
+	return d.String()
+}
+
+// cellCSS returns the width, alignment, shading and borders of a cell.
+func cellCSS(cell domain.TableCell) string {
+	var d declarations
+	if width := cell.Width(); width > 0 {
+		d.set("width", points(width))
+	}
+	switch cell.VerticalAlignment() {
+	case domain.VerticalAlignCenter:
+		d.set("vertical-align", "middle")
+	case domain.VerticalAlignBottom:
+		d.set("vertical-align", "bottom")
+	}
+	if shading := cell.Shading(); shading != domain.ColorWhite && shading != (domain.Color{}) {
+		d.set("background-color", "#"+color.ToHex(shading))
+	}
+	borders := cell.Borders()
+	setBorder(&d, "border-top", borders.Top)
+	setBorder(&d, "border-right", borders.Right)
+	setBorder(&d, "border-bottom", borders.Bottom)
+	setBorder(&d, "border-left", borders.Left)
+	return d.String()
+}
+
+// fontFamily returns a quoted CSS font family, resolving theme fonts.
+func (w *htmlWriter) fontFamily(font domain.Font) string {
+	name := font.Name
+	switch font.Theme {
+	case domain.ThemeFontMajor:
+		name = w.theme.MajorFont
+	case domain.ThemeFontMinor:
+		name = w.theme.MinorFont
+	}
+	if name == "" {
+		return ""
+	}
+	return `"` + strings.ReplaceAll(name, `"`, "") + `"`
+}
+
+// themeColor resolves a theme color reference against the document theme.
+func (w *htmlWriter) themeColor(ref domain.ThemeColorRef) domain.Color {
+	c, _ := w.theme.Colors.Color(ref.Color)
+	if ref.Shade != 0 {
+		c = color.Shade(c, ref.Shade)
+	}
+	if ref.Tint != 0 {
+		c = color.Tint(c, ref.Tint)
+	}
+	return c
+}
+
+func setIndentation(d *declarations, indent domain.Indentation) {
+	if indent.Left > 0 {
+		d.set("margin-left", points(indent.Left))
+	}
+	if indent.Right > 0 {
+		d.set("margin-right", points(indent.Right))
+	}
+	if indent.FirstLine > 0 {
+		d.set("text-indent", points(indent.FirstLine))
+	} else if indent.Hanging > 0 {
+		d.set("text-indent", points(-indent.Hanging))
+	}
+}
+
+func setBorder(d *declarations, name string, border domain.BorderStyle) {
+	var style string
+	switch border.Style {
+	case domain.BorderNone:
+		return
+	case domain.BorderDotted:
+		style = "dotted"
+	case domain.BorderDashed:
+		style = "dashed"
+	case domain.BorderDouble, domain.BorderTriple:
+		style = "double"
+	default:
+		style = "solid"
+	}
+	width := max(border.Width, 2)
+	d.set(name, strconv.FormatFloat(float64(width)/8, 'f', -1, 64)+"pt "+style+" #"+color.ToHex(border.Color))
+}
+
+func cssAlignment(align domain.Alignment) string {
+	switch align {
+	case domain.AlignmentCenter:
+		return "center"
+	case domain.AlignmentRight:
+		return "right"
+	case domain.AlignmentJustify, domain.AlignmentDistribute:
+		return "justify"
+	}
+	return ""
+}
+
+// points converts twips to CSS points.
+func points(twips int) string {
+	return strconv.FormatFloat(float64(twips)/20, 'f', -1, 64) + "pt"
+}
+
+// halfPoints converts a font size in half-points to CSS points.
+func halfPoints(size int) string {
+	return strconv.FormatFloat(float64(size)/2, 'f', -1, 64) + "pt"
+}
@@ -0,0 +1,99 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package export converts Word documents to Markdown, HTML and plain text.
+//
+// Heading styles become # headings, numbered paragraphs list items, bold,
+// italic, strikethrough and monospace runs emphasis and code, hyperlinks
+// links and tables GFM tables. Footnotes are written as Markdown
+// footnotes.
+//
+// ToHTML writes a standalone HTML5 page with the paragraph styles as CSS
+// classes and direct formatting as inline styles.
+//
+//	doc, err := docx.OpenDocument("report.docx")
+//	if err != nil {
+//		return err
+//	}
+//	md, err := export.ToMarkdown(doc, export.Options{ImageDir: "report_files"})
+package export
+
+import (
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Options configures an export.
+type Options struct {
+	// HeadersFooters adds the text of headers before the body and of
+	// footers after it. A header or footer repeated by several sections is
+	// written once.
+	HeadersFooters bool
+
+	// ImageDir receives each image of the document as a file that
+	// ToMarkdown and ToHTML link to, and is created if needed. When it is
+	// empty, ToMarkdown leaves images out and ToHTML embeds them as data
+	// URIs. ToText always leaves images out.
+	ImageDir string
+
+	// ImageURL is the path prefix of image links; empty uses ImageDir.
+	ImageURL string
+}
+
+// ToMarkdown converts doc to GitHub Flavored Markdown.
+func ToMarkdown(doc domain.Document, opts Options) (string, error) {
+	return convert("export.ToMarkdown", doc, opts, false)
+}
+
+// ToText converts doc to plain text. Paragraphs are separated by blank
+// lines, list items keep their markers and table cells are separated by
+// tabs.
+func ToText(doc domain.Document, opts Options) (string, error) {
+	return convert("export.ToText", doc, opts, true)
+}
+
+// ToHTML converts doc to a standalone HTML5 page. Paragraph styles become
+// CSS classes, headings h1-h6, numbered paragraphs lists and footnotes
+// links to a list of notes at the end.
+func ToHTML(doc domain.Document, opts Options) (string, error) {
+	if doc == nil {
+		return "", errors.InvalidArgument("export.ToHTML", "doc", doc, "document cannot be nil")
+	}
+	w := newHTMLWriter(doc, opts)
+	if err := w.document(); err != nil {
+		return "", errors.Wrap(err, "export.ToHTML")
+	}
+	return w.page(), nil
+}
+
+func convert(op string, doc domain.Document, opts Options, plain bool) (string, error) {
+	if doc == nil {
+		return "", errors.InvalidArgument(op, "doc", doc, "document cannot be nil")
+	}
+	w := newWriter(doc, opts, plain)
+	if err := w.document(); err != nil {
+		return "", errors.Wrap(err, op)
+	}
+	return w.String(), nil
+}
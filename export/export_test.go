@@ -0,0 +1,180 @@
+package export_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/export"
+	"github.com/mmonterroca/docxgo/v2/markdown"
+)
+
+const notes = `# Release 2.4
+
+Upgrade with **care** and *patience*, see [the guide](https://example.com/guide).[^1]
+
+1. First
+2. Second
+   - Nested
+3. Third
+
+> Quoted
+
+` + "```" + `
+go get example.com/tool
+` + "```" + `
+
+| Feature | Status |
+|:--------|-------:|
+| Themes  | ` + "`done`" + ` |
+
+[^1]: Read it twice.
+`
+
+func TestToMarkdownRoundTrip(t *testing.T) {
+	doc, err := markdown.Convert([]byte(notes), markdown.Options{})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	reopened, err := docx.OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("OpenDocumentFromBytes() error = %v", err)
+	}
+
+	want := `# Release 2.4
+
+Upgrade with **care** and *patience*, see [the guide](https://example.com/guide).[^1]
+
+1. First
+2. Second
+   - Nested
+3. Third
+
+> Quoted
+
+` + "```" + `
+go get example.com/tool
+` + "```" + `
+
+| Feature | Status |
+| --- | ---: |
+| Themes | ` + "`done`" + ` |
+
+[^1]: Read it twice.
+`
+	for name, d := range map[string]domain.Document{"converted": doc, "reopened": reopened} {
+		got, err := export.ToMarkdown(d, export.Options{})
+		if err != nil {
+			t.Fatalf("ToMarkdown(%s) error = %v", name, err)
+		}
+		if got != want {
+			t.Errorf("ToMarkdown(%s) =\n%s\nwant\n%s", name, got, want)
+		}
+	}
+}
+
+func TestToText(t *testing.T) {
+	doc, err := markdown.Convert([]byte(notes), markdown.Options{})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	got, err := export.ToText(doc, export.Options{})
+	if err != nil {
+		t.Fatalf("ToText() error = %v", err)
+	}
+	want := "Release 2.4\n\nUpgrade with care and patience, see the guide.[1]\n\n" +
+		"1. First\n2. Second\n   - Nested\n3. Third\n\nQuoted\n\ngo get example.com/tool\n\n" +
+		"Feature\tStatus\nThemes\tdone\n\n[1] Read it twice.\n"
+	if got != want {
+		t.Errorf("ToText() =\n%q\nwant\n%q", got, want)
+	}
+}
+
+func TestToMarkdownDocument(t *testing.T) {
+	doc := docx.NewDocument()
+	section, _ := doc.DefaultSection()
+	header, _ := section.Header(domain.HeaderDefault)
+	addParagraph(t, header, "Acme | Confidential")
+	footer, _ := section.Footer(domain.FooterDefault)
+	addParagraph(t, footer, "Page footer")
+
+	addParagraph(t, doc, "Bold heading").SetBold(true)
+	doc.Paragraphs()[0].SetStyle(domain.StyleIDHeading2)
+	addParagraph(t, doc, "1. not a list with *stars* and snake_case")
+
+	addParagraph(t, doc, "Logo: ")
+	para := doc.Paragraphs()[2]
+	var img bytes.Buffer
+	png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+	logo, err := para.AddImageFromBytes(img.Bytes(), domain.ImageFormatPNG, domain.ImageSize{}, domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+	logo.SetDescription("Acme logo")
+
+	table, _ := doc.AddTable(2, 3)
+	for col, text := range []string{"Q1", "", "Q3"} {
+		cell, _ := table.Rows()[0].Cell(col)
+		if text != "" {
+			addParagraph(t, cell, text)
+		}
+	}
+	merged, _ := table.Rows()[1].Cell(0)
+	if err := merged.Merge(2, 1); err != nil {
+		t.Fatalf("Merge() error = %v", err)
+	}
+	addParagraph(t, merged, "Half year")
+
+	dir := t.TempDir()
+	got, err := export.ToMarkdown(doc, export.Options{
+		HeadersFooters: true,
+		ImageDir:       filepath.Join(dir, "media"),
+		ImageURL:       "media",
+	})
+	if err != nil {
+		t.Fatalf("ToMarkdown() error = %v", err)
+	}
+	want := "Acme | Confidential\n\n## Bold heading\n\n1\\. not a list with \\*stars\\* and snake_case\n\n" +
+		"Logo: ![Acme logo](media/image1.png)\n\n| Q1 |  | Q3 |\n| --- | --- | --- |\n| Half year |  |  |\n\nPage footer\n"
+	if got != want {
+		t.Errorf("ToMarkdown() =\n%q\nwant\n%q", got, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "media", "image1.png")); err != nil {
+		t.Errorf("image not extracted: %v", err)
+	}
+
+	if got, _ := export.ToMarkdown(doc, export.Options{}); strings.Contains(got, "Acme") || strings.Contains(got, "![") {
+		t.Errorf("ToMarkdown() without options kept headers or images:\n%s", got)
+	}
+	if _, err := export.ToMarkdown(nil, export.Options{}); err == nil {
+		t.Error("ToMarkdown(nil) should fail")
+	}
+}
+
+func addParagraph(t *testing.T, c interface {
+	AddParagraph() (domain.Paragraph, error)
+}, text string) domain.Run {
+	t.Helper()
+	para, err := c.AddParagraph()
+	if err != nil {
+		t.Fatalf("AddParagraph() error = %v", err)
+	}
+	run, err := para.AddRun()
+	if err != nil {
+		t.Fatalf("AddRun() error = %v", err)
+	}
+	if err := run.SetText(text); err != nil {
+		t.Fatalf("SetText() error = %v", err)
+	}
+	return run
+}
@@ -0,0 +1,564 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package export
+
+import (
+	"encoding/base64"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// openList is a ul or ol element whose last li is still open.
+type openList struct {
+	id, level int
+	tag       string
+}
+
+// htmlWriter writes a document as HTML in reading order.
+type htmlWriter struct {
+	doc     domain.Document
+	opts    Options
+	theme   domain.DocumentTheme
+	out     *strings.Builder
+	body    strings.Builder
+	lists   []openList
+	wrapper string // Open blockquote or pre element, if any
+	counter listCounter
+	classes map[string]string // CSS class -> paragraph style ID
+	notes   []domain.Footnote
+	images  *imageFiles
+}
+
+func newHTMLWriter(doc domain.Document, opts Options) *htmlWriter {
+	w := &htmlWriter{
+		doc:     doc,
+		opts:    opts,
+		theme:   doc.Theme(),
+		counter: listCounter{},
+		classes: map[string]string{},
+		images:  newImageFiles(opts),
+	}
+	w.out = &w.body
+	return w
+}
+
+// document writes the header, body, footnotes and footer.
+func (w *htmlWriter) document() error {
+	if w.opts.HeadersFooters {
+		if err := w.headersFooters(true); err != nil {
+			return err
+		}
+	}
+	for _, b := range w.doc.Blocks() {
+		var err error
+		switch {
+		case b.Paragraph != nil:
+			err = w.paragraph(b.Paragraph)
+		case b.Table != nil:
+			err = w.table(b.Table)
+		case b.AltChunk != nil && b.AltChunk.Format == domain.AltChunkText:
+			w.closeBlocks()
+			w.out.WriteString("<p>" + html.EscapeString(string(b.AltChunk.Data)) + "</p>\n")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	w.closeBlocks()
+	if err := w.footnotes(); err != nil {
+		return err
+	}
+	if w.opts.HeadersFooters {
+		return w.headersFooters(false)
+	}
+	return nil
+}
+
+// page wraps the body in an HTML5 document with the stylesheet.
+func (w *htmlWriter) page() string {
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	if meta := w.doc.Metadata(); meta != nil && meta.Title != "" {
+		b.WriteString("<title>" + html.EscapeString(meta.Title) + "</title>\n")
+	}
+	b.WriteString("<style>\n" + w.stylesheet() + "</style>\n</head>\n<body>\n")
+	b.WriteString(w.body.String())
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// headersFooters writes the headers in a header element or the footers in
+// a footer element, skipping repeated ones.
+func (w *htmlWriter) headersFooters(headers bool) error {
+	var parts []string
+	for _, paras := range sectionParts(w.doc, headers) {
+		var part strings.Builder
+		w.out = &part
+		for _, para := range paras {
+			if err := w.paragraph(para); err != nil {
+				w.out = &w.body
+				return err
+			}
+		}
+		w.closeBlocks()
+		w.out = &w.body
+		if text := part.String(); text != "" && !slices.Contains(parts, text) {
+			parts = append(parts, text)
+		}
+	}
+	if len(parts) == 0 {
+		return nil
+	}
+	tag := "footer"
+	if headers {
+		tag = "header"
+	}
+	w.out.WriteString("<" + tag + ">\n" + strings.Join(parts, "") + "</" + tag + ">\n")
+	return nil
+}
+
+// paragraph writes a paragraph as a heading, list item, quote, code or p
+// element, followed by the content of its text boxes.
+func (w *htmlWriter) paragraph(p domain.Paragraph) error {
+	content, err := w.runs(p)
+	if err != nil {
+		return err
+	}
+	class := w.class(p)
+
+	if ref, ok := p.Numbering(); ok {
+		w.listItem(ref, attributes(class, w.paragraphCSS(p, true)), content)
+	} else {
+		w.closeLists()
+		style := paragraphStyle(p)
+		switch level := headingLevel(style); {
+		case level > 0:
+			w.wrap("")
+			tag := "h" + strconv.Itoa(level)
+			w.out.WriteString("<" + tag + attributes(class, w.paragraphCSS(p, false)) + ">" + content + "</" + tag + ">\n")
+		case isCodeParagraph(p):
+			if w.wrapper == "pre" {
+				w.out.WriteString("\n")
+			}
+			w.wrap("pre")
+			w.out.WriteString(html.EscapeString(codeText(p)))
+		default:
+			if style == "quote" || style == "intensequote" {
+				w.wrap("blockquote")
+			} else {
+				w.wrap("")
+			}
+			if content == "" {
+				content = "<br>"
+			}
+			w.out.WriteString("<p" + attributes(class, w.paragraphCSS(p, false)) + ">" + content + "</p>\n")
+		}
+	}
+
+	for _, shape := range p.Shapes() {
+		for _, inner := range shape.Paragraphs() {
+			if err := w.paragraph(inner); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// class returns the CSS class of a paragraph's style, the default
+// paragraph style when it has none, and records it for the stylesheet.
+func (w *htmlWriter) class(p domain.Paragraph) string {
+	id := styleID(p)
+	if id == "" {
+		id = domain.StyleIDNormal
+		if style, err := w.doc.StyleManager().DefaultStyle(domain.StyleTypeParagraph); err == nil {
+			id = style.ID()
+		}
+	}
+	class := className(id)
+	if class != "" {
+		w.classes[class] = id
+	}
+	return class
+}
+
+// className keeps the letters, digits, hyphens and underscores of a style
+// ID.
+func className(id string) string {
+	class := strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || isWordRune(r) {
+			return r
+		}
+		return -1
+	}, id)
+	if class != "" && class[0] >= '0' && class[0] <= '9' {
+		class = "s" + class
+	}
+	return class
+}
+
+// attributes returns the class and style attributes, leaving out empty
+// ones.
+func attributes(class, style string) string {
+	var b strings.Builder
+	if class != "" {
+		b.WriteString(` class="` + class + `"`)
+	}
+	if style != "" {
+		b.WriteString(` style="` + html.EscapeString(style) + `"`)
+	}
+	return b.String()
+}
+
+// wrap closes the open blockquote or pre element unless it is name, and
+// opens name.
+func (w *htmlWriter) wrap(name string) {
+	if w.wrapper == name {
+		return
+	}
+	switch w.wrapper {
+	case "pre":
+		w.out.WriteString("</code></pre>\n")
+	case "blockquote":
+		w.out.WriteString("</blockquote>\n")
+	}
+	switch name {
+	case "pre":
+		w.out.WriteString("<pre><code>")
+	case "blockquote":
+		w.out.WriteString("<blockquote>\n")
+	}
+	w.wrapper = name
+}
+
+// listItem opens an li element, opening and closing ul and ol elements
+// as the list and level change. The li stays open for nested lists.
+func (w *htmlWriter) listItem(ref domain.NumberingReference, attrs, content string) {
+	w.wrap("")
+	def, n := w.counter.next(w.doc, ref)
+	tag := "ul"
+	if def.Kind == domain.ListDecimal {
+		tag = "ol"
+	}
+
+	for len(w.lists) > 0 && w.lists[len(w.lists)-1].level > ref.Level {
+		w.closeList()
+	}
+	if len(w.lists) > 0 {
+		if top := w.lists[len(w.lists)-1]; top.level == ref.Level && (top.id != ref.ID || top.tag != tag) {
+			w.closeList()
+		}
+	}
+
+	if len(w.lists) > 0 && w.lists[len(w.lists)-1].level == ref.Level {
+		w.out.WriteString("</li>\n")
+	} else {
+		open := "<" + tag
+		if tag == "ol" {
+			if n != 1 {
+				open += ` start="` + strconv.Itoa(n) + `"`
+			}
+			if kind := orderedListType(def.Format); kind != "" {
+				open += ` type="` + kind + `"`
+			}
+		}
+		w.out.WriteString(open + ">\n")
+		w.lists = append(w.lists, openList{id: ref.ID, level: ref.Level, tag: tag})
+	}
+	w.out.WriteString("<li" + attrs + ">" + content)
+}
+
+// orderedListType returns the ol type attribute of a Word number format.
+func orderedListType(format string) string {
+	switch format {
+	case "lowerLetter":
+		return "a"
+	case "upperLetter":
+		return "A"
+	case "lowerRoman":
+		return "i"
+	case "upperRoman":
+		return "I"
+	}
+	return ""
+}
+
+func (w *htmlWriter) closeList() {
+	top := w.lists[len(w.lists)-1]
+	w.lists = w.lists[:len(w.lists)-1]
+	w.out.WriteString("</li>\n</" + top.tag + ">\n")
+}
+
+func (w *htmlWriter) closeLists() {
+	for len(w.lists) > 0 {
+		w.closeList()
+	}
+}
+
+// closeBlocks closes open lists, quotes and code.
+func (w *htmlWriter) closeBlocks() {
+	w.closeLists()
+	w.wrap("")
+}
+
+// runs writes the runs of a paragraph with their formatting, links,
+// images and footnote references.
+func (w *htmlWriter) runs(p domain.Paragraph) (string, error) {
+	var b strings.Builder
+	for _, run := range p.Runs() {
+		if img := runImage(run); img != nil {
+			tag, err := w.image(img)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(tag)
+		}
+		if note := runFootnote(run); note != nil {
+			n := strconv.Itoa(w.noteNumber(note))
+			b.WriteString(`<sup class="footnote-ref"><a href="#fn` + n + `" id="fnref` + n + `">` + n + `</a></sup>`)
+		}
+
+		text, link := runText(run)
+		content := strings.ReplaceAll(html.EscapeString(text), "\n", "<br>")
+		for _, br := range runBreaks(run) {
+			if br == domain.BreakTypeLine {
+				content += "<br>"
+			}
+		}
+		if content == "" {
+			continue
+		}
+		content = w.formatRun(run, content)
+		if link != "" {
+			content = `<a href="` + html.EscapeString(link) + `">` + content + "</a>"
+		}
+		b.WriteString(content)
+	}
+	return b.String(), nil
+}
+
+// formatRun wraps run content in elements for its formatting and a span
+// for the formatting HTML has no element for.
+func (w *htmlWriter) formatRun(run domain.Run, content string) string {
+	code := monospace(run)
+	if code {
+		content = "<code>" + content + "</code>"
+	}
+	switch run.Script() {
+	case domain.ScriptSuperscript:
+		content = "<sup>" + content + "</sup>"
+	case domain.ScriptSubscript:
+		content = "<sub>" + content + "</sub>"
+	}
+	if run.Strike() {
+		content = "<s>" + content + "</s>"
+	}
+	switch run.Underline() {
+	case domain.UnderlineNone:
+	case domain.UnderlineDouble:
+		content = `<u style="text-decoration-style: double">` + content + "</u>"
+	case domain.UnderlineDotted:
+		content = `<u style="text-decoration-style: dotted">` + content + "</u>"
+	case domain.UnderlineDashed:
+		content = `<u style="text-decoration-style: dashed">` + content + "</u>"
+	case domain.UnderlineWave:
+		content = `<u style="text-decoration-style: wavy">` + content + "</u>"
+	default:
+		content = "<u>" + content + "</u>"
+	}
+	if run.Italic() {
+		content = "<em>" + content + "</em>"
+	}
+	if run.Bold() {
+		content = "<strong>" + content + "</strong>"
+	}
+	if style := w.runCSS(run, code); style != "" {
+		content = `<span style="` + html.EscapeString(style) + `">` + content + "</span>"
+	}
+	return content
+}
+
+// image returns an img element linking to the extracted file, or holding
+// the image as a data URI.
+func (w *htmlWriter) image(img domain.Image) (string, error) {
+	src, err := w.images.link("export.ToHTML", img)
+	if err != nil {
+		return "", err
+	}
+	if src == "" {
+		data := img.Data()
+		if len(data) == 0 {
+			return "", nil
+		}
+		src = "data:" + imageMIMEType(img.Format()) + ";base64," + base64.StdEncoding.EncodeToString(data)
+	}
+	tag := `<img src="` + html.EscapeString(src) + `" alt="` + html.EscapeString(img.Description()) + `"`
+	if size := img.Size(); size.WidthPx > 0 && size.HeightPx > 0 {
+		tag += ` width="` + strconv.Itoa(size.WidthPx) + `" height="` + strconv.Itoa(size.HeightPx) + `"`
+	}
+	return tag + ">", nil
+}
+
+// imageMIMEType returns the media type of an image format.
+func imageMIMEType(format domain.ImageFormat) string {
+	switch format {
+	case domain.ImageFormatPNG:
+		return constants.ContentTypePNG
+	case domain.ImageFormatJPEG, domain.ImageFormatJPG:
+		return constants.ContentTypeJPEG
+	case domain.ImageFormatGIF:
+		return constants.ContentTypeGIF
+	case domain.ImageFormatBMP:
+		return constants.ContentTypeBMP
+	case domain.ImageFormatTIFF, domain.ImageFormatTIF:
+		return constants.ContentTypeTIFF
+	case domain.ImageFormatSVG:
+		return constants.ContentTypeSVG
+	case domain.ImageFormatEMF:
+		return constants.ContentTypeEMF
+	case domain.ImageFormatWMF:
+		return constants.ContentTypeWMF
+	}
+	return "image/" + string(format)
+}
+
+// noteNumber returns the number of a footnote, numbering it on first use.
+func (w *htmlWriter) noteNumber(note domain.Footnote) int {
+	if i := slices.Index(w.notes, note); i >= 0 {
+		return i + 1
+	}
+	w.notes = append(w.notes, note)
+	return len(w.notes)
+}
+
+// table writes a table, turning gridSpan and vMerge into colspan and
+// rowspan.
+func (w *htmlWriter) table(t domain.Table) error {
+	w.closeBlocks()
+	open := "<table"
+	if class := className(t.Style().Name); class != "" {
+		open += ` class="` + class + `"`
+	}
+	w.out.WriteString(open + ">\n")
+
+	grid := t.Grid()
+	for r, row := range t.Rows() {
+		w.out.WriteString("<tr>\n")
+		for c, cell := range row.Cells() {
+			var attrs string
+			if r < len(grid) && c < len(grid[r]) {
+				info := grid[r][c]
+				if !info.IsOrigin(r, c) {
+					continue
+				}
+				if info.ColSpan > 1 {
+					attrs += ` colspan="` + strconv.Itoa(info.ColSpan) + `"`
+				}
+				if info.RowSpan > 1 {
+					attrs += ` rowspan="` + strconv.Itoa(info.RowSpan) + `"`
+				}
+			}
+			if style := cellCSS(cell); style != "" {
+				attrs += ` style="` + html.EscapeString(style) + `"`
+			}
+			w.out.WriteString("<td" + attrs + ">\n")
+			for _, para := range cell.Paragraphs() {
+				if err := w.paragraph(para); err != nil {
+					return err
+				}
+			}
+			for _, nested := range cell.Tables() {
+				if err := w.table(nested); err != nil {
+					return err
+				}
+			}
+			w.closeBlocks()
+			w.out.WriteString("</td>\n")
+		}
+		w.out.WriteString("</tr>\n")
+	}
+	w.out.WriteString("</table>\n")
+	return nil
+}
+
+// footnotes writes the referenced notes as an ordered list, each linking
+// back to its reference.
+func (w *htmlWriter) footnotes() error {
+	if len(w.notes) == 0 {
+		return nil
+	}
+	w.out.WriteString("<section class=\"footnotes\">\n<ol>\n")
+	for i := 0; i < len(w.notes); i++ {
+		n := strconv.Itoa(i + 1)
+		w.out.WriteString(`<li id="fn` + n + `">` + "\n")
+		for _, para := range w.notes[i].Paragraphs() {
+			if err := w.paragraph(para); err != nil {
+				return err
+			}
+		}
+		w.closeBlocks()
+		w.out.WriteString(`<a href="#fnref` + n + `" class="footnote-back">↩</a>` + "\n</li>\n")
+	}
+	w.out.WriteString("</ol>\n</section>\n")
+	return nil
+}
+
+// isCodeParagraph reports whether a paragraph holds only monospace text.
+func isCodeParagraph(p domain.Paragraph) bool {
+	code := false
+	for _, run := range p.Runs() {
+		text, link := runText(run)
+		switch {
+		case link != "" || runImage(run) != nil || runFootnote(run) != nil:
+			return false
+		case strings.TrimSpace(text) == "":
+		case !monospace(run):
+			return false
+		default:
+			code = true
+		}
+	}
+	return code
+}
+
+// codeText returns the text of a code paragraph with breaks as newlines.
+func codeText(p domain.Paragraph) string {
+	var b strings.Builder
+	for _, run := range p.Runs() {
+		text, _ := runText(run)
+		b.WriteString(text)
+		for _, br := range runBreaks(run) {
+			if br == domain.BreakTypeLine {
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,112 @@
+package export_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/export"
+	"github.com/mmonterroca/docxgo/v2/markdown"
+)
+
+func TestToHTMLStructure(t *testing.T) {
+	doc, err := markdown.Convert([]byte(notes), markdown.Options{})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	doc.SetMetadata(&domain.Metadata{Title: "Release <2.4>"})
+
+	got, err := export.ToHTML(doc, export.Options{})
+	if err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	for _, want := range []string{
+		"<!DOCTYPE html>",
+		"<title>Release &lt;2.4&gt;</title>",
+		`.Heading1 { font-family: "Calibri Light"; font-size: 16pt; font-weight: bold; margin-top: 12pt; margin-bottom: 6pt; }`,
+		`<h1 class="Heading1">Release 2.4</h1>`,
+		`with <strong>care</strong> and <em>patience</em>, see <a href="https://example.com/guide">`,
+		`<sup class="footnote-ref"><a href="#fn1" id="fnref1">1</a></sup>`,
+		"<ol>\n<li class=\"ListParagraph\">First</li>\n<li class=\"ListParagraph\">Second<ul>\n<li class=\"ListParagraph\">Nested</li>\n</ul>\n</li>\n<li class=\"ListParagraph\">Third</li>\n</ol>",
+		"<blockquote>\n<p class=\"Quote\">Quoted</p>\n</blockquote>",
+		"<pre><code>go get example.com/tool</code></pre>",
+		`<table class="TableGrid">`,
+		`<li id="fn1">` + "\n" + `<p class="FootnoteText">Read it twice.</p>` + "\n" + `<a href="#fnref1" class="footnote-back">`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ToHTML() missing %s\n%s", want, got)
+		}
+	}
+}
+
+func TestToHTMLFormattingAndTables(t *testing.T) {
+	doc := docx.NewDocument()
+	section, _ := doc.DefaultSection()
+	footer, _ := section.Footer(domain.FooterDefault)
+	addParagraph(t, footer, "Page footer")
+
+	run := addParagraph(t, doc, "Warning & notice")
+	run.SetColor(domain.Color{R: 0xC0})
+	run.SetSize(28)
+	run.SetHighlight(domain.HighlightYellow)
+	run.SetUnderline(domain.UnderlineDouble)
+	doc.Paragraphs()[0].SetAlignment(domain.AlignmentCenter)
+
+	para := doc.Paragraphs()[0]
+	var img bytes.Buffer
+	png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 4, 2)))
+	logo, err := para.AddImageFromBytes(img.Bytes(), domain.ImageFormatPNG, domain.ImageSize{}, domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+	logo.SetDescription("Logo")
+
+	table, _ := doc.AddTable(2, 3)
+	wide, _ := table.Rows()[0].Cell(0)
+	wide.Merge(2, 1)
+	addParagraph(t, wide, "Wide")
+	tall, _ := table.Rows()[0].Cell(2)
+	tall.Merge(1, 2)
+	tall.SetShading(domain.Color{R: 0xDD, G: 0xEE, B: 0xFF})
+
+	got, err := export.ToHTML(doc, export.Options{HeadersFooters: true})
+	if err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	for _, want := range []string{
+		`<p class="Normal" style="text-align: center"><span style="font-size: 14pt; color: #C00000; background-color: #FFFF00"><u style="text-decoration-style: double">Warning &amp; notice</u></span>`,
+		`<img src="data:image/png;base64,`,
+		`alt="Logo" width="4" height="2">`,
+		`<td colspan="2">` + "\n" + `<p class="Normal">Wide</p>`,
+		`<td rowspan="2" style="background-color: #DDEEFF">`,
+		"<footer>\n<p class=\"Normal\">Page footer</p>\n</footer>\n</body>",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ToHTML() missing %s\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "<td") != 4 {
+		t.Errorf("ToHTML() should write 4 cells for the merged table:\n%s", got)
+	}
+
+	dir := filepath.Join(t.TempDir(), "files")
+	got, err = export.ToHTML(doc, export.Options{ImageDir: dir})
+	if err != nil {
+		t.Fatalf("ToHTML() error = %v", err)
+	}
+	if !strings.Contains(got, `<img src="`+filepath.ToSlash(dir)+`/image1.png"`) {
+		t.Errorf("ToHTML() should link the extracted image:\n%s", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "image1.png")); err != nil {
+		t.Errorf("image not extracted: %v", err)
+	}
+	if strings.Contains(got, "<footer>") {
+		t.Error("ToHTML() wrote footers without HeadersFooters")
+	}
+}
@@ -0,0 +1,82 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package export
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// imageFiles writes the images of a document to Options.ImageDir, each
+// once.
+type imageFiles struct {
+	opts  Options
+	links map[string]string // media target -> link
+}
+
+func newImageFiles(opts Options) *imageFiles {
+	return &imageFiles{opts: opts, links: map[string]string{}}
+}
+
+// link writes img to the image directory the first time it is seen and
+// returns its link, or "" when Options.ImageDir is empty.
+func (f *imageFiles) link(op string, img domain.Image) (string, error) {
+	data := img.Data()
+	if f.opts.ImageDir == "" || len(data) == 0 {
+		return "", nil
+	}
+	key := img.Target()
+	if key == "" {
+		key = img.ID()
+	}
+	if link, ok := f.links[key]; ok {
+		return link, nil
+	}
+
+	name := path.Base(img.Target())
+	if img.Target() == "" {
+		name = "image" + strconv.Itoa(len(f.links)+1) + "." + string(img.Format())
+	}
+	if err := os.MkdirAll(f.opts.ImageDir, 0o755); err != nil {
+		return "", errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	if err := os.WriteFile(filepath.Join(f.opts.ImageDir, name), data, 0o644); err != nil {
+		return "", errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+
+	base := f.opts.ImageURL
+	if base == "" {
+		base = filepath.ToSlash(f.opts.ImageDir)
+	}
+	link := strings.TrimSuffix(base, "/") + "/" + name
+	f.links[key] = link
+	return link, nil
+}
@@ -0,0 +1,466 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package export
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+// format is the character formatting Markdown can express.
+type format struct {
+	bold, italic, strike, code bool
+}
+
+type segmentKind int
+
+const (
+	segmentText segmentKind = iota
+	segmentBreak
+	segmentImage
+	segmentNote
+)
+
+// segment is a piece of paragraph content with uniform formatting.
+type segment struct {
+	kind   segmentKind
+	text   string // Text, or the image description
+	format format
+	link   string // Hyperlink or image URL
+	note   int    // Footnote number
+}
+
+// inlineContext selects how line breaks are written.
+type inlineContext int
+
+const (
+	inlineBlock   inlineContext = iota // Paragraphs and list items
+	inlineHeading                      // Headings, on one line without bold
+	inlineCell                         // Table cells, on one line
+)
+
+// monospaceFonts are the fonts whose runs are written as code.
+var monospaceFonts = []string{
+	"courier", "courier new", "consolas", "menlo", "monaco", "lucida console",
+	"fira code", "source code pro", "cascadia code", "cascadia mono",
+	"jetbrains mono", "dejavu sans mono", "liberation mono", "roboto mono", "sf mono",
+}
+
+// segments splits the runs of a paragraph into segments in order.
+func (w *writer) segments(p domain.Paragraph) ([]segment, error) {
+	var segs []segment
+	for _, run := range p.Runs() {
+		f := format{
+			bold:   run.Bold(),
+			italic: run.Italic(),
+			strike: run.Strike(),
+			code:   monospace(run),
+		}
+
+		if img := runImage(run); img != nil {
+			var link string
+			if !w.plain {
+				var err error
+				if link, err = w.images.link("export.ToMarkdown", img); err != nil {
+					return nil, err
+				}
+			}
+			if link != "" {
+				segs = append(segs, segment{kind: segmentImage, text: img.Description(), link: link})
+			}
+		}
+		if note := runFootnote(run); note != nil {
+			segs = append(segs, segment{kind: segmentNote, note: w.noteNumber(note)})
+		}
+
+		text, link := runText(run)
+		segs = appendText(segs, text, f, link)
+
+		for _, br := range runBreaks(run) {
+			if br == domain.BreakTypeLine {
+				segs = append(segs, segment{kind: segmentBreak})
+			}
+		}
+	}
+	return segs, nil
+}
+
+// runText returns the text a run shows, field results included, and the
+// target of its hyperlink field, if any. A hyperlink shows its result in
+// place of the run text.
+func runText(run domain.Run) (text, link string) {
+	var fields []domain.Field
+	if withFields, ok := run.(interface{ Fields() []domain.Field }); ok {
+		fields = withFields.Fields()
+	}
+	var b strings.Builder
+	for _, field := range fields {
+		if url := fieldURL(field); url != "" && link == "" {
+			link = url
+			if field.Result() == "" {
+				b.WriteString(run.Text())
+			}
+		}
+		b.WriteString(field.Result())
+	}
+	if link == "" {
+		b.WriteString(run.Text())
+	}
+	return b.String(), link
+}
+
+// fieldURL returns the target of a hyperlink field, or "".
+func fieldURL(field domain.Field) string {
+	if field.Type() != domain.FieldTypeHyperlink {
+		return ""
+	}
+	accessor, ok := field.(interface {
+		GetProperty(string) (string, bool)
+	})
+	if !ok {
+		return ""
+	}
+	url, _ := accessor.GetProperty("url")
+	return url
+}
+
+// runImage returns the image a run holds, or nil.
+func runImage(run domain.Run) domain.Image {
+	if withImage, ok := run.(interface{ Image() domain.Image }); ok {
+		return withImage.Image()
+	}
+	return nil
+}
+
+// runFootnote returns the footnote a run references, or nil.
+func runFootnote(run domain.Run) domain.Footnote {
+	if withNote, ok := run.(interface{ Footnote() domain.Footnote }); ok {
+		return withNote.Footnote()
+	}
+	return nil
+}
+
+// runBreaks returns the breaks after the text of a run.
+func runBreaks(run domain.Run) []domain.BreakType {
+	if withBreaks, ok := run.(interface{ Breaks() []domain.BreakType }); ok {
+		return withBreaks.Breaks()
+	}
+	return nil
+}
+
+// monospace reports whether a run uses a monospace font.
+func monospace(run domain.Run) bool {
+	return slices.Contains(monospaceFonts, strings.ToLower(run.Font().Name))
+}
+
+// appendText adds text as segments, with a break for each newline.
+// Whitespace is not formatted unless it is code.
+func appendText(segs []segment, text string, f format, link string) []segment {
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			segs = append(segs, segment{kind: segmentBreak})
+		}
+		if line == "" {
+			continue
+		}
+		lineFormat := f
+		if strings.TrimSpace(line) == "" && !f.code {
+			lineFormat = format{}
+		}
+		segs = append(segs, segment{kind: segmentText, text: line, format: lineFormat, link: link})
+	}
+	return segs
+}
+
+// noteNumber returns the number of a footnote, numbering it on first use.
+func (w *writer) noteNumber(note domain.Footnote) int {
+	if i := slices.Index(w.notes, note); i >= 0 {
+		return i + 1
+	}
+	w.notes = append(w.notes, note)
+	return len(w.notes)
+}
+
+// isCode reports whether a paragraph holds only code, making it a code
+// block.
+func isCode(segs []segment) bool {
+	code := false
+	for _, seg := range segs {
+		switch {
+		case seg.kind == segmentImage || seg.kind == segmentNote:
+			return false
+		case seg.kind != segmentText || strings.TrimSpace(seg.text) == "":
+		case !seg.format.code || seg.link != "":
+			return false
+		default:
+			code = true
+		}
+	}
+	return code
+}
+
+// plainText returns the text of the segments with breaks as newlines.
+func plainText(segs []segment) string {
+	var b strings.Builder
+	for _, seg := range segs {
+		switch seg.kind {
+		case segmentText:
+			b.WriteString(seg.text)
+		case segmentBreak:
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// inline writes the segments of a paragraph, as Markdown unless the
+// writer is plain.
+func (w *writer) inline(segs []segment, ctx inlineContext) string {
+	for len(segs) > 0 && segs[0].kind == segmentBreak {
+		segs = segs[1:]
+	}
+	for len(segs) > 0 && segs[len(segs)-1].kind == segmentBreak {
+		segs = segs[:len(segs)-1]
+	}
+
+	if w.plain {
+		var b strings.Builder
+		for _, seg := range segs {
+			switch seg.kind {
+			case segmentText:
+				b.WriteString(seg.text)
+			case segmentBreak:
+				if ctx == inlineBlock {
+					b.WriteString("\n")
+				} else {
+					b.WriteString(" ")
+				}
+			case segmentNote:
+				b.WriteString("[" + strconv.Itoa(seg.note) + "]")
+			}
+		}
+		return strings.TrimSpace(b.String())
+	}
+
+	if ctx == inlineHeading {
+		segs = slices.Clone(segs)
+		for i := range segs {
+			segs[i].format.bold = false
+		}
+	}
+	var b strings.Builder
+	for i := 0; i < len(segs); {
+		j := i + 1
+		link := ""
+		if segs[i].kind == segmentText {
+			link = segs[i].link
+		}
+		for j < len(segs) && (segs[j].kind == segmentText && segs[j].link == link || segs[j].kind != segmentText && link == "") {
+			j++
+		}
+		if link == "" {
+			b.WriteString(w.formatted(segs[i:j], ctx))
+		} else {
+			b.WriteString("[" + w.formatted(segs[i:j], ctx) + "](" + linkDestination(link) + ")")
+		}
+		i = j
+	}
+
+	text := strings.Trim(b.String(), " \t")
+	if ctx == inlineBlock {
+		text = escapeBlockStart(text)
+	}
+	return text
+}
+
+// formatted writes segments with emphasis markers, opening and closing
+// them as the formatting changes. Whitespace stays outside the markers.
+func (w *writer) formatted(segs []segment, ctx inlineContext) string {
+	var b []byte
+	var open []string
+	closeTo := func(n int) {
+		if len(open) <= n {
+			return
+		}
+		end := len(strings.TrimRight(string(b), " \t"))
+		trail := string(b[end:])
+		b = b[:end]
+		for len(open) > n {
+			b = append(b, open[len(open)-1]...)
+			open = open[:len(open)-1]
+		}
+		b = append(b, trail...)
+	}
+
+	for _, seg := range segs {
+		switch seg.kind {
+		case segmentBreak:
+			switch ctx {
+			case inlineBlock:
+				b = append(b, "\\\n"...)
+			case inlineCell:
+				b = append(b, "<br>"...)
+			default:
+				b = append(b, ' ')
+			}
+		case segmentImage:
+			b = append(b, "!["+w.escapeText(seg.text)+"]("+linkDestination(seg.link)+")"...)
+		case segmentNote:
+			b = append(b, "[^"+strconv.Itoa(seg.note)+"]"...)
+		case segmentText:
+			want := markers(seg.format)
+			keep := 0
+			for keep < len(open) && slices.Contains(want, open[keep]) {
+				keep++
+			}
+			closeTo(keep)
+
+			text := seg.text
+			if len(open) < len(want) {
+				trimmed := strings.TrimLeft(text, " \t")
+				b = append(b, text[:len(text)-len(trimmed)]...)
+				text = trimmed
+			}
+			for _, m := range want {
+				if !slices.Contains(open, m) {
+					b = append(b, m...)
+					open = append(open, m)
+				}
+			}
+			if seg.format.code {
+				b = append(b, codeSpan(text)...)
+			} else {
+				b = append(b, w.escapeText(text)...)
+			}
+		}
+	}
+	closeTo(0)
+	return string(b)
+}
+
+// markers returns the emphasis markers of a format, outermost first.
+func markers(f format) []string {
+	var m []string
+	if f.strike {
+		m = append(m, "~~")
+	}
+	if f.bold {
+		m = append(m, "**")
+	}
+	if f.italic {
+		m = append(m, "*")
+	}
+	return m
+}
+
+// codeSpan wraps text in enough backticks to hold the backticks inside it.
+func codeSpan(text string) string {
+	longest, current := 0, 0
+	for _, r := range text {
+		if r == '`' {
+			current++
+			longest = max(longest, current)
+		} else {
+			current = 0
+		}
+	}
+	fence := strings.Repeat("`", longest+1)
+	if strings.HasPrefix(text, "`") || strings.HasSuffix(text, "`") ||
+		(strings.HasPrefix(text, " ") && strings.HasSuffix(text, " ") && strings.TrimSpace(text) != "") {
+		text = " " + text + " "
+	}
+	return fence + text + fence
+}
+
+// linkDestination writes a URL, in angle brackets when it has spaces or
+// parentheses.
+func linkDestination(url string) string {
+	if !strings.ContainsAny(url, " ()<>") {
+		return url
+	}
+	return "<" + strings.NewReplacer("<", "%3C", ">", "%3E").Replace(url) + ">"
+}
+
+// escapeText escapes the characters Markdown would read as markup. An
+// underscore inside a word is left alone, as GFM does not emphasize it.
+func (w *writer) escapeText(text string) string {
+	if w.plain {
+		return text
+	}
+	runes := []rune(text)
+	var b strings.Builder
+	for i, r := range runes {
+		switch r {
+		case '\\', '`', '*', '[', ']', '<', '~':
+			b.WriteByte('\\')
+		case '_':
+			if i == 0 || i == len(runes)-1 || !isWordRune(runes[i-1]) || !isWordRune(runes[i+1]) {
+				b.WriteByte('\\')
+			}
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// escapeBlockStart escapes a paragraph start that Markdown would read as
+// a heading, quote, list item or rule.
+func escapeBlockStart(text string) string {
+	if text == "" {
+		return text
+	}
+	follows := func(i int) bool {
+		return i >= len(text) || text[i] == ' ' || text[i] == '\t'
+	}
+	switch text[0] {
+	case '>':
+		return `\` + text
+	case '#':
+		if follows(len(text) - len(strings.TrimLeft(text, "#"))) {
+			return `\` + text
+		}
+	case '-', '+':
+		if follows(1) || strings.Trim(text, "- ") == "" {
+			return `\` + text
+		}
+	case '=':
+		if strings.Trim(text, "= ") == "" {
+			return `\` + text
+		}
+	}
+	digits := len(text) - len(strings.TrimLeft(text, "0123456789"))
+	if digits > 0 && digits <= 9 && digits < len(text) && (text[digits] == '.' || text[digits] == ')') && follows(digits+1) {
+		return text[:digits] + `\` + text[digits:]
+	}
+	return text
+}
@@ -0,0 +1,512 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package export
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+// blockKind decides how a block is separated from its neighbours.
+type blockKind int
+
+const (
+	blockText blockKind = iota
+	blockList
+	blockQuote
+	blockCode
+)
+
+type block struct {
+	kind blockKind
+	text string
+}
+
+// writer collects the blocks of a document in reading order.
+type writer struct {
+	doc    domain.Document
+	opts   Options
+	plain  bool
+	blocks []block
+
+	listOffsets []int // content column of each open list level
+	lists       listCounter
+	notes       []domain.Footnote
+	images      *imageFiles
+}
+
+func newWriter(doc domain.Document, opts Options, plain bool) *writer {
+	return &writer{
+		doc:    doc,
+		opts:   opts,
+		plain:  plain,
+		lists:  listCounter{},
+		images: newImageFiles(opts),
+	}
+}
+
+// document writes the headers, body, footnotes and footers.
+func (w *writer) document() error {
+	if w.opts.HeadersFooters {
+		if err := w.headersFooters(true); err != nil {
+			return err
+		}
+	}
+	for _, b := range w.doc.Blocks() {
+		var err error
+		switch {
+		case b.Paragraph != nil:
+			err = w.paragraph(b.Paragraph)
+		case b.Table != nil:
+			err = w.table(b.Table)
+		case b.AltChunk != nil && b.AltChunk.Format == domain.AltChunkText:
+			w.add(blockText, w.escapeText(string(b.AltChunk.Data)))
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := w.footnotes(); err != nil {
+		return err
+	}
+	if w.opts.HeadersFooters {
+		return w.headersFooters(false)
+	}
+	return nil
+}
+
+// headersFooters writes the headers or footers of every section, skipping
+// ones whose text was already written.
+func (w *writer) headersFooters(headers bool) error {
+	seen := map[string]bool{}
+	for _, paras := range sectionParts(w.doc, headers) {
+		mark := len(w.blocks)
+		for _, para := range paras {
+			if err := w.paragraph(para); err != nil {
+				return err
+			}
+		}
+		var key strings.Builder
+		for _, b := range w.blocks[mark:] {
+			key.WriteString(b.text + "\n")
+		}
+		if seen[key.String()] {
+			w.blocks = w.blocks[:mark]
+		}
+		seen[key.String()] = true
+	}
+	return nil
+}
+
+// sectionParts returns the paragraphs of each header, or each footer, of
+// every section in order.
+func sectionParts(doc domain.Document, headers bool) [][]domain.Paragraph {
+	var parts [][]domain.Paragraph
+	for _, section := range doc.Sections() {
+		if headers {
+			if sec, ok := section.(interface {
+				HeadersAll() map[domain.HeaderType]domain.Header
+			}); ok {
+				all := sec.HeadersAll()
+				for _, kind := range slices.Sorted(maps.Keys(all)) {
+					parts = append(parts, all[kind].Paragraphs())
+				}
+			}
+		} else if sec, ok := section.(interface {
+			FootersAll() map[domain.FooterType]domain.Footer
+		}); ok {
+			all := sec.FootersAll()
+			for _, kind := range slices.Sorted(maps.Keys(all)) {
+				parts = append(parts, all[kind].Paragraphs())
+			}
+		}
+	}
+	return parts
+}
+
+// add appends a block. Consecutive code paragraphs form one code block.
+func (w *writer) add(kind blockKind, text string) {
+	if kind != blockList {
+		w.listOffsets = nil
+	}
+	if kind == blockCode && len(w.blocks) > 0 && w.blocks[len(w.blocks)-1].kind == blockCode {
+		w.blocks[len(w.blocks)-1].text += "\n" + text
+		return
+	}
+	if kind == blockQuote && !w.plain {
+		text = prefixLines(text, "> ", 0)
+	}
+	w.blocks = append(w.blocks, block{kind: kind, text: text})
+}
+
+// String joins the blocks. List items are kept together and consecutive
+// quoted paragraphs stay in one quote.
+func (w *writer) String() string {
+	var b strings.Builder
+	for i, blk := range w.blocks {
+		if i > 0 {
+			prev := w.blocks[i-1].kind
+			switch {
+			case prev == blockList && blk.kind == blockList:
+				b.WriteString("\n")
+			case prev == blockQuote && blk.kind == blockQuote && !w.plain:
+				b.WriteString("\n>\n")
+			default:
+				b.WriteString("\n\n")
+			}
+		}
+		if blk.kind == blockCode && !w.plain {
+			fence := "```"
+			for strings.Contains(blk.text, fence) {
+				fence += "`"
+			}
+			b.WriteString(fence + "\n" + blk.text + "\n" + fence)
+			continue
+		}
+		b.WriteString(blk.text)
+	}
+	if b.Len() > 0 {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// paragraph writes a paragraph as a heading, list item, quote, code block
+// or plain paragraph, followed by the content of its text boxes.
+func (w *writer) paragraph(p domain.Paragraph) error {
+	segs, err := w.segments(p)
+	if err != nil {
+		return err
+	}
+
+	style := paragraphStyle(p)
+	if ref, ok := p.Numbering(); ok {
+		w.listItem(ref, w.inline(segs, inlineBlock))
+	} else if level := headingLevel(style); level > 0 {
+		if text := w.inline(segs, inlineHeading); text != "" {
+			if !w.plain {
+				text = strings.Repeat("#", level) + " " + text
+			}
+			w.add(blockText, text)
+		}
+	} else if isCode(segs) {
+		w.add(blockCode, plainText(segs))
+	} else if text := w.inline(segs, inlineBlock); text != "" {
+		if style == "quote" || style == "intensequote" {
+			w.add(blockQuote, text)
+		} else {
+			w.add(blockText, text)
+		}
+	}
+
+	for _, shape := range p.Shapes() {
+		for _, inner := range shape.Paragraphs() {
+			if err := w.paragraph(inner); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// styleID returns the style a paragraph was given, or "".
+func styleID(p domain.Paragraph) string {
+	if named, ok := p.(interface{ StyleName() string }); ok {
+		return named.StyleName()
+	}
+	return ""
+}
+
+// paragraphStyle returns the paragraph style lower-cased without spaces,
+// so that style IDs and names compare alike.
+func paragraphStyle(p domain.Paragraph) string {
+	return strings.ToLower(strings.ReplaceAll(styleID(p), " ", ""))
+}
+
+// headingLevel returns the Markdown heading level of a style, or 0. Title
+// is a level 1 heading and levels past 6 stay at 6.
+func headingLevel(style string) int {
+	if style == "title" {
+		return 1
+	}
+	if level, err := strconv.Atoi(strings.TrimPrefix(style, "heading")); err == nil && strings.HasPrefix(style, "heading") && level > 0 {
+		return min(level, 6)
+	}
+	return 0
+}
+
+// listItem writes a list item. Items are numbered per list and level, and
+// a level deeper than the open levels is written one level down.
+func (w *writer) listItem(ref domain.NumberingReference, text string) {
+	def, n := w.lists.next(w.doc, ref)
+	marker := "-"
+	if def.Kind == domain.ListDecimal {
+		if w.plain {
+			marker = formatNumber(n, def.Format) + "."
+		} else {
+			marker = strconv.Itoa(n) + "."
+		}
+	}
+
+	level := min(max(ref.Level, domain.NumberingLevelMin), len(w.listOffsets))
+	indent := 0
+	if level > 0 {
+		indent = w.listOffsets[level-1]
+	}
+	content := indent + len(marker) + 1
+	w.add(blockList, strings.Repeat(" ", indent)+marker+" "+prefixLines(text, strings.Repeat(" ", content), 1))
+	w.listOffsets = append(w.listOffsets[:level], content)
+}
+
+// listCounter numbers list items per list and level. Like Word, a list
+// keeps counting across other paragraphs, and deeper levels restart after
+// each item.
+type listCounter map[int][]int
+
+// next counts an item and returns its list level and number. Lists the
+// document does not define are bullets.
+func (c listCounter) next(doc domain.Document, ref domain.NumberingReference) (domain.ListLevel, int) {
+	def, ok := doc.ListLevel(ref)
+	if !ok {
+		def = domain.ListLevel{Kind: domain.ListBullet, Format: "bullet"}
+	}
+	counts := c[ref.ID]
+	if counts == nil {
+		counts = make([]int, domain.NumberingLevelMax+1)
+		c[ref.ID] = counts
+	}
+	level := min(max(ref.Level, domain.NumberingLevelMin), domain.NumberingLevelMax)
+	counts[level]++
+	clear(counts[level+1:])
+	return def, def.Start + counts[level] - 1
+}
+
+// formatNumber writes a list number in a Word number format.
+func formatNumber(n int, format string) string {
+	switch format {
+	case "lowerLetter", "upperLetter":
+		var letters string
+		for n > 0 {
+			n--
+			letters = string(rune('a'+n%26)) + letters
+			n /= 26
+		}
+		if format == "upperLetter" {
+			return strings.ToUpper(letters)
+		}
+		return letters
+	case "lowerRoman", "upperRoman":
+		roman := romanNumeral(n)
+		if format == "lowerRoman" {
+			return strings.ToLower(roman)
+		}
+		return roman
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+func romanNumeral(n int) string {
+	if n <= 0 {
+		return strconv.Itoa(n)
+	}
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+	var b strings.Builder
+	for i, v := range values {
+		for n >= v {
+			b.WriteString(symbols[i])
+			n -= v
+		}
+	}
+	return b.String()
+}
+
+// table writes a GFM table whose first row is the header, or tab
+// separated rows as plain text. Merged cells keep their content in the
+// first cell and leave the others empty.
+func (w *writer) table(t domain.Table) error {
+	var rows [][]string
+	var aligns []domain.Alignment
+	columns := 0
+	for r, row := range t.Rows() {
+		var cells []string
+		rowCells := row.Cells()
+		for c, cell := range rowCells {
+			if cell.IsHorizontallyMergedContinuation() || cell.VMerge() == domain.VMergeContinue {
+				cells = append(cells, "")
+				continue
+			}
+			text, err := w.cell(cell, r == 0)
+			if err != nil {
+				return err
+			}
+			cells = append(cells, text)
+			if r == 0 {
+				align := domain.AlignmentLeft
+				if paras := cell.Paragraphs(); len(paras) > 0 {
+					align = paras[0].Alignment()
+				}
+				aligns = append(aligns, align)
+			}
+			if span := cell.GridSpan(); span > 1 && (c+1 >= len(rowCells) || !rowCells[c+1].IsHorizontallyMergedContinuation()) {
+				for range span - 1 {
+					cells = append(cells, "")
+					if r == 0 {
+						aligns = append(aligns, lastAlign(aligns))
+					}
+				}
+			}
+		}
+		columns = max(columns, len(cells))
+		rows = append(rows, cells)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0, len(rows)+1)
+	for r, cells := range rows {
+		for len(cells) < columns {
+			cells = append(cells, "")
+		}
+		if w.plain {
+			lines = append(lines, strings.Join(cells, "\t"))
+			continue
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+		if r == 0 {
+			seps := make([]string, columns)
+			for i := range seps {
+				seps[i] = "---"
+				if i < len(aligns) {
+					switch aligns[i] {
+					case domain.AlignmentCenter:
+						seps[i] = ":---:"
+					case domain.AlignmentRight:
+						seps[i] = "---:"
+					}
+				}
+			}
+			lines = append(lines, "| "+strings.Join(seps, " | ")+" |")
+		}
+	}
+	w.add(blockText, strings.Join(lines, "\n"))
+	return nil
+}
+
+// lastAlign returns the last column alignment, which spanned columns repeat.
+func lastAlign(aligns []domain.Alignment) domain.Alignment {
+	if len(aligns) == 0 {
+		return domain.AlignmentLeft
+	}
+	return aligns[len(aligns)-1]
+}
+
+// cell returns the content of a table cell on one line. Header cells drop
+// bold, which the header row implies.
+func (w *writer) cell(cell domain.TableCell, header bool) (string, error) {
+	var parts []string
+	for _, para := range cell.Paragraphs() {
+		segs, err := w.segments(para)
+		if err != nil {
+			return "", err
+		}
+		if header {
+			for i := range segs {
+				segs[i].format.bold = false
+			}
+		}
+		if text := w.inline(segs, inlineCell); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	for _, nested := range cell.Tables() {
+		for _, row := range nested.Rows() {
+			for _, inner := range row.Cells() {
+				text, err := w.cell(inner, false)
+				if err != nil {
+					return "", err
+				}
+				if text != "" {
+					parts = append(parts, text)
+				}
+			}
+		}
+	}
+
+	sep := "<br>"
+	if w.plain {
+		sep = " "
+	}
+	text := strings.Join(parts, sep)
+	if !w.plain {
+		text = strings.ReplaceAll(text, "|", `\|`)
+	}
+	return text, nil
+}
+
+// footnotes writes the notes referenced so far, numbered in order of
+// reference. Notes referenced from other notes are appended as they come.
+func (w *writer) footnotes() error {
+	for i := 0; i < len(w.notes); i++ {
+		var paras []string
+		for _, para := range w.notes[i].Paragraphs() {
+			segs, err := w.segments(para)
+			if err != nil {
+				return err
+			}
+			if text := w.inline(segs, inlineBlock); text != "" {
+				paras = append(paras, text)
+			}
+		}
+		label := "[" + strconv.Itoa(i+1) + "]"
+		if w.plain {
+			w.add(blockText, label+" "+strings.Join(paras, "\n\n"))
+			continue
+		}
+		text := "[^" + strconv.Itoa(i+1) + "]: " + strings.Join(paras, "\n\n")
+		w.add(blockText, prefixLines(text, "    ", 1))
+	}
+	return nil
+}
+
+// prefixLines prefixes the lines of text from line start on. Empty lines
+// get the prefix without trailing spaces.
+func prefixLines(text, prefix string, start int) string {
+	lines := strings.Split(text, "\n")
+	for i := start; i < len(lines); i++ {
+		if lines[i] == "" {
+			lines[i] = strings.TrimRight(prefix, " ")
+		} else {
+			lines[i] = prefix + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
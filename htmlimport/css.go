@@ -0,0 +1,220 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package htmlimport
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// namedColors are the CSS color keywords recognized besides hex and
+// rgb() values.
+var namedColors = map[string]string{
+	"aqua": "#00ffff", "black": "#000000", "blue": "#0000ff", "cyan": "#00ffff",
+	"fuchsia": "#ff00ff", "gray": "#808080", "green": "#008000", "grey": "#808080",
+	"lime": "#00ff00", "magenta": "#ff00ff", "maroon": "#800000", "navy": "#000080",
+	"olive": "#808000", "orange": "#ffa500", "purple": "#800080", "red": "#ff0000",
+	"silver": "#c0c0c0", "teal": "#008080", "white": "#ffffff", "yellow": "#ffff00",
+}
+
+// fontSizeKeywords are the CSS absolute font sizes in half-points.
+var fontSizeKeywords = map[string]int{
+	"xx-small": 14, "x-small": 15, "small": 20, "medium": 24,
+	"large": 27, "x-large": 36, "xx-large": 48,
+}
+
+// highlightColors are the colors Word can highlight text with. White
+// maps to no highlight.
+var highlightColors = []struct {
+	color     domain.Color
+	highlight domain.HighlightColor
+}{
+	{domain.Color{R: 255, G: 255, B: 255}, domain.HighlightNone},
+	{domain.Color{R: 255, G: 255}, domain.HighlightYellow},
+	{domain.Color{G: 255}, domain.HighlightGreen},
+	{domain.Color{G: 255, B: 255}, domain.HighlightCyan},
+	{domain.Color{R: 255, B: 255}, domain.HighlightMagenta},
+	{domain.Color{B: 255}, domain.HighlightBlue},
+	{domain.Color{R: 255}, domain.HighlightRed},
+	{domain.Color{B: 128}, domain.HighlightDarkBlue},
+	{domain.Color{G: 128, B: 128}, domain.HighlightDarkCyan},
+	{domain.Color{G: 128}, domain.HighlightDarkGreen},
+	{domain.Color{R: 128, B: 128}, domain.HighlightDarkMagenta},
+	{domain.Color{R: 128}, domain.HighlightDarkRed},
+	{domain.Color{R: 128, G: 128}, domain.HighlightDarkYellow},
+	{domain.Color{R: 128, G: 128, B: 128}, domain.HighlightDarkGray},
+	{domain.Color{R: 192, G: 192, B: 192}, domain.HighlightLightGray},
+}
+
+// declarations parses an inline style attribute into lowercase property
+// names and their values.
+func declarations(style string) map[string]string {
+	decls := map[string]string{}
+	for _, decl := range strings.Split(style, ";") {
+		prop, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		if prop = strings.ToLower(strings.TrimSpace(prop)); prop != "" && value != "" {
+			decls[prop] = value
+		}
+	}
+	return decls
+}
+
+// parseColor reads a hex, rgb() or named CSS color. Transparent and
+// unknown colors are not ok.
+func parseColor(value string) (domain.Color, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if hex, ok := namedColors[value]; ok {
+		value = hex
+	}
+
+	if hex, ok := strings.CutPrefix(value, "#"); ok {
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		n, err := strconv.ParseUint(hex, 16, 32)
+		if len(hex) != 6 || err != nil {
+			return domain.Color{}, false
+		}
+		return domain.Color{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n)}, true
+	}
+
+	args, ok := strings.CutPrefix(value, "rgb(")
+	if !ok {
+		args, ok = strings.CutPrefix(value, "rgba(")
+	}
+	if !ok || !strings.HasSuffix(args, ")") {
+		return domain.Color{}, false
+	}
+	parts := strings.FieldsFunc(strings.TrimSuffix(args, ")"), func(r rune) bool {
+		return r == ',' || r == ' ' || r == '/'
+	})
+	if len(parts) < 3 {
+		return domain.Color{}, false
+	}
+	if len(parts) > 3 {
+		if alpha, err := strconv.ParseFloat(strings.TrimSuffix(parts[3], "%"), 64); err == nil && alpha == 0 {
+			return domain.Color{}, false
+		}
+	}
+	var rgb [3]uint8
+	for i, part := range parts[:3] {
+		scale := 1.0
+		if pct, ok := strings.CutSuffix(part, "%"); ok {
+			part, scale = pct, 2.55
+		}
+		v, err := strconv.ParseFloat(part, 64)
+		if err != nil {
+			return domain.Color{}, false
+		}
+		rgb[i] = uint8(math.Round(math.Max(0, math.Min(255, v*scale))))
+	}
+	return domain.Color{R: rgb[0], G: rgb[1], B: rgb[2]}, true
+}
+
+// parseFontSize converts a CSS font size to half-points. Relative sizes
+// scale current.
+func parseFontSize(value string, current int) (int, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if size, ok := fontSizeKeywords[value]; ok {
+		return size, true
+	}
+
+	units := []struct {
+		suffix string
+		scale  float64
+	}{
+		{"pt", 2}, {"px", 1.5}, {"rem", float64(constants.DefaultFontSize)},
+		{"em", float64(current)}, {"%", float64(current) / 100},
+	}
+	for _, unit := range units {
+		number, ok := strings.CutSuffix(value, unit.suffix)
+		if !ok {
+			continue
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+		if err != nil || v <= 0 {
+			return 0, false
+		}
+		size := int(math.Round(v * unit.scale))
+		return max(constants.MinFontSize, min(constants.MaxFontSize, size)), true
+	}
+	return 0, false
+}
+
+// parseAlignment reads a text-align value or align attribute.
+func parseAlignment(value string) (domain.Alignment, bool) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "left", "start":
+		return domain.AlignmentLeft, true
+	case "center", "-webkit-center":
+		return domain.AlignmentCenter, true
+	case "right", "end":
+		return domain.AlignmentRight, true
+	case "justify":
+		return domain.AlignmentJustify, true
+	}
+	return domain.AlignmentLeft, false
+}
+
+// backgroundColor returns the color of a background or
+// background-color declaration.
+func backgroundColor(decls map[string]string) (domain.Color, bool) {
+	if value, ok := decls["background-color"]; ok {
+		return parseColor(value)
+	}
+	value := decls["background"]
+	if c, ok := parseColor(value); ok {
+		return c, true
+	}
+	for _, part := range strings.Fields(value) {
+		if c, ok := parseColor(part); ok {
+			return c, true
+		}
+	}
+	return domain.Color{}, false
+}
+
+// nearestHighlight maps a background color to the closest highlight
+// color Word supports.
+func nearestHighlight(c domain.Color) domain.HighlightColor {
+	best, bestDistance := domain.HighlightNone, math.MaxInt
+	for _, candidate := range highlightColors {
+		dr := int(c.R) - int(candidate.color.R)
+		dg := int(c.G) - int(candidate.color.G)
+		db := int(c.B) - int(candidate.color.B)
+		if d := dr*dr + dg*dg + db*db; d < bestDistance {
+			best, bestDistance = candidate.highlight, d
+		}
+	}
+	return best
+}
@@ -0,0 +1,136 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package htmlimport converts HTML into Word documents.
+//
+// It reads the markup typically produced by rich text editors: p, h1-h6,
+// strong/em/u/s, sup/sub, a, ul/ol/li, tables with colspan and rowspan,
+// img, br, blockquote and pre/code. Inline color, font-size, text-align
+// and background styles are applied; other CSS, scripts and style sheets
+// are ignored.
+//
+//	doc, err := htmlimport.Convert(body, htmlimport.Options{Theme: themes.Corporate})
+//	if err != nil {
+//		return err
+//	}
+//	return doc.SaveAs("notes.docx")
+//
+// Insert places the content at a position of an existing document, for
+// example to fill a template.
+package htmlimport
+
+import (
+	"os"
+	"path/filepath"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+	"github.com/mmonterroca/docxgo/v2/themes"
+)
+
+// Options configures a conversion.
+type Options struct {
+	// Theme styles the document; nil keeps the default styles. Code uses
+	// the theme's monospace font.
+	Theme themes.Theme
+
+	// BaseDir is the directory relative image paths resolve against. Empty
+	// uses the working directory, or the HTML file's directory with
+	// ConvertFile.
+	BaseDir string
+
+	// AllowLocalFiles lets img elements read image files from disk. It is
+	// off by default, since HTML from users could otherwise copy any file
+	// the process can read into the document. Paths that leave BaseDir,
+	// files without an image extension and files over 32 MB are refused
+	// even when it is set. Refused images are replaced by their alt text.
+	AllowLocalFiles bool
+}
+
+// Convert creates a document from HTML source.
+func Convert(src []byte, opts Options) (domain.Document, error) {
+	doc := docx.NewDocument()
+	if opts.Theme != nil {
+		if err := opts.Theme.ApplyTo(doc); err != nil {
+			return nil, errors.Wrap(err, "htmlimport.Convert")
+		}
+	}
+	if err := Append(doc, src, opts); err != nil {
+		return nil, errors.Wrap(err, "htmlimport.Convert")
+	}
+	return doc, nil
+}
+
+// ConvertFile creates a document from an HTML file. Images resolve
+// against the file's directory unless opts.BaseDir is set.
+func ConvertFile(path string, opts Options) (domain.Document, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "htmlimport.ConvertFile")
+	}
+	if opts.BaseDir == "" {
+		opts.BaseDir = filepath.Dir(path)
+	}
+	doc, err := Convert(src, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "htmlimport.ConvertFile")
+	}
+	return doc, nil
+}
+
+// Append adds the HTML content to the end of doc. opts.Theme only
+// selects the code font; apply the theme to doc to restyle it.
+func Append(doc domain.Document, src []byte, opts Options) error {
+	if doc == nil {
+		return errors.InvalidArgument("htmlimport.Append", "doc", doc, "document cannot be nil")
+	}
+	c := newConverter(doc, opts)
+	f := &flow{c: c, container: doc}
+	if err := f.children(parse(src), textStyle{size: c.size}); err != nil {
+		return errors.Wrap(err, "htmlimport.Append")
+	}
+	f.end()
+	return nil
+}
+
+// Insert adds the HTML content to doc so that it starts at block index
+// at of doc.Blocks(). An index equal to the number of blocks appends.
+func Insert(doc domain.Document, at int, src []byte, opts Options) error {
+	const op = "htmlimport.Insert"
+	if doc == nil {
+		return errors.InvalidArgument(op, "doc", doc, "document cannot be nil")
+	}
+	start := len(doc.Blocks())
+	if at < 0 || at > start {
+		return errors.InvalidArgument(op, "at", at, "position out of bounds")
+	}
+	if err := Append(doc, src, opts); err != nil {
+		return errors.Wrap(err, op)
+	}
+	if err := doc.MoveBlocks(start, len(doc.Blocks()), at); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return nil
+}
@@ -0,0 +1,364 @@
+package htmlimport_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/htmlimport"
+	"github.com/mmonterroca/docxgo/v2/themes"
+)
+
+const article = `<!DOCTYPE html>
+<html><head><title>Ignored</title><style>p { color: red }</style></head>
+<body>
+<h1>Release   2.4</h1>
+<p style="text-align: center">Upgrade <b>before</b> June.
+<p>Second paragraph<br>next line</p>
+<blockquote><p>Quoted</p></blockquote>
+<ol start="3">
+  <li>Third
+    <ul><li>Nested bullet</li></ul>
+  <li><p>Fourth</p>
+</ol>
+<pre><code>make
+  make test
+</code></pre>
+<hr>
+<script>document.write("<p>nope</p>")</script>
+</body></html>`
+
+func TestConvertBlocks(t *testing.T) {
+	doc, err := htmlimport.Convert([]byte(article), htmlimport.Options{})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	paras := doc.Paragraphs()
+	want := []struct {
+		text, style string
+		level       int // -1 for no numbering
+	}{
+		{"Release 2.4", domain.StyleIDHeading1, -1},
+		{"Upgrade before June.", "", -1},
+		{"Second paragraphnext line", "", -1},
+		{"Quoted", domain.StyleIDQuote, -1},
+		{"Third", domain.StyleIDListParagraph, 0},
+		{"Nested bullet", domain.StyleIDListParagraph, 1},
+		{"Fourth", domain.StyleIDListParagraph, 0},
+		{"make  make test", "", -1},
+		{"", "", -1},
+	}
+	if len(paras) != len(want) {
+		for _, p := range paras {
+			t.Logf("%q", p.Text())
+		}
+		t.Fatalf("got %d paragraphs, want %d", len(paras), len(want))
+	}
+	ids := map[string]int{}
+	for i, w := range want {
+		p := paras[i]
+		style := ""
+		if named, ok := p.(interface{ StyleName() string }); ok {
+			style = named.StyleName()
+		}
+		if p.Text() != w.text || style != w.style {
+			t.Errorf("paragraph %d = %q (%s), want %q (%s)", i, p.Text(), style, w.text, w.style)
+		}
+		ref, numbered := p.Numbering()
+		if numbered != (w.level >= 0) || (numbered && ref.Level != w.level) {
+			t.Errorf("paragraph %d numbering = %+v, %v; want level %d", i, ref, numbered, w.level)
+		}
+		if numbered {
+			ids[w.text] = ref.ID
+		}
+	}
+	if ids["Third"] != ids["Fourth"] || ids["Third"] == ids["Nested bullet"] {
+		t.Errorf("list IDs = %v; ordered items should share a list apart from the bullets", ids)
+	}
+	if level, ok := doc.ListLevel(domain.NumberingReference{ID: ids["Third"]}); !ok || level.Start != 3 {
+		t.Errorf("ordered list level = %+v, %v; want start 3", level, ok)
+	}
+
+	if paras[1].Alignment() != domain.AlignmentCenter {
+		t.Errorf("alignment = %v, want center", paras[1].Alignment())
+	}
+	if runs := paras[1].Runs(); len(runs) != 3 || !runs[1].Bold() || runs[1].Text() != "before" {
+		t.Errorf("bold run not converted: %d runs", len(runs))
+	}
+	if code := paras[7].Runs()[0]; code.Font().Name != "Courier New" {
+		t.Errorf("code font = %q", code.Font().Name)
+	}
+	if paras[8].Borders().Bottom.Style != domain.BorderSingle {
+		t.Error("horizontal rule has no bottom border")
+	}
+}
+
+func TestConvertInlines(t *testing.T) {
+	src := `<p>H<sub>2</sub>O is x<sup>2</sup>, <u>under</u> <s>gone</s> <code>go test</code>
+<a href="https://example.com/docs">docs</a> <span style="color: #c00; font-size: 14pt">red</span>
+<span style="background-color: yellow">marked</span> <em><strong>both</strong></em></p>`
+	doc, err := htmlimport.Convert([]byte(src), htmlimport.Options{Theme: themes.Corporate})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	para := doc.Paragraphs()[0]
+	if want := "H2O is x2, under gone go test docs red marked both"; para.Text() != want {
+		t.Errorf("text = %q, want %q", para.Text(), want)
+	}
+	runs := para.Runs()
+	find := func(text string) domain.Run {
+		for _, run := range runs {
+			if run.Text() == text {
+				return run
+			}
+		}
+		t.Fatalf("no run %q", text)
+		return nil
+	}
+	if find("2").Script() != domain.ScriptSubscript || runs[3].Script() != domain.ScriptSuperscript {
+		t.Error("subscript or superscript not applied")
+	}
+	if find("under").Underline() != domain.UnderlineSingle || !find("gone").Strike() {
+		t.Error("underline or strike not applied")
+	}
+	if font := find("go test").Font().Name; font != themes.Corporate.Fonts().Monospace {
+		t.Errorf("code font = %q, want theme monospace", font)
+	}
+	if ref, ok := find("docs").ThemeColor(); !ok || ref.Color != domain.ThemeColorHyperlink {
+		t.Errorf("link color = %+v, %v", ref, ok)
+	}
+	if red := find("red"); red.Color() != (domain.Color{R: 0xCC}) || red.Size() != 28 {
+		t.Errorf("red run color = %+v size = %d", red.Color(), red.Size())
+	}
+	if find("marked").Highlight() != domain.HighlightYellow {
+		t.Errorf("highlight = %v", find("marked").Highlight())
+	}
+	if both := find("both"); !both.Bold() || !both.Italic() {
+		t.Error("nested emphasis not applied")
+	}
+
+	// Superscript survives a save and reload.
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	reopened, err := docx.OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("OpenDocumentFromBytes() error = %v", err)
+	}
+	if got := reopened.Paragraphs()[0].Runs()[1].Script(); got != domain.ScriptSubscript {
+		t.Errorf("reopened script = %v, want subscript", got)
+	}
+}
+
+func TestConvertTable(t *testing.T) {
+	src := `<table>
+<thead><tr><th colspan="2">Quarter</th><th>Total</th></tr></thead>
+<tbody>
+<tr><td rowspan="2" style="background: #D9E2F3">Q1</td><td>Jan</td><td align="right">10</td>
+<tr><td>Feb</td><td style="text-align: right">20</td>
+</tbody>
+</table>`
+	doc, err := htmlimport.Convert([]byte(src), htmlimport.Options{})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	tables := doc.Tables()
+	if len(tables) != 1 || tables[0].RowCount() != 3 || tables[0].ColumnCount() != 3 {
+		t.Fatalf("tables = %d", len(tables))
+	}
+	tbl := tables[0]
+	grid := tbl.Grid()
+	if grid[0][0].ColSpan != 2 || grid[1][0].RowSpan != 2 {
+		t.Errorf("merges = %+v / %+v", grid[0][0], grid[1][0])
+	}
+
+	cell := func(row, col int) domain.TableCell {
+		r, _ := tbl.Row(row)
+		c, _ := r.Cell(col)
+		return c
+	}
+	if run := cell(0, 0).Paragraphs()[0].Runs()[0]; run.Text() != "Quarter" || !run.Bold() {
+		t.Errorf("header cell = %q bold=%v", run.Text(), run.Bold())
+	}
+	if got := cell(1, 0).Shading(); got != (domain.Color{R: 0xD9, G: 0xE2, B: 0xF3}) {
+		t.Errorf("cell shading = %+v", got)
+	}
+	if text := cell(2, 1).Paragraphs()[0].Text(); text != "Feb" {
+		t.Errorf("cell (2, 1) = %q, want Feb", text)
+	}
+	for _, row := range []int{1, 2} {
+		if align := cell(row, 2).Paragraphs()[0].Alignment(); align != domain.AlignmentRight {
+			t.Errorf("row %d total alignment = %v, want right", row, align)
+		}
+	}
+}
+
+func TestConvertTableSpanLimits(t *testing.T) {
+	src := `<table>
+<tr><td colspan="0">zero</td><td colspan="x">bad</td><td colspan="5000">wide</td><td>past the last column</td></tr>
+<tr><td>a</td></tr>
+</table>`
+	doc, err := htmlimport.Convert([]byte(src), htmlimport.Options{})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+	tables := doc.Tables()
+	if len(tables) != 1 || tables[0].ColumnCount() != 63 {
+		t.Fatalf("tables = %d", len(tables))
+	}
+	grid := tables[0].Grid()
+	if grid[0][0].ColSpan != 1 || grid[0][1].ColSpan != 1 || grid[0][2].ColSpan != 61 {
+		t.Errorf("spans = %d, %d, %d; want 1, 1, 61", grid[0][0].ColSpan, grid[0][1].ColSpan, grid[0][2].ColSpan)
+	}
+	row, _ := tables[0].Row(0)
+	wide, _ := row.Cell(2)
+	var texts []string
+	for _, p := range wide.Paragraphs() {
+		texts = append(texts, p.Text())
+	}
+	if got := strings.Join(texts, "|"); got != "wide|past the last column" {
+		t.Errorf("last cell = %q, want the cell past the limit added to it", got)
+	}
+}
+
+func TestConvertFileWithImages(t *testing.T) {
+	dir := t.TempDir()
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 200, 100))); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "chart.png"), img.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dataURI := "data:image/png;base64," + base64.StdEncoding.EncodeToString(img.Bytes())
+	src := `<p><img src="chart.png" alt="Chart" width="100"></p>
+<p><img src="` + dataURI + `"> <img src="https://example.com/a.png" alt="remote"></p>`
+	path := filepath.Join(dir, "page.html")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := htmlimport.ConvertFile(path, htmlimport.Options{AllowLocalFiles: true})
+	if err != nil {
+		t.Fatalf("ConvertFile() error = %v", err)
+	}
+	images := doc.Images()
+	if len(images) != 2 || images[0].Image.Description() != "Chart" {
+		t.Fatalf("images = %v", images)
+	}
+	if size := images[0].Image.Size(); size.WidthPx != 100 || size.HeightPx != 50 {
+		t.Errorf("image size = %dx%d, want 100x50", size.WidthPx, size.HeightPx)
+	}
+	if text := doc.Paragraphs()[1].Text(); text != " remote" {
+		t.Errorf("remote image text = %q", text)
+	}
+
+	if _, err := htmlimport.Convert([]byte(`<img src="missing.png">`), htmlimport.Options{BaseDir: dir, AllowLocalFiles: true}); err == nil ||
+		!strings.Contains(err.Error(), "missing.png") {
+		t.Errorf("missing image error = %v", err)
+	}
+
+	// Malformed data URIs do not fail the conversion.
+	for _, uri := range []string{"data:image/png;base64,@@@", "data:text/plain;base64,aGk=", "data:image/png,raw"} {
+		doc, err := htmlimport.Convert([]byte(`<p>See <img src="`+uri+`" alt="figure"></p>`), htmlimport.Options{})
+		if err != nil {
+			t.Errorf("%s: Convert() error = %v", uri, err)
+			continue
+		}
+		if n := len(doc.Images()); n != 0 || doc.Paragraphs()[0].Text() != "See figure" {
+			t.Errorf("%s: %d images, text %q; want the alt text", uri, n, doc.Paragraphs()[0].Text())
+		}
+	}
+}
+
+func TestConvertRefusesLocalFiles(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "site")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{filepath.Join(root, "secret.png"), filepath.Join(dir, "ok.png"), filepath.Join(dir, "data.txt")} {
+		if err := os.WriteFile(path, img.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(root, "secret.png"), filepath.Join(dir, "link.png")); err != nil {
+		t.Fatal(err)
+	}
+
+	hostile := []string{
+		"../secret.png",
+		filepath.ToSlash(filepath.Join(root, "secret.png")),
+		"file://" + filepath.ToSlash(filepath.Join(root, "secret.png")),
+		"%2e%2e/secret.png",
+		"link.png",
+		"data.txt",
+		"/dev/zero",
+		"file:///etc/passwd",
+	}
+	for _, src := range hostile {
+		doc, err := htmlimport.Convert([]byte(`<p><img src="`+src+`" alt="refused"></p>`),
+			htmlimport.Options{BaseDir: dir, AllowLocalFiles: true})
+		if err != nil {
+			t.Errorf("%s: Convert() error = %v", src, err)
+			continue
+		}
+		if n := len(doc.Images()); n != 0 || doc.Paragraphs()[0].Text() != "refused" {
+			t.Errorf("%s: %d images, text %q; want the alt text", src, n, doc.Paragraphs()[0].Text())
+		}
+	}
+
+	// Without AllowLocalFiles nothing is read from disk.
+	doc, err := htmlimport.Convert([]byte(`<img src="ok.png">`), htmlimport.Options{BaseDir: dir})
+	if err != nil || len(doc.Images()) != 0 {
+		t.Errorf("default options read a local image: %v", err)
+	}
+	doc, err = htmlimport.Convert([]byte(`<img src="ok.png">`), htmlimport.Options{BaseDir: dir, AllowLocalFiles: true})
+	if err != nil || len(doc.Images()) != 1 {
+		t.Errorf("image inside BaseDir: %d images, %v", len(doc.Images()), err)
+	}
+}
+
+func TestInsert(t *testing.T) {
+	doc := docx.NewDocument()
+	for _, text := range []string{"Before", "After"} {
+		p, _ := doc.AddParagraph()
+		run, _ := p.AddRun()
+		_ = run.SetText(text)
+	}
+
+	src := []byte("<p>One</p><table><tr><td>Cell</td></tr></table><p>Two</p>")
+	if err := htmlimport.Insert(doc, 1, src, htmlimport.Options{}); err != nil {
+		t.Fatalf("Insert() error = %v", err)
+	}
+	var got []string
+	for _, block := range doc.Blocks() {
+		switch {
+		case block.Paragraph != nil:
+			got = append(got, block.Paragraph.Text())
+		case block.Table != nil:
+			got = append(got, "<table>")
+		}
+	}
+	if want := "Before,One,<table>,Two,After"; strings.Join(got, ",") != want {
+		t.Errorf("blocks = %v, want %s", got, want)
+	}
+
+	if err := htmlimport.Insert(doc, 10, src, htmlimport.Options{}); err == nil {
+		t.Error("Insert() past the end should fail")
+	}
+}
@@ -0,0 +1,277 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package htmlimport
+
+import (
+	"html"
+	"strings"
+)
+
+// node is an element or, when tag is empty, a text node.
+type node struct {
+	tag      string
+	attrs    map[string]string
+	text     string
+	children []*node
+}
+
+// voidElements never have content or an end tag.
+var voidElements = map[string]bool{
+	"area": true, "base": true, "br": true, "col": true, "embed": true, "hr": true,
+	"img": true, "input": true, "link": true, "meta": true, "param": true,
+	"source": true, "track": true, "wbr": true,
+}
+
+// skippedElements hold content that is never displayed.
+var skippedElements = map[string]bool{
+	"head": true, "noscript": true, "script": true, "style": true,
+	"template": true, "textarea": true, "title": true,
+}
+
+// blockElements start a new paragraph.
+var blockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true,
+	"dd": true, "div": true, "dl": true, "dt": true, "figcaption": true,
+	"figure": true, "footer": true, "h1": true, "h2": true, "h3": true,
+	"h4": true, "h5": true, "h6": true, "header": true, "hr": true,
+	"li": true, "main": true, "nav": true, "ol": true, "p": true, "pre": true,
+	"section": true, "table": true, "ul": true,
+}
+
+// treeBuilder assembles nodes, closing elements whose end tags are
+// optional the way browsers do.
+type treeBuilder struct {
+	stack []*node
+}
+
+// parse builds a node tree from HTML source. Malformed markup is
+// tolerated: unknown end tags are ignored and open elements are closed
+// at the end of the input.
+func parse(src []byte) *node {
+	root := &node{tag: "#root"}
+	b := &treeBuilder{stack: []*node{root}}
+	s := strings.TrimPrefix(string(src), "\uFEFF")
+
+	for i := 0; i < len(s); {
+		if s[i] != '<' {
+			end := nextTag(s, i)
+			b.top().children = append(b.top().children, &node{text: html.UnescapeString(s[i:end])})
+			i = end
+			continue
+		}
+
+		switch rest := s[i:]; {
+		case strings.HasPrefix(rest, "<!--"):
+			i = skipPast(s, i+4, "-->")
+		case strings.HasPrefix(rest, "<!"), strings.HasPrefix(rest, "<?"):
+			i = skipPast(s, i+2, ">")
+		case strings.HasPrefix(rest, "</"):
+			name, end := tagName(s, i+2)
+			b.close(name)
+			i = skipPast(s, end, ">")
+		default:
+			name, end := tagName(s, i+1)
+			attrs, end, selfClosing := attributes(s, end)
+			i = end
+			if skippedElements[name] {
+				i = skipElement(s, i, name)
+				continue
+			}
+			b.open(&node{tag: name, attrs: attrs}, selfClosing || voidElements[name])
+		}
+	}
+	return root
+}
+
+func (b *treeBuilder) top() *node {
+	return b.stack[len(b.stack)-1]
+}
+
+// open adds an element, first closing the elements it implicitly ends.
+func (b *treeBuilder) open(n *node, void bool) {
+	switch n.tag {
+	case "li":
+		b.closeWithin([]string{"li"}, "ul", "ol")
+	case "dt", "dd":
+		b.closeWithin([]string{"dt", "dd"}, "dl")
+	case "td", "th":
+		b.closeWithin([]string{"td", "th"}, "tr", "table")
+	case "tr":
+		b.closeWithin([]string{"tr"}, "table")
+	case "thead", "tbody", "tfoot":
+		b.closeWithin([]string{"thead", "tbody", "tfoot"}, "table")
+	}
+	if blockElements[n.tag] {
+		// A paragraph cannot contain blocks; close it through any
+		// inline elements still open inside it.
+		for i := len(b.stack) - 1; i > 0; i-- {
+			tag := b.stack[i].tag
+			if tag == "p" {
+				b.stack = b.stack[:i]
+				break
+			}
+			if blockElements[tag] || tag == "td" || tag == "th" {
+				break
+			}
+		}
+	}
+
+	parent := b.top()
+	parent.children = append(parent.children, n)
+	if !void {
+		b.stack = append(b.stack, n)
+	}
+}
+
+// close ends the innermost open element named tag, if any.
+func (b *treeBuilder) close(tag string) {
+	for i := len(b.stack) - 1; i > 0; i-- {
+		if b.stack[i].tag == tag {
+			b.stack = b.stack[:i]
+			return
+		}
+	}
+}
+
+// closeWithin closes the innermost open element named in tags, unless
+// one of the boundary elements is reached first.
+func (b *treeBuilder) closeWithin(tags []string, boundaries ...string) {
+	for i := len(b.stack) - 1; i > 0; i-- {
+		tag := b.stack[i].tag
+		for _, boundary := range boundaries {
+			if tag == boundary {
+				return
+			}
+		}
+		for _, t := range tags {
+			if tag == t {
+				b.stack = b.stack[:i]
+				return
+			}
+		}
+	}
+}
+
+// nextTag returns the index of the next '<' that starts markup, or the
+// end of s. Other '<' characters are text.
+func nextTag(s string, i int) int {
+	for j := i + 1; j < len(s); j++ {
+		if s[j] == '<' && j+1 < len(s) && (isLetter(s[j+1]) || strings.ContainsRune("/!?", rune(s[j+1]))) {
+			return j
+		}
+	}
+	return len(s)
+}
+
+// skipPast returns the index just after the next occurrence of marker.
+func skipPast(s string, i int, marker string) int {
+	if end := strings.Index(s[i:], marker); end >= 0 {
+		return i + end + len(marker)
+	}
+	return len(s)
+}
+
+// skipElement returns the index just after the end tag of name.
+func skipElement(s string, i int, name string) int {
+	lower := strings.ToLower(s[i:])
+	end := strings.Index(lower, "</"+name)
+	if end < 0 {
+		return len(s)
+	}
+	return skipPast(s, i+end, ">")
+}
+
+// tagName reads a lowercase tag name starting at i.
+func tagName(s string, i int) (string, int) {
+	start := i
+	for i < len(s) && !isSpace(s[i]) && s[i] != '>' && s[i] != '/' {
+		i++
+	}
+	return strings.ToLower(s[start:i]), i
+}
+
+// attributes reads the attributes of a start tag up to its closing '>'.
+func attributes(s string, i int) (map[string]string, int, bool) {
+	attrs := map[string]string{}
+	for i < len(s) {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		switch {
+		case i >= len(s):
+			return attrs, i, false
+		case s[i] == '>':
+			return attrs, i + 1, false
+		case strings.HasPrefix(s[i:], "/>"):
+			return attrs, i + 2, true
+		case s[i] == '/':
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '>' && !strings.HasPrefix(s[i:], "/>") {
+			i++
+		}
+		name := strings.ToLower(s[start:i])
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		value := ""
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					end = len(s) - i - 1
+				}
+				value = s[i+1 : i+1+end]
+				i = min(i+2+end, len(s))
+			} else {
+				start := i
+				for i < len(s) && !isSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				value = s[start:i]
+			}
+		}
+		if _, seen := attrs[name]; !seen && name != "" {
+			attrs[name] = html.UnescapeString(value)
+		}
+	}
+	return attrs, i, false
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
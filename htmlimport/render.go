@@ -0,0 +1,905 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package htmlimport
+
+import (
+	"encoding/base64"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// headingStyles maps heading levels to paragraph styles.
+var headingStyles = map[string]string{
+	"h1": domain.StyleIDHeading1, "h2": domain.StyleIDHeading2, "h3": domain.StyleIDHeading3,
+	"h4": domain.StyleIDHeading4, "h5": domain.StyleIDHeading5, "h6": domain.StyleIDHeading6,
+}
+
+// emuPerPixel converts CSS pixels at 96 DPI to EMUs.
+const emuPerPixel = 9525
+
+// paragraphAdder is a document or table cell.
+type paragraphAdder interface {
+	AddParagraph() (domain.Paragraph, error)
+}
+
+// tableAdder is a document or table cell.
+type tableAdder interface {
+	AddTable(rows, cols int) (domain.Table, error)
+}
+
+// converter holds the settings shared by a conversion.
+type converter struct {
+	doc        domain.Document
+	opts       Options
+	mono       string
+	size       int // Default font size in half-points
+	ruleColor  domain.Color
+	imageWidth int // Widest image in EMUs
+}
+
+func newConverter(doc domain.Document, opts Options) *converter {
+	c := &converter{
+		doc:        doc,
+		opts:       opts,
+		mono:       "Courier New",
+		size:       constants.DefaultFontSize,
+		ruleColor:  domain.Color{R: 191, G: 191, B: 191},
+		imageWidth: 6 * 914400,
+	}
+	if opts.Theme != nil {
+		if mono := opts.Theme.Fonts().Monospace; mono != "" {
+			c.mono = mono
+		}
+		c.ruleColor = opts.Theme.Colors().Muted
+	}
+	if section, err := doc.DefaultSection(); err == nil {
+		size, margins := section.PageSize(), section.Margins()
+		if width := size.Width - margins.Left - margins.Right; width > 0 {
+			c.imageWidth = width * 635 // 914400 EMU / 1440 twips
+		}
+	}
+	return c
+}
+
+// blockState describes the paragraphs of the enclosing blocks.
+type blockState struct {
+	style   string // Paragraph style, e.g. a heading
+	align   domain.Alignment
+	aligned bool
+	quote   bool
+	pre     bool
+	list    bool
+	level   int
+	listID  int
+	ordered bool
+	item    *pendingItem
+}
+
+// pendingItem is the numbering of a list item, taken by its first
+// paragraph.
+type pendingItem struct {
+	ref  domain.NumberingReference
+	used bool
+}
+
+// textStyle is the formatting inherited from enclosing elements.
+type textStyle struct {
+	bold, italic, underline, strike, code bool
+
+	script    domain.Script
+	color     *domain.Color
+	size      int // Half-points
+	highlight domain.HighlightColor
+	link      string
+}
+
+// flow adds the content of one container, starting paragraphs as inline
+// content arrives.
+type flow struct {
+	c         *converter
+	container paragraphAdder
+	block     blockState
+	para      domain.Paragraph
+	space     bool // Collapsed whitespace is dropped at this point
+}
+
+// children converts the child nodes of n.
+func (f *flow) children(n *node, style textStyle) error {
+	for _, child := range n.children {
+		if err := f.node(child, style); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// node converts an element or text node.
+func (f *flow) node(n *node, style textStyle) error {
+	if n.tag == "" {
+		return f.text(n.text, style)
+	}
+	decls := declarations(n.attrs["style"])
+	style = f.c.inherit(n, decls, style)
+
+	switch n.tag {
+	case "br":
+		return f.lineBreak()
+	case "img":
+		return f.image(n, style)
+	case "hr":
+		f.end()
+		p, err := f.paragraph()
+		if err != nil {
+			return err
+		}
+		f.end()
+		return p.SetBorderBottom(domain.BorderStyle{Style: domain.BorderSingle, Width: 6, Color: f.c.ruleColor})
+	case "ul", "ol":
+		return f.list(n, style)
+	case "table":
+		return f.table(n, style)
+	}
+	if blockElements[n.tag] {
+		return f.blockElement(n, decls, style)
+	}
+	return f.children(n, style)
+}
+
+// inherit applies the formatting of element n and its inline styles.
+func (c *converter) inherit(n *node, decls map[string]string, style textStyle) textStyle {
+	switch n.tag {
+	case "b", "strong", "th":
+		style.bold = true
+	case "i", "em", "cite", "dfn", "var":
+		style.italic = true
+	case "u", "ins":
+		style.underline = true
+	case "s", "strike", "del":
+		style.strike = true
+	case "sup":
+		style.script = domain.ScriptSuperscript
+	case "sub":
+		style.script = domain.ScriptSubscript
+	case "code", "kbd", "samp", "tt", "pre":
+		style.code = true
+	case "mark":
+		style.highlight = domain.HighlightYellow
+	case "a":
+		if href := strings.TrimSpace(n.attrs["href"]); href != "" && !strings.HasPrefix(href, "#") &&
+			!strings.HasPrefix(strings.ToLower(href), "javascript:") {
+			style.link = href
+		}
+	case "font":
+		if color, ok := parseColor(n.attrs["color"]); ok {
+			style.color = &color
+		}
+	}
+
+	if color, ok := parseColor(decls["color"]); ok {
+		style.color = &color
+	}
+	if size, ok := parseFontSize(decls["font-size"], style.size); ok {
+		style.size = size
+	}
+	// Cell backgrounds shade the cell instead.
+	if n.tag != "td" && n.tag != "th" && n.tag != "tr" && n.tag != "table" {
+		if color, ok := backgroundColor(decls); ok {
+			style.highlight = nearestHighlight(color)
+		}
+	}
+	return style
+}
+
+// blockElement converts a paragraph-level element such as p, a heading,
+// blockquote or pre.
+func (f *flow) blockElement(n *node, decls map[string]string, style textStyle) error {
+	f.end()
+	saved := f.block
+	defer func() { f.block = saved }()
+
+	if id, ok := headingStyles[n.tag]; ok {
+		f.block.style = id
+	}
+	switch n.tag {
+	case "blockquote":
+		f.block.quote = true
+	case "pre":
+		f.block.pre = true
+		trimTrailingNewline(n)
+	}
+	if align, ok := alignment(n, decls); ok {
+		f.block.align, f.block.aligned = align, true
+	}
+
+	if err := f.children(n, style); err != nil {
+		return err
+	}
+	f.end()
+	return nil
+}
+
+// trimTrailingNewline removes the newline that ends the last text of a
+// pre element, which browsers do not display.
+func trimTrailingNewline(n *node) {
+	for len(n.children) > 0 {
+		n = n.children[len(n.children)-1]
+		if n.tag == "" {
+			n.text = strings.TrimSuffix(strings.TrimSuffix(n.text, "\n"), "\r")
+			return
+		}
+	}
+}
+
+// alignment returns the text-align style or align attribute of n.
+func alignment(n *node, decls map[string]string) (domain.Alignment, bool) {
+	if value, ok := decls["text-align"]; ok {
+		return parseAlignment(value)
+	}
+	return parseAlignment(n.attrs["align"])
+}
+
+// paragraph returns the current paragraph, starting one styled for the
+// enclosing blocks if needed. The first paragraph of a list item carries
+// the item's number.
+func (f *flow) paragraph() (domain.Paragraph, error) {
+	if f.para != nil {
+		return f.para, nil
+	}
+	p, err := f.container.AddParagraph()
+	if err != nil {
+		return nil, err
+	}
+	f.para, f.space = p, true
+
+	st := f.block
+	switch {
+	case st.style != "":
+		err = p.SetStyle(st.style)
+	case st.quote:
+		err = p.SetStyle(domain.StyleIDQuote)
+	case st.list:
+		err = p.SetStyle(domain.StyleIDListParagraph)
+	}
+	if err == nil && st.aligned {
+		err = p.SetAlignment(st.align)
+	}
+	if err != nil || !st.list {
+		return p, err
+	}
+
+	if item := st.item; item != nil && !item.used {
+		item.used = true
+		return p, p.SetNumbering(item.ref)
+	}
+	return p, p.SetIndent(domain.Indentation{Left: 720 * (st.level + 1)})
+}
+
+// end finishes the current paragraph, dropping its trailing whitespace.
+func (f *flow) end() {
+	if f.para == nil {
+		return
+	}
+	if !f.block.pre {
+		runs := f.para.Runs()
+		for i := len(runs) - 1; i >= 0; i-- {
+			text := runs[i].Text()
+			if text == "" {
+				continue
+			}
+			if trimmed := strings.TrimRight(text, " "); trimmed != text {
+				_ = runs[i].SetText(trimmed)
+			}
+			break
+		}
+	}
+	f.para = nil
+}
+
+// text adds text, collapsing whitespace outside pre blocks.
+func (f *flow) text(text string, style textStyle) error {
+	if f.block.pre {
+		if f.para == nil {
+			text = strings.TrimPrefix(strings.TrimPrefix(text, "\r"), "\n")
+		}
+		text = strings.ReplaceAll(strings.ReplaceAll(text, "\r\n", "\n"), "\r", "\n")
+		for i, line := range strings.Split(text, "\n") {
+			if i > 0 {
+				if err := f.lineBreak(); err != nil {
+					return err
+				}
+			}
+			if line == "" {
+				continue
+			}
+			p, err := f.paragraph()
+			if err != nil {
+				return err
+			}
+			if err := f.c.addText(p, line, style); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	text = collapseSpace(text)
+	if f.para == nil || f.space {
+		text = strings.TrimPrefix(text, " ")
+	}
+	if text == "" {
+		return nil
+	}
+	p, err := f.paragraph()
+	if err != nil {
+		return err
+	}
+	f.space = strings.HasSuffix(text, " ")
+	return f.c.addText(p, text, style)
+}
+
+// collapseSpace replaces each run of HTML whitespace with one space.
+func collapseSpace(text string) string {
+	var b strings.Builder
+	space := false
+	for i := 0; i < len(text); i++ {
+		if isSpace(text[i]) {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteByte(text[i])
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+// lineBreak adds a line break to the current paragraph.
+func (f *flow) lineBreak() error {
+	p, err := f.paragraph()
+	if err != nil {
+		return err
+	}
+	f.space = true
+	run, err := p.AddRun()
+	if err != nil {
+		return err
+	}
+	return run.AddBreak(domain.BreakTypeLine)
+}
+
+// addText adds a formatted run, as a hyperlink when the style links.
+func (c *converter) addText(p domain.Paragraph, text string, style textStyle) error {
+	run, err := p.AddRun()
+	if err != nil {
+		return err
+	}
+	if err = run.SetText(text); err != nil {
+		return err
+	}
+	if style.link != "" {
+		if err = run.AddField(docx.NewHyperlinkField(style.link, text)); err != nil {
+			return err
+		}
+		if style.color == nil {
+			err = run.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorHyperlink})
+		}
+		if err == nil {
+			err = run.SetUnderline(domain.UnderlineSingle)
+		}
+	}
+
+	if style.bold && err == nil {
+		err = run.SetBold(true)
+	}
+	if style.italic && err == nil {
+		err = run.SetItalic(true)
+	}
+	if style.underline && err == nil {
+		err = run.SetUnderline(domain.UnderlineSingle)
+	}
+	if style.strike && err == nil {
+		err = run.SetStrike(true)
+	}
+	if style.script != domain.ScriptBaseline && err == nil {
+		err = run.SetScript(style.script)
+	}
+	if style.code && err == nil {
+		err = run.SetFont(domain.Font{Name: c.mono})
+	}
+	if style.color != nil && err == nil {
+		err = run.SetColor(*style.color)
+	}
+	if style.size != c.size && err == nil {
+		err = run.SetSize(style.size)
+	}
+	if style.highlight != domain.HighlightNone && err == nil {
+		err = run.SetHighlight(style.highlight)
+	}
+	return err
+}
+
+// list numbers the items of a ul or ol. A nested list of the same kind
+// continues its parent's numbering one level down.
+func (f *flow) list(n *node, style textStyle) error {
+	if err := f.startItem(); err != nil {
+		return err
+	}
+	saved := f.block
+	defer func() { f.block = saved }()
+
+	st := f.block
+	ordered := n.tag == "ol"
+	start := 1
+	if value, err := strconv.Atoi(strings.TrimSpace(n.attrs["start"])); err == nil && ordered {
+		start = value
+	}
+	level := 0
+	if st.list {
+		level = min(st.level+1, domain.NumberingLevelMax)
+	}
+	id := st.listID
+	if !st.list || st.ordered != ordered || (ordered && start != 1) {
+		def := domain.ListDefinition{Kind: domain.ListBullet}
+		if ordered {
+			def = domain.ListDefinition{Kind: domain.ListDecimal, Start: max(start, 0)}
+		}
+		var err error
+		if id, err = f.c.doc.AddList(def); err != nil {
+			return err
+		}
+	}
+
+	itemState := st
+	itemState.list, itemState.level, itemState.listID, itemState.ordered = true, level, id, ordered
+	itemState.style = ""
+	for _, child := range n.children {
+		switch child.tag {
+		case "li":
+			f.block = itemState
+			f.block.item = &pendingItem{ref: domain.NumberingReference{ID: id, Level: level}}
+			decls := declarations(child.attrs["style"])
+			if align, ok := alignment(child, decls); ok {
+				f.block.align, f.block.aligned = align, true
+			}
+			if err := f.children(child, f.c.inherit(child, decls, style)); err != nil {
+				return err
+			}
+			if err := f.startItem(); err != nil {
+				return err
+			}
+			f.end()
+		case "ul", "ol":
+			// A list directly inside a list belongs to the previous item.
+			if err := f.list(child, style); err != nil {
+				return err
+			}
+		default:
+			if err := f.node(child, style); err != nil {
+				return err
+			}
+		}
+	}
+	f.end()
+	return nil
+}
+
+// startItem adds the numbered paragraph of a list item that has not
+// started one yet, so that nested lists and tables follow its marker.
+func (f *flow) startItem() error {
+	f.end()
+	if item := f.block.item; item != nil && !item.used {
+		if _, err := f.paragraph(); err != nil {
+			return err
+		}
+		f.end()
+	}
+	return nil
+}
+
+// tableCell is a td or th placed on the table grid.
+type tableCell struct {
+	node       *node
+	style      textStyle
+	row, col   int
+	rows, cols int
+	overflow   []tableCell // cells past the last column, added to this one
+}
+
+// table converts a table, merging cells that span rows or columns.
+// Containers that cannot hold tables get one paragraph per row.
+func (f *flow) table(n *node, style textStyle) error {
+	if err := f.startItem(); err != nil {
+		return err
+	}
+	cells, rowCount, colCount := layoutTable(n, style, f.c)
+	if rowCount == 0 || colCount == 0 {
+		return nil
+	}
+
+	adder, ok := f.container.(tableAdder)
+	if !ok {
+		for row := 0; row < rowCount; row++ {
+			first := true
+			for _, cell := range cells {
+				if cell.row != row {
+					continue
+				}
+				if !first {
+					if err := f.text("\t", cell.style); err != nil {
+						return err
+					}
+				}
+				first = false
+				for i, part := range cell.parts() {
+					if i > 0 {
+						if err := f.text("\t", part.style); err != nil {
+							return err
+						}
+					}
+					if err := f.children(part.node, part.style); err != nil {
+						return err
+					}
+				}
+			}
+			f.end()
+		}
+		return nil
+	}
+
+	tbl, err := adder.AddTable(rowCount, colCount)
+	if err != nil {
+		return err
+	}
+	if err := tbl.SetStyle(domain.TableStyleGrid); err != nil {
+		return err
+	}
+	targets := make([]domain.TableCell, len(cells))
+	for i, cell := range cells {
+		row, err := tbl.Row(cell.row)
+		if err != nil {
+			return err
+		}
+		if targets[i], err = row.Cell(cell.col); err != nil {
+			return err
+		}
+	}
+	for i, cell := range cells {
+		if cell.rows > 1 || cell.cols > 1 {
+			if err := targets[i].Merge(cell.cols, cell.rows); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i, cell := range cells {
+		decls := declarations(cell.node.attrs["style"])
+		if color, ok := backgroundColor(decls); ok {
+			if err := targets[i].SetShading(color); err != nil {
+				return err
+			}
+		}
+		cellFlow := &flow{c: f.c, container: targets[i]}
+		if align, ok := alignment(cell.node, decls); ok {
+			cellFlow.block.align, cellFlow.block.aligned = align, true
+		}
+		for _, part := range cell.parts() {
+			if err := cellFlow.children(part.node, part.style); err != nil {
+				return err
+			}
+			cellFlow.end()
+		}
+	}
+	return nil
+}
+
+// parts returns the cell followed by the cells added to it.
+func (cell tableCell) parts() []tableCell {
+	return append([]tableCell{cell}, cell.overflow...)
+}
+
+// layoutTable places the cells of a table on its grid, following
+// rowspan and colspan. It returns the cells and the grid size. Tables
+// have at most constants.MaxTableCols columns: spans are cut at the last
+// column and cells past it are added to the cell before them.
+func layoutTable(n *node, style textStyle, c *converter) ([]tableCell, int, int) {
+	var rows []*node
+	for _, child := range n.children {
+		switch child.tag {
+		case "tr":
+			rows = append(rows, child)
+		case "thead", "tbody", "tfoot":
+			for _, row := range child.children {
+				if row.tag == "tr" {
+					rows = append(rows, row)
+				}
+			}
+		}
+	}
+
+	var (
+		cells    []tableCell
+		occupied = map[[2]int]bool{}
+		colCount int
+	)
+	for r, row := range rows {
+		rowStyle := c.inherit(row, declarations(row.attrs["style"]), style)
+		col := 0
+		for _, child := range row.children {
+			if child.tag != "td" && child.tag != "th" {
+				continue
+			}
+			for occupied[[2]int{r, col}] {
+				col++
+			}
+			cellStyle := c.inherit(child, declarations(child.attrs["style"]), rowStyle)
+			if col >= constants.MaxTableCols {
+				if len(cells) > 0 {
+					last := &cells[len(cells)-1]
+					last.overflow = append(last.overflow, tableCell{node: child, style: cellStyle})
+				}
+				continue
+			}
+			cell := tableCell{
+				node:  child,
+				style: cellStyle,
+				row:   r,
+				col:   col,
+				rows:  span(child.attrs["rowspan"], len(rows)-r, len(rows)-r),
+				cols:  span(child.attrs["colspan"], 1, constants.MaxTableCols-col),
+			}
+			// Spans that overlap an earlier cell are cut short.
+			for dc := 1; dc < cell.cols; dc++ {
+				if occupied[[2]int{r, col + dc}] {
+					cell.cols = dc
+				}
+			}
+			for dr := 0; dr < cell.rows; dr++ {
+				for dc := 0; dc < cell.cols; dc++ {
+					occupied[[2]int{r + dr, col + dc}] = true
+				}
+			}
+			cells = append(cells, cell)
+			col += cell.cols
+			colCount = max(colCount, col)
+		}
+	}
+	return cells, len(rows), colCount
+}
+
+// span reads a rowspan or colspan attribute, at least 1 and at most
+// limit. zero is the span of 0: the remaining rows for a rowspan, 1 for
+// a colspan.
+func span(value string, zero, limit int) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	switch {
+	case err != nil || n < 0:
+		return 1
+	case n == 0:
+		return max(min(zero, limit), 1)
+	case n > limit:
+		return limit
+	}
+	return n
+}
+
+// image embeds a local or data URI image, sized by its width and height
+// attributes and scaled down to the page width. Remote images become
+// links, since nothing is downloaded, and refused local images their alt
+// text.
+func (f *flow) image(n *node, style textStyle) error {
+	src := strings.TrimSpace(n.attrs["src"])
+	alt := n.attrs["alt"]
+	if src == "" {
+		return nil
+	}
+	if strings.Contains(src, "://") && !strings.HasPrefix(src, "file://") {
+		text := alt
+		if text == "" {
+			text = src
+		}
+		style.link = src
+		return f.text(text, style)
+	}
+
+	p, err := f.paragraph()
+	if err != nil {
+		return err
+	}
+	f.space = false
+	var img domain.Image
+	if strings.HasPrefix(src, "data:") {
+		data, format, ok := decodeDataURI(src)
+		if !ok {
+			// Malformed or non-image data URIs are shown as their alt text.
+			return f.text(alt, style)
+		}
+		img, err = p.AddImageFromBytes(data, format, domain.ImageSize{}, domain.ImagePosition{})
+	} else {
+		data, format, ok, rerr := f.c.localImage(src)
+		if rerr != nil {
+			return errors.WrapWithContext(rerr, "htmlimport.Append", map[string]interface{}{"image": truncate(src, 80)})
+		}
+		if !ok {
+			return f.text(alt, style)
+		}
+		img, err = p.AddImageFromBytes(data, format, domain.ImageSize{}, domain.ImagePosition{})
+	}
+	if err != nil {
+		return errors.WrapWithContext(err, "htmlimport.Append", map[string]interface{}{"image": truncate(src, 80)})
+	}
+
+	if alt != "" {
+		if err := img.SetDescription(alt); err != nil {
+			return err
+		}
+	}
+	size := img.Size()
+	width, werr := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(n.attrs["width"]), "px"))
+	height, herr := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(n.attrs["height"]), "px"))
+	switch {
+	case werr == nil && herr == nil && width > 0 && height > 0:
+	case werr == nil && width > 0 && size.WidthPx > 0:
+		height = size.HeightPx * width / size.WidthPx
+	case herr == nil && height > 0 && size.HeightPx > 0:
+		width = size.WidthPx * height / size.HeightPx
+	default:
+		width, height = size.WidthPx, size.HeightPx
+	}
+	if width <= 0 || height <= 0 {
+		return nil
+	}
+	if width*emuPerPixel > f.c.imageWidth {
+		height = height * f.c.imageWidth / (width * emuPerPixel)
+		width = f.c.imageWidth / emuPerPixel
+	}
+	if width == size.WidthPx && height == size.HeightPx {
+		return nil
+	}
+	return img.SetSize(domain.ImageSize{
+		WidthPx:   width,
+		HeightPx:  height,
+		WidthEMU:  width * emuPerPixel,
+		HeightEMU: height * emuPerPixel,
+	})
+}
+
+// maxLocalImageSize is the largest image file read from disk.
+const maxLocalImageSize = 32 << 20
+
+// localImageFormats are the extensions of image files read from disk.
+var localImageFormats = map[string]domain.ImageFormat{
+	".png": domain.ImageFormatPNG, ".jpg": domain.ImageFormatJPEG, ".jpeg": domain.ImageFormatJPEG,
+	".gif": domain.ImageFormatGIF, ".bmp": domain.ImageFormatBMP, ".tif": domain.ImageFormatTIFF,
+	".tiff": domain.ImageFormatTIFF, ".svg": domain.ImageFormatSVG, ".webp": domain.ImageFormatWEBP,
+}
+
+// localImage reads the image file of an img src. ok is false when the
+// image is refused: local files are not allowed, the path resolves
+// outside BaseDir (after symbolic links), it has no image extension or
+// it is not a regular file of at most maxLocalImageSize bytes. Files
+// that are allowed but cannot be read are errors.
+func (c *converter) localImage(src string) (data []byte, format domain.ImageFormat, ok bool, err error) {
+	if !c.opts.AllowLocalFiles {
+		return nil, "", false, nil
+	}
+	base := c.opts.BaseDir
+	if base == "" {
+		base = "."
+	}
+	if base, err = filepath.Abs(base); err == nil {
+		base, err = filepath.EvalSymlinks(base)
+	}
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "htmlimport.localImage")
+	}
+
+	path := strings.TrimPrefix(src, "file://")
+	if unescaped, uerr := url.PathUnescape(path); uerr == nil {
+		path = unescaped
+	}
+	path = filepath.FromSlash(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	path = filepath.Clean(path)
+	if !within(base, path) {
+		return nil, "", false, nil
+	}
+	if format, ok = localImageFormats[strings.ToLower(filepath.Ext(path))]; !ok {
+		return nil, "", false, nil
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "htmlimport.localImage")
+	}
+	if !within(base, resolved) {
+		return nil, "", false, nil
+	}
+
+	file, err := os.Open(resolved)
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "htmlimport.localImage")
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "htmlimport.localImage")
+	}
+	if !info.Mode().IsRegular() || info.Size() > maxLocalImageSize {
+		return nil, "", false, nil
+	}
+	data, err = io.ReadAll(io.LimitReader(file, maxLocalImageSize+1))
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "htmlimport.localImage")
+	}
+	if len(data) > maxLocalImageSize {
+		return nil, "", false, nil
+	}
+	return data, format, true, nil
+}
+
+// within reports whether path is base or inside it. Both are clean
+// absolute paths.
+func within(base, path string) bool {
+	rel, err := filepath.Rel(base, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// decodeDataURI decodes a base64 image data URI.
+func decodeDataURI(src string) ([]byte, domain.ImageFormat, bool) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(src, "data:"), ",")
+	if !ok || !strings.HasSuffix(header, ";base64") || !strings.HasPrefix(header, "image/") {
+		return nil, "", false
+	}
+	subtype := strings.TrimSuffix(strings.TrimPrefix(header, "image/"), ";base64")
+	format := domain.ImageFormat(strings.TrimSuffix(subtype, "+xml"))
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(payload), ""))
+	if err != nil {
+		return nil, "", false
+	}
+	return data, format, true
+}
+
+// truncate shortens s for error messages.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,269 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"math"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/writer"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Default chart size used by Word: 6 x 3.5 inches.
+const (
+	defaultChartWidthEMU  = 5486400
+	defaultChartHeightEMU = 3200400
+)
+
+// defaultChartColors is the Office accent palette used when neither the
+// chart nor the document sets colors.
+var defaultChartColors = []domain.Color{
+	{R: 0x44, G: 0x72, B: 0xC4},
+	{R: 0xED, G: 0x7D, B: 0x31},
+	{R: 0xA5, G: 0xA5, B: 0xA5},
+	{R: 0xFF, G: 0xC0, B: 0x00},
+	{R: 0x5B, G: 0x9B, B: 0xD5},
+	{R: 0x70, G: 0xAD, B: 0x47},
+}
+
+// docxChart implements the domain.Chart interface.
+type docxChart struct {
+	id             string
+	spec           domain.ChartSpec
+	size           domain.ImageSize
+	position       domain.ImagePosition
+	description    string
+	relationshipID string
+	target         string
+	parts          []*writer.Part // Parts read from a document, kept until the spec changes
+	workbook       string         // Embedded workbook read with the chart, relative to word/
+}
+
+// newChart creates a chart stored in the part charts/<id>.xml.
+func newChart(id string, spec domain.ChartSpec) (*docxChart, error) {
+	chart := &docxChart{
+		id:       id,
+		position: domain.DefaultImagePosition(),
+		target:   "charts/" + id + ".xml",
+		size: domain.ImageSize{
+			WidthPx:   defaultChartWidthEMU / 9525,
+			HeightPx:  defaultChartHeightEMU / 9525,
+			WidthEMU:  defaultChartWidthEMU,
+			HeightEMU: defaultChartHeightEMU,
+		},
+	}
+	if err := chart.SetSpec(spec); err != nil {
+		return nil, err
+	}
+	return chart, nil
+}
+
+// NewChartFromPackage restores a chart read from the part at target,
+// relative to word/. parts holds the chart part and the parts it links to,
+// such as its workbook and styles; they are written back unchanged until
+// SetSpec replaces the chart. spec is nil for charts the model cannot
+// describe, such as 3-D or combined charts, whose Spec is then empty.
+func NewChartFromPackage(target string, spec *domain.ChartSpec, parts []*writer.Part) (domain.Chart, error) {
+	chart := &docxChart{
+		id:       strings.TrimSuffix(path.Base(target), path.Ext(target)),
+		position: domain.DefaultImagePosition(),
+		target:   target,
+		size: domain.ImageSize{
+			WidthPx:   defaultChartWidthEMU / 9525,
+			HeightPx:  defaultChartHeightEMU / 9525,
+			WidthEMU:  defaultChartWidthEMU,
+			HeightEMU: defaultChartHeightEMU,
+		},
+		parts: parts,
+	}
+	if spec != nil {
+		if err := validateChartSpec(*spec); err != nil {
+			return nil, err
+		}
+		chart.spec = cloneChartSpec(*spec)
+	}
+	for _, part := range parts {
+		if part.ContentType == constants.ContentTypeSpreadsheet {
+			chart.workbook = strings.TrimPrefix(part.Name, "word/")
+		}
+	}
+	return chart, nil
+}
+
+// ID returns the unique chart ID.
+func (c *docxChart) ID() string {
+	return c.id
+}
+
+// Spec returns a copy of the chart definition.
+func (c *docxChart) Spec() domain.ChartSpec {
+	return cloneChartSpec(c.spec)
+}
+
+// SetSpec validates and replaces the chart definition.
+func (c *docxChart) SetSpec(spec domain.ChartSpec) error {
+	if err := validateChartSpec(spec); err != nil {
+		return err
+	}
+	c.spec = cloneChartSpec(spec)
+	c.parts = nil
+	return nil
+}
+
+// Size returns the chart dimensions.
+func (c *docxChart) Size() domain.ImageSize {
+	return c.size
+}
+
+// SetSize sets the chart dimensions. Sizes given only in pixels are
+// converted to EMUs at 96 DPI.
+func (c *docxChart) SetSize(size domain.ImageSize) error {
+	if size.WidthEMU == 0 && size.HeightEMU == 0 {
+		size.WidthEMU, size.HeightEMU = size.WidthPx*9525, size.HeightPx*9525
+	}
+	if size.WidthEMU <= 0 || size.HeightEMU <= 0 {
+		return errors.InvalidArgument("Chart.SetSize", "size", size, "width and height must be positive")
+	}
+	if size.WidthPx == 0 && size.HeightPx == 0 {
+		size.WidthPx = int(math.Round(float64(size.WidthEMU) / 9525))
+		size.HeightPx = int(math.Round(float64(size.HeightEMU) / 9525))
+	}
+	c.size = size
+	return nil
+}
+
+// Position returns the chart position settings.
+func (c *docxChart) Position() domain.ImagePosition {
+	return c.position
+}
+
+// SetPosition places the chart inline or floating.
+func (c *docxChart) SetPosition(pos domain.ImagePosition) error {
+	if pos.Type == "" {
+		pos.Type = domain.ImagePositionInline
+	}
+	if pos.Type != domain.ImagePositionInline && pos.Type != domain.ImagePositionFloating {
+		return errors.InvalidArgument("Chart.SetPosition", "pos.Type", pos.Type, "unsupported position type")
+	}
+	if pos.WrapText == "" {
+		pos.WrapText = domain.WrapNone
+	}
+	c.position = pos
+	return nil
+}
+
+// Description returns the alt text description.
+func (c *docxChart) Description() string {
+	return c.description
+}
+
+// SetDescription sets the alt text description.
+func (c *docxChart) SetDescription(desc string) error {
+	c.description = desc
+	return nil
+}
+
+// RelationshipID returns the relationship ID of the chart part.
+func (c *docxChart) RelationshipID() string {
+	return c.relationshipID
+}
+
+// Target returns the chart part path relative to word/.
+func (c *docxChart) Target() string {
+	return c.target
+}
+
+// validateChartSpec checks that the series fit the chart type.
+func validateChartSpec(spec domain.ChartSpec) error {
+	const op = "Chart.SetSpec"
+	switch spec.Type {
+	case domain.ChartColumn, domain.ChartBar, domain.ChartLine, domain.ChartPie, domain.ChartScatter:
+	default:
+		return errors.InvalidArgument(op, "spec.Type", spec.Type, "unsupported chart type")
+	}
+	switch spec.Legend {
+	case domain.LegendRight, domain.LegendTop, domain.LegendBottom, domain.LegendLeft, domain.LegendNone:
+	default:
+		return errors.InvalidArgument(op, "spec.Legend", spec.Legend, "unsupported legend position")
+	}
+	if len(spec.Series) == 0 {
+		return errors.InvalidArgument(op, "spec.Series", spec.Series, "at least one series is required")
+	}
+	if spec.Type == domain.ChartPie && len(spec.Series) != 1 {
+		return errors.InvalidArgument(op, "spec.Series", len(spec.Series), "pie charts take exactly one series")
+	}
+	if spec.Type != domain.ChartScatter && len(spec.Categories) == 0 {
+		return errors.InvalidArgument(op, "spec.Categories", spec.Categories, "categories are required")
+	}
+	for _, series := range spec.Series {
+		if !allFinite(series.Values) || !allFinite(series.XValues) {
+			return errors.InvalidArgument(op, "spec.Series", series.Name, "values must be finite numbers")
+		}
+		if spec.Type == domain.ChartScatter {
+			if len(series.Values) == 0 || len(series.XValues) != len(series.Values) {
+				return errors.InvalidArgument(op, "spec.Series", series.Name, "scatter series need matching X and Y values")
+			}
+			continue
+		}
+		if len(series.Values) != len(spec.Categories) {
+			return errors.InvalidArgument(op, "spec.Series", series.Name, "series must have one value per category")
+		}
+	}
+	for _, axis := range []domain.ChartAxis{spec.XAxis, spec.YAxis} {
+		if !allFinite([]float64{axis.Min, axis.Max}) {
+			return errors.InvalidArgument(op, "axis.Min", axis.Min, "axis bounds must be finite numbers")
+		}
+		if axis.Max < axis.Min {
+			return errors.InvalidArgument(op, "axis.Max", axis.Max, "axis maximum cannot be below its minimum")
+		}
+	}
+	return nil
+}
+
+// allFinite reports whether no value is NaN or infinite.
+func allFinite(values []float64) bool {
+	for _, v := range values {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func cloneChartSpec(spec domain.ChartSpec) domain.ChartSpec {
+	spec.Categories = slices.Clone(spec.Categories)
+	spec.Colors = slices.Clone(spec.Colors)
+	spec.Series = slices.Clone(spec.Series)
+	for i := range spec.Series {
+		spec.Series[i].Values = slices.Clone(spec.Series[i].Values)
+		spec.Series[i].XValues = slices.Clone(spec.Series[i].XValues)
+	}
+	return spec
+}
@@ -0,0 +1,184 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"math"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/themes"
+)
+
+func salesChart() domain.ChartSpec {
+	return domain.ChartSpec{
+		Type:       domain.ChartColumn,
+		Title:      "Quarterly Sales",
+		Categories: []string{"Q1", "Q2", "Q3"},
+		Series: []domain.ChartSeries{
+			{Name: "2024", Values: []float64{10, 12.5, 9}},
+			{Name: "2025", Values: []float64{11, 14, 15}},
+		},
+		YAxis: domain.ChartAxis{Gridlines: true, NumberFormat: "#,##0"},
+	}
+}
+
+func writtenParts(t *testing.T, doc domain.Document) map[string][]byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	return zipParts(t, buf.Bytes())
+}
+
+func zipParts(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	parts := make(map[string][]byte)
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		parts[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	return parts
+}
+
+func TestChartValidation(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+
+	tests := map[string]func(*domain.ChartSpec){
+		"unknown type":      func(s *domain.ChartSpec) { s.Type = "radar" },
+		"no series":         func(s *domain.ChartSpec) { s.Series = nil },
+		"no categories":     func(s *domain.ChartSpec) { s.Categories = nil },
+		"short series":      func(s *domain.ChartSpec) { s.Series[0].Values = []float64{1} },
+		"two pie series":    func(s *domain.ChartSpec) { s.Type = domain.ChartPie },
+		"scatter without X": func(s *domain.ChartSpec) { s.Type = domain.ChartScatter },
+		"inverted axis":     func(s *domain.ChartSpec) { s.YAxis.Min, s.YAxis.Max = 10, 5 },
+		"unknown legend":    func(s *domain.ChartSpec) { s.Legend = "center" },
+		"NaN value":         func(s *domain.ChartSpec) { s.Series[0].Values[1] = math.NaN() },
+		"infinite value":    func(s *domain.ChartSpec) { s.Series[1].Values[0] = math.Inf(-1) },
+		"infinite axis":     func(s *domain.ChartSpec) { s.YAxis.Max = math.Inf(1) },
+		"NaN axis":          func(s *domain.ChartSpec) { s.XAxis.Min = math.NaN() },
+	}
+	for name, mutate := range tests {
+		spec := salesChart()
+		mutate(&spec)
+		if _, err := para.AddChart(spec, domain.ImageSize{}, domain.ImagePosition{}); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if len(para.Runs()) != 0 {
+		t.Errorf("failed charts should not add runs")
+	}
+
+	spec := salesChart()
+	chart, err := para.AddChart(spec, domain.ImageSize{}, domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddChart() error = %v", err)
+	}
+	spec.Series[0].Values[0] = 99
+	if chart.Spec().Series[0].Values[0] != 10 {
+		t.Error("chart should keep its own copy of the data")
+	}
+	if size := chart.Size(); size.WidthEMU != defaultChartWidthEMU || size.HeightEMU != defaultChartHeightEMU {
+		t.Errorf("default size = %+v", size)
+	}
+	if len(para.Charts()) != 1 || chart.RelationshipID() == "" {
+		t.Errorf("chart should be registered with the paragraph and a relationship")
+	}
+}
+
+func TestChartParts(t *testing.T) {
+	doc := NewDocument()
+	if err := themes.Corporate.ApplyTo(doc); err != nil {
+		t.Fatalf("ApplyTo() error = %v", err)
+	}
+	para, _ := doc.AddParagraph()
+	chart, err := para.AddChart(salesChart(), domain.ImageSize{}, domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddChart() error = %v", err)
+	}
+	pie := domain.ChartSpec{
+		Type:       domain.ChartPie,
+		Categories: []string{"A", "B"},
+		Series:     []domain.ChartSeries{{Values: []float64{1, 3}}},
+		Colors:     []domain.Color{domain.ColorRed, domain.ColorGreen},
+	}
+	if _, err := para.AddChart(pie, domain.NewImageSize(300, 300), domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddChart(pie) error = %v", err)
+	}
+
+	parts := writtenParts(t, doc)
+	chartXML := string(parts["word/"+chart.Target()])
+	for _, want := range []string{"<c:barChart>", `<c:v>Q2</c:v>`, `<c:v>12.5</c:v>`, "Sheet1!$B$2:$B$4", `val="2F5496"`, `val="4F81BD"`, "<c:majorGridlines>", `formatCode="#,##0"`, "Quarterly Sales", `<c:externalData r:id="rId1">`} {
+		if !strings.Contains(chartXML, want) {
+			t.Errorf("chart part missing %q", want)
+		}
+	}
+
+	rels := string(parts["word/charts/_rels/"+path.Base(chart.Target())+".rels"])
+	start := strings.Index(rels, `Target="../`)
+	if start < 0 {
+		t.Fatalf("chart relationships missing workbook: %s", rels)
+	}
+	target := rels[start+len(`Target="../`):]
+	workbook := parts["word/"+target[:strings.Index(target, `"`)]]
+	if workbook == nil {
+		t.Fatal("embedded workbook not written")
+	}
+	sheet := string(zipParts(t, workbook)["xl/worksheets/sheet1.xml"])
+	for _, want := range []string{`<c r="B1" t="inlineStr"><is><t>2024</t></is></c>`, `<c r="A3" t="inlineStr"><is><t>Q2</t></is></c>`, `<c r="C4"><v>15</v></c>`} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("sheet missing %q in %s", want, sheet)
+		}
+	}
+
+	contentTypes := string(parts["[Content_Types].xml"])
+	if strings.Count(contentTypes, "drawingml.chart+xml") != 2 || strings.Count(contentTypes, "spreadsheetml.sheet\"") != 2 {
+		t.Errorf("content types missing chart overrides: %s", contentTypes)
+	}
+	if !strings.Contains(string(parts["word/_rels/document.xml.rels"]), "relationships/chart") {
+		t.Error("document relationships missing chart")
+	}
+	pieFound := false
+	for name, data := range parts {
+		if strings.HasPrefix(name, "word/charts/chart") && strings.Contains(string(data), "<c:pieChart>") {
+			pieFound = strings.Contains(string(data), `val="FF0000"`) && strings.Count(string(data), "<c:dPt>") == 2
+		}
+	}
+	if !pieFound {
+		t.Error("pie chart should color each slice with the chart colors")
+	}
+}
@@ -133,6 +133,33 @@ func TestDocument_AddSectionWithBreak(t *testing.T) {
 	}
 }
 
+func TestDocument_MoveBlocks(t *testing.T) {
+	doc := core.NewDocument()
+	first, _ := doc.AddParagraph()
+	second, _ := doc.AddParagraph()
+	table, _ := doc.AddTable(1, 1)
+	last, _ := doc.AddParagraph()
+
+	// Move the table and the last paragraph between the first two.
+	if err := doc.MoveBlocks(2, 4, 1); err != nil {
+		t.Fatalf("MoveBlocks failed: %v", err)
+	}
+	blocks := doc.Blocks()
+	if blocks[0].Paragraph != first || blocks[1].Table != table || blocks[2].Paragraph != last || blocks[3].Paragraph != second {
+		t.Errorf("unexpected block order after MoveBlocks")
+	}
+	paras := doc.Paragraphs()
+	if len(paras) != 3 || paras[0] != first || paras[1] != last || paras[2] != second {
+		t.Errorf("paragraphs not kept in document order")
+	}
+
+	for _, args := range [][3]int{{-1, 1, 0}, {2, 1, 0}, {0, 5, 0}, {0, 2, 3}} {
+		if err := doc.MoveBlocks(args[0], args[1], args[2]); err == nil {
+			t.Errorf("MoveBlocks%v should fail", args)
+		}
+	}
+}
+
 func TestDocument_AddTable_InvalidDimensions(t *testing.T) {
 	doc := core.NewDocument()
 
@@ -40,15 +40,18 @@ SOFTWARE.
 package core
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
 	"github.com/mmonterroca/docxgo/v2/internal/manager"
 	"github.com/mmonterroca/docxgo/v2/internal/serializer"
 	"github.com/mmonterroca/docxgo/v2/internal/writer"
+	xmlstructs "github.com/mmonterroca/docxgo/v2/internal/xml"
 	"github.com/mmonterroca/docxgo/v2/pkg/constants"
 	"github.com/mmonterroca/docxgo/v2/pkg/errors"
 )
@@ -69,7 +72,12 @@ type document struct {
 	activeSection   *docxSection
 	numberingPart   []byte
 	numberingTarget string
+	lists           []domain.ListDefinition
 	backgroundColor *domain.Color
+	compression     domain.ImageCompression
+	chartColors     []domain.Color
+	theme           *domain.DocumentTheme
+	thumbnail       []byte
 }
 
 // NewDocument creates a new Document.
@@ -147,10 +155,183 @@ func (d *document) AddTable(rows, cols int) (domain.Table, error) {
 	}
 
 	id := d.idGen.NextTableID()
-	table := NewTable(id, rows, cols, d.idGen, d.relManager, d.mediaManager)
-	d.tables = append(d.tables, table)
-	d.blocks = append(d.blocks, domain.Block{Table: table})
-	return table, nil
+	tbl := NewTable(id, rows, cols, d.idGen, d.relManager, d.mediaManager)
+	if coreTable, ok := tbl.(*table); ok {
+		coreTable.doc = d
+	}
+	d.tables = append(d.tables, tbl)
+	d.blocks = append(d.blocks, domain.Block{Table: tbl})
+	return tbl, nil
+}
+
+// insertTableCaption creates a Caption-styled paragraph directly above or below tbl.
+func (d *document) insertTableCaption(tbl *table, label, text string, position domain.CaptionPosition) (domain.Paragraph, error) {
+	const op = "Document.insertTableCaption"
+
+	blockIndex := d.tableBlockIndex(tbl)
+	if blockIndex == -1 {
+		return nil, errors.InvalidState(op, "table not found in document body")
+	}
+
+	insertAt := blockIndex
+	if position == domain.CaptionBelow {
+		insertAt = blockIndex + 1
+	}
+
+	para := NewParagraph(d.idGen.NextParagraphID(), d.idGen, d.relManager, d.mediaManager)
+	if err := para.SetStyle(domain.StyleIDCaption); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	labelRun, err := para.AddRun()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if err := labelRun.SetText(label + " "); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	seq := NewField(domain.FieldTypeSeq)
+	if err := seq.SetCode(fmt.Sprintf(`%s %s \* ARABIC`, constants.FieldCodeSeq, label)); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	seqRun, err := para.AddRun()
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if err := seqRun.AddField(seq); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	if text != "" {
+		textRun, err := para.AddRun()
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		if err := textRun.SetText(": " + text); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	d.insertBlocks(insertAt, domain.Block{Paragraph: para})
+	d.numberSequence(label)
+
+	return para, nil
+}
+
+// numberSequence sets the results of the body's SEQ fields for label in
+// document order, so captions are numbered by position rather than by
+// the order they were inserted in.
+func (d *document) numberSequence(label string) {
+	count := 0
+	d.walkParagraphs(func(para domain.Paragraph, _ domain.Table, part domain.ImagePart, _ domain.Section) {
+		if part != domain.ImagePartBody {
+			return
+		}
+		for _, r := range para.Runs() {
+			withFields, ok := r.(interface{ Fields() []domain.Field })
+			if !ok {
+				continue
+			}
+			for _, field := range withFields.Fields() {
+				args := strings.Fields(field.Code())
+				if field.Type() != domain.FieldTypeSeq || len(args) < 2 || !strings.EqualFold(args[1], label) {
+					continue
+				}
+				// \r resets the sequence and \c repeats the last number.
+				repeat := false
+				for i, arg := range args[2:] {
+					switch {
+					case strings.EqualFold(arg, `\c`):
+						repeat = true
+					case strings.EqualFold(arg, `\r`) && i+3 < len(args):
+						if n, err := strconv.Atoi(args[i+3]); err == nil {
+							count, repeat = n, true
+						}
+					}
+				}
+				if !repeat {
+					count++
+				}
+				if withResult, ok := field.(interface{ SetResult(string) }); ok {
+					withResult.SetResult(strconv.Itoa(count))
+				}
+			}
+		}
+	})
+}
+
+// tableBlockIndex returns the index of tbl in the body blocks, or -1.
+func (d *document) tableBlockIndex(tbl *table) int {
+	for idx, block := range d.blocks {
+		if t, ok := block.Table.(*table); ok && t == tbl {
+			return idx
+		}
+	}
+	return -1
+}
+
+// insertBlocks inserts blocks at position at, keeping the flat paragraph and
+// table lists in document order.
+func (d *document) insertBlocks(at int, blocks ...domain.Block) {
+	paraIndex, tableIndex := d.countBlocks(at)
+	for _, block := range blocks {
+		switch {
+		case block.Paragraph != nil:
+			d.paragraphs = append(d.paragraphs[:paraIndex], append([]domain.Paragraph{block.Paragraph}, d.paragraphs[paraIndex:]...)...)
+			paraIndex++
+		case block.Table != nil:
+			d.tables = append(d.tables[:tableIndex], append([]domain.Table{block.Table}, d.tables[tableIndex:]...)...)
+			tableIndex++
+		}
+	}
+	d.blocks = append(d.blocks[:at], append(blocks, d.blocks[at:]...)...)
+}
+
+// detachTable removes tbl from the body. When tbl directly follows previous,
+// separated only by an empty paragraph, that paragraph is removed as well;
+// one holding a bookmark, field or other content, or ending a section, is
+// kept.
+func (d *document) detachTable(tbl, previous *table) {
+	at := d.tableBlockIndex(tbl)
+	if at == -1 {
+		return
+	}
+	d.removeBlock(at)
+
+	if at >= 2 && d.tableBlockIndex(previous) == at-2 {
+		block := d.blocks[at-1]
+		if para, ok := block.Paragraph.(*paragraph); ok && block.SectionBreak == nil && para.isSeparator() {
+			d.removeBlock(at - 1)
+		}
+	}
+}
+
+// removeBlock removes the block at position at from the body.
+func (d *document) removeBlock(at int) {
+	paraIndex, tableIndex := d.countBlocks(at)
+	switch block := d.blocks[at]; {
+	case block.Paragraph != nil:
+		d.paragraphs = append(d.paragraphs[:paraIndex], d.paragraphs[paraIndex+1:]...)
+	case block.Table != nil:
+		d.tables = append(d.tables[:tableIndex], d.tables[tableIndex+1:]...)
+	}
+	d.blocks = append(d.blocks[:at], d.blocks[at+1:]...)
+}
+
+// countBlocks returns how many paragraphs and tables precede block position at.
+func (d *document) countBlocks(at int) (int, int) {
+	paragraphs, tables := 0, 0
+	for _, block := range d.blocks[:at] {
+		switch {
+		case block.Paragraph != nil:
+			paragraphs++
+		case block.Table != nil:
+			tables++
+		}
+	}
+	return paragraphs, tables
 }
 
 // AddSection adds a new section to the document using a next-page break.
@@ -246,13 +427,35 @@ func (d *document) Blocks() []domain.Block {
 	return blocks
 }
 
+// MoveBlocks moves blocks[start:end] so that they begin at index at.
+func (d *document) MoveBlocks(start, end, at int) error {
+	const op = "Document.MoveBlocks"
+	if start < 0 || start > end || end > len(d.blocks) {
+		return errors.InvalidArgument(op, "range", [2]int{start, end}, "block range out of bounds")
+	}
+	if at < 0 || at > len(d.blocks)-(end-start) {
+		return errors.InvalidArgument(op, "at", at, "position out of bounds")
+	}
+	moved := make([]domain.Block, end-start)
+	copy(moved, d.blocks[start:end])
+	for i := end - 1; i >= start; i-- {
+		d.removeBlock(i)
+	}
+	d.insertBlocks(at, moved...)
+	return nil
+}
+
 // generateHeadingBookmarks generates bookmarks for all headings in the document.
 // This is required for Table of Contents (TOC) fields to work properly.
 // Bookmarks are named _Toc{sequential_number} and only applied to paragraphs with Heading styles.
 func (d *document) generateHeadingBookmarks() {
 	bookmarkCounter := 0
 
-	for _, para := range d.paragraphs {
+	// Headings in tables are listed by TOCs too
+	d.walkParagraphs(func(para domain.Paragraph, _ domain.Table, part domain.ImagePart, _ domain.Section) {
+		if part != domain.ImagePartBody {
+			return
+		}
 		// Type assert to access internal paragraph methods
 		if p, ok := para.(*paragraph); ok {
 			styleName := p.StyleName()
@@ -265,7 +468,7 @@ func (d *document) generateHeadingBookmarks() {
 				bookmarkCounter++
 			}
 		}
-	}
+	})
 }
 
 // prepareHeaderFooterRelationships ensures that every header/footer defined in the
@@ -321,6 +524,44 @@ func (d *document) prepareHeaderFooterRelationships() {
 	}
 }
 
+// addHeaderFooterRels writes the relationships of each header and footer
+// part, such as its images and hyperlinks, to the part's own .rels part.
+func (d *document) addHeaderFooterRels(zw *writer.ZipWriter) error {
+	add := func(target string, rels *manager.RelationshipManager) error {
+		if target == "" || rels == nil || rels.Count() == 0 {
+			return nil
+		}
+		data, err := marshalPart(rels.ToXML())
+		if err != nil {
+			return errors.Wrap(err, "Document.WriteTo")
+		}
+		zw.AddPart("word/_rels/"+target+".rels", "", data)
+		return nil
+	}
+
+	for _, sec := range d.sections {
+		coreSection, ok := sec.(*docxSection)
+		if !ok {
+			continue
+		}
+		for _, header := range coreSection.HeadersAll() {
+			if h, ok := header.(*docxHeader); ok {
+				if err := add(h.TargetPath(), h.relationMgr); err != nil {
+					return err
+				}
+			}
+		}
+		for _, footer := range coreSection.FootersAll() {
+			if f, ok := footer.(*docxFooter); ok {
+				if err := add(f.TargetPath(), f.relationMgr); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
 // ensureDefaultRelationships guarantees that the DOCX package contains the
 // required relationships for styles, fonts, and theme assets. Without these
 // entries Word falls back to implicit defaults and style assignments appear as
@@ -376,14 +617,21 @@ func (d *document) WriteTo(w io.Writer) (int64, error) {
 
 	// Ensure required base relationships are present before serialization
 	d.ensureDefaultRelationships()
+	d.pruneChartRelationships()
+	hasFootnotes := d.prepareFootnotesRelationship()
 
 	// Serialize domain objects to XML structures
 	ser := serializer.NewDocumentSerializer()
+	ser.SetTheme(d.Theme())
 	xmlDoc := ser.SerializeDocument(d)
 	headers, footers := ser.SerializeSectionParts(d)
 
 	// Create ZIP writer
 	zipWriter := writer.NewZipWriter(w)
+	zipWriter.SetTheme(xmlstructs.NewTheme(d.Theme()))
+	if d.thumbnail != nil {
+		zipWriter.SetThumbnail(d.thumbnail)
+	}
 	defer func() {
 		if err := zipWriter.Close(); err != nil {
 			// Log error but don't override return value as document may have been partially written
@@ -402,16 +650,29 @@ func (d *document) WriteTo(w io.Writer) (int64, error) {
 	styles := ser.SerializeStyles(d.styleManager)
 
 	mediaFiles := d.mediaManager.All()
+	if d.compression != (domain.ImageCompression{}) {
+		mediaFiles = compressMedia(mediaFiles, d.Images(), d.compression)
+	}
 
-	// Write document structure
-	var numberingPart *writer.NumberingPart
-	if len(d.numberingPart) > 0 {
-		numberingPart = &writer.NumberingPart{
-			Data:   d.numberingPart,
-			Target: d.numberingTarget,
+	if err := d.addChartParts(zipWriter); err != nil {
+		return 0, err
+	}
+	d.addEmbeddedParts(zipWriter)
+	if err := d.addHeaderFooterRels(zipWriter); err != nil {
+		return 0, err
+	}
+	if hasFootnotes {
+		if err := d.addFootnotesPart(zipWriter, ser); err != nil {
+			return 0, err
 		}
 	}
 
+	// Write document structure
+	numberingPart, err := d.numberingPartForWrite()
+	if err != nil {
+		return 0, err
+	}
+
 	if err := zipWriter.WriteDocument(xmlDoc, rels, coreProps, appProps, styles, mediaFiles, headers, footers, numberingPart); err != nil {
 		return 0, errors.WrapWithCode(err, errors.ErrCodeIO, "Document.WriteTo")
 	}
@@ -506,6 +767,30 @@ func (d *document) BackgroundColor() (domain.Color, bool) {
 	return *d.backgroundColor, true
 }
 
+// SetThumbnail sets the JPEG preview written to docProps/thumbnail.jpeg.
+func (d *document) SetThumbnail(jpeg []byte) error {
+	if d == nil {
+		return errors.InvalidState("Document.SetThumbnail", "document is nil")
+	}
+	if jpeg == nil {
+		d.thumbnail = nil
+		return nil
+	}
+	if !bytes.HasPrefix(jpeg, []byte{0xFF, 0xD8, 0xFF}) {
+		return errors.InvalidArgument("Document.SetThumbnail", "jpeg", len(jpeg), "thumbnail must be JPEG data")
+	}
+	d.thumbnail = append([]byte(nil), jpeg...)
+	return nil
+}
+
+// Thumbnail returns the JPEG preview of the document, or nil.
+func (d *document) Thumbnail() []byte {
+	if d == nil {
+		return nil
+	}
+	return d.thumbnail
+}
+
 // StyleManager returns the style manager for this document.
 func (d *document) StyleManager() domain.StyleManager {
 	return d.styleManager
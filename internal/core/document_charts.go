@@ -0,0 +1,167 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"encoding/xml"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/writer"
+	xmlstructs "github.com/mmonterroca/docxgo/v2/internal/xml"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// SetChartColors sets the series colors of charts without their own.
+func (d *document) SetChartColors(colors []domain.Color) error {
+	d.chartColors = slices.Clone(colors)
+	return nil
+}
+
+// ChartColors returns the default chart series colors.
+func (d *document) ChartColors() []domain.Color {
+	if len(d.chartColors) == 0 {
+		return slices.Clone(defaultChartColors)
+	}
+	return slices.Clone(d.chartColors)
+}
+
+// pruneChartRelationships drops document relationships to chart parts no
+// chart in the document writes, such as those of charts read from a file
+// that could not be restored.
+func (d *document) pruneChartRelationships() {
+	written := make(map[string]bool)
+	d.walkParagraphs(func(para domain.Paragraph, _ domain.Table, _ domain.ImagePart, _ domain.Section) {
+		for _, chart := range para.Charts() {
+			written[chart.Target()] = true
+		}
+	})
+	for _, rel := range d.relManager.All() {
+		target := strings.TrimPrefix(strings.TrimPrefix(rel.Target, "/"), "word/")
+		if rel.Type == constants.RelTypeChart && !written[target] {
+			_ = d.relManager.Delete(rel.ID)
+		}
+	}
+}
+
+// addChartParts queues the part, relationships and embedded workbook of
+// every chart in the document. Charts read from a file keep their parts
+// unchanged until their spec is replaced.
+func (d *document) addChartParts(zw *writer.ZipWriter) error {
+	var charts []*docxChart
+	d.walkParagraphs(func(para domain.Paragraph, _ domain.Table, _ domain.ImagePart, _ domain.Section) {
+		for _, chart := range para.Charts() {
+			if c, ok := chart.(*docxChart); ok {
+				charts = append(charts, c)
+			}
+		}
+	})
+
+	// Workbooks of new or changed charts must not overwrite kept parts.
+	taken := make(map[string]bool)
+	for _, chart := range charts {
+		for _, part := range chart.parts {
+			// Pictures are written with the media.
+			if media, err := d.mediaManager.GetByPath(part.Name); taken[part.Name] || (err == nil && media != nil) {
+				continue
+			}
+			zw.AddPart(part.Name, part.ContentType, part.Data)
+			taken[part.Name] = true
+		}
+	}
+
+	for _, chart := range charts {
+		if len(chart.parts) > 0 {
+			continue
+		}
+		spec := chart.Spec()
+		colors := spec.Colors
+		if len(colors) == 0 {
+			colors = d.ChartColors()
+		}
+
+		name := path.Base(chart.Target())
+		workbook := chart.workbook
+		if workbook == "" {
+			number := strings.TrimPrefix(strings.TrimSuffix(name, ".xml"), "chart")
+			workbook = "embeddings/Microsoft_Excel_Worksheet" + number + ".xlsx"
+			for i := 1; taken["word/"+workbook]; i++ {
+				workbook = fmt.Sprintf("embeddings/Microsoft_Excel_Worksheet%s_%d.xlsx", number, i)
+			}
+		}
+		taken["word/"+workbook] = true
+
+		data, err := writer.Workbook(xmlstructs.ChartSheetRows(spec))
+		if err != nil {
+			return errors.Wrap(err, "Document.WriteTo")
+		}
+		chartXML, err := marshalPart(xmlstructs.NewChartSpace(spec, colors, "rId1"))
+		if err != nil {
+			return errors.Wrap(err, "Document.WriteTo")
+		}
+		dir := path.Dir("word/" + chart.Target())
+		relsXML, err := marshalPart(&xmlstructs.Relationships{
+			Xmlns: constants.NamespacePackageRels,
+			Relationships: []*xmlstructs.Relationship{
+				{ID: "rId1", Type: constants.RelTypePackage, Target: relativePartPath(dir, "word/"+workbook)},
+			},
+		})
+		if err != nil {
+			return errors.Wrap(err, "Document.WriteTo")
+		}
+
+		zw.AddPart(dir+"/"+name, constants.ContentTypeChart, chartXML)
+		zw.AddPart(dir+"/_rels/"+name+".rels", "", relsXML)
+		zw.AddPart("word/"+workbook, constants.ContentTypeSpreadsheet, data)
+	}
+	return nil
+}
+
+// relativePartPath returns the path of the part name as seen from the
+// package folder dir.
+func relativePartPath(dir, name string) string {
+	prefix := ""
+	for dir != "." && dir != "/" && !strings.HasPrefix(name, dir+"/") {
+		dir = path.Dir(dir)
+		prefix += "../"
+	}
+	if dir == "." || dir == "/" {
+		return prefix + name
+	}
+	return prefix + strings.TrimPrefix(name, dir+"/")
+}
+
+// marshalPart encodes v as a standalone XML part.
+func marshalPart(v interface{}) ([]byte, error) {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
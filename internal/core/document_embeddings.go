@@ -0,0 +1,119 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/writer"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// altChunkParts gives the part extension and content type of each
+// alternative format chunk.
+var altChunkParts = map[domain.AltChunkFormat]struct{ ext, contentType string }{
+	domain.AltChunkHTML: {".html", constants.ContentTypeHTML},
+	domain.AltChunkMHT:  {".mht", "message/rfc822"},
+	domain.AltChunkRTF:  {".rtf", constants.ContentTypeRTF},
+	domain.AltChunkDOCX: {".docx", constants.ContentTypeDocument},
+	domain.AltChunkText: {".txt", constants.ContentTypeText},
+}
+
+// EmbeddedObjects returns every embedded object in document order.
+func (d *document) EmbeddedObjects() []domain.EmbeddedObject {
+	var objects []domain.EmbeddedObject
+	d.walkParagraphs(func(para domain.Paragraph, _ domain.Table, _ domain.ImagePart, _ domain.Section) {
+		objects = append(objects, para.EmbeddedObjects()...)
+	})
+	return objects
+}
+
+// InsertAltChunk appends content that Word imports when it opens the file.
+func (d *document) InsertAltChunk(data []byte) error {
+	const op = "Document.InsertAltChunk"
+	if len(bytes.TrimSpace(data)) == 0 {
+		return errors.InvalidArgument(op, "data", len(data), "data cannot be empty")
+	}
+
+	format := detectAltChunkFormat(data)
+	target := d.idGen.GenerateID("altChunk") + altChunkParts[format].ext
+	for rel, _ := d.relManager.GetByTarget(target); rel != nil; rel, _ = d.relManager.GetByTarget(target) {
+		target = d.idGen.GenerateID("altChunk") + altChunkParts[format].ext
+	}
+	relID, err := d.relManager.Add(constants.RelTypeAltChunk, target, "Internal")
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	d.InsertHydratedAltChunk(domain.AltChunk{
+		Format:         format,
+		Data:           bytes.Clone(data),
+		RelationshipID: relID,
+		Target:         target,
+	})
+	return nil
+}
+
+// InsertHydratedAltChunk appends a chunk read from a document. Its
+// relationship must already be registered.
+func (d *document) InsertHydratedAltChunk(chunk domain.AltChunk) {
+	d.blocks = append(d.blocks, domain.Block{AltChunk: &chunk})
+}
+
+// detectAltChunkFormat identifies RTF, DOCX, MHT and HTML content;
+// anything else is imported as plain text.
+func detectAltChunkFormat(data []byte) domain.AltChunkFormat {
+	trimmed := bytes.TrimLeft(bytes.TrimPrefix(data, []byte("\xEF\xBB\xBF")), " \t\r\n")
+	switch {
+	case bytes.HasPrefix(trimmed, []byte(`{\rtf`)):
+		return domain.AltChunkRTF
+	case bytes.HasPrefix(data, []byte("PK\x03\x04")):
+		return domain.AltChunkDOCX
+	case bytes.HasPrefix(bytes.ToUpper(trimmed), []byte("MIME-VERSION:")):
+		return domain.AltChunkMHT
+	case bytes.HasPrefix(trimmed, []byte("<")):
+		return domain.AltChunkHTML
+	default:
+		return domain.AltChunkText
+	}
+}
+
+// addEmbeddedParts queues the parts of embedded objects and alternative
+// format chunks.
+func (d *document) addEmbeddedParts(zw *writer.ZipWriter) {
+	for _, obj := range d.EmbeddedObjects() {
+		if docxObj, ok := obj.(*docxEmbeddedObject); ok {
+			data, contentType := docxObj.partData()
+			zw.AddPart("word/"+docxObj.target, contentType, data)
+		}
+	}
+	for _, block := range d.blocks {
+		if chunk := block.AltChunk; chunk != nil {
+			zw.AddPart("word/"+strings.TrimPrefix(chunk.Target, "/"), altChunkParts[chunk.Format].contentType, chunk.Data)
+		}
+	}
+}
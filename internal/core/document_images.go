@@ -0,0 +1,140 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/manager"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Images returns every image in the body, tables, text boxes, headers and
+// footers.
+func (d *document) Images() []domain.DocumentImage {
+	var images []domain.DocumentImage
+	d.walkParagraphs(func(para domain.Paragraph, tbl domain.Table, part domain.ImagePart, section domain.Section) {
+		for _, img := range para.Images() {
+			images = append(images, domain.DocumentImage{
+				Image:     img,
+				Paragraph: para,
+				Table:     tbl,
+				Part:      part,
+				Section:   section,
+			})
+		}
+	})
+	return images
+}
+
+// walkParagraphs calls fn for every paragraph of the document in order:
+// the body with its tables, text boxes and footnotes, then each section's
+// headers and footers. tbl is the innermost table holding the paragraph, if any.
+func (d *document) walkParagraphs(fn func(para domain.Paragraph, tbl domain.Table, part domain.ImagePart, section domain.Section)) {
+	var addParagraph func(para domain.Paragraph, tbl domain.Table, part domain.ImagePart, section domain.Section)
+	addParagraph = func(para domain.Paragraph, tbl domain.Table, part domain.ImagePart, section domain.Section) {
+		fn(para, tbl, part, section)
+		for _, shape := range para.Shapes() {
+			for _, inner := range shape.Paragraphs() {
+				addParagraph(inner, tbl, part, section)
+			}
+		}
+		for _, note := range para.Footnotes() {
+			for _, inner := range note.Paragraphs() {
+				addParagraph(inner, tbl, part, section)
+			}
+		}
+	}
+
+	var addTable func(tbl domain.Table, part domain.ImagePart, section domain.Section)
+	addTable = func(tbl domain.Table, part domain.ImagePart, section domain.Section) {
+		for _, row := range tbl.Rows() {
+			for _, cell := range row.Cells() {
+				for _, para := range cell.Paragraphs() {
+					addParagraph(para, tbl, part, section)
+				}
+				for _, nested := range cell.Tables() {
+					addTable(nested, part, section)
+				}
+			}
+		}
+	}
+
+	for _, block := range d.blocks {
+		switch {
+		case block.Paragraph != nil:
+			addParagraph(block.Paragraph, nil, domain.ImagePartBody, nil)
+		case block.Table != nil:
+			addTable(block.Table, domain.ImagePartBody, nil)
+		}
+	}
+
+	for _, section := range d.sections {
+		sec, ok := section.(*docxSection)
+		if !ok {
+			continue
+		}
+		headers := sec.HeadersAll()
+		for _, kind := range slices.Sorted(maps.Keys(headers)) {
+			for _, para := range headers[kind].Paragraphs() {
+				addParagraph(para, nil, domain.ImagePartHeader, section)
+			}
+		}
+		footers := sec.FootersAll()
+		for _, kind := range slices.Sorted(maps.Keys(footers)) {
+			for _, para := range footers[kind].Paragraphs() {
+				addParagraph(para, nil, domain.ImagePartFooter, section)
+			}
+		}
+	}
+}
+
+// ExtractMedia writes each media part to dir and returns the file paths.
+func (d *document) ExtractMedia(dir string) ([]string, error) {
+	if dir == "" {
+		return nil, errors.InvalidArgument("Document.ExtractMedia", "dir", dir, "directory cannot be empty")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "Document.ExtractMedia")
+	}
+
+	files := d.mediaManager.All()
+	slices.SortFunc(files, func(a, b *manager.MediaFile) int { return strings.Compare(a.Path, b.Path) })
+
+	paths := make([]string, 0, len(files))
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.Base(filepath.FromSlash(file.Path)))
+		if err := os.WriteFile(path, file.Data, 0o644); err != nil {
+			return paths, errors.WrapWithCode(err, errors.ErrCodeIO, "Document.ExtractMedia")
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
@@ -0,0 +1,223 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"bytes"
+	"image"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+func TestDocumentImagesLocations(t *testing.T) {
+	doc := NewDocument()
+	body, _ := doc.AddParagraph()
+	if _, err := body.AddImageFromBytes(encodeTestPNG(t, 10, 10), "", domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+
+	tbl, _ := doc.AddTable(1, 1)
+	row, _ := tbl.Row(0)
+	cell, _ := row.Cell(0)
+	cellPara, _ := cell.AddParagraph()
+	if _, err := cellPara.AddImageFromBytes(encodeTestPNG(t, 12, 12), "", domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+
+	section, _ := doc.DefaultSection()
+	header, _ := section.Header(domain.HeaderDefault)
+	headerPara, _ := header.AddParagraph()
+	if _, err := headerPara.AddImageFromBytes(encodeTestPNG(t, 14, 14), "", domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+	footer, _ := section.Footer(domain.FooterDefault)
+	footerPara, _ := footer.AddParagraph()
+	if _, err := footerPara.AddImageFromBytes(encodeTestPNG(t, 16, 16), "", domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+
+	images := doc.Images()
+	if len(images) != 4 {
+		t.Fatalf("Images() returned %d images, want 4", len(images))
+	}
+	want := []struct {
+		part  domain.ImagePart
+		para  domain.Paragraph
+		table bool
+		width int
+	}{
+		{domain.ImagePartBody, body, false, 10},
+		{domain.ImagePartBody, cellPara, true, 12},
+		{domain.ImagePartHeader, headerPara, false, 14},
+		{domain.ImagePartFooter, footerPara, false, 16},
+	}
+	for i, w := range want {
+		got := images[i]
+		if got.Part != w.part || got.Paragraph != w.para || (got.Table != nil) != w.table || got.Image.Size().WidthPx != w.width {
+			t.Errorf("image %d = %+v, want %+v", i, got, w)
+		}
+		if (got.Section != nil) != (w.part != domain.ImagePartBody) {
+			t.Errorf("image %d section = %v", i, got.Section)
+		}
+	}
+}
+
+func TestHeaderFooterImageRelationships(t *testing.T) {
+	doc := NewDocument()
+	body, _ := doc.AddParagraph()
+	_, _ = body.AddImageFromBytes(encodeTestPNG(t, 10, 10), "", domain.ImageSize{}, domain.ImagePosition{})
+	section, _ := doc.DefaultSection()
+	header, _ := section.Header(domain.HeaderDefault)
+	headerPara, _ := header.AddParagraph()
+	_, _ = headerPara.AddImageFromBytes(encodeTestPNG(t, 14, 14), "", domain.ImageSize{}, domain.ImagePosition{})
+	footer, _ := section.Footer(domain.FooterDefault)
+	footerPara, _ := footer.AddParagraph()
+	_, _ = footerPara.AddImageFromBytes(encodeTestPNG(t, 16, 16), "", domain.ImageSize{}, domain.ImagePosition{})
+
+	parts := writtenParts(t, doc)
+	embed := regexp.MustCompile(`r:embed="([^"]+)"`)
+	for _, part := range []struct {
+		name  string
+		width int
+	}{
+		{"word/document.xml", 10},
+		{"word/header1.xml", 14},
+		{"word/footer1.xml", 16},
+	} {
+		rels := "word/_rels/" + strings.TrimPrefix(part.name, "word/") + ".rels"
+		match := embed.FindSubmatch(parts[part.name])
+		if match == nil {
+			t.Errorf("%s has no picture", part.name)
+			continue
+		}
+		target := regexp.MustCompile(`Id="` + string(match[1]) + `"[^>]*Target="([^"]+)"`).FindSubmatch(parts[rels])
+		if target == nil {
+			t.Errorf("%s: r:embed %s is not in %s:\n%s", part.name, match[1], rels, parts[rels])
+			continue
+		}
+		img, _, err := image.Decode(bytes.NewReader(parts["word/"+string(target[1])]))
+		if err != nil || img.Bounds().Dx() != part.width {
+			t.Errorf("%s: r:embed %s resolves to %s, want the %dpx image", part.name, match[1], target[1], part.width)
+		}
+	}
+	if n := strings.Count(string(parts["word/_rels/document.xml.rels"]), constants.RelTypeImage); n != 1 {
+		t.Errorf("document.xml.rels has %d image relationships, want 1", n)
+	}
+}
+
+func TestImageReplaceData(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	logo := encodeTestPNG(t, 20, 20)
+	img, err := para.AddImageFromBytes(logo, "", domain.NewImageSize(100, 100), domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+	other, _ := para.AddImageFromBytes(logo, "", domain.ImageSize{}, domain.ImagePosition{})
+	_ = img.SetRotation(90)
+
+	if err := img.ReplaceData(nil, true); err == nil {
+		t.Error("expected error for empty data")
+	}
+	if err := img.ReplaceData([]byte("not an image"), true); err == nil {
+		t.Error("expected error for unknown data")
+	}
+
+	wide := encodeTestPNG(t, 40, 10)
+	if err := img.ReplaceData(wide, true); err != nil {
+		t.Fatalf("ReplaceData() error = %v", err)
+	}
+	if size := img.Size(); size.WidthPx != 100 || size.HeightPx != 25 {
+		t.Errorf("Size() = %+v, want 100x25 fitted in the old box", size)
+	}
+	if img.Rotation() != 90 {
+		t.Error("ReplaceData should keep formatting")
+	}
+	if img.RelationshipID() == other.RelationshipID() {
+		t.Error("replaced image should no longer share the old relationship")
+	}
+	if !bytes.Equal(img.Data(), wide) || !bytes.Equal(other.Data(), logo) {
+		t.Error("only the replaced image should change")
+	}
+
+	wideRelID := img.RelationshipID()
+	if err := img.ReplaceData(encodeTestPNG(t, 10, 10), false); err != nil {
+		t.Fatalf("ReplaceData() error = %v", err)
+	}
+	if size := img.Size(); size.WidthPx != 100 || size.HeightPx != 25 {
+		t.Errorf("Size() = %+v, want the displayed size kept", size)
+	}
+	// The logo stays for the other image; the unused wide picture goes.
+	media := writtenMedia(t, doc)
+	if len(media) != 2 {
+		t.Errorf("media parts = %d, want 2", len(media))
+	}
+	for name, data := range media {
+		if bytes.Equal(data, wide) {
+			t.Errorf("%s still holds the replaced picture", name)
+		}
+	}
+	if _, err := doc.(*document).relManager.Get(wideRelID); err == nil {
+		t.Errorf("relationship %s of the replaced picture was kept", wideRelID)
+	}
+	if _, err := doc.(*document).relManager.Get(other.RelationshipID()); err != nil {
+		t.Errorf("relationship of the shared logo was removed: %v", err)
+	}
+}
+
+func TestDocumentExtractMedia(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	_, _ = para.AddImageFromBytes(encodeTestPNG(t, 10, 10), "", domain.ImageSize{}, domain.ImagePosition{})
+	_, _ = para.AddImageFromBytes(encodeTestPNG(t, 20, 10), "", domain.ImageSize{}, domain.ImagePosition{})
+
+	dir := filepath.Join(t.TempDir(), "media")
+	paths, err := doc.ExtractMedia(dir)
+	if err != nil {
+		t.Fatalf("ExtractMedia() error = %v", err)
+	}
+	if len(paths) != 2 {
+		t.Fatalf("ExtractMedia() returned %v, want 2 files", paths)
+	}
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("ReadFile() error = %v", err)
+		}
+		if !bytes.Equal(data, doc.Images()[i].Image.Data()) {
+			t.Errorf("%s does not match image %d", path, i)
+		}
+	}
+
+	if _, err := doc.ExtractMedia(""); err == nil {
+		t.Error("expected error for empty directory")
+	}
+}
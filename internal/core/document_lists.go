@@ -0,0 +1,151 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/serializer"
+	"github.com/mmonterroca/docxgo/v2/internal/writer"
+	xmlstructs "github.com/mmonterroca/docxgo/v2/internal/xml"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// AddList creates a list numbering and returns its ID. Lists follow the
+// definitions of a numbering part read from an existing document.
+func (d *document) AddList(def domain.ListDefinition) (int, error) {
+	if def.Kind != domain.ListBullet && def.Kind != domain.ListDecimal {
+		return 0, errors.InvalidArgument("Document.AddList", "kind", def.Kind, "unknown list kind")
+	}
+	if def.Start < 0 {
+		return 0, errors.InvalidArgument("Document.AddList", "start", def.Start, "start cannot be negative")
+	}
+	d.lists = append(d.lists, def)
+	_, maxNumID := xmlstructs.NumberingIDs(d.numberingPart)
+	return maxNumID + len(d.lists), nil
+}
+
+// ListLevel returns the markers of a list level, from the lists added by
+// AddList or the numbering part read with the document.
+func (d *document) ListLevel(ref domain.NumberingReference) (domain.ListLevel, bool) {
+	if ref.Level < domain.NumberingLevelMin || ref.Level > domain.NumberingLevelMax {
+		return domain.ListLevel{}, false
+	}
+
+	var format string
+	var start int
+	_, maxNumID := xmlstructs.NumberingIDs(d.numberingPart)
+	if i := ref.ID - maxNumID - 1; i >= 0 && i < len(d.lists) {
+		lvl := xmlstructs.NewAbstractNum(0, d.lists[i]).Levels[ref.Level]
+		format, start = lvl.Format.Val, lvl.Start.Val
+	} else {
+		var ok bool
+		if format, start, ok = xmlstructs.NumberingLevelFormat(d.numberingPart, ref.ID, ref.Level); !ok {
+			return domain.ListLevel{}, false
+		}
+	}
+
+	kind := domain.ListDecimal
+	if format == "bullet" || format == "none" {
+		kind = domain.ListBullet
+	}
+	return domain.ListLevel{Kind: kind, Format: format, Start: start}, true
+}
+
+// numberingPartForWrite returns numbering.xml with the lists added by
+// AddList, or nil when the document has no numbering.
+func (d *document) numberingPartForWrite() (*writer.NumberingPart, error) {
+	if len(d.lists) == 0 {
+		if len(d.numberingPart) == 0 {
+			return nil, nil
+		}
+		return &writer.NumberingPart{Data: d.numberingPart, Target: d.numberingTarget}, nil
+	}
+
+	maxAbstractID, maxNumID := xmlstructs.NumberingIDs(d.numberingPart)
+	lists := xmlstructs.NewNumbering(d.lists, maxAbstractID+1, maxNumID+1)
+	if len(d.numberingPart) == 0 {
+		data, err := marshalPart(lists)
+		if err != nil {
+			return nil, errors.Wrap(err, "Document.WriteTo")
+		}
+		return &writer.NumberingPart{Data: data, Target: "numbering.xml"}, nil
+	}
+	data, err := xmlstructs.MergeNumbering(d.numberingPart, lists)
+	if err != nil {
+		return nil, errors.Wrap(err, "Document.WriteTo")
+	}
+	return &writer.NumberingPart{Data: data, Target: d.numberingTarget}, nil
+}
+
+// Footnotes returns every footnote in document order.
+func (d *document) Footnotes() []domain.Footnote {
+	var notes []domain.Footnote
+	d.walkParagraphs(func(para domain.Paragraph, _ domain.Table, _ domain.ImagePart, _ domain.Section) {
+		notes = append(notes, para.Footnotes()...)
+	})
+	return notes
+}
+
+// prepareFootnotesRelationship adds the footnotes relationship when the
+// document has footnotes and reports whether footnotes.xml is needed.
+func (d *document) prepareFootnotesRelationship() bool {
+	if rel, _ := d.relManager.GetByTarget(footnotesTarget); rel != nil {
+		return true
+	}
+	if len(d.Footnotes()) == 0 {
+		return false
+	}
+	_, _ = d.relManager.Add(constants.RelTypeFootnotes, footnotesTarget, "Internal")
+	return true
+}
+
+// footnotesTarget is the footnotes part, relative to word/.
+const footnotesTarget = "footnotes.xml"
+
+// addFootnotesPart queues footnotes.xml and its relationships. Footnote
+// text shares the relationship IDs of the main document, so hyperlinks
+// and images resolve the same way in both parts.
+func (d *document) addFootnotesPart(zw *writer.ZipWriter, ser *serializer.DocumentSerializer) error {
+	part, err := marshalPart(ser.SerializeFootnotes(d.Footnotes()))
+	if err != nil {
+		return errors.Wrap(err, "Document.WriteTo")
+	}
+	rels := d.relManager.ToXML()
+	kept := rels.Relationships[:0]
+	for _, rel := range rels.Relationships {
+		if rel.Type == constants.RelTypeHyperlink || rel.Type == constants.RelTypeImage {
+			kept = append(kept, rel)
+		}
+	}
+	rels.Relationships = kept
+	relsXML, err := marshalPart(rels)
+	if err != nil {
+		return errors.Wrap(err, "Document.WriteTo")
+	}
+	zw.AddPart("word/"+footnotesTarget, constants.ContentTypeFootnotes, part)
+	zw.AddPart("word/_rels/"+footnotesTarget+".rels", "", relsXML)
+	return nil
+}
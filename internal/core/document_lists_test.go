@@ -0,0 +1,143 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+func TestDocumentLists(t *testing.T) {
+	doc := NewDocument()
+	bullets, err := doc.AddList(domain.ListDefinition{Kind: domain.ListBullet})
+	if err != nil {
+		t.Fatalf("AddList() error = %v", err)
+	}
+	steps, _ := doc.AddList(domain.ListDefinition{Kind: domain.ListDecimal, Start: 4})
+	if bullets != 1 || steps != 2 {
+		t.Errorf("list IDs = %d, %d; want 1, 2", bullets, steps)
+	}
+	if _, err := doc.AddList(domain.ListDefinition{Kind: domain.ListKind(9)}); err == nil {
+		t.Error("AddList() accepted an unknown kind")
+	}
+
+	para, _ := doc.AddParagraph()
+	para.SetNumbering(domain.NumberingReference{ID: steps})
+	numbering := string(writtenParts(t, doc)["word/numbering.xml"])
+	for _, want := range []string{
+		`<w:abstractNum w:abstractNumId="2">`,
+		`<w:start w:val="4"></w:start><w:numFmt w:val="decimal"></w:numFmt>`,
+		`<w:lvlText w:val="•"></w:lvlText>`,
+		`<w:num w:numId="2"><w:abstractNumId w:val="2"></w:abstractNumId></w:num>`,
+	} {
+		if !strings.Contains(numbering, want) {
+			t.Errorf("numbering.xml missing %s", want)
+		}
+	}
+}
+
+func TestDocumentListsExtendNumberingPart(t *testing.T) {
+	doc := NewDocument().(*document)
+	doc.SetNumberingPart([]byte(`<w:numbering xmlns:w="`+"http://schemas.openxmlformats.org/wordprocessingml/2006/main"+`">`+
+		`<w:abstractNum w:abstractNumId="3"></w:abstractNum><w:num w:numId="7"><w:abstractNumId w:val="3"/></w:num></w:numbering>`), "numbering.xml")
+	id, _ := doc.AddList(domain.ListDefinition{Kind: domain.ListBullet})
+	if id != 8 {
+		t.Errorf("list ID = %d, want 8 after the existing numId 7", id)
+	}
+
+	numbering := string(writtenParts(t, doc)["word/numbering.xml"])
+	added := strings.Index(numbering, `<w:abstractNum w:abstractNumId="4">`)
+	if added < 0 || added > strings.Index(numbering, `<w:num w:numId="7">`) {
+		t.Errorf("new definition should precede the existing w:num: %s", numbering)
+	}
+	if !strings.Contains(numbering, `<w:num w:numId="8"><w:abstractNumId w:val="4"></w:abstractNumId></w:num></w:numbering>`) {
+		t.Errorf("new list instance missing: %s", numbering)
+	}
+}
+
+func TestDocumentListLevel(t *testing.T) {
+	doc := NewDocument().(*document)
+	doc.SetNumberingPart([]byte(`<w:numbering xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">`+
+		`<w:abstractNum w:abstractNumId="0"><w:lvl w:ilvl="0"><w:start w:val="1"/><w:numFmt w:val="upperRoman"/></w:lvl>`+
+		`<w:lvl w:ilvl="1"><w:numFmt w:val="bullet"/></w:lvl></w:abstractNum>`+
+		`<w:num w:numId="5"><w:abstractNumId w:val="0"/><w:lvlOverride w:ilvl="0"><w:startOverride w:val="3"/></w:lvlOverride></w:num>`+
+		`</w:numbering>`), "numbering.xml")
+	steps, _ := doc.AddList(domain.ListDefinition{Kind: domain.ListDecimal, Start: 2})
+
+	tests := []struct {
+		ref  domain.NumberingReference
+		want domain.ListLevel
+	}{
+		{domain.NumberingReference{ID: 5}, domain.ListLevel{Kind: domain.ListDecimal, Format: "upperRoman", Start: 3}},
+		{domain.NumberingReference{ID: 5, Level: 1}, domain.ListLevel{Kind: domain.ListBullet, Format: "bullet"}},
+		{domain.NumberingReference{ID: steps}, domain.ListLevel{Kind: domain.ListDecimal, Format: "decimal", Start: 2}},
+		{domain.NumberingReference{ID: steps, Level: 1}, domain.ListLevel{Kind: domain.ListDecimal, Format: "lowerLetter", Start: 1}},
+	}
+	for _, tt := range tests {
+		if got, ok := doc.ListLevel(tt.ref); !ok || got != tt.want {
+			t.Errorf("ListLevel(%+v) = %+v, %v; want %+v", tt.ref, got, ok, tt.want)
+		}
+	}
+	if _, ok := doc.ListLevel(domain.NumberingReference{ID: 9}); ok {
+		t.Error("ListLevel() found an unknown list")
+	}
+}
+
+func TestDocumentFootnotes(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	run, _ := para.AddRun()
+	run.SetText("Claim")
+	note, err := para.AddFootnote()
+	if err != nil {
+		t.Fatalf("AddFootnote() error = %v", err)
+	}
+	text, _ := note.AddParagraph()
+	noteRun, _ := text.AddRun()
+	noteRun.SetText("Source.")
+
+	if notes := doc.Footnotes(); len(notes) != 1 || notes[0].ID() != 1 || notes[0].Text() != "Source." {
+		t.Fatalf("Footnotes() = %v", notes)
+	}
+
+	parts := writtenParts(t, doc)
+	if !strings.Contains(string(parts["word/document.xml"]), `<w:footnoteReference w:id="1"></w:footnoteReference>`) {
+		t.Error("document.xml missing the footnote reference")
+	}
+	footnotes := string(parts["word/footnotes.xml"])
+	for _, want := range []string{`w:type="separator" w:id="-1"`, `<w:footnote w:id="1">`, `<w:pStyle w:val="FootnoteText">`, `<w:footnoteRef>`, `Source.`} {
+		if !strings.Contains(footnotes, want) {
+			t.Errorf("footnotes.xml missing %s", want)
+		}
+	}
+	if !strings.Contains(string(parts["word/_rels/document.xml.rels"]), `Target="footnotes.xml"`) {
+		t.Error("document relationships missing footnotes.xml")
+	}
+	if !strings.Contains(string(parts["[Content_Types].xml"]), `/word/footnotes.xml`) {
+		t.Error("content types missing footnotes.xml")
+	}
+}
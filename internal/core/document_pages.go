@@ -0,0 +1,177 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/layout"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// maxFieldPasses bounds the layouts UpdateFields runs: a TOC filled in
+// can move the headings it lists to other pages.
+const maxFieldPasses = 3
+
+// paginator lays out documents for PageCount and UpdateFields. The
+// renderers depend on core, so the root package installs one with
+// SetPaginator rather than core importing it.
+var paginator layout.Paginator
+
+// SetPaginator sets the layout used by PageCount and UpdateFields.
+func SetPaginator(p layout.Paginator) {
+	paginator = p
+}
+
+// paginate lays out the document with the installed paginator.
+func (d *document) paginate(op string) (layout.Pagination, error) {
+	if paginator == nil {
+		return nil, errors.InvalidState(op, "no paginator installed")
+	}
+	pages, err := paginator(d)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return pages, nil
+}
+
+// PageCount lays out the document and returns its number of pages.
+func (d *document) PageCount() (int, error) {
+	pages, err := d.paginate("Document.PageCount")
+	if err != nil {
+		return 0, err
+	}
+	return pages.PageCount(), nil
+}
+
+// UpdateFields lays out the document and updates its page and TOC fields
+// until their results no longer change the layout.
+func (d *document) UpdateFields() error {
+	d.generateHeadingBookmarks()
+	for range maxFieldPasses {
+		pages, err := d.paginate("Document.UpdateFields")
+		if err != nil {
+			return err
+		}
+		if !d.applyPages(pages) {
+			break
+		}
+	}
+	return nil
+}
+
+// applyPages updates the page fields of the document from a pagination
+// and reports whether any result changed. Paragraphs that are not laid
+// out take the page of the paragraph before them, or for headers and
+// footers the first page of their section.
+func (d *document) applyPages(pages layout.Pagination) bool {
+	bookmarks := map[string]int{}
+	var headings []tocEntry
+	last := 1
+	d.walkParagraphs(func(para domain.Paragraph, _ domain.Table, part domain.ImagePart, _ domain.Section) {
+		if part != domain.ImagePartBody {
+			return
+		}
+		if n, ok := pages.Page(para); ok {
+			last = n
+		}
+		p, ok := para.(*paragraph)
+		if !ok || p.BookmarkName() == "" {
+			return
+		}
+		bookmarks[p.BookmarkName()] = last
+		if level := headingLevel(p.StyleName()); level > 0 {
+			headings = append(headings, tocEntry{level: level, text: p.Text(), page: last, bookmark: p.BookmarkName()})
+		}
+	})
+
+	changed := false
+	page, section := 1, 0
+	d.walkParagraphs(func(para domain.Paragraph, _ domain.Table, part domain.ImagePart, sec domain.Section) {
+		if part == domain.ImagePartBody {
+			if n, ok := pages.Page(para); ok {
+				page = n
+				section, _ = pages.Section(para)
+			}
+		} else if i := slices.Index(d.sections, sec); i >= 0 {
+			if first, _, ok := pages.SectionPages(i); ok {
+				page, section = first, i
+			}
+		}
+
+		sectionPages := 1
+		if first, last, ok := pages.SectionPages(section); ok {
+			sectionPages = last - first + 1
+		}
+		values := pageValues{page: page, pages: pages.PageCount(), sectionPages: sectionPages, bookmarks: bookmarks, headings: headings}
+
+		for _, run := range para.Runs() {
+			withFields, ok := run.(interface{ Fields() []domain.Field })
+			if !ok {
+				continue
+			}
+			for _, field := range withFields.Fields() {
+				f, ok := field.(*docxField)
+				if !ok || !isPageField(f.Type()) {
+					continue
+				}
+				before := f.Result()
+				f.setPageValues(values)
+				_ = f.Update()
+				changed = changed || f.Result() != before
+			}
+		}
+	})
+	return changed
+}
+
+// isPageField reports whether a field shows values from the pagination.
+func isPageField(fieldType domain.FieldType) bool {
+	switch fieldType { //nolint:exhaustive // Other fields do not depend on pages
+	case domain.FieldTypePageNumber, domain.FieldTypeNumPages, domain.FieldTypePageCount,
+		domain.FieldTypeSectionPages, domain.FieldTypePageRef, domain.FieldTypeTOC:
+		return true
+	default:
+		return false
+	}
+}
+
+// headingLevel returns the level of a Heading style ("Heading2" or
+// "Heading 2"), or 0.
+func headingLevel(styleName string) int {
+	rest, ok := strings.CutPrefix(styleName, "Heading")
+	if !ok {
+		return 0
+	}
+	level, err := strconv.Atoi(strings.TrimLeftFunc(rest, unicode.IsSpace))
+	if err != nil || level < 1 || level > 9 {
+		return 0
+	}
+	return level
+}
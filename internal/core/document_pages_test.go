@@ -0,0 +1,163 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/layout"
+	"github.com/mmonterroca/docxgo/v2/render/pdf"
+)
+
+// The root package installs the PDF paginator; these tests do the same.
+func init() {
+	SetPaginator(func(doc domain.Document) (layout.Pagination, error) {
+		return pdf.Paginate(doc, pdf.Options{})
+	})
+}
+
+func TestDocumentUpdateFields(t *testing.T) {
+	doc := NewDocument()
+	addFieldParagraph := func(text string, field domain.Field) domain.Field {
+		t.Helper()
+		para, _ := doc.AddParagraph()
+		run, _ := para.AddRun()
+		_ = run.SetText(text)
+		if err := run.AddField(field); err != nil {
+			t.Fatalf("AddField: %v", err)
+		}
+		return field
+	}
+	addHeading := func(text string) {
+		para, _ := doc.AddParagraph()
+		_ = para.SetStyle("Heading1")
+		run, _ := para.AddRun()
+		_ = run.SetText(text)
+	}
+
+	toc := addFieldParagraph("", NewTOCField(map[string]string{"levels": "1-2"}))
+	addHeading("Introduction")
+	pageRef := addFieldParagraph("Details are on page ", NewPageRefField("_Toc1"))
+	_ = doc.AddPageBreak()
+	addHeading("Details")
+	page := addFieldParagraph("Page ", NewPageNumberField())
+	count := addFieldParagraph("Pages ", NewPageCountField())
+	sectionPages := addFieldParagraph("Section pages ", NewSectionPagesField())
+	missing := addFieldParagraph("", NewPageRefField("nowhere"))
+
+	pages, err := doc.PageCount()
+	if err != nil || pages != 2 {
+		t.Fatalf("PageCount() = %d, %v; want 2", pages, err)
+	}
+	if err := doc.UpdateFields(); err != nil {
+		t.Fatalf("UpdateFields: %v", err)
+	}
+
+	for _, tc := range []struct {
+		field domain.Field
+		want  string
+	}{
+		{page, "2"},
+		{count, "2"},
+		{sectionPages, "2"},
+		{pageRef, "2"},
+		{missing, "Error! Bookmark not defined."},
+	} {
+		if got := tc.field.Result(); got != tc.want {
+			t.Errorf("%s result = %q, want %q", tc.field.Code(), got, tc.want)
+		}
+	}
+
+	if got, want := toc.Result(), "Introduction\t1\nDetails\t2"; got != want {
+		t.Errorf("TOC result = %q, want %q", got, want)
+	}
+
+	body := string(writtenParts(t, doc)["word/document.xml"])
+	entry := regexp.MustCompile(`<w:p>\s*<w:pPr>\s*<w:pStyle w:val="TOC1"></w:pStyle>\s*` +
+		`<w:tabs>\s*<w:tab w:val="right" w:leader="dot" w:pos="9026"></w:tab>\s*</w:tabs>\s*</w:pPr>`)
+	if n := len(entry.FindAllString(body, -1)); n != 2 {
+		t.Errorf("found %d TOC1 paragraphs with a dot leader tab, want 2:\n%s", n, body)
+	}
+	for _, want := range []string{
+		`<w:hyperlink w:anchor="_Toc0">`,
+		`<w:hyperlink w:anchor="_Toc1">`,
+		`<w:t>Details</w:t>`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("document.xml lacks %s", want)
+		}
+	}
+	if strings.Contains(body, "<w:br>") {
+		t.Error("TOC entries are separated by line breaks")
+	}
+	if strings.Contains(body, `w:dirty="true"`) {
+		t.Error("updated fields are still marked dirty")
+	}
+}
+
+func TestTOCListsHeadingsInTables(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	run, _ := para.AddRun()
+	toc := NewTOCField(nil)
+	_ = run.AddField(toc)
+
+	table, _ := doc.AddTable(1, 1)
+	row, _ := table.Row(0)
+	cell, _ := row.Cell(0)
+	heading, _ := cell.AddParagraph()
+	_ = heading.SetStyle("Heading1")
+	run, _ = heading.AddRun()
+	_ = run.SetText("Results")
+
+	para, _ = doc.AddParagraph()
+	run, _ = para.AddRun()
+	pageRef := NewPageRefField("_Toc0")
+	_ = run.AddField(pageRef)
+
+	if err := doc.UpdateFields(); err != nil {
+		t.Fatalf("UpdateFields: %v", err)
+	}
+	if got, want := toc.Result(), "Results\t1"; got != want {
+		t.Errorf("TOC result = %q, want %q", got, want)
+	}
+	if got := heading.(*paragraph).BookmarkName(); got != "_Toc0" {
+		t.Errorf("heading bookmark = %q, want _Toc0", got)
+	}
+	if got := pageRef.Result(); got != "1" {
+		t.Errorf("PAGEREF result = %q, want 1", got)
+	}
+}
+
+func TestFieldUpdateWithoutPages(t *testing.T) {
+	for _, field := range []domain.Field{NewSectionPagesField(), NewPageRefField("_Toc0")} {
+		if err := field.Update(); err != nil || field.Result() != "1" {
+			t.Errorf("%s: Update() = %v, result %q", field.Code(), err, field.Result())
+		}
+	}
+}
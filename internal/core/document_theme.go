@@ -0,0 +1,56 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// SetTheme sets the document theme written to word/theme/theme1.xml.
+func (d *document) SetTheme(theme domain.DocumentTheme) error {
+	const op = "Document.SetTheme"
+	if strings.TrimSpace(theme.Name) == "" {
+		return errors.InvalidArgument(op, "theme.Name", theme.Name, "theme name cannot be empty")
+	}
+	if strings.TrimSpace(theme.MajorFont) == "" {
+		return errors.InvalidArgument(op, "theme.MajorFont", theme.MajorFont, "major font cannot be empty")
+	}
+	if strings.TrimSpace(theme.MinorFont) == "" {
+		return errors.InvalidArgument(op, "theme.MinorFont", theme.MinorFont, "minor font cannot be empty")
+	}
+	d.theme = &theme
+	return nil
+}
+
+// Theme returns the document theme; the Office theme by default.
+func (d *document) Theme() domain.DocumentTheme {
+	if d.theme == nil {
+		return domain.DefaultDocumentTheme()
+	}
+	return *d.theme
+}
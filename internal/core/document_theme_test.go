@@ -0,0 +1,97 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+func TestDocumentTheme(t *testing.T) {
+	doc := NewDocument()
+	if got := doc.Theme(); got.Name != "Office Theme" || got.MinorFont != "Calibri" {
+		t.Errorf("default theme = %+v", got)
+	}
+	theme := doc.Theme()
+	if !strings.Contains(string(writtenParts(t, doc)["word/theme/theme1.xml"]), `<a:sysClr val="windowText" lastClr="000000">`) {
+		t.Error("default theme should keep the Office color scheme")
+	}
+
+	theme.Name = "Brand"
+	theme.Colors.Accent1 = domain.Color{R: 0x1A, G: 0x73, B: 0xE8}
+	theme.Colors.Dark1 = domain.Color{R: 0x20, G: 0x20, B: 0x20}
+	theme.MajorFont = "Georgia"
+	if err := doc.SetTheme(theme); err != nil {
+		t.Fatalf("SetTheme() error = %v", err)
+	}
+
+	para, _ := doc.AddParagraph()
+	run, _ := para.AddRun()
+	run.SetText("Accent")
+	if err := run.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorAccent1, Shade: 0xBF}); err != nil {
+		t.Fatalf("SetThemeColor() error = %v", err)
+	}
+	if err := run.SetFont(domain.Font{Name: "Georgia", Theme: domain.ThemeFontMajor}); err != nil {
+		t.Fatalf("SetFont() error = %v", err)
+	}
+	plain, _ := para.AddRun()
+	plain.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorText1})
+
+	parts := writtenParts(t, doc)
+	part := string(parts["word/theme/theme1.xml"])
+	for _, want := range []string{
+		`name="Brand"`,
+		`<a:srgbClr val="202020">`,
+		`<a:srgbClr val="1A73E8">`,
+		`<a:latin typeface="Georgia">`,
+		`<a:fmtScheme name="Office"><a:fillStyleLst>`,
+	} {
+		if !strings.Contains(part, want) {
+			t.Errorf("theme1.xml missing %s", want)
+		}
+	}
+	main := string(parts["word/document.xml"])
+	for _, want := range []string{
+		`<w:color w:val="1155AF" w:themeColor="accent1" w:themeShade="BF">`,
+		`w:asciiTheme="majorHAnsi"`,
+		`<w:color w:val="202020" w:themeColor="text1">`,
+	} {
+		if !strings.Contains(main, want) {
+			t.Errorf("document.xml missing %s", want)
+		}
+	}
+
+	if err := doc.SetTheme(domain.DocumentTheme{Name: "Empty"}); err == nil {
+		t.Error("expected error for missing fonts")
+	}
+	if err := run.SetThemeColor(domain.ThemeColorRef{Color: "accent9"}); err == nil {
+		t.Error("expected error for unknown theme color")
+	}
+	if err := run.SetFont(domain.Font{Name: "Georgia", Theme: "display"}); err == nil {
+		t.Error("expected error for unknown theme font")
+	}
+}
@@ -0,0 +1,280 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"bytes"
+	"fmt"
+	"image/png"
+	"math"
+	"path"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/ole"
+	"github.com/mmonterroca/docxgo/v2/internal/svg"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// progIDPackage is the program ID of files wrapped in an OLE Package.
+const progIDPackage = "Package"
+
+// officeEmbedding describes an Office file type that Word embeds as is.
+type officeEmbedding struct {
+	progID      string
+	contentType string
+	partName    string
+}
+
+// officeEmbeddings maps file extensions to the applications that own them.
+var officeEmbeddings = map[string]officeEmbedding{
+	".xlsx": {"Excel.Sheet.12", constants.ContentTypeSpreadsheet, "Microsoft_Excel_Worksheet"},
+	".docx": {"Word.Document.12", constants.ContentTypeWordprocessing, "Microsoft_Word_Document"},
+	".pptx": {"PowerPoint.Show.12", constants.ContentTypePresentation, "Microsoft_PowerPoint_Presentation"},
+}
+
+// docxEmbeddedObject implements the domain.EmbeddedObject interface.
+type docxEmbeddedObject struct {
+	id             string
+	fileName       string
+	progID         string
+	data           []byte
+	part           []byte // Part read from a document, written back verbatim
+	icon           []byte
+	iconRelID      string
+	size           domain.ImageSize
+	relationshipID string
+	target         string
+}
+
+// newEmbeddedObject creates an object stored in the part embeddings/<name>,
+// numbered after id.
+func newEmbeddedObject(id, fileName string, data []byte, opts domain.EmbedOptions) (*docxEmbeddedObject, error) {
+	const op = "Paragraph.AddEmbeddedObject"
+	fileName = path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+	if fileName == "" || fileName == "." || fileName == "/" {
+		return nil, errors.InvalidArgument(op, "fileName", fileName, "file name cannot be empty")
+	}
+	if len(data) == 0 {
+		return nil, errors.InvalidArgument(op, "data", len(data), "data cannot be empty")
+	}
+
+	ext := strings.ToLower(path.Ext(fileName))
+	obj := &docxEmbeddedObject{
+		fileName: fileName,
+		progID:   progIDPackage,
+		data:     bytes.Clone(data),
+	}
+	if office, ok := officeEmbeddings[ext]; ok {
+		obj.progID = office.progID
+	}
+	obj.setID(id)
+
+	obj.icon = opts.Icon
+	if obj.icon == nil {
+		icon, err := renderFileIcon(ext)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		obj.icon = icon
+	}
+	switch detectImageFormatFromData(obj.icon) {
+	case domain.ImageFormatPNG, domain.ImageFormatJPEG, domain.ImageFormatGIF, domain.ImageFormatBMP,
+		domain.ImageFormatEMF, domain.ImageFormatWMF:
+	default:
+		return nil, errors.InvalidArgument(op, "opts.Icon", len(obj.icon), "icon must be a PNG, JPEG, GIF, BMP, EMF or WMF image")
+	}
+	obj.icon = bytes.Clone(obj.icon)
+
+	size := opts.Size
+	if size == (domain.ImageSize{}) {
+		info, err := readImageInfo(obj.icon)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		size = domain.ImageSize{WidthPx: info.width, HeightPx: info.height}
+	}
+	if err := obj.SetSize(size); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return obj, nil
+}
+
+// NewEmbeddedObjectFromPackage restores an object read from a document.
+// Packages are unwrapped to expose the embedded file; the part itself is
+// written back unchanged. The object gets its ID when it is attached.
+func NewEmbeddedObjectFromPackage(target, progID string, part, icon []byte) domain.EmbeddedObject {
+	obj := &docxEmbeddedObject{
+		fileName: path.Base(target),
+		progID:   progID,
+		data:     part,
+		part:     part,
+		icon:     icon,
+		target:   target,
+	}
+	if progID == progIDPackage {
+		if name, data, err := ole.ReadPackageObject(part); err == nil {
+			obj.fileName, obj.data = name, data
+		}
+	}
+	return obj
+}
+
+// setID sets the object ID and names the embedded part after it.
+func (o *docxEmbeddedObject) setID(id string) {
+	number := strings.TrimLeft(id, "abcdefghijklmnopqrstuvwxyz")
+	ext := strings.ToLower(path.Ext(o.fileName))
+	o.id = id
+	o.target = "embeddings/oleObject" + number + ".bin"
+	if office, ok := officeEmbeddings[ext]; ok {
+		o.target = "embeddings/" + office.partName + number + ext
+	}
+}
+
+// ID returns the unique object ID.
+func (o *docxEmbeddedObject) ID() string {
+	return o.id
+}
+
+// FileName returns the name of the embedded file.
+func (o *docxEmbeddedObject) FileName() string {
+	return o.fileName
+}
+
+// ProgID returns the OLE program ID.
+func (o *docxEmbeddedObject) ProgID() string {
+	return o.progID
+}
+
+// Data returns a copy of the embedded file.
+func (o *docxEmbeddedObject) Data() []byte {
+	return bytes.Clone(o.data)
+}
+
+// Icon returns a copy of the preview image.
+func (o *docxEmbeddedObject) Icon() []byte {
+	return bytes.Clone(o.icon)
+}
+
+// Size returns the displayed icon size.
+func (o *docxEmbeddedObject) Size() domain.ImageSize {
+	return o.size
+}
+
+// SetSize sets the displayed icon size. Sizes given only in pixels are
+// converted to EMUs at 96 DPI.
+func (o *docxEmbeddedObject) SetSize(size domain.ImageSize) error {
+	if size.WidthEMU == 0 && size.HeightEMU == 0 {
+		size.WidthEMU, size.HeightEMU = size.WidthPx*9525, size.HeightPx*9525
+	}
+	if size.WidthEMU <= 0 || size.HeightEMU <= 0 {
+		return errors.InvalidArgument("EmbeddedObject.SetSize", "size", size, "width and height must be positive")
+	}
+	if size.WidthPx == 0 && size.HeightPx == 0 {
+		size.WidthPx = int(math.Round(float64(size.WidthEMU) / 9525))
+		size.HeightPx = int(math.Round(float64(size.HeightEMU) / 9525))
+	}
+	o.size = size
+	return nil
+}
+
+// RelationshipID returns the relationship ID of the embedded part.
+func (o *docxEmbeddedObject) RelationshipID() string {
+	return o.relationshipID
+}
+
+// SetRelationshipID sets the relationship ID of the embedded part.
+func (o *docxEmbeddedObject) SetRelationshipID(relID string) {
+	o.relationshipID = relID
+}
+
+// Target returns the embedded part path relative to word/.
+func (o *docxEmbeddedObject) Target() string {
+	return o.target
+}
+
+// IconRelationshipID returns the relationship ID of the preview image.
+func (o *docxEmbeddedObject) IconRelationshipID() string {
+	return o.iconRelID
+}
+
+// relationshipType returns the relationship type of the embedded part.
+func (o *docxEmbeddedObject) relationshipType() string {
+	if strings.HasSuffix(o.target, ".bin") {
+		return constants.RelTypeOLEObject
+	}
+	return constants.RelTypePackage
+}
+
+// partData returns the content and content type of the embedded part.
+func (o *docxEmbeddedObject) partData() ([]byte, string) {
+	contentType := constants.ContentTypeOLEObject
+	if office, ok := officeEmbeddings[strings.ToLower(path.Ext(o.target))]; ok {
+		contentType = office.contentType
+	}
+	switch {
+	case o.part != nil:
+		return o.part, contentType
+	case o.progID == progIDPackage:
+		return ole.PackageObject(o.fileName, o.data), contentType
+	default:
+		return o.data, contentType
+	}
+}
+
+// fileIconColors tints the default icon by file type.
+var fileIconColors = map[string]string{
+	".xlsx": "#217346", ".xls": "#217346", ".csv": "#217346",
+	".docx": "#2B579A", ".doc": "#2B579A", ".rtf": "#2B579A", ".txt": "#2B579A",
+	".pptx": "#D24726", ".ppt": "#D24726",
+	".pdf": "#D93025",
+	".zip": "#8A6D3B",
+}
+
+// renderFileIcon draws a page with a folded corner and a band colored by
+// file type.
+func renderFileIcon(ext string) ([]byte, error) {
+	color, ok := fileIconColors[ext]
+	if !ok {
+		color = "#737373"
+	}
+	icon := fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="48" height="60" viewBox="0 0 48 60">`+
+		`<path d="M8 2H31L43 14V58H8Z" fill="#FFFFFF" stroke="#8C8C8C" stroke-width="1.5"/>`+
+		`<path d="M31 2V14H43" fill="#E6E6E6" stroke="#8C8C8C" stroke-width="1.5"/>`+
+		`<path d="M15 22H36M15 27H36M15 48H36M15 53H30" stroke="#BFBFBF" stroke-width="2"/>`+
+		`<rect x="3" y="31" width="30" height="12" rx="1.5" fill="%s"/>`+
+		`</svg>`, color)
+
+	raster, err := svg.Rasterize([]byte(icon), 48, 60)
+	if err != nil {
+		return nil, errors.Wrap(err, "renderFileIcon")
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, raster); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeInternal, "renderFileIcon")
+	}
+	return buf.Bytes(), nil
+}
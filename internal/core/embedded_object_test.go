@@ -0,0 +1,129 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/ole"
+)
+
+func TestAddEmbeddedObject(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+
+	pdf := []byte("%PDF-1.7 compliance packet")
+	obj, err := para.AddEmbeddedObject(`C:\packets\packet.pdf`, pdf, domain.EmbedOptions{})
+	if err != nil {
+		t.Fatalf("AddEmbeddedObject() error = %v", err)
+	}
+	if obj.FileName() != "packet.pdf" || obj.ProgID() != "Package" || !strings.HasSuffix(obj.Target(), ".bin") {
+		t.Errorf("unexpected object %s %s %s", obj.FileName(), obj.ProgID(), obj.Target())
+	}
+	if size := obj.Size(); size.WidthPx != 48 || size.HeightPx != 60 {
+		t.Errorf("default icon size = %+v", size)
+	}
+
+	icon := encodeTestPNG(t, 32, 32)
+	sheet, err := para.AddEmbeddedObject("source.xlsx", []byte("PK\x03\x04"), domain.EmbedOptions{Icon: icon})
+	if err != nil {
+		t.Fatalf("AddEmbeddedObject(xlsx) error = %v", err)
+	}
+	if sheet.ProgID() != "Excel.Sheet.12" || !strings.HasSuffix(sheet.Target(), ".xlsx") || !bytes.Equal(sheet.Icon(), icon) {
+		t.Errorf("unexpected workbook object %s %s", sheet.ProgID(), sheet.Target())
+	}
+	if len(para.Runs()) != 2 || len(doc.EmbeddedObjects()) != 2 {
+		t.Errorf("objects should be added as runs")
+	}
+
+	parts := writtenParts(t, doc)
+	name, data, err := ole.ReadPackageObject(parts["word/"+obj.Target()])
+	if err != nil || name != "packet.pdf" || !bytes.Equal(data, pdf) {
+		t.Errorf("package part = %q %q %v", name, data, err)
+	}
+	if !bytes.Equal(parts["word/"+sheet.Target()], []byte("PK\x03\x04")) {
+		t.Error("workbook should be embedded as is")
+	}
+	main := string(parts["word/document.xml"])
+	for _, want := range []string{`ProgID="Package"`, `ProgID="Excel.Sheet.12"`, `DrawAspect="Icon"`, `<v:imagedata r:id="`} {
+		if !strings.Contains(main, want) {
+			t.Errorf("document.xml missing %s", want)
+		}
+	}
+	if !strings.Contains(string(parts["[Content_Types].xml"]), "application/vnd.openxmlformats-officedocument.oleObject") {
+		t.Error("missing OLE object content type")
+	}
+
+	if _, err := para.AddEmbeddedObject("", pdf, domain.EmbedOptions{}); err == nil {
+		t.Error("expected error for empty file name")
+	}
+	if _, err := para.AddEmbeddedObject("a.pdf", nil, domain.EmbedOptions{}); err == nil {
+		t.Error("expected error for empty data")
+	}
+	if _, err := para.AddEmbeddedObject("a.pdf", pdf, domain.EmbedOptions{Icon: []byte("<svg/>")}); err == nil {
+		t.Error("expected error for unsupported icon")
+	}
+}
+
+func TestInsertAltChunk(t *testing.T) {
+	tests := []struct {
+		data        string
+		format      domain.AltChunkFormat
+		contentType string
+	}{
+		{"<html><body>Hi</body></html>", domain.AltChunkHTML, "text/html"},
+		{`{\rtf1\ansi Hi}`, domain.AltChunkRTF, "application/rtf"},
+		{"PK\x03\x04docx", domain.AltChunkDOCX, "application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"},
+		{"MIME-Version: 1.0\r\n", domain.AltChunkMHT, "message/rfc822"},
+		{"just text", domain.AltChunkText, "text/plain"},
+	}
+	for _, tt := range tests {
+		doc := NewDocument()
+		if err := doc.InsertAltChunk([]byte(tt.data)); err != nil {
+			t.Fatalf("InsertAltChunk(%q) error = %v", tt.data, err)
+		}
+		chunk := doc.Blocks()[0].AltChunk
+		if chunk == nil || chunk.Format != tt.format {
+			t.Fatalf("InsertAltChunk(%q) block = %+v, want %s", tt.data, chunk, tt.format)
+		}
+		parts := writtenParts(t, doc)
+		if string(parts["word/"+chunk.Target]) != tt.data {
+			t.Errorf("chunk part %s not written", chunk.Target)
+		}
+		if !strings.Contains(string(parts["[Content_Types].xml"]), tt.contentType) {
+			t.Errorf("missing content type %s", tt.contentType)
+		}
+		if !strings.Contains(string(parts["word/document.xml"]), `<w:altChunk r:id="`+chunk.RelationshipID+`">`) {
+			t.Errorf("document.xml missing altChunk for %s", tt.format)
+		}
+	}
+
+	if err := NewDocument().InsertAltChunk([]byte("  \n")); err == nil {
+		t.Error("expected error for empty chunk")
+	}
+}
@@ -0,0 +1,104 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"encoding/xml"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/latex"
+	xmlstructs "github.com/mmonterroca/docxgo/v2/internal/xml"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// docxEquation implements the domain.Equation interface.
+type docxEquation struct {
+	mode    domain.EquationMode
+	content domain.MathNode
+	raw     string // m:oMath content of equations read from a document
+}
+
+// newEquation creates an equation, converting LaTeX input.
+func newEquation(content domain.MathNode, mode domain.EquationMode) (*docxEquation, error) {
+	if content == nil {
+		return nil, errors.InvalidArgument("newEquation", "content", content, "equation content cannot be nil")
+	}
+	if src, ok := content.(domain.MathLaTeX); ok {
+		parsed, err := latex.Parse(string(src))
+		if err != nil {
+			return nil, err
+		}
+		content = parsed
+	}
+	eq := &docxEquation{content: content}
+	if err := eq.SetMode(mode); err != nil {
+		return nil, err
+	}
+	return eq, nil
+}
+
+// Mode returns whether the equation is inline or on its own line.
+func (e *docxEquation) Mode() domain.EquationMode {
+	return e.mode
+}
+
+// SetMode switches between inline and display layout. An empty mode is
+// inline.
+func (e *docxEquation) SetMode(mode domain.EquationMode) error {
+	switch mode {
+	case "":
+		mode = domain.EquationInline
+	case domain.EquationInline, domain.EquationDisplay:
+	default:
+		return errors.InvalidArgument("Equation.SetMode", "mode", mode, "mode must be inline or display")
+	}
+	e.mode = mode
+	return nil
+}
+
+// Content returns the equation tree, or nil for equations read from a
+// document.
+func (e *docxEquation) Content() domain.MathNode {
+	return e.content
+}
+
+// OMML returns the equation as an m:oMath element.
+func (e *docxEquation) OMML() string {
+	if e.content == nil {
+		return `<m:oMath xmlns:m="` + constants.NamespaceMath + `">` + e.raw + `</m:oMath>`
+	}
+	data, err := xml.Marshal(xmlstructs.NewOMath(e.content))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// RawContent returns the verbatim m:oMath content of an equation read from
+// a document. This is an internal method used by the serializer.
+func (e *docxEquation) RawContent() string {
+	return e.raw
+}
@@ -0,0 +1,81 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+func TestAddEquation(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+
+	if _, err := para.AddEquation(nil, domain.EquationInline); err == nil {
+		t.Error("expected error for nil content")
+	}
+	if _, err := para.AddEquation(domain.MathText("x"), "block"); err == nil {
+		t.Error("expected error for unknown mode")
+	}
+	if _, err := para.AddEquation(domain.MathLaTeX(`\frac{1}`), domain.EquationInline); err == nil {
+		t.Error("expected error for invalid LaTeX")
+	}
+	if len(para.Runs()) != 0 {
+		t.Fatal("failed equations should not add runs")
+	}
+
+	run, _ := para.AddRun()
+	_ = run.SetText("Area ")
+	eq, err := para.AddEquation(domain.MathLaTeX(`\pi r^2`), "")
+	if err != nil {
+		t.Fatalf("AddEquation() error = %v", err)
+	}
+	if eq.Mode() != domain.EquationInline {
+		t.Errorf("default mode = %s, want inline", eq.Mode())
+	}
+	want := domain.MathRow{domain.MathText("π"), domain.MathScript{Base: domain.MathText("r"), Sup: domain.MathText("2")}}
+	if !reflect.DeepEqual(eq.Content(), want) {
+		t.Errorf("Content() = %#v, want the parsed LaTeX", eq.Content())
+	}
+
+	display, _ := doc.AddParagraph()
+	if _, err := display.AddEquation(domain.MathFraction{Num: domain.MathText("a"), Den: domain.MathText("b")}, domain.EquationDisplay); err != nil {
+		t.Fatalf("AddEquation(display) error = %v", err)
+	}
+
+	main := string(writtenParts(t, doc)["word/document.xml"])
+	inline := strings.Index(main, "<m:oMath xmlns:m=")
+	if inline < 0 || inline < strings.Index(main, "Area ") {
+		t.Errorf("inline equation should follow the text run")
+	}
+	for _, want := range []string{"<m:sSup>", "<m:oMathPara", `<m:jc m:val="centerGroup">`, "<m:f>"} {
+		if !strings.Contains(main, want) {
+			t.Errorf("document.xml missing %s", want)
+		}
+	}
+}
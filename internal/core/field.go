@@ -27,6 +27,8 @@ package core
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -43,6 +45,23 @@ type docxField struct {
 	result     string
 	isDirty    bool // Indicates if field needs recalculation
 	properties map[string]string
+	pages      *pageValues // Set by Document.UpdateFields
+}
+
+// pageValues holds what page fields show, from the pagination of the
+// document.
+type pageValues struct {
+	page, pages, sectionPages int
+	bookmarks                 map[string]int // PAGEREF targets
+	headings                  []tocEntry
+}
+
+// tocEntry is a heading listed by TOC fields.
+type tocEntry struct {
+	level    int
+	text     string
+	page     int
+	bookmark string
 }
 
 // NewField creates a new field with the specified type.
@@ -86,6 +105,20 @@ func NewTOCField(switches map[string]string) domain.Field {
 	return field
 }
 
+// NewSectionPagesField creates a field for the number of pages in the
+// section.
+func NewSectionPagesField() domain.Field {
+	return NewField(domain.FieldTypeSectionPages)
+}
+
+// NewPageRefField creates a PAGEREF field showing the page of a bookmark.
+func NewPageRefField(bookmark string) domain.Field {
+	field := NewField(domain.FieldTypePageRef).(*docxField)
+	field.properties["bookmark"] = bookmark
+	field.code = fmt.Sprintf(`%s %s \h`, constants.FieldCodePageRef, bookmark)
+	return field
+}
+
 // NewHyperlinkField creates a hyperlink field.
 func NewHyperlinkField(url, displayText string) domain.Field {
 	field := NewField(domain.FieldTypeHyperlink).(*docxField)
@@ -159,12 +192,33 @@ func (f *docxField) Update() error {
 	switch f.fieldType {
 	case domain.FieldTypePageNumber:
 		f.result = "1" // Placeholder - actual value determined by Word
-	case domain.FieldTypePageCount:
+		if f.pages != nil {
+			f.result = strconv.Itoa(f.pages.page)
+		}
+	case domain.FieldTypePageCount, domain.FieldTypeNumPages:
 		f.result = "1" // Placeholder - actual value determined by Word
-	case domain.FieldTypeNumPages:
+		if f.pages != nil {
+			f.result = strconv.Itoa(f.pages.pages)
+		}
+	case domain.FieldTypeSectionPages:
+		f.result = "1" // Placeholder - actual value determined by Word
+		if f.pages != nil {
+			f.result = strconv.Itoa(f.pages.sectionPages)
+		}
+	case domain.FieldTypePageRef:
 		f.result = "1" // Placeholder - actual value determined by Word
+		if f.pages != nil {
+			if page, ok := f.pages.bookmarks[f.bookmark()]; ok {
+				f.result = strconv.Itoa(page)
+			} else {
+				f.result = "Error! Bookmark not defined."
+			}
+		}
 	case domain.FieldTypeTOC:
 		f.result = "Table of Contents" // Placeholder
+		if f.pages != nil {
+			f.result = f.tocResult()
+		}
 	case domain.FieldTypeStyleRef:
 		f.result = "" // Placeholder - populated by Word
 	case domain.FieldTypeHyperlink:
@@ -222,6 +276,10 @@ func (f *docxField) getDefaultCode() string {
 		return constants.FieldCodeSeq + ` Figure`
 	case domain.FieldTypeRef:
 		return constants.FieldCodeRef
+	case domain.FieldTypeSectionPages:
+		return constants.FieldCodeSectionPages
+	case domain.FieldTypePageRef:
+		return constants.FieldCodePageRef
 	case domain.FieldTypeHyperlink:
 		return "HYPERLINK" // Hyperlink fields use HYPERLINK code
 	case domain.FieldTypeCustom:
@@ -264,6 +322,90 @@ func (f *docxField) buildTOCCode() string {
 	return code
 }
 
+// setPageValues sets the values page fields show and marks the field for
+// an update.
+func (f *docxField) setPageValues(values pageValues) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.pages = &values
+	f.isDirty = true
+}
+
+// bookmark returns the bookmark a PAGEREF field points to.
+func (f *docxField) bookmark() string {
+	if name, ok := f.properties["bookmark"]; ok {
+		return name
+	}
+	parts := strings.Fields(f.code)
+	if len(parts) > 1 && strings.EqualFold(parts[0], constants.FieldCodePageRef) {
+		return parts[1]
+	}
+	return ""
+}
+
+var (
+	tocLevelsPattern = regexp.MustCompile(`\\+o\s*"(\d)-(\d)"`)
+	tocNoPagePattern = regexp.MustCompile(`\\+n\b`)
+)
+
+// tocLevels returns the heading levels a TOC field lists, from its \o
+// switch.
+func (f *docxField) tocLevels() (int, int) {
+	m := tocLevelsPattern.FindStringSubmatch(f.code)
+	if m == nil {
+		return 1, 3
+	}
+	from, _ := strconv.Atoi(m[1])
+	to, _ := strconv.Atoi(m[2])
+	return from, to
+}
+
+// tocResult lists the headings of the document, one per line, each
+// followed by a tab and its page. The serializer writes the entries of
+// TOCEntries as TOC paragraphs instead.
+func (f *docxField) tocResult() string {
+	var lines []string
+	f.eachTOCEntry(func(_ int, text, page, _ string) {
+		if page != "" {
+			text += "\t" + page
+		}
+		lines = append(lines, text)
+	})
+	if len(lines) == 0 {
+		return "No table of contents entries found."
+	}
+	return strings.Join(lines, "\n")
+}
+
+// TOCEntries calls fn for each entry of a TOC field updated by
+// Document.UpdateFields, with the heading level, text, page ("" when the
+// field hides pages) and bookmark of the heading. Other fields have no
+// entries.
+func (f *docxField) TOCEntries(fn func(level int, text, page, bookmark string)) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	f.eachTOCEntry(fn)
+}
+
+// eachTOCEntry lists the headings within the levels of the field.
+func (f *docxField) eachTOCEntry(fn func(level int, text, page, bookmark string)) {
+	if f.fieldType != domain.FieldTypeTOC || f.pages == nil {
+		return
+	}
+	from, to := f.tocLevels()
+	hidePages := f.properties["hidePageNumbers"] == "true" || tocNoPagePattern.MatchString(f.code)
+	for _, entry := range f.pages.headings {
+		if entry.level < from || entry.level > to {
+			continue
+		}
+		page := ""
+		if !hidePages {
+			page = strconv.Itoa(entry.page)
+		}
+		fn(entry.level, entry.text, page, entry.bookmark)
+	}
+}
+
 // SetProperty sets a field property (for advanced customization).
 func (f *docxField) SetProperty(key, value string) {
 	f.mu.Lock()
@@ -0,0 +1,113 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/manager"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// docxFootnote implements domain.Footnote.
+type docxFootnote struct {
+	id           int
+	paragraphs   []domain.Paragraph
+	idGen        IDGenerator
+	relManager   *manager.RelationshipManager
+	mediaManager *manager.MediaManager
+}
+
+// ID returns the footnote number used in footnotes.xml.
+func (f *docxFootnote) ID() int {
+	return f.id
+}
+
+// AddParagraph adds a paragraph to the footnote text.
+func (f *docxFootnote) AddParagraph() (domain.Paragraph, error) {
+	para := NewParagraph(f.idGen.NextParagraphID(), f.idGen, f.relManager, f.mediaManager)
+	if err := para.SetStyle(domain.StyleIDFootnoteText); err != nil {
+		return nil, errors.Wrap(err, "Footnote.AddParagraph")
+	}
+	f.paragraphs = append(f.paragraphs, para)
+	return para, nil
+}
+
+// Paragraphs returns the paragraphs of the footnote text.
+func (f *docxFootnote) Paragraphs() []domain.Paragraph {
+	paragraphs := make([]domain.Paragraph, len(f.paragraphs))
+	copy(paragraphs, f.paragraphs)
+	return paragraphs
+}
+
+// Text returns the plain text of the footnote, one line per paragraph.
+func (f *docxFootnote) Text() string {
+	lines := make([]string, len(f.paragraphs))
+	for i, para := range f.paragraphs {
+		lines[i] = para.Text()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// AddFootnote adds a footnote reference at the end of the paragraph.
+func (p *paragraph) AddFootnote() (domain.Footnote, error) {
+	id := len(p.footnotes) + 1
+	if gen, ok := p.idGen.(interface{ NextFootnoteID() string }); ok {
+		n, err := strconv.Atoi(strings.TrimPrefix(gen.NextFootnoteID(), constants.IDPrefixFootnote))
+		if err != nil {
+			return nil, errors.Wrap(err, "Paragraph.AddFootnote")
+		}
+		id = n
+	}
+	note := &docxFootnote{id: id, idGen: p.idGen, relManager: p.relManager, mediaManager: p.mediaManager}
+
+	run := NewRun(p.idGen.NextRunID(), p.relManager)
+	if setter, ok := run.(interface{ setFootnote(domain.Footnote) }); ok {
+		setter.setFootnote(note)
+	}
+	p.runs = append(p.runs, run)
+	p.footnotes = append(p.footnotes, note)
+	return note, nil
+}
+
+// Footnotes returns the footnotes referenced from this paragraph.
+func (p *paragraph) Footnotes() []domain.Footnote {
+	footnotes := make([]domain.Footnote, len(p.footnotes))
+	copy(footnotes, p.footnotes)
+	return footnotes
+}
+
+// Footnote returns the footnote referenced by this run, if any.
+func (r *run) Footnote() domain.Footnote {
+	return r.footnote
+}
+
+// setFootnote makes the run a reference to a footnote.
+func (r *run) setFootnote(note domain.Footnote) {
+	r.footnote = note
+}
@@ -25,17 +25,22 @@ SOFTWARE.
 package core
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
-	"image"
 	_ "image/gif"  // Register GIF format decoder
 	_ "image/jpeg" // Register JPEG format decoder
-	_ "image/png"  // Register PNG format decoder
+	"image/png"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/manager"
+	"github.com/mmonterroca/docxgo/v2/internal/svg"
+	"github.com/mmonterroca/docxgo/v2/internal/webp"
 	"github.com/mmonterroca/docxgo/v2/pkg/constants"
 	"github.com/mmonterroca/docxgo/v2/pkg/errors"
 )
@@ -51,20 +56,41 @@ type docxImage struct {
 	target         string
 	description    string
 	position       domain.ImagePosition
+
+	// SVG images carry a bitmap rendition for viewers without SVG support.
+	fallback       []byte
+	fallbackRelID  string
+	fallbackTarget string
+
+	crop           domain.ImageCrop
+	rotation       float64
+	flipH, flipV   bool
+	border         domain.ImageBorder
+	effects        domain.ImageEffects
+	hyperlink      string
+	hyperlinkRelID string
+	relManager     *manager.RelationshipManager
+	mediaManager   *manager.MediaManager
 }
 
 // NewImage creates a new image from a file path.
 func NewImage(id, path string) (domain.Image, error) {
+	// Detect format from extension before reading, so other files are
+	// never loaded
+	format := detectImageFormat(path)
+	if format == "" {
+		return nil, errors.InvalidArgument("NewImage", "path", path, "unsupported image format")
+	}
+
 	// Read image file
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "NewImage")
 	}
 
-	// Detect format from extension
-	format := detectImageFormat(path)
-	if format == "" {
-		return nil, errors.InvalidArgument("NewImage", "path", path, "unsupported image format")
+	data, converted, err := convertWebP(data, format)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewImage")
 	}
 
 	// Get image dimensions
@@ -75,9 +101,10 @@ func NewImage(id, path string) (domain.Image, error) {
 
 	// Generate target path
 	ext := strings.ToLower(filepath.Ext(path))
-	if ext == "" {
-		ext = "." + string(format)
+	if ext == "" || converted != format {
+		ext = "." + string(converted)
 	}
+	format = converted
 	target := fmt.Sprintf("media/image%s%s", id, ext)
 
 	return &docxImage{
@@ -223,6 +250,77 @@ func (img *docxImage) Data() []byte {
 	return data
 }
 
+// ReplaceData swaps the picture for new image data. Formatting and position
+// are kept. The previous media part and its relationship are removed unless
+// another image still uses them.
+func (img *docxImage) ReplaceData(data []byte, keepAspect bool) error {
+	if len(data) == 0 {
+		return errors.InvalidArgument("Image.ReplaceData", "data", nil, "image data cannot be empty")
+	}
+	format := detectImageFormatFromData(data)
+	if format == "" {
+		return errors.InvalidArgument("Image.ReplaceData", "data", nil, "unsupported image format")
+	}
+	data, format, err := convertWebP(data, format)
+	if err != nil {
+		return errors.Wrap(err, "Image.ReplaceData")
+	}
+	natural, err := getImageDimensions(data)
+	if err != nil {
+		return errors.Wrap(err, "Image.ReplaceData")
+	}
+
+	size := img.size
+	if keepAspect && natural.WidthEMU > 0 && natural.HeightEMU > 0 {
+		scale := min(float64(size.WidthEMU)/float64(natural.WidthEMU), float64(size.HeightEMU)/float64(natural.HeightEMU))
+		size.WidthEMU = int(math.Round(float64(natural.WidthEMU) * scale))
+		size.HeightEMU = int(math.Round(float64(natural.HeightEMU) * scale))
+		size.WidthPx = int(math.Round(float64(size.WidthEMU) / 9525))
+		size.HeightPx = int(math.Round(float64(size.HeightEMU) / 9525))
+	}
+
+	target := fmt.Sprintf("media/image%s.%s", img.id, format)
+	relID := img.relationshipID
+	if img.mediaManager != nil && img.relManager != nil {
+		_, mediaPath, err := img.mediaManager.Add(data, "image."+string(format))
+		if err != nil {
+			return errors.Wrap(err, "Image.ReplaceData")
+		}
+		target = strings.TrimPrefix(mediaPath, "word/")
+		if relID, err = img.relManager.AddImage(target); err != nil {
+			return errors.Wrap(err, "Image.ReplaceData")
+		}
+	}
+
+	oldRelID, oldTarget := img.relationshipID, img.target
+	oldFallbackRelID, oldFallbackTarget := img.fallbackRelID, img.fallbackTarget
+	img.data, img.format = data, format
+	img.size, img.originalSize = size, natural
+	img.relationshipID, img.target = relID, target
+	img.fallback, img.fallbackRelID, img.fallbackTarget = nil, "", ""
+	if img.mediaManager != nil && img.relManager != nil {
+		img.releaseMedia(oldRelID, oldTarget)
+		img.releaseMedia(oldFallbackRelID, oldFallbackTarget)
+	}
+	if format == domain.ImageFormatSVG && img.mediaManager != nil && img.relManager != nil {
+		if err := registerSVGFallback(img, img.relManager, img.mediaManager); err != nil {
+			return errors.Wrap(err, "Image.ReplaceData")
+		}
+	}
+	return nil
+}
+
+// releaseMedia drops the image's reference to a media part, deleting the
+// relationship to it once the part is no longer used.
+func (img *docxImage) releaseMedia(relID, target string) {
+	if target == "" || !img.mediaManager.Release("word/"+target) {
+		return
+	}
+	if rel, err := img.relManager.Get(relID); err == nil && rel.Target == target {
+		_ = img.relManager.Delete(relID)
+	}
+}
+
 // RelationshipID returns the relationship ID for this image.
 func (img *docxImage) RelationshipID() string {
 	return img.relationshipID
@@ -265,6 +363,73 @@ func (img *docxImage) SetPosition(pos domain.ImagePosition) error {
 	return nil
 }
 
+// Fallback returns the bitmap rendition stored alongside an SVG image, or nil.
+func (img *docxImage) Fallback() []byte {
+	if img.fallback == nil {
+		return nil
+	}
+	data := make([]byte, len(img.fallback))
+	copy(data, img.fallback)
+	return data
+}
+
+// SetFallback sets the PNG rendition written alongside an SVG image.
+func (img *docxImage) SetFallback(data []byte) error {
+	if img.format != domain.ImageFormatSVG {
+		return errors.InvalidState("Image.SetFallback", "fallback images are only used for SVG images")
+	}
+	if len(data) == 0 {
+		return errors.InvalidArgument("Image.SetFallback", "data", data, "fallback data cannot be empty")
+	}
+	img.fallback = make([]byte, len(data))
+	copy(img.fallback, data)
+	return nil
+}
+
+// FallbackRelationshipID returns the relationship ID of the fallback bitmap.
+func (img *docxImage) FallbackRelationshipID() string {
+	return img.fallbackRelID
+}
+
+// FallbackTarget returns the package path of the fallback bitmap.
+func (img *docxImage) FallbackTarget() string {
+	return img.fallbackTarget
+}
+
+// SetFallbackRelationship records where the fallback bitmap is stored
+// (called by the paragraph and the reader).
+func (img *docxImage) SetFallbackRelationship(relID, target string) {
+	img.fallbackRelID = relID
+	img.fallbackTarget = target
+}
+
+// maxFallbackPixels caps the longest side of rendered SVG fallbacks.
+const maxFallbackPixels = 2048
+
+// renderSVGFallback rasterizes an SVG image to PNG at twice its display size,
+// which keeps the bitmap sharp on high-density screens.
+func renderSVGFallback(img *docxImage) ([]byte, error) {
+	w, h := img.size.WidthPx*2, img.size.HeightPx*2
+	if w <= 0 || h <= 0 {
+		w, h = img.originalSize.WidthPx*2, img.originalSize.HeightPx*2
+	}
+	if longest := max(w, h); longest > maxFallbackPixels {
+		w = max(1, w*maxFallbackPixels/longest)
+		h = max(1, h*maxFallbackPixels/longest)
+	}
+
+	raster, err := svg.Rasterize(img.data, w, h)
+	if err != nil {
+		return nil, errors.Wrap(err, "renderSVGFallback")
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, raster); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeInternal, "renderSVGFallback")
+	}
+	return buf.Bytes(), nil
+}
+
 // detectImageFormat detects the image format from file extension.
 func detectImageFormat(path string) domain.ImageFormat {
 	ext := strings.ToLower(filepath.Ext(path))
@@ -285,6 +450,10 @@ func detectImageFormat(path string) domain.ImageFormat {
 		return domain.ImageFormatSVG
 	case "webp":
 		return domain.ImageFormatWEBP
+	case "emf":
+		return domain.ImageFormatEMF
+	case "wmf":
+		return domain.ImageFormatWMF
 	default:
 		return ""
 	}
@@ -302,27 +471,45 @@ func formatFromContentType(contentType string) domain.ImageFormat {
 		return domain.ImageFormatBMP
 	case constants.ContentTypeTIFF:
 		return domain.ImageFormatTIFF
+	case constants.ContentTypeSVG:
+		return domain.ImageFormatSVG
+	case constants.ContentTypeEMF:
+		return domain.ImageFormatEMF
+	case constants.ContentTypeWMF:
+		return domain.ImageFormatWMF
 	default:
 		return ""
 	}
 }
 
-// getImageDimensions reads image dimensions from image data.
+// getImageDimensions reads image dimensions from image data. The display
+// size honours the resolution stored in the file and assumes 96 DPI when
+// there is none. SVG sizes come from the width, height and viewBox attributes.
 func getImageDimensions(data []byte) (domain.ImageSize, error) {
-	// Decode image to get dimensions
-	img, format, err := image.DecodeConfig(strings.NewReader(string(data)))
+	info, err := readImageInfo(data)
 	if err != nil {
-		// If decode fails, try reading as binary
-		reader := strings.NewReader(string(data))
-		img, format, err = image.DecodeConfig(reader)
-		if err != nil {
-			return domain.ImageSize{}, errors.Wrap(err, "getImageDimensions")
-		}
+		return domain.ImageSize{}, errors.Wrap(err, "getImageDimensions")
 	}
+	return info.size(), nil
+}
 
-	_ = format // format string is for logging if needed
+// convertWebP re-encodes WebP data as PNG, since Word cannot display WebP.
+// Other data is returned unchanged.
+func convertWebP(data []byte, format domain.ImageFormat) ([]byte, domain.ImageFormat, error) {
+	if !webp.IsWebP(data) {
+		return data, format, nil
+	}
+
+	decoded, err := webp.Decode(data)
+	if err != nil {
+		return nil, "", errors.Wrap(err, "convertWebP")
+	}
 
-	return domain.NewImageSize(img.Width, img.Height), nil
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, decoded); err != nil {
+		return nil, "", errors.WrapWithCode(err, errors.ErrCodeInternal, "convertWebP")
+	}
+	return buf.Bytes(), domain.ImageFormatPNG, nil
 }
 
 // ReadImageFromReader creates an image from an io.Reader.
@@ -333,23 +520,85 @@ func ReadImageFromReader(id string, reader io.Reader, format domain.ImageFormat)
 		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "ReadImageFromReader")
 	}
 
-	// Get dimensions
-	size, err := getImageDimensions(data)
+	img, err := NewImageFromBytes(id, data, format)
 	if err != nil {
 		return nil, errors.Wrap(err, "ReadImageFromReader")
 	}
+	return img, nil
+}
 
-	// Generate target
-	target := fmt.Sprintf("media/image%s.%s", id, format)
+// NewImageFromBytes creates an image from in-memory data. When format is
+// empty it is detected from the data.
+func NewImageFromBytes(id string, data []byte, format domain.ImageFormat) (domain.Image, error) {
+	if len(data) == 0 {
+		return nil, errors.InvalidArgument("NewImageFromBytes", "data", data, "image data cannot be empty")
+	}
+
+	if format == "" {
+		format = detectImageFormatFromData(data)
+		if format == "" {
+			return nil, errors.InvalidArgument("NewImageFromBytes", "format", format, "unable to detect image format")
+		}
+	}
+
+	converted, format, err := convertWebP(data, format)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewImageFromBytes")
+	}
+
+	size, err := getImageDimensions(converted)
+	if err != nil {
+		return nil, errors.Wrap(err, "NewImageFromBytes")
+	}
+
+	copyData := make([]byte, len(converted))
+	copy(copyData, converted)
 
 	return &docxImage{
 		id:           id,
 		format:       format,
 		size:         size,
 		originalSize: size,
-		data:         data,
-		target:       target,
+		data:         copyData,
+		target:       fmt.Sprintf("media/image%s.%s", id, format),
 		description:  "",
 		position:     domain.DefaultImagePosition(),
 	}, nil
 }
+
+// detectImageFormatFromData identifies the image format from its signature.
+func detectImageFormatFromData(data []byte) domain.ImageFormat {
+	switch {
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return domain.ImageFormatPNG
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return domain.ImageFormatJPEG
+	case bytes.HasPrefix(data, []byte("GIF87a")), bytes.HasPrefix(data, []byte("GIF89a")):
+		return domain.ImageFormatGIF
+	case bytes.HasPrefix(data, []byte("BM")):
+		return domain.ImageFormatBMP
+	case bytes.HasPrefix(data, []byte("II*\x00")), bytes.HasPrefix(data, []byte("MM\x00*")):
+		return domain.ImageFormatTIFF
+	case len(data) >= 12 && bytes.HasPrefix(data, []byte("RIFF")) && string(data[8:12]) == "WEBP":
+		return domain.ImageFormatWEBP
+	case len(data) >= 44 && binary.LittleEndian.Uint32(data) == 1 && string(data[40:44]) == " EMF":
+		return domain.ImageFormatEMF
+	case bytes.HasPrefix(data, wmfPlaceableKey), isWMFHeader(data):
+		return domain.ImageFormatWMF
+	}
+
+	if svg.IsSVG(data) {
+		return domain.ImageFormatSVG
+	}
+	return ""
+}
+
+// isWMFHeader reports whether data starts with a standard metafile header:
+// a memory or disk type followed by the header size of nine words.
+func isWMFHeader(data []byte) bool {
+	if len(data) < 18 {
+		return false
+	}
+	kind := binary.LittleEndian.Uint16(data)
+	return (kind == 1 || kind == 2) && binary.LittleEndian.Uint16(data[2:]) == 9
+}
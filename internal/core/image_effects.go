@@ -0,0 +1,188 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"math"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/manager"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Crop returns the picture cropping.
+func (img *docxImage) Crop() domain.ImageCrop {
+	return img.crop
+}
+
+// SetCrop trims the picture edges by percentages of the original image.
+func (img *docxImage) SetCrop(crop domain.ImageCrop) error {
+	for _, v := range []float64{crop.Left, crop.Top, crop.Right, crop.Bottom} {
+		if v < 0 || v > 100 || math.IsNaN(v) {
+			return errors.InvalidArgument("Image.SetCrop", "crop", crop, "crop values must be between 0 and 100 percent")
+		}
+	}
+	if crop.Left+crop.Right >= 100 || crop.Top+crop.Bottom >= 100 {
+		return errors.InvalidArgument("Image.SetCrop", "crop", crop, "crop removes the whole image")
+	}
+	img.crop = crop
+	return nil
+}
+
+// Rotation returns the clockwise rotation in degrees.
+func (img *docxImage) Rotation() float64 {
+	return img.rotation
+}
+
+// SetRotation rotates the picture clockwise. Angles are normalized to
+// [0, 360).
+func (img *docxImage) SetRotation(degrees float64) error {
+	if math.IsNaN(degrees) || math.IsInf(degrees, 0) {
+		return errors.InvalidArgument("Image.SetRotation", "degrees", degrees, "rotation must be a finite angle")
+	}
+	degrees = math.Mod(degrees, 360)
+	if degrees < 0 {
+		degrees += 360
+	}
+	img.rotation = degrees
+	return nil
+}
+
+// Flip reports whether the picture is mirrored.
+func (img *docxImage) Flip() (horizontal, vertical bool) {
+	return img.flipH, img.flipV
+}
+
+// SetFlip mirrors the picture horizontally and/or vertically.
+func (img *docxImage) SetFlip(horizontal, vertical bool) error {
+	img.flipH, img.flipV = horizontal, vertical
+	return nil
+}
+
+// Border returns the picture outline.
+func (img *docxImage) Border() domain.ImageBorder {
+	return img.border
+}
+
+// maxBorderWidth is the widest picture outline in points, the 20116800
+// EMU limit of a:ln.
+const maxBorderWidth = 20116800 / 12700
+
+// SetBorder sets the picture outline. A zero width removes it; widths
+// over 1584 points are clamped to it.
+func (img *docxImage) SetBorder(border domain.ImageBorder) error {
+	if math.IsNaN(border.Width) || math.IsInf(border.Width, 0) {
+		return errors.InvalidArgument("Image.SetBorder", "width", border.Width, "border width must be finite")
+	}
+	if border.Width < 0 {
+		return errors.InvalidArgument("Image.SetBorder", "width", border.Width, "border width cannot be negative")
+	}
+	border.Width = math.Min(border.Width, maxBorderWidth)
+	img.border = border
+	return nil
+}
+
+// Effects returns the picture effects.
+func (img *docxImage) Effects() domain.ImageEffects {
+	return img.effects
+}
+
+// SetEffects applies shadow, reflection and color adjustments.
+func (img *docxImage) SetEffects(effects domain.ImageEffects) error {
+	switch effects.Shadow {
+	case domain.ShadowNone, domain.ShadowOuter, domain.ShadowCentered, domain.ShadowInner:
+	default:
+		return errors.InvalidArgument("Image.SetEffects", "shadow", effects.Shadow, "unknown shadow preset")
+	}
+	switch effects.Reflection {
+	case domain.ReflectionNone, domain.ReflectionTight, domain.ReflectionHalf, domain.ReflectionFull:
+	default:
+		return errors.InvalidArgument("Image.SetEffects", "reflection", effects.Reflection, "unknown reflection preset")
+	}
+	if !inPercentRange(effects.Brightness, -100) {
+		return errors.InvalidArgument("Image.SetEffects", "brightness", effects.Brightness, "brightness must be between -100 and 100")
+	}
+	if !inPercentRange(effects.Contrast, -100) {
+		return errors.InvalidArgument("Image.SetEffects", "contrast", effects.Contrast, "contrast must be between -100 and 100")
+	}
+	if !inPercentRange(effects.Transparency, 0) {
+		return errors.InvalidArgument("Image.SetEffects", "transparency", effects.Transparency, "transparency must be between 0 and 100")
+	}
+	img.effects = effects
+	return nil
+}
+
+// inPercentRange reports whether v is a finite percentage between minimum
+// and 100. NaN fails every comparison, so it is checked explicitly.
+func inPercentRange(v, minimum float64) bool {
+	return !math.IsNaN(v) && !math.IsInf(v, 0) && v >= minimum && v <= 100
+}
+
+// Hyperlink returns the URL opened when the picture is clicked.
+func (img *docxImage) Hyperlink() string {
+	return img.hyperlink
+}
+
+// SetHyperlink makes the picture a link. The relationship is created when the
+// image is attached to a document, or immediately if it already is.
+func (img *docxImage) SetHyperlink(url string) error {
+	if img.relManager != nil && img.hyperlinkRelID != "" {
+		if err := img.relManager.Delete(img.hyperlinkRelID); err != nil {
+			return errors.Wrap(err, "Image.SetHyperlink")
+		}
+	}
+	img.hyperlink, img.hyperlinkRelID = url, ""
+	if img.relManager == nil || url == "" {
+		return nil
+	}
+
+	relID, err := img.relManager.AddHyperlink(url)
+	if err != nil {
+		return errors.Wrap(err, "Image.SetHyperlink")
+	}
+	img.hyperlinkRelID = relID
+	return nil
+}
+
+// HyperlinkRelationshipID returns the relationship ID of the picture link.
+func (img *docxImage) HyperlinkRelationshipID() string {
+	return img.hyperlinkRelID
+}
+
+// SetHyperlinkRelationship records a link read from an existing document,
+// whose relationship is already registered.
+func (img *docxImage) SetHyperlinkRelationship(url, relID string) {
+	img.hyperlink, img.hyperlinkRelID = url, relID
+}
+
+// bind ties the image to the relationship and media managers of the part it
+// is placed in, registering any link set before it was attached.
+func (img *docxImage) bind(rm *manager.RelationshipManager, mm *manager.MediaManager) error {
+	img.relManager, img.mediaManager = rm, mm
+	if img.hyperlink == "" || img.hyperlinkRelID != "" {
+		return nil
+	}
+	return img.SetHyperlink(img.hyperlink)
+}
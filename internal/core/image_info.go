@@ -0,0 +1,372 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"math"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/svg"
+	"github.com/mmonterroca/docxgo/v2/internal/webp"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// imageInfo holds the pixel size and resolution read from an image header.
+// A zero DPI means the file does not record one.
+type imageInfo struct {
+	width, height int
+	dpiX, dpiY    float64
+}
+
+// size converts the header information to display dimensions.
+func (info imageInfo) size() domain.ImageSize {
+	return domain.NewImageSizeDPI(info.width, info.height, info.dpiX, info.dpiY)
+}
+
+// readImageInfo parses just enough of an image header to learn its pixel
+// size and resolution.
+func readImageInfo(data []byte) (imageInfo, error) {
+	var (
+		info imageInfo
+		err  error
+	)
+	switch detectImageFormatFromData(data) {
+	case domain.ImageFormatPNG:
+		info, err = readPNGInfo(data)
+	case domain.ImageFormatJPEG:
+		info, err = readJPEGInfo(data)
+	case domain.ImageFormatGIF:
+		info, err = readDecodedInfo(data)
+	case domain.ImageFormatBMP:
+		info, err = readBMPInfo(data)
+	case domain.ImageFormatTIFF:
+		info, err = readTIFFInfo(data)
+	case domain.ImageFormatWEBP:
+		info.width, info.height, err = webp.DecodeConfig(data)
+	case domain.ImageFormatEMF:
+		info, err = readEMFInfo(data)
+	case domain.ImageFormatWMF:
+		info, err = readWMFInfo(data)
+	case domain.ImageFormatSVG:
+		var w, h float64
+		w, h, err = svg.Size(data)
+		info.width, info.height = max(1, int(math.Round(w))), max(1, int(math.Round(h)))
+	default:
+		return imageInfo{}, errors.Unsupported("readImageInfo", "unknown image format")
+	}
+	if err != nil {
+		return imageInfo{}, errors.Wrap(err, "readImageInfo")
+	}
+	if info.width <= 0 || info.height <= 0 {
+		return imageInfo{}, errors.InvalidArgument("readImageInfo", "data", len(data), "image has no dimensions")
+	}
+	info.dpiX, info.dpiY = saneDPI(info.dpiX), saneDPI(info.dpiY)
+	return info, nil
+}
+
+// saneDPI discards resolutions no real image uses, which usually come from
+// files that leave the field at a placeholder value.
+func saneDPI(dpi float64) float64 {
+	if dpi < 1 || dpi > 10000 || math.IsNaN(dpi) {
+		return 0
+	}
+	return dpi
+}
+
+func readDecodedInfo(data []byte) (imageInfo, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return imageInfo{}, err
+	}
+	return imageInfo{width: cfg.Width, height: cfg.Height}, nil
+}
+
+// readPNGInfo reads IHDR and the optional pHYs chunk, which stores pixels
+// per metre.
+func readPNGInfo(data []byte) (imageInfo, error) {
+	if len(data) < 24 || string(data[12:16]) != "IHDR" {
+		return imageInfo{}, errors.InvalidArgument("readPNGInfo", "data", len(data), "missing IHDR chunk")
+	}
+	info := imageInfo{
+		width:  int(binary.BigEndian.Uint32(data[16:20])),
+		height: int(binary.BigEndian.Uint32(data[20:24])),
+	}
+
+	for pos := 8; pos+8 <= len(data); {
+		length := int(binary.BigEndian.Uint32(data[pos : pos+4]))
+		kind := string(data[pos+4 : pos+8])
+		body := pos + 8
+		if length < 0 || body+length > len(data) || kind == "IDAT" || kind == "IEND" {
+			break
+		}
+		if kind == "pHYs" && length >= 9 && data[body+8] == 1 {
+			info.dpiX = float64(binary.BigEndian.Uint32(data[body:body+4])) * 0.0254
+			info.dpiY = float64(binary.BigEndian.Uint32(data[body+4:body+8])) * 0.0254
+		}
+		pos = body + length + 4 // skip the CRC
+	}
+	return info, nil
+}
+
+// readJPEGInfo takes the size from the frame header and the resolution from
+// the JFIF APP0 segment.
+func readJPEGInfo(data []byte) (imageInfo, error) {
+	info, err := readDecodedInfo(data)
+	if err != nil {
+		return imageInfo{}, err
+	}
+
+	for pos := 2; pos+4 <= len(data) && data[pos] == 0xFF; {
+		marker := data[pos+1]
+		if marker == 0xDA || marker == 0xD9 {
+			break // start of scan: no more header segments
+		}
+		length := int(binary.BigEndian.Uint16(data[pos+2 : pos+4]))
+		if length < 2 {
+			break
+		}
+		seg := data[pos+4 : min(len(data), pos+2+length)]
+		if marker == 0xE0 && len(seg) >= 12 && string(seg[:5]) == "JFIF\x00" {
+			x := float64(binary.BigEndian.Uint16(seg[8:10]))
+			y := float64(binary.BigEndian.Uint16(seg[10:12]))
+			switch seg[7] {
+			case 1: // dots per inch
+				info.dpiX, info.dpiY = x, y
+			case 2: // dots per centimetre
+				info.dpiX, info.dpiY = x*2.54, y*2.54
+			}
+			break
+		}
+		pos += 2 + length
+	}
+	return info, nil
+}
+
+// readBMPInfo reads the DIB header that follows the 14-byte file header.
+func readBMPInfo(data []byte) (imageInfo, error) {
+	if len(data) < 26 {
+		return imageInfo{}, errors.InvalidArgument("readBMPInfo", "data", len(data), "truncated BMP header")
+	}
+	le := binary.LittleEndian
+	headerSize := le.Uint32(data[14:18])
+	if headerSize == 12 { // BITMAPCOREHEADER
+		return imageInfo{
+			width:  int(le.Uint16(data[18:20])),
+			height: int(le.Uint16(data[20:22])),
+		}, nil
+	}
+
+	info := imageInfo{
+		width:  int(int32(le.Uint32(data[18:22]))),
+		height: int(int32(le.Uint32(data[22:26]))),
+	}
+	if info.height < 0 { // top-down bitmap
+		info.height = -info.height
+	}
+	if headerSize >= 40 && len(data) >= 46 {
+		info.dpiX = float64(int32(le.Uint32(data[38:42]))) * 0.0254
+		info.dpiY = float64(int32(le.Uint32(data[42:46]))) * 0.0254
+	}
+	return info, nil
+}
+
+// TIFF tags and field types used by readTIFFInfo.
+const (
+	tiffImageWidth     = 256
+	tiffImageLength    = 257
+	tiffXResolution    = 282
+	tiffYResolution    = 283
+	tiffResolutionUnit = 296
+
+	tiffShort    = 3
+	tiffLong     = 4
+	tiffRational = 5
+)
+
+// readTIFFInfo walks the first image file directory.
+func readTIFFInfo(data []byte) (imageInfo, error) {
+	if len(data) < 8 {
+		return imageInfo{}, errors.InvalidArgument("readTIFFInfo", "data", len(data), "truncated TIFF header")
+	}
+	var order binary.ByteOrder = binary.LittleEndian
+	if data[0] == 'M' {
+		order = binary.BigEndian
+	}
+
+	ifd := int(order.Uint32(data[4:8]))
+	if ifd < 8 || ifd+2 > len(data) {
+		return imageInfo{}, errors.InvalidArgument("readTIFFInfo", "data", ifd, "invalid IFD offset")
+	}
+
+	var (
+		info       imageInfo
+		xRes, yRes float64
+		unit       = 2 // inches unless the file says otherwise
+	)
+	count := int(order.Uint16(data[ifd : ifd+2]))
+	for i := 0; i < count; i++ {
+		entry := ifd + 2 + i*12
+		if entry+12 > len(data) {
+			break
+		}
+		tag := order.Uint16(data[entry : entry+2])
+		kind := order.Uint16(data[entry+2 : entry+4])
+		value := data[entry+8 : entry+12]
+
+		integer := func() int {
+			if kind == tiffShort {
+				return int(order.Uint16(value[:2]))
+			}
+			return int(order.Uint32(value))
+		}
+		rational := func() float64 {
+			off := int(order.Uint32(value))
+			if kind != tiffRational || off < 0 || off+8 > len(data) {
+				return 0
+			}
+			den := order.Uint32(data[off+4 : off+8])
+			if den == 0 {
+				return 0
+			}
+			return float64(order.Uint32(data[off:off+4])) / float64(den)
+		}
+
+		switch tag {
+		case tiffImageWidth:
+			info.width = integer()
+		case tiffImageLength:
+			info.height = integer()
+		case tiffXResolution:
+			xRes = rational()
+		case tiffYResolution:
+			yRes = rational()
+		case tiffResolutionUnit:
+			unit = integer()
+		}
+	}
+
+	switch unit {
+	case 2:
+		info.dpiX, info.dpiY = xRes, yRes
+	case 3: // centimetres
+		info.dpiX, info.dpiY = xRes*2.54, yRes*2.54
+	}
+	return info, nil
+}
+
+// readEMFInfo reads the EMR_HEADER record. The picture frame, in hundredths of
+// a millimetre, gives the intended physical size; the bounds give the size in
+// device pixels.
+func readEMFInfo(data []byte) (imageInfo, error) {
+	if len(data) < 88 {
+		return imageInfo{}, errors.InvalidArgument("readEMFInfo", "data", len(data), "truncated EMF header")
+	}
+	le := binary.LittleEndian
+	rect := func(off int) (int, int) {
+		left := int(int32(le.Uint32(data[off:])))
+		top := int(int32(le.Uint32(data[off+4:])))
+		right := int(int32(le.Uint32(data[off+8:])))
+		bottom := int(int32(le.Uint32(data[off+12:])))
+		return right - left, bottom - top
+	}
+
+	boundsW, boundsH := rect(8)
+	frameW, frameH := rect(24)
+
+	var info imageInfo
+	if frameW > 0 && frameH > 0 {
+		info.width = boundsW + 1
+		info.height = boundsH + 1
+		if boundsW <= 0 || boundsH <= 0 {
+			info.width = int(math.Round(float64(frameW) * 96 / 2540))
+			info.height = int(math.Round(float64(frameH) * 96 / 2540))
+		}
+		info.dpiX = float64(info.width) * 2540 / float64(frameW)
+		info.dpiY = float64(info.height) * 2540 / float64(frameH)
+		return info, nil
+	}
+
+	// No frame: fall back to the reference device resolution.
+	info.width, info.height = boundsW+1, boundsH+1
+	devW, devH := int32(le.Uint32(data[72:])), int32(le.Uint32(data[76:]))
+	mmW, mmH := int32(le.Uint32(data[80:])), int32(le.Uint32(data[84:]))
+	if mmW > 0 && mmH > 0 {
+		info.dpiX = float64(devW) * 25.4 / float64(mmW)
+		info.dpiY = float64(devH) * 25.4 / float64(mmH)
+	}
+	return info, nil
+}
+
+// wmfPlaceableKey starts an Aldus placeable metafile header.
+var wmfPlaceableKey = []byte{0xD7, 0xCD, 0xC6, 0x9A}
+
+// wmfSetWindowExt is the META_SETWINDOWEXT record function.
+const wmfSetWindowExt = 0x020C
+
+// readWMFInfo reads the placeable header's bounding box and units per inch.
+// Plain metafiles have no physical size, so their window extent is taken as
+// pixels at 96 DPI.
+func readWMFInfo(data []byte) (imageInfo, error) {
+	le := binary.LittleEndian
+	if bytes.HasPrefix(data, wmfPlaceableKey) {
+		if len(data) < 22 {
+			return imageInfo{}, errors.InvalidArgument("readWMFInfo", "data", len(data), "truncated placeable header")
+		}
+		left, top := int16(le.Uint16(data[6:])), int16(le.Uint16(data[8:]))
+		right, bottom := int16(le.Uint16(data[10:])), int16(le.Uint16(data[12:]))
+		inch := float64(le.Uint16(data[14:]))
+		if inch == 0 {
+			return imageInfo{}, errors.InvalidArgument("readWMFInfo", "inch", 0, "placeable header has no units per inch")
+		}
+		w := math.Abs(float64(right) - float64(left))
+		h := math.Abs(float64(bottom) - float64(top))
+		return imageInfo{
+			width:  int(math.Round(w * 96 / inch)),
+			height: int(math.Round(h * 96 / inch)),
+			dpiX:   96,
+			dpiY:   96,
+		}, nil
+	}
+
+	if len(data) < 18 {
+		return imageInfo{}, errors.InvalidArgument("readWMFInfo", "data", len(data), "truncated WMF header")
+	}
+	for pos := 18; pos+10 <= len(data); {
+		words := int(le.Uint32(data[pos:]))
+		if words < 3 {
+			break
+		}
+		if le.Uint16(data[pos+4:]) == wmfSetWindowExt {
+			h := int(math.Abs(float64(int16(le.Uint16(data[pos+6:])))))
+			w := int(math.Abs(float64(int16(le.Uint16(data[pos+8:])))))
+			return imageInfo{width: w, height: h}, nil
+		}
+		pos += words * 2
+	}
+	return imageInfo{}, errors.Unsupported("readWMFInfo", "WMF without placeable header or window extent")
+}
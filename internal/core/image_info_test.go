@@ -0,0 +1,259 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"hash/crc32"
+	"image"
+	"image/jpeg"
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+// testWebP is a 6x4 lossless WebP image.
+const testWebP = "524946463a000000574542505650384c2d0000002f05c000101730ff028222ff" +
+	"47131014f93f9a80a0e8bae58277262868db8669bb3f884337d811fd8fd88f43" +
+	"2c00"
+
+func le16(v int) []byte { return binary.LittleEndian.AppendUint16(nil, uint16(v)) }
+func le32(v int) []byte { return binary.LittleEndian.AppendUint32(nil, uint32(v)) }
+
+// pngWithPHYs inserts a pHYs chunk after IHDR.
+func pngWithPHYs(t *testing.T, width, height, ppm int) []byte {
+	body := append(binary.BigEndian.AppendUint32(nil, uint32(ppm)), binary.BigEndian.AppendUint32(nil, uint32(ppm))...)
+	body = append(body, 1)
+	chunk := binary.BigEndian.AppendUint32(nil, uint32(len(body)))
+	chunk = append(chunk, "pHYs"...)
+	chunk = append(chunk, body...)
+	chunk = binary.BigEndian.AppendUint32(chunk, crc32.ChecksumIEEE(chunk[4:]))
+
+	data := encodeTestPNG(t, width, height)
+	return append(append(append([]byte{}, data[:33]...), chunk...), data[33:]...)
+}
+
+// jpegWithJFIF replaces any APP0 segment with a JFIF header in the given units.
+func jpegWithJFIF(t *testing.T, width, height, units, x, y int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	app0 := []byte{0xFF, 0xE0, 0x00, 0x10, 'J', 'F', 'I', 'F', 0, 1, 1, byte(units)}
+	app0 = binary.BigEndian.AppendUint16(app0, uint16(x))
+	app0 = binary.BigEndian.AppendUint16(app0, uint16(y))
+	app0 = append(app0, 0, 0)
+	data := buf.Bytes()
+	return append(append(append([]byte{}, data[:2]...), app0...), data[2:]...)
+}
+
+func bmpHeader(width, height, ppm int) []byte {
+	data := append([]byte("BM"), make([]byte, 12)...)
+	data = append(data, le32(40)...)
+	data = append(data, le32(width)...)
+	data = append(data, le32(height)...)
+	data = append(data, le16(1)...)
+	data = append(data, le16(24)...)
+	data = append(data, make([]byte, 8)...)
+	data = append(data, le32(ppm)...)
+	data = append(data, le32(ppm)...)
+	return append(data, make([]byte, 8)...)
+}
+
+// tiffHeader builds a big-endian TIFF with a single IFD.
+func tiffHeader(width, height, xRes, yRes, unit int) []byte {
+	be := binary.BigEndian
+	const entries = 5
+	ratOff := 8 + 2 + entries*12 + 4
+	data := append([]byte("MM\x00*"), be.AppendUint32(nil, 8)...)
+	data = be.AppendUint16(data, entries)
+	entry := func(tag, kind, value int) {
+		data = be.AppendUint16(data, uint16(tag))
+		data = be.AppendUint16(data, uint16(kind))
+		data = be.AppendUint32(data, 1)
+		if kind == tiffShort {
+			data = be.AppendUint16(data, uint16(value))
+			data = append(data, 0, 0)
+		} else {
+			data = be.AppendUint32(data, uint32(value))
+		}
+	}
+	entry(tiffImageWidth, tiffLong, width)
+	entry(tiffImageLength, tiffShort, height)
+	entry(tiffXResolution, tiffRational, ratOff)
+	entry(tiffYResolution, tiffRational, ratOff+8)
+	entry(tiffResolutionUnit, tiffShort, unit)
+	data = be.AppendUint32(data, 0)
+	for _, res := range []int{xRes, yRes} {
+		data = be.AppendUint32(data, uint32(res))
+		data = be.AppendUint32(data, 1)
+	}
+	return data
+}
+
+// emfHeader builds an EMR_HEADER with the given pixel bounds and frame in
+// hundredths of a millimetre.
+func emfHeader(boundsW, boundsH, frameW, frameH int) []byte {
+	data := append(le32(1), le32(88)...)
+	for _, v := range []int{0, 0, boundsW - 1, boundsH - 1, 0, 0, frameW, frameH} {
+		data = append(data, le32(v)...)
+	}
+	data = append(data, " EMF"...)
+	data = append(data, make([]byte, 28)...)
+	for _, v := range []int{1920, 1080, 508, 286} {
+		data = append(data, le32(v)...)
+	}
+	return data
+}
+
+func placeableWMF(right, bottom, inch int) []byte {
+	data := append([]byte{}, wmfPlaceableKey...)
+	data = append(data, le16(0)...)
+	for _, v := range []int{0, 0, right, bottom, inch} {
+		data = append(data, le16(v)...)
+	}
+	data = append(data, make([]byte, 6)...)
+	return append(data, le16(1)...)
+}
+
+func plainWMF(width, height int) []byte {
+	data := append(le16(1), le16(9)...)
+	data = append(data, make([]byte, 14)...)
+	data = append(data, le32(5)...)
+	data = append(data, le16(wmfSetWindowExt)...)
+	data = append(data, le16(height)...)
+	return append(data, le16(width)...)
+}
+
+func TestReadImageInfo(t *testing.T) {
+	tests := []struct {
+		name          string
+		data          []byte
+		width, height int
+		emuW, emuH    int
+	}{
+		{"PNG without pHYs", encodeTestPNG(t, 96, 48), 96, 48, 914400, 457200},
+		{"PNG at 300 DPI", pngWithPHYs(t, 300, 150, 11811), 300, 150, 914400, 457200},
+		{"JPEG dots per inch", jpegWithJFIF(t, 144, 72, 1, 144, 72), 144, 72, 914400, 914400},
+		{"JPEG dots per cm", jpegWithJFIF(t, 118, 118, 2, 118, 118), 118, 118, 360000, 360000},
+		{"JPEG aspect only", jpegWithJFIF(t, 96, 96, 0, 1, 1), 96, 96, 914400, 914400},
+		{"BMP", bmpHeader(600, -300, 23622), 600, 300, 914400, 457200},
+		{"BMP without resolution", bmpHeader(96, 96, 0), 96, 96, 914400, 914400},
+		{"TIFF inches", tiffHeader(200, 100, 200, 100, 2), 200, 100, 914400, 914400},
+		{"TIFF centimetres", tiffHeader(254, 254, 100, 100, 3), 254, 254, 914400, 914400},
+		{"WebP", mustDecodeHex(t, testWebP), 6, 4, 57150, 38100},
+		{"EMF", emfHeader(800, 400, 5080, 2540), 800, 400, 1828800, 914400},
+		{"placeable WMF", placeableWMF(1440, 720, 1440), 96, 48, 914400, 457200},
+		{"plain WMF", plainWMF(192, 96), 192, 96, 1828800, 914400},
+		{"SVG", []byte(`<svg width="1in" height="0.5in"/>`), 96, 48, 914400, 457200},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := readImageInfo(tt.data)
+			if err != nil {
+				t.Fatalf("readImageInfo() error = %v", err)
+			}
+			if info.width != tt.width || info.height != tt.height {
+				t.Errorf("pixels = %dx%d, want %dx%d", info.width, info.height, tt.width, tt.height)
+			}
+			size := info.size()
+			if !emuNear(size.WidthEMU, tt.emuW) || !emuNear(size.HeightEMU, tt.emuH) {
+				t.Errorf("EMU = %dx%d, want about %dx%d", size.WidthEMU, size.HeightEMU, tt.emuW, tt.emuH)
+			}
+		})
+	}
+
+	if _, err := readImageInfo([]byte("not an image")); err == nil {
+		t.Error("expected error for unknown data")
+	}
+	if _, err := readImageInfo(append(le16(1), le16(9)...)); err == nil {
+		t.Error("expected error for truncated WMF")
+	}
+}
+
+func TestDetectMetafileFormats(t *testing.T) {
+	if got := detectImageFormatFromData(emfHeader(10, 10, 100, 100)); got != domain.ImageFormatEMF {
+		t.Errorf("EMF detected as %q", got)
+	}
+	if got := detectImageFormatFromData(placeableWMF(10, 10, 1440)); got != domain.ImageFormatWMF {
+		t.Errorf("placeable WMF detected as %q", got)
+	}
+	if got := detectImageFormatFromData(plainWMF(10, 10)); got != domain.ImageFormatWMF {
+		t.Errorf("plain WMF detected as %q", got)
+	}
+	if got := detectImageFormat("drawing.EMF"); got != domain.ImageFormatEMF {
+		t.Errorf("detectImageFormat(.EMF) = %q", got)
+	}
+	if got := formatFromContentType("image/x-wmf"); got != domain.ImageFormatWMF {
+		t.Errorf("formatFromContentType(image/x-wmf) = %q", got)
+	}
+}
+
+func TestWebPConvertedToPNG(t *testing.T) {
+	data := mustDecodeHex(t, testWebP)
+
+	img, err := NewImageFromBytes("7", data, "")
+	if err != nil {
+		t.Fatalf("NewImageFromBytes() error = %v", err)
+	}
+	if img.Format() != domain.ImageFormatPNG {
+		t.Errorf("Format() = %q, want png", img.Format())
+	}
+	if !bytes.HasPrefix(img.Data(), []byte("\x89PNG")) {
+		t.Error("Data() should hold PNG bytes")
+	}
+	if size := img.Size(); size.WidthPx != 6 || size.HeightPx != 4 {
+		t.Errorf("Size() = %dx%d, want 6x4", size.WidthPx, size.HeightPx)
+	}
+
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	img, err = para.AddImageFromBytes(data, domain.ImageFormatWEBP, domain.ImageSize{}, domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+	if !strings.HasSuffix(img.Target(), ".png") {
+		t.Errorf("Target() = %q, want a .png media part", img.Target())
+	}
+}
+
+// emuNear tolerates the rounding in resolutions stored per metre.
+func emuNear(got, want int) bool {
+	return got-want <= 100 && want-got <= 100
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	data, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex.DecodeString: %v", err)
+	}
+	return data
+}
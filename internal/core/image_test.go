@@ -29,10 +29,12 @@ import (
 	"image"
 	"image/color"
 	"image/png"
+	"math"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
 )
@@ -415,3 +417,255 @@ func TestDefaultImagePosition(t *testing.T) {
 		t.Errorf("WrapText = %v, want %v", pos.WrapText, domain.WrapNone)
 	}
 }
+
+func encodeTestPNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatalf("Failed to encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestNewImageFromBytes(t *testing.T) {
+	data := encodeTestPNG(t, 40, 20)
+
+	img, err := NewImageFromBytes("img10", data, "")
+	if err != nil {
+		t.Fatalf("NewImageFromBytes() error = %v", err)
+	}
+	if img.Format() != domain.ImageFormatPNG {
+		t.Errorf("Format() = %v, want detected png", img.Format())
+	}
+	if size := img.Size(); size.WidthPx != 40 || size.HeightPx != 20 {
+		t.Errorf("Size() = %dx%d, want 40x20", size.WidthPx, size.HeightPx)
+	}
+
+	data[len(data)-1] ^= 0xFF
+	if img.Data()[len(data)-1] == data[len(data)-1] {
+		t.Error("image should keep its own copy of the data")
+	}
+
+	if _, err := NewImageFromBytes("img11", nil, domain.ImageFormatPNG); err == nil {
+		t.Error("expected error for empty data")
+	}
+	if _, err := NewImageFromBytes("img12", []byte("plain text"), ""); err == nil {
+		t.Error("expected error for undetectable format")
+	}
+}
+
+func TestDetectImageFormatFromData(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want domain.ImageFormat
+	}{
+		{"png", []byte("\x89PNG\r\n\x1a\nrest"), domain.ImageFormatPNG},
+		{"jpeg", []byte{0xFF, 0xD8, 0xFF, 0xE0}, domain.ImageFormatJPEG},
+		{"gif", []byte("GIF89a..."), domain.ImageFormatGIF},
+		{"bmp", []byte("BM...."), domain.ImageFormatBMP},
+		{"tiff", []byte("II*\x00...."), domain.ImageFormatTIFF},
+		{"webp", []byte("RIFF\x00\x00\x00\x00WEBPVP8 "), domain.ImageFormatWEBP},
+		{"svg", []byte(`<?xml version="1.0"?><svg xmlns="http://www.w3.org/2000/svg"/>`), domain.ImageFormatSVG},
+		{"unknown", []byte("hello"), ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectImageFormatFromData(tt.data); got != tt.want {
+				t.Errorf("detectImageFormatFromData() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParagraphAddImageFromMemory(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	data := encodeTestPNG(t, 100, 50)
+
+	img, err := para.AddImageFromBytes(data, domain.ImageFormatPNG, domain.ImageSize{WidthPx: 200}, domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+	if size := img.Size(); size.WidthPx != 200 || size.HeightPx != 100 {
+		t.Errorf("Size() = %dx%d, want aspect-preserving 200x100", size.WidthPx, size.HeightPx)
+	}
+	if img.Position().Type != domain.ImagePositionInline {
+		t.Errorf("zero position should be inline, got %q", img.Position().Type)
+	}
+	if !strings.HasSuffix(img.Target(), ".png") {
+		t.Errorf("Target() = %q, want a .png media part", img.Target())
+	}
+
+	floating := domain.ImagePosition{Type: domain.ImagePositionFloating, HAlign: domain.HAlignRight}
+	img, err = para.AddImageFromReader(bytes.NewReader(data), "", domain.ImageSize{}, floating)
+	if err != nil {
+		t.Fatalf("AddImageFromReader() error = %v", err)
+	}
+	if img.Position().Type != domain.ImagePositionFloating || img.Position().WrapText != domain.WrapNone {
+		t.Errorf("Position() = %+v", img.Position())
+	}
+	if size := img.Size(); size.WidthPx != 100 {
+		t.Errorf("zero size should keep the natural width, got %d", size.WidthPx)
+	}
+
+	fsys := fstest.MapFS{"assets/logo.png": &fstest.MapFile{Data: data}}
+	if _, err := para.AddImageFromFS(fsys, "assets/logo.png", domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddImageFromFS() error = %v", err)
+	}
+	if _, err := para.AddImageFromFS(fsys, "assets/missing.png", domain.ImageSize{}, domain.ImagePosition{}); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if _, err := para.AddImageFromReader(nil, "", domain.ImageSize{}, domain.ImagePosition{}); err == nil {
+		t.Error("expected error for nil reader")
+	}
+
+	if got := len(para.Images()); got != 3 {
+		t.Errorf("Images() = %d, want 3", got)
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+}
+
+func TestHeaderAddImageFromBytes(t *testing.T) {
+	doc := NewDocument()
+	section, _ := doc.DefaultSection()
+	header, err := section.Header(domain.HeaderDefault)
+	if err != nil {
+		t.Fatalf("Header() error = %v", err)
+	}
+	para, _ := header.AddParagraph()
+	if _, err := para.AddImageFromBytes(encodeTestPNG(t, 10, 10), "", domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddImageFromBytes() in header error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+}
+
+func TestParagraphAddSVG(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	logo := []byte(`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 40 10"><rect width="40" height="10"/></svg>`)
+	fallback := encodeTestPNG(t, 80, 20)
+
+	img, err := para.AddSVG(logo, fallback, domain.ImageSize{}, domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddSVG() error = %v", err)
+	}
+	if size := img.Size(); size.WidthPx != 40 || size.HeightPx != 10 {
+		t.Errorf("Size() = %dx%d, want the viewBox size 40x10", size.WidthPx, size.HeightPx)
+	}
+
+	svgImg := img.(*docxImage)
+	if !bytes.Equal(svgImg.Fallback(), fallback) {
+		t.Error("supplied fallback should be kept as is")
+	}
+	if svgImg.FallbackRelationshipID() == "" || svgImg.FallbackRelationshipID() == svgImg.RelationshipID() {
+		t.Errorf("fallback needs its own relationship, got %q", svgImg.FallbackRelationshipID())
+	}
+	if !strings.HasSuffix(img.Target(), ".svg") || !strings.HasSuffix(svgImg.FallbackTarget(), ".png") {
+		t.Errorf("unexpected targets %q and %q", img.Target(), svgImg.FallbackTarget())
+	}
+
+	if _, err := para.AddSVG(logo, []byte("not a bitmap"), domain.ImageSize{}, domain.ImagePosition{}); err == nil {
+		t.Error("expected error for an invalid fallback")
+	}
+
+	bitmap, err := NewImageFromBytes("img99", fallback, "")
+	if err != nil {
+		t.Fatalf("NewImageFromBytes() error = %v", err)
+	}
+	if err := bitmap.(*docxImage).SetFallback(fallback); err == nil {
+		t.Error("SetFallback should be rejected for bitmap images")
+	}
+}
+
+func TestImageFormattingValidation(t *testing.T) {
+	img, err := NewImageFromBytes("1", encodeTestPNG(t, 10, 10), "")
+	if err != nil {
+		t.Fatalf("NewImageFromBytes() error = %v", err)
+	}
+
+	if err := img.SetCrop(domain.ImageCrop{Left: -1}); err == nil {
+		t.Error("expected error for negative crop")
+	}
+	if err := img.SetCrop(domain.ImageCrop{Left: 60, Right: 40}); err == nil {
+		t.Error("expected error when cropping away the whole width")
+	}
+	if err := img.SetRotation(-450); err != nil || img.Rotation() != 270 {
+		t.Errorf("SetRotation(-450) = %v, Rotation() = %v; want 270", err, img.Rotation())
+	}
+	if err := img.SetBorder(domain.ImageBorder{Width: -1}); err == nil {
+		t.Error("expected error for negative border width")
+	}
+	for _, width := range []float64{math.NaN(), math.Inf(1)} {
+		if err := img.SetBorder(domain.ImageBorder{Width: width}); err == nil {
+			t.Errorf("expected error for border width %v", width)
+		}
+	}
+	if err := img.SetBorder(domain.ImageBorder{Width: 1e9}); err != nil || img.Border().Width != 1584 {
+		t.Errorf("SetBorder(1e9) = %v, Width = %v; want clamped to 1584", err, img.Border().Width)
+	}
+	for _, effects := range []domain.ImageEffects{
+		{Shadow: "glow"},
+		{Reflection: "mirror"},
+		{Brightness: 101},
+		{Contrast: -101},
+		{Transparency: 120},
+		{Brightness: math.NaN()},
+		{Contrast: math.NaN()},
+		{Brightness: math.Inf(1)},
+		{Contrast: math.Inf(-1)},
+		{Transparency: math.NaN()},
+	} {
+		if err := img.SetEffects(effects); err == nil {
+			t.Errorf("expected error for %+v", effects)
+		}
+	}
+}
+
+func TestImageHyperlinkRelationships(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	img, err := NewImageFromBytes("1", encodeTestPNG(t, 10, 10), "")
+	if err != nil {
+		t.Fatalf("NewImageFromBytes() error = %v", err)
+	}
+	if err := img.SetHyperlink("https://example.com/a"); err != nil {
+		t.Fatalf("SetHyperlink() error = %v", err)
+	}
+
+	docxImg := img.(*docxImage)
+	if docxImg.HyperlinkRelationshipID() != "" {
+		t.Error("relationship should wait until the image is attached")
+	}
+	if err := para.(*paragraph).attachImage(img, ""); err != nil {
+		t.Fatalf("attachImage() error = %v", err)
+	}
+	first := docxImg.HyperlinkRelationshipID()
+	if first == "" {
+		t.Fatal("attaching should register the hyperlink")
+	}
+
+	if err := img.SetHyperlink("https://example.com/b"); err != nil {
+		t.Fatalf("SetHyperlink() error = %v", err)
+	}
+	rm := para.(*paragraph).relManager
+	if _, err := rm.Get(first); err == nil {
+		t.Error("replacing the link should drop the old relationship")
+	}
+	if rel, err := rm.Get(docxImg.HyperlinkRelationshipID()); err != nil || rel.Target != "https://example.com/b" {
+		t.Errorf("new relationship = %+v, %v", rel, err)
+	}
+
+	if err := img.SetHyperlink(""); err != nil || docxImg.HyperlinkRelationshipID() != "" {
+		t.Errorf("clearing the link: err = %v, relID = %q", err, docxImg.HyperlinkRelationshipID())
+	}
+}
@@ -311,3 +311,49 @@ func TestDocument_ComplexDocument(t *testing.T) {
 
 	t.Logf("Complex document: %d bytes", buf.Len())
 }
+
+func TestDocument_WriteThumbnail(t *testing.T) {
+	doc := NewDocument()
+	if err := doc.SetThumbnail([]byte("not a jpeg")); err == nil {
+		t.Error("SetThumbnail accepted non-JPEG data")
+	}
+	preview := []byte{0xFF, 0xD8, 0xFF, 0xE0, 0x00, 0x10}
+	if err := doc.SetThumbnail(preview); err != nil {
+		t.Fatalf("SetThumbnail failed: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("invalid zip: %v", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(data)
+	}
+	if parts["docProps/thumbnail.jpeg"] != string(preview) {
+		t.Error("thumbnail part missing or altered")
+	}
+	if !strings.Contains(parts["_rels/.rels"], `Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail" Target="docProps/thumbnail.jpeg"`) {
+		t.Errorf("thumbnail relationship missing:\n%s", parts["_rels/.rels"])
+	}
+	if !strings.Contains(parts["[Content_Types].xml"], `PartName="/docProps/thumbnail.jpeg" ContentType="image/jpeg"`) &&
+		!strings.Contains(parts["[Content_Types].xml"], `Extension="jpeg" ContentType="image/jpeg"`) {
+		t.Errorf("thumbnail content type missing:\n%s", parts["[Content_Types].xml"])
+	}
+
+	_ = doc.SetThumbnail(nil)
+	buf.Reset()
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo failed: %v", err)
+	}
+	if bytes.Contains(buf.Bytes(), []byte("docProps/thumbnail.jpeg")) {
+		t.Error("cleared thumbnail still written")
+	}
+}
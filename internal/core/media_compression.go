@@ -0,0 +1,182 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/manager"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// defaultJPEGQuality is used for downsampled JPEGs when no quality is set.
+const defaultJPEGQuality = 85
+
+// SetImageCompression enables image optimization on save.
+func (d *document) SetImageCompression(opts domain.ImageCompression) error {
+	if math.IsNaN(opts.TargetDPI) || math.IsInf(opts.TargetDPI, 0) {
+		return errors.InvalidArgument("Document.SetImageCompression", "TargetDPI", opts.TargetDPI, "target DPI must be finite")
+	}
+	if opts.TargetDPI < 0 {
+		return errors.InvalidArgument("Document.SetImageCompression", "TargetDPI", opts.TargetDPI, "target DPI cannot be negative")
+	}
+	if opts.JPEGQuality < 0 || opts.JPEGQuality > 100 {
+		return errors.InvalidArgument("Document.SetImageCompression", "JPEGQuality", opts.JPEGQuality, "JPEG quality must be between 1 and 100")
+	}
+	d.compression = opts
+	return nil
+}
+
+// ImageCompression returns the image optimization applied on save.
+func (d *document) ImageCompression() domain.ImageCompression {
+	return d.compression
+}
+
+// compressMedia returns the media files to write, replacing oversized PNG
+// and JPEG images by downsampled or re-encoded copies. Files shared by
+// several pictures are sized for the largest one.
+func compressMedia(files []*manager.MediaFile, images []domain.DocumentImage, opts domain.ImageCompression) []*manager.MediaFile {
+	type need struct{ width, height int }
+	needs := make(map[string]need)
+	for _, located := range images {
+		img := located.Image
+		path := "word/" + strings.TrimPrefix(strings.TrimPrefix(img.Target(), "/"), "word/")
+		w, h := displayPixels(img, opts.TargetDPI)
+		cur := needs[path]
+		needs[path] = need{max(cur.width, w), max(cur.height, h)}
+	}
+
+	out := make([]*manager.MediaFile, len(files))
+	for i, file := range files {
+		out[i] = file
+		target, ok := needs[file.Path]
+		if !ok {
+			continue
+		}
+		if data := recompress(file.Data, target.width, target.height, opts); data != nil {
+			replaced := *file
+			replaced.Data = data
+			out[i] = &replaced
+		}
+	}
+	return out
+}
+
+// displayPixels returns how many source pixels the picture needs to show at
+// dpi, accounting for cropping. Zero means no downsampling.
+func displayPixels(img domain.Image, dpi float64) (int, int) {
+	if dpi <= 0 {
+		return 0, 0
+	}
+	size, crop := img.Size(), img.Crop()
+	visibleX := 1 - (crop.Left+crop.Right)/100
+	visibleY := 1 - (crop.Top+crop.Bottom)/100
+	w := float64(size.WidthEMU) / 914400 * dpi / visibleX
+	h := float64(size.HeightEMU) / 914400 * dpi / visibleY
+	return int(math.Ceil(w)), int(math.Ceil(h))
+}
+
+// recompress downsamples data to at least width x height pixels and
+// re-encodes it. It returns nil when the result would not be smaller or the
+// format is not PNG or JPEG.
+func recompress(data []byte, width, height int, opts domain.ImageCompression) []byte {
+	format := detectImageFormatFromData(data)
+	if format != domain.ImageFormatPNG && format != domain.ImageFormatJPEG {
+		return nil
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	scale := 1.0
+	if width > 0 && height > 0 {
+		scale = math.Max(float64(width)/float64(cfg.Width), float64(height)/float64(cfg.Height))
+	}
+	shrink := scale < 1
+	if !shrink && (format != domain.ImageFormatJPEG || opts.JPEGQuality == 0) {
+		return nil
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	var result image.Image = src
+	if shrink {
+		result = downsample(src, max(1, int(math.Ceil(float64(cfg.Width)*scale))), max(1, int(math.Ceil(float64(cfg.Height)*scale))))
+	}
+
+	var buf bytes.Buffer
+	if format == domain.ImageFormatJPEG {
+		quality := opts.JPEGQuality
+		if quality == 0 {
+			quality = defaultJPEGQuality
+		}
+		err = jpeg.Encode(&buf, result, &jpeg.Options{Quality: quality})
+	} else {
+		err = (&png.Encoder{CompressionLevel: png.BestCompression}).Encode(&buf, result)
+	}
+	if err != nil || buf.Len() >= len(data) {
+		return nil
+	}
+	return buf.Bytes()
+}
+
+// downsample shrinks src to width x height by averaging the source pixels
+// covered by each destination pixel.
+func downsample(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0, y1 := y*sh/height, max((y+1)*sh/height, y*sh/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*sw/width, max((x+1)*sw/width, x*sw/width+1)
+
+			var r, g, b, a uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					r, g, b, a = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa)
+				}
+			}
+			n := uint64((y1 - y0) * (x1 - x0))
+			premultiplied := color.RGBA64{
+				R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n),
+			}
+			dst.Set(x, y, premultiplied)
+		}
+	}
+	return dst
+}
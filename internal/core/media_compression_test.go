@@ -0,0 +1,164 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+// noisyJPEG encodes a photo-like image that compresses poorly.
+func noisyJPEG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	seed := uint32(1)
+	for i := range img.Pix {
+		seed = seed*1664525 + 1013904223
+		img.Pix[i] = uint8(seed >> 24)
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 95}); err != nil {
+		t.Fatalf("jpeg.Encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// writtenMedia saves doc and returns its media parts.
+func writtenMedia(t *testing.T, doc domain.Document) map[string][]byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("zip.NewReader() error = %v", err)
+	}
+	media := make(map[string][]byte)
+	for _, f := range zr.File {
+		if !strings.HasPrefix(f.Name, "word/media/") {
+			continue
+		}
+		rc, _ := f.Open()
+		media[f.Name], _ = io.ReadAll(rc)
+		rc.Close()
+	}
+	return media
+}
+
+func TestRepeatedImagesShareMedia(t *testing.T) {
+	doc := NewDocument()
+	logo := encodeTestPNG(t, 20, 20)
+
+	var relIDs []string
+	for i := 0; i < 3; i++ {
+		para, _ := doc.AddParagraph()
+		img, err := para.AddImageFromBytes(logo, "", domain.ImageSize{}, domain.ImagePosition{})
+		if err != nil {
+			t.Fatalf("AddImageFromBytes() error = %v", err)
+		}
+		relIDs = append(relIDs, img.RelationshipID())
+	}
+	if relIDs[0] != relIDs[1] || relIDs[1] != relIDs[2] {
+		t.Errorf("relationship IDs = %v, want one shared relationship", relIDs)
+	}
+	if media := writtenMedia(t, doc); len(media) != 1 {
+		t.Errorf("media parts = %d, want 1", len(media))
+	}
+}
+
+func TestImageCompressionOnSave(t *testing.T) {
+	doc := NewDocument()
+	photo := noisyJPEG(t, 400, 200)
+	para, _ := doc.AddParagraph()
+	// 400x200 pixels shown at one inch wide needs 100x50 pixels at 100 DPI.
+	if _, err := para.AddImageFromBytes(photo, "", domain.NewImageSizeInches(1, 0.5), domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+	cropped, err := para.AddImageFromBytes(noisyJPEG(t, 400, 400), "", domain.NewImageSizeInches(1, 1), domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+	_ = cropped.SetCrop(domain.ImageCrop{Left: 25, Right: 25, Top: 25, Bottom: 25})
+
+	if err := doc.SetImageCompression(domain.ImageCompression{TargetDPI: -1}); err == nil {
+		t.Error("expected error for negative DPI")
+	}
+	for _, dpi := range []float64{math.NaN(), math.Inf(1)} {
+		if err := doc.SetImageCompression(domain.ImageCompression{TargetDPI: dpi}); err == nil {
+			t.Errorf("expected error for DPI %v", dpi)
+		}
+	}
+	if err := doc.SetImageCompression(domain.ImageCompression{JPEGQuality: 101}); err == nil {
+		t.Error("expected error for quality above 100")
+	}
+
+	before := writtenMedia(t, doc)
+	if err := doc.SetImageCompression(domain.ImageCompression{TargetDPI: 100, JPEGQuality: 70}); err != nil {
+		t.Fatalf("SetImageCompression() error = %v", err)
+	}
+	after := writtenMedia(t, doc)
+
+	want := map[int]image.Point{400 * 200: {100, 50}, 400 * 400: {200, 200}}
+	for name, data := range after {
+		orig, _ := jpeg.DecodeConfig(bytes.NewReader(before[name]))
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got, exp := (image.Point{cfg.Width, cfg.Height}), want[orig.Width*orig.Height]; got != exp {
+			t.Errorf("%s: %v, want %v", name, got, exp)
+		}
+		if len(data) >= len(before[name]) {
+			t.Errorf("%s: %d bytes, want fewer than %d", name, len(data), len(before[name]))
+		}
+	}
+
+	img := doc.Paragraphs()[0].Images()[0]
+	if cfg, _ := jpeg.DecodeConfig(bytes.NewReader(img.Data())); cfg.Width != 400 {
+		t.Error("compression must not change the stored image")
+	}
+}
+
+func TestDownsampleAveragesPixels(t *testing.T) {
+	src := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		src.Set(x, 0, color.NRGBA{255, 0, 0, 255})
+		src.Set(x, 1, color.NRGBA{0, 0, 255, 255})
+	}
+	got := downsample(src, 2, 1)
+	if c := got.NRGBAAt(1, 0); c.R < 126 || c.R > 128 || c.B < 126 || c.B > 128 || c.A != 255 {
+		t.Errorf("averaged pixel = %v, want purple", c)
+	}
+}
@@ -26,11 +26,17 @@ SOFTWARE.
 package core
 
 import (
+	"bytes"
+	"image"
+	"io"
+	"io/fs"
+	"path"
 	"path/filepath"
 	"strings"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
 	"github.com/mmonterroca/docxgo/v2/internal/manager"
+	"github.com/mmonterroca/docxgo/v2/internal/svg"
 	"github.com/mmonterroca/docxgo/v2/pkg/constants"
 	"github.com/mmonterroca/docxgo/v2/pkg/errors"
 )
@@ -49,6 +55,11 @@ type paragraph struct {
 	runs          []domain.Run
 	fields        []domain.Field
 	images        []domain.Image
+	shapes        []domain.Shape
+	charts        []domain.Chart
+	equations     []domain.Equation
+	objects       []domain.EmbeddedObject
+	footnotes     []domain.Footnote
 	styleName     string
 	alignment     domain.Alignment
 	indent        domain.Indentation
@@ -176,6 +187,113 @@ func (p *paragraph) AddImageWithPosition(path string, size domain.ImageSize, pos
 	return img, nil
 }
 
+// AddImageFromBytes adds an image from in-memory data.
+func (p *paragraph) AddImageFromBytes(data []byte, format domain.ImageFormat, size domain.ImageSize, pos domain.ImagePosition) (domain.Image, error) {
+	img, err := NewImageFromBytes(p.idGen.NextImageID(), data, format)
+	if err != nil {
+		return nil, errors.Wrap(err, "Paragraph.AddImageFromBytes")
+	}
+
+	return p.placeImage("Paragraph.AddImageFromBytes", img, size, pos, "")
+}
+
+// AddImageFromReader adds an image read from r.
+func (p *paragraph) AddImageFromReader(r io.Reader, format domain.ImageFormat, size domain.ImageSize, pos domain.ImagePosition) (domain.Image, error) {
+	if r == nil {
+		return nil, errors.InvalidArgument("Paragraph.AddImageFromReader", "r", nil, "reader cannot be nil")
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "Paragraph.AddImageFromReader")
+	}
+
+	img, err := NewImageFromBytes(p.idGen.NextImageID(), data, format)
+	if err != nil {
+		return nil, errors.Wrap(err, "Paragraph.AddImageFromReader")
+	}
+
+	return p.placeImage("Paragraph.AddImageFromReader", img, size, pos, "")
+}
+
+// AddImageFromFS adds an image loaded from a file system such as embed.FS.
+func (p *paragraph) AddImageFromFS(fsys fs.FS, name string, size domain.ImageSize, pos domain.ImagePosition) (domain.Image, error) {
+	if fsys == nil {
+		return nil, errors.InvalidArgument("Paragraph.AddImageFromFS", "fsys", nil, "file system cannot be nil")
+	}
+
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "Paragraph.AddImageFromFS")
+	}
+
+	img, err := NewImageFromBytes(p.idGen.NextImageID(), data, detectImageFormat(name))
+	if err != nil {
+		return nil, errors.Wrap(err, "Paragraph.AddImageFromFS")
+	}
+
+	return p.placeImage("Paragraph.AddImageFromFS", img, size, pos, path.Base(name))
+}
+
+// AddSVG adds an SVG image with a bitmap fallback, rendered from the SVG
+// when fallback is nil.
+func (p *paragraph) AddSVG(svgData, fallback []byte, size domain.ImageSize, pos domain.ImagePosition) (domain.Image, error) {
+	if !svg.IsSVG(svgData) {
+		return nil, errors.InvalidArgument("Paragraph.AddSVG", "svg", len(svgData), "data is not an SVG document")
+	}
+
+	img, err := NewImageFromBytes(p.idGen.NextImageID(), svgData, domain.ImageFormatSVG)
+	if err != nil {
+		return nil, errors.Wrap(err, "Paragraph.AddSVG")
+	}
+
+	if fallback != nil {
+		if _, _, err := image.DecodeConfig(bytes.NewReader(fallback)); err != nil {
+			return nil, errors.InvalidArgument("Paragraph.AddSVG", "fallback", len(fallback), "fallback is not a supported bitmap image")
+		}
+		if err := img.(*docxImage).SetFallback(fallback); err != nil {
+			return nil, errors.Wrap(err, "Paragraph.AddSVG")
+		}
+	}
+
+	return p.placeImage("Paragraph.AddSVG", img, size, pos, "")
+}
+
+// placeImage applies the optional size and position, then attaches the image.
+func (p *paragraph) placeImage(op string, img domain.Image, size domain.ImageSize, pos domain.ImagePosition, sourceName string) (domain.Image, error) {
+	if size != (domain.ImageSize{}) {
+		if err := img.SetSize(size); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	if pos != (domain.ImagePosition{}) {
+		if pos.Type == "" {
+			pos.Type = domain.ImagePositionInline
+		}
+		if pos.WrapText == "" {
+			pos.WrapText = domain.WrapNone
+		}
+		if positioned, ok := img.(interface {
+			SetPosition(domain.ImagePosition) error
+		}); ok {
+			if err := positioned.SetPosition(pos); err != nil {
+				return nil, errors.Wrap(err, op)
+			}
+		}
+	}
+
+	if sourceName == "" {
+		sourceName = "image." + string(img.Format())
+	}
+
+	if err := p.attachImage(img, sourceName); err != nil {
+		return nil, err
+	}
+
+	return img, nil
+}
+
 // attachImage registers the image with the media and relationship managers and appends it as a drawing run.
 func (p *paragraph) attachImage(img domain.Image, sourceName string) error {
 	if p.mediaManager == nil {
@@ -185,6 +303,10 @@ func (p *paragraph) attachImage(img domain.Image, sourceName string) error {
 	if sourceName == "" {
 		sourceName = img.ID()
 	}
+	// Converted images (WebP stored as PNG) must not keep the source extension.
+	if ext := filepath.Ext(sourceName); ext != "" && detectImageFormat(sourceName) != detectImageFormat("."+string(img.Format())) {
+		sourceName = strings.TrimSuffix(sourceName, ext) + "." + string(img.Format())
+	}
 
 	_, mediaPath, err := p.mediaManager.Add(img.Data(), sourceName)
 	if err != nil {
@@ -203,6 +325,14 @@ func (p *paragraph) attachImage(img domain.Image, sourceName string) error {
 
 	if docxImg, ok := img.(*docxImage); ok {
 		docxImg.SetRelationshipID(relID)
+		if err := docxImg.bind(p.relManager, p.mediaManager); err != nil {
+			return errors.Wrap(err, "Paragraph.attachImage")
+		}
+		if docxImg.format == domain.ImageFormatSVG {
+			if err := registerSVGFallback(docxImg, p.relManager, p.mediaManager); err != nil {
+				return errors.Wrap(err, "Paragraph.attachImage")
+			}
+		}
 	}
 
 	run := NewRun(p.idGen.NextRunID(), p.relManager)
@@ -215,6 +345,262 @@ func (p *paragraph) attachImage(img domain.Image, sourceName string) error {
 	return nil
 }
 
+// registerSVGFallback stores the bitmap fallback of an SVG image, rendering
+// it first when none was supplied.
+func registerSVGFallback(img *docxImage, rm *manager.RelationshipManager, mm *manager.MediaManager) error {
+	if img.fallback == nil {
+		fallback, err := renderSVGFallback(img)
+		if err != nil {
+			return err
+		}
+		img.fallback = fallback
+	}
+
+	format := detectImageFormatFromData(img.fallback)
+	if format == "" {
+		format = domain.ImageFormatPNG
+	}
+	_, mediaPath, err := mm.Add(img.fallback, "image."+string(format))
+	if err != nil {
+		return err
+	}
+
+	target := strings.TrimPrefix(mediaPath, "word/")
+	relID, err := rm.AddImage(target)
+	if err != nil {
+		return err
+	}
+
+	img.SetFallbackRelationship(relID, target)
+	return nil
+}
+
+// AddTextBox adds a text box to the paragraph.
+func (p *paragraph) AddTextBox(size domain.ImageSize, pos domain.ImagePosition) (domain.Shape, error) {
+	return p.addShape("Paragraph.AddTextBox", domain.ShapeTextBox, size, pos)
+}
+
+// AddShape adds a drawing shape to the paragraph.
+func (p *paragraph) AddShape(shapeType domain.ShapeType, size domain.ImageSize, pos domain.ImagePosition) (domain.Shape, error) {
+	return p.addShape("Paragraph.AddShape", shapeType, size, pos)
+}
+
+// addShape creates a shape and appends it as a drawing run.
+func (p *paragraph) addShape(op string, shapeType domain.ShapeType, size domain.ImageSize, pos domain.ImagePosition) (domain.Shape, error) {
+	shape, err := newShape(p.idGen.GenerateID("shape"), shapeType, p.idGen, p.relManager, p.mediaManager)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if err := shape.SetSize(size); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if pos != (domain.ImagePosition{}) {
+		if err := shape.SetPosition(pos); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	run := NewRun(p.idGen.NextRunID(), p.relManager)
+	if setter, ok := run.(interface{ setShape(domain.Shape) }); ok {
+		setter.setShape(shape)
+	}
+
+	p.runs = append(p.runs, run)
+	p.shapes = append(p.shapes, shape)
+	return shape, nil
+}
+
+// AddChart adds a native chart to the paragraph. Its data is written to
+// a workbook embedded next to the chart part when the document is saved.
+func (p *paragraph) AddChart(spec domain.ChartSpec, size domain.ImageSize, pos domain.ImagePosition) (domain.Chart, error) {
+	const op = "Paragraph.AddChart"
+	if p.relManager == nil {
+		return nil, errors.InvalidState(op, "paragraph is not attached to a document part")
+	}
+	// Charts read from a document keep their parts, so new names skip them.
+	id := p.idGen.GenerateID("chart")
+	for rel, _ := p.relManager.GetByTarget("charts/" + id + ".xml"); rel != nil; rel, _ = p.relManager.GetByTarget("charts/" + id + ".xml") {
+		id = p.idGen.GenerateID("chart")
+	}
+	chart, err := newChart(id, spec)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if size != (domain.ImageSize{}) {
+		if err := chart.SetSize(size); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+	if pos != (domain.ImagePosition{}) {
+		if err := chart.SetPosition(pos); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	relID, err := p.relManager.Add(constants.RelTypeChart, chart.Target(), "Internal")
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	chart.relationshipID = relID
+
+	run := NewRun(p.idGen.NextRunID(), p.relManager)
+	if setter, ok := run.(interface{ setChart(domain.Chart) }); ok {
+		setter.setChart(chart)
+	}
+
+	p.runs = append(p.runs, run)
+	p.charts = append(p.charts, chart)
+	return chart, nil
+}
+
+// AddEmbeddedObject embeds a file as an OLE object shown as an icon.
+func (p *paragraph) AddEmbeddedObject(fileName string, data []byte, opts domain.EmbedOptions) (domain.EmbeddedObject, error) {
+	const op = "Paragraph.AddEmbeddedObject"
+	if p.relManager == nil || p.mediaManager == nil {
+		return nil, errors.InvalidState(op, "paragraph is not attached to a document part")
+	}
+	obj, err := newEmbeddedObject(p.idGen.GenerateID("object"), fileName, data, opts)
+	if err != nil {
+		return nil, err
+	}
+	// Documents read from disk may already use the part name.
+	for rel, _ := p.relManager.GetByTarget(obj.target); rel != nil; rel, _ = p.relManager.GetByTarget(obj.target) {
+		obj.setID(p.idGen.GenerateID("object"))
+	}
+
+	iconExt := "." + string(detectImageFormatFromData(obj.icon))
+	_, iconPath, err := p.mediaManager.Add(obj.icon, obj.id+iconExt)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if obj.iconRelID, err = p.relManager.AddImage(strings.TrimPrefix(iconPath, "word/")); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if obj.relationshipID, err = p.relManager.Add(obj.relationshipType(), obj.target, "Internal"); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	run := NewRun(p.idGen.NextRunID(), p.relManager)
+	if setter, ok := run.(interface{ setEmbeddedObject(domain.EmbeddedObject) }); ok {
+		setter.setEmbeddedObject(obj)
+	}
+	p.runs = append(p.runs, run)
+	p.objects = append(p.objects, obj)
+	return obj, nil
+}
+
+// AttachHydratedChartToRun keeps a chart read from a document,
+// registering its existing relationship.
+func (p *paragraph) AttachHydratedChartToRun(r domain.Run, chart domain.Chart, relID string) error {
+	const op = "Paragraph.AttachHydratedChartToRun"
+	coreRun, ok := r.(*run)
+	if !ok {
+		return errors.InvalidArgument(op, "run", r, "unexpected run implementation")
+	}
+	docxChart, ok := chart.(*docxChart)
+	if !ok {
+		return errors.InvalidArgument(op, "chart", chart, "unexpected chart implementation")
+	}
+	if p.relManager == nil {
+		return errors.InvalidState(op, "paragraph is not attached to a document part")
+	}
+
+	if err := p.relManager.RegisterExisting(relID, constants.RelTypeChart, docxChart.target, "Internal"); err != nil {
+		return errors.Wrap(err, op)
+	}
+	docxChart.relationshipID = relID
+
+	coreRun.setChart(chart)
+	p.charts = append(p.charts, chart)
+	return nil
+}
+
+// AttachHydratedEmbeddedObjectToRun keeps an object read from a document,
+// registering its existing relationships and preview image.
+func (p *paragraph) AttachHydratedEmbeddedObjectToRun(r domain.Run, obj domain.EmbeddedObject, relID, iconRelID, iconPath string) error {
+	const op = "Paragraph.AttachHydratedEmbeddedObjectToRun"
+	coreRun, ok := r.(*run)
+	if !ok {
+		return errors.InvalidArgument(op, "run", r, "unexpected run implementation")
+	}
+	docxObj, ok := obj.(*docxEmbeddedObject)
+	if !ok {
+		return errors.InvalidArgument(op, "obj", obj, "unexpected embedded object implementation")
+	}
+	if p.relManager == nil || p.mediaManager == nil {
+		return errors.InvalidState(op, "paragraph is not attached to a document part")
+	}
+
+	docxObj.id = p.idGen.GenerateID("object")
+	docxObj.relationshipID = relID
+	docxObj.iconRelID = iconRelID
+	if err := p.relManager.RegisterExisting(relID, docxObj.relationshipType(), docxObj.target, "Internal"); err != nil {
+		return errors.Wrap(err, op)
+	}
+	if iconRelID != "" && len(docxObj.icon) > 0 {
+		if err := p.relManager.RegisterExisting(iconRelID, constants.RelTypeImage, strings.TrimPrefix(iconPath, "word/"), "Internal"); err != nil {
+			return errors.Wrap(err, op)
+		}
+		id := strings.TrimSuffix(path.Base(iconPath), path.Ext(iconPath))
+		if _, err := p.mediaManager.RegisterExisting(id, iconPath, "", docxObj.icon); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	coreRun.setEmbeddedObject(obj)
+	p.objects = append(p.objects, obj)
+	return nil
+}
+
+// AddEquation adds an Office Math equation to the paragraph.
+func (p *paragraph) AddEquation(content domain.MathNode, mode domain.EquationMode) (domain.Equation, error) {
+	eq, err := newEquation(content, mode)
+	if err != nil {
+		return nil, errors.Wrap(err, "Paragraph.AddEquation")
+	}
+	p.appendEquation(eq)
+	return eq, nil
+}
+
+// AddHydratedEquation keeps an equation read from a document. omml is the
+// content of its m:oMath element.
+func (p *paragraph) AddHydratedEquation(omml string, mode domain.EquationMode) (domain.Equation, error) {
+	eq := &docxEquation{raw: omml}
+	if err := eq.SetMode(mode); err != nil {
+		return nil, errors.Wrap(err, "Paragraph.AddHydratedEquation")
+	}
+	p.appendEquation(eq)
+	return eq, nil
+}
+
+// appendEquation appends eq as an equation run.
+func (p *paragraph) appendEquation(eq domain.Equation) {
+	run := NewRun(p.idGen.NextRunID(), p.relManager)
+	if setter, ok := run.(interface{ setEquation(domain.Equation) }); ok {
+		setter.setEquation(eq)
+	}
+	p.runs = append(p.runs, run)
+	p.equations = append(p.equations, eq)
+}
+
+// AttachHydratedShapeToRun creates a shape for a drawing read from an
+// existing document and attaches it to the provided run.
+func (p *paragraph) AttachHydratedShapeToRun(r domain.Run, shapeType domain.ShapeType) (domain.Shape, error) {
+	coreRun, ok := r.(*run)
+	if !ok {
+		return nil, errors.InvalidArgument("Paragraph.AttachHydratedShapeToRun", "run", r, "unexpected run implementation")
+	}
+
+	shape, err := newShape(p.idGen.GenerateID("shape"), shapeType, p.idGen, p.relManager, p.mediaManager)
+	if err != nil {
+		return nil, errors.Wrap(err, "Paragraph.AttachHydratedShapeToRun")
+	}
+
+	coreRun.setShape(shape)
+	p.shapes = append(p.shapes, shape)
+	return shape, nil
+}
+
 // RegisterHydratedImage records an image that was rehydrated from an existing document.
 // It preserves media metadata so the image can be written back without renaming.
 func (p *paragraph) RegisterHydratedImage(img domain.Image, mediaPath, contentType string, data []byte) error {
@@ -265,6 +651,28 @@ func (p *paragraph) AttachHydratedImageToRun(r domain.Run, img domain.Image, med
 			return errors.Wrap(err, "Paragraph.AttachHydratedImageToRun")
 		}
 	}
+
+	if docxImg, ok := img.(*docxImage); ok && docxImg.fallbackRelID != "" && len(docxImg.fallback) > 0 {
+		if coreRun.relManager != nil {
+			if err := coreRun.relManager.RegisterExisting(docxImg.fallbackRelID, constants.RelTypeImage, docxImg.fallbackTarget, "Internal"); err != nil {
+				return errors.Wrap(err, "Paragraph.AttachHydratedImageToRun")
+			}
+		}
+		if p.mediaManager != nil {
+			id := strings.TrimSuffix(path.Base(docxImg.fallbackTarget), path.Ext(docxImg.fallbackTarget))
+			if _, err := p.mediaManager.RegisterExisting(id, "word/"+docxImg.fallbackTarget, "", docxImg.fallback); err != nil {
+				return errors.Wrap(err, "Paragraph.AttachHydratedImageToRun")
+			}
+		}
+	}
+	if docxImg, ok := img.(*docxImage); ok && coreRun.relManager != nil {
+		if docxImg.hyperlinkRelID != "" {
+			if err := coreRun.relManager.RegisterExisting(docxImg.hyperlinkRelID, constants.RelTypeHyperlink, docxImg.hyperlink, "External"); err != nil {
+				return errors.Wrap(err, "Paragraph.AttachHydratedImageToRun")
+			}
+		}
+		docxImg.relManager, docxImg.mediaManager = coreRun.relManager, p.mediaManager
+	}
 	return p.RegisterHydratedImage(img, mediaPath, contentType, data)
 }
 
@@ -275,6 +683,34 @@ func (p *paragraph) Images() []domain.Image {
 	return images
 }
 
+// Shapes returns all shapes in this paragraph.
+func (p *paragraph) Shapes() []domain.Shape {
+	shapes := make([]domain.Shape, len(p.shapes))
+	copy(shapes, p.shapes)
+	return shapes
+}
+
+// Charts returns all charts in this paragraph.
+func (p *paragraph) Charts() []domain.Chart {
+	charts := make([]domain.Chart, len(p.charts))
+	copy(charts, p.charts)
+	return charts
+}
+
+// Equations returns all equations in this paragraph.
+func (p *paragraph) Equations() []domain.Equation {
+	equations := make([]domain.Equation, len(p.equations))
+	copy(equations, p.equations)
+	return equations
+}
+
+// EmbeddedObjects returns all embedded objects in this paragraph.
+func (p *paragraph) EmbeddedObjects() []domain.EmbeddedObject {
+	objects := make([]domain.EmbeddedObject, len(p.objects))
+	copy(objects, p.objects)
+	return objects
+}
+
 // Runs returns all runs in this paragraph.
 func (p *paragraph) Runs() []domain.Run {
 	// Return a copy to prevent external modification
@@ -323,6 +759,14 @@ func (p *paragraph) StyleName() string {
 	return p.styleName
 }
 
+// isSeparator reports whether the paragraph is empty, holding no runs,
+// fields, drawings, notes or bookmark.
+func (p *paragraph) isSeparator() bool {
+	return len(p.runs) == 0 && len(p.fields) == 0 && len(p.images) == 0 && len(p.shapes) == 0 &&
+		len(p.charts) == 0 && len(p.equations) == 0 && len(p.objects) == 0 && len(p.footnotes) == 0 &&
+		p.bookmarkID == "" && p.bookmarkName == ""
+}
+
 // SetBookmark sets a bookmark for this paragraph (used for TOC).
 // This is an internal method used when generating TOC.
 func (p *paragraph) SetBookmark(id, name string) {
@@ -38,14 +38,21 @@ type run struct {
 	id         string
 	text       string
 	image      domain.Image
+	shape      domain.Shape
+	chart      domain.Chart
+	equation   domain.Equation
+	object     domain.EmbeddedObject
+	footnote   domain.Footnote
 	font       domain.Font
 	color      domain.Color
+	themeColor *domain.ThemeColorRef
 	size       int // in half-points
 	bold       bool
 	italic     bool
 	underline  domain.UnderlineStyle
 	strike     bool
 	highlight  domain.HighlightColor
+	script     domain.Script
 	fields     []domain.Field     // Fields embedded in this run
 	breaks     []domain.BreakType // Breaks in this run
 	relManager *manager.RelationshipManager
@@ -85,6 +92,46 @@ func (r *run) setImage(img domain.Image) {
 	r.image = img
 }
 
+// Shape returns the shape associated with this run, if any.
+func (r *run) Shape() domain.Shape {
+	return r.shape
+}
+
+// setShape attaches a shape to the run for serialization.
+func (r *run) setShape(shape domain.Shape) {
+	r.shape = shape
+}
+
+// Chart returns the chart associated with this run, if any.
+func (r *run) Chart() domain.Chart {
+	return r.chart
+}
+
+// setChart attaches a chart to the run for serialization.
+func (r *run) setChart(chart domain.Chart) {
+	r.chart = chart
+}
+
+// Equation returns the equation associated with this run, if any.
+func (r *run) Equation() domain.Equation {
+	return r.equation
+}
+
+// setEquation attaches an equation to the run for serialization.
+func (r *run) setEquation(eq domain.Equation) {
+	r.equation = eq
+}
+
+// EmbeddedObject returns the embedded object associated with this run, if any.
+func (r *run) EmbeddedObject() domain.EmbeddedObject {
+	return r.object
+}
+
+// setEmbeddedObject attaches an embedded object to the run for serialization.
+func (r *run) setEmbeddedObject(obj domain.EmbeddedObject) {
+	r.object = obj
+}
+
 // Font returns the font settings for this run.
 func (r *run) Font() domain.Font {
 	return r.font
@@ -95,6 +142,9 @@ func (r *run) SetFont(font domain.Font) error {
 	if font.Name == "" {
 		return errors.InvalidArgument("Run.SetFont", "font.Name", font.Name, "font name cannot be empty")
 	}
+	if font.Theme != "" && font.Theme != domain.ThemeFontMajor && font.Theme != domain.ThemeFontMinor {
+		return errors.InvalidArgument("Run.SetFont", "font.Theme", font.Theme, "unknown theme font")
+	}
 	r.font = font
 	return nil
 }
@@ -108,6 +158,24 @@ func (r *run) Color() domain.Color {
 func (r *run) SetColor(color domain.Color) error {
 	// Color validation is implicit via uint8 type (0-255)
 	r.color = color
+	r.themeColor = nil
+	return nil
+}
+
+// ThemeColor returns the theme color of the text, if one is set.
+func (r *run) ThemeColor() (domain.ThemeColorRef, bool) {
+	if r.themeColor == nil {
+		return domain.ThemeColorRef{}, false
+	}
+	return *r.themeColor, true
+}
+
+// SetThemeColor colors the text with a theme color.
+func (r *run) SetThemeColor(ref domain.ThemeColorRef) error {
+	if _, ok := domain.DefaultDocumentTheme().Colors.Color(ref.Color); !ok {
+		return errors.InvalidArgument("Run.SetThemeColor", "ref.Color", ref.Color, "unknown theme color")
+	}
+	r.themeColor = &ref
 	return nil
 }
 
@@ -191,6 +259,20 @@ func (r *run) SetHighlight(color domain.HighlightColor) error {
 	return nil
 }
 
+// Script returns whether the text is superscript or subscript.
+func (r *run) Script() domain.Script {
+	return r.script
+}
+
+// SetScript raises the text as superscript or lowers it as subscript.
+func (r *run) SetScript(script domain.Script) error {
+	if script < domain.ScriptBaseline || script > domain.ScriptSubscript {
+		return errors.InvalidArgument("Run.SetScript", "script", script, "invalid script")
+	}
+	r.script = script
+	return nil
+}
+
 // AddText is a convenience method that appends text to the run.
 func (r *run) AddText(text string) error {
 	r.text += text
@@ -173,7 +173,7 @@ func (s *docxSection) Header(headerType domain.HeaderType) (domain.Header, error
 	header := &docxHeader{
 		headerType:   headerType,
 		paragraphs:   make([]domain.Paragraph, 0, constants.DefaultParagraphCapacity),
-		relationMgr:  s.relationMgr,
+		relationMgr:  manager.NewRelationshipManager(manager.NewIDGenerator()),
 		idGen:        s.idGen,
 		mediaManager: s.mediaManager,
 	}
@@ -196,7 +196,7 @@ func (s *docxSection) Footer(footerType domain.FooterType) (domain.Footer, error
 	footer := &docxFooter{
 		footerType:   footerType,
 		paragraphs:   make([]domain.Paragraph, 0, constants.DefaultParagraphCapacity),
-		relationMgr:  s.relationMgr,
+		relationMgr:  manager.NewRelationshipManager(manager.NewIDGenerator()),
 		idGen:        s.idGen,
 		mediaManager: s.mediaManager,
 	}
@@ -234,7 +234,7 @@ type docxHeader struct {
 	mu           sync.RWMutex
 	headerType   domain.HeaderType
 	paragraphs   []domain.Paragraph
-	relationMgr  *manager.RelationshipManager
+	relationMgr  *manager.RelationshipManager // Relationships of the header part
 	idGen        *manager.IDGenerator
 	relID        string
 	targetPath   string
@@ -298,7 +298,7 @@ type docxFooter struct {
 	mu           sync.RWMutex
 	footerType   domain.FooterType
 	paragraphs   []domain.Paragraph
-	relationMgr  *manager.RelationshipManager
+	relationMgr  *manager.RelationshipManager // Relationships of the footer part
 	idGen        *manager.IDGenerator
 	relID        string
 	targetPath   string
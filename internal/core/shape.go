@@ -0,0 +1,222 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"math"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/manager"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Default shape colors, taken from the Office theme accent.
+var (
+	shapeFillColor    = domain.Color{R: 0x44, G: 0x72, B: 0xC4}
+	shapeOutlineColor = domain.Color{R: 0x2F, G: 0x52, B: 0x8F}
+)
+
+// docxShape implements the domain.Shape interface.
+type docxShape struct {
+	id          string
+	shapeType   domain.ShapeType
+	size        domain.ImageSize
+	position    domain.ImagePosition
+	fill        domain.Color
+	filled      bool
+	outline     domain.ShapeOutline
+	textAnchor  domain.VerticalAlign
+	description string
+	paragraphs  []domain.Paragraph
+
+	idGen        IDGenerator
+	relManager   *manager.RelationshipManager
+	mediaManager *manager.MediaManager
+}
+
+// newShape creates a shape with Word's default look for its type. Its
+// paragraphs share the managers of the part the shape is placed in.
+func newShape(id string, shapeType domain.ShapeType, idGen IDGenerator, relManager *manager.RelationshipManager, mediaManager *manager.MediaManager) (*docxShape, error) {
+	shape := &docxShape{
+		id:           id,
+		shapeType:    shapeType,
+		position:     domain.DefaultImagePosition(),
+		textAnchor:   domain.VAlignCenter,
+		idGen:        idGen,
+		relManager:   relManager,
+		mediaManager: mediaManager,
+	}
+
+	switch shapeType {
+	case domain.ShapeTextBox:
+		shape.fill, shape.filled = domain.ColorWhite, true
+		shape.outline = domain.ShapeOutline{Color: domain.ColorBlack, Width: 0.75}
+		shape.textAnchor = domain.VAlignTop
+	case domain.ShapeRectangle, domain.ShapeRoundedRectangle, domain.ShapeEllipse, domain.ShapeCallout:
+		shape.fill, shape.filled = shapeFillColor, true
+		shape.outline = domain.ShapeOutline{Color: shapeOutlineColor, Width: 1}
+	case domain.ShapeLine, domain.ShapeArrow:
+		shape.outline = domain.ShapeOutline{Color: shapeFillColor, Width: 1}
+	default:
+		return nil, errors.InvalidArgument("newShape", "shapeType", shapeType, "unsupported shape type")
+	}
+	return shape, nil
+}
+
+// ID returns the unique shape ID.
+func (s *docxShape) ID() string {
+	return s.id
+}
+
+// Type returns the shape geometry.
+func (s *docxShape) Type() domain.ShapeType {
+	return s.shapeType
+}
+
+// Size returns the shape dimensions.
+func (s *docxShape) Size() domain.ImageSize {
+	return s.size
+}
+
+// SetSize sets the shape dimensions. Sizes given only in pixels are
+// converted to EMUs at 96 DPI. Lines may have a zero width or height.
+func (s *docxShape) SetSize(size domain.ImageSize) error {
+	if size.WidthEMU == 0 && size.HeightEMU == 0 {
+		size.WidthEMU, size.HeightEMU = size.WidthPx*9525, size.HeightPx*9525
+	}
+	if size.WidthEMU < 0 || size.HeightEMU < 0 {
+		return errors.InvalidArgument("Shape.SetSize", "size", size, "dimensions cannot be negative")
+	}
+	if size.WidthEMU == 0 && size.HeightEMU == 0 || !s.isLine() && (size.WidthEMU == 0 || size.HeightEMU == 0) {
+		return errors.InvalidArgument("Shape.SetSize", "size", size, "width and height must be positive")
+	}
+	if size.WidthPx == 0 && size.HeightPx == 0 {
+		size.WidthPx = int(math.Round(float64(size.WidthEMU) / 9525))
+		size.HeightPx = int(math.Round(float64(size.HeightEMU) / 9525))
+	}
+	s.size = size
+	return nil
+}
+
+// Position returns the shape position settings.
+func (s *docxShape) Position() domain.ImagePosition {
+	return s.position
+}
+
+// SetPosition places the shape inline or floating.
+func (s *docxShape) SetPosition(pos domain.ImagePosition) error {
+	if pos.Type == "" {
+		pos.Type = domain.ImagePositionInline
+	}
+	if pos.Type != domain.ImagePositionInline && pos.Type != domain.ImagePositionFloating {
+		return errors.InvalidArgument("Shape.SetPosition", "pos.Type", pos.Type, "unsupported position type")
+	}
+	if pos.WrapText == "" {
+		pos.WrapText = domain.WrapNone
+	}
+	s.position = pos
+	return nil
+}
+
+// Fill returns the fill color and whether the shape is filled.
+func (s *docxShape) Fill() (domain.Color, bool) {
+	return s.fill, s.filled
+}
+
+// SetFill fills the shape with a solid color.
+func (s *docxShape) SetFill(color domain.Color) error {
+	if s.isLine() {
+		return errors.InvalidState("Shape.SetFill", "lines cannot be filled")
+	}
+	s.fill, s.filled = color, true
+	return nil
+}
+
+// ClearFill makes the shape transparent.
+func (s *docxShape) ClearFill() {
+	s.fill, s.filled = domain.Color{}, false
+}
+
+// Outline returns the shape outline.
+func (s *docxShape) Outline() domain.ShapeOutline {
+	return s.outline
+}
+
+// SetOutline sets the shape outline.
+func (s *docxShape) SetOutline(outline domain.ShapeOutline) error {
+	if outline.Width < 0 || outline.Width > 1584 {
+		return errors.InvalidArgument("Shape.SetOutline", "outline.Width", outline.Width, "width must be between 0 and 1584 points")
+	}
+	s.outline = outline
+	return nil
+}
+
+// TextAnchor returns the vertical alignment of the text.
+func (s *docxShape) TextAnchor() domain.VerticalAlign {
+	return s.textAnchor
+}
+
+// SetTextAnchor aligns the text to the top, center or bottom of the shape.
+func (s *docxShape) SetTextAnchor(align domain.VerticalAlign) error {
+	switch align {
+	case domain.VAlignTop, domain.VAlignCenter, domain.VAlignBottom:
+		s.textAnchor = align
+		return nil
+	default:
+		return errors.InvalidArgument("Shape.SetTextAnchor", "align", align, "text anchor must be top, center or bottom")
+	}
+}
+
+// Description returns the alt text description.
+func (s *docxShape) Description() string {
+	return s.description
+}
+
+// SetDescription sets the alt text description.
+func (s *docxShape) SetDescription(desc string) error {
+	s.description = desc
+	return nil
+}
+
+// AddParagraph adds a paragraph to the shape text.
+func (s *docxShape) AddParagraph() (domain.Paragraph, error) {
+	if s.isLine() {
+		return nil, errors.InvalidState("Shape.AddParagraph", "lines cannot hold text")
+	}
+	para := NewParagraph(s.idGen.NextParagraphID(), s.idGen, s.relManager, s.mediaManager)
+	s.paragraphs = append(s.paragraphs, para)
+	return para, nil
+}
+
+// Paragraphs returns the paragraphs of the shape text.
+func (s *docxShape) Paragraphs() []domain.Paragraph {
+	paragraphs := make([]domain.Paragraph, len(s.paragraphs))
+	copy(paragraphs, s.paragraphs)
+	return paragraphs
+}
+
+func (s *docxShape) isLine() bool {
+	return s.shapeType == domain.ShapeLine || s.shapeType == domain.ShapeArrow
+}
@@ -0,0 +1,98 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+func TestShapeDefaultsAndValidation(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+
+	box, err := para.AddTextBox(domain.NewImageSize(200, 100), domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddTextBox() error = %v", err)
+	}
+	if fill, ok := box.Fill(); !ok || fill != domain.ColorWhite {
+		t.Errorf("text box fill = %v, %v, want white", fill, ok)
+	}
+	if box.TextAnchor() != domain.VAlignTop || box.Position().Type != domain.ImagePositionInline {
+		t.Errorf("unexpected text box defaults %s %+v", box.TextAnchor(), box.Position())
+	}
+	if len(para.Runs()) != 1 || len(para.Shapes()) != 1 {
+		t.Errorf("text box should be added as a drawing run")
+	}
+
+	line, err := para.AddShape(domain.ShapeLine, domain.ImageSize{WidthPx: 100}, domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddShape(line) error = %v", err)
+	}
+	if size := line.Size(); size.WidthEMU != 952500 || size.HeightEMU != 0 {
+		t.Errorf("line size = %+v", size)
+	}
+	if _, err := line.AddParagraph(); err == nil {
+		t.Error("expected error adding text to a line")
+	}
+	if err := line.SetFill(domain.ColorRed); err == nil {
+		t.Error("expected error filling a line")
+	}
+
+	if _, err := para.AddShape("star", domain.NewImageSize(10, 10), domain.ImagePosition{}); err == nil {
+		t.Error("expected error for unknown shape type")
+	}
+	if _, err := para.AddShape(domain.ShapeRectangle, domain.NewImageSize(10, 0), domain.ImagePosition{}); err == nil {
+		t.Error("expected error for a rectangle without height")
+	}
+	if err := box.SetOutline(domain.ShapeOutline{Width: -1}); err == nil {
+		t.Error("expected error for negative outline width")
+	}
+	if err := box.SetTextAnchor(domain.VAlignInside); err == nil {
+		t.Error("expected error for unsupported text anchor")
+	}
+	if err := box.SetPosition(domain.ImagePosition{Type: "absolute"}); err == nil {
+		t.Error("expected error for unknown position type")
+	}
+}
+
+func TestTextBoxContentImages(t *testing.T) {
+	doc := NewDocument()
+	para, _ := doc.AddParagraph()
+	box, _ := para.AddTextBox(domain.NewImageSize(200, 100), domain.ImagePosition{})
+	inner, _ := box.AddParagraph()
+	if _, err := inner.AddImageFromBytes(encodeTestPNG(t, 10, 10), "", domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddImageFromBytes() error = %v", err)
+	}
+
+	images := doc.Images()
+	if len(images) != 1 || images[0].Paragraph != inner {
+		t.Fatalf("Images() = %+v, want the text box image", images)
+	}
+	if media := writtenMedia(t, doc); len(media) != 1 {
+		t.Errorf("media parts = %d, want 1", len(media))
+	}
+}
@@ -26,6 +26,8 @@ SOFTWARE.
 package core
 
 import (
+	"strings"
+
 	"github.com/mmonterroca/docxgo/v2/domain"
 	"github.com/mmonterroca/docxgo/v2/internal/manager"
 	"github.com/mmonterroca/docxgo/v2/pkg/constants"
@@ -40,6 +42,9 @@ type table struct {
 	width        domain.TableWidth
 	alignment    domain.Alignment
 	style        domain.TableStyle
+	position     *domain.TablePosition
+	doc          *document  // Owning document for body-level tables; nil when nested
+	cell         *tableCell // Parent cell for nested tables; nil at body level
 	idGen        *manager.IDGenerator
 	relManager   *manager.RelationshipManager
 	mediaManager *manager.MediaManager
@@ -171,11 +176,162 @@ func (t *table) SetStyle(style domain.TableStyle) error {
 	return nil
 }
 
+// Position returns the floating position of the table, if any.
+func (t *table) Position() (domain.TablePosition, bool) {
+	if t.position == nil {
+		return domain.TablePosition{}, false
+	}
+	return *t.position, true
+}
+
+// SetPosition turns the table into a floating table.
+func (t *table) SetPosition(pos domain.TablePosition) error {
+	const op = "Table.SetPosition"
+
+	if pos.HorizontalAnchor < domain.TableAnchorText || pos.HorizontalAnchor > domain.TableAnchorPage {
+		return errors.InvalidArgument(op, "pos.HorizontalAnchor", pos.HorizontalAnchor,
+			"invalid horizontal anchor")
+	}
+	if pos.VerticalAnchor < domain.TableAnchorText || pos.VerticalAnchor > domain.TableAnchorPage {
+		return errors.InvalidArgument(op, "pos.VerticalAnchor", pos.VerticalAnchor,
+			"invalid vertical anchor")
+	}
+	if pos.LeftFromText < 0 || pos.RightFromText < 0 || pos.TopFromText < 0 || pos.BottomFromText < 0 {
+		return errors.InvalidArgument(op, "pos", pos,
+			"distance from text cannot be negative")
+	}
+
+	t.position = &pos
+	return nil
+}
+
+// ClearPosition removes floating positioning from the table.
+func (t *table) ClearPosition() {
+	t.position = nil
+}
+
+// Grid returns the logical layout of the table with merge spans resolved.
+func (t *table) Grid() [][]domain.CellMergeInfo {
+	cellAt := t.cellAt
+
+	colOf := func(row int, target *tableCell) int {
+		r, ok := t.rows[row].(*tableRow)
+		if !ok {
+			return -1
+		}
+		for idx, candidate := range r.cells {
+			if c, ok := candidate.(*tableCell); ok && c == target {
+				return idx
+			}
+		}
+		return -1
+	}
+
+	grid := make([][]domain.CellMergeInfo, len(t.rows))
+	for rowIdx, row := range t.rows {
+		grid[rowIdx] = make([]domain.CellMergeInfo, len(row.Cells()))
+		for colIdx := range grid[rowIdx] {
+			cell := cellAt(rowIdx, colIdx)
+			if cell == nil {
+				grid[rowIdx][colIdx] = domain.CellMergeInfo{GridSpan: 1, RowSpan: 1, ColSpan: 1, OriginRow: rowIdx, OriginCol: colIdx}
+				continue
+			}
+
+			// Resolve the leading cell of a horizontal merge in this row.
+			leader, leaderCol := cell, colIdx
+			if cell.hMergeParent != nil {
+				if col := colOf(rowIdx, cell.hMergeParent); col >= 0 {
+					leader, leaderCol = cell.hMergeParent, col
+				}
+			}
+
+			// Walk up through vertical continuations to the restart cell.
+			originRow := rowIdx
+			if leader.vMerge == domain.VMergeContinue {
+				for originRow > 0 {
+					above := cellAt(originRow-1, leaderCol)
+					if above == nil || above.hMergeParent != nil {
+						break
+					}
+					originRow--
+					if above.vMerge != domain.VMergeContinue {
+						break
+					}
+				}
+			}
+
+			origin := cellAt(originRow, leaderCol)
+			rowSpan := 1
+			if origin != nil && origin.vMerge == domain.VMergeRestart {
+				for next := originRow + 1; ; next++ {
+					below := cellAt(next, leaderCol)
+					if below == nil || below.vMerge != domain.VMergeContinue {
+						break
+					}
+					rowSpan++
+				}
+			}
+
+			colSpan := 1
+			if origin != nil && origin.gridSpan > 1 {
+				colSpan = origin.gridSpan
+			}
+
+			grid[rowIdx][colIdx] = domain.CellMergeInfo{
+				GridSpan:  cell.gridSpan,
+				VMerge:    cell.vMerge,
+				RowSpan:   rowSpan,
+				ColSpan:   colSpan,
+				OriginRow: originRow,
+				OriginCol: leaderCol,
+			}
+		}
+	}
+
+	return grid
+}
+
+// cellAt returns the cell at (row, col), or nil when out of range.
+func (t *table) cellAt(row, col int) *tableCell {
+	if row < 0 || row >= len(t.rows) {
+		return nil
+	}
+	r, ok := t.rows[row].(*tableRow)
+	if !ok || col < 0 || col >= len(r.cells) {
+		return nil
+	}
+	c, _ := r.cells[col].(*tableCell)
+	return c
+}
+
+// AddCaption inserts a numbered caption paragraph next to the table.
+func (t *table) AddCaption(label, text string, position domain.CaptionPosition) (domain.Paragraph, error) {
+	const op = "Table.AddCaption"
+
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return nil, errors.InvalidArgument(op, "label", label, "caption label cannot be empty")
+	}
+	if strings.ContainsAny(label, " \t\"") {
+		return nil, errors.InvalidArgument(op, "label", label,
+			"caption label must be a single word (it names the SEQ sequence)")
+	}
+	if position < domain.CaptionAbove || position > domain.CaptionBelow {
+		return nil, errors.InvalidArgument(op, "position", position, "invalid caption position")
+	}
+	if t.doc == nil {
+		return nil, errors.InvalidState(op, "captions are only supported on tables in the document body")
+	}
+
+	return t.doc.insertTableCaption(t, label, text, position)
+}
+
 // tableRow implements the domain.TableRow interface.
 type tableRow struct {
 	id           string
 	cells        []domain.TableCell
 	height       int
+	exactHeight  bool // height is fixed rather than a minimum
 	table        *table
 	idGen        *manager.IDGenerator
 	relManager   *manager.RelationshipManager
@@ -225,13 +381,31 @@ func (r *tableRow) Height() int {
 	return r.height
 }
 
-// SetHeight sets the row height in twips.
+// SetHeight sets the row height in twips. The row grows with its content.
 func (r *tableRow) SetHeight(twips int) error {
 	if twips < 0 {
 		return errors.InvalidArgument("TableRow.SetHeight", "twips", twips,
 			"height cannot be negative")
 	}
 	r.height = twips
+	r.exactHeight = false
+	return nil
+}
+
+// ExactHeight reports whether the row height is fixed.
+func (r *tableRow) ExactHeight() bool {
+	return r.exactHeight
+}
+
+// SetExactHeight fixes the row height in twips; content that does not
+// fit is clipped.
+func (r *tableRow) SetExactHeight(twips int) error {
+	if twips < 0 {
+		return errors.InvalidArgument("TableRow.SetExactHeight", "twips", twips,
+			"height cannot be negative")
+	}
+	r.height = twips
+	r.exactHeight = true
 	return nil
 }
 
@@ -505,9 +679,12 @@ func (c *tableCell) AddTable(rows, cols int) (domain.Table, error) {
 			"cols must be at least 1")
 	}
 
-	table := NewTable(c.idGen.GenerateID("table"), rows, cols, c.idGen, c.relManager, c.mediaManager)
-	c.tables = append(c.tables, table)
-	return table, nil
+	nested := NewTable(c.idGen.GenerateID("table"), rows, cols, c.idGen, c.relManager, c.mediaManager)
+	if coreTable, ok := nested.(*table); ok {
+		coreTable.cell = c
+	}
+	c.tables = append(c.tables, nested)
+	return nested, nil
 }
 
 // Tables returns all nested tables in this cell.
@@ -384,3 +384,193 @@ func TestTableCellAddTable_InvalidArguments(t *testing.T) {
 		})
 	}
 }
+
+func TestTablePosition(t *testing.T) {
+	table := newTestTable("tbl1", 2, 2)
+
+	if _, ok := table.Position(); ok {
+		t.Fatal("new table should not be floating")
+	}
+
+	pos := domain.TablePosition{
+		HorizontalAnchor: domain.TableAnchorMargin,
+		VerticalAnchor:   domain.TableAnchorPage,
+		X:                1440,
+		Y:                2880,
+		LeftFromText:     180,
+		RightFromText:    180,
+	}
+	if err := table.SetPosition(pos); err != nil {
+		t.Fatalf("SetPosition() error = %v", err)
+	}
+
+	got, ok := table.Position()
+	if !ok || got != pos {
+		t.Errorf("Position() = %+v, %v; want %+v, true", got, ok, pos)
+	}
+
+	table.ClearPosition()
+	if _, ok := table.Position(); ok {
+		t.Error("Position() should be cleared")
+	}
+
+	if err := table.SetPosition(domain.TablePosition{TopFromText: -1}); err == nil {
+		t.Error("SetPosition() should reject negative distances")
+	}
+	if err := table.SetPosition(domain.TablePosition{HorizontalAnchor: domain.TableAnchor(99)}); err == nil {
+		t.Error("SetPosition() should reject unknown anchors")
+	}
+}
+
+func TestTableAddCaption(t *testing.T) {
+	doc := NewDocument()
+	if _, err := doc.AddParagraph(); err != nil {
+		t.Fatalf("AddParagraph() error = %v", err)
+	}
+	first, _ := doc.AddTable(2, 2)
+	second, _ := doc.AddTable(2, 2)
+
+	above, err := first.AddCaption("Table", "Quarterly results", domain.CaptionAbove)
+	if err != nil {
+		t.Fatalf("AddCaption() error = %v", err)
+	}
+	below, err := second.AddCaption("Table", "", domain.CaptionBelow)
+	if err != nil {
+		t.Fatalf("AddCaption() error = %v", err)
+	}
+
+	blocks := doc.Blocks()
+	if len(blocks) != 5 {
+		t.Fatalf("expected 5 blocks, got %d", len(blocks))
+	}
+	if blocks[1].Paragraph != above || blocks[2].Table != first {
+		t.Error("caption above should precede the first table")
+	}
+	if blocks[3].Table != second || blocks[4].Paragraph != below {
+		t.Error("caption below should follow the second table")
+	}
+
+	paras := doc.Paragraphs()
+	if len(paras) != 3 || paras[1] != above || paras[2] != below {
+		t.Error("captions should be kept in document order in Paragraphs()")
+	}
+
+	styled, ok := above.(interface{ StyleName() string })
+	if !ok || styled.StyleName() != domain.StyleIDCaption {
+		t.Error("caption paragraph should use the Caption style")
+	}
+
+	runs := above.Runs()
+	if len(runs) != 3 {
+		t.Fatalf("expected 3 caption runs, got %d", len(runs))
+	}
+	if runs[0].Text() != "Table " || runs[2].Text() != ": Quarterly results" {
+		t.Errorf("unexpected caption text %q / %q", runs[0].Text(), runs[2].Text())
+	}
+
+	fields := runs[1].(interface{ Fields() []domain.Field }).Fields()
+	if len(fields) != 1 || fields[0].Type() != domain.FieldTypeSeq {
+		t.Fatalf("expected a SEQ field in the caption, got %v", fields)
+	}
+	if fields[0].Code() != `SEQ Table \* ARABIC` {
+		t.Errorf("Code() = %q", fields[0].Code())
+	}
+
+	secondFields := below.Runs()[1].(interface{ Fields() []domain.Field }).Fields()
+	if secondFields[0].Result() != "2" {
+		t.Errorf("second caption number = %q, want 2", secondFields[0].Result())
+	}
+	if len(below.Runs()) != 2 {
+		t.Error("caption without text should not add a trailing run")
+	}
+}
+
+func TestTableAddCaptionNumbersByPosition(t *testing.T) {
+	doc := NewDocument()
+	first, _ := doc.AddTable(1, 1)
+	second, _ := doc.AddTable(1, 1)
+	third, _ := doc.AddTable(1, 1)
+
+	number := func(caption domain.Paragraph) string {
+		t.Helper()
+		fields := caption.Runs()[1].(interface{ Fields() []domain.Field }).Fields()
+		return fields[0].Result()
+	}
+
+	last, _ := third.AddCaption("Table", "", domain.CaptionBelow)
+	head, _ := first.AddCaption("Table", "", domain.CaptionAbove)
+	figure, _ := second.AddCaption("Figure", "", domain.CaptionBelow)
+	middle, _ := second.AddCaption("Table", "", domain.CaptionAbove)
+
+	for _, tc := range []struct {
+		caption domain.Paragraph
+		want    string
+	}{
+		{head, "1"},
+		{middle, "2"},
+		{last, "3"},
+		{figure, "1"},
+	} {
+		if got := number(tc.caption); got != tc.want {
+			t.Errorf("caption %q numbered %s, want %s", tc.caption.Text(), got, tc.want)
+		}
+	}
+}
+
+func TestTableAddCaption_Invalid(t *testing.T) {
+	doc := NewDocument()
+	table, _ := doc.AddTable(1, 1)
+
+	if _, err := table.AddCaption("", "text", domain.CaptionAbove); err == nil {
+		t.Error("expected error for empty label")
+	}
+	if _, err := table.AddCaption("My Table", "text", domain.CaptionAbove); err == nil {
+		t.Error("expected error for multi-word label")
+	}
+	if _, err := table.AddCaption("Table", "text", domain.CaptionPosition(5)); err == nil {
+		t.Error("expected error for invalid position")
+	}
+
+	row, _ := table.Row(0)
+	cell, _ := row.Cell(0)
+	nested, _ := cell.AddTable(1, 1)
+	if _, err := nested.AddCaption("Table", "nested", domain.CaptionAbove); err == nil {
+		t.Error("expected error for nested table caption")
+	}
+}
+
+func TestTableGrid(t *testing.T) {
+	table := newTestTable("tblGrid", 3, 3)
+
+	row, _ := table.Row(0)
+	origin, _ := row.Cell(1)
+	if err := origin.Merge(2, 2); err != nil {
+		t.Fatalf("Merge(2, 2) error = %v", err)
+	}
+
+	grid := table.Grid()
+	if len(grid) != 3 || len(grid[0]) != 3 {
+		t.Fatalf("Grid() dimensions = %dx%d, want 3x3", len(grid), len(grid[0]))
+	}
+
+	for _, pos := range [][2]int{{0, 1}, {0, 2}, {1, 1}, {1, 2}} {
+		info := grid[pos[0]][pos[1]]
+		if info.OriginRow != 0 || info.OriginCol != 1 {
+			t.Errorf("grid[%d][%d] origin = (%d,%d), want (0,1)", pos[0], pos[1], info.OriginRow, info.OriginCol)
+		}
+		if info.RowSpan != 2 || info.ColSpan != 2 {
+			t.Errorf("grid[%d][%d] span = %dx%d, want 2x2", pos[0], pos[1], info.RowSpan, info.ColSpan)
+		}
+	}
+	if !grid[0][1].IsOrigin(0, 1) {
+		t.Error("grid[0][1] should be the merge origin")
+	}
+	if grid[1][2].IsOrigin(1, 2) {
+		t.Error("grid[1][2] should be covered by the merge")
+	}
+
+	plain := grid[2][0]
+	if !plain.IsOrigin(2, 0) || plain.RowSpan != 1 || plain.ColSpan != 1 {
+		t.Errorf("grid[2][0] = %+v, want an unmerged cell", plain)
+	}
+}
@@ -0,0 +1,450 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+Copyright (c) 2020-2023 fumiama (original go-docx)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+package core
+
+import (
+	"slices"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// mergeRegion is a rectangular merged area identified by its top-left cell.
+type mergeRegion struct {
+	row, col   int
+	rows, cols int
+}
+
+// mergeRegions returns the merged regions of the table. Spans that do not
+// form a consistent rectangle (e.g. set by hand with SetGridSpan) are ignored.
+func (t *table) mergeRegions() []mergeRegion {
+	grid := t.Grid()
+	regions := make([]mergeRegion, 0)
+
+	for r, cells := range grid {
+		for c, info := range cells {
+			if !info.IsOrigin(r, c) || (info.RowSpan <= 1 && info.ColSpan <= 1) {
+				continue
+			}
+
+			region := mergeRegion{row: r, col: c, rows: info.RowSpan, cols: info.ColSpan}
+			if regionCovered(grid, region) {
+				regions = append(regions, region)
+			}
+		}
+	}
+
+	return regions
+}
+
+func regionCovered(grid [][]domain.CellMergeInfo, region mergeRegion) bool {
+	for r := region.row; r < region.row+region.rows; r++ {
+		if r >= len(grid) || region.col+region.cols > len(grid[r]) {
+			return false
+		}
+		for c := region.col; c < region.col+region.cols; c++ {
+			if info := grid[r][c]; info.OriginRow != region.row || info.OriginCol != region.col {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// applyMergeRegions clears all merge state and re-merges the given regions.
+func (t *table) applyMergeRegions(op string, regions []mergeRegion) error {
+	rows, err := t.coreRows(op)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		for _, candidate := range row.cells {
+			if cell, ok := candidate.(*tableCell); ok {
+				cell.gridSpan = 1
+				cell.vMerge = domain.VMergeNone
+				cell.hMergeParent = nil
+			}
+		}
+	}
+
+	for _, region := range regions {
+		if region.rows <= 1 && region.cols <= 1 {
+			continue
+		}
+		origin := t.cellAt(region.row, region.col)
+		if origin == nil {
+			return errors.InvalidState(op, "merge region outside of table")
+		}
+		if err := origin.Merge(region.cols, region.rows); err != nil {
+			return errors.Wrap(err, op)
+		}
+	}
+
+	return nil
+}
+
+// coreRows returns the rows as their concrete type.
+func (t *table) coreRows(op string) ([]*tableRow, error) {
+	rows := make([]*tableRow, len(t.rows))
+	for i, candidate := range t.rows {
+		row, ok := candidate.(*tableRow)
+		if !ok {
+			return nil, errors.InvalidState(op, "unexpected row implementation type")
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// InsertColumn inserts an empty column before index.
+func (t *table) InsertColumn(index int) error {
+	const op = "Table.InsertColumn"
+
+	if index < 0 || index > t.cols {
+		return errors.InvalidArgument(op, "index", index, "column index out of bounds")
+	}
+
+	rows, err := t.coreRows(op)
+	if err != nil {
+		return err
+	}
+	regions := t.mergeRegions()
+
+	for _, row := range rows {
+		cell := NewTableCell(row, t.idGen.NextCellID(), t.idGen, t.relManager, t.mediaManager)
+		at := min(index, len(row.cells))
+		row.cells = slices.Insert(row.cells, at, cell)
+	}
+	t.cols++
+
+	for i := range regions {
+		region := &regions[i]
+		switch {
+		case region.col >= index:
+			region.col++
+		case region.col+region.cols > index:
+			region.cols++
+		}
+	}
+
+	return t.applyMergeRegions(op, regions)
+}
+
+// DeleteColumn deletes the column at index.
+func (t *table) DeleteColumn(index int) error {
+	const op = "Table.DeleteColumn"
+
+	if index < 0 || index >= t.cols {
+		return errors.InvalidArgument(op, "index", index, "column index out of bounds")
+	}
+	if t.cols == 1 {
+		return errors.InvalidState(op, "cannot delete the only column of a table")
+	}
+
+	rows, err := t.coreRows(op)
+	if err != nil {
+		return err
+	}
+
+	// Inside a merged region every cell but the origin is an empty
+	// continuation, so drop the region's last cell instead of the one at
+	// index to keep the origin (and its content) alive.
+	remove := make([]int, len(rows))
+	for i := range remove {
+		remove[i] = index
+	}
+
+	regions := t.mergeRegions()
+	kept := regions[:0]
+	for _, region := range regions {
+		switch {
+		case region.col > index:
+			region.col--
+		case region.col+region.cols > index:
+			if region.cols == 1 {
+				continue
+			}
+			for r := region.row; r < region.row+region.rows; r++ {
+				remove[r] = region.col + region.cols - 1
+			}
+			region.cols--
+		}
+		kept = append(kept, region)
+	}
+
+	for i, row := range rows {
+		if remove[i] < len(row.cells) {
+			row.cells = slices.Delete(row.cells, remove[i], remove[i]+1)
+		}
+	}
+	t.cols--
+
+	return t.applyMergeRegions(op, kept)
+}
+
+// SplitAt moves rows index.. into a new table placed after this one.
+func (t *table) SplitAt(index int) (domain.Table, error) {
+	const op = "Table.SplitAt"
+
+	if index <= 0 || index >= len(t.rows) {
+		return nil, errors.InvalidArgument(op, "index", index,
+			"split must leave at least one row in each table")
+	}
+
+	rows, err := t.coreRows(op)
+	if err != nil {
+		return nil, err
+	}
+
+	blockIndex := -1
+	if t.doc != nil {
+		if blockIndex = t.doc.tableBlockIndex(t); blockIndex == -1 {
+			return nil, errors.InvalidState(op, "table not found in document body")
+		}
+	}
+
+	var top, bottom []mergeRegion
+	for _, region := range t.mergeRegions() {
+		switch {
+		case region.row+region.rows <= index:
+			top = append(top, region)
+		case region.row >= index:
+			region.row -= index
+			bottom = append(bottom, region)
+		default:
+			upper, lower := region, region
+			upper.rows = index - region.row
+			lower.row, lower.rows = 0, region.row+region.rows-index
+			top = append(top, upper)
+			bottom = append(bottom, lower)
+		}
+	}
+
+	second := &table{
+		id:           t.idGen.GenerateID("table"),
+		rows:         make([]domain.TableRow, 0, len(rows)-index),
+		cols:         t.cols,
+		width:        t.width,
+		alignment:    t.alignment,
+		style:        t.style,
+		doc:          t.doc,
+		cell:         t.cell,
+		idGen:        t.idGen,
+		relManager:   t.relManager,
+		mediaManager: t.mediaManager,
+	}
+	for _, row := range rows[index:] {
+		row.table = second
+		second.rows = append(second.rows, row)
+	}
+	t.rows = slices.Clone(t.rows[:index])
+
+	if err := t.applyMergeRegions(op, top); err != nil {
+		return nil, err
+	}
+	if err := second.applyMergeRegions(op, bottom); err != nil {
+		return nil, err
+	}
+
+	switch {
+	case t.doc != nil:
+		// Adjacent tables are joined when Word opens the file, so keep them
+		// apart with an empty paragraph.
+		separator := NewParagraph(t.idGen.NextParagraphID(), t.idGen, t.relManager, t.mediaManager)
+		t.doc.insertBlocks(blockIndex+1, domain.Block{Paragraph: separator}, domain.Block{Table: second})
+	case t.cell != nil:
+		t.cell.insertTableAfter(t, second)
+	}
+
+	return second, nil
+}
+
+// MergeWith appends the rows of next and removes next from its container.
+func (t *table) MergeWith(next domain.Table) error {
+	const op = "Table.MergeWith"
+
+	other, ok := next.(*table)
+	if !ok || other == nil {
+		return errors.InvalidArgument(op, "next", next, "table was not created by this document")
+	}
+	if other == t {
+		return errors.InvalidArgument(op, "next", next, "cannot merge a table with itself")
+	}
+	if other.cols != t.cols {
+		return errors.InvalidArgument(op, "next", other.cols,
+			"tables must have the same number of columns")
+	}
+
+	otherRows, err := other.coreRows(op)
+	if err != nil {
+		return err
+	}
+
+	regions := t.mergeRegions()
+	for _, region := range other.mergeRegions() {
+		region.row += len(t.rows)
+		regions = append(regions, region)
+	}
+
+	switch {
+	case other.doc != nil:
+		other.doc.detachTable(other, t)
+	case other.cell != nil:
+		other.cell.removeTable(other)
+	}
+	other.doc, other.cell = nil, nil
+
+	for _, row := range otherRows {
+		row.table = t
+		t.rows = append(t.rows, row)
+	}
+	other.rows = make([]domain.TableRow, 0)
+
+	return t.applyMergeRegions(op, regions)
+}
+
+// SortRows stably sorts the rows below headerRows by the cells in column col.
+func (t *table) SortRows(col int, compare func(a, b domain.TableCell) int, headerRows int) error {
+	const op = "Table.SortRows"
+
+	if col < 0 || col >= t.cols {
+		return errors.InvalidArgument(op, "col", col, "column index out of bounds")
+	}
+	if headerRows < 0 || headerRows > len(t.rows) {
+		return errors.InvalidArgument(op, "headerRows", headerRows, "header row count out of bounds")
+	}
+	if compare == nil {
+		compare = domain.CompareCellText
+	}
+
+	for _, region := range t.mergeRegions() {
+		if region.rows > 1 && region.row+region.rows > headerRows {
+			return errors.InvalidState(op, "cannot sort rows that are part of a vertical merge")
+		}
+	}
+
+	rows, err := t.coreRows(op)
+	if err != nil {
+		return err
+	}
+
+	// Horizontal merges live within a row and move with it.
+	key := func(row *tableRow) domain.TableCell {
+		if col >= len(row.cells) {
+			return nil
+		}
+		cell, ok := row.cells[col].(*tableCell)
+		if !ok {
+			return row.cells[col]
+		}
+		if cell.hMergeParent != nil {
+			return cell.hMergeParent
+		}
+		return cell
+	}
+
+	body := rows[headerRows:]
+	slices.SortStableFunc(body, func(a, b *tableRow) int {
+		return compare(key(a), key(b))
+	})
+	for i, row := range body {
+		t.rows[headerRows+i] = row
+	}
+
+	return nil
+}
+
+// Transpose swaps rows and columns.
+func (t *table) Transpose() error {
+	const op = "Table.Transpose"
+
+	if len(t.rows) == 0 {
+		return errors.InvalidState(op, "cannot transpose an empty table")
+	}
+
+	rows, err := t.coreRows(op)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if len(row.cells) != t.cols {
+			return errors.InvalidState(op, "row cell count does not match column count")
+		}
+	}
+
+	regions := t.mergeRegions()
+
+	transposed := make([]domain.TableRow, t.cols)
+	for c := 0; c < t.cols; c++ {
+		newRow := &tableRow{
+			id:           t.idGen.NextRowID(),
+			cells:        make([]domain.TableCell, 0, len(rows)),
+			table:        t,
+			idGen:        t.idGen,
+			relManager:   t.relManager,
+			mediaManager: t.mediaManager,
+		}
+		for _, row := range rows {
+			cell := row.cells[c]
+			if coreCell, ok := cell.(*tableCell); ok {
+				coreCell.row = newRow
+				coreCell.width = 0
+			}
+			newRow.cells = append(newRow.cells, cell)
+		}
+		transposed[c] = newRow
+	}
+
+	t.rows = transposed
+	t.cols = len(rows)
+
+	for i := range regions {
+		region := &regions[i]
+		region.row, region.col = region.col, region.row
+		region.rows, region.cols = region.cols, region.rows
+	}
+
+	return t.applyMergeRegions(op, regions)
+}
+
+// insertTableAfter places tbl right after anchor among the nested tables.
+func (c *tableCell) insertTableAfter(anchor, tbl *table) {
+	for i, candidate := range c.tables {
+		if existing, ok := candidate.(*table); ok && existing == anchor {
+			c.tables = slices.Insert(c.tables, i+1, domain.Table(tbl))
+			return
+		}
+	}
+	c.tables = append(c.tables, tbl)
+}
+
+// removeTable removes tbl from the nested tables.
+func (c *tableCell) removeTable(tbl *table) {
+	c.tables = slices.DeleteFunc(c.tables, func(candidate domain.Table) bool {
+		existing, ok := candidate.(*table)
+		return ok && existing == tbl
+	})
+}
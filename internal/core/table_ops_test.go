@@ -0,0 +1,379 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package core
+
+import (
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+func opsCell(t *testing.T, table domain.Table, row, col int) domain.TableCell {
+	t.Helper()
+	r, err := table.Row(row)
+	if err != nil {
+		t.Fatalf("Row(%d) error = %v", row, err)
+	}
+	cell, err := r.Cell(col)
+	if err != nil {
+		t.Fatalf("Cell(%d) error = %v", col, err)
+	}
+	return cell
+}
+
+func setCellText(t *testing.T, table domain.Table, row, col int, text string) domain.TableCell {
+	t.Helper()
+	cell := opsCell(t, table, row, col)
+	para, _ := cell.AddParagraph()
+	run, _ := para.AddRun()
+	_ = run.SetText(text)
+	return cell
+}
+
+func columnText(t *testing.T, table domain.Table, col int) []string {
+	t.Helper()
+	texts := make([]string, table.RowCount())
+	for i := range texts {
+		texts[i] = domain.CellText(opsCell(t, table, i, col))
+	}
+	return texts
+}
+
+func assertRegion(t *testing.T, table domain.Table, row, col, rows, cols int) {
+	t.Helper()
+	grid := table.Grid()
+	for r := row; r < row+rows; r++ {
+		for c := col; c < col+cols; c++ {
+			info := grid[r][c]
+			if info.OriginRow != row || info.OriginCol != col || info.RowSpan != rows || info.ColSpan != cols {
+				t.Fatalf("grid[%d][%d] = %+v, want region (%d,%d) %dx%d", r, c, info, row, col, rows, cols)
+			}
+		}
+	}
+}
+
+func TestTableInsertColumn(t *testing.T) {
+	table := newTestTable("tblInsertCol", 2, 3)
+	header := setCellText(t, table, 0, 0, "Header")
+	if err := header.Merge(2, 1); err != nil {
+		t.Fatalf("Merge error = %v", err)
+	}
+	setCellText(t, table, 1, 2, "last")
+
+	if err := table.InsertColumn(1); err != nil {
+		t.Fatalf("InsertColumn(1) error = %v", err)
+	}
+	if table.ColumnCount() != 4 {
+		t.Fatalf("ColumnCount() = %d, want 4", table.ColumnCount())
+	}
+	assertRegion(t, table, 0, 0, 1, 3)
+	if got := domain.CellText(opsCell(t, table, 1, 3)); got != "last" {
+		t.Errorf("shifted cell text = %q, want %q", got, "last")
+	}
+
+	if err := table.InsertColumn(0); err != nil {
+		t.Fatalf("InsertColumn(0) error = %v", err)
+	}
+	assertRegion(t, table, 0, 1, 1, 3)
+	if got := domain.CellText(opsCell(t, table, 0, 1)); got != "Header" {
+		t.Errorf("merge origin text = %q, want Header", got)
+	}
+
+	if err := table.InsertColumn(table.ColumnCount()); err != nil {
+		t.Fatalf("append column error = %v", err)
+	}
+	if err := table.InsertColumn(table.ColumnCount() + 1); err == nil {
+		t.Error("expected error for out-of-range index")
+	}
+	for i, row := range table.Rows() {
+		if len(row.Cells()) != table.ColumnCount() {
+			t.Errorf("row %d has %d cells, want %d", i, len(row.Cells()), table.ColumnCount())
+		}
+	}
+}
+
+func TestTableDeleteColumn(t *testing.T) {
+	table := newTestTable("tblDeleteCol", 3, 3)
+	header := setCellText(t, table, 0, 0, "Header")
+	if err := header.Merge(2, 1); err != nil {
+		t.Fatalf("Merge error = %v", err)
+	}
+	side := setCellText(t, table, 1, 2, "side")
+	if err := side.Merge(1, 2); err != nil {
+		t.Fatalf("Merge error = %v", err)
+	}
+
+	// Deleting the origin column of a 2-wide merge keeps its content.
+	if err := table.DeleteColumn(0); err != nil {
+		t.Fatalf("DeleteColumn(0) error = %v", err)
+	}
+	if table.ColumnCount() != 2 {
+		t.Fatalf("ColumnCount() = %d, want 2", table.ColumnCount())
+	}
+	if got := domain.CellText(opsCell(t, table, 0, 0)); got != "Header" {
+		t.Errorf("header text = %q, want Header", got)
+	}
+	if cell := opsCell(t, table, 0, 0); cell.GridSpan() != 1 || cell.VMerge() != domain.VMergeNone {
+		t.Error("header should no longer be merged")
+	}
+	assertRegion(t, table, 1, 1, 2, 1)
+
+	// Deleting a column holding a 1-wide vertical merge drops the region.
+	if err := table.DeleteColumn(1); err != nil {
+		t.Fatalf("DeleteColumn(1) error = %v", err)
+	}
+	for r := 0; r < table.RowCount(); r++ {
+		if cell := opsCell(t, table, r, 0); cell.VMerge() != domain.VMergeNone {
+			t.Errorf("row %d VMerge() = %v, want none", r, cell.VMerge())
+		}
+	}
+
+	if err := table.DeleteColumn(0); err == nil {
+		t.Error("expected error when deleting the only column")
+	}
+	if err := table.DeleteColumn(5); err == nil {
+		t.Error("expected error for out-of-range index")
+	}
+}
+
+func TestTableSplitAndMerge(t *testing.T) {
+	doc := NewDocument()
+	table, _ := doc.AddTable(4, 2)
+	for r := 0; r < 4; r++ {
+		setCellText(t, table, r, 1, string(rune('a'+r)))
+	}
+	span := setCellText(t, table, 1, 0, "span")
+	if err := span.Merge(1, 2); err != nil {
+		t.Fatalf("Merge error = %v", err)
+	}
+
+	second, err := table.SplitAt(2)
+	if err != nil {
+		t.Fatalf("SplitAt(2) error = %v", err)
+	}
+	if table.RowCount() != 2 || second.RowCount() != 2 {
+		t.Fatalf("row counts = %d/%d, want 2/2", table.RowCount(), second.RowCount())
+	}
+	if got := columnText(t, second, 1); got[0] != "c" || got[1] != "d" {
+		t.Errorf("second table rows = %v", got)
+	}
+
+	// The vertical merge across the split becomes a plain cell on each side.
+	if cell := opsCell(t, table, 1, 0); cell.VMerge() != domain.VMergeNone || domain.CellText(cell) != "span" {
+		t.Errorf("upper part of split merge: VMerge=%v text=%q", cell.VMerge(), domain.CellText(cell))
+	}
+	if cell := opsCell(t, second, 0, 0); cell.VMerge() != domain.VMergeNone {
+		t.Errorf("lower part of split merge VMerge() = %v", cell.VMerge())
+	}
+
+	blocks := doc.Blocks()
+	if len(blocks) != 3 || blocks[0].Table != table || blocks[1].Paragraph == nil || blocks[2].Table != second {
+		t.Fatalf("unexpected body after split: %+v", blocks)
+	}
+	if len(doc.Tables()) != 2 || len(doc.Paragraphs()) != 1 {
+		t.Fatalf("tables/paragraphs = %d/%d, want 2/1", len(doc.Tables()), len(doc.Paragraphs()))
+	}
+
+	if _, err := table.SplitAt(0); err == nil {
+		t.Error("expected error when splitting before the first row")
+	}
+
+	if err := table.MergeWith(second); err != nil {
+		t.Fatalf("MergeWith error = %v", err)
+	}
+	if table.RowCount() != 4 {
+		t.Fatalf("RowCount() = %d, want 4", table.RowCount())
+	}
+	if got := columnText(t, table, 1); got[3] != "d" {
+		t.Errorf("merged rows = %v", got)
+	}
+	if len(doc.Blocks()) != 1 || len(doc.Tables()) != 1 || len(doc.Paragraphs()) != 0 {
+		t.Errorf("merge should remove the second table and the separator, blocks = %d", len(doc.Blocks()))
+	}
+
+	if err := table.MergeWith(table); err == nil {
+		t.Error("expected error when merging a table with itself")
+	}
+	narrow, _ := doc.AddTable(1, 3)
+	if err := table.MergeWith(narrow); err == nil {
+		t.Error("expected error when column counts differ")
+	}
+}
+
+func TestTableMergeKeepsSectionBreakAndBookmark(t *testing.T) {
+	// A paragraph ending a section sits between the tables, as read from
+	// a file where its w:pPr holds the w:sectPr.
+	doc := NewDocument()
+	first, _ := doc.AddTable(1, 2)
+	if _, err := doc.AddParagraph(); err != nil {
+		t.Fatalf("AddParagraph error = %v", err)
+	}
+	if _, err := doc.AddSectionWithBreak(domain.SectionBreakTypeContinuous); err != nil {
+		t.Fatalf("AddSectionWithBreak error = %v", err)
+	}
+	second, _ := doc.AddTable(1, 2)
+	if err := first.MergeWith(second); err != nil {
+		t.Fatalf("MergeWith error = %v", err)
+	}
+	blocks := doc.Blocks()
+	if len(blocks) != 3 || blocks[0].Table != first || blocks[1].Paragraph == nil || blocks[2].SectionBreak == nil {
+		t.Errorf("section break paragraph removed, blocks = %+v", blocks)
+	}
+	if first.RowCount() != 2 {
+		t.Errorf("RowCount() = %d, want 2", first.RowCount())
+	}
+
+	// An empty paragraph holding a bookmark is kept too.
+	doc = NewDocument()
+	first, _ = doc.AddTable(1, 2)
+	marked, _ := doc.AddParagraph()
+	marked.(*paragraph).SetBookmark("1", "_Toc1")
+	second, _ = doc.AddTable(1, 2)
+	if err := first.MergeWith(second); err != nil {
+		t.Fatalf("MergeWith error = %v", err)
+	}
+	if blocks := doc.Blocks(); len(blocks) != 2 || blocks[1].Paragraph != marked {
+		t.Errorf("bookmarked paragraph removed, blocks = %+v", blocks)
+	}
+}
+
+func TestTableSplitAndMerge_KeepsVerticalMerges(t *testing.T) {
+	table := newTestTable("tblSplitMerge", 4, 2)
+	if err := opsCell(t, table, 0, 0).Merge(2, 2); err != nil {
+		t.Fatalf("Merge error = %v", err)
+	}
+	if err := opsCell(t, table, 2, 1).Merge(1, 2); err != nil {
+		t.Fatalf("Merge error = %v", err)
+	}
+
+	second, err := table.SplitAt(2)
+	if err != nil {
+		t.Fatalf("SplitAt error = %v", err)
+	}
+	assertRegion(t, table, 0, 0, 2, 2)
+	assertRegion(t, second, 0, 1, 2, 1)
+
+	if err := table.MergeWith(second); err != nil {
+		t.Fatalf("MergeWith error = %v", err)
+	}
+	assertRegion(t, table, 0, 0, 2, 2)
+	assertRegion(t, table, 2, 1, 2, 1)
+}
+
+func TestTableNestedSplit(t *testing.T) {
+	outer := newTestTable("tblOuter", 1, 1)
+	cell := opsCell(t, outer, 0, 0)
+	nested, _ := cell.AddTable(3, 1)
+
+	second, err := nested.SplitAt(1)
+	if err != nil {
+		t.Fatalf("SplitAt error = %v", err)
+	}
+	tables := cell.Tables()
+	if len(tables) != 2 || tables[0] != nested || tables[1] != second {
+		t.Fatalf("expected the split table right after the original, got %d tables", len(tables))
+	}
+
+	if err := nested.MergeWith(second); err != nil {
+		t.Fatalf("MergeWith error = %v", err)
+	}
+	if len(cell.Tables()) != 1 || nested.RowCount() != 3 {
+		t.Errorf("tables = %d, rows = %d; want 1 and 3", len(cell.Tables()), nested.RowCount())
+	}
+}
+
+func TestTableSortRows(t *testing.T) {
+	table := newTestTable("tblSort", 5, 2)
+	setCellText(t, table, 0, 0, "Qty")
+	for i, qty := range []string{"10", "9", "100", "9"} {
+		setCellText(t, table, i+1, 0, qty)
+		setCellText(t, table, i+1, 1, string(rune('a'+i)))
+	}
+
+	if err := table.SortRows(0, nil, 1); err != nil {
+		t.Fatalf("SortRows error = %v", err)
+	}
+	want := []string{"Qty", "9", "9", "10", "100"}
+	for i, got := range columnText(t, table, 0) {
+		if got != want[i] {
+			t.Fatalf("sorted column = %v, want %v", columnText(t, table, 0), want)
+		}
+	}
+	if got := columnText(t, table, 1); got[1] != "b" || got[2] != "d" {
+		t.Errorf("sort should be stable, got %v", got)
+	}
+
+	descending := func(a, b domain.TableCell) int { return -domain.CompareCellText(a, b) }
+	if err := table.SortRows(1, descending, 1); err != nil {
+		t.Fatalf("SortRows error = %v", err)
+	}
+	if got := columnText(t, table, 1); got[1] != "d" || got[4] != "a" {
+		t.Errorf("descending sort = %v", got)
+	}
+
+	if err := opsCell(t, table, 2, 1).Merge(1, 2); err != nil {
+		t.Fatalf("Merge error = %v", err)
+	}
+	if err := table.SortRows(0, nil, 1); err == nil {
+		t.Error("expected error when sorting rows with vertical merges")
+	}
+	if err := table.SortRows(3, nil, 0); err == nil {
+		t.Error("expected error for out-of-range column")
+	}
+}
+
+func TestTableTranspose(t *testing.T) {
+	table := newTestTable("tblTranspose", 2, 3)
+	header := setCellText(t, table, 0, 0, "Header")
+	if err := header.Merge(2, 1); err != nil {
+		t.Fatalf("Merge error = %v", err)
+	}
+	setCellText(t, table, 1, 2, "corner")
+	if err := header.SetWidth(2000); err != nil {
+		t.Fatalf("SetWidth error = %v", err)
+	}
+
+	if err := table.Transpose(); err != nil {
+		t.Fatalf("Transpose error = %v", err)
+	}
+	if table.RowCount() != 3 || table.ColumnCount() != 2 {
+		t.Fatalf("dimensions = %dx%d, want 3x2", table.RowCount(), table.ColumnCount())
+	}
+	assertRegion(t, table, 0, 0, 2, 1)
+	if got := domain.CellText(opsCell(t, table, 2, 1)); got != "corner" {
+		t.Errorf("transposed corner = %q", got)
+	}
+	if opsCell(t, table, 0, 0).Width() != 0 {
+		t.Error("cell widths should be cleared")
+	}
+
+	if err := table.Transpose(); err != nil {
+		t.Fatalf("second Transpose error = %v", err)
+	}
+	assertRegion(t, table, 0, 0, 1, 2)
+	if got := domain.CellText(opsCell(t, table, 0, 0)); got != "Header" {
+		t.Errorf("origin text = %q", got)
+	}
+}
@@ -0,0 +1,472 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package latex converts a practical subset of LaTeX math into equation
+// trees: scripts, fractions, roots, large operators, stretchy delimiters,
+// matrix environments, text, function names, Greek letters and common
+// symbols. Whitespace is insignificant, as in LaTeX math mode.
+package latex
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+const opParse = "latex.Parse"
+
+// token is a command (without its backslash) or a single character. The
+// zero token marks the end of the input.
+type token struct {
+	cmd  bool
+	text string
+}
+
+func (t token) is(text string) bool    { return !t.cmd && t.text == text }
+func (t token) isCmd(name string) bool { return t.cmd && t.text == name }
+func (t token) eof() bool              { return !t.cmd && t.text == "" }
+func (t token) String() string {
+	if t.cmd {
+		return `\` + t.text
+	}
+	return t.text
+}
+
+type parser struct {
+	src []rune
+	pos int
+}
+
+// Parse converts LaTeX math source into an equation tree. Surrounding $ or
+// $$ delimiters are ignored.
+func Parse(src string) (domain.MathNode, error) {
+	src = strings.TrimSpace(src)
+	src = strings.TrimSpace(strings.Trim(src, "$"))
+	if src == "" {
+		return nil, errors.InvalidArgument(opParse, "src", src, "equation is empty")
+	}
+
+	p := &parser{src: []rune(src)}
+	row, err := p.parseRow(func(token) bool { return false })
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); !tok.eof() {
+		return nil, errors.InvalidArgument(opParse, "src", tok.String(), "unexpected token")
+	}
+	return row, nil
+}
+
+// peek returns the next token without consuming it.
+func (p *parser) peek() token {
+	tok, _ := p.scan()
+	return tok
+}
+
+// next consumes and returns the next token.
+func (p *parser) next() token {
+	tok, end := p.scan()
+	p.pos = end
+	return tok
+}
+
+func (p *parser) scan() (token, int) {
+	i := p.skipSpace(p.pos)
+	if i >= len(p.src) {
+		return token{}, i
+	}
+	r := p.src[i]
+	if r != '\\' {
+		return token{text: string(r)}, i + 1
+	}
+	i++
+	if i >= len(p.src) {
+		return token{cmd: true}, i
+	}
+	if !unicode.IsLetter(p.src[i]) {
+		return token{cmd: true, text: string(p.src[i])}, i + 1
+	}
+	start := i
+	for i < len(p.src) && unicode.IsLetter(p.src[i]) {
+		i++
+	}
+	return token{cmd: true, text: string(p.src[start:i])}, i
+}
+
+func (p *parser) skipSpace(i int) int {
+	for i < len(p.src) && unicode.IsSpace(p.src[i]) {
+		i++
+	}
+	return i
+}
+
+func (p *parser) expect(text string) error {
+	if tok := p.next(); !tok.is(text) {
+		found := tok.String()
+		if tok.eof() {
+			found = "end of input"
+		}
+		return errors.InvalidArgument(opParse, "src", found, "expected "+text)
+	}
+	return nil
+}
+
+// parseRow parses nodes until the end of the input, a closing brace or a
+// token accepted by stop. The stopping token is not consumed.
+func (p *parser) parseRow(stop func(token) bool) (domain.MathNode, error) {
+	var row domain.MathRow
+	for {
+		tok := p.peek()
+		if tok.eof() || tok.is("}") || stop(tok) {
+			return simplify(row), nil
+		}
+		node, err := p.parseAtom(stop)
+		if err != nil {
+			return nil, err
+		}
+		if node, err = p.parseScripts(node); err != nil {
+			return nil, err
+		}
+		row = appendNode(row, node)
+	}
+}
+
+// parseScripts attaches any following subscript and superscript to base.
+func (p *parser) parseScripts(base domain.MathNode) (domain.MathNode, error) {
+	var sub, sup domain.MathNode
+	for {
+		tok := p.peek()
+		target := &sub
+		switch {
+		case tok.is("_"):
+		case tok.is("^"):
+			target = &sup
+		case tok.isCmd("limits"), tok.isCmd("nolimits"):
+			p.next()
+			continue
+		default:
+			if sub == nil && sup == nil {
+				return base, nil
+			}
+			if base == nil {
+				base = domain.MathRow{}
+			}
+			return domain.MathScript{Base: base, Sub: sub, Sup: sup}, nil
+		}
+		p.next()
+		if *target != nil {
+			return nil, errors.InvalidArgument(opParse, "src", tok.text, "double script")
+		}
+		arg, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		*target = arg
+	}
+}
+
+// parseArg parses a braced group or a single atom.
+func (p *parser) parseArg() (domain.MathNode, error) {
+	tok := p.peek()
+	if tok.eof() {
+		return nil, errors.InvalidArgument(opParse, "src", "end of input", "missing argument")
+	}
+	return p.parseAtom(func(token) bool { return false })
+}
+
+// parseAtom parses a single character, group or command. stop is the
+// stopping rule of the enclosing row, which large operators respect when
+// collecting their body.
+func (p *parser) parseAtom(stop func(token) bool) (domain.MathNode, error) {
+	tok := p.next()
+	if !tok.cmd {
+		switch tok.text {
+		case "{":
+			row, err := p.parseRow(func(token) bool { return false })
+			if err != nil {
+				return nil, err
+			}
+			if row == nil {
+				row = domain.MathRow{}
+			}
+			return row, p.expect("}")
+		case "^", "_":
+			// A script without a base, such as ^{14}C.
+			p.pos--
+			return nil, nil
+		case "}", "&":
+			return nil, errors.InvalidArgument(opParse, "src", tok.text, "unexpected token")
+		case "'":
+			return domain.MathText("′"), nil
+		case "~":
+			return domain.MathText("\u00A0"), nil
+		}
+		return domain.MathText(tok.text), nil
+	}
+
+	name := tok.text
+	switch name {
+	case "frac", "dfrac", "tfrac":
+		num, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		den, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		return domain.MathFraction{Num: num, Den: den}, nil
+	case "sqrt":
+		var degree domain.MathNode
+		if p.peek().is("[") {
+			p.next()
+			var err error
+			if degree, err = p.parseRow(func(t token) bool { return t.is("]") }); err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+		}
+		base, err := p.parseArg()
+		if err != nil {
+			return nil, err
+		}
+		return domain.MathRadical{Degree: degree, Base: base}, nil
+	case "text", "textrm", "mathrm", "mbox", "operatorname":
+		text, err := p.rawGroup()
+		if err != nil {
+			return nil, err
+		}
+		return domain.MathPlainText(text), nil
+	case "left":
+		return p.parseDelimiter()
+	case "begin":
+		return p.parseEnvironment()
+	}
+
+	if op, ok := naryOperators[name]; ok {
+		return p.parseNary(op, stop)
+	}
+	if _, ok := functions[name]; ok {
+		return domain.MathPlainText(name), nil
+	}
+	if symbol, ok := symbols[name]; ok {
+		return domain.MathText(symbol), nil
+	}
+	if space, ok := spaces[name]; ok {
+		return domain.MathText(space), nil
+	}
+	if len([]rune(name)) == 1 && strings.ContainsAny(name, `{}%$#&_|`) {
+		if name == "|" {
+			return domain.MathText("‖"), nil
+		}
+		return domain.MathText(name), nil
+	}
+	if name == "" {
+		return nil, errors.InvalidArgument(opParse, "src", `\`, "incomplete command")
+	}
+	return nil, errors.InvalidArgument(opParse, "src", `\`+name, "unsupported LaTeX command")
+}
+
+// parseNary reads the limits of a large operator and its body, which runs
+// to the next relation symbol or the end of the enclosing row.
+func (p *parser) parseNary(op string, stop func(token) bool) (domain.MathNode, error) {
+	scripted, err := p.parseScripts(domain.MathText(op))
+	if err != nil {
+		return nil, err
+	}
+	nary := domain.MathNary{Operator: op}
+	if script, ok := scripted.(domain.MathScript); ok {
+		nary.Sub, nary.Sup = script.Sub, script.Sup
+	}
+	body, err := p.parseRow(func(t token) bool { return stop(t) || isRelation(t) })
+	if err != nil {
+		return nil, err
+	}
+	nary.Body = body
+	return nary, nil
+}
+
+// parseDelimiter parses \left<delim> ... \right<delim>.
+func (p *parser) parseDelimiter() (domain.MathNode, error) {
+	open, err := p.delimiter()
+	if err != nil {
+		return nil, err
+	}
+	body, err := p.parseRow(func(t token) bool { return t.isCmd("right") })
+	if err != nil {
+		return nil, err
+	}
+	if !p.next().isCmd("right") {
+		return nil, errors.InvalidArgument(opParse, "src", `\left`, `missing \right`)
+	}
+	closing, err := p.delimiter()
+	if err != nil {
+		return nil, err
+	}
+	if open == "" && closing == "" {
+		// \left. \right. draws no brackets at all.
+		return simplify(domain.MathRow{body}), nil
+	}
+	return domain.MathDelimiter{Open: open, Close: closing, Body: body}, nil
+}
+
+func (p *parser) delimiter() (string, error) {
+	tok := p.next()
+	if !tok.cmd && tok.text != "" && !strings.Contains("{}^_&", tok.text) {
+		if tok.text == "." {
+			return "", nil
+		}
+		return tok.text, nil
+	}
+	if d, ok := delimiters[tok.text]; ok && tok.cmd {
+		return d, nil
+	}
+	return "", errors.InvalidArgument(opParse, "src", tok.String(), "unsupported delimiter")
+}
+
+// parseEnvironment parses a matrix environment after \begin.
+func (p *parser) parseEnvironment() (domain.MathNode, error) {
+	name, err := p.rawGroup()
+	if err != nil {
+		return nil, err
+	}
+	brackets, ok := environments[name]
+	if !ok {
+		return nil, errors.InvalidArgument(opParse, "src", name, "unsupported environment")
+	}
+
+	cellEnd := func(t token) bool { return t.is("&") || t.isCmd(`\`) || t.isCmd("end") }
+	var matrix domain.MathMatrix
+	row := []domain.MathNode{}
+	for {
+		cell, err := p.parseRow(cellEnd)
+		if err != nil {
+			return nil, err
+		}
+		if cell == nil {
+			cell = domain.MathRow{}
+		}
+		row = append(row, cell)
+
+		tok := p.next()
+		switch {
+		case tok.is("&"):
+			continue
+		case tok.isCmd(`\`):
+			matrix.Rows = append(matrix.Rows, row)
+			row = []domain.MathNode{}
+			continue
+		case tok.isCmd("end"):
+		default:
+			return nil, errors.InvalidArgument(opParse, "src", name, `missing \end`)
+		}
+		if end, err := p.rawGroup(); err != nil || end != name {
+			return nil, errors.InvalidArgument(opParse, "src", name, `mismatched \end`)
+		}
+		// A trailing \\ leaves an empty last row.
+		if len(row) > 1 || !isEmpty(row[0]) {
+			matrix.Rows = append(matrix.Rows, row)
+		}
+		break
+	}
+
+	if brackets == [2]string{} {
+		return matrix, nil
+	}
+	return domain.MathDelimiter{Open: brackets[0], Close: brackets[1], Body: matrix}, nil
+}
+
+// rawGroup returns the text of a braced group without interpreting it.
+func (p *parser) rawGroup() (string, error) {
+	i := p.skipSpace(p.pos)
+	if i >= len(p.src) || p.src[i] != '{' {
+		return "", errors.InvalidArgument(opParse, "src", string(p.src[min(i, len(p.src)-1):]), "expected {")
+	}
+	var sb strings.Builder
+	depth := 0
+	for i++; i < len(p.src); i++ {
+		r := p.src[i]
+		switch {
+		case r == '\\' && i+1 < len(p.src) && strings.ContainsRune(`{}\`, p.src[i+1]):
+			i++
+			r = p.src[i]
+		case r == '{':
+			depth++
+		case r == '}' && depth == 0:
+			p.pos = i + 1
+			return sb.String(), nil
+		case r == '}':
+			depth--
+		}
+		sb.WriteRune(r)
+	}
+	return "", errors.InvalidArgument(opParse, "src", sb.String(), "unterminated group")
+}
+
+// appendNode adds node to row, merging adjacent text.
+func appendNode(row domain.MathRow, node domain.MathNode) domain.MathRow {
+	switch n := node.(type) {
+	case nil:
+		return row
+	case domain.MathText:
+		if n == "" {
+			return row
+		}
+		if len(row) > 0 {
+			if last, ok := row[len(row)-1].(domain.MathText); ok {
+				row[len(row)-1] = last + n
+				return row
+			}
+		}
+	}
+	return append(row, node)
+}
+
+// simplify unwraps single-node rows.
+func simplify(row domain.MathRow) domain.MathNode {
+	switch len(row) {
+	case 0:
+		return nil
+	case 1:
+		return row[0]
+	}
+	return row
+}
+
+func isEmpty(node domain.MathNode) bool {
+	row, ok := node.(domain.MathRow)
+	return node == nil || ok && len(row) == 0
+}
+
+func isRelation(t token) bool {
+	if !t.cmd {
+		return strings.Contains("=<>", t.text) && t.text != ""
+	}
+	_, ok := relations[t.text]
+	return ok
+}
@@ -0,0 +1,59 @@
+package latex
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		src  string
+		want domain.MathNode
+	}{
+		{"x^2 + y_1", domain.MathRow{
+			domain.MathScript{Base: domain.MathText("x"), Sup: domain.MathText("2")},
+			domain.MathText("+"),
+			domain.MathScript{Base: domain.MathText("y"), Sub: domain.MathText("1")},
+		}},
+		{`\frac{a+b}{2}`, domain.MathFraction{Num: domain.MathText("a+b"), Den: domain.MathText("2")}},
+		{`\frac12`, domain.MathFraction{Num: domain.MathText("1"), Den: domain.MathText("2")}},
+		{`\sqrt[3]{x}`, domain.MathRadical{Degree: domain.MathText("3"), Base: domain.MathText("x")}},
+		{`x_i^{2}`, domain.MathScript{Base: domain.MathText("x"), Sub: domain.MathText("i"), Sup: domain.MathText("2")}},
+		{`\sum_{i=1}^{n} i = \frac{n(n+1)}{2}`, domain.MathRow{
+			domain.MathNary{Operator: "∑", Sub: domain.MathText("i=1"), Sup: domain.MathText("n"), Body: domain.MathText("i")},
+			domain.MathText("="),
+			domain.MathFraction{Num: domain.MathText("n(n+1)"), Den: domain.MathText("2")},
+		}},
+		{`\int_0^\infty e^{-x} dx`, domain.MathNary{
+			Operator: "∫", Sub: domain.MathText("0"), Sup: domain.MathText("∞"),
+			Body: domain.MathRow{domain.MathScript{Base: domain.MathText("e"), Sup: domain.MathText("-x")}, domain.MathText("dx")},
+		}},
+		{`\left[ \alpha \right)`, domain.MathDelimiter{Open: "[", Close: ")", Body: domain.MathText("α")}},
+		{`\begin{pmatrix} a & b \\ c & d \end{pmatrix}`, domain.MathDelimiter{Open: "(", Close: ")", Body: domain.MathMatrix{Rows: [][]domain.MathNode{
+			{domain.MathText("a"), domain.MathText("b")},
+			{domain.MathText("c"), domain.MathText("d")},
+		}}}},
+		{`\sin\theta \text{ m/s}`, domain.MathRow{domain.MathPlainText("sin"), domain.MathText("θ"), domain.MathPlainText(" m/s")}},
+		{`$E = mc^2$`, domain.MathRow{domain.MathText("E=m"), domain.MathScript{Base: domain.MathText("c"), Sup: domain.MathText("2")}}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.src)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.src, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Parse(%q) = %#v, want %#v", tt.src, got, tt.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, src := range []string{"", `\frac{a}`, `x^2^3`, `{x`, `x}`, `\unknown`, `\left( x`, `\begin{matrix} a \end{pmatrix}`, `\begin{align} x \end{align}`} {
+		if _, err := Parse(src); err == nil {
+			t.Errorf("Parse(%q) expected error", src)
+		}
+	}
+}
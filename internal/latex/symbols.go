@@ -0,0 +1,123 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package latex
+
+// naryOperators maps large operator commands to their characters.
+var naryOperators = map[string]string{
+	"sum":       "∑",
+	"prod":      "∏",
+	"coprod":    "∐",
+	"int":       "∫",
+	"iint":      "∬",
+	"iiint":     "∭",
+	"oint":      "∮",
+	"bigcup":    "⋃",
+	"bigcap":    "⋂",
+	"bigvee":    "⋁",
+	"bigwedge":  "⋀",
+	"bigoplus":  "⨁",
+	"bigotimes": "⨂",
+}
+
+// functions are set upright, as LaTeX does for operator names.
+var functions = map[string]struct{}{
+	"sin": {}, "cos": {}, "tan": {}, "cot": {}, "sec": {}, "csc": {},
+	"arcsin": {}, "arccos": {}, "arctan": {},
+	"sinh": {}, "cosh": {}, "tanh": {}, "coth": {},
+	"log": {}, "ln": {}, "lg": {}, "exp": {},
+	"lim": {}, "liminf": {}, "limsup": {}, "max": {}, "min": {}, "sup": {}, "inf": {},
+	"det": {}, "dim": {}, "ker": {}, "deg": {}, "gcd": {}, "arg": {}, "hom": {}, "Pr": {}, "mod": {},
+}
+
+// relations end the body of a large operator.
+var relations = map[string]struct{}{
+	"le": {}, "leq": {}, "ge": {}, "geq": {}, "ne": {}, "neq": {}, "approx": {}, "equiv": {},
+	"sim": {}, "simeq": {}, "cong": {}, "propto": {}, "to": {}, "rightarrow": {}, "leftarrow": {},
+	"Rightarrow": {}, "Leftarrow": {}, "iff": {}, "implies": {}, "in": {}, "notin": {},
+	"subset": {}, "subseteq": {}, "supset": {}, "supseteq": {}, "ll": {}, "gg": {},
+}
+
+// symbols maps commands to the characters they stand for.
+var symbols = map[string]string{
+	// Lowercase Greek
+	"alpha": "α", "beta": "β", "gamma": "γ", "delta": "δ", "epsilon": "ϵ", "varepsilon": "ε",
+	"zeta": "ζ", "eta": "η", "theta": "θ", "vartheta": "ϑ", "iota": "ι", "kappa": "κ",
+	"lambda": "λ", "mu": "μ", "nu": "ν", "xi": "ξ", "pi": "π", "varpi": "ϖ", "rho": "ρ",
+	"varrho": "ϱ", "sigma": "σ", "varsigma": "ς", "tau": "τ", "upsilon": "υ", "phi": "ϕ",
+	"varphi": "φ", "chi": "χ", "psi": "ψ", "omega": "ω",
+	// Uppercase Greek
+	"Gamma": "Γ", "Delta": "Δ", "Theta": "Θ", "Lambda": "Λ", "Xi": "Ξ", "Pi": "Π",
+	"Sigma": "Σ", "Upsilon": "Υ", "Phi": "Φ", "Psi": "Ψ", "Omega": "Ω",
+	// Operators
+	"cdot": "⋅", "times": "×", "div": "÷", "pm": "±", "mp": "∓", "ast": "∗", "star": "⋆",
+	"circ": "∘", "bullet": "∙", "oplus": "⊕", "otimes": "⊗", "cup": "∪", "cap": "∩",
+	"wedge": "∧", "land": "∧", "vee": "∨", "lor": "∨", "setminus": "∖", "neg": "¬", "lnot": "¬",
+	// Relations
+	"le": "≤", "leq": "≤", "ge": "≥", "geq": "≥", "ne": "≠", "neq": "≠", "approx": "≈",
+	"equiv": "≡", "sim": "∼", "simeq": "≃", "cong": "≅", "propto": "∝", "ll": "≪", "gg": "≫",
+	"in": "∈", "notin": "∉", "ni": "∋", "subset": "⊂", "subseteq": "⊆", "supset": "⊃",
+	"supseteq": "⊇", "perp": "⊥", "parallel": "∥", "mid": "∣",
+	// Arrows
+	"to": "→", "rightarrow": "→", "leftarrow": "←", "gets": "←", "leftrightarrow": "↔",
+	"Rightarrow": "⇒", "implies": "⇒", "Leftarrow": "⇐", "Leftrightarrow": "⇔", "iff": "⇔",
+	"mapsto": "↦", "uparrow": "↑", "downarrow": "↓",
+	// Miscellaneous
+	"infty": "∞", "partial": "∂", "nabla": "∇", "forall": "∀", "exists": "∃", "nexists": "∄",
+	"emptyset": "∅", "varnothing": "∅", "hbar": "ℏ", "ell": "ℓ", "Re": "ℜ", "Im": "ℑ",
+	"aleph": "ℵ", "angle": "∠", "degree": "°", "prime": "′", "therefore": "∴", "because": "∵",
+	"ldots": "…", "dots": "…", "cdots": "⋯", "vdots": "⋮", "ddots": "⋱",
+	"langle": "⟨", "rangle": "⟩", "lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈", "rceil": "⌉",
+}
+
+// spaces maps spacing commands to Unicode spaces.
+var spaces = map[string]string{
+	",":     "\u2009", // thin space
+	":":     "\u205F", // medium space
+	">":     "\u205F",
+	";":     "\u2004", // thick space
+	" ":     " ",
+	"!":     "",
+	"quad":  "\u2003",
+	"qquad": "\u2003\u2003",
+}
+
+// delimiters maps delimiter commands usable after \left and \right.
+var delimiters = map[string]string{
+	"{": "{", "}": "}", "|": "‖", "lbrace": "{", "rbrace": "}",
+	"langle": "⟨", "rangle": "⟩", "vert": "|", "lvert": "|", "rvert": "|",
+	"Vert": "‖", "lVert": "‖", "rVert": "‖",
+	"lfloor": "⌊", "rfloor": "⌋", "lceil": "⌈", "rceil": "⌉",
+}
+
+// environments maps matrix environments to their surrounding brackets.
+var environments = map[string][2]string{
+	"matrix":  {},
+	"pmatrix": {"(", ")"},
+	"bmatrix": {"[", "]"},
+	"Bmatrix": {"{", "}"},
+	"vmatrix": {"|", "|"},
+	"Vmatrix": {"‖", "‖"},
+	"cases":   {"{", ""},
+}
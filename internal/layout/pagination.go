@@ -0,0 +1,50 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package layout
+
+import "github.com/mmonterroca/docxgo/v2/domain"
+
+// Pagination tells on which pages the content of a laid out document
+// falls. The PDF renderer implements it; documents use it to update
+// their page fields without depending on a renderer.
+type Pagination interface {
+	// PageCount returns the number of pages.
+	PageCount() int
+
+	// SectionPages returns the first and last page of the section at
+	// index i, in document order.
+	SectionPages(i int) (first, last int, ok bool)
+
+	// Page returns the page a paragraph of the body, of a table in it or
+	// of a footnote starts on, and false for paragraphs not laid out.
+	Page(para domain.Paragraph) (int, bool)
+
+	// Section returns the index of the section a paragraph starts in,
+	// for the paragraphs Page knows.
+	Section(para domain.Paragraph) (int, bool)
+}
+
+// Paginator lays out a document and reports its pages.
+type Paginator func(doc domain.Document) (Pagination, error)
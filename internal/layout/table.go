@@ -0,0 +1,282 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package layout estimates geometry (text extents, table column widths) for
+// document content without a rendering engine. All lengths are in twips.
+package layout
+
+import (
+	"sort"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+// DefaultCellMargin is Word's default left and right cell margin (0.08").
+const DefaultCellMargin = 108
+
+// minContentWidth keeps empty columns from collapsing completely.
+const minContentWidth = 144
+
+// ColumnWidths computes grid column widths for table, in twips.
+//
+// The algorithm follows the automatic table layout used by browsers and word
+// processors: each column gets a minimum width (its longest unbreakable word)
+// and a maximum width (its longest line). Cells with an explicit width fix
+// their column, spanning cells widen the columns they cover, and the result is
+// fitted to the table width, which is the available width for auto tables, a
+// fraction of it for percentage tables or the exact value for fixed tables.
+// The returned widths always sum to the table width for fixed and percentage
+// tables.
+func ColumnWidths(table domain.Table, available int) []int {
+	cols := table.ColumnCount()
+	if cols <= 0 {
+		return nil
+	}
+
+	mins, maxs, fixed := columnRanges(table, available)
+
+	target := 0
+	switch width := table.Width(); width.Type {
+	case domain.WidthDXA:
+		target = width.Value
+	case domain.WidthPct:
+		target = available * width.Value / 5000
+	}
+
+	return fitColumns(mins, maxs, fixed, target, available)
+}
+
+// CellWidth returns the width of a cell starting at column col and spanning
+// span grid columns.
+func CellWidth(widths []int, col, span int) int {
+	if span < 1 {
+		span = 1
+	}
+	total := 0
+	for i := col; i < col+span && i < len(widths); i++ {
+		if i >= 0 {
+			total += widths[i]
+		}
+	}
+	return total
+}
+
+// TableExtent returns the minimum and maximum natural widths of table.
+func TableExtent(table domain.Table, available int) (int, int) {
+	mins, maxs, _ := columnRanges(table, available)
+	return sum(mins), sum(maxs)
+}
+
+type spanningCell struct {
+	col, span int
+	min, max  int
+	fixed     int
+}
+
+// columnRanges measures every cell and returns per-column min, max and fixed widths.
+func columnRanges(table domain.Table, available int) ([]int, []int, []int) {
+	cols := table.ColumnCount()
+	mins := make([]int, cols)
+	maxs := make([]int, cols)
+	fixed := make([]int, cols)
+	for i := range mins {
+		mins[i] = minContentWidth + 2*DefaultCellMargin
+		maxs[i] = mins[i]
+	}
+
+	spanning := make([]spanningCell, 0)
+	for _, row := range table.Rows() {
+		for col, cell := range row.Cells() {
+			if col >= cols || cell.IsHorizontallyMergedContinuation() {
+				continue
+			}
+
+			span := cell.GridSpan()
+			if span < 1 {
+				span = 1
+			}
+			if col+span > cols {
+				span = cols - col
+			}
+
+			cmin, cmax := MeasureCell(cell, available)
+			cmin += 2 * DefaultCellMargin
+			cmax += 2 * DefaultCellMargin
+
+			if span > 1 {
+				spanning = append(spanning, spanningCell{col: col, span: span, min: cmin, max: cmax, fixed: cell.Width()})
+				continue
+			}
+
+			if w := cell.Width(); w > 0 && w > fixed[col] {
+				fixed[col] = w
+			}
+			if cmin > mins[col] {
+				mins[col] = cmin
+			}
+			if cmax > maxs[col] {
+				maxs[col] = cmax
+			}
+		}
+	}
+
+	// Fixed widths win over content; the text wraps or overflows inside them.
+	for i, w := range fixed {
+		if w > 0 {
+			mins[i], maxs[i] = w, w
+		}
+	}
+
+	// Narrow spans first so that wide spans see the widened columns.
+	sort.SliceStable(spanning, func(i, j int) bool { return spanning[i].span < spanning[j].span })
+	for _, sc := range spanning {
+		cmin, cmax := sc.min, sc.max
+		if sc.fixed > 0 {
+			cmin, cmax = sc.fixed, sc.fixed
+		}
+		widen(mins, fixed, maxs, sc.col, sc.span, cmin)
+		widen(maxs, fixed, maxs, sc.col, sc.span, cmax)
+	}
+	for i := range mins {
+		if maxs[i] < mins[i] {
+			maxs[i] = mins[i]
+		}
+	}
+
+	return mins, maxs, fixed
+}
+
+// widen grows values[col:col+span] so that they sum to at least need. Extra
+// width goes to non-fixed columns in proportion to their weights.
+func widen(values, fixed, weights []int, col, span, need int) {
+	current := sum(values[col : col+span])
+	if need <= current {
+		return
+	}
+
+	flexible := make([]int, 0, span)
+	weight := 0
+	for i := col; i < col+span; i++ {
+		if fixed[i] == 0 {
+			flexible = append(flexible, i)
+			weight += weights[i]
+		}
+	}
+	if len(flexible) == 0 {
+		return
+	}
+
+	distribute(values, flexible, weights, weight, need-current)
+}
+
+// distribute adds extra to values[idx...] proportionally to weights.
+func distribute(values, idx, weights []int, totalWeight, extra int) {
+	given := 0
+	for n, i := range idx {
+		share := extra / len(idx)
+		if totalWeight > 0 {
+			share = extra * weights[i] / totalWeight
+		}
+		if n == len(idx)-1 {
+			share = extra - given
+		}
+		values[i] += share
+		given += share
+	}
+}
+
+// fitColumns resolves final widths from the column ranges. A zero target means
+// the table is auto-sized and may use up to available twips.
+func fitColumns(mins, maxs, fixed []int, target, available int) []int {
+	widths := make([]int, len(mins))
+	sumMin, sumMax := sum(mins), sum(maxs)
+
+	limit := target
+	if limit <= 0 {
+		limit = available
+	}
+
+	switch {
+	case limit <= 0 || (target <= 0 && sumMax <= limit):
+		copy(widths, maxs)
+		return widths
+
+	case sumMax <= limit:
+		// Fixed or percentage table wider than its content: grow flexible columns.
+		copy(widths, maxs)
+		flexible := make([]int, 0, len(widths))
+		weight := 0
+		for i := range widths {
+			if fixed[i] == 0 {
+				flexible = append(flexible, i)
+				weight += maxs[i]
+			}
+		}
+		if len(flexible) == 0 {
+			for i := range widths {
+				flexible = append(flexible, i)
+			}
+			weight = sumMax
+		}
+		distribute(widths, flexible, maxs, weight, limit-sumMax)
+
+	case sumMin <= limit:
+		// Give every column its minimum, then share the rest by how much more
+		// each column would like.
+		copy(widths, mins)
+		flexible := make([]int, 0, len(widths))
+		desire := make([]int, len(widths))
+		totalDesire := 0
+		for i := range widths {
+			desire[i] = maxs[i] - mins[i]
+			if desire[i] > 0 {
+				flexible = append(flexible, i)
+				totalDesire += desire[i]
+			}
+		}
+		if len(flexible) > 0 {
+			distribute(widths, flexible, desire, totalDesire, limit-sumMin)
+		}
+
+	default:
+		// Content cannot fit: scale minimum widths down so the grid stays
+		// within the page and viewers wrap the text instead of clipping it.
+		given := 0
+		for i := range widths {
+			widths[i] = mins[i] * limit / sumMin
+			given += widths[i]
+		}
+		widths[len(widths)-1] += limit - given
+	}
+
+	return widths
+}
+
+func sum(values []int) int {
+	total := 0
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
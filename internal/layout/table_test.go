@@ -0,0 +1,159 @@
+package layout_test
+
+import (
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/core"
+	"github.com/mmonterroca/docxgo/v2/internal/layout"
+)
+
+func fillCell(t *testing.T, table domain.Table, row, col int, text string) domain.TableCell {
+	t.Helper()
+	r, err := table.Row(row)
+	if err != nil {
+		t.Fatalf("Row(%d): %v", row, err)
+	}
+	cell, err := r.Cell(col)
+	if err != nil {
+		t.Fatalf("Cell(%d): %v", col, err)
+	}
+	para, _ := cell.AddParagraph()
+	run, _ := para.AddRun()
+	run.SetText(text)
+	return cell
+}
+
+func total(widths []int) int {
+	sum := 0
+	for _, w := range widths {
+		sum += w
+	}
+	return sum
+}
+
+func TestColumnWidths_AutoFollowsContent(t *testing.T) {
+	doc := core.NewDocument()
+	table, _ := doc.AddTable(2, 3)
+	fillCell(t, table, 0, 0, "ID")
+	fillCell(t, table, 0, 1, "Description of the item")
+	fillCell(t, table, 0, 2, "Qty")
+	fillCell(t, table, 1, 0, "1")
+	fillCell(t, table, 1, 1, "Widget")
+	fillCell(t, table, 1, 2, "12")
+
+	widths := layout.ColumnWidths(table, 9360)
+	if len(widths) != 3 {
+		t.Fatalf("expected 3 widths, got %d", len(widths))
+	}
+	if !(widths[1] > widths[0] && widths[1] > widths[2]) {
+		t.Errorf("description column should be widest, got %v", widths)
+	}
+	if total(widths) > 9360 {
+		t.Errorf("auto table should fit the available width, got %d", total(widths))
+	}
+
+	// Content fits on one line, so each column gets its natural width.
+	_, natural := layout.MeasureCell(mustCell(t, table, 0, 1), 9360)
+	if widths[1] != natural+2*layout.DefaultCellMargin {
+		t.Errorf("expected natural width %d, got %d", natural+2*layout.DefaultCellMargin, widths[1])
+	}
+}
+
+func TestColumnWidths_WrapsWhenTooWide(t *testing.T) {
+	doc := core.NewDocument()
+	table, _ := doc.AddTable(1, 2)
+	long := "Lorem ipsum dolor sit amet consectetur adipiscing elit sed do eiusmod tempor incididunt ut labore et dolore magna aliqua"
+	fillCell(t, table, 0, 0, long)
+	fillCell(t, table, 0, 1, long+" "+long)
+
+	widths := layout.ColumnWidths(table, 9360)
+	if total(widths) != 9360 {
+		t.Errorf("wrapping table should use the full width, got %d", total(widths))
+	}
+	if widths[1] <= widths[0] {
+		t.Errorf("column with more text should get more space, got %v", widths)
+	}
+}
+
+func TestColumnWidths_FixedAndPercent(t *testing.T) {
+	doc := core.NewDocument()
+	table, _ := doc.AddTable(1, 3)
+	fillCell(t, table, 0, 0, "a")
+	fixed := fillCell(t, table, 0, 1, "b")
+	fillCell(t, table, 0, 2, "c")
+	if err := fixed.SetWidth(3000); err != nil {
+		t.Fatalf("SetWidth: %v", err)
+	}
+
+	if err := table.SetWidth(domain.TableWidth{Type: domain.WidthPct, Value: 2500}); err != nil {
+		t.Fatalf("SetWidth: %v", err)
+	}
+	widths := layout.ColumnWidths(table, 10000)
+	if total(widths) != 5000 {
+		t.Errorf("50%% table should be 5000 twips, got %d (%v)", total(widths), widths)
+	}
+	if widths[1] != 3000 {
+		t.Errorf("fixed column should keep 3000 twips, got %d", widths[1])
+	}
+
+	if err := table.SetWidth(domain.TableWidth{Type: domain.WidthDXA, Value: 7200}); err != nil {
+		t.Fatalf("SetWidth: %v", err)
+	}
+	widths = layout.ColumnWidths(table, 10000)
+	if total(widths) != 7200 || widths[1] != 3000 {
+		t.Errorf("fixed-width table should be 7200 twips with fixed column intact, got %v", widths)
+	}
+	if widths[0] != widths[2] {
+		t.Errorf("equal content should share extra space equally, got %v", widths)
+	}
+}
+
+func TestColumnWidths_Spans(t *testing.T) {
+	doc := core.NewDocument()
+	table, _ := doc.AddTable(2, 2)
+	header := fillCell(t, table, 0, 0, "A very long heading spanning both columns")
+	if err := header.Merge(2, 1); err != nil {
+		t.Fatalf("Merge: %v", err)
+	}
+	fillCell(t, table, 1, 0, "x")
+	fillCell(t, table, 1, 1, "y")
+
+	widths := layout.ColumnWidths(table, 9360)
+	_, natural := layout.MeasureCell(header, 9360)
+	if total(widths) < natural+2*layout.DefaultCellMargin {
+		t.Errorf("spanned columns (%v) should fit the heading (%d)", widths, natural)
+	}
+	if layout.CellWidth(widths, 0, 2) != total(widths) {
+		t.Error("CellWidth should sum spanned columns")
+	}
+}
+
+func TestSectionTextWidth(t *testing.T) {
+	if got := layout.SectionTextWidth(nil); got != 12240-2*1440 {
+		t.Errorf("default text width = %d", got)
+	}
+
+	doc := core.NewDocument()
+	section, _ := doc.DefaultSection()
+	section.SetPageSize(domain.PageSizeA4)
+	section.SetOrientation(domain.OrientationLandscape)
+	if got := layout.SectionTextWidth(section); got != 16838-2*1440 {
+		t.Errorf("landscape A4 text width = %d", got)
+	}
+
+	section.SetColumns(2)
+	if got := layout.SectionTextWidth(section); got != (16838-2*1440-720)/2 {
+		t.Errorf("two-column text width = %d", got)
+	}
+}
+
+func mustCell(t *testing.T, table domain.Table, row, col int) domain.TableCell {
+	t.Helper()
+	r, _ := table.Row(row)
+	cell, err := r.Cell(col)
+	if err != nil {
+		t.Fatalf("Cell: %v", err)
+	}
+	return cell
+}
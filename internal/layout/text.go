@@ -0,0 +1,197 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package layout
+
+import (
+	"unicode"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/fontmetrics"
+)
+
+// SectionTextWidth returns the width available to body text in a section
+// (page width minus margins, divided between text columns). It applies the
+// same defaults as the section serializer.
+func SectionTextWidth(section domain.Section) int {
+	pageSize := domain.PageSizeLetter
+	margins := domain.DefaultMargins
+	columns := 1
+
+	if section != nil {
+		if size := section.PageSize(); size.Width > 0 && size.Height > 0 {
+			pageSize = size
+		}
+		if m := section.Margins(); m != (domain.Margins{}) {
+			margins = m
+		}
+		if section.Orientation() == domain.OrientationLandscape && pageSize.Width < pageSize.Height {
+			pageSize.Width, pageSize.Height = pageSize.Height, pageSize.Width
+		}
+		if c := section.Columns(); c > 1 {
+			columns = c
+		}
+	}
+
+	width := pageSize.Width - margins.Left - margins.Right
+	if columns > 1 {
+		// Matches the 0.5" column spacing written by SectionProperties.SetColumns.
+		width = (width - (columns-1)*720) / columns
+	}
+	if width < minContentWidth {
+		width = minContentWidth
+	}
+	return width
+}
+
+// MeasureCell returns the minimum and maximum content widths of a table cell,
+// excluding cell margins.
+func MeasureCell(cell domain.TableCell, available int) (int, int) {
+	minW, maxW := 0, 0
+	for _, para := range cell.Paragraphs() {
+		pmin, pmax := MeasureParagraph(para)
+		minW = maxInt(minW, pmin)
+		maxW = maxInt(maxW, pmax)
+	}
+	for _, nested := range cell.Tables() {
+		tmin, tmax := TableExtent(nested, available)
+		minW = maxInt(minW, tmin)
+		maxW = maxInt(maxW, tmax)
+	}
+	return minW, maxW
+}
+
+// MeasureParagraph returns the width of the widest unbreakable word (minimum)
+// and of the longest line (maximum) in a paragraph, including indentation.
+func MeasureParagraph(para domain.Paragraph) (int, int) {
+	indent := para.Indent()
+	inset := indent.Left + indent.Right
+
+	m := &paragraphMeasure{}
+	for _, run := range para.Runs() {
+		m.addRun(run)
+	}
+	m.endLine()
+
+	minW := m.longestWord + inset
+	maxW := m.longestLine + inset + maxInt(indent.FirstLine, 0)
+	return minW, maxW
+}
+
+type paragraphMeasure struct {
+	word, line               int
+	longestWord, longestLine int
+}
+
+func (m *paragraphMeasure) addRun(run domain.Run) {
+	font := run.Font().Name
+	size := run.Size()
+	bold := run.Bold()
+
+	text := run.Text()
+	if withFields, ok := run.(interface{ Fields() []domain.Field }); ok {
+		for _, field := range withFields.Fields() {
+			text += field.Result()
+		}
+	}
+	m.addText(text, font, size, bold)
+
+	if withImage, ok := run.(interface{ Image() domain.Image }); ok && withImage.Image() != nil {
+		img := withImage.Image()
+		m.addBox(img.Size().WidthEMU / constants.EMUsPerTwip)
+	}
+
+	if withBreaks, ok := run.(interface{ Breaks() []domain.BreakType }); ok {
+		for range withBreaks.Breaks() {
+			m.endLine()
+		}
+	}
+}
+
+func (m *paragraphMeasure) addText(text, font string, size int, bold bool) {
+	if text == "" {
+		return
+	}
+
+	metrics := fontmetrics.Lookup(font, bold)
+	if size <= 0 {
+		size = constants.DefaultFontSize
+	}
+	// Advance (font units) -> twips: size is in half-points, i.e. 10 twips each.
+	scale := float64(size) * constants.TwipsPerPoint / 2 / float64(metrics.UnitsPerEm)
+	toTwips := func(r rune) int {
+		return int(float64(metrics.Advance(r))*scale + 0.5)
+	}
+
+	for _, r := range text {
+		switch {
+		case r == '\n':
+			m.endLine()
+		case unicode.IsSpace(r):
+			m.endWord()
+			m.line += toTwips(r)
+		case isBreakableAfter(r):
+			// CJK text can wrap between any two characters.
+			w := toTwips(r)
+			m.word += w
+			m.line += w
+			m.endWord()
+		default:
+			w := toTwips(r)
+			m.word += w
+			m.line += w
+		}
+	}
+}
+
+func (m *paragraphMeasure) addBox(width int) {
+	m.endWord()
+	m.word = width
+	m.line += width
+	m.endWord()
+}
+
+func (m *paragraphMeasure) endWord() {
+	m.longestWord = maxInt(m.longestWord, m.word)
+	m.word = 0
+}
+
+func (m *paragraphMeasure) endLine() {
+	m.endWord()
+	m.longestLine = maxInt(m.longestLine, m.line)
+	m.line = 0
+}
+
+func isBreakableAfter(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r) ||
+		unicode.Is(unicode.Katakana, r) || unicode.Is(unicode.Hangul, r)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -35,18 +35,19 @@ import (
 
 // characterStyle implements domain.CharacterStyle.
 type characterStyle struct {
-	mu        sync.RWMutex
-	id        string
-	name      string
-	basedOn   string
-	font      domain.Font
-	isDefault bool
-	isBuiltIn bool
-	bold      bool
-	italic    bool
-	underline domain.UnderlineStyle
-	color     domain.Color
-	size      int // in half-points
+	mu         sync.RWMutex
+	id         string
+	name       string
+	basedOn    string
+	font       domain.Font
+	isDefault  bool
+	isBuiltIn  bool
+	bold       bool
+	italic     bool
+	underline  domain.UnderlineStyle
+	color      domain.Color
+	themeColor *domain.ThemeColorRef
+	size       int // in half-points
 }
 
 // newCharacterStyle creates a new character style.
@@ -131,6 +132,9 @@ func (cs *characterStyle) SetFont(font domain.Font) error {
 			"font name cannot be empty",
 		)
 	}
+	if font.Theme != "" && font.Theme != domain.ThemeFontMajor && font.Theme != domain.ThemeFontMinor {
+		return errors.NewValidationError("CharacterStyle.SetFont", "font.Theme", font.Theme, "unknown theme font")
+	}
 
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
@@ -226,6 +230,29 @@ func (cs *characterStyle) SetColor(color domain.Color) error {
 	cs.mu.Lock()
 	defer cs.mu.Unlock()
 	cs.color = color
+	cs.themeColor = nil
+	return nil
+}
+
+// ThemeColor returns the theme color of the text, if one is set.
+func (cs *characterStyle) ThemeColor() (domain.ThemeColorRef, bool) {
+	cs.mu.RLock()
+	defer cs.mu.RUnlock()
+	if cs.themeColor == nil {
+		return domain.ThemeColorRef{}, false
+	}
+	return *cs.themeColor, true
+}
+
+// SetThemeColor sets a theme color for the text.
+func (cs *characterStyle) SetThemeColor(ref domain.ThemeColorRef) error {
+	if _, ok := domain.DefaultDocumentTheme().Colors.Color(ref.Color); !ok {
+		return errors.NewValidationError("CharacterStyle.SetThemeColor", "ref.Color", ref.Color, "unknown theme color")
+	}
+
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	cs.themeColor = &ref
 	return nil
 }
 
@@ -26,6 +26,7 @@ SOFTWARE.
 package manager
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"path/filepath"
 	"strconv"
@@ -49,7 +50,9 @@ type MediaFile struct {
 // It is thread-safe.
 type MediaManager struct {
 	mu      sync.RWMutex
-	files   map[string]*MediaFile // key is ID
+	files   map[string]*MediaFile        // key is ID
+	hashes  map[[sha256.Size]byte]string // content hash to ID, for deduplication
+	refs    map[string]int               // path to number of references, for Release
 	idGen   *IDGenerator
 	counter int // Counter for generating unique file names
 }
@@ -57,12 +60,16 @@ type MediaManager struct {
 // NewMediaManager creates a new media manager.
 func NewMediaManager(idGen *IDGenerator) *MediaManager {
 	return &MediaManager{
-		files: make(map[string]*MediaFile, constants.DefaultMediaCapacity),
-		idGen: idGen,
+		files:  make(map[string]*MediaFile, constants.DefaultMediaCapacity),
+		hashes: make(map[[sha256.Size]byte]string, constants.DefaultMediaCapacity),
+		refs:   make(map[string]int, constants.DefaultMediaCapacity),
+		idGen:  idGen,
 	}
 }
 
-// Add adds a media file and returns its ID and path.
+// Add adds a media file and returns its ID and path. Adding bytes that are
+// already stored returns the existing file, so repeated images share one part.
+// Each call counts as a reference until Release.
 func (mm *MediaManager) Add(data []byte, filename string) (id, path string, err error) {
 	if len(data) == 0 {
 		return "", "", errors.InvalidArgument("MediaManager.Add", "data", data, "media data cannot be empty")
@@ -74,6 +81,12 @@ func (mm *MediaManager) Add(data []byte, filename string) (id, path string, err
 	mm.mu.Lock()
 	defer mm.mu.Unlock()
 
+	hash := sha256.Sum256(data)
+	if existing, ok := mm.files[mm.hashes[hash]]; ok {
+		mm.refs[existing.Path]++
+		return existing.ID, existing.Path, nil
+	}
+
 	// Generate unique ID
 	id = mm.idGen.NextImageID()
 
@@ -95,6 +108,8 @@ func (mm *MediaManager) Add(data []byte, filename string) (id, path string, err
 	}
 
 	mm.files[id] = file
+	mm.hashes[hash] = id
+	mm.refs[path]++
 	return id, path, nil
 }
 
@@ -153,6 +168,10 @@ func (mm *MediaManager) RegisterExisting(id, path, contentType string, data []by
 		ContentType: contentType,
 		Data:        copyData,
 	}
+	if hash := sha256.Sum256(copyData); mm.hashes[hash] == "" {
+		mm.hashes[hash] = id
+	}
+	mm.refs[m]++
 
 	return id, nil
 }
@@ -210,14 +229,47 @@ func (mm *MediaManager) Delete(id string) error {
 	mm.mu.Lock()
 	defer mm.mu.Unlock()
 
-	if _, exists := mm.files[id]; !exists {
+	file, exists := mm.files[id]
+	if !exists {
 		return errors.NotFound("MediaManager.Delete", "media file")
 	}
 
+	if hash := sha256.Sum256(file.Data); mm.hashes[hash] == id {
+		delete(mm.hashes, hash)
+	}
 	delete(mm.files, id)
+	delete(mm.refs, file.Path)
 	return nil
 }
 
+// Release drops one reference to the media file at path, taken by Add or
+// RegisterExisting. The file is removed once nothing references it, and
+// Release reports whether it was.
+func (mm *MediaManager) Release(path string) bool {
+	mm.mu.Lock()
+	defer mm.mu.Unlock()
+
+	switch refs := mm.refs[path]; {
+	case refs == 0:
+		return false
+	case refs > 1:
+		mm.refs[path]--
+		return false
+	}
+
+	delete(mm.refs, path)
+	for id, file := range mm.files {
+		if file.Path != path {
+			continue
+		}
+		if hash := sha256.Sum256(file.Data); mm.hashes[hash] == id {
+			delete(mm.hashes, hash)
+		}
+		delete(mm.files, id)
+	}
+	return true
+}
+
 // detectContentType returns the MIME type for a file extension.
 func (mm *MediaManager) detectContentType(ext string) string {
 	switch ext {
@@ -235,6 +287,8 @@ func (mm *MediaManager) detectContentType(ext string) string {
 		return constants.ContentTypeWMF
 	case ".emf":
 		return constants.ContentTypeEMF
+	case ".svg":
+		return constants.ContentTypeSVG
 	default:
 		return "application/octet-stream"
 	}
@@ -0,0 +1,95 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+Copyright (c) 2020-2023 fumiama (original go-docx)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package manager
+
+import "testing"
+
+func TestMediaManagerDeduplicates(t *testing.T) {
+	mm := NewMediaManager(NewIDGenerator())
+	logo := []byte("logo bytes")
+
+	id1, path1, err := mm.Add(logo, "logo.png")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	id2, path2, err := mm.Add([]byte("logo bytes"), "copy.png")
+	if err != nil {
+		t.Fatalf("Add: %v", err)
+	}
+	if id1 != id2 || path1 != path2 || mm.Count() != 1 {
+		t.Errorf("identical bytes should share a part: %s %s, %s %s, count %d", id1, path1, id2, path2, mm.Count())
+	}
+
+	if _, path3, _ := mm.Add([]byte("other"), "other.png"); path3 == path1 {
+		t.Error("different bytes need their own part")
+	}
+
+	if err := mm.Delete(id1); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, path4, _ := mm.Add(logo, "logo.png"); path4 == path1 {
+		t.Error("deleted media should not be reused")
+	}
+
+	hydrated := NewMediaManager(NewIDGenerator())
+	if _, err := hydrated.RegisterExisting("image7", "word/media/image7.jpeg", "", []byte("photo")); err != nil {
+		t.Fatalf("RegisterExisting: %v", err)
+	}
+	if _, path, _ := hydrated.Add([]byte("photo"), "photo.jpeg"); path != "word/media/image7.jpeg" {
+		t.Errorf("Add should reuse the hydrated part, got %s", path)
+	}
+}
+
+func TestMediaManagerRelease(t *testing.T) {
+	mm := NewMediaManager(NewIDGenerator())
+	_, path, _ := mm.Add([]byte("logo"), "logo.png")
+	_, _, _ = mm.Add([]byte("logo"), "logo.png")
+
+	if mm.Release(path) || mm.Count() != 1 {
+		t.Fatalf("media shared by two references was removed")
+	}
+	if !mm.Release(path) || mm.Count() != 0 {
+		t.Fatalf("media without references was kept")
+	}
+	if mm.Release(path) || mm.Release("word/media/unknown.png") {
+		t.Error("Release of unknown media reported a removal")
+	}
+	if _, again, _ := mm.Add([]byte("logo"), "logo.png"); again == path {
+		t.Error("released media should not be reused")
+	}
+}
+
+func TestRelationshipManagerReusesImageRelationships(t *testing.T) {
+	rm := NewRelationshipManager(NewIDGenerator())
+	first, _ := rm.AddImage("media/image1.png")
+	second, _ := rm.AddImage("media/image1.png")
+	if first != second || rm.Count() != 1 {
+		t.Errorf("AddImage should reuse %s, got %s (count %d)", first, second, rm.Count())
+	}
+	if other, _ := rm.AddImage("media/image2.png"); other == first {
+		t.Error("a new target needs a new relationship")
+	}
+}
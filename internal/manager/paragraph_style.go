@@ -48,6 +48,7 @@ type paragraphStyle struct {
 	spacingBefore   int
 	spacingAfter    int
 	lineSpacing     int
+	lineRule        domain.LineSpacingRule
 	indentation     domain.Indentation
 	keepNext        bool
 	keepLines       bool
@@ -57,6 +58,7 @@ type paragraphStyle struct {
 	runItalic       bool
 	runUnderline    domain.UnderlineStyle
 	runColor        domain.Color
+	runThemeColor   *domain.ThemeColorRef
 	runSize         int
 }
 
@@ -168,6 +170,9 @@ func (ps *paragraphStyle) SetFont(font domain.Font) error {
 			"font name cannot be empty",
 		)
 	}
+	if font.Theme != "" && font.Theme != domain.ThemeFontMajor && font.Theme != domain.ThemeFontMinor {
+		return errors.NewValidationError("ParagraphStyle.SetFont", "font.Theme", font.Theme, "unknown theme font")
+	}
 
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
@@ -293,6 +298,31 @@ func (ps *paragraphStyle) SetLineSpacing(value int) error {
 	return nil
 }
 
+// LineSpacingRule returns how the line spacing value is measured.
+func (ps *paragraphStyle) LineSpacingRule() domain.LineSpacingRule {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	return ps.lineRule
+}
+
+// SetLineSpacingRule sets how the line spacing value is measured:
+// in 240ths of a line for LineSpacingAuto, in twips otherwise.
+func (ps *paragraphStyle) SetLineSpacingRule(rule domain.LineSpacingRule) error {
+	if rule < domain.LineSpacingAuto || rule > domain.LineSpacingAtLeast {
+		return errors.NewValidationError(
+			"ParagraphStyle.SetLineSpacingRule",
+			"rule",
+			rule,
+			"unknown line spacing rule",
+		)
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.lineRule = rule
+	return nil
+}
+
 // Indentation returns the paragraph indentation.
 func (ps *paragraphStyle) Indentation() domain.Indentation {
 	ps.mu.RLock()
@@ -443,6 +473,29 @@ func (ps *paragraphStyle) SetColor(color domain.Color) error {
 	ps.mu.Lock()
 	defer ps.mu.Unlock()
 	ps.runColor = color
+	ps.runThemeColor = nil
+	return nil
+}
+
+// ThemeColor returns the default run theme color, if one is set.
+func (ps *paragraphStyle) ThemeColor() (domain.ThemeColorRef, bool) {
+	ps.mu.RLock()
+	defer ps.mu.RUnlock()
+	if ps.runThemeColor == nil {
+		return domain.ThemeColorRef{}, false
+	}
+	return *ps.runThemeColor, true
+}
+
+// SetThemeColor sets a theme color for the default run.
+func (ps *paragraphStyle) SetThemeColor(ref domain.ThemeColorRef) error {
+	if _, ok := domain.DefaultDocumentTheme().Colors.Color(ref.Color); !ok {
+		return errors.NewValidationError("ParagraphStyle.SetThemeColor", "ref.Color", ref.Color, "unknown theme color")
+	}
+
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	ps.runThemeColor = &ref
 	return nil
 }
 
@@ -154,8 +154,18 @@ func (rm *RelationshipManager) Delete(id string) error {
 	return nil
 }
 
-// AddImage adds an image relationship.
+// AddImage adds an image relationship, reusing an existing one for the same
+// target so shared media parts are referenced once.
 func (rm *RelationshipManager) AddImage(target string) (string, error) {
+	rm.mu.RLock()
+	for _, rel := range rm.relationships {
+		if rel.Type == constants.RelTypeImage && rel.Target == target {
+			rm.mu.RUnlock()
+			return rel.ID, nil
+		}
+	}
+	rm.mu.RUnlock()
+
 	return rm.Add(constants.RelTypeImage, target, "Internal")
 }
 
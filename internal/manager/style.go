@@ -300,6 +300,13 @@ func (sm *styleManager) createBuiltInParagraphStyles() {
 	footer.SetSize(20)
 	sm.styles[domain.StyleIDFooter] = footer
 
+	// Footnote text
+	footnoteText := newParagraphStyle(domain.StyleIDFootnoteText, "footnote text", true)
+	footnoteText.SetBasedOn(domain.StyleIDNormal)
+	footnoteText.SetSpacingAfter(0)
+	footnoteText.SetSize(20) // 10pt
+	sm.styles[domain.StyleIDFootnoteText] = footnoteText
+
 	// Body Text variants
 	bodyText := newParagraphStyle(domain.StyleIDBodyText, "Body Text", true)
 	bodyText.SetBasedOn(domain.StyleIDNormal)
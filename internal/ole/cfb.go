@@ -0,0 +1,439 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package ole reads and writes the OLE objects Word uses to embed files:
+// compound files (the Microsoft "structured storage" container) and the
+// OLE1 Package object that wraps an arbitrary file.
+package ole
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+const (
+	sectorSize     = 512
+	miniSectorSize = 64
+	miniCutoff     = 4096
+	headerDIFAT    = 109
+	entrySize      = 128
+
+	freeSect   = 0xFFFFFFFF
+	endOfChain = 0xFFFFFFFE
+	fatSect    = 0xFFFFFFFD
+	difSect    = 0xFFFFFFFC
+	noStream   = 0xFFFFFFFF
+
+	entryStream  = 2
+	entryRoot    = 5
+	colorBlack   = 1
+	maxNameRunes = 31
+)
+
+var signature = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+// stream is a named stream in the root storage of a compound file.
+type stream struct {
+	name string
+	data []byte
+}
+
+// dirEntry is a directory entry being written.
+type dirEntry struct {
+	name        string
+	kind        byte
+	left, right uint32
+	child       uint32
+	clsid       [16]byte
+	start       uint32
+	size        uint64
+}
+
+// writeCompoundFile builds a version 3 compound file whose root storage
+// has the given class ID and holds streams.
+func writeCompoundFile(clsid [16]byte, streams []stream) []byte {
+	entries := []*dirEntry{{name: "Root Entry", kind: entryRoot, clsid: clsid, start: endOfChain, left: noStream, right: noStream}}
+
+	// Small streams live in the mini stream, chained by the mini FAT.
+	var miniStream []byte
+	var miniFAT []uint32
+	var big [][]byte
+	var bigEntries []*dirEntry
+	for _, s := range streams {
+		entry := &dirEntry{name: s.name, kind: entryStream, start: endOfChain, size: uint64(len(s.data)), left: noStream, right: noStream, child: noStream}
+		entries = append(entries, entry)
+		switch {
+		case len(s.data) == 0:
+		case len(s.data) < miniCutoff:
+			entry.start = uint32(len(miniFAT))
+			n := sectorsFor(len(s.data), miniSectorSize)
+			for i := 1; i < n; i++ {
+				miniFAT = append(miniFAT, uint32(len(miniFAT)+1))
+			}
+			miniFAT = append(miniFAT, endOfChain)
+			miniStream = append(miniStream, pad(s.data, miniSectorSize)...)
+		default:
+			big = append(big, s.data)
+			bigEntries = append(bigEntries, entry)
+		}
+	}
+	entries[0].child = buildTree(entries)
+
+	nDir := sectorsFor(len(entries)*entrySize, sectorSize)
+	nMiniFAT := sectorsFor(len(miniFAT)*4, sectorSize)
+	nMiniStream := sectorsFor(len(miniStream), sectorSize)
+	used := nDir + nMiniFAT + nMiniStream
+	for _, data := range big {
+		used += sectorsFor(len(data), sectorSize)
+	}
+	nFAT, nDIFAT := 1, 0
+	for {
+		nDIFAT = 0
+		if nFAT > headerDIFAT {
+			nDIFAT = sectorsFor(nFAT-headerDIFAT, sectorSize/4-1)
+		}
+		if nFAT*sectorSize/4 >= used+nFAT+nDIFAT {
+			break
+		}
+		nFAT++
+	}
+
+	fat := make([]uint32, nFAT*sectorSize/4)
+	for i := range fat {
+		fat[i] = freeSect
+	}
+	next := 0
+	allocate := func(n int) uint32 {
+		if n == 0 {
+			return endOfChain
+		}
+		start := next
+		for i := 0; i < n-1; i++ {
+			fat[start+i] = uint32(start + i + 1)
+		}
+		fat[start+n-1] = endOfChain
+		next += n
+		return uint32(start)
+	}
+	for i := 0; i < nFAT; i++ {
+		fat[next] = fatSect
+		next++
+	}
+	for i := 0; i < nDIFAT; i++ {
+		fat[next] = difSect
+		next++
+	}
+	dirStart := allocate(nDir)
+	miniFATStart := allocate(nMiniFAT)
+	if nMiniStream > 0 {
+		entries[0].start = allocate(nMiniStream)
+		entries[0].size = uint64(len(miniStream))
+	}
+	for i, data := range big {
+		bigEntries[i].start = allocate(sectorsFor(len(data), sectorSize))
+	}
+
+	var out bytes.Buffer
+	header := make([]byte, sectorSize)
+	copy(header, signature)
+	le := binary.LittleEndian
+	le.PutUint16(header[24:], 0x003E)
+	le.PutUint16(header[26:], 3)
+	le.PutUint16(header[28:], 0xFFFE)
+	le.PutUint16(header[30:], 9)
+	le.PutUint16(header[32:], 6)
+	le.PutUint32(header[44:], uint32(nFAT))
+	le.PutUint32(header[48:], dirStart)
+	le.PutUint32(header[56:], miniCutoff)
+	le.PutUint32(header[60:], miniFATStart)
+	le.PutUint32(header[64:], uint32(nMiniFAT))
+	le.PutUint32(header[68:], endOfChain)
+	le.PutUint32(header[72:], uint32(nDIFAT))
+	if nDIFAT > 0 {
+		le.PutUint32(header[68:], uint32(nFAT))
+	}
+	for i := 0; i < headerDIFAT; i++ {
+		v := uint32(freeSect)
+		if i < nFAT {
+			v = uint32(i)
+		}
+		le.PutUint32(header[76+4*i:], v)
+	}
+	out.Write(header)
+
+	writeUint32s(&out, fat)
+
+	// DIFAT sectors list the FAT sectors beyond the first 109.
+	for i := 0; i < nDIFAT; i++ {
+		sector := make([]uint32, sectorSize/4)
+		for j := range sector {
+			sector[j] = freeSect
+		}
+		for j := 0; j < sectorSize/4-1; j++ {
+			if fatIndex := headerDIFAT + i*(sectorSize/4-1) + j; fatIndex < nFAT {
+				sector[j] = uint32(fatIndex)
+			}
+		}
+		sector[sectorSize/4-1] = endOfChain
+		if i < nDIFAT-1 {
+			sector[sectorSize/4-1] = uint32(nFAT + i + 1)
+		}
+		writeUint32s(&out, sector)
+	}
+
+	var dir bytes.Buffer
+	for _, entry := range entries {
+		dir.Write(entry.encode())
+	}
+	for dir.Len()%sectorSize != 0 {
+		dir.Write((&dirEntry{left: noStream, right: noStream, child: noStream}).encode())
+	}
+	out.Write(dir.Bytes())
+
+	miniFATBytes := make([]uint32, nMiniFAT*sectorSize/4)
+	for i := range miniFATBytes {
+		miniFATBytes[i] = freeSect
+	}
+	copy(miniFATBytes, miniFAT)
+	writeUint32s(&out, miniFATBytes)
+
+	out.Write(pad(miniStream, sectorSize))
+	for _, data := range big {
+		out.Write(pad(data, sectorSize))
+	}
+	return out.Bytes()
+}
+
+// buildTree links the stream entries into a balanced binary tree ordered
+// as compound files require and returns the index of its root.
+func buildTree(entries []*dirEntry) uint32 {
+	ids := make([]int, 0, len(entries)-1)
+	for i := 1; i < len(entries); i++ {
+		ids = append(ids, i)
+	}
+	slices.SortFunc(ids, func(a, b int) int { return compareNames(entries[a].name, entries[b].name) })
+
+	var build func(lo, hi int) uint32
+	build = func(lo, hi int) uint32 {
+		if lo > hi {
+			return noStream
+		}
+		mid := (lo + hi) / 2
+		entry := entries[ids[mid]]
+		entry.left = build(lo, mid-1)
+		entry.right = build(mid+1, hi)
+		return uint32(ids[mid])
+	}
+	return build(0, len(ids)-1)
+}
+
+// compareNames orders entry names by length, then case-insensitively.
+func compareNames(a, b string) int {
+	la, lb := len(utf16.Encode([]rune(a))), len(utf16.Encode([]rune(b)))
+	if la != lb {
+		return la - lb
+	}
+	return strings.Compare(strings.ToUpper(a), strings.ToUpper(b))
+}
+
+func (e *dirEntry) encode() []byte {
+	buf := make([]byte, entrySize)
+	le := binary.LittleEndian
+	if e.name != "" {
+		name := utf16.Encode([]rune(e.name))
+		for i, c := range name {
+			le.PutUint16(buf[2*i:], c)
+		}
+		le.PutUint16(buf[64:], uint16(2*(len(name)+1)))
+	}
+	buf[66] = e.kind
+	buf[67] = colorBlack
+	le.PutUint32(buf[68:], e.left)
+	le.PutUint32(buf[72:], e.right)
+	le.PutUint32(buf[76:], e.child)
+	copy(buf[80:96], e.clsid[:])
+	le.PutUint32(buf[116:], e.start)
+	le.PutUint64(buf[120:], e.size)
+	if e.kind == 0 {
+		le.PutUint32(buf[116:], 0)
+	}
+	return buf
+}
+
+// readCompoundFile returns the streams of a compound file keyed by name.
+func readCompoundFile(data []byte) (map[string][]byte, error) {
+	const op = "ole.readCompoundFile"
+	if len(data) < sectorSize || !bytes.Equal(data[:8], signature) {
+		return nil, errors.InvalidArgument(op, "data", len(data), "not a compound file")
+	}
+	le := binary.LittleEndian
+	shift := le.Uint16(data[30:])
+	miniShift := le.Uint16(data[32:])
+	if shift != 9 && shift != 12 || miniShift != 6 {
+		return nil, errors.InvalidArgument(op, "sectorShift", shift, "unsupported sector size")
+	}
+	size := 1 << shift
+	nSectors := (len(data) - size) / size
+	sector := func(n uint32) []byte {
+		if int(n) >= nSectors {
+			return nil
+		}
+		off := (int(n) + 1) * size
+		return data[off : off+size]
+	}
+
+	// Collect the FAT sector numbers from the header and DIFAT chain.
+	nFAT := int(le.Uint32(data[44:]))
+	var fatSectors []uint32
+	for i := 0; i < headerDIFAT && len(fatSectors) < nFAT; i++ {
+		fatSectors = append(fatSectors, le.Uint32(data[76+4*i:]))
+	}
+	for difat, seen := le.Uint32(data[68:]), 0; difat < endOfChain-1 && len(fatSectors) < nFAT && seen < nSectors; seen++ {
+		buf := sector(difat)
+		if buf == nil {
+			return nil, errors.InvalidArgument(op, "difat", difat, "sector out of range")
+		}
+		for i := 0; i < size/4-1 && len(fatSectors) < nFAT; i++ {
+			fatSectors = append(fatSectors, le.Uint32(buf[4*i:]))
+		}
+		difat = le.Uint32(buf[size-4:])
+	}
+	var fat []uint32
+	for _, n := range fatSectors {
+		buf := sector(n)
+		if buf == nil {
+			return nil, errors.InvalidArgument(op, "fat", n, "sector out of range")
+		}
+		fat = append(fat, readUint32s(buf)...)
+	}
+
+	chain := func(start uint32, table []uint32, read func(uint32) []byte) ([]byte, error) {
+		var out []byte
+		for n, steps := start, 0; n != endOfChain; steps++ {
+			buf := read(n)
+			if buf == nil || int(n) >= len(table) || steps > len(table) {
+				return nil, errors.InvalidArgument(op, "chain", start, "broken sector chain")
+			}
+			out = append(out, buf...)
+			n = table[n]
+		}
+		return out, nil
+	}
+
+	dir, err := chain(le.Uint32(data[48:]), fat, sector)
+	if err != nil {
+		return nil, err
+	}
+	if len(dir) < entrySize || dir[66] != entryRoot {
+		return nil, errors.InvalidArgument(op, "directory", len(dir), "missing root entry")
+	}
+	var miniFAT []uint32
+	if start := le.Uint32(data[60:]); start != endOfChain && le.Uint32(data[64:]) > 0 {
+		raw, err := chain(start, fat, sector)
+		if err != nil {
+			return nil, err
+		}
+		miniFAT = readUint32s(raw)
+	}
+	var miniStream []byte
+	if start := le.Uint32(dir[116:]); start != endOfChain && le.Uint64(dir[120:]) > 0 {
+		if miniStream, err = chain(start, fat, sector); err != nil {
+			return nil, err
+		}
+	}
+	miniSector := func(n uint32) []byte {
+		off := int(n) * miniSectorSize
+		if off+miniSectorSize > len(miniStream) {
+			return nil
+		}
+		return miniStream[off : off+miniSectorSize]
+	}
+
+	streams := make(map[string][]byte)
+	for off := entrySize; off+entrySize <= len(dir); off += entrySize {
+		entry := dir[off : off+entrySize]
+		if entry[66] != entryStream {
+			continue
+		}
+		nameLen := min(int(le.Uint16(entry[64:])), 64)
+		name := make([]uint16, 0, nameLen/2)
+		for i := 0; i+1 < nameLen; i += 2 {
+			if c := le.Uint16(entry[i:]); c != 0 {
+				name = append(name, c)
+			}
+		}
+		start, length := le.Uint32(entry[116:]), le.Uint64(entry[120:])
+		if shift == 9 {
+			length &= 0xFFFFFFFF
+		}
+		var content []byte
+		switch {
+		case length == 0:
+		case length < miniCutoff:
+			content, err = chain(start, miniFAT, miniSector)
+		default:
+			content, err = chain(start, fat, sector)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(content)) < length {
+			return nil, errors.InvalidArgument(op, "stream", string(utf16.Decode(name)), "stream is truncated")
+		}
+		streams[string(utf16.Decode(name))] = content[:length]
+	}
+	return streams, nil
+}
+
+func sectorsFor(n, size int) int {
+	return (n + size - 1) / size
+}
+
+func pad(data []byte, size int) []byte {
+	if rem := len(data) % size; rem != 0 {
+		return append(slices.Clip(data), make([]byte, size-rem)...)
+	}
+	return data
+}
+
+func writeUint32s(buf *bytes.Buffer, values []uint32) {
+	b := make([]byte, 4*len(values))
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(b[4*i:], v)
+	}
+	buf.Write(b)
+}
+
+func readUint32s(data []byte) []uint32 {
+	values := make([]uint32, len(data)/4)
+	for i := range values {
+		values[i] = binary.LittleEndian.Uint32(data[4*i:])
+	}
+	return values
+}
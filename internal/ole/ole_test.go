@@ -0,0 +1,69 @@
+package ole
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPackageObjectRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"empty.txt", 0},
+		{"small.pdf", 1000},
+		{"medium.csv", 5000},
+		{"Résumé 数据.bin", 200000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := make([]byte, tt.size)
+			for i := range data {
+				data[i] = byte(i * 7)
+			}
+			obj := PackageObject(`C:\reports\`+tt.name, data)
+			if len(obj)%sectorSize != 0 {
+				t.Errorf("compound file size %d is not sector aligned", len(obj))
+			}
+			name, content, err := ReadPackageObject(obj)
+			if err != nil {
+				t.Fatalf("ReadPackageObject() error = %v", err)
+			}
+			if name != tt.name {
+				t.Errorf("file name = %q, want %q", name, tt.name)
+			}
+			if !bytes.Equal(content, data) {
+				t.Errorf("content differs: %d bytes, want %d", len(content), len(data))
+			}
+		})
+	}
+}
+
+func TestCompoundFileManyStreams(t *testing.T) {
+	var streams []stream
+	for i := 0; i < 20; i++ {
+		streams = append(streams, stream{name: string(rune('A'+i)) + "stream", data: bytes.Repeat([]byte{byte(i)}, 100*i)})
+	}
+	// Large enough to need FAT sectors beyond the header's DIFAT array.
+	streams = append(streams, stream{name: "big", data: bytes.Repeat([]byte("docx"), 2_000_000)})
+
+	got, err := readCompoundFile(writeCompoundFile(PackageCLSID, streams))
+	if err != nil {
+		t.Fatalf("readCompoundFile() error = %v", err)
+	}
+	for _, s := range streams {
+		if !bytes.Equal(got[s.name], s.data) {
+			t.Errorf("stream %q: %d bytes, want %d", s.name, len(got[s.name]), len(s.data))
+		}
+	}
+}
+
+func TestReadPackageObjectRejectsGarbage(t *testing.T) {
+	if _, _, err := ReadPackageObject([]byte("not ole")); err == nil {
+		t.Error("expected error for non-compound data")
+	}
+	obj := writeCompoundFile(PackageCLSID, []stream{{name: "Contents", data: []byte("x")}})
+	if _, _, err := ReadPackageObject(obj); err == nil {
+		t.Error("expected error for compound file without native data")
+	}
+}
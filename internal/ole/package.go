@@ -0,0 +1,234 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package ole
+
+import (
+	"bytes"
+	"encoding/binary"
+	"path"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// PackageCLSID is the class ID of the OLE1 Package object,
+// {0003000C-0000-0000-C000-000000000046}.
+var PackageCLSID = [16]byte{0x0C, 0x00, 0x03, 0x00, 0x00, 0x00, 0x00, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46}
+
+const (
+	nativeStream  = "\x01Ole10Native"
+	compObjStream = "\x01CompObj"
+
+	// packageEmbedded marks the native data as an embedded file rather
+	// than a link.
+	packageEmbedded = 3
+	unicodeMarker   = 0x71B239F4
+)
+
+// PackageObject wraps a file in an OLE Package object, the form Word uses
+// for attachments that no installed application owns. The result is the
+// content of an embeddings/oleObjectN.bin part.
+func PackageObject(fileName string, data []byte) []byte {
+	name := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+
+	var native bytes.Buffer
+	writeUint16(&native, 2)
+	writeANSI(&native, name)
+	writeANSI(&native, name)
+	writeUint16(&native, 0)
+	writeUint16(&native, packageEmbedded)
+	writeUint32(&native, uint32(len(ansi(name))+1))
+	writeANSI(&native, name)
+	writeUint32(&native, uint32(len(data)))
+	native.Write(data)
+	// Unicode copies of the temp path, label and source path.
+	for i := 0; i < 3; i++ {
+		units := utf16.Encode([]rune(name))
+		writeUint32(&native, uint32(len(units)))
+		for _, u := range units {
+			writeUint16(&native, u)
+		}
+	}
+
+	var payload bytes.Buffer
+	writeUint32(&payload, uint32(native.Len()))
+	payload.Write(native.Bytes())
+
+	return writeCompoundFile(PackageCLSID, []stream{
+		{name: compObjStream, data: packageCompObj()},
+		{name: nativeStream, data: payload.Bytes()},
+	})
+}
+
+// ReadPackageObject extracts the file wrapped by an OLE Package object.
+func ReadPackageObject(data []byte) (fileName string, content []byte, err error) {
+	const op = "ole.ReadPackageObject"
+	streams, err := readCompoundFile(data)
+	if err != nil {
+		return "", nil, err
+	}
+	native, ok := streams[nativeStream]
+	if !ok {
+		return "", nil, errors.InvalidArgument(op, "data", len(data), "not a package object")
+	}
+
+	r := &reader{data: native}
+	size := r.uint32()
+	if int(size) < len(native)-4 {
+		r.data = native[:4+size]
+	}
+	r.uint16()
+	label := r.cstring()
+	r.cstring()
+	r.uint32()
+	r.skip(int(r.uint32()))
+	content = r.bytes(int(r.uint32()))
+	if r.err {
+		return "", nil, errors.InvalidArgument(op, "data", len(native), "truncated package data")
+	}
+	fileName = label
+
+	// Prefer the Unicode label when the extension is present.
+	r.data = native[r.pos:]
+	r.pos = 0
+	r.utf16()
+	if unicodeLabel := r.utf16(); !r.err && unicodeLabel != "" {
+		fileName = unicodeLabel
+	}
+	return fileName, content, nil
+}
+
+// packageCompObj returns the CompObj stream naming the Package class.
+func packageCompObj() []byte {
+	var buf bytes.Buffer
+	writeUint32(&buf, 0xFFFE0001)
+	writeUint32(&buf, 0x00000A03)
+	writeUint32(&buf, 0xFFFFFFFF)
+	buf.Write(PackageCLSID[:])
+	writeLengthPrefixed(&buf, "OLE Package")
+	writeUint32(&buf, 0)
+	writeLengthPrefixed(&buf, "Package")
+	writeUint32(&buf, unicodeMarker)
+	writeUint32(&buf, 0)
+	writeUint32(&buf, 0)
+	writeUint32(&buf, 0)
+	return buf.Bytes()
+}
+
+// ansi maps a name to the single-byte code page used by OLE1; characters
+// outside Latin-1 become underscores, the Unicode extension keeps them.
+func ansi(s string) []byte {
+	out := make([]byte, 0, len(s))
+	for _, r := range s {
+		if r > 0xFF {
+			r = '_'
+		}
+		out = append(out, byte(r))
+	}
+	return out
+}
+
+func writeANSI(buf *bytes.Buffer, s string) {
+	buf.Write(ansi(s))
+	buf.WriteByte(0)
+}
+
+func writeLengthPrefixed(buf *bytes.Buffer, s string) {
+	writeUint32(buf, uint32(len(s)+1))
+	writeANSI(buf, s)
+}
+
+func writeUint16(buf *bytes.Buffer, v uint16) {
+	buf.Write(binary.LittleEndian.AppendUint16(nil, v))
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	buf.Write(binary.LittleEndian.AppendUint32(nil, v))
+}
+
+// reader decodes little-endian fields, recording rather than panicking
+// on truncated input.
+type reader struct {
+	data []byte
+	pos  int
+	err  bool
+}
+
+func (r *reader) bytes(n int) []byte {
+	if r.err || n < 0 || r.pos+n > len(r.data) {
+		r.err = true
+		return nil
+	}
+	b := r.data[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *reader) skip(n int) { r.bytes(n) }
+
+func (r *reader) uint16() uint16 {
+	if b := r.bytes(2); b != nil {
+		return binary.LittleEndian.Uint16(b)
+	}
+	return 0
+}
+
+func (r *reader) uint32() uint32 {
+	if b := r.bytes(4); b != nil {
+		return binary.LittleEndian.Uint32(b)
+	}
+	return 0
+}
+
+func (r *reader) cstring() string {
+	if r.err {
+		return ""
+	}
+	end := bytes.IndexByte(r.data[r.pos:], 0)
+	if end < 0 {
+		r.err = true
+		return ""
+	}
+	b := r.bytes(end + 1)[:end]
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return string(runes)
+}
+
+func (r *reader) utf16() string {
+	n := int(r.uint32())
+	raw := r.bytes(2 * n)
+	if raw == nil {
+		return ""
+	}
+	units := make([]uint16, n)
+	for i := range units {
+		units[i] = binary.LittleEndian.Uint16(raw[2*i:])
+	}
+	return string(utf16.Decode(units))
+}
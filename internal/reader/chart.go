@@ -0,0 +1,381 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package reader
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/core"
+	"github.com/mmonterroca/docxgo/v2/internal/writer"
+	xmlstructs "github.com/mmonterroca/docxgo/v2/internal/xml"
+	pkgcolor "github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// chartTypes maps the chart groups of a plot area to chart types. Bar
+// groups are columns unless their direction says otherwise.
+var chartTypes = map[string]domain.ChartType{
+	"barChart":     domain.ChartColumn,
+	"lineChart":    domain.ChartLine,
+	"pieChart":     domain.ChartPie,
+	"scatterChart": domain.ChartScatter,
+}
+
+// chartLegendPositions maps c:legendPos values to legend positions.
+var chartLegendPositions = map[string]domain.LegendPosition{
+	"r":  domain.LegendRight,
+	"tr": domain.LegendRight,
+	"t":  domain.LegendTop,
+	"b":  domain.LegendBottom,
+	"l":  domain.LegendLeft,
+}
+
+// hydrateChart restores the chart a drawing references. The chart part and
+// the parts it links to are kept as read; charts the model cannot describe,
+// such as 3-D or combined charts, are kept with an empty spec.
+func hydrateChart(para domain.Paragraph, run domain.Run, container, ref *Element, floating bool, ctx *reconstructContext) error {
+	attacher, ok := para.(interface {
+		AttachHydratedChartToRun(run domain.Run, chart domain.Chart, relID string) error
+	})
+	if !ok {
+		return nil
+	}
+
+	relID, _ := getAttr(ref, "id")
+	target, ok := ctx.resolveRelationshipTarget(relID)
+	if !ok || target == "" {
+		return errors.Errorf(errors.ErrCodeInvalidState, opHydrateChart, "relationship %s missing chart target", relID)
+	}
+	data, partPath, found := ctx.partFor(target)
+	if !found {
+		return errors.Errorf(errors.ErrCodeInvalidState, opHydrateChart, "unable to resolve chart part %s", partPath)
+	}
+	tree, err := parseXMLTree(data)
+	if err != nil {
+		return xmlPartError(partPath, err)
+	}
+	parts, err := ctx.linkedParts(partPath)
+	if err != nil {
+		return err
+	}
+
+	chartTarget := strings.TrimPrefix(partPath, "word/")
+	var chart domain.Chart
+	if spec, ok := chartSpec(tree); ok {
+		chart, err = core.NewChartFromPackage(chartTarget, &spec, parts)
+	}
+	if chart == nil || err != nil {
+		if chart, err = core.NewChartFromPackage(chartTarget, nil, parts); err != nil {
+			return errors.Wrap(err, opHydrateChart)
+		}
+	}
+
+	if widthEMU, heightEMU := extractDrawingExtent(container); widthEMU > 0 && heightEMU > 0 {
+		_ = chart.SetSize(domain.ImageSize{WidthEMU: widthEMU, HeightEMU: heightEMU})
+	}
+	if floating {
+		_ = chart.SetPosition(buildFloatingPosition(container))
+	}
+	if desc := extractDrawingDescription(container); desc != "" {
+		_ = chart.SetDescription(desc)
+	}
+
+	if err := attacher.AttachHydratedChartToRun(run, chart, relID); err != nil {
+		return errors.Wrap(err, opHydrateChart)
+	}
+	return nil
+}
+
+// linkedParts returns the part name and, following the relationships of
+// each part, the internal parts it links to, as they were read.
+func (ctx *reconstructContext) linkedParts(name string) ([]*writer.Part, error) {
+	pkg := ctx.parsed.Package
+	var parts []*writer.Part
+	seen := make(map[string]bool)
+
+	var add func(name string) error
+	add = func(name string) error {
+		stored, ok := pkg.lookupPart(name)
+		if !ok || seen[normalizePartName(stored)] {
+			return nil
+		}
+		seen[normalizePartName(stored)] = true
+		parts = append(parts, &writer.Part{Name: stored, ContentType: pkg.contentTypeFor(stored), Data: pkg.RawParts[stored]})
+
+		relsName, ok := pkg.lookupPart(path.Dir(stored) + "/_rels/" + path.Base(stored) + ".rels")
+		if !ok {
+			return nil
+		}
+		parts = append(parts, &writer.Part{Name: relsName, Data: pkg.RawParts[relsName]})
+		var rels xmlstructs.Relationships
+		if err := decodeXML(pkg.RawParts[relsName], &rels, relsName); err != nil {
+			return err
+		}
+		for _, rel := range rels.Relationships {
+			if rel == nil || rel.Target == "" || strings.EqualFold(rel.TargetMode, "External") {
+				continue
+			}
+			target := path.Join(path.Dir(stored), rel.Target)
+			if strings.HasPrefix(rel.Target, "/") {
+				target = strings.TrimPrefix(rel.Target, "/")
+			}
+			if err := add(target); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := add(name); err != nil {
+		return nil, err
+	}
+	return parts, nil
+}
+
+// chartSpec reads a chart part (c:chartSpace) back into a chart definition.
+// It reports false when the plot area holds no single supported chart.
+func chartSpec(space *Element) (domain.ChartSpec, bool) {
+	chart := findChild(space, "chart")
+	plot := findChild(chart, "plotArea")
+	if plot == nil {
+		return domain.ChartSpec{}, false
+	}
+
+	var (
+		spec  domain.ChartSpec
+		group *Element
+	)
+	axes := make(map[string]*Element)
+	for _, child := range plot.Children {
+		if child == nil {
+			continue
+		}
+		switch name := child.Name.Local; {
+		case name == "catAx" || name == "valAx":
+			axes[chartChildVal(child, "axId")] = child
+		case strings.HasSuffix(name, "Chart"):
+			kind, supported := chartTypes[name]
+			if !supported || group != nil {
+				return domain.ChartSpec{}, false
+			}
+			group, spec.Type = child, kind
+		}
+	}
+	if group == nil {
+		return domain.ChartSpec{}, false
+	}
+	if spec.Type == domain.ChartColumn && chartChildVal(group, "barDir") == "bar" {
+		spec.Type = domain.ChartBar
+	}
+
+	if title := findChild(chart, "title"); title != nil {
+		spec.Title = chartText(title)
+	}
+	spec.Legend = domain.LegendNone
+	if legend := findChild(chart, "legend"); legend != nil {
+		spec.Legend = chartLegendPositions[chartChildVal(legend, "legendPos")]
+	}
+	if labels := findChild(group, "dLbls"); labels != nil {
+		spec.DataLabels = chartChildVal(labels, "showVal") == "1"
+	}
+
+	var colors []domain.Color
+	for _, ser := range group.Children {
+		if ser == nil || ser.Name.Local != "ser" {
+			continue
+		}
+		series := domain.ChartSeries{Name: strings.Join(chartCache(findChild(ser, "tx")), "")}
+		if v := findChild(findChild(ser, "tx"), "v"); v != nil && series.Name == "" {
+			series.Name = v.Text
+		}
+		if spec.Type == domain.ChartScatter {
+			series.XValues = chartNumbers(chartCache(findChild(ser, "xVal")))
+			series.Values = chartNumbers(chartCache(findChild(ser, "yVal")))
+		} else {
+			if spec.Categories == nil {
+				spec.Categories = chartCache(findChild(ser, "cat"))
+			}
+			series.Values = chartNumbers(chartCache(findChild(ser, "val")))
+		}
+		spec.Series = append(spec.Series, series)
+
+		if spec.Type == domain.ChartPie {
+			for _, point := range ser.Children {
+				if point != nil && point.Name.Local == "dPt" {
+					if c, ok := chartColor(point); ok {
+						colors = append(colors, c)
+					}
+				}
+			}
+		} else if c, ok := chartColor(ser); ok {
+			colors = append(colors, c)
+		}
+	}
+	// Colors are kept when every series, or every pie slice, has one.
+	want := len(spec.Series)
+	if spec.Type == domain.ChartPie {
+		want = len(spec.Categories)
+	}
+	if want > 0 && len(colors) == want {
+		spec.Colors = colors
+	}
+
+	// The group lists the X (or category) axis first.
+	var ids []string
+	for _, child := range group.Children {
+		if child != nil && child.Name.Local == "axId" {
+			id, _ := getAttr(child, "val")
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 2 {
+		spec.XAxis = chartAxis(axes[ids[0]])
+		spec.YAxis = chartAxis(axes[ids[1]])
+	}
+	return spec, true
+}
+
+// chartAxis reads the settings of a c:catAx or c:valAx element.
+func chartAxis(axis *Element) domain.ChartAxis {
+	if axis == nil {
+		return domain.ChartAxis{}
+	}
+	out := domain.ChartAxis{
+		Gridlines: findChild(axis, "majorGridlines") != nil,
+		Hidden:    chartChildVal(axis, "delete") == "1",
+	}
+	if title := findChild(axis, "title"); title != nil {
+		out.Title = chartText(title)
+	}
+	if scaling := findChild(axis, "scaling"); scaling != nil {
+		minimum, minErr := strconv.ParseFloat(chartChildVal(scaling, "min"), 64)
+		maximum, maxErr := strconv.ParseFloat(chartChildVal(scaling, "max"), 64)
+		if minErr == nil && maxErr == nil && maximum > minimum {
+			out.Min, out.Max = minimum, maximum
+		}
+	}
+	if numFmt := findChild(axis, "numFmt"); numFmt != nil {
+		if linked, _ := getAttr(numFmt, "sourceLinked"); linked != "1" {
+			out.NumberFormat, _ = getAttr(numFmt, "formatCode")
+		}
+	}
+	return out
+}
+
+// chartCache returns the cached values of a series name, categories or
+// values, in point order.
+func chartCache(data *Element) []string {
+	cache := findDescendant(data, "strCache")
+	if cache == nil {
+		cache = findDescendant(data, "numCache")
+	}
+	if cache == nil {
+		cache = findDescendant(data, "strLit")
+	}
+	if cache == nil {
+		cache = findDescendant(data, "numLit")
+	}
+	if cache == nil {
+		return nil
+	}
+
+	count, _ := strconv.Atoi(chartChildVal(cache, "ptCount"))
+	values := make([]string, max(count, 0))
+	for _, pt := range cache.Children {
+		if pt == nil || pt.Name.Local != "pt" {
+			continue
+		}
+		idxAttr, _ := getAttr(pt, "idx")
+		idx, err := strconv.Atoi(idxAttr)
+		if err != nil || idx < 0 || idx >= len(values) {
+			continue
+		}
+		if v := findChild(pt, "v"); v != nil {
+			values[idx] = v.Text
+		}
+	}
+	return values
+}
+
+// chartNumbers parses cached values; blanks count as zero.
+func chartNumbers(values []string) []float64 {
+	if values == nil {
+		return nil
+	}
+	out := make([]float64, len(values))
+	for i, v := range values {
+		out[i], _ = strconv.ParseFloat(strings.TrimSpace(v), 64)
+	}
+	return out
+}
+
+// chartColor returns the fill of a series or data point: the solid fill
+// of its shape, line or marker.
+func chartColor(elem *Element) (domain.Color, bool) {
+	for _, parent := range []*Element{
+		findChild(elem, "spPr"),
+		findChild(findChild(elem, "spPr"), "ln"),
+		findChild(findChild(elem, "marker"), "spPr"),
+	} {
+		if clr := findChild(findChild(parent, "solidFill"), "srgbClr"); clr != nil {
+			val, _ := getAttr(clr, "val")
+			if c, err := pkgcolor.FromHex(val); err == nil {
+				return c, true
+			}
+		}
+	}
+	return domain.Color{}, false
+}
+
+// chartText joins the DrawingML text of a title or series name.
+func chartText(elem *Element) string {
+	var b strings.Builder
+	var walk func(*Element)
+	walk = func(e *Element) {
+		for _, child := range e.Children {
+			if child == nil {
+				continue
+			}
+			if child.Name.Local == "t" {
+				b.WriteString(child.Text)
+				continue
+			}
+			walk(child)
+		}
+	}
+	if elem != nil {
+		walk(elem)
+	}
+	return b.String()
+}
+
+// chartChildVal returns the val attribute of the named child.
+func chartChildVal(parent *Element, local string) string {
+	val, _ := getAttr(findChild(parent, local), "val")
+	return val
+}
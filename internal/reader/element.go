@@ -27,6 +27,7 @@ package reader
 import (
 	"bytes"
 	"encoding/xml"
+	"fmt"
 )
 
 // Element represents a generic XML element with nested children.
@@ -91,3 +92,67 @@ func parseElement(dec *xml.Decoder, start xml.StartElement) (*Element, error) {
 		}
 	}
 }
+
+// namespacePrefixes maps namespace URIs to the prefixes used when elements
+// are written back verbatim.
+var namespacePrefixes = map[string]string{
+	"http://schemas.openxmlformats.org/officeDocument/2006/math":          "m",
+	"http://schemas.openxmlformats.org/wordprocessingml/2006/main":        "w",
+	"http://schemas.openxmlformats.org/officeDocument/2006/relationships": "r",
+	"http://schemas.openxmlformats.org/drawingml/2006/main":               "a",
+	"http://www.w3.org/XML/1998/namespace":                                "xml",
+	"xml":                                                                 "xml",
+}
+
+// innerXML serializes the children of e. Prefixes are assumed to be
+// declared by the enclosing part; namespaces outside namespacePrefixes are
+// declared where they are used.
+func (e *Element) innerXML() string {
+	var buf bytes.Buffer
+	for _, child := range e.Children {
+		child.writeXML(&buf)
+	}
+	return buf.String()
+}
+
+func (e *Element) writeXML(buf *bytes.Buffer) {
+	var decls []string
+	qualify := func(name xml.Name) string {
+		if name.Space == "" {
+			return name.Local
+		}
+		prefix, ok := namespacePrefixes[name.Space]
+		if !ok {
+			prefix = fmt.Sprintf("ns%d", len(decls))
+			decls = append(decls, fmt.Sprintf(` xmlns:%s="%s"`, prefix, escapeAttr(name.Space)))
+		}
+		return prefix + ":" + name.Local
+	}
+
+	tag := qualify(e.Name)
+	var attrs bytes.Buffer
+	for _, attr := range e.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Space == "" && attr.Name.Local == "xmlns" {
+			continue
+		}
+		fmt.Fprintf(&attrs, ` %s="%s"`, qualify(attr.Name), escapeAttr(attr.Value))
+	}
+
+	buf.WriteString("<" + tag)
+	for _, decl := range decls {
+		buf.WriteString(decl)
+	}
+	buf.Write(attrs.Bytes())
+	buf.WriteString(">")
+	_ = xml.EscapeText(buf, []byte(e.Text))
+	for _, child := range e.Children {
+		child.writeXML(buf)
+	}
+	buf.WriteString("</" + tag + ">")
+}
+
+func escapeAttr(value string) string {
+	var buf bytes.Buffer
+	_ = xml.EscapeText(&buf, []byte(value))
+	return buf.String()
+}
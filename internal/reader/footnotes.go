@@ -0,0 +1,91 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package reader
+
+import (
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// hydrateFootnote adds the footnote referenced by a w:footnoteReference
+// element, with its text from footnotes.xml.
+func hydrateFootnote(para domain.Paragraph, ref *Element, ctx *reconstructContext) error {
+	note, err := para.AddFootnote()
+	if err != nil {
+		return errors.Wrap(err, opHydrateFootnote)
+	}
+	if ctx == nil || ctx.parsed == nil || ctx.parsed.Footnotes == nil {
+		return nil
+	}
+
+	id, _ := getAttr(ref, "id")
+	for _, child := range ctx.parsed.Footnotes.Children {
+		if child == nil || child.Name.Local != "footnote" {
+			continue
+		}
+		if childID, _ := getAttr(child, "id"); childID != id {
+			continue
+		}
+		for _, p := range child.Children {
+			if p == nil || p.Name.Local != "p" {
+				continue
+			}
+			notePara, err := note.AddParagraph()
+			if err != nil {
+				return errors.Wrap(err, opHydrateFootnote)
+			}
+			if err := populateParagraph(notePara, withoutFootnoteMark(p), ctx); err != nil {
+				return err
+			}
+		}
+		break
+	}
+	return nil
+}
+
+// withoutFootnoteMark drops the w:footnoteRef run that numbers a note,
+// and the space that follows it, since the writer adds both again.
+func withoutFootnoteMark(p *Element) *Element {
+	trimmed := &Element{Name: p.Name, Attr: p.Attr}
+	skipSpace := false
+	for _, child := range p.Children {
+		if child != nil && child.Name.Local == "r" {
+			if findChild(child, "footnoteRef") != nil {
+				skipSpace = true
+				continue
+			}
+			if skipSpace {
+				skipSpace = false
+				if t := findChild(child, "t"); t != nil && strings.TrimSpace(t.Text) == "" && len(child.Children) <= 2 {
+					continue
+				}
+			}
+		}
+		trimmed.Children = append(trimmed.Children, child)
+	}
+	return trimmed
+}
@@ -44,6 +44,7 @@ type ParsedPackage struct {
 	StylesTree   *Element
 	HeaderTrees  map[string]*Element
 	FooterTrees  map[string]*Element
+	Footnotes    *Element
 
 	RootRelationships     *xmlstructs.Relationships
 	DocumentRelationships *xmlstructs.Relationships
@@ -149,6 +150,14 @@ func ParsePackage(pkg *Package) (*ParsedPackage, error) {
 		parsed.FooterTrees[name] = tree
 	}
 
+	if name, ok := pkg.lookupPart(constants.PathFootnotes); ok {
+		tree, err := parseXMLTree(pkg.RawParts[name])
+		if err != nil {
+			return nil, xmlPartError(name, err)
+		}
+		parsed.Footnotes = tree
+	}
+
 	for name, data := range pkg.ThemeParts {
 		if len(data) == 0 {
 			continue
@@ -31,6 +31,9 @@ import (
 	"image/color"
 	"image/png"
 	"os"
+	"path"
+	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -288,6 +291,45 @@ func TestReconstructDocumentFormatting(t *testing.T) {
 	}
 }
 
+func TestReconstructThemeReferences(t *testing.T) {
+	doc := core.NewDocument()
+	para, _ := doc.AddParagraph()
+	run, _ := para.AddRun()
+	run.SetText("Themed")
+	ref := domain.ThemeColorRef{Color: domain.ThemeColorAccent2, Tint: 0x99}
+	if err := run.SetThemeColor(ref); err != nil {
+		t.Fatalf("SetThemeColor: %v", err)
+	}
+	if err := run.SetFont(domain.Font{Name: "Calibri Light", Theme: domain.ThemeFontMajor}); err != nil {
+		t.Fatalf("SetFont: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	pkg, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+	parsed, err := ParsePackage(pkg)
+	if err != nil {
+		t.Fatalf("ParsePackage: %v", err)
+	}
+	reconstructed, err := ReconstructDocument(parsed)
+	if err != nil {
+		t.Fatalf("ReconstructDocument: %v", err)
+	}
+
+	got := reconstructed.Paragraphs()[0].Runs()[0]
+	if gotRef, ok := got.ThemeColor(); !ok || gotRef != ref {
+		t.Fatalf("theme color = %+v, %v; want %+v", gotRef, ok, ref)
+	}
+	if font := got.Font(); font.Theme != domain.ThemeFontMajor || font.Name != "Calibri Light" {
+		t.Fatalf("unexpected font: %+v", font)
+	}
+}
+
 func TestReconstructRunContentExtensions(t *testing.T) {
 	doc := core.NewDocument()
 	para, err := doc.AddParagraph()
@@ -913,3 +955,825 @@ func TestReconstructDocumentTable(t *testing.T) {
 		}
 	}
 }
+
+func roundTripDocument(t *testing.T, doc domain.Document) domain.Document {
+	t.Helper()
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	pkg, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+
+	parsed, err := ParsePackage(pkg)
+	if err != nil {
+		t.Fatalf("ParsePackage: %v", err)
+	}
+
+	reconstructed, err := ReconstructDocument(parsed)
+	if err != nil {
+		t.Fatalf("ReconstructDocument: %v", err)
+	}
+	return reconstructed
+}
+
+func TestCaptionNumbersContinueInOpenedDocument(t *testing.T) {
+	doc := core.NewDocument()
+	first, _ := doc.AddTable(1, 1)
+	if _, err := first.AddCaption("Table", "First", domain.CaptionAbove); err != nil {
+		t.Fatalf("AddCaption: %v", err)
+	}
+	_, _ = doc.AddTable(1, 1)
+
+	opened := roundTripDocument(t, doc)
+	caption, err := opened.Tables()[1].AddCaption("Table", "Second", domain.CaptionAbove)
+	if err != nil {
+		t.Fatalf("AddCaption: %v", err)
+	}
+	fields := caption.Runs()[1].(interface{ Fields() []domain.Field }).Fields()
+	if len(fields) != 1 || fields[0].Result() != "2" {
+		t.Errorf("caption added to the opened document numbered %v, want 2", fields)
+	}
+}
+
+func TestReconstructFloatingTableAndCaption(t *testing.T) {
+	doc := core.NewDocument()
+	table, err := doc.AddTable(1, 1)
+	if err != nil {
+		t.Fatalf("AddTable: %v", err)
+	}
+
+	pos := domain.TablePosition{
+		HorizontalAnchor: domain.TableAnchorMargin,
+		VerticalAnchor:   domain.TableAnchorPage,
+		X:                1440,
+		Y:                720,
+		LeftFromText:     180,
+		RightFromText:    180,
+		TopFromText:      90,
+		BottomFromText:   90,
+		AllowOverlap:     true,
+	}
+	if err := table.SetPosition(pos); err != nil {
+		t.Fatalf("SetPosition: %v", err)
+	}
+	if _, err := table.AddCaption("Table", "Totals", domain.CaptionAbove); err != nil {
+		t.Fatalf("AddCaption: %v", err)
+	}
+
+	reconstructed := roundTripDocument(t, doc)
+
+	tables := reconstructed.Tables()
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(tables))
+	}
+	got, ok := tables[0].Position()
+	if !ok {
+		t.Fatal("expected floating position to survive round-trip")
+	}
+	if got != pos {
+		t.Errorf("Position() = %+v, want %+v", got, pos)
+	}
+
+	blocks := reconstructed.Blocks()
+	if len(blocks) < 2 || blocks[0].Paragraph == nil || blocks[1].Table == nil {
+		t.Fatal("expected caption paragraph before the table")
+	}
+	if text := blocks[0].Paragraph.Text(); !strings.Contains(text, "Table") || !strings.Contains(text, "Totals") {
+		t.Errorf("unexpected caption text %q", text)
+	}
+	if styled, ok := blocks[0].Paragraph.(interface{ StyleName() string }); !ok || styled.StyleName() != domain.StyleIDCaption {
+		t.Error("expected caption style to survive round-trip")
+	}
+}
+
+func TestReconstructTableMergesAndCellProperties(t *testing.T) {
+	doc := core.NewDocument()
+	table, err := doc.AddTable(3, 3)
+	if err != nil {
+		t.Fatalf("AddTable: %v", err)
+	}
+	if err := table.SetStyle(domain.TableStyleGrid); err != nil {
+		t.Fatalf("SetStyle: %v", err)
+	}
+	if err := table.SetWidth(domain.TableWidth{Type: domain.WidthPct, Value: 4000}); err != nil {
+		t.Fatalf("SetWidth: %v", err)
+	}
+	if err := table.SetAlignment(domain.AlignmentCenter); err != nil {
+		t.Fatalf("SetAlignment: %v", err)
+	}
+
+	row0, _ := table.Row(0)
+	if err := row0.SetHeight(600); err != nil {
+		t.Fatalf("SetHeight: %v", err)
+	}
+	header, _ := row0.Cell(0)
+	if err := header.Merge(2, 1); err != nil {
+		t.Fatalf("Merge header: %v", err)
+	}
+	para, _ := header.AddParagraph()
+	run, _ := para.AddRun()
+	run.SetText("Header")
+
+	side, _ := row0.Cell(2)
+	if err := side.Merge(1, 3); err != nil {
+		t.Fatalf("Merge side: %v", err)
+	}
+	if err := side.SetShading(domain.Color{R: 0xDD, G: 0xEE, B: 0xFF}); err != nil {
+		t.Fatalf("SetShading: %v", err)
+	}
+	if err := side.SetVerticalAlignment(domain.VerticalAlignCenter); err != nil {
+		t.Fatalf("SetVerticalAlignment: %v", err)
+	}
+	border := domain.BorderStyle{Style: domain.BorderDouble, Width: 8, Color: domain.Color{R: 0xFF}}
+	if err := side.SetBorders(domain.TableBorders{Bottom: border}); err != nil {
+		t.Fatalf("SetBorders: %v", err)
+	}
+	if err := side.SetWidth(2000); err != nil {
+		t.Fatalf("SetWidth: %v", err)
+	}
+
+	reconstructed := roundTripDocument(t, doc)
+	tables := reconstructed.Tables()
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 table, got %d", len(tables))
+	}
+	got := tables[0]
+
+	if got.RowCount() != 3 || got.ColumnCount() != 3 {
+		t.Fatalf("dimensions = %dx%d, want 3x3", got.RowCount(), got.ColumnCount())
+	}
+	if got.Style().Name != domain.StyleIDTableGrid {
+		t.Errorf("Style() = %q", got.Style().Name)
+	}
+	if w := got.Width(); w.Type != domain.WidthPct || w.Value != 4000 {
+		t.Errorf("Width() = %+v", w)
+	}
+	if got.Alignment() != domain.AlignmentCenter {
+		t.Errorf("Alignment() = %v", got.Alignment())
+	}
+
+	grid := got.Grid()
+	if info := grid[0][1]; info.OriginRow != 0 || info.OriginCol != 0 || info.ColSpan != 2 {
+		t.Errorf("grid[0][1] = %+v, want part of the 2-column header", info)
+	}
+	if info := grid[2][2]; info.OriginRow != 0 || info.OriginCol != 2 || info.RowSpan != 3 {
+		t.Errorf("grid[2][2] = %+v, want part of the 3-row side cell", info)
+	}
+	if info := grid[1][0]; !info.IsOrigin(1, 0) || info.RowSpan != 1 || info.ColSpan != 1 {
+		t.Errorf("grid[1][0] = %+v, want an unmerged cell", info)
+	}
+
+	gotRow0, _ := got.Row(0)
+	if gotRow0.Height() != 600 {
+		t.Errorf("row height = %d, want 600", gotRow0.Height())
+	}
+	gotHeader, _ := gotRow0.Cell(0)
+	if len(gotHeader.Paragraphs()) != 1 || gotHeader.Paragraphs()[0].Text() != "Header" {
+		t.Error("expected header text on the merge origin")
+	}
+
+	gotSide, _ := gotRow0.Cell(2)
+	if gotSide.Shading() != (domain.Color{R: 0xDD, G: 0xEE, B: 0xFF}) {
+		t.Errorf("Shading() = %+v", gotSide.Shading())
+	}
+	if gotSide.VerticalAlignment() != domain.VerticalAlignCenter {
+		t.Errorf("VerticalAlignment() = %v", gotSide.VerticalAlignment())
+	}
+	if gotSide.Borders().Bottom != border {
+		t.Errorf("Borders().Bottom = %+v, want %+v", gotSide.Borders().Bottom, border)
+	}
+	if gotSide.Borders().Top.Style != domain.BorderNone {
+		t.Error("unset borders should stay empty")
+	}
+	if gotSide.Width() != 2000 {
+		t.Errorf("Width() = %d, want 2000", gotSide.Width())
+	}
+}
+
+func TestReconstructNestedTable(t *testing.T) {
+	doc := core.NewDocument()
+	table, err := doc.AddTable(1, 2)
+	if err != nil {
+		t.Fatalf("AddTable: %v", err)
+	}
+	row, _ := table.Row(0)
+	outer, _ := row.Cell(1)
+	nested, err := outer.AddTable(2, 2)
+	if err != nil {
+		t.Fatalf("AddTable nested: %v", err)
+	}
+	nestedRow, _ := nested.Row(1)
+	inner, _ := nestedRow.Cell(1)
+	para, _ := inner.AddParagraph()
+	run, _ := para.AddRun()
+	run.SetText("inner")
+
+	// Empty paragraphs the user wrote next to a nested table are content.
+	first, _ := row.Cell(0)
+	intro, _ := first.AddParagraph()
+	introRun, _ := intro.AddRun()
+	introRun.SetText("Intro")
+	_, _ = first.AddParagraph()
+	_, _ = first.AddTable(1, 1)
+
+	if err := row.(interface{ SetExactHeight(int) error }).SetExactHeight(720); err != nil {
+		t.Fatalf("SetExactHeight: %v", err)
+	}
+	if err := nestedRow.SetHeight(400); err != nil {
+		t.Fatalf("SetHeight: %v", err)
+	}
+
+	reconstructed := doc
+	for pass := 0; pass < 2; pass++ {
+		reconstructed = roundTripDocument(t, reconstructed)
+	}
+
+	tables := reconstructed.Tables()
+	if len(tables) != 1 {
+		t.Fatalf("expected 1 top-level table, got %d", len(tables))
+	}
+	gotRow, _ := tables[0].Row(0)
+	gotOuter, _ := gotRow.Cell(1)
+	if len(gotOuter.Tables()) != 1 {
+		t.Fatalf("expected nested table, got %d", len(gotOuter.Tables()))
+	}
+	if n := len(gotOuter.Paragraphs()); n != 0 {
+		t.Errorf("placeholder paragraphs should not accumulate, got %d", n)
+	}
+	gotFirst, _ := gotRow.Cell(0)
+	if paras := gotFirst.Paragraphs(); len(paras) != 2 || paras[0].Text() != "Intro" || paras[1].Text() != "" {
+		t.Errorf("cell paragraphs = %d, want Intro and an empty paragraph kept", len(paras))
+	}
+	if exact, ok := gotRow.(interface{ ExactHeight() bool }); !ok || !exact.ExactHeight() || gotRow.Height() != 720 {
+		t.Errorf("row height = %d, want exactly 720", gotRow.Height())
+	}
+
+	gotNested := gotOuter.Tables()[0]
+	if gotNested.RowCount() != 2 || gotNested.ColumnCount() != 2 {
+		t.Fatalf("nested dimensions = %dx%d, want 2x2", gotNested.RowCount(), gotNested.ColumnCount())
+	}
+	gotNestedRow, _ := gotNested.Row(1)
+	if exact := gotNestedRow.(interface{ ExactHeight() bool }); exact.ExactHeight() || gotNestedRow.Height() != 400 {
+		t.Errorf("nested row height = %d exact %v, want at least 400", gotNestedRow.Height(), exact.ExactHeight())
+	}
+	gotInner, _ := gotNestedRow.Cell(1)
+	if len(gotInner.Paragraphs()) != 1 || gotInner.Paragraphs()[0].Text() != "inner" {
+		t.Error("expected nested cell text to survive round-trip")
+	}
+}
+
+func TestReconstructShapes(t *testing.T) {
+	doc := core.NewDocument()
+	para, err := doc.AddParagraph()
+	if err != nil {
+		t.Fatalf("AddParagraph: %v", err)
+	}
+
+	box, err := para.AddTextBox(domain.NewImageSizeInches(2, 1), domain.ImagePosition{
+		Type:     domain.ImagePositionFloating,
+		HAlign:   domain.HAlignRight,
+		VAlign:   domain.VAlignTop,
+		WrapText: domain.WrapSquare,
+	})
+	if err != nil {
+		t.Fatalf("AddTextBox: %v", err)
+	}
+	for _, text := range []string{"Sidebar", "Second line"} {
+		inner, _ := box.AddParagraph()
+		run, _ := inner.AddRun()
+		_ = run.SetText(text)
+	}
+
+	callout, err := para.AddShape(domain.ShapeCallout, domain.NewImageSize(120, 60), domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddShape: %v", err)
+	}
+	_ = callout.SetFill(domain.Color{R: 0xFF, G: 0xC0, B: 0x00})
+	_ = callout.SetOutline(domain.ShapeOutline{Color: domain.ColorRed, Width: 2})
+	_ = callout.SetTextAnchor(domain.VAlignBottom)
+	_ = callout.SetDescription("New!")
+
+	ellipse, _ := para.AddShape(domain.ShapeEllipse, domain.NewImageSize(40, 40), domain.ImagePosition{})
+	ellipse.ClearFill()
+	if _, err := para.AddShape(domain.ShapeArrow, domain.NewImageSize(100, 0), domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddShape arrow: %v", err)
+	}
+
+	check := func(t *testing.T, got domain.Document) {
+		t.Helper()
+		shapes := got.Paragraphs()[0].Shapes()
+		if len(shapes) != 4 {
+			t.Fatalf("expected 4 shapes, got %d", len(shapes))
+		}
+
+		gotBox := shapes[0]
+		if gotBox.Type() != domain.ShapeTextBox || gotBox.Size() != box.Size() || gotBox.Position().HAlign != domain.HAlignRight {
+			t.Errorf("unexpected text box %s %+v %+v", gotBox.Type(), gotBox.Size(), gotBox.Position())
+		}
+		paras := gotBox.Paragraphs()
+		if len(paras) != 2 || paras[0].Text() != "Sidebar" || paras[1].Text() != "Second line" {
+			t.Errorf("unexpected text box paragraphs %v", paras)
+		}
+
+		gotCallout := shapes[1]
+		if fill, ok := gotCallout.Fill(); gotCallout.Type() != domain.ShapeCallout || !ok || fill != (domain.Color{R: 0xFF, G: 0xC0, B: 0x00}) {
+			t.Errorf("unexpected callout %s fill %v", gotCallout.Type(), fill)
+		}
+		if gotCallout.Outline() != (domain.ShapeOutline{Color: domain.ColorRed, Width: 2}) || gotCallout.TextAnchor() != domain.VAlignBottom || gotCallout.Description() != "New!" {
+			t.Errorf("unexpected callout formatting %+v %s %q", gotCallout.Outline(), gotCallout.TextAnchor(), gotCallout.Description())
+		}
+		if _, ok := shapes[2].Fill(); shapes[2].Type() != domain.ShapeEllipse || ok {
+			t.Errorf("expected an unfilled ellipse, got %s", shapes[2].Type())
+		}
+		if shapes[3].Type() != domain.ShapeArrow || shapes[3].Size().HeightEMU != 0 {
+			t.Errorf("unexpected arrow %s %+v", shapes[3].Type(), shapes[3].Size())
+		}
+	}
+
+	check(t, roundTripDocument(t, doc))
+
+	// Word wraps shapes in mc:AlternateContent with a VML fallback.
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	pkg, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+	main := string(pkg.MainDocument)
+	main = strings.ReplaceAll(main, "<w:drawing>", `<mc:AlternateContent xmlns:mc="http://schemas.openxmlformats.org/markup-compatibility/2006"><mc:Choice Requires="wps"><w:drawing>`)
+	main = strings.ReplaceAll(main, "</w:drawing>", `</w:drawing></mc:Choice><mc:Fallback><w:pict/></mc:Fallback></mc:AlternateContent>`)
+	if !strings.Contains(main, "mc:Choice") {
+		t.Fatal("expected drawings to wrap")
+	}
+	pkg.MainDocument = []byte(main)
+	parsed, err := ParsePackage(pkg)
+	if err != nil {
+		t.Fatalf("ParsePackage: %v", err)
+	}
+	reconstructed, err := ReconstructDocument(parsed)
+	if err != nil {
+		t.Fatalf("ReconstructDocument: %v", err)
+	}
+	check(t, reconstructed)
+}
+
+func TestReconstructEquations(t *testing.T) {
+	doc := core.NewDocument()
+	para, _ := doc.AddParagraph()
+	run, _ := para.AddRun()
+	_ = run.SetText("Energy ")
+	inline, err := para.AddEquation(domain.MathScript{Base: domain.MathText("x"), Sup: domain.MathText("2")}, domain.EquationInline)
+	if err != nil {
+		t.Fatalf("AddEquation: %v", err)
+	}
+	tail, _ := para.AddRun()
+	_ = tail.SetText(" grows.")
+
+	displayPara, _ := doc.AddParagraph()
+	display, err := displayPara.AddEquation(domain.MathLaTeX(`\int_0^1 \frac{\sqrt{x}}{1+x} \, dx`), domain.EquationDisplay)
+	if err != nil {
+		t.Fatalf("AddEquation LaTeX: %v", err)
+	}
+
+	check := func(t *testing.T, got domain.Document) {
+		t.Helper()
+		paras := got.Paragraphs()
+		eqs := paras[0].Equations()
+		if len(eqs) != 1 || eqs[0].Mode() != domain.EquationInline || eqs[0].OMML() != inline.OMML() {
+			t.Fatalf("unexpected inline equations %v", eqs)
+		}
+		if runs := paras[0].Runs(); len(runs) != 3 || runs[2].Text() != " grows." {
+			t.Errorf("equation should keep its place between runs")
+		}
+		eqs = paras[1].Equations()
+		if len(eqs) != 1 || eqs[0].Mode() != domain.EquationDisplay || eqs[0].OMML() != display.OMML() {
+			t.Fatalf("unexpected display equation %v", eqs)
+		}
+	}
+	once := roundTripDocument(t, doc)
+	check(t, once)
+	check(t, roundTripDocument(t, once))
+
+	// Word declares the math namespace on the document and formats runs.
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	pkg, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+	main := strings.Replace(string(pkg.MainDocument), "<w:document ", `<w:document xmlns:m="http://schemas.openxmlformats.org/officeDocument/2006/math" `, 1)
+	main = strings.Replace(main, "</w:p>", `<m:oMath><m:r><w:rPr><w:rFonts w:ascii="Cambria Math"/></w:rPr><m:t>z</m:t></m:r></m:oMath></w:p>`, 1)
+	pkg.MainDocument = []byte(main)
+	parsed, err := ParsePackage(pkg)
+	if err != nil {
+		t.Fatalf("ParsePackage: %v", err)
+	}
+	reconstructed, err := ReconstructDocument(parsed)
+	if err != nil {
+		t.Fatalf("ReconstructDocument: %v", err)
+	}
+	eqs := reconstructed.Paragraphs()[0].Equations()
+	if len(eqs) != 2 || !strings.Contains(eqs[1].OMML(), `<m:r><w:rPr><w:rFonts w:ascii="Cambria Math"></w:rFonts></w:rPr><m:t>z</m:t></m:r>`) {
+		t.Fatalf("unexpected Word equation %v", eqs)
+	}
+}
+
+func TestReconstructEmbeddedObjectsAndAltChunks(t *testing.T) {
+	doc := core.NewDocument()
+	para, _ := doc.AddParagraph()
+	run, _ := para.AddRun()
+	_ = run.SetText("Sources: ")
+	csv := []byte("region,total\nnorth,12\n")
+	if _, err := para.AddEmbeddedObject("totals.csv", csv, domain.EmbedOptions{}); err != nil {
+		t.Fatalf("AddEmbeddedObject csv: %v", err)
+	}
+	xlsx := []byte("PK\x03\x04 workbook")
+	if _, err := para.AddEmbeddedObject("totals.xlsx", xlsx, domain.EmbedOptions{Size: domain.NewImageSize(96, 96)}); err != nil {
+		t.Fatalf("AddEmbeddedObject xlsx: %v", err)
+	}
+	html := []byte("<p>Imported <b>HTML</b></p>")
+	if err := doc.InsertAltChunk(html); err != nil {
+		t.Fatalf("InsertAltChunk: %v", err)
+	}
+
+	check := func(t *testing.T, got domain.Document) {
+		t.Helper()
+		objects := got.EmbeddedObjects()
+		if len(objects) != 2 {
+			t.Fatalf("embedded objects = %d, want 2", len(objects))
+		}
+		if objects[0].FileName() != "totals.csv" || objects[0].ProgID() != "Package" || !bytes.Equal(objects[0].Data(), csv) {
+			t.Errorf("package object = %s %s %q", objects[0].FileName(), objects[0].ProgID(), objects[0].Data())
+		}
+		if objects[1].ProgID() != "Excel.Sheet.12" || !bytes.Equal(objects[1].Data(), xlsx) || objects[1].Size().WidthPx != 96 {
+			t.Errorf("workbook object = %s %q %+v", objects[1].ProgID(), objects[1].Data(), objects[1].Size())
+		}
+		if len(objects[0].Icon()) == 0 {
+			t.Error("embedded object lost its icon")
+		}
+		blocks := got.Blocks()
+		last := blocks[len(blocks)-1]
+		if last.AltChunk == nil || last.AltChunk.Format != domain.AltChunkHTML || !bytes.Equal(last.AltChunk.Data, html) {
+			t.Fatalf("unexpected alt chunk block %+v", last)
+		}
+	}
+	once := roundTripDocument(t, doc)
+	check(t, once)
+	check(t, roundTripDocument(t, once))
+}
+
+func TestBuildFieldFromInstructionPageFields(t *testing.T) {
+	tests := map[string]domain.FieldType{
+		`PAGE`:               domain.FieldTypePageNumber,
+		`PAGEREF _Toc3 \h`:   domain.FieldTypePageRef,
+		` sectionpages `:     domain.FieldTypeSectionPages,
+		`NUMPAGES \* Arabic`: domain.FieldTypePageCount,
+	}
+	for instr, want := range tests {
+		field, err := buildFieldFromInstruction(instr)
+		if err != nil || field == nil || field.Type() != want {
+			t.Errorf("buildFieldFromInstruction(%q) = %v, %v; want type %v", instr, field, err, want)
+		}
+	}
+}
+
+func TestReconstructCharts(t *testing.T) {
+	column := domain.ChartSpec{
+		Type:       domain.ChartColumn,
+		Title:      "Quarterly Sales",
+		Categories: []string{"Q1", "Q2", "Q3"},
+		Series: []domain.ChartSeries{
+			{Name: "2024", Values: []float64{10, 12.5, 9}},
+			{Name: "2025", Values: []float64{11, 14, 15}},
+		},
+		XAxis:      domain.ChartAxis{Title: "Quarter"},
+		YAxis:      domain.ChartAxis{Min: 0, Max: 20, NumberFormat: "#,##0", Gridlines: true},
+		Legend:     domain.LegendBottom,
+		DataLabels: true,
+		Colors:     []domain.Color{domain.ColorRed, domain.ColorBlue},
+	}
+	scatter := domain.ChartSpec{
+		Type:   domain.ChartScatter,
+		Series: []domain.ChartSeries{{Name: "Fit", XValues: []float64{1, 2, 3}, Values: []float64{2, 4, 8}}},
+		XAxis:  domain.ChartAxis{Hidden: true},
+		Legend: domain.LegendNone,
+		Colors: []domain.Color{domain.ColorGreen},
+	}
+
+	doc := core.NewDocument()
+	para, _ := doc.AddParagraph()
+	chart, err := para.AddChart(column, domain.NewImageSize(480, 240), domain.ImagePosition{})
+	if err != nil {
+		t.Fatalf("AddChart: %v", err)
+	}
+	_ = chart.SetDescription("Sales by quarter")
+	para, _ = doc.AddParagraph()
+	if _, err := para.AddChart(scatter, domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddChart: %v", err)
+	}
+
+	check := func(t *testing.T, got domain.Document) {
+		t.Helper()
+		var charts []domain.Chart
+		for _, para := range got.Paragraphs() {
+			charts = append(charts, para.Charts()...)
+		}
+		if len(charts) != 2 {
+			t.Fatalf("charts = %d, want 2", len(charts))
+		}
+		if spec := charts[0].Spec(); !reflect.DeepEqual(spec, column) {
+			t.Errorf("column chart = %+v, want %+v", spec, column)
+		}
+		if charts[0].Size().WidthPx != 480 || charts[0].Description() != "Sales by quarter" {
+			t.Errorf("column chart size %+v, description %q", charts[0].Size(), charts[0].Description())
+		}
+		if spec := charts[1].Spec(); !reflect.DeepEqual(spec, scatter) {
+			t.Errorf("scatter chart = %+v, want %+v", spec, scatter)
+		}
+	}
+	once := roundTripDocument(t, doc)
+	check(t, once)
+	twice := roundTripDocument(t, once)
+	check(t, twice)
+
+	// Adding a chart after reopening must not reuse the parts of the
+	// charts read back.
+	para, _ = twice.AddParagraph()
+	if _, err := para.AddChart(scatter, domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddChart: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := twice.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	pkg, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+	rels := string(pkg.RawParts["word/_rels/document.xml.rels"])
+	if n := strings.Count(rels, constants.RelTypeChart); n != 3 {
+		t.Fatalf("document.xml.rels has %d chart relationships, want 3:\n%s", n, rels)
+	}
+	types := string(pkg.RawParts["[Content_Types].xml"])
+	for _, rel := range regexp.MustCompile(`Target="(charts/[^"]+)"`).FindAllStringSubmatch(rels, -1) {
+		name := "word/" + rel[1]
+		chartRels := string(pkg.RawParts[path.Dir(name)+"/_rels/"+path.Base(name)+".rels"])
+		workbook := regexp.MustCompile(`Target="\.\./(embeddings/[^"]+)"`).FindStringSubmatch(chartRels)
+		switch {
+		case len(pkg.RawParts[name]) == 0:
+			t.Errorf("%s is missing", name)
+		case !strings.Contains(types, `PartName="/`+name+`"`):
+			t.Errorf("%s has no content type override", name)
+		case workbook == nil || len(pkg.RawParts["word/"+workbook[1]]) == 0:
+			t.Errorf("%s has no embedded workbook:\n%s", name, chartRels)
+		}
+	}
+}
+
+func TestReconstructUnsupportedChart(t *testing.T) {
+	doc := core.NewDocument()
+	para, _ := doc.AddParagraph()
+	spec := domain.ChartSpec{
+		Type:       domain.ChartColumn,
+		Categories: []string{"A"},
+		Series:     []domain.ChartSeries{{Values: []float64{1}}},
+	}
+	if _, err := para.AddChart(spec, domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+		t.Fatalf("AddChart: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	// A 3-D chart has no equivalent in the chart model.
+	pkg, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+	for name, data := range pkg.RawParts {
+		if strings.HasPrefix(name, "word/charts/chart") {
+			pkg.RawParts[name] = bytes.ReplaceAll(data, []byte("c:barChart>"), []byte("c:bar3DChart>"))
+		}
+	}
+	parsed, err := ParsePackage(pkg)
+	if err != nil {
+		t.Fatalf("ParsePackage: %v", err)
+	}
+	got, err := ReconstructDocument(parsed)
+	if err != nil {
+		t.Fatalf("ReconstructDocument: %v", err)
+	}
+	charts := got.Paragraphs()[0].Charts()
+	if len(charts) != 1 {
+		t.Fatalf("charts = %d, want the 3-D chart kept", len(charts))
+	}
+	if spec := charts[0].Spec(); spec.Type != "" || len(spec.Series) != 0 {
+		t.Errorf("3-D chart spec = %+v, want empty", spec)
+	}
+
+	buf.Reset()
+	if _, err := got.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	saved, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+	name := "word/" + charts[0].Target()
+	for _, part := range []string{name, "word/charts/_rels/" + path.Base(name) + ".rels"} {
+		if !bytes.Equal(saved.RawParts[part], pkg.RawParts[part]) {
+			t.Errorf("%s changed on save:\n%s", part, saved.RawParts[part])
+		}
+	}
+	if rels := string(saved.RawParts["word/_rels/document.xml.rels"]); !strings.Contains(rels, constants.RelTypeChart) {
+		t.Errorf("document.xml.rels lost the 3-D chart:\n%s", rels)
+	}
+	if types := string(saved.RawParts["[Content_Types].xml"]); !strings.Contains(types, `PartName="/`+name+`"`) {
+		t.Errorf("[Content_Types].xml lost the 3-D chart:\n%s", types)
+	}
+	for part := range pkg.RawParts {
+		if strings.HasPrefix(part, "word/embeddings/") && !bytes.Equal(saved.RawParts[part], pkg.RawParts[part]) {
+			t.Errorf("%s changed on save", part)
+		}
+	}
+}
+
+func TestReconstructChartsKeepParts(t *testing.T) {
+	spec := domain.ChartSpec{
+		Type:       domain.ChartColumn,
+		Categories: []string{"A", "B"},
+		Series:     []domain.ChartSeries{{Name: "S", Values: []float64{1, 2}}},
+	}
+	doc := core.NewDocument()
+	for i := 0; i < 2; i++ {
+		para, _ := doc.AddParagraph()
+		if _, err := para.AddChart(spec, domain.ImageSize{}, domain.ImagePosition{}); err != nil {
+			t.Fatalf("AddChart: %v", err)
+		}
+	}
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	// Markup the model ignores must survive in charts left unedited.
+	const marker = `<c:roundedCorners val="1"/>`
+	pkg, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+	for name, data := range pkg.RawParts {
+		if strings.HasPrefix(name, "word/charts/chart") {
+			pkg.RawParts[name] = bytes.Replace(data, []byte(`<c:roundedCorners val="0"></c:roundedCorners>`), []byte(marker), 1)
+		}
+	}
+	parsed, err := ParsePackage(pkg)
+	if err != nil {
+		t.Fatalf("ParsePackage: %v", err)
+	}
+	got, err := ReconstructDocument(parsed)
+	if err != nil {
+		t.Fatalf("ReconstructDocument: %v", err)
+	}
+	kept := got.Paragraphs()[0].Charts()[0]
+	edited := got.Paragraphs()[1].Charts()[0]
+	changed := spec
+	changed.Series = []domain.ChartSeries{{Name: "S", Values: []float64{3, 4}}}
+	if err := edited.SetSpec(changed); err != nil {
+		t.Fatalf("SetSpec: %v", err)
+	}
+
+	buf.Reset()
+	if _, err := got.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	saved, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+	keptName := "word/" + kept.Target()
+	if !bytes.Equal(saved.RawParts[keptName], pkg.RawParts[keptName]) {
+		t.Errorf("unedited %s changed on save:\n%s", keptName, saved.RawParts[keptName])
+	}
+	editedName := "word/" + edited.Target()
+	if data := string(saved.RawParts[editedName]); strings.Contains(data, marker) || !strings.Contains(data, "<c:v>3</c:v>") {
+		t.Errorf("edited %s was not rewritten:\n%s", editedName, data)
+	}
+	relsName := "word/charts/_rels/" + path.Base(editedName) + ".rels"
+	if !bytes.Equal(saved.RawParts[relsName], pkg.RawParts[relsName]) {
+		t.Errorf("edited chart moved its workbook:\n%s", saved.RawParts[relsName])
+	}
+
+	parsed, err = ParsePackage(saved)
+	if err != nil {
+		t.Fatalf("ParsePackage: %v", err)
+	}
+	reopened, err := ReconstructDocument(parsed)
+	if err != nil {
+		t.Fatalf("ReconstructDocument: %v", err)
+	}
+	if got := reopened.Paragraphs()[1].Charts()[0].Spec(); !reflect.DeepEqual(got.Series, changed.Series) {
+		t.Errorf("edited chart series = %+v, want %+v", got.Series, changed.Series)
+	}
+}
+
+func TestReconstructStyleLineSpacingRule(t *testing.T) {
+	doc := core.NewDocument()
+	style, err := doc.StyleManager().GetStyle(domain.StyleIDHeading1)
+	if err != nil {
+		t.Fatalf("GetStyle: %v", err)
+	}
+	heading := style.(interface {
+		domain.ParagraphStyle
+		SetLineSpacingRule(domain.LineSpacingRule) error
+	})
+	if err := heading.SetLineSpacing(240); err != nil {
+		t.Fatalf("SetLineSpacing: %v", err)
+	}
+	if err := heading.SetLineSpacingRule(domain.LineSpacingExact); err != nil {
+		t.Fatalf("SetLineSpacingRule: %v", err)
+	}
+	if _, err := doc.AddParagraph(); err != nil {
+		t.Fatalf("AddParagraph: %v", err)
+	}
+
+	for i, got := range []domain.Document{roundTripDocument(t, doc), roundTripDocument(t, roundTripDocument(t, doc))} {
+		style, err := got.StyleManager().GetStyle(domain.StyleIDHeading1)
+		if err != nil {
+			t.Fatalf("GetStyle: %v", err)
+		}
+		read := style.(interface {
+			domain.ParagraphStyle
+			LineSpacingRule() domain.LineSpacingRule
+		})
+		if read.LineSpacing() != 240 || read.LineSpacingRule() != domain.LineSpacingExact {
+			t.Errorf("round trip %d: line spacing = %d rule %v, want exactly 240 twips", i+1, read.LineSpacing(), read.LineSpacingRule())
+		}
+	}
+}
+
+func TestReconstructStyleInheritsBold(t *testing.T) {
+	doc := core.NewDocument()
+	if _, err := doc.AddParagraph(); err != nil {
+		t.Fatalf("AddParagraph: %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	pkg, err := LoadPackageFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("LoadPackageFromBytes: %v", err)
+	}
+
+	// Heading2 lists no w:b and inherits it from a bold Heading1;
+	// Heading3 lists none either and is based on Normal.
+	replace := func(xml []byte, id, def string) []byte {
+		re := regexp.MustCompile(`(?s)<w:style [^>]*w:styleId="` + id + `">.*?</w:style>`)
+		return re.ReplaceAll(xml, []byte(def))
+	}
+	styles := pkg.Styles
+	styles = replace(styles, "Heading1", `<w:style w:type="paragraph" w:styleId="Heading1"><w:name w:val="heading 1"/><w:basedOn w:val="Normal"/><w:rPr><w:b/><w:i w:val="0"/></w:rPr></w:style>`)
+	styles = replace(styles, "Heading2", `<w:style w:type="paragraph" w:styleId="Heading2"><w:name w:val="heading 2"/><w:basedOn w:val="Heading1"/><w:rPr><w:i/></w:rPr></w:style>`)
+	styles = replace(styles, "Heading3", `<w:style w:type="paragraph" w:styleId="Heading3"><w:name w:val="heading 3"/><w:basedOn w:val="Normal"/></w:style>`)
+	pkg.Styles = styles
+
+	parsed, err := ParsePackage(pkg)
+	if err != nil {
+		t.Fatalf("ParsePackage: %v", err)
+	}
+	got, err := ReconstructDocument(parsed)
+	if err != nil {
+		t.Fatalf("ReconstructDocument: %v", err)
+	}
+	for _, tc := range []struct {
+		id           string
+		bold, italic bool
+	}{
+		{domain.StyleIDHeading1, true, false},
+		{domain.StyleIDHeading2, true, true},
+		{domain.StyleIDHeading3, false, false},
+	} {
+		style, err := got.StyleManager().GetStyle(tc.id)
+		if err != nil {
+			t.Fatalf("GetStyle(%s): %v", tc.id, err)
+		}
+		rs := style.(interface {
+			Bold() bool
+			Italic() bool
+		})
+		if rs.Bold() != tc.bold || rs.Italic() != tc.italic {
+			t.Errorf("%s bold, italic = %v, %v, want %v, %v", tc.id, rs.Bold(), rs.Italic(), tc.bold, tc.italic)
+		}
+	}
+}
@@ -24,6 +24,7 @@ package reader
 
 import (
 	"fmt"
+	"path"
 	"strconv"
 	"strings"
 
@@ -48,6 +49,10 @@ const (
 	opHydrateHyperlink        = "reader.hydrateHyperlink"
 	opHydrateSimpleField      = "reader.hydrateSimpleField"
 	opHydrateDrawing          = "reader.hydrateDrawing"
+	opHydrateObject           = "reader.hydrateObject"
+	opHydrateChart            = "reader.hydrateChart"
+	opHydrateFootnote         = "reader.hydrateFootnote"
+	opHydrateAltChunk         = "reader.hydrateAltChunk"
 	opBuildField              = "reader.buildFieldFromInstruction"
 	opHydrateTable            = "reader.hydrateTable"
 	opHydrateTableCell        = "reader.hydrateTableCell"
@@ -99,6 +104,13 @@ func ReconstructDocument(parsed *ParsedPackage) (domain.Document, error) {
 
 	ctx := newReconstructContext(doc, parsed, defaultSection)
 
+	if err := hydrateTheme(doc, parsed); err != nil {
+		return nil, errors.Wrap(err, opReconstructDocument)
+	}
+	if err := hydrateStyles(doc, parsed.StylesTree); err != nil {
+		return nil, errors.Wrap(err, opReconstructDocument)
+	}
+
 	if registrar, ok := doc.(interface {
 		RegisterExistingRelationship(string, string, string, string) error
 	}); ok && parsed.DocumentRelationships != nil {
@@ -135,6 +147,10 @@ func ReconstructDocument(parsed *ParsedPackage) (domain.Document, error) {
 			if err := hydrateTable(doc, child, ctx); err != nil {
 				return nil, errors.Wrap(err, opReconstructDocument)
 			}
+		case "altChunk":
+			if err := hydrateAltChunk(doc, child, ctx); err != nil {
+				return nil, errors.Wrap(err, opReconstructDocument)
+			}
 		}
 	}
 
@@ -144,9 +160,31 @@ func ReconstructDocument(parsed *ParsedPackage) (domain.Document, error) {
 		}
 	}
 
+	hydrateThumbnail(doc, parsed)
+
 	return doc, nil
 }
 
+// hydrateThumbnail keeps a JPEG preview referenced from the package
+// relationships. Other preview formats (WMF, EMF) are dropped.
+func hydrateThumbnail(doc domain.Document, parsed *ParsedPackage) {
+	if parsed.RootRelationships == nil || parsed.Package == nil {
+		return
+	}
+	for _, rel := range parsed.RootRelationships.Relationships {
+		if rel == nil || rel.Type != constants.RelTypeThumbnail {
+			continue
+		}
+		name, ok := parsed.Package.lookupPart(strings.TrimPrefix(rel.Target, "/"))
+		if !ok {
+			continue
+		}
+		if err := doc.SetThumbnail(parsed.Package.RawParts[name]); err == nil {
+			return
+		}
+	}
+}
+
 func hydrateParagraph(doc domain.Document, elem *Element, ctx *reconstructContext) error {
 	para, err := doc.AddParagraph()
 	if err != nil {
@@ -187,6 +225,19 @@ func populateParagraph(para domain.Paragraph, elem *Element, ctx *reconstructCon
 			if err := hydrateSimpleField(para, child, ctx, state); err != nil {
 				return err
 			}
+		case "oMath":
+			if err := hydrateEquation(para, child, domain.EquationInline); err != nil {
+				return err
+			}
+		case "oMathPara":
+			for _, math := range child.Children {
+				if math.Name.Local != "oMath" {
+					continue
+				}
+				if err := hydrateEquation(para, math, domain.EquationDisplay); err != nil {
+					return err
+				}
+			}
 		}
 	}
 
@@ -421,6 +472,7 @@ func hydrateRun(para domain.Paragraph, elem *Element, ctx *reconstructContext, s
 		breaks      []domain.BreakType
 		props       *Element
 		drawings    []*Element
+		objects     []*Element
 	)
 
 	for _, child := range elem.Children {
@@ -449,10 +501,21 @@ func hydrateRun(para domain.Paragraph, elem *Element, ctx *reconstructContext, s
 			props = child
 		case "drawing":
 			drawings = append(drawings, child)
+		case "object":
+			objects = append(objects, child)
+		case "footnoteReference":
+			if err := hydrateFootnote(para, child, ctx); err != nil {
+				return err
+			}
+		case "AlternateContent":
+			// Word wraps shapes in mc:AlternateContent with a VML fallback.
+			if drawing := findChild(findChild(child, "Choice"), "drawing"); drawing != nil {
+				drawings = append(drawings, drawing)
+			}
 		}
 	}
 
-	createRun := textBuilder.Len() > 0 || len(breaks) > 0 || len(extraFields) > 0 || len(drawings) > 0
+	createRun := textBuilder.Len() > 0 || len(breaks) > 0 || len(extraFields) > 0 || len(drawings) > 0 || len(objects) > 0
 	if !createRun && state != nil && state.shouldForceRun() {
 		createRun = true
 	}
@@ -513,9 +576,50 @@ func hydrateRun(para domain.Paragraph, elem *Element, ctx *reconstructContext, s
 		}
 	}
 
+	for _, object := range objects {
+		if err := hydrateObject(para, run, object, ctx); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// themeColorAliases maps the scheme names Word also accepts in
+// w:themeColor to the text and background names.
+var themeColorAliases = map[string]domain.ThemeColor{
+	"dark1":  domain.ThemeColorText1,
+	"light1": domain.ThemeColorBackground1,
+	"dark2":  domain.ThemeColorText2,
+	"light2": domain.ThemeColorBackground2,
+}
+
+// parseThemeColor reads the theme color reference of a w:color element.
+func parseThemeColor(elem *Element) (domain.ThemeColorRef, bool) {
+	val, ok := getAttr(elem, "themeColor")
+	if !ok || val == "" {
+		return domain.ThemeColorRef{}, false
+	}
+	ref := domain.ThemeColorRef{Color: domain.ThemeColor(val)}
+	if alias, ok := themeColorAliases[val]; ok {
+		ref.Color = alias
+	}
+	if _, ok := domain.DefaultDocumentTheme().Colors.Color(ref.Color); !ok {
+		return domain.ThemeColorRef{}, false
+	}
+	if v, ok := getAttr(elem, "themeShade"); ok {
+		if n, err := strconv.ParseUint(v, 16, 8); err == nil {
+			ref.Shade = uint8(n)
+		}
+	}
+	if v, ok := getAttr(elem, "themeTint"); ok {
+		if n, err := strconv.ParseUint(v, 16, 8); err == nil {
+			ref.Tint = uint8(n)
+		}
+	}
+	return ref, true
+}
+
 func applyRunProperties(run domain.Run, props *Element) error {
 	if run == nil || props == nil {
 		return nil
@@ -567,6 +671,11 @@ func applyRunProperties(run domain.Run, props *Element) error {
 				return errors.Wrap(err, opApplyRunProperties)
 			}
 		}
+		if ref, ok := parseThemeColor(colorElem); ok {
+			if err := run.SetThemeColor(ref); err != nil {
+				return errors.Wrap(err, opApplyRunProperties)
+			}
+		}
 	}
 
 	sizeVal := ""
@@ -615,6 +724,17 @@ func applyRunProperties(run domain.Run, props *Element) error {
 			changed = true
 		}
 
+		if val, ok := getAttr(fontElem, "asciiTheme"); ok {
+			switch {
+			case strings.HasPrefix(val, "major"):
+				updated.Theme = domain.ThemeFontMajor
+				changed = true
+			case strings.HasPrefix(val, "minor"):
+				updated.Theme = domain.ThemeFontMinor
+				changed = true
+			}
+		}
+
 		if changed {
 			if updated.Name == "" {
 				updated.Name = current.Name
@@ -635,6 +755,19 @@ func applyRunProperties(run domain.Run, props *Element) error {
 		}
 	}
 
+	if vertAlignElem := findChild(props, "vertAlign"); vertAlignElem != nil {
+		script := domain.ScriptBaseline
+		switch val, _ := getAttr(vertAlignElem, "val"); val {
+		case "superscript":
+			script = domain.ScriptSuperscript
+		case "subscript":
+			script = domain.ScriptSubscript
+		}
+		if err := run.SetScript(script); err != nil {
+			return errors.Wrap(err, opApplyRunProperties)
+		}
+	}
+
 	return nil
 }
 
@@ -732,6 +865,20 @@ func hydrateSimpleField(para domain.Paragraph, elem *Element, ctx *reconstructCo
 	return nil
 }
 
+// hydrateEquation keeps an m:oMath element verbatim.
+func hydrateEquation(para domain.Paragraph, elem *Element, mode domain.EquationMode) error {
+	adder, ok := para.(interface {
+		AddHydratedEquation(omml string, mode domain.EquationMode) (domain.Equation, error)
+	})
+	if !ok {
+		return nil
+	}
+	if _, err := adder.AddHydratedEquation(elem.innerXML(), mode); err != nil {
+		return errors.Wrap(err, opHydrateParagraph)
+	}
+	return nil
+}
+
 func hydrateDrawing(para domain.Paragraph, run domain.Run, elem *Element, ctx *reconstructContext) error {
 	if para == nil || run == nil || elem == nil || ctx == nil {
 		return nil
@@ -747,11 +894,29 @@ func hydrateDrawing(para domain.Paragraph, run domain.Run, elem *Element, ctx *r
 		return nil
 	}
 
+	if wsp := findDescendant(container, "wsp"); wsp != nil {
+		return hydrateShape(para, run, container, wsp, floating, ctx)
+	}
+	if data := findChild(findChild(container, "graphic"), "graphicData"); data != nil {
+		if chart := findChild(data, "chart"); chart != nil {
+			return hydrateChart(para, run, container, chart, floating, ctx)
+		}
+	}
+
 	relID := extractDrawingRelationshipID(container)
 	if relID == "" {
 		return nil
 	}
 
+	// Pictures inserted from SVG embed a bitmap fallback in the blip and the
+	// SVG itself in an asvg:svgBlip extension.
+	fallbackRelID := ""
+	if svgBlip := findDescendant(container, "svgBlip"); svgBlip != nil {
+		if svgRelID, ok := getAttr(svgBlip, "embed"); ok && svgRelID != "" {
+			fallbackRelID, relID = relID, svgRelID
+		}
+	}
+
 	target, ok := ctx.resolveRelationshipTarget(relID)
 	if !ok || target == "" {
 		return errors.Errorf(errors.ErrCodeInvalidState, opHydrateDrawing, "relationship %s missing media target", relID)
@@ -776,10 +941,26 @@ func hydrateDrawing(para domain.Paragraph, run domain.Run, elem *Element, ctx *r
 		setter.SetRelationshipID(relID)
 	}
 
+	if fallbackRelID != "" {
+		if err := hydrateSVGFallback(img, fallbackRelID, ctx); err != nil {
+			return err
+		}
+	}
+
 	if desc := extractDrawingDescription(container); desc != "" {
 		_ = img.SetDescription(desc)
 	}
 
+	applyPictureFormat(img, container)
+
+	if relID, ok := extractDrawingHyperlinkID(container); ok {
+		if url, found := ctx.resolveRelationshipTarget(relID); found && url != "" {
+			if setter, ok := img.(interface{ SetHyperlinkRelationship(url, relID string) }); ok {
+				setter.SetHyperlinkRelationship(url, relID)
+			}
+		}
+	}
+
 	if widthEMU, heightEMU := extractDrawingExtent(container); widthEMU > 0 && heightEMU > 0 {
 		size := domain.ImageSize{
 			WidthEMU:  widthEMU,
@@ -825,6 +1006,279 @@ func hydrateDrawing(para domain.Paragraph, run domain.Run, elem *Element, ctx *r
 	return nil
 }
 
+// hydrateObject restores an embedded OLE object (w:object) with its icon.
+// Linked objects have no embedded part and are skipped.
+func hydrateObject(para domain.Paragraph, run domain.Run, elem *Element, ctx *reconstructContext) error {
+	attacher, ok := para.(interface {
+		AttachHydratedEmbeddedObjectToRun(run domain.Run, obj domain.EmbeddedObject, relID, iconRelID, iconPath string) error
+	})
+	oleObject := findDescendant(elem, "OLEObject")
+	if !ok || oleObject == nil || ctx == nil {
+		return nil
+	}
+	if kind, _ := getAttr(oleObject, "Type"); kind != "" && kind != "Embed" {
+		return nil
+	}
+
+	relID, _ := getAttr(oleObject, "id")
+	target, ok := ctx.resolveRelationshipTarget(relID)
+	if !ok || target == "" {
+		return errors.Errorf(errors.ErrCodeInvalidState, opHydrateObject, "relationship %s missing embedding target", relID)
+	}
+	part, partPath, found := ctx.partFor(target)
+	if !found {
+		return errors.Errorf(errors.ErrCodeInvalidState, opHydrateObject, "unable to resolve embedded part %s", partPath)
+	}
+
+	var icon []byte
+	iconRelID, iconPath := "", ""
+	if imageData := findDescendant(elem, "imagedata"); imageData != nil {
+		if id, ok := getAttr(imageData, "id"); ok {
+			if iconTarget, ok := ctx.resolveRelationshipTarget(id); ok {
+				if media, mediaPath, found := ctx.mediaPartFor(iconTarget); found && media != nil {
+					icon, iconRelID, iconPath = media.Data, id, mediaPath
+				}
+			}
+		}
+	}
+
+	progID, _ := getAttr(oleObject, "ProgID")
+	obj := core.NewEmbeddedObjectFromPackage(strings.TrimPrefix(partPath, "word/"), progID, part, icon)
+	if shape := findChild(elem, "shape"); shape != nil {
+		style, _ := getAttr(shape, "style")
+		if width, height := parseVMLSize(style); width > 0 && height > 0 {
+			_ = obj.SetSize(domain.ImageSize{WidthEMU: width, HeightEMU: height})
+		}
+	}
+
+	if err := attacher.AttachHydratedEmbeddedObjectToRun(run, obj, relID, iconRelID, iconPath); err != nil {
+		return errors.Wrap(err, opHydrateObject)
+	}
+	return nil
+}
+
+// vmlUnits gives the EMUs per unit of VML style lengths; bare numbers
+// are pixels.
+var vmlUnits = map[string]float64{"": 9525, "px": 9525, "pt": 12700, "in": 914400, "cm": 360000, "mm": 36000}
+
+// parseVMLSize reads the width and height of a VML style in EMUs.
+func parseVMLSize(style string) (width, height int) {
+	for _, decl := range strings.Split(style, ";") {
+		name, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		unit := strings.TrimLeft(value, "0123456789.")
+		emuPerUnit, known := vmlUnits[unit]
+		n, err := strconv.ParseFloat(strings.TrimSuffix(value, unit), 64)
+		if !known || err != nil {
+			continue
+		}
+		switch strings.TrimSpace(name) {
+		case "width":
+			width = int(n*emuPerUnit + 0.5)
+		case "height":
+			height = int(n*emuPerUnit + 0.5)
+		}
+	}
+	return width, height
+}
+
+// altChunkFormats maps alternative format part content types to formats.
+var altChunkFormats = map[string]domain.AltChunkFormat{
+	constants.ContentTypeHTML:     domain.AltChunkHTML,
+	"application/xhtml+xml":       domain.AltChunkHTML,
+	"message/rfc822":              domain.AltChunkMHT,
+	constants.ContentTypeRTF:      domain.AltChunkRTF,
+	"text/rtf":                    domain.AltChunkRTF,
+	constants.ContentTypeDocument: domain.AltChunkDOCX,
+	constants.ContentTypeText:     domain.AltChunkText,
+}
+
+// hydrateAltChunk keeps a body-level w:altChunk. Chunks in formats the
+// document model cannot represent are dropped.
+func hydrateAltChunk(doc domain.Document, elem *Element, ctx *reconstructContext) error {
+	inserter, ok := doc.(interface{ InsertHydratedAltChunk(domain.AltChunk) })
+	if !ok {
+		return nil
+	}
+	relID, _ := getAttr(elem, "id")
+	target, ok := ctx.resolveRelationshipTarget(relID)
+	if !ok || target == "" {
+		return errors.Errorf(errors.ErrCodeInvalidState, opHydrateAltChunk, "relationship %s missing chunk target", relID)
+	}
+	data, partPath, found := ctx.partFor(target)
+	if !found {
+		return errors.Errorf(errors.ErrCodeInvalidState, opHydrateAltChunk, "unable to resolve chunk part %s", partPath)
+	}
+
+	format, ok := altChunkFormats[ctx.parsed.Package.contentTypeFor(partPath)]
+	if !ok {
+		return nil
+	}
+	inserter.InsertHydratedAltChunk(domain.AltChunk{
+		Format:         format,
+		Data:           data,
+		RelationshipID: relID,
+		Target:         strings.TrimPrefix(partPath, "word/"),
+	})
+	return nil
+}
+
+// hydrateShape rebuilds a text box or preset shape, including the paragraphs
+// of its text.
+func hydrateShape(para domain.Paragraph, run domain.Run, container, wsp *Element, floating bool, ctx *reconstructContext) error {
+	attacher, ok := para.(interface {
+		AttachHydratedShapeToRun(domain.Run, domain.ShapeType) (domain.Shape, error)
+	})
+	if !ok {
+		return nil
+	}
+
+	shape, err := attacher.AttachHydratedShapeToRun(run, readShapeType(wsp))
+	if err != nil {
+		return errors.Wrap(err, opHydrateDrawing)
+	}
+
+	if extent := findChild(container, "extent"); extent != nil {
+		_ = shape.SetSize(domain.ImageSize{
+			WidthEMU:  attrToInt(extent, "cx"),
+			HeightEMU: attrToInt(extent, "cy"),
+		})
+	}
+	if floating {
+		_ = shape.SetPosition(buildFloatingPosition(container))
+	}
+	if desc := extractDrawingDescription(container); desc != "" {
+		_ = shape.SetDescription(desc)
+	}
+
+	spPr := findChild(wsp, "spPr")
+	if fill := findChild(spPr, "solidFill"); fill != nil {
+		if clr, ok := readSrgbColor(fill); ok {
+			_ = shape.SetFill(clr)
+		}
+	} else if findChild(spPr, "noFill") != nil {
+		shape.ClearFill()
+	}
+
+	if ln := findChild(spPr, "ln"); ln != nil {
+		outline := shape.Outline()
+		if findChild(ln, "noFill") != nil {
+			outline.Width = 0
+		} else {
+			if _, ok := getAttr(ln, "w"); ok {
+				outline.Width = float64(attrToInt(ln, "w")) / 12700
+			}
+			if clr, ok := readSrgbColor(findChild(ln, "solidFill")); ok {
+				outline.Color = clr
+			}
+		}
+		_ = shape.SetOutline(outline)
+	}
+
+	if bodyPr := findChild(wsp, "bodyPr"); bodyPr != nil {
+		switch anchor, _ := getAttr(bodyPr, "anchor"); anchor {
+		case "t":
+			_ = shape.SetTextAnchor(domain.VAlignTop)
+		case "ctr":
+			_ = shape.SetTextAnchor(domain.VAlignCenter)
+		case "b":
+			_ = shape.SetTextAnchor(domain.VAlignBottom)
+		}
+	}
+
+	content := findDescendant(findChild(wsp, "txbx"), "txbxContent")
+	if content == nil {
+		return nil
+	}
+	for _, child := range content.Children {
+		if child == nil || child.Name.Local != "p" {
+			continue
+		}
+		inner, err := shape.AddParagraph()
+		if err != nil {
+			return errors.Wrap(err, opHydrateDrawing)
+		}
+		if err := populateParagraph(inner, child, ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// readShapeType maps a shape's preset geometry to a shape type. Unknown
+// geometries are read as rectangles.
+func readShapeType(wsp *Element) domain.ShapeType {
+	if txBox, ok := getAttr(findChild(wsp, "cNvSpPr"), "txBox"); ok && parseBoolAttr(txBox) {
+		return domain.ShapeTextBox
+	}
+
+	spPr := findChild(wsp, "spPr")
+	prst, _ := getAttr(findChild(spPr, "prstGeom"), "prst")
+	switch {
+	case prst == "roundRect":
+		return domain.ShapeRoundedRectangle
+	case prst == "ellipse":
+		return domain.ShapeEllipse
+	case prst == "line" || strings.HasPrefix(prst, "straightConnector"):
+		ln := findChild(spPr, "ln")
+		for _, end := range []*Element{findChild(ln, "headEnd"), findChild(ln, "tailEnd")} {
+			if kind, ok := getAttr(end, "type"); ok && kind != "none" {
+				return domain.ShapeArrow
+			}
+		}
+		return domain.ShapeLine
+	case strings.Contains(prst, "Callout"):
+		return domain.ShapeCallout
+	}
+	if findChild(wsp, "txbx") != nil && prst == "" {
+		return domain.ShapeTextBox
+	}
+	return domain.ShapeRectangle
+}
+
+// readSrgbColor returns the RGB color of a fill element.
+func readSrgbColor(fill *Element) (domain.Color, bool) {
+	val, ok := getAttr(findChild(fill, "srgbClr"), "val")
+	if !ok {
+		return domain.Color{}, false
+	}
+	parsed, err := pkgcolor.FromHex(val)
+	return parsed, err == nil
+}
+
+// hydrateSVGFallback attaches the bitmap referenced by relID to an SVG image.
+func hydrateSVGFallback(img domain.Image, relID string, ctx *reconstructContext) error {
+	withFallback, ok := img.(interface {
+		SetFallback([]byte) error
+		SetFallbackRelationship(relID, target string)
+	})
+	if !ok || img.Format() != domain.ImageFormatSVG {
+		return nil
+	}
+
+	target, ok := ctx.resolveRelationshipTarget(relID)
+	if !ok || target == "" {
+		return errors.Errorf(errors.ErrCodeInvalidState, opHydrateDrawing, "relationship %s missing media target", relID)
+	}
+
+	part, partPath, found := ctx.mediaPartFor(target)
+	if !found || part == nil || len(part.Data) == 0 {
+		return errors.Errorf(errors.ErrCodeInvalidState, opHydrateDrawing, "unable to resolve media part for %s", partPath)
+	}
+	if part.Path != "" {
+		partPath = part.Path
+	}
+
+	if err := withFallback.SetFallback(part.Data); err != nil {
+		return errors.Wrap(err, opHydrateDrawing)
+	}
+	withFallback.SetFallbackRelationship(relID, strings.TrimPrefix(strings.TrimPrefix(partPath, "/"), "word/"))
+	return nil
+}
+
 func extractDrawingRelationshipID(elem *Element) string {
 	if elem == nil {
 		return ""
@@ -906,6 +1360,102 @@ func extractDrawingDescription(elem *Element) string {
 	return ""
 }
 
+// extractDrawingHyperlinkID returns the relationship of the picture's click
+// hyperlink.
+func extractDrawingHyperlinkID(elem *Element) (string, bool) {
+	for _, props := range []*Element{findChild(elem, "docPr"), findDescendant(elem, "cNvPr")} {
+		if link := findChild(props, "hlinkClick"); link != nil {
+			if relID, ok := getAttr(link, "id"); ok && relID != "" {
+				return relID, true
+			}
+		}
+	}
+	return "", false
+}
+
+// applyPictureFormat restores cropping, rotation, flips, outline and effects
+// from the picture element.
+func applyPictureFormat(img domain.Image, container *Element) {
+	pic := findDescendant(container, "pic")
+	if pic == nil {
+		return
+	}
+
+	if blipFill := findChild(pic, "blipFill"); blipFill != nil {
+		if srcRect := findChild(blipFill, "srcRect"); srcRect != nil {
+			_ = img.SetCrop(domain.ImageCrop{
+				Left:   float64(attrToInt(srcRect, "l")) / 1000,
+				Top:    float64(attrToInt(srcRect, "t")) / 1000,
+				Right:  float64(attrToInt(srcRect, "r")) / 1000,
+				Bottom: float64(attrToInt(srcRect, "b")) / 1000,
+			})
+		}
+	}
+
+	spPr := findChild(pic, "spPr")
+	if xfrm := findChild(spPr, "xfrm"); xfrm != nil {
+		_ = img.SetRotation(float64(attrToInt(xfrm, "rot")) / 60000)
+		flipH, _ := getAttr(xfrm, "flipH")
+		flipV, _ := getAttr(xfrm, "flipV")
+		_ = img.SetFlip(parseBoolAttr(flipH), parseBoolAttr(flipV))
+	}
+
+	if ln := findChild(spPr, "ln"); ln != nil && findChild(ln, "noFill") == nil {
+		border := domain.ImageBorder{Width: float64(attrToInt(ln, "w")) / 12700}
+		if border.Width == 0 {
+			border.Width = 0.75 // DrawingML default line width
+		}
+		if clr := findDescendant(ln, "srgbClr"); clr != nil {
+			if val, ok := getAttr(clr, "val"); ok {
+				if parsed, err := pkgcolor.FromHex(val); err == nil {
+					border.Color = parsed
+				}
+			}
+		}
+		_ = img.SetBorder(border)
+	}
+
+	var effects domain.ImageEffects
+	if effectLst := findChild(spPr, "effectLst"); effectLst != nil {
+		if shadow := findChild(effectLst, "outerShdw"); shadow != nil {
+			effects.Shadow = domain.ShadowOuter
+			if algn, _ := getAttr(shadow, "algn"); algn == "ctr" && attrToInt(shadow, "dist") == 0 {
+				effects.Shadow = domain.ShadowCentered
+			}
+		} else if findChild(effectLst, "innerShdw") != nil {
+			effects.Shadow = domain.ShadowInner
+		}
+		if reflection := findChild(effectLst, "reflection"); reflection != nil {
+			switch endPos := attrToInt(reflection, "endPos"); {
+			case endPos <= 45000:
+				effects.Reflection = domain.ReflectionTight
+			case endPos <= 72000:
+				effects.Reflection = domain.ReflectionHalf
+			default:
+				effects.Reflection = domain.ReflectionFull
+			}
+		}
+	}
+
+	if blip := findDescendant(pic, "blip"); blip != nil {
+		if alpha := findChild(blip, "alphaModFix"); alpha != nil {
+			amt := 100000
+			if _, ok := getAttr(alpha, "amt"); ok {
+				amt = attrToInt(alpha, "amt")
+			}
+			effects.Transparency = 100 - float64(amt)/1000
+		}
+		effects.Grayscale = findChild(blip, "grayscl") != nil
+		if lum := findChild(blip, "lum"); lum != nil {
+			effects.Brightness = float64(attrToInt(lum, "bright")) / 1000
+			effects.Contrast = float64(attrToInt(lum, "contrast")) / 1000
+		}
+	}
+	if effects != (domain.ImageEffects{}) {
+		_ = img.SetEffects(effects)
+	}
+}
+
 func buildFloatingPosition(elem *Element) domain.ImagePosition {
 	pos := domain.DefaultImagePosition()
 	pos.Type = domain.ImagePositionFloating
@@ -1173,6 +1723,20 @@ func (ctx *reconstructContext) mediaPartFor(target string) (*MediaPart, string,
 	return part, normalizedPath, true
 }
 
+// partFor returns a part of the package other than media, such as an
+// embedded object, along with its normalized path.
+func (ctx *reconstructContext) partFor(target string) ([]byte, string, bool) {
+	normalizedPath := normalizeMediaPath(target)
+	if ctx == nil || ctx.parsed == nil || ctx.parsed.Package == nil {
+		return nil, normalizedPath, false
+	}
+	name, ok := ctx.parsed.Package.lookupPart(normalizedPath)
+	if !ok {
+		return nil, normalizedPath, false
+	}
+	return ctx.parsed.Package.RawParts[name], name, true
+}
+
 func newFieldState(ctx *reconstructContext) *fieldState {
 	return &fieldState{ctx: ctx}
 }
@@ -1257,6 +1821,10 @@ func buildFieldFromInstruction(instr string) (domain.Field, error) {
 	var field domain.Field
 
 	switch {
+	case strings.HasPrefix(upper, constants.FieldCodePageRef):
+		field = core.NewField(domain.FieldTypePageRef)
+	case strings.HasPrefix(upper, constants.FieldCodeSectionPages):
+		field = core.NewField(domain.FieldTypeSectionPages)
 	case strings.HasPrefix(upper, strings.ToUpper(constants.FieldCodePageNumber)):
 		field = core.NewField(domain.FieldTypePageNumber)
 	case strings.HasPrefix(upper, strings.ToUpper(constants.FieldCodeNumPages)):
@@ -1552,20 +2120,22 @@ func (ctx *reconstructContext) hydrateHeader(section domain.Section, headerType
 		return nil
 	}
 
-	return ctx.withSectionHydrationDisabled(func() error {
-		for _, child := range tree.Children {
-			if child == nil || child.Name.Local != "p" {
-				continue
-			}
-			para, err := header.AddParagraph()
-			if err != nil {
-				return errors.Wrap(err, opHydrateSectionHeader)
-			}
-			if err := populateParagraph(para, child, ctx); err != nil {
-				return err
+	return ctx.withPartRelationships(target, func() error {
+		return ctx.withSectionHydrationDisabled(func() error {
+			for _, child := range tree.Children {
+				if child == nil || child.Name.Local != "p" {
+					continue
+				}
+				para, err := header.AddParagraph()
+				if err != nil {
+					return errors.Wrap(err, opHydrateSectionHeader)
+				}
+				if err := populateParagraph(para, child, ctx); err != nil {
+					return err
+				}
 			}
-		}
-		return nil
+			return nil
+		})
 	})
 }
 
@@ -1592,23 +2162,51 @@ func (ctx *reconstructContext) hydrateFooter(section domain.Section, footerType
 		return nil
 	}
 
-	return ctx.withSectionHydrationDisabled(func() error {
-		for _, child := range tree.Children {
-			if child == nil || child.Name.Local != "p" {
-				continue
-			}
-			para, err := footer.AddParagraph()
-			if err != nil {
-				return errors.Wrap(err, opHydrateSectionFooter)
-			}
-			if err := populateParagraph(para, child, ctx); err != nil {
-				return err
+	return ctx.withPartRelationships(target, func() error {
+		return ctx.withSectionHydrationDisabled(func() error {
+			for _, child := range tree.Children {
+				if child == nil || child.Name.Local != "p" {
+					continue
+				}
+				para, err := footer.AddParagraph()
+				if err != nil {
+					return errors.Wrap(err, opHydrateSectionFooter)
+				}
+				if err := populateParagraph(para, child, ctx); err != nil {
+					return err
+				}
 			}
-		}
-		return nil
+			return nil
+		})
 	})
 }
 
+// withPartRelationships resolves relationship IDs against the .rels part of
+// target, such as a header, while fn runs. Parts without one keep resolving
+// against the document relationships.
+func (ctx *reconstructContext) withPartRelationships(target string, fn func() error) error {
+	name := normalizeMediaPath(target)
+	data, name, ok := ctx.partFor(path.Dir(name) + "/_rels/" + path.Base(name) + ".rels")
+	if !ok || len(data) == 0 {
+		return fn()
+	}
+	var rels xmlstructs.Relationships
+	if err := decodeXML(data, &rels, name); err != nil {
+		return err
+	}
+
+	relationships := make(map[string]*xmlstructs.Relationship, len(rels.Relationships))
+	for _, rel := range rels.Relationships {
+		if rel != nil && rel.ID != "" {
+			relationships[rel.ID] = rel
+		}
+	}
+	saved := ctx.relationships
+	ctx.relationships = relationships
+	defer func() { ctx.relationships = saved }()
+	return fn()
+}
+
 func (ctx *reconstructContext) withSectionHydrationDisabled(fn func() error) error {
 	if fn == nil {
 		return nil
@@ -1905,87 +2503,460 @@ func hydrateTable(doc domain.Document, elem *Element, ctx *reconstructContext) e
 		return nil
 	}
 
-	rows := make([]*Element, 0, len(elem.Children))
+	layout := readTableLayout(elem)
+	if layout == nil {
+		return nil
+	}
+
+	table, err := doc.AddTable(len(layout.rows), layout.cols)
+	if err != nil {
+		return errors.Wrap(err, opHydrateTable)
+	}
+
+	return populateTable(table, elem, layout, ctx)
+}
+
+// tableLayout maps the w:tc elements of a table onto its logical grid.
+type tableLayout struct {
+	rows []tableRowLayout
+	cols int
+}
+
+type tableRowLayout struct {
+	elem  *Element
+	cells []tableCellLayout
+}
+
+type tableCellLayout struct {
+	elem   *Element
+	col    int
+	span   int
+	vMerge domain.VerticalMergeType
+}
+
+// readTableLayout resolves the grid column of every cell, honouring
+// w:gridBefore and w:gridSpan. It returns nil for tables without cells.
+func readTableLayout(elem *Element) *tableLayout {
+	layout := &tableLayout{}
+	if grid := findChild(elem, "tblGrid"); grid != nil {
+		for _, child := range grid.Children {
+			if child != nil && child.Name.Local == "gridCol" {
+				layout.cols++
+			}
+		}
+	}
+
+	hasCells := false
 	for _, child := range elem.Children {
 		if child == nil || child.Name.Local != "tr" {
 			continue
 		}
-		rows = append(rows, child)
+
+		row := tableRowLayout{elem: child}
+		col := 0
+		if before, ok := parseIntAttr(findChild(findChild(child, "trPr"), "gridBefore"), "val"); ok && before > 0 {
+			col = before
+		}
+
+		for _, tc := range child.Children {
+			if tc == nil || tc.Name.Local != "tc" {
+				continue
+			}
+			props := findChild(tc, "tcPr")
+
+			span := 1
+			if v, ok := parseIntAttr(findChild(props, "gridSpan"), "val"); ok && v > 1 {
+				span = v
+			}
+
+			vMerge := domain.VMergeNone
+			if vm := findChild(props, "vMerge"); vm != nil {
+				vMerge = domain.VMergeContinue
+				if val, _ := getAttr(vm, "val"); val == "restart" {
+					vMerge = domain.VMergeRestart
+				}
+			}
+
+			row.cells = append(row.cells, tableCellLayout{elem: tc, col: col, span: span, vMerge: vMerge})
+			col += span
+			hasCells = true
+		}
+
+		if col > layout.cols {
+			layout.cols = col
+		}
+		layout.rows = append(layout.rows, row)
 	}
 
-	if len(rows) == 0 {
+	if !hasCells || layout.cols == 0 {
 		return nil
 	}
+	return layout
+}
 
-	rowCells := make([][]*Element, len(rows))
-	maxCols := 0
-	for idx, row := range rows {
-		cells := make([]*Element, 0, len(row.Children))
-		for _, child := range row.Children {
-			if child == nil || child.Name.Local != "tc" {
-				continue
+// rowSpan counts the rows covered by a vertical merge starting at rowIdx.
+func (l *tableLayout) rowSpan(rowIdx, col int) int {
+	span := 1
+	for r := rowIdx + 1; r < len(l.rows); r++ {
+		continued := false
+		for _, cell := range l.rows[r].cells {
+			if cell.col == col {
+				continued = cell.vMerge == domain.VMergeContinue
+				break
 			}
-			cells = append(cells, child)
 		}
-		if len(cells) > maxCols {
-			maxCols = len(cells)
+		if !continued {
+			break
 		}
-		rowCells[idx] = cells
+		span++
 	}
+	return span
+}
 
-	if maxCols == 0 {
+// applyRowHeight sets the height of a row from w:trHeight. Rows sized
+// automatically keep no height.
+func applyRowHeight(row domain.TableRow, trHeight *Element) error {
+	height, ok := parseIntAttr(trHeight, "val")
+	if !ok || height <= 0 {
 		return nil
 	}
-
-	table, err := doc.AddTable(len(rows), maxCols)
-	if err != nil {
-		return errors.Wrap(err, opHydrateTable)
+	switch rule, _ := getAttr(trHeight, "hRule"); rule {
+	case "auto":
+		return nil
+	case "exact":
+		if exact, ok := row.(interface{ SetExactHeight(int) error }); ok {
+			return exact.SetExactHeight(height)
+		}
 	}
+	return row.SetHeight(height)
+}
 
-	for i, cells := range rowCells {
+// populateTable fills a table created with the dimensions of layout.
+func populateTable(table domain.Table, elem *Element, layout *tableLayout, ctx *reconstructContext) error {
+	for i, rowLayout := range layout.rows {
 		row, err := table.Row(i)
 		if err != nil {
 			return errors.Wrap(err, opHydrateTable)
 		}
 
-		for j, cellElem := range cells {
-			if j >= table.ColumnCount() {
+		if err := applyRowHeight(row, findChild(findChild(rowLayout.elem, "trPr"), "trHeight")); err != nil {
+			return errors.Wrap(err, opHydrateTable)
+		}
+
+		for _, cellLayout := range rowLayout.cells {
+			// Continuation cells belong to the region started above.
+			if cellLayout.vMerge == domain.VMergeContinue {
 				continue
 			}
 
-			cell, err := row.Cell(j)
+			cell, err := row.Cell(cellLayout.col)
 			if err != nil {
 				return errors.Wrap(err, opHydrateTable)
 			}
+			if cell.IsHorizontallyMergedContinuation() {
+				continue
+			}
+
+			rows := 1
+			if cellLayout.vMerge == domain.VMergeRestart {
+				rows = layout.rowSpan(i, cellLayout.col)
+			}
+			if cellLayout.span > 1 || rows > 1 {
+				// Overlapping or ragged merges are kept as unmerged cells.
+				_ = cell.Merge(cellLayout.span, rows)
+			}
 
-			if err := hydrateTableCell(cell, cellElem, ctx); err != nil {
+			if err := hydrateTableCell(cell, cellLayout.elem, ctx); err != nil {
 				return err
 			}
 		}
 	}
 
+	if err := applyTableProperties(table, findChild(elem, "tblPr")); err != nil {
+		return err
+	}
+
+	return applyTablePosition(table, findChild(elem, "tblPr"))
+}
+
+func applyTableProperties(table domain.Table, props *Element) error {
+	if table == nil || props == nil {
+		return nil
+	}
+
+	if style := findChild(props, "tblStyle"); style != nil {
+		if val, ok := getAttr(style, "val"); ok && val != "" {
+			if err := table.SetStyle(domain.TableStyle{Name: val}); err != nil {
+				return errors.Wrap(err, opHydrateTable)
+			}
+		}
+	}
+
+	if width, ok := readTableWidth(findChild(props, "tblW")); ok {
+		if err := table.SetWidth(width); err != nil {
+			return errors.Wrap(err, opHydrateTable)
+		}
+	}
+
+	if jc := findChild(props, "jc"); jc != nil {
+		if val, ok := getAttr(jc, "val"); ok {
+			if align, ok := mapAlignment(val); ok {
+				if err := table.SetAlignment(align); err != nil {
+					return errors.Wrap(err, opHydrateTable)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func readTableWidth(elem *Element) (domain.TableWidth, bool) {
+	if elem == nil {
+		return domain.TableWidth{}, false
+	}
+
+	w := attrToInt(elem, "w")
+	typ, _ := getAttr(elem, "type")
+	switch typ {
+	case constants.WidthTypeDXA, "":
+		if w <= 0 {
+			return domain.TableWidth{}, false
+		}
+		return domain.TableWidth{Type: domain.WidthDXA, Value: w}, true
+	case constants.WidthTypePct:
+		return domain.TableWidth{Type: domain.WidthPct, Value: w}, true
+	default:
+		return domain.TableWidth{Type: domain.WidthAuto}, true
+	}
+}
+
+func applyTablePosition(table domain.Table, props *Element) error {
+	if table == nil || props == nil {
+		return nil
+	}
+
+	tblpPr := findChild(props, "tblpPr")
+	if tblpPr == nil {
+		return nil
+	}
+
+	pos := domain.TablePosition{
+		HorizontalAnchor: mapTableAnchor(tblpPr, "horzAnchor"),
+		VerticalAnchor:   mapTableAnchor(tblpPr, "vertAnchor"),
+		X:                attrToInt(tblpPr, "tblpX"),
+		Y:                attrToInt(tblpPr, "tblpY"),
+		LeftFromText:     attrToInt(tblpPr, "leftFromText"),
+		RightFromText:    attrToInt(tblpPr, "rightFromText"),
+		TopFromText:      attrToInt(tblpPr, "topFromText"),
+		BottomFromText:   attrToInt(tblpPr, "bottomFromText"),
+		AllowOverlap:     true,
+	}
+
+	if overlap := findChild(props, "tblOverlap"); overlap != nil {
+		if val, ok := getAttr(overlap, "val"); ok && val == constants.TableOverlapValueNever {
+			pos.AllowOverlap = false
+		}
+	}
+
+	if err := table.SetPosition(pos); err != nil {
+		return errors.Wrap(err, opHydrateTable)
+	}
 	return nil
 }
 
+func mapTableAnchor(elem *Element, name string) domain.TableAnchor {
+	val, _ := getAttr(elem, name)
+	switch val {
+	case constants.TableAnchorValueMargin:
+		return domain.TableAnchorMargin
+	case constants.TableAnchorValuePage:
+		return domain.TableAnchorPage
+	default:
+		return domain.TableAnchorText
+	}
+}
+
 func hydrateTableCell(cell domain.TableCell, elem *Element, ctx *reconstructContext) error {
 	if cell == nil || elem == nil {
 		return nil
 	}
 
+	if err := applyTableCellProperties(cell, findChild(elem, "tcPr")); err != nil {
+		return err
+	}
+
+	// A cell must end with a paragraph, so the serializer follows a final
+	// nested table with an empty one, and leads with another when the cell
+	// has no paragraphs of its own. Drop those so repeated round-trips do
+	// not keep adding blank lines; other empty paragraphs are content.
+	var content []*Element
 	for _, child := range elem.Children {
-		if child == nil || child.Name.Local != "p" {
+		if child != nil && (child.Name.Local == "p" || child.Name.Local == "tbl") {
+			content = append(content, child)
+		}
+	}
+	padding := make(map[*Element]bool)
+	if n := len(content); n >= 2 && isPaddingParagraph(content[n-1]) && content[n-2].Name.Local == "tbl" {
+		padding[content[n-1]] = true
+		paragraphs := 0
+		for _, child := range content {
+			if child.Name.Local == "p" {
+				paragraphs++
+			}
+		}
+		if paragraphs == 2 && isPaddingParagraph(content[0]) && content[1].Name.Local == "tbl" {
+			padding[content[0]] = true
+		}
+	}
+
+	for _, child := range elem.Children {
+		if child == nil {
 			continue
 		}
 
-		para, err := cell.AddParagraph()
-		if err != nil {
+		switch child.Name.Local {
+		case "p":
+			if padding[child] {
+				continue
+			}
+
+			para, err := cell.AddParagraph()
+			if err != nil {
+				return errors.Wrap(err, opHydrateTableCell)
+			}
+
+			if err := populateParagraph(para, child, ctx); err != nil {
+				return err
+			}
+
+		case "tbl":
+			layout := readTableLayout(child)
+			if layout == nil {
+				continue
+			}
+
+			nested, err := cell.AddTable(len(layout.rows), layout.cols)
+			if err != nil {
+				return errors.Wrap(err, opHydrateTableCell)
+			}
+
+			if err := populateTable(nested, child, layout, ctx); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func applyTableCellProperties(cell domain.TableCell, props *Element) error {
+	if cell == nil || props == nil {
+		return nil
+	}
+
+	if tcW := findChild(props, "tcW"); tcW != nil {
+		if typ, _ := getAttr(tcW, "type"); typ == constants.WidthTypeDXA {
+			if w := attrToInt(tcW, "w"); w > 0 {
+				if err := cell.SetWidth(w); err != nil {
+					return errors.Wrap(err, opHydrateTableCell)
+				}
+			}
+		}
+	}
+
+	if shd := findChild(props, "shd"); shd != nil {
+		if fill, ok := getAttr(shd, "fill"); ok && fill != "" && !strings.EqualFold(fill, "auto") {
+			if c, err := pkgcolor.FromHex(fill); err == nil {
+				if err := cell.SetShading(c); err != nil {
+					return errors.Wrap(err, opHydrateTableCell)
+				}
+			}
+		}
+	}
+
+	if vAlign := findChild(props, "vAlign"); vAlign != nil {
+		val, _ := getAttr(vAlign, "val")
+		align := domain.VerticalAlignTop
+		switch val {
+		case constants.VerticalAlignmentValueCenter:
+			align = domain.VerticalAlignCenter
+		case constants.VerticalAlignmentValueBottom:
+			align = domain.VerticalAlignBottom
+		}
+		if err := cell.SetVerticalAlignment(align); err != nil {
 			return errors.Wrap(err, opHydrateTableCell)
 		}
+	}
 
-		if err := populateParagraph(para, child, ctx); err != nil {
-			return err
+	if tcBorders := findChild(props, "tcBorders"); tcBorders != nil {
+		borders := domain.TableBorders{
+			Top:    readBorder(tcBorders, "top"),
+			Left:   readBorder(tcBorders, "left", "start"),
+			Bottom: readBorder(tcBorders, "bottom"),
+			Right:  readBorder(tcBorders, "right", "end"),
+		}
+		if err := cell.SetBorders(borders); err != nil {
+			return errors.Wrap(err, opHydrateTableCell)
 		}
 	}
 
 	return nil
 }
+
+// readBorder reads the first present border element among names.
+func readBorder(parent *Element, names ...string) domain.BorderStyle {
+	for _, name := range names {
+		elem := findChild(parent, name)
+		if elem == nil {
+			continue
+		}
+
+		val, _ := getAttr(elem, "val")
+		border := domain.BorderStyle{
+			Style: mapBorderLineStyle(val),
+			Width: attrToInt(elem, "sz"),
+		}
+		if hex, ok := getAttr(elem, "color"); ok && !strings.EqualFold(hex, "auto") {
+			if c, err := pkgcolor.FromHex(hex); err == nil {
+				border.Color = c
+			}
+		}
+		return border
+	}
+	return domain.BorderStyle{}
+}
+
+func mapBorderLineStyle(value string) domain.BorderLineStyle {
+	switch value {
+	case constants.BorderValueSingle:
+		return domain.BorderSingle
+	case constants.BorderValueDotted:
+		return domain.BorderDotted
+	case constants.BorderValueDashed:
+		return domain.BorderDashed
+	case constants.BorderValueDouble:
+		return domain.BorderDouble
+	case constants.BorderValueTriple:
+		return domain.BorderTriple
+	case constants.BorderValueThick:
+		return domain.BorderThick
+	default:
+		return domain.BorderNone
+	}
+}
+
+// isEmptyParagraph reports whether a w:p element has no content besides properties.
+// isPaddingParagraph reports whether elem is an empty paragraph.
+func isPaddingParagraph(elem *Element) bool {
+	return elem.Name.Local == "p" && isEmptyParagraph(elem)
+}
+
+func isEmptyParagraph(elem *Element) bool {
+	for _, child := range elem.Children {
+		if child != nil && child.Name.Local != "pPr" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,314 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package reader
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	pkgcolor "github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+const (
+	opHydrateTheme  = "reader.hydrateTheme"
+	opHydrateStyles = "reader.hydrateStyles"
+)
+
+// hydrateTheme loads word/theme/theme1.xml into the document theme so
+// theme colors and fonts resolve as they do in Word.
+func hydrateTheme(doc domain.Document, parsed *ParsedPackage) error {
+	var data []byte
+	for name, part := range parsed.ThemeParts {
+		if normalizePartName(name) == normalizePartName(constants.PathTheme) {
+			data = part
+		}
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	tree, err := parseXMLTree(data)
+	if err != nil {
+		return xmlPartError(constants.PathTheme, err)
+	}
+
+	theme := domain.DefaultDocumentTheme()
+	if name, ok := getAttr(tree, "name"); ok && name != "" {
+		theme.Name = name
+	}
+	elements := findChild(tree, "themeElements")
+	if scheme := findChild(elements, "clrScheme"); scheme != nil {
+		slots := map[string]*domain.Color{
+			"dk1": &theme.Colors.Dark1, "lt1": &theme.Colors.Light1,
+			"dk2": &theme.Colors.Dark2, "lt2": &theme.Colors.Light2,
+			"accent1": &theme.Colors.Accent1, "accent2": &theme.Colors.Accent2,
+			"accent3": &theme.Colors.Accent3, "accent4": &theme.Colors.Accent4,
+			"accent5": &theme.Colors.Accent5, "accent6": &theme.Colors.Accent6,
+			"hlink": &theme.Colors.Hyperlink, "folHlink": &theme.Colors.FollowedHyperlink,
+		}
+		for _, slot := range scheme.Children {
+			target, ok := slots[slot.Name.Local]
+			if !ok {
+				continue
+			}
+			if clr, ok := schemeSlotColor(slot); ok {
+				*target = clr
+			}
+		}
+	}
+	if fonts := findChild(elements, "fontScheme"); fonts != nil {
+		if typeface, ok := getAttr(findChild(findChild(fonts, "majorFont"), "latin"), "typeface"); ok && typeface != "" {
+			theme.MajorFont = typeface
+		}
+		if typeface, ok := getAttr(findChild(findChild(fonts, "minorFont"), "latin"), "typeface"); ok && typeface != "" {
+			theme.MinorFont = typeface
+		}
+	}
+
+	if err := doc.SetTheme(theme); err != nil {
+		return errors.Wrap(err, opHydrateTheme)
+	}
+	return nil
+}
+
+// schemeSlotColor reads an a:srgbClr value or the last value of an
+// a:sysClr system color.
+func schemeSlotColor(slot *Element) (domain.Color, bool) {
+	val, ok := getAttr(findChild(slot, "srgbClr"), "val")
+	if !ok {
+		val, ok = getAttr(findChild(slot, "sysClr"), "lastClr")
+	}
+	if !ok {
+		return domain.Color{}, false
+	}
+	clr, err := pkgcolor.FromHex(val)
+	return clr, err == nil
+}
+
+// hydrateStyles applies the formatting of styles.xml to the styles the
+// document already defines, so templates keep their look. Document
+// defaults apply to the Normal style.
+func hydrateStyles(doc domain.Document, tree *Element) error {
+	styles := doc.StyleManager()
+	if tree == nil || styles == nil {
+		return nil
+	}
+	theme := doc.Theme()
+
+	if defaults := findChild(tree, "docDefaults"); defaults != nil {
+		if normal, err := styles.GetStyle(domain.StyleIDNormal); err == nil {
+			pPr := findChild(findChild(defaults, "pPrDefault"), "pPr")
+			rPr := findChild(findChild(defaults, "rPrDefault"), "rPr")
+			if err := applyStyleProperties(normal, pPr, rPr, theme, nil); err != nil {
+				return errors.Wrap(err, opHydrateStyles)
+			}
+		}
+	}
+
+	defs := make(map[string]*Element)
+	for _, elem := range tree.Children {
+		if elem.Name.Local == "style" {
+			if id, ok := getAttr(elem, "styleId"); ok {
+				defs[id] = elem
+			}
+		}
+	}
+	defaultRPr := findChild(findChild(findChild(tree, "docDefaults"), "rPrDefault"), "rPr")
+
+	for _, elem := range tree.Children {
+		if elem.Name.Local != "style" {
+			continue
+		}
+		id, _ := getAttr(elem, "styleId")
+		style, err := styles.GetStyle(id)
+		if id == "" || err != nil {
+			continue
+		}
+		resolve := func(name string) (bool, bool) {
+			return styleOnOff(defs, defaultRPr, elem, name), true
+		}
+		if err := applyStyleProperties(style, findChild(elem, "pPr"), findChild(elem, "rPr"), theme, resolve); err != nil {
+			return errors.WrapWithContext(err, opHydrateStyles, map[string]interface{}{"styleID": id})
+		}
+	}
+	return nil
+}
+
+// applyStyleLineSpacing sets the line spacing of a style with its
+// w:lineRule. A value the style cannot measure by that rule is skipped.
+func applyStyleLineSpacing(ps domain.ParagraphStyle, line int, spacing *Element) error {
+	rule := domain.LineSpacingAuto
+	if val, ok := getAttr(spacing, "lineRule"); ok && val != "" {
+		rule = mapLineSpacingRule(val)
+	}
+	if rs, ok := ps.(interface {
+		SetLineSpacingRule(domain.LineSpacingRule) error
+	}); ok {
+		if err := rs.SetLineSpacingRule(rule); err != nil {
+			return err
+		}
+	} else if rule != domain.LineSpacingAuto {
+		return nil
+	}
+	return ps.SetLineSpacing(line)
+}
+
+// styleOnOff resolves an on/off run property such as w:b for the style
+// definition elem, following its basedOn chain to the document defaults.
+func styleOnOff(defs map[string]*Element, defaultRPr, elem *Element, name string) bool {
+	seen := make(map[*Element]bool)
+	for elem != nil && !seen[elem] {
+		seen[elem] = true
+		if val, ok := parseOnOff(findChild(findChild(elem, "rPr"), name)); ok {
+			return val
+		}
+		basedOn, _ := getAttr(findChild(elem, "basedOn"), "val")
+		elem = defs[basedOn]
+	}
+	val, _ := parseOnOff(findChild(defaultRPr, name))
+	return val
+}
+
+// applyStyleProperties applies paragraph and run properties to a style.
+// resolve, when set, gives bold and italic as the style inherits them, so
+// a built-in default is not kept where the document's definition differs;
+// otherwise only values listed in rPr apply.
+func applyStyleProperties(style domain.Style, pPr, rPr *Element, theme domain.DocumentTheme, resolve func(name string) (bool, bool)) error {
+	if ps, ok := style.(domain.ParagraphStyle); ok && pPr != nil {
+		if spacing := findChild(pPr, "spacing"); spacing != nil {
+			if n, ok := intAttr(spacing, "before"); ok {
+				if err := ps.SetSpacingBefore(n); err != nil {
+					return err
+				}
+			}
+			if n, ok := intAttr(spacing, "after"); ok {
+				if err := ps.SetSpacingAfter(n); err != nil {
+					return err
+				}
+			}
+			if n, ok := intAttr(spacing, "line"); ok {
+				if err := applyStyleLineSpacing(ps, n, spacing); err != nil {
+					return err
+				}
+			}
+		}
+		if val, ok := getAttr(findChild(pPr, "jc"), "val"); ok {
+			if align, ok := mapAlignment(val); ok {
+				if err := ps.SetAlignment(align); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if rPr == nil && resolve == nil {
+		return nil
+	}
+	onOff := resolve
+	if onOff == nil {
+		onOff = func(name string) (bool, bool) { return parseOnOff(findChild(rPr, name)) }
+	}
+
+	if rs, ok := style.(interface{ SetBold(bool) error }); ok {
+		if bold, ok := onOff("b"); ok {
+			if err := rs.SetBold(bold); err != nil {
+				return err
+			}
+		}
+	}
+	if rs, ok := style.(interface{ SetItalic(bool) error }); ok {
+		if italic, ok := onOff("i"); ok {
+			if err := rs.SetItalic(italic); err != nil {
+				return err
+			}
+		}
+	}
+	if rs, ok := style.(interface{ SetSize(int) error }); ok {
+		if n, ok := intAttr(findChild(rPr, "sz"), "val"); ok {
+			if err := rs.SetSize(n); err != nil {
+				return err
+			}
+		}
+	}
+
+	if colorElem := findChild(rPr, "color"); colorElem != nil {
+		if rs, ok := style.(interface {
+			SetColor(domain.Color) error
+			SetThemeColor(domain.ThemeColorRef) error
+		}); ok {
+			if val, ok := getAttr(colorElem, "val"); ok {
+				if clr, err := pkgcolor.FromHex(val); err == nil {
+					if err := rs.SetColor(clr); err != nil {
+						return err
+					}
+				}
+			}
+			if ref, ok := parseThemeColor(colorElem); ok {
+				if err := rs.SetThemeColor(ref); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if fontElem := findChild(rPr, "rFonts"); fontElem != nil {
+		font := style.Font()
+		if name, ok := getAttr(fontElem, "ascii"); ok && name != "" {
+			font.Name = name
+		}
+		if val, ok := getAttr(fontElem, "asciiTheme"); ok {
+			switch {
+			case strings.HasPrefix(val, "major"):
+				font.Name, font.Theme = theme.MajorFont, domain.ThemeFontMajor
+			case strings.HasPrefix(val, "minor"):
+				font.Name, font.Theme = theme.MinorFont, domain.ThemeFontMinor
+			}
+		}
+		if val, ok := getAttr(fontElem, "eastAsia"); ok {
+			font.EastAsia = val
+		}
+		if val, ok := getAttr(fontElem, "cs"); ok {
+			font.CS = val
+		}
+		if font.Name != "" {
+			if err := style.SetFont(font); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// intAttr reads an integer attribute, ignoring malformed values.
+func intAttr(elem *Element, name string) (int, bool) {
+	val, ok := getAttr(elem, name)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(val)
+	return n, err == nil
+}
@@ -0,0 +1,43 @@
+package serializer
+
+import (
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/xml"
+)
+
+// serializeFootnoteReference turns a run into the superscript number
+// that refers to a footnote.
+func serializeFootnoteReference(xmlRun *xml.Run, note domain.Footnote) {
+	if xmlRun.Properties == nil {
+		xmlRun.Properties = &xml.RunProperties{}
+	}
+	xmlRun.Properties.VertAlign = &xml.VertAlign{Val: "superscript"}
+	xmlRun.FootnoteReference = &xml.FootnoteReference{ID: note.ID()}
+	xmlRun.Text = nil
+}
+
+// SerializeFootnotes converts the footnotes of a document to the
+// footnotes.xml part. Each note starts with its own number.
+func (s *DocumentSerializer) SerializeFootnotes(notes []domain.Footnote) *xml.Footnotes {
+	part := xml.NewFootnotes()
+	for _, note := range notes {
+		xmlNote := &xml.Footnote{ID: note.ID()}
+		for i, para := range note.Paragraphs() {
+			xmlPara := s.paraSerializer.Serialize(para)
+			if i == 0 {
+				mark := &xml.Run{
+					Properties:  &xml.RunProperties{VertAlign: &xml.VertAlign{Val: "superscript"}},
+					FootnoteRef: &struct{}{},
+				}
+				space := &xml.Run{Text: &xml.Text{Space: "preserve", Content: " "}}
+				xmlPara.Elements = append([]interface{}{mark, space}, xmlPara.Elements...)
+			}
+			xmlNote.Paragraphs = append(xmlNote.Paragraphs, xmlPara)
+		}
+		if len(xmlNote.Paragraphs) == 0 {
+			xmlNote.Paragraphs = []*xml.Paragraph{{}}
+		}
+		part.Footnotes = append(part.Footnotes, xmlNote)
+	}
+	return part
+}
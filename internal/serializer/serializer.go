@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/layout"
 	"github.com/mmonterroca/docxgo/v2/internal/xml"
 	"github.com/mmonterroca/docxgo/v2/pkg/color"
 	"github.com/mmonterroca/docxgo/v2/pkg/constants"
@@ -36,6 +37,8 @@ type drawingIDProvider interface {
 // RunSerializer converts a domain.Run to xml.Run
 type RunSerializer struct {
 	idProvider drawingIDProvider
+	paragraphs *ParagraphSerializer  // Serializes text box content
+	theme      *domain.DocumentTheme // Resolves theme colors; nil uses the Office theme
 }
 
 // NewRunSerializer creates a new RunSerializer.
@@ -48,6 +51,11 @@ func (s *RunSerializer) SetDrawingIDProvider(provider drawingIDProvider) {
 	s.idProvider = provider
 }
 
+// SetTheme sets the document theme that theme colors resolve against.
+func (s *RunSerializer) SetTheme(theme domain.DocumentTheme) {
+	s.theme = &theme
+}
+
 // Serialize converts a domain.Run to xml.Run.
 func (s *RunSerializer) Serialize(run domain.Run) *xml.Run {
 	xmlRun := &xml.Run{
@@ -67,6 +75,36 @@ func (s *RunSerializer) Serialize(run domain.Run) *xml.Run {
 		}
 	}
 
+	if shapeProvider, ok := run.(interface{ Shape() domain.Shape }); ok {
+		if shape := shapeProvider.Shape(); shape != nil {
+			xmlRun.Drawing = s.serializeShape(shape)
+			xmlRun.Text = nil
+		}
+	}
+
+	if provider, ok := run.(interface{ EmbeddedObject() domain.EmbeddedObject }); ok {
+		if obj := provider.EmbeddedObject(); obj != nil {
+			xmlRun.Object = s.serializeEmbeddedObject(obj)
+		}
+	}
+
+	if chartProvider, ok := run.(interface{ Chart() domain.Chart }); ok {
+		if chart := chartProvider.Chart(); chart != nil {
+			drawingID := 1
+			if s.idProvider != nil {
+				drawingID = s.idProvider.NextDrawingID()
+			}
+			xmlRun.Drawing = xml.NewChartDrawing(chart, drawingID)
+			xmlRun.Text = nil
+		}
+	}
+
+	if provider, ok := run.(interface{ Footnote() domain.Footnote }); ok {
+		if note := provider.Footnote(); note != nil {
+			serializeFootnoteReference(xmlRun, note)
+		}
+	}
+
 	// Add breaks if any
 	if breaks := run.(interface{ Breaks() []domain.BreakType }).Breaks(); breaks != nil {
 		for _, br := range breaks {
@@ -89,6 +127,38 @@ func (s *RunSerializer) serializeDrawing(img domain.Image, drawingID int) *xml.D
 	return xml.NewInlineDrawing(img, drawingID)
 }
 
+// serializeEmbeddedObject converts an embedded object shown as an icon.
+func (s *RunSerializer) serializeEmbeddedObject(obj domain.EmbeddedObject) *xml.Object {
+	objectID := 1
+	if s.idProvider != nil {
+		objectID = s.idProvider.NextDrawingID()
+	}
+	iconRelID := ""
+	if provider, ok := obj.(interface{ IconRelationshipID() string }); ok {
+		iconRelID = provider.IconRelationshipID()
+	}
+	return xml.NewObject(obj, iconRelID, objectID)
+}
+
+// serializeShape converts a shape and the paragraphs it holds.
+func (s *RunSerializer) serializeShape(shape domain.Shape) *xml.Drawing {
+	drawingID := 1
+	if s.idProvider != nil {
+		drawingID = s.idProvider.NextDrawingID()
+	}
+
+	paragraphs := s.paragraphs
+	if paragraphs == nil {
+		paragraphs = NewParagraphSerializer()
+		paragraphs.runSerializer.idProvider = s.idProvider
+	}
+	content := make([]*xml.Paragraph, 0, len(shape.Paragraphs()))
+	for _, para := range shape.Paragraphs() {
+		content = append(content, paragraphs.SerializeAll(para)...)
+	}
+	return xml.NewShapeDrawing(shape, drawingID, content)
+}
+
 func (s *RunSerializer) serializeProperties(run domain.Run) *xml.RunProperties {
 	props := &xml.RunProperties{}
 
@@ -115,7 +185,9 @@ func (s *RunSerializer) serializeProperties(run domain.Run) *xml.RunProperties {
 	}
 
 	// Color
-	if run.Color() != domain.ColorBlack {
+	if ref, ok := run.ThemeColor(); ok {
+		props.Color = serializeThemeColor(ref, s.theme)
+	} else if run.Color() != domain.ColorBlack {
 		props.Color = &xml.Color{
 			Val: color.ToHex(run.Color()),
 		}
@@ -128,15 +200,7 @@ func (s *RunSerializer) serializeProperties(run domain.Run) *xml.RunProperties {
 	}
 
 	// Font
-	font := run.Font()
-	if font.Name != "" && font.Name != constants.DefaultFontName {
-		props.Font = &xml.Font{
-			ASCII:    font.Name,
-			HAnsi:    font.Name,
-			EastAsia: font.EastAsia,
-			CS:       font.CS,
-		}
-	}
+	props.Font = serializeFont(run.Font())
 
 	// Highlight
 	if run.Highlight() != domain.HighlightNone {
@@ -145,6 +209,14 @@ func (s *RunSerializer) serializeProperties(run domain.Run) *xml.RunProperties {
 		}
 	}
 
+	// Superscript and subscript
+	switch run.Script() {
+	case domain.ScriptSuperscript:
+		props.VertAlign = &xml.VertAlign{Val: "superscript"}
+	case domain.ScriptSubscript:
+		props.VertAlign = &xml.VertAlign{Val: "subscript"}
+	}
+
 	return props
 }
 
@@ -246,17 +318,72 @@ func (s *RunSerializer) highlightColorToString(hlColor domain.HighlightColor) st
 // ParagraphSerializer converts a domain.Paragraph to xml.Paragraph
 type ParagraphSerializer struct {
 	runSerializer *RunSerializer
+	textWidth     int // Text width (twips) where TOC page numbers are aligned
 }
 
 // NewParagraphSerializer creates a new ParagraphSerializer.
 func NewParagraphSerializer() *ParagraphSerializer {
-	return &ParagraphSerializer{
+	s := &ParagraphSerializer{
 		runSerializer: NewRunSerializer(),
+		textWidth:     layout.SectionTextWidth(nil),
 	}
+	s.runSerializer.paragraphs = s
+	return s
 }
 
-// Serialize converts a domain.Paragraph to xml.Paragraph.
+// SetTextWidth sets the text width, in twips, of subsequently serialized
+// paragraphs. TOC entries align their page numbers to it.
+func (s *ParagraphSerializer) SetTextWidth(twips int) {
+	if twips > 0 {
+		s.textWidth = twips
+	}
+}
+
+// paragraphStart, among the elements of a paragraph, starts a new w:p
+// with props, or with the properties of the paragraph when props is nil.
+// Fields whose result spans paragraphs, such as a TOC, use it.
+type paragraphStart struct {
+	props *xml.ParagraphProperties
+}
+
+// Serialize converts a domain.Paragraph to xml.Paragraph. The entries of
+// a table of contents are separated by line breaks; SerializeAll writes
+// them as paragraphs of their own.
 func (s *ParagraphSerializer) Serialize(para domain.Paragraph) *xml.Paragraph {
+	return s.serialize(para, false)
+}
+
+// SerializeAll converts a domain.Paragraph to the paragraphs it is
+// written as: one, or for a paragraph holding an updated TOC field one
+// paragraph per entry, styled TOC1 to TOC9, with the field ending in a
+// paragraph after them as Word writes it.
+func (s *ParagraphSerializer) SerializeAll(para domain.Paragraph) []*xml.Paragraph {
+	xmlPara := s.serialize(para, true)
+	var (
+		out     []*xml.Paragraph
+		current = &xml.Paragraph{Properties: xmlPara.Properties}
+	)
+	for _, element := range xmlPara.Elements {
+		start, ok := element.(paragraphStart)
+		if !ok {
+			current.Elements = append(current.Elements, element)
+			continue
+		}
+		// Runs before the field stay in the paragraph; an empty one is
+		// dropped.
+		if len(out) > 0 || len(current.Elements) > 0 {
+			out = append(out, current)
+		}
+		props := start.props
+		if props == nil {
+			props = s.serializeProperties(para)
+		}
+		current = &xml.Paragraph{Properties: props}
+	}
+	return append(out, current)
+}
+
+func (s *ParagraphSerializer) serialize(para domain.Paragraph, split bool) *xml.Paragraph {
 	xmlPara := &xml.Paragraph{
 		Properties: s.serializeProperties(para),
 		Elements:   make([]interface{}, 0, len(para.Runs())+2),
@@ -277,12 +404,20 @@ func (s *ParagraphSerializer) Serialize(para domain.Paragraph) *xml.Paragraph {
 
 	// Serialize runs - expand runs with fields into multiple XML runs
 	for _, run := range para.Runs() {
+		// Equations replace the run at paragraph level
+		if provider, ok := run.(interface{ Equation() domain.Equation }); ok {
+			if eq := provider.Equation(); eq != nil {
+				xmlPara.Elements = append(xmlPara.Elements, serializeEquation(eq))
+				continue
+			}
+		}
+
 		// Check if run has fields
 		if runWithFields, ok := run.(interface{ Fields() []domain.Field }); ok {
 			fields := runWithFields.Fields()
 			if len(fields) > 0 {
 				// Expand run with fields into multiple XML runs
-				xmlPara.Elements = append(xmlPara.Elements, s.expandRunWithFields(run, fields)...)
+				xmlPara.Elements = append(xmlPara.Elements, s.expandRunWithFields(run, fields, split)...)
 				continue
 			}
 		}
@@ -306,6 +441,22 @@ func (s *ParagraphSerializer) Serialize(para domain.Paragraph) *xml.Paragraph {
 	return xmlPara
 }
 
+// serializeEquation converts an equation to m:oMath, wrapped in
+// m:oMathPara for display equations.
+func serializeEquation(eq domain.Equation) interface{} {
+	math := &xml.OMath{XmlnsM: constants.NamespaceMath}
+	if raw, ok := eq.(interface{ RawContent() string }); ok && eq.Content() == nil {
+		math.Raw = raw.RawContent()
+	} else {
+		math = xml.NewOMath(eq.Content())
+	}
+	if eq.Mode() == domain.EquationDisplay {
+		math.XmlnsM = ""
+		return xml.NewOMathPara(math)
+	}
+	return math
+}
+
 func (s *ParagraphSerializer) expandRunWithNewlines(run domain.Run, text string) []interface{} {
 	parts := strings.Split(text, "\n")
 	if len(parts) == 0 {
@@ -358,9 +509,11 @@ func (s *ParagraphSerializer) expandRunWithNewlines(run domain.Run, text string)
 }
 
 // expandRunWithFields expands a run containing fields into XML elements while preserving formatting.
-// The returned slice may include runs, hyperlinks, and field components.
-func (s *ParagraphSerializer) expandRunWithFields(run domain.Run, fields []domain.Field) []interface{} {
+// The returned slice may include runs, hyperlinks, and field components. With split, TOC entries
+// start paragraphs of their own.
+func (s *ParagraphSerializer) expandRunWithFields(run domain.Run, fields []domain.Field, split bool) []interface{} {
 	elements := make([]interface{}, 0, len(fields)*5)
+	linked := false // The hyperlink shows the run text
 
 	for _, field := range fields {
 		wasDirty := false
@@ -422,6 +575,7 @@ func (s *ParagraphSerializer) expandRunWithFields(run domain.Run, fields []domai
 						Runs: []*xml.Run{xmlRun},
 					}
 					elements = append(elements, hyperlink)
+					linked = true
 					continue
 				}
 			}
@@ -430,6 +584,14 @@ func (s *ParagraphSerializer) expandRunWithFields(run domain.Run, fields []domai
 			// which is handled below
 		}
 
+		var entries []tocEntry
+		if split {
+			entries = tocEntries(field)
+		}
+		if len(entries) > 0 {
+			elements = append(elements, paragraphStart{props: s.tocProperties(field, entries[0])})
+		}
+
 		beginRun := &xml.Run{FieldChar: xml.NewFieldBegin()}
 		if dirtyField, ok := field.(interface{ IsDirty() bool }); ok {
 			if dirtyField.IsDirty() {
@@ -445,11 +607,32 @@ func (s *ParagraphSerializer) expandRunWithFields(run domain.Run, fields []domai
 		sepRun := &xml.Run{FieldChar: xml.NewFieldSeparate()}
 		elements = append(elements, sepRun)
 
-		resultText := field.Result()
-		if resultText != "" {
+		if len(entries) > 0 {
+			for i, entry := range entries {
+				if i > 0 {
+					elements = append(elements, paragraphStart{props: s.tocProperties(field, entry)})
+				}
+				elements = append(elements, s.tocEntryContent(entry))
+			}
+			// The field ends in a paragraph after the entries, which holds
+			// the rest of the run.
+			elements = append(elements, paragraphStart{}, &xml.Run{FieldChar: xml.NewFieldEnd()})
+			continue
+		}
+
+		// Results of several lines, such as TOC entries, are separated by
+		// line breaks.
+		lines := strings.Split(field.Result(), "\n")
+		for i, line := range lines {
+			if line == "" && len(lines) == 1 {
+				break
+			}
 			resultRun := &xml.Run{
 				Properties: s.runSerializer.serializeProperties(run),
-				Text:       &xml.Text{Content: resultText},
+				Text:       s.runSerializer.serializeTextContent(line),
+			}
+			if i < len(lines)-1 {
+				resultRun.Break = &xml.Break{}
 			}
 			elements = append(elements, resultRun)
 		}
@@ -458,13 +641,72 @@ func (s *ParagraphSerializer) expandRunWithFields(run domain.Run, fields []domai
 		elements = append(elements, endRun)
 	}
 
-	if run.Text() != "" {
+	if run.Text() != "" && !linked {
 		elements = append(elements, s.runSerializer.Serialize(run))
 	}
 
 	return elements
 }
 
+// tocEntry is a heading listed by an updated TOC field.
+type tocEntry struct {
+	level                int
+	text, page, bookmark string
+}
+
+// tocEntries returns the entries of an updated TOC field, or nil.
+func tocEntries(field domain.Field) []tocEntry {
+	provider, ok := field.(interface {
+		TOCEntries(fn func(level int, text, page, bookmark string))
+	})
+	if !ok || field.Type() != domain.FieldTypeTOC {
+		return nil
+	}
+	var entries []tocEntry
+	provider.TOCEntries(func(level int, text, page, bookmark string) {
+		entries = append(entries, tocEntry{level: level, text: text, page: page, bookmark: bookmark})
+	})
+	return entries
+}
+
+// tocProperties styles the paragraph of a TOC entry TOC1 to TOC9, with
+// its page number at a right tab stop on the text margin.
+func (s *ParagraphSerializer) tocProperties(field domain.Field, entry tocEntry) *xml.ParagraphProperties {
+	level := min(max(entry.level, 1), 9)
+	props := &xml.ParagraphProperties{
+		Style: &xml.ParagraphStyleRef{Val: fmt.Sprintf("TOC%d", level)},
+	}
+	if entry.page != "" {
+		leader := "dot"
+		if accessor, ok := field.(interface {
+			GetProperty(string) (string, bool)
+		}); ok {
+			if hide, _ := accessor.GetProperty("hideTabLeader"); hide == "true" {
+				leader = "none"
+			}
+		}
+		props.Tabs = &xml.Tabs{Tabs: []*xml.TabStop{{Val: "right", Leader: leader, Pos: s.textWidth}}}
+	}
+	return props
+}
+
+// tocEntryContent returns the text, tab and page of a TOC entry, linked
+// to the heading's bookmark.
+func (s *ParagraphSerializer) tocEntryContent(entry tocEntry) interface{} {
+	runs := []*xml.Run{{Text: s.runSerializer.serializeTextContent(entry.text)}}
+	if entry.page != "" {
+		runs = append(runs, &xml.Run{Tab: &struct{}{}}, &xml.Run{Text: s.runSerializer.serializeTextContent(entry.page)})
+	}
+	if entry.bookmark == "" {
+		elements := make([]interface{}, len(runs))
+		for i, run := range runs {
+			elements[i] = run
+		}
+		return elements
+	}
+	return &xml.Hyperlink{Anchor: entry.bookmark, Runs: runs}
+}
+
 func (s *ParagraphSerializer) serializeProperties(para domain.Paragraph) *xml.ParagraphProperties {
 	props := &xml.ParagraphProperties{}
 
@@ -605,27 +847,44 @@ func (s *ParagraphSerializer) lineSpacingRuleToString(rule domain.LineSpacingRul
 // TableSerializer converts domain tables to XML
 type TableSerializer struct {
 	paraSerializer *ParagraphSerializer
+	availableWidth int // Text width (twips) that auto and percentage tables fit into
 }
 
 // NewTableSerializer creates a new TableSerializer.
 func NewTableSerializer() *TableSerializer {
 	return &TableSerializer{
 		paraSerializer: NewParagraphSerializer(),
+		availableWidth: layout.SectionTextWidth(nil),
+	}
+}
+
+// SetAvailableWidth sets the text width, in twips, used to lay out the
+// columns of subsequently serialized tables.
+func (s *TableSerializer) SetAvailableWidth(twips int) {
+	if twips > 0 {
+		s.availableWidth = twips
+		s.paraSerializer.SetTextWidth(twips)
 	}
 }
 
 // Serialize converts a domain.Table to xml.Table.
 func (s *TableSerializer) Serialize(table domain.Table) *xml.Table {
+	return s.serializeTable(table, s.availableWidth)
+}
+
+func (s *TableSerializer) serializeTable(table domain.Table, available int) *xml.Table {
+	widths := layout.ColumnWidths(table, available)
+
 	xmlTable := &xml.Table{
 		Properties: s.serializeTableProperties(table),
-		Grid:       s.serializeGrid(table),
+		Grid:       s.serializeGrid(widths),
 		Rows:       make([]*xml.TableRow, 0, table.RowCount()),
 	}
 
 	// Serialize rows
 	for i := 0; i < table.RowCount(); i++ {
 		row, _ := table.Row(i)
-		xmlTable.Rows = append(xmlTable.Rows, s.serializeRow(row))
+		xmlTable.Rows = append(xmlTable.Rows, s.serializeRow(row, widths))
 	}
 
 	return xmlTable
@@ -658,55 +917,91 @@ func (s *TableSerializer) serializeTableProperties(table domain.Table) *xml.Tabl
 		}
 	}
 
+	// Floating position
+	if pos, ok := table.Position(); ok {
+		props.Position = &xml.TablePositionProperties{
+			LeftFromText:   pos.LeftFromText,
+			RightFromText:  pos.RightFromText,
+			TopFromText:    pos.TopFromText,
+			BottomFromText: pos.BottomFromText,
+			VertAnchor:     s.anchorToString(pos.VerticalAnchor),
+			HorzAnchor:     s.anchorToString(pos.HorizontalAnchor),
+			X:              pos.X,
+			Y:              pos.Y,
+		}
+		overlap := constants.TableOverlapValueNever
+		if pos.AllowOverlap {
+			overlap = constants.TableOverlapValueOverlap
+		}
+		props.Overlap = &xml.TableOverlap{Val: overlap}
+	}
+
 	return props
 }
 
-func (s *TableSerializer) serializeGrid(table domain.Table) *xml.TableGrid {
+func (s *TableSerializer) anchorToString(anchor domain.TableAnchor) string {
+	switch anchor {
+	case domain.TableAnchorMargin:
+		return constants.TableAnchorValueMargin
+	case domain.TableAnchorPage:
+		return constants.TableAnchorValuePage
+	default:
+		return constants.TableAnchorValueText
+	}
+}
+
+func (s *TableSerializer) serializeGrid(widths []int) *xml.TableGrid {
 	grid := &xml.TableGrid{
-		Cols: make([]*xml.GridCol, table.ColumnCount()),
+		Cols: make([]*xml.GridCol, len(widths)),
 	}
 
-	for i := 0; i < table.ColumnCount(); i++ {
-		grid.Cols[i] = &xml.GridCol{W: intPtr(0)}
+	for i, w := range widths {
+		grid.Cols[i] = &xml.GridCol{W: intPtr(w)}
 	}
 
 	return grid
 }
 
-func (s *TableSerializer) serializeRow(row domain.TableRow) *xml.TableRow {
+func (s *TableSerializer) serializeRow(row domain.TableRow, widths []int) *xml.TableRow {
 	xmlRow := &xml.TableRow{
 		Cells: make([]*xml.TableCell, 0, len(row.Cells())),
 	}
 
 	// Height
 	if row.Height() > 0 {
+		rule := "atLeast"
+		if exact, ok := row.(interface{ ExactHeight() bool }); ok && exact.ExactHeight() {
+			rule = "exact"
+		}
 		xmlRow.Properties = &xml.TableRowProperties{
 			Height: &xml.TableRowHeight{
 				Val:  row.Height(),
-				Rule: "atLeast",
+				Rule: rule,
 			},
 		}
 	}
 
 	// Serialize cells, skipping horizontal merge continuations
-	for _, cell := range row.Cells() {
+	for col, cell := range row.Cells() {
 		if cell.IsHorizontallyMergedContinuation() {
 			continue
 		}
-		xmlRow.Cells = append(xmlRow.Cells, s.serializeCell(cell))
+		xmlRow.Cells = append(xmlRow.Cells, s.serializeCell(cell, layout.CellWidth(widths, col, cell.GridSpan())))
 	}
 
 	return xmlRow
 }
 
-func (s *TableSerializer) serializeCell(cell domain.TableCell) *xml.TableCell {
+func (s *TableSerializer) serializeCell(cell domain.TableCell, width int) *xml.TableCell {
 	paragraphs := cell.Paragraphs()
 	tables := cell.Tables()
 
 	content := make([]interface{}, 0, len(paragraphs)+len(tables)+1)
 
 	for _, para := range paragraphs {
-		content = append(content, s.paraSerializer.Serialize(para))
+		for _, xmlPara := range s.paraSerializer.SerializeAll(para) {
+			content = append(content, xmlPara)
+		}
 	}
 
 	if len(tables) > 0 {
@@ -716,7 +1011,7 @@ func (s *TableSerializer) serializeCell(cell domain.TableCell) *xml.TableCell {
 		}
 
 		for _, table := range tables {
-			content = append(content, s.Serialize(table))
+			content = append(content, s.serializeTable(table, width-2*layout.DefaultCellMargin))
 		}
 
 		// Word expects a trailing empty paragraph after nested tables to keep the end-of-cell marker intact.
@@ -728,20 +1023,24 @@ func (s *TableSerializer) serializeCell(cell domain.TableCell) *xml.TableCell {
 	}
 
 	return &xml.TableCell{
-		Properties: s.serializeCellProperties(cell),
+		Properties: s.serializeCellProperties(cell, width),
 		Content:    content,
 	}
 }
 
-func (s *TableSerializer) serializeCellProperties(cell domain.TableCell) *xml.TableCellProperties {
+func (s *TableSerializer) serializeCellProperties(cell domain.TableCell, layoutWidth int) *xml.TableCellProperties {
 	props := &xml.TableCellProperties{}
 
-	// Width (Word expects tcW even for auto width)
+	// Width: explicit widths win; otherwise emit the computed layout width so
+	// viewers that honour tcW/tblGrid (LibreOffice, Google Docs) match Word.
 	widthType := constants.WidthTypeAuto
 	widthValue := 0
 	if cell.Width() > 0 {
 		widthType = constants.WidthTypeDXA
 		widthValue = cell.Width()
+	} else if layoutWidth > 0 {
+		widthType = constants.WidthTypeDXA
+		widthValue = layoutWidth
 	}
 	props.Width = &xml.TableWidth{
 		Type: widthType,
@@ -765,6 +1064,9 @@ func (s *TableSerializer) serializeCellProperties(cell domain.TableCell) *xml.Ta
 		props.VMerge = vMerge
 	}
 
+	// Borders
+	props.Borders = s.serializeCellBorders(cell.Borders())
+
 	// Vertical alignment
 	if cell.VerticalAlignment() != domain.VerticalAlignTop {
 		props.VAlign = &xml.VerticalAlign{
@@ -783,6 +1085,54 @@ func (s *TableSerializer) serializeCellProperties(cell domain.TableCell) *xml.Ta
 	return props
 }
 
+// serializeCellBorders converts cell borders; sides without a style are omitted.
+func (s *TableSerializer) serializeCellBorders(borders domain.TableBorders) *xml.TableBorders {
+	side := func(b domain.BorderStyle) *xml.Border {
+		if b.Style == domain.BorderNone {
+			return nil
+		}
+		border := &xml.Border{
+			Val: s.borderStyleToString(b.Style),
+			Sz:  b.Width,
+		}
+		if border.Sz <= 0 {
+			border.Sz = 4
+		}
+		border.Color = color.ToHex(b.Color)
+		return border
+	}
+
+	result := &xml.TableBorders{
+		Top:    side(borders.Top),
+		Left:   side(borders.Left),
+		Bottom: side(borders.Bottom),
+		Right:  side(borders.Right),
+	}
+	if result.Top == nil && result.Left == nil && result.Bottom == nil && result.Right == nil {
+		return nil
+	}
+	return result
+}
+
+func (s *TableSerializer) borderStyleToString(style domain.BorderLineStyle) string {
+	switch style {
+	case domain.BorderSingle:
+		return constants.BorderValueSingle
+	case domain.BorderDotted:
+		return constants.BorderValueDotted
+	case domain.BorderDashed:
+		return constants.BorderValueDashed
+	case domain.BorderDouble:
+		return constants.BorderValueDouble
+	case domain.BorderTriple:
+		return constants.BorderValueTriple
+	case domain.BorderThick:
+		return constants.BorderValueThick
+	default:
+		return constants.BorderValueNone
+	}
+}
+
 func (s *TableSerializer) widthTypeToString(wType domain.WidthType) string {
 	switch wType {
 	case domain.WidthAuto:
@@ -854,6 +1204,7 @@ type DocumentSerializer struct {
 	paraSerializer  *ParagraphSerializer
 	tableSerializer *TableSerializer
 	drawingCounter  int
+	theme           *domain.DocumentTheme
 }
 
 // NewDocumentSerializer creates a new DocumentSerializer.
@@ -871,6 +1222,14 @@ func NewDocumentSerializer() *DocumentSerializer {
 	return serializer
 }
 
+// SetTheme sets the document theme that theme colors in runs and styles
+// resolve against.
+func (s *DocumentSerializer) SetTheme(theme domain.DocumentTheme) {
+	s.theme = &theme
+	s.paraSerializer.runSerializer.SetTheme(theme)
+	s.tableSerializer.paraSerializer.runSerializer.SetTheme(theme)
+}
+
 // NextDrawingID returns a unique ID for drawing elements.
 func (s *DocumentSerializer) NextDrawingID() int {
 	s.drawingCounter++
@@ -884,13 +1243,31 @@ func (s *DocumentSerializer) SerializeBody(doc domain.Document) *xml.Body {
 		Content: make([]interface{}, 0, len(blocks)),
 	}
 
+	// Tables are laid out against the text width of the section they belong
+	// to; a section break closes the section that precedes it.
+	sections := doc.Sections()
+	sectionIndex := 0
+	setTableWidth := func() {
+		if sectionIndex < len(sections) {
+			width := layout.SectionTextWidth(sections[sectionIndex])
+			s.tableSerializer.SetAvailableWidth(width)
+			s.paraSerializer.SetTextWidth(width)
+		}
+	}
+	setTableWidth()
+
 	for _, block := range blocks {
 		switch {
 		case block.Paragraph != nil:
-			body.Content = append(body.Content, s.paraSerializer.Serialize(block.Paragraph))
+			for _, xmlPara := range s.paraSerializer.SerializeAll(block.Paragraph) {
+				body.Content = append(body.Content, xmlPara)
+			}
 		case block.Table != nil:
 			body.Content = append(body.Content, s.tableSerializer.Serialize(block.Table))
 		case block.SectionBreak != nil && block.SectionBreak.Section != nil:
+			sectionIndex++
+			setTableWidth()
+
 			sectPr := s.serializeSectionProperties(block.SectionBreak.Section)
 			if sectPr == nil {
 				continue
@@ -907,10 +1284,11 @@ func (s *DocumentSerializer) SerializeBody(doc domain.Document) *xml.Body {
 				},
 			}
 			body.Content = append(body.Content, para)
+		case block.AltChunk != nil:
+			body.Content = append(body.Content, &xml.AltChunk{ID: block.AltChunk.RelationshipID})
 		}
 	}
 
-	sections := doc.Sections()
 	if len(sections) > 0 {
 		if sectPr := s.serializeSectionProperties(sections[len(sections)-1]); sectPr != nil {
 			body.SectPr = sectPr
@@ -972,7 +1350,9 @@ func (s *DocumentSerializer) SerializeSectionParts(doc domain.Document) (map[str
 
 			xmlHeader := xml.NewHeader()
 			for _, para := range headerMeta.Paragraphs() {
-				xmlHeader.AddParagraph(s.paraSerializer.Serialize(para))
+				for _, xmlPara := range s.paraSerializer.SerializeAll(para) {
+					xmlHeader.AddParagraph(xmlPara)
+				}
 			}
 			headers[target] = xmlHeader
 		}
@@ -997,7 +1377,9 @@ func (s *DocumentSerializer) SerializeSectionParts(doc domain.Document) (map[str
 
 			xmlFooter := xml.NewFooter()
 			for _, para := range footerMeta.Paragraphs() {
-				xmlFooter.AddParagraph(s.paraSerializer.Serialize(para))
+				for _, xmlPara := range s.paraSerializer.SerializeAll(para) {
+					xmlFooter.AddParagraph(xmlPara)
+				}
 			}
 			footers[target] = xmlFooter
 		}
@@ -1191,6 +1573,23 @@ func (s *DocumentSerializer) serializeParagraphStyleProperties(style domain.Styl
 		}
 	}
 
+	if ps, ok := style.(interface{ LineSpacing() int }); ok {
+		rule := domain.LineSpacingAuto
+		if rs, ok := style.(interface {
+			LineSpacingRule() domain.LineSpacingRule
+		}); ok {
+			rule = rs.LineSpacingRule()
+		}
+		if line := ps.LineSpacing(); line > 0 && (line != constants.DefaultLineSpacing || rule != domain.LineSpacingAuto) {
+			if props.Spacing == nil {
+				props.Spacing = &xml.StyleSpacing{}
+			}
+			props.Spacing.Line = &line
+			props.Spacing.LineRule = *s.paraSerializer.lineSpacingRuleToString(rule)
+			hasProps = true
+		}
+	}
+
 	if ps, ok := style.(interface{ KeepNext() bool }); ok {
 		if ps.KeepNext() {
 			props.KeepNext = &struct{}{}
@@ -1236,14 +1635,8 @@ func (s *DocumentSerializer) serializeRunStyleProperties(style domain.Style) *xm
 	hasProps := false
 
 	// Font
-	font := style.Font()
-	if font.Name != "" && font.Name != constants.DefaultFontName {
-		props.Font = &xml.Font{
-			ASCII:    font.Name,
-			HAnsi:    font.Name,
-			EastAsia: font.EastAsia,
-			CS:       font.CS,
-		}
+	if font := serializeFont(style.Font()); font != nil {
+		props.Font = font
 		hasProps = true
 	}
 
@@ -1264,7 +1657,15 @@ func (s *DocumentSerializer) serializeRunStyleProperties(style domain.Style) *xm
 	}
 
 	// Color
-	if rs, ok := style.(interface{ Color() domain.Color }); ok {
+	if rs, ok := style.(interface {
+		ThemeColor() (domain.ThemeColorRef, bool)
+	}); ok {
+		if ref, ok := rs.ThemeColor(); ok {
+			props.Color = serializeThemeColor(ref, s.theme)
+			hasProps = true
+		}
+	}
+	if rs, ok := style.(interface{ Color() domain.Color }); ok && props.Color == nil {
 		color := rs.Color()
 		if color != domain.ColorBlack {
 			props.Color = &xml.Color{
@@ -19,6 +19,7 @@ package serializer_test
 
 import (
 	stdxml "encoding/xml"
+	"strings"
 	"testing"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
@@ -225,6 +226,87 @@ func TestTableSerializer(t *testing.T) {
 	}
 }
 
+func TestTableSerializer_AutofitGrid(t *testing.T) {
+	doc := core.NewDocument()
+	table, _ := doc.AddTable(1, 2)
+
+	row, _ := table.Row(0)
+	short, _ := row.Cell(0)
+	para, _ := short.AddParagraph()
+	run, _ := para.AddRun()
+	run.SetText("No.")
+	long, _ := row.Cell(1)
+	para, _ = long.AddParagraph()
+	run, _ = para.AddRun()
+	run.SetText("A considerably longer description")
+
+	ser := serializer.NewTableSerializer()
+	ser.SetAvailableWidth(9000)
+	xmlTable := ser.Serialize(table)
+
+	grid := xmlTable.Grid.Cols
+	if *grid[0].W <= 0 || *grid[1].W <= *grid[0].W {
+		t.Fatalf("expected content-based grid, got %d / %d", *grid[0].W, *grid[1].W)
+	}
+	if *grid[0].W+*grid[1].W > 9000 {
+		t.Errorf("grid should fit the available width")
+	}
+
+	for i, cell := range xmlTable.Rows[0].Cells {
+		if cell.Properties.Width.Type != "dxa" || cell.Properties.Width.W != *grid[i].W {
+			t.Errorf("cell %d tcW = %+v, want dxa %d", i, cell.Properties.Width, *grid[i].W)
+		}
+	}
+}
+
+func TestTableSerializer_FloatingPosition(t *testing.T) {
+	doc := core.NewDocument()
+	table, _ := doc.AddTable(1, 1)
+
+	ser := serializer.NewTableSerializer()
+	if props := ser.Serialize(table).Properties; props.Position != nil || props.Overlap != nil {
+		t.Fatal("inline table should not emit tblpPr or tblOverlap")
+	}
+
+	if err := table.SetPosition(domain.TablePosition{
+		HorizontalAnchor: domain.TableAnchorPage,
+		VerticalAnchor:   domain.TableAnchorMargin,
+		X:                720,
+		Y:                -360,
+		LeftFromText:     144,
+		BottomFromText:   288,
+	}); err != nil {
+		t.Fatalf("SetPosition: %v", err)
+	}
+
+	props := ser.Serialize(table).Properties
+	if props.Position == nil {
+		t.Fatal("expected tblpPr to be set")
+	}
+	want := xmlstructs.TablePositionProperties{
+		LeftFromText:   144,
+		BottomFromText: 288,
+		VertAnchor:     "margin",
+		HorzAnchor:     "page",
+		X:              720,
+		Y:              -360,
+	}
+	if *props.Position != want {
+		t.Errorf("tblpPr = %+v, want %+v", *props.Position, want)
+	}
+	if props.Overlap == nil || props.Overlap.Val != "never" {
+		t.Errorf("expected tblOverlap never, got %+v", props.Overlap)
+	}
+
+	out, err := stdxml.Marshal(props)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if !strings.Contains(string(out), `<w:tblpPr w:leftFromText="144" w:rightFromText="0" w:topFromText="0" w:bottomFromText="288" w:vertAnchor="margin" w:horzAnchor="page" w:tblpX="720" w:tblpY="-360"></w:tblpPr><w:tblOverlap w:val="never"></w:tblOverlap><w:tblW`) {
+		t.Errorf("unexpected tblPr XML: %s", out)
+	}
+}
+
 func TestDocumentSerializer(t *testing.T) {
 	doc := core.NewDocument()
 
@@ -607,3 +689,36 @@ func TestRunSerializer_WithTextBreaks(t *testing.T) {
 		t.Errorf("expected at least 3 elements (text+break+text), got %d", len(runs))
 	}
 }
+
+func TestTableSerializer_CellBorders(t *testing.T) {
+	doc := core.NewDocument()
+	table, _ := doc.AddTable(1, 1)
+	row, _ := table.Row(0)
+	cell, _ := row.Cell(0)
+
+	ser := serializer.NewTableSerializer()
+	if ser.Serialize(table).Rows[0].Cells[0].Properties.Borders != nil {
+		t.Fatal("cell without borders should not emit tcBorders")
+	}
+
+	if err := cell.SetBorders(domain.TableBorders{
+		Top:  domain.BorderStyle{Style: domain.BorderDashed, Width: 12, Color: domain.Color{B: 0xFF}},
+		Left: domain.BorderStyle{Style: domain.BorderSingle},
+	}); err != nil {
+		t.Fatalf("SetBorders: %v", err)
+	}
+
+	borders := ser.Serialize(table).Rows[0].Cells[0].Properties.Borders
+	if borders == nil || borders.Top == nil || borders.Left == nil {
+		t.Fatalf("expected top and left borders, got %+v", borders)
+	}
+	if borders.Bottom != nil || borders.Right != nil {
+		t.Error("unset sides should be omitted")
+	}
+	if borders.Top.Val != "dashed" || borders.Top.Sz != 12 || borders.Top.Color != "0000FF" {
+		t.Errorf("top border = %+v", *borders.Top)
+	}
+	if borders.Left.Sz != 4 {
+		t.Errorf("left border should default to 1/2pt, got %d", borders.Left.Sz)
+	}
+}
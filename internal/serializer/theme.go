@@ -0,0 +1,55 @@
+package serializer
+
+import (
+	"fmt"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/xml"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// serializeThemeColor converts a theme color reference to w:color. Word
+// ignores w:val when w:themeColor is present, but other readers use it, so
+// it carries the resolved color.
+func serializeThemeColor(ref domain.ThemeColorRef, theme *domain.DocumentTheme) *xml.Color {
+	scheme := domain.DefaultDocumentTheme().Colors
+	if theme != nil {
+		scheme = theme.Colors
+	}
+	value, _ := scheme.Color(ref.Color)
+	out := &xml.Color{ThemeColor: string(ref.Color)}
+	if ref.Shade != 0 {
+		value = color.Shade(value, ref.Shade)
+		out.ThemeShade = fmt.Sprintf("%02X", ref.Shade)
+	}
+	if ref.Tint != 0 {
+		value = color.Tint(value, ref.Tint)
+		out.ThemeTint = fmt.Sprintf("%02X", ref.Tint)
+	}
+	out.Val = color.ToHex(value)
+	return out
+}
+
+// serializeFont converts a font to w:rFonts, or returns nil when the
+// default font is used. Theme fonts keep the name as the fallback typeface.
+func serializeFont(font domain.Font) *xml.Font {
+	out := &xml.Font{}
+	if font.Name != "" && (font.Name != constants.DefaultFontName || font.Theme != "") {
+		out.ASCII = font.Name
+		out.HAnsi = font.Name
+	}
+	out.EastAsia = font.EastAsia
+	out.CS = font.CS
+	if font.Theme != "" {
+		prefix := string(font.Theme)
+		out.ASCIITheme = prefix + "HAnsi"
+		out.HAnsiTheme = prefix + "HAnsi"
+		out.EastAsiaTheme = prefix + "EastAsia"
+		out.CSTheme = prefix + "Bidi"
+	}
+	if out.ASCII == "" && out.ASCIITheme == "" {
+		return nil
+	}
+	return out
+}
@@ -0,0 +1,493 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package svg
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// matrix is an affine transform [a c e; b d f] as used by SVG.
+type matrix struct {
+	a, b, c, d, e, f float64
+}
+
+func identityMatrix() matrix              { return matrix{a: 1, d: 1} }
+func translateMatrix(x, y float64) matrix { return matrix{a: 1, d: 1, e: x, f: y} }
+func scaleMatrix(x, y float64) matrix     { return matrix{a: x, d: y} }
+
+// mul returns m·n: n is applied first.
+func (m matrix) mul(n matrix) matrix {
+	return matrix{
+		a: m.a*n.a + m.c*n.b,
+		b: m.b*n.a + m.d*n.b,
+		c: m.a*n.c + m.c*n.d,
+		d: m.b*n.c + m.d*n.d,
+		e: m.a*n.e + m.c*n.f + m.e,
+		f: m.b*n.e + m.d*n.f + m.f,
+	}
+}
+
+func (m matrix) apply(p point) point {
+	return point{m.a*p.x + m.c*p.y + m.e, m.b*p.x + m.d*p.y + m.f}
+}
+
+// scale returns the mean scale factor, used for stroke widths.
+func (m matrix) scale() float64 {
+	return math.Sqrt(math.Abs(m.a*m.d - m.b*m.c))
+}
+
+// parseTransform parses a transform attribute such as
+// "translate(10 20) rotate(45)".
+func parseTransform(value string) matrix {
+	m := identityMatrix()
+	for value = strings.TrimSpace(value); value != ""; value = strings.TrimLeft(value, " ,\t\r\n") {
+		open := strings.IndexByte(value, '(')
+		end := strings.IndexByte(value, ')')
+		if open < 0 || end < open {
+			break
+		}
+		name := strings.TrimSpace(value[:open])
+		args := parseNumberList(value[open+1 : end])
+		value = value[end+1:]
+
+		arg := func(i int, fallback float64) float64 {
+			if i < len(args) {
+				return args[i]
+			}
+			return fallback
+		}
+
+		var t matrix
+		switch name {
+		case "matrix":
+			if len(args) != 6 {
+				continue
+			}
+			t = matrix{args[0], args[1], args[2], args[3], args[4], args[5]}
+		case "translate":
+			t = translateMatrix(arg(0, 0), arg(1, 0))
+		case "scale":
+			sx := arg(0, 1)
+			t = scaleMatrix(sx, arg(1, sx))
+		case "rotate":
+			rad := arg(0, 0) * math.Pi / 180
+			cos, sin := math.Cos(rad), math.Sin(rad)
+			cx, cy := arg(1, 0), arg(2, 0)
+			t = translateMatrix(cx, cy).mul(matrix{a: cos, b: sin, c: -sin, d: cos}).mul(translateMatrix(-cx, -cy))
+		case "skewX":
+			t = matrix{a: 1, c: math.Tan(arg(0, 0) * math.Pi / 180), d: 1}
+		case "skewY":
+			t = matrix{a: 1, b: math.Tan(arg(0, 0) * math.Pi / 180), d: 1}
+		default:
+			continue
+		}
+		m = m.mul(t)
+	}
+	return m
+}
+
+type point struct {
+	x, y float64
+}
+
+// subpath is a flattened polyline in device space.
+type subpath struct {
+	points []point
+	closed bool
+}
+
+// pathBuilder flattens path commands given in user space into device-space
+// polylines. Curves are transformed first, then subdivided.
+type pathBuilder struct {
+	m       matrix
+	paths   []subpath
+	start   point // user-space start of the current subpath
+	current point // user-space current point
+	open    bool
+}
+
+func (pb *pathBuilder) moveTo(p point) {
+	pb.paths = append(pb.paths, subpath{points: []point{pb.m.apply(p)}})
+	pb.start, pb.current, pb.open = p, p, true
+}
+
+func (pb *pathBuilder) ensureOpen() {
+	if !pb.open {
+		pb.moveTo(pb.current)
+	}
+}
+
+func (pb *pathBuilder) lineTo(p point) {
+	pb.ensureOpen()
+	last := &pb.paths[len(pb.paths)-1]
+	last.points = append(last.points, pb.m.apply(p))
+	pb.current = p
+}
+
+func (pb *pathBuilder) cubicTo(c1, c2, p point) {
+	pb.ensureOpen()
+	p0 := pb.m.apply(pb.current)
+	d1, d2, d3 := pb.m.apply(c1), pb.m.apply(c2), pb.m.apply(p)
+
+	// Subdivide by the control polygon length: about one segment per 3px.
+	length := dist(p0, d1) + dist(d1, d2) + dist(d2, d3)
+	n := int(math.Ceil(length / 3))
+	n = max(1, min(n, 100))
+
+	last := &pb.paths[len(pb.paths)-1]
+	for i := 1; i <= n; i++ {
+		t := float64(i) / float64(n)
+		u := 1 - t
+		last.points = append(last.points, point{
+			x: u*u*u*p0.x + 3*u*u*t*d1.x + 3*u*t*t*d2.x + t*t*t*d3.x,
+			y: u*u*u*p0.y + 3*u*u*t*d1.y + 3*u*t*t*d2.y + t*t*t*d3.y,
+		})
+	}
+	pb.current = p
+}
+
+func (pb *pathBuilder) quadTo(c, p point) {
+	p0 := pb.current
+	c1 := point{p0.x + 2.0/3*(c.x-p0.x), p0.y + 2.0/3*(c.y-p0.y)}
+	c2 := point{p.x + 2.0/3*(c.x-p.x), p.y + 2.0/3*(c.y-p.y)}
+	pb.cubicTo(c1, c2, p)
+}
+
+// arcTo appends an elliptical arc using the SVG endpoint parameterization,
+// approximated with one cubic Bézier per quarter turn.
+func (pb *pathBuilder) arcTo(rx, ry, rotation float64, largeArc, sweep bool, p point) {
+	p0 := pb.current
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 || p0 == p {
+		pb.lineTo(p)
+		return
+	}
+
+	phi := rotation * math.Pi / 180
+	cos, sin := math.Cos(phi), math.Sin(phi)
+
+	// Step 1: compute (x1', y1').
+	dx, dy := (p0.x-p.x)/2, (p0.y-p.y)/2
+	x1 := cos*dx + sin*dy
+	y1 := -sin*dx + cos*dy
+
+	// Scale radii up if they cannot span the endpoints.
+	if lambda := x1*x1/(rx*rx) + y1*y1/(ry*ry); lambda > 1 {
+		s := math.Sqrt(lambda)
+		rx, ry = rx*s, ry*s
+	}
+
+	// Step 2: compute the centre (cx', cy').
+	num := rx*rx*ry*ry - rx*rx*y1*y1 - ry*ry*x1*x1
+	den := rx*rx*y1*y1 + ry*ry*x1*x1
+	coef := 0.0
+	if den > 0 && num > 0 {
+		coef = math.Sqrt(num / den)
+	}
+	if largeArc == sweep {
+		coef = -coef
+	}
+	cxp := coef * rx * y1 / ry
+	cyp := -coef * ry * x1 / rx
+
+	// Step 3: centre in user space.
+	cx := cos*cxp - sin*cyp + (p0.x+p.x)/2
+	cy := sin*cxp + cos*cyp + (p0.y+p.y)/2
+
+	// Step 4: start angle and sweep.
+	theta := vectorAngle(1, 0, (x1-cxp)/rx, (y1-cyp)/ry)
+	delta := vectorAngle((x1-cxp)/rx, (y1-cyp)/ry, (-x1-cxp)/rx, (-y1-cyp)/ry)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	segments := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(segments)
+	alpha := 4.0 / 3 * math.Tan(step/4)
+
+	ellipse := func(angle float64) (point, point) {
+		ca, sa := math.Cos(angle), math.Sin(angle)
+		pt := point{cx + rx*ca*cos - ry*sa*sin, cy + rx*ca*sin + ry*sa*cos}
+		deriv := point{-rx*sa*cos - ry*ca*sin, -rx*sa*sin + ry*ca*cos}
+		return pt, deriv
+	}
+
+	angle := theta
+	from, fromDeriv := ellipse(angle)
+	for i := 0; i < segments; i++ {
+		angle += step
+		to, toDeriv := ellipse(angle)
+		if i == segments-1 {
+			to = p
+		}
+		c1 := point{from.x + alpha*fromDeriv.x, from.y + alpha*fromDeriv.y}
+		c2 := point{to.x - alpha*toDeriv.x, to.y - alpha*toDeriv.y}
+		pb.cubicTo(c1, c2, to)
+		from, fromDeriv = to, toDeriv
+	}
+}
+
+func (pb *pathBuilder) close() {
+	if !pb.open {
+		return
+	}
+	pb.paths[len(pb.paths)-1].closed = true
+	pb.current = pb.start
+	pb.open = false
+}
+
+func vectorAngle(ux, uy, vx, vy float64) float64 {
+	return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+}
+
+func dist(a, b point) float64 {
+	return math.Hypot(b.x-a.x, b.y-a.y)
+}
+
+// scanner tokenizes path data and number lists.
+type scanner struct {
+	s   string
+	pos int
+}
+
+func (sc *scanner) skipSeparators() {
+	for sc.pos < len(sc.s) {
+		switch sc.s[sc.pos] {
+		case ' ', ',', '\t', '\n', '\r', '\f':
+			sc.pos++
+		default:
+			return
+		}
+	}
+}
+
+// number reads the next number, accepting forms such as "-1.5e3", ".5.5"
+// (two numbers) and "1-2" (two numbers).
+func (sc *scanner) number() (float64, bool) {
+	sc.skipSeparators()
+	start := sc.pos
+	i := sc.pos
+	if i < len(sc.s) && (sc.s[i] == '+' || sc.s[i] == '-') {
+		i++
+	}
+	digits, dot := false, false
+	for i < len(sc.s) {
+		ch := sc.s[i]
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits = true
+		case ch == '.' && !dot:
+			dot = true
+		default:
+			goto exponent
+		}
+		i++
+	}
+exponent:
+	if digits && i < len(sc.s) && (sc.s[i] == 'e' || sc.s[i] == 'E') {
+		j := i + 1
+		if j < len(sc.s) && (sc.s[j] == '+' || sc.s[j] == '-') {
+			j++
+		}
+		if j < len(sc.s) && sc.s[j] >= '0' && sc.s[j] <= '9' {
+			for j < len(sc.s) && sc.s[j] >= '0' && sc.s[j] <= '9' {
+				j++
+			}
+			i = j
+		}
+	}
+	if !digits {
+		sc.pos = start
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(sc.s[start:i], 64)
+	if err != nil {
+		sc.pos = start
+		return 0, false
+	}
+	sc.pos = i
+	return n, true
+}
+
+// flag reads an arc flag, which may be written without separators.
+func (sc *scanner) flag() (bool, bool) {
+	sc.skipSeparators()
+	if sc.pos < len(sc.s) && (sc.s[sc.pos] == '0' || sc.s[sc.pos] == '1') {
+		v := sc.s[sc.pos] == '1'
+		sc.pos++
+		return v, true
+	}
+	return false, false
+}
+
+func (sc *scanner) numbers(n int) ([]float64, bool) {
+	out := make([]float64, n)
+	for i := range out {
+		v, ok := sc.number()
+		if !ok {
+			return nil, false
+		}
+		out[i] = v
+	}
+	return out, true
+}
+
+// buildPathData appends SVG path data to pb. Parsing stops at the first
+// error, keeping what was drawn so far, as browsers do.
+func buildPathData(pb *pathBuilder, data string) {
+	sc := &scanner{s: data}
+	var cmd byte
+	var lastCtrl point // reflected control point for S/T
+	var lastCmd byte
+
+	for {
+		sc.skipSeparators()
+		if sc.pos >= len(sc.s) {
+			return
+		}
+		if ch := sc.s[sc.pos]; (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z') {
+			cmd = ch
+			sc.pos++
+		} else if cmd == 0 {
+			return
+		}
+
+		rel := cmd >= 'a'
+		cur := pb.current
+		abs := func(x, y float64) point {
+			if rel {
+				return point{cur.x + x, cur.y + y}
+			}
+			return point{x, y}
+		}
+
+		switch cmd | 0x20 {
+		case 'z':
+			pb.close()
+			lastCmd = 'z'
+			// A command letter must follow; guard against "Z 1 2".
+			cmd = 0
+			continue
+		case 'm':
+			v, ok := sc.numbers(2)
+			if !ok {
+				return
+			}
+			pb.moveTo(abs(v[0], v[1]))
+			// Subsequent pairs are implicit lineto commands.
+			if rel {
+				cmd = 'l'
+			} else {
+				cmd = 'L'
+			}
+			lastCmd = 'm'
+			continue
+		case 'l':
+			v, ok := sc.numbers(2)
+			if !ok {
+				return
+			}
+			pb.lineTo(abs(v[0], v[1]))
+		case 'h':
+			v, ok := sc.numbers(1)
+			if !ok {
+				return
+			}
+			x := v[0]
+			if rel {
+				x += cur.x
+			}
+			pb.lineTo(point{x, cur.y})
+		case 'v':
+			v, ok := sc.numbers(1)
+			if !ok {
+				return
+			}
+			y := v[0]
+			if rel {
+				y += cur.y
+			}
+			pb.lineTo(point{cur.x, y})
+		case 'c':
+			v, ok := sc.numbers(6)
+			if !ok {
+				return
+			}
+			c2 := abs(v[2], v[3])
+			pb.cubicTo(abs(v[0], v[1]), c2, abs(v[4], v[5]))
+			lastCtrl = c2
+		case 's':
+			v, ok := sc.numbers(4)
+			if !ok {
+				return
+			}
+			c1 := cur
+			if lastCmd == 'c' || lastCmd == 's' {
+				c1 = point{2*cur.x - lastCtrl.x, 2*cur.y - lastCtrl.y}
+			}
+			c2 := abs(v[0], v[1])
+			pb.cubicTo(c1, c2, abs(v[2], v[3]))
+			lastCtrl = c2
+		case 'q':
+			v, ok := sc.numbers(4)
+			if !ok {
+				return
+			}
+			c := abs(v[0], v[1])
+			pb.quadTo(c, abs(v[2], v[3]))
+			lastCtrl = c
+		case 't':
+			v, ok := sc.numbers(2)
+			if !ok {
+				return
+			}
+			c := cur
+			if lastCmd == 'q' || lastCmd == 't' {
+				c = point{2*cur.x - lastCtrl.x, 2*cur.y - lastCtrl.y}
+			}
+			pb.quadTo(c, abs(v[0], v[1]))
+			lastCtrl = c
+		case 'a':
+			radii, ok := sc.numbers(3)
+			if !ok {
+				return
+			}
+			large, ok1 := sc.flag()
+			sweep, ok2 := sc.flag()
+			end, ok3 := sc.numbers(2)
+			if !ok1 || !ok2 || !ok3 {
+				return
+			}
+			pb.arcTo(radii[0], radii[1], radii[2], large, sweep, abs(end[0], end[1]))
+		default:
+			return
+		}
+		lastCmd = cmd | 0x20
+	}
+}
@@ -0,0 +1,258 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package svg
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// subSamples is the number of sub-scanlines per pixel row used for
+// vertical anti-aliasing. Horizontal coverage is computed exactly.
+const subSamples = 4
+
+type edge struct {
+	x0, y0, x1, y1 float64 // y0 < y1
+	dir            int     // +1 downwards, -1 upwards
+}
+
+type crossing struct {
+	x   float64
+	dir int
+}
+
+// rasterize computes per-pixel coverage of paths (implicitly closed) within
+// bounds and calls plot for every covered pixel.
+func rasterize(paths []subpath, bounds image.Rectangle, evenOdd bool, plot func(x, y int, coverage float64)) {
+	edges := make([]edge, 0, 64)
+	for _, sp := range paths {
+		n := len(sp.points)
+		if n < 2 {
+			continue
+		}
+		for i := 0; i < n; i++ {
+			a, b := sp.points[i], sp.points[(i+1)%n]
+			if a.y == b.y || math.IsNaN(a.y) || math.IsNaN(b.y) {
+				continue
+			}
+			if a.y < b.y {
+				edges = append(edges, edge{a.x, a.y, b.x, b.y, 1})
+			} else {
+				edges = append(edges, edge{b.x, b.y, a.x, a.y, -1})
+			}
+		}
+	}
+	if len(edges) == 0 {
+		return
+	}
+	sort.Slice(edges, func(i, j int) bool { return edges[i].y0 < edges[j].y0 })
+
+	minY, maxY := edges[0].y0, edges[0].y1
+	for _, e := range edges {
+		maxY = math.Max(maxY, e.y1)
+	}
+	top := max(bounds.Min.Y, int(math.Floor(minY)))
+	bottom := min(bounds.Max.Y, int(math.Ceil(maxY)))
+
+	width := bounds.Dx()
+	cover := make([]float64, width+1)
+	active := make([]edge, 0, 16)
+	crossings := make([]crossing, 0, 16)
+	next := 0
+
+	for y := top; y < bottom; y++ {
+		clear(cover)
+		touched := false
+
+		for s := 0; s < subSamples; s++ {
+			sy := float64(y) + (float64(s)+0.5)/subSamples
+
+			for next < len(edges) && edges[next].y0 <= sy {
+				active = append(active, edges[next])
+				next++
+			}
+			kept := active[:0]
+			crossings = crossings[:0]
+			for _, e := range active {
+				if e.y1 <= sy {
+					continue
+				}
+				kept = append(kept, e)
+				if sy < e.y0 {
+					continue
+				}
+				t := (sy - e.y0) / (e.y1 - e.y0)
+				crossings = append(crossings, crossing{e.x0 + t*(e.x1-e.x0), e.dir})
+			}
+			active = kept
+			if len(crossings) < 2 {
+				continue
+			}
+			sort.Slice(crossings, func(i, j int) bool { return crossings[i].x < crossings[j].x })
+
+			winding := 0
+			for i := 0; i < len(crossings)-1; i++ {
+				winding += crossings[i].dir
+				inside := winding != 0
+				if evenOdd {
+					inside = winding%2 != 0
+				}
+				if inside {
+					addSpan(cover, crossings[i].x-float64(bounds.Min.X), crossings[i+1].x-float64(bounds.Min.X), 1.0/subSamples)
+					touched = true
+				}
+			}
+		}
+
+		if !touched {
+			continue
+		}
+		for x := 0; x < width; x++ {
+			if c := cover[x]; c > 0.001 {
+				plot(bounds.Min.X+x, y, math.Min(c, 1))
+			}
+		}
+	}
+}
+
+// addSpan adds weight times the horizontal coverage of [x0, x1) to cover.
+func addSpan(cover []float64, x0, x1, weight float64) {
+	limit := float64(len(cover) - 1)
+	x0, x1 = math.Max(x0, 0), math.Min(x1, limit)
+	if x1 <= x0 {
+		return
+	}
+	first, last := int(x0), int(x1)
+	if first == last {
+		cover[first] += (x1 - x0) * weight
+		return
+	}
+	cover[first] += (float64(first+1) - x0) * weight
+	for x := first + 1; x < last; x++ {
+		cover[x] += weight
+	}
+	if last < len(cover)-1 {
+		cover[last] += (x1 - float64(last)) * weight
+	}
+}
+
+// strokePaths returns polygons whose nonzero union is the stroke outline of
+// paths at half-width hw. Every polygon is oriented the same way, so the
+// pieces overlap without cancelling. Joins are drawn round.
+func strokePaths(paths []subpath, hw float64, lineCap string) []subpath {
+	out := make([]subpath, 0, 16)
+	add := func(pts ...point) {
+		if signedArea(pts) < 0 {
+			for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+				pts[i], pts[j] = pts[j], pts[i]
+			}
+		}
+		out = append(out, subpath{points: pts, closed: true})
+	}
+
+	for _, sp := range paths {
+		pts := dedupe(sp.points)
+		if len(pts) == 1 {
+			// A zero-length subpath is only visible with round or square caps.
+			switch lineCap {
+			case "round":
+				add(circle(pts[0], hw)...)
+			case "square":
+				p := pts[0]
+				add(point{p.x - hw, p.y - hw}, point{p.x + hw, p.y - hw}, point{p.x + hw, p.y + hw}, point{p.x - hw, p.y + hw})
+			}
+			continue
+		}
+
+		closed := sp.closed && len(pts) > 2
+		if closed {
+			pts = append(pts, pts[0])
+		}
+
+		for i := 0; i+1 < len(pts); i++ {
+			a, b := pts[i], pts[i+1]
+			dx, dy := b.x-a.x, b.y-a.y
+			length := math.Hypot(dx, dy)
+			ux, uy := dx/length, dy/length
+
+			if !closed && lineCap == "square" {
+				if i == 0 {
+					a = point{a.x - ux*hw, a.y - uy*hw}
+				}
+				if i+2 == len(pts) {
+					b = point{b.x + ux*hw, b.y + uy*hw}
+				}
+			}
+
+			nx, ny := -uy*hw, ux*hw
+			add(
+				point{a.x + nx, a.y + ny}, point{b.x + nx, b.y + ny},
+				point{b.x - nx, b.y - ny}, point{a.x - nx, a.y - ny},
+			)
+
+			// Joins at interior vertices (and the start vertex of closed paths).
+			if i > 0 || closed {
+				add(circle(pts[i], hw)...)
+			}
+		}
+
+		if !closed && lineCap == "round" {
+			add(circle(pts[0], hw)...)
+			add(circle(pts[len(pts)-1], hw)...)
+		}
+	}
+	return out
+}
+
+func dedupe(pts []point) []point {
+	out := make([]point, 0, len(pts))
+	for _, p := range pts {
+		if len(out) == 0 || dist(out[len(out)-1], p) > 1e-9 {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+func circle(c point, r float64) []point {
+	n := int(math.Ceil(2 * math.Pi * r / 2))
+	n = max(8, min(n, 64))
+	pts := make([]point, n)
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		pts[i] = point{c.x + r*math.Cos(a), c.y + r*math.Sin(a)}
+	}
+	return pts
+}
+
+func signedArea(pts []point) float64 {
+	area := 0.0
+	for i := range pts {
+		a, b := pts[i], pts[(i+1)%len(pts)]
+		area += a.x*b.y - b.x*a.y
+	}
+	return area / 2
+}
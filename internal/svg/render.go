@@ -0,0 +1,475 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package svg
+
+import (
+	"image"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// paint is a resolved fill or stroke colour; a nil *paint means "none".
+type paint struct {
+	r, g, b float64 // 0..1
+}
+
+// style holds the inherited presentation properties that affect rendering.
+type style struct {
+	fill          *paint
+	stroke        *paint
+	color         paint
+	strokeWidth   float64
+	fillOpacity   float64
+	strokeOpacity float64
+	opacity       float64 // product of ancestor group opacities
+	evenOdd       bool
+	lineCap       string
+	hidden        bool
+}
+
+func defaultStyle() style {
+	return style{
+		fill:          &paint{},
+		strokeWidth:   1,
+		fillOpacity:   1,
+		strokeOpacity: 1,
+		opacity:       1,
+		lineCap:       "butt",
+	}
+}
+
+// renderer walks the element tree and paints shapes into img.
+type renderer struct {
+	img *image.RGBA
+	ids map[string]*node
+}
+
+func (r *renderer) index(n *node) {
+	if id := n.attrs["id"]; id != "" {
+		if _, exists := r.ids[id]; !exists {
+			r.ids[id] = n
+		}
+	}
+	for _, child := range n.children {
+		r.index(child)
+	}
+}
+
+func (r *renderer) render(n *node, m matrix, parent style, depth int) {
+	st, display := r.resolveStyle(n, parent)
+	if !display {
+		return
+	}
+	if t, ok := n.attrs["transform"]; ok && n.name != "svg" {
+		m = m.mul(parseTransform(t))
+	}
+
+	switch n.name {
+	case "svg", "g", "a", "switch":
+		if n.name == "svg" && depth > 0 {
+			// A nested viewport: position it and map its viewBox.
+			x, y := parseCoord(n.attrs["x"]), parseCoord(n.attrs["y"])
+			w, h := intrinsicSize(n)
+			m = m.mul(translateMatrix(x, y)).mul(viewportTransform(n, w, h))
+		}
+		for _, child := range n.children {
+			r.render(child, m, st, depth+1)
+		}
+	case "use":
+		r.renderUse(n, m, st, depth)
+	case "rect", "circle", "ellipse", "line", "polyline", "polygon", "path":
+		if st.hidden {
+			return
+		}
+		pb := &pathBuilder{m: m}
+		if !buildShape(pb, n) {
+			return
+		}
+		r.paintPath(pb.paths, m, st, n.name != "line")
+	}
+	// defs, symbol (outside use), gradients, text, clipPath, mask, etc. are
+	// not painted.
+}
+
+func (r *renderer) renderUse(n *node, m matrix, st style, depth int) {
+	if depth > maxUseDepth*8 {
+		return
+	}
+	href := n.attrs["href"] // xlink:href and href share the local name
+	target := r.ids[strings.TrimPrefix(href, "#")]
+	if target == nil || !strings.HasPrefix(href, "#") {
+		return
+	}
+	m = m.mul(translateMatrix(parseCoord(n.attrs["x"]), parseCoord(n.attrs["y"])))
+
+	if target.name == "symbol" {
+		st, display := r.resolveStyle(target, st)
+		if !display {
+			return
+		}
+		w, h := intrinsicSize(target)
+		if v, ok := parseLength(n.attrs["width"]); ok {
+			w = v
+		}
+		if v, ok := parseLength(n.attrs["height"]); ok {
+			h = v
+		}
+		m = m.mul(viewportTransform(target, w, h))
+		for _, child := range target.children {
+			r.render(child, m, st, depth+maxUseDepth)
+		}
+		return
+	}
+	r.render(target, m, st, depth+maxUseDepth)
+}
+
+// buildShape converts a basic shape element into path segments.
+func buildShape(pb *pathBuilder, n *node) bool {
+	attr := func(name string) float64 { return parseCoord(n.attrs[name]) }
+
+	switch n.name {
+	case "rect":
+		x, y, w, h := attr("x"), attr("y"), attr("width"), attr("height")
+		if w <= 0 || h <= 0 {
+			return false
+		}
+		rx, hasRx := n.attrs["rx"]
+		ry, hasRy := n.attrs["ry"]
+		rxv, ryv := parseCoord(rx), parseCoord(ry)
+		switch {
+		case hasRx && !hasRy:
+			ryv = rxv
+		case hasRy && !hasRx:
+			rxv = ryv
+		}
+		rxv, ryv = math.Min(math.Max(rxv, 0), w/2), math.Min(math.Max(ryv, 0), h/2)
+		if rxv == 0 || ryv == 0 {
+			pb.moveTo(point{x, y})
+			pb.lineTo(point{x + w, y})
+			pb.lineTo(point{x + w, y + h})
+			pb.lineTo(point{x, y + h})
+			pb.close()
+			return true
+		}
+		pb.moveTo(point{x + rxv, y})
+		pb.lineTo(point{x + w - rxv, y})
+		pb.arcTo(rxv, ryv, 0, false, true, point{x + w, y + ryv})
+		pb.lineTo(point{x + w, y + h - ryv})
+		pb.arcTo(rxv, ryv, 0, false, true, point{x + w - rxv, y + h})
+		pb.lineTo(point{x + rxv, y + h})
+		pb.arcTo(rxv, ryv, 0, false, true, point{x, y + h - ryv})
+		pb.lineTo(point{x, y + ryv})
+		pb.arcTo(rxv, ryv, 0, false, true, point{x + rxv, y})
+		pb.close()
+	case "circle", "ellipse":
+		cx, cy := attr("cx"), attr("cy")
+		rx, ry := attr("rx"), attr("ry")
+		if n.name == "circle" {
+			rx, ry = attr("r"), attr("r")
+		}
+		if rx <= 0 || ry <= 0 {
+			return false
+		}
+		pb.moveTo(point{cx + rx, cy})
+		pb.arcTo(rx, ry, 0, false, true, point{cx - rx, cy})
+		pb.arcTo(rx, ry, 0, false, true, point{cx + rx, cy})
+		pb.close()
+	case "line":
+		pb.moveTo(point{attr("x1"), attr("y1")})
+		pb.lineTo(point{attr("x2"), attr("y2")})
+	case "polyline", "polygon":
+		nums := parseNumberList(n.attrs["points"])
+		if len(nums) < 4 {
+			return false
+		}
+		pb.moveTo(point{nums[0], nums[1]})
+		for i := 2; i+1 < len(nums); i += 2 {
+			pb.lineTo(point{nums[i], nums[i+1]})
+		}
+		if n.name == "polygon" {
+			pb.close()
+		}
+	case "path":
+		buildPathData(pb, n.attrs["d"])
+	}
+	return len(pb.paths) > 0
+}
+
+// paintPath fills and strokes flattened subpaths.
+func (r *renderer) paintPath(paths []subpath, m matrix, st style, fillable bool) {
+	if fillable && st.fill != nil && st.fillOpacity > 0 {
+		r.fill(paths, *st.fill, st.fillOpacity*st.opacity, st.evenOdd)
+	}
+	if st.stroke != nil && st.strokeWidth > 0 && st.strokeOpacity > 0 {
+		width := st.strokeWidth * m.scale()
+		outline := strokePaths(paths, width/2, st.lineCap)
+		r.fill(outline, *st.stroke, st.strokeOpacity*st.opacity, false)
+	}
+}
+
+// resolveStyle applies presentation attributes and then the style attribute
+// on top of the inherited style. It reports false when the element is not
+// displayed at all.
+func (r *renderer) resolveStyle(n *node, parent style) (style, bool) {
+	st := parent
+	props := make(map[string]string, 8)
+	for _, key := range []string{
+		"fill", "stroke", "stroke-width", "fill-opacity", "stroke-opacity",
+		"opacity", "fill-rule", "stroke-linecap", "color",
+		"display", "visibility",
+	} {
+		if v, ok := n.attrs[key]; ok {
+			props[key] = v
+		}
+	}
+	for _, decl := range strings.Split(n.attrs["style"], ";") {
+		key, value, ok := strings.Cut(decl, ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important"))
+		props[strings.TrimSpace(key)] = value
+	}
+
+	// color must resolve first so currentColor picks up the element's value.
+	if v, ok := props["color"]; ok {
+		if c, ok := parseColor(v, parent.color); ok {
+			st.color = c
+		}
+	}
+
+	for key, value := range props {
+		value = strings.TrimSpace(value)
+		switch key {
+		case "fill":
+			st.fill = r.resolvePaint(value, st.color, parent.fill)
+		case "stroke":
+			st.stroke = r.resolvePaint(value, st.color, parent.stroke)
+		case "stroke-width":
+			if w, ok := parseLength(value); ok {
+				st.strokeWidth = w
+			} else if value == "0" {
+				st.strokeWidth = 0
+			}
+		case "fill-opacity":
+			st.fillOpacity = parseOpacity(value, parent.fillOpacity)
+		case "stroke-opacity":
+			st.strokeOpacity = parseOpacity(value, parent.strokeOpacity)
+		case "opacity":
+			st.opacity = parent.opacity * parseOpacity(value, 1)
+		case "fill-rule":
+			st.evenOdd = value == "evenodd"
+		case "stroke-linecap":
+			st.lineCap = value
+		case "display":
+			if value == "none" {
+				return st, false
+			}
+		case "visibility":
+			st.hidden = value == "hidden" || value == "collapse"
+		}
+	}
+	return st, true
+}
+
+func (r *renderer) resolvePaint(value string, current paint, inherited *paint) *paint {
+	switch {
+	case value == "none" || value == "transparent":
+		return nil
+	case value == "inherit":
+		return inherited
+	case strings.HasPrefix(value, "url("):
+		end := strings.IndexByte(value, ')')
+		if end < 0 {
+			return nil
+		}
+		ref := strings.Trim(strings.TrimSpace(value[4:end]), `'"`)
+		if p, ok := r.gradientColor(strings.TrimPrefix(ref, "#"), current, 0); ok {
+			return &p
+		}
+		// Fallback colour after the url(), e.g. "url(#g) red".
+		if c, ok := parseColor(strings.TrimSpace(value[end+1:]), current); ok {
+			return &c
+		}
+		return nil
+	}
+	if c, ok := parseColor(value, current); ok {
+		return &c
+	}
+	return inherited
+}
+
+// gradientColor approximates a gradient by the average of its stop colours,
+// following href chains for gradients that inherit their stops.
+func (r *renderer) gradientColor(id string, current paint, depth int) (paint, bool) {
+	grad := r.ids[id]
+	if grad == nil || depth > maxUseDepth {
+		return paint{}, false
+	}
+	var sum paint
+	count := 0
+	for _, stop := range grad.children {
+		if stop.name != "stop" {
+			continue
+		}
+		value := stop.attrs["stop-color"]
+		for _, decl := range strings.Split(stop.attrs["style"], ";") {
+			if key, v, ok := strings.Cut(decl, ":"); ok && strings.TrimSpace(key) == "stop-color" {
+				value = strings.TrimSpace(v)
+			}
+		}
+		if value == "" {
+			value = "black"
+		}
+		if c, ok := parseColor(value, current); ok {
+			sum.r += c.r
+			sum.g += c.g
+			sum.b += c.b
+			count++
+		}
+	}
+	if count == 0 {
+		if href := grad.attrs["href"]; strings.HasPrefix(href, "#") {
+			return r.gradientColor(href[1:], current, depth+1)
+		}
+		return paint{}, false
+	}
+	n := float64(count)
+	return paint{sum.r / n, sum.g / n, sum.b / n}, true
+}
+
+func parseOpacity(value string, fallback float64) float64 {
+	value = strings.TrimSpace(value)
+	scale := 1.0
+	if strings.HasSuffix(value, "%") {
+		value = strings.TrimSuffix(value, "%")
+		scale = 0.01
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return fallback
+	}
+	return math.Min(math.Max(v*scale, 0), 1)
+}
+
+// parseColor parses #rgb, #rrggbb, rgb(), rgba(), named colours and
+// currentColor.
+func parseColor(value string, current paint) (paint, bool) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch {
+	case value == "":
+		return paint{}, false
+	case value == "currentcolor":
+		return current, true
+	case strings.HasPrefix(value, "#"):
+		hex := value[1:]
+		if len(hex) == 3 || len(hex) == 4 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) == 8 {
+			hex = hex[:6]
+		}
+		if len(hex) != 6 {
+			return paint{}, false
+		}
+		v, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return paint{}, false
+		}
+		return paint{float64(v>>16) / 255, float64(v>>8&0xFF) / 255, float64(v&0xFF) / 255}, true
+	case strings.HasPrefix(value, "rgb"):
+		open, end := strings.IndexByte(value, '('), strings.IndexByte(value, ')')
+		if open < 0 || end < open {
+			return paint{}, false
+		}
+		parts := strings.FieldsFunc(value[open+1:end], func(r rune) bool { return r == ',' || r == ' ' || r == '/' })
+		if len(parts) < 3 {
+			return paint{}, false
+		}
+		var ch [3]float64
+		for i := range ch {
+			p := parts[i]
+			if strings.HasSuffix(p, "%") {
+				v, _ := strconv.ParseFloat(strings.TrimSuffix(p, "%"), 64)
+				ch[i] = v / 100
+			} else {
+				v, _ := strconv.ParseFloat(p, 64)
+				ch[i] = v / 255
+			}
+			ch[i] = math.Min(math.Max(ch[i], 0), 1)
+		}
+		return paint{ch[0], ch[1], ch[2]}, true
+	}
+	if rgb, ok := namedColors[value]; ok {
+		return paint{float64(rgb>>16) / 255, float64(rgb>>8&0xFF) / 255, float64(rgb&0xFF) / 255}, true
+	}
+	return paint{}, false
+}
+
+// namedColors covers the CSS basic colours plus the most common extended
+// keywords.
+var namedColors = map[string]uint32{
+	"black": 0x000000, "silver": 0xC0C0C0, "gray": 0x808080, "grey": 0x808080,
+	"white": 0xFFFFFF, "maroon": 0x800000, "red": 0xFF0000, "purple": 0x800080,
+	"fuchsia": 0xFF00FF, "magenta": 0xFF00FF, "green": 0x008000, "lime": 0x00FF00,
+	"olive": 0x808000, "yellow": 0xFFFF00, "navy": 0x000080, "blue": 0x0000FF,
+	"teal": 0x008080, "aqua": 0x00FFFF, "cyan": 0x00FFFF, "orange": 0xFFA500,
+	"brown": 0xA52A2A, "pink": 0xFFC0CB, "gold": 0xFFD700, "indigo": 0x4B0082,
+	"violet": 0xEE82EE, "darkgray": 0xA9A9A9, "darkgrey": 0xA9A9A9,
+	"lightgray": 0xD3D3D3, "lightgrey": 0xD3D3D3, "darkred": 0x8B0000,
+	"darkgreen": 0x006400, "darkblue": 0x00008B, "lightblue": 0xADD8E6,
+	"lightgreen": 0x90EE90, "steelblue": 0x4682B4, "skyblue": 0x87CEEB,
+	"crimson": 0xDC143C, "coral": 0xFF7F50, "tomato": 0xFF6347,
+	"orangered": 0xFF4500, "salmon": 0xFA8072, "khaki": 0xF0E68C,
+	"beige": 0xF5F5DC, "tan": 0xD2B48C, "chocolate": 0xD2691E,
+	"slategray": 0x708090, "slategrey": 0x708090, "dimgray": 0x696969,
+	"dimgrey": 0x696969, "whitesmoke": 0xF5F5F5, "gainsboro": 0xDCDCDC,
+	"forestgreen": 0x228B22, "seagreen": 0x2E8B57, "royalblue": 0x4169E1,
+	"dodgerblue": 0x1E90FF, "turquoise": 0x40E0D0, "darkorange": 0xFF8C00,
+	"firebrick": 0xB22222, "goldenrod": 0xDAA520, "lavender": 0xE6E6FA,
+	"ivory": 0xFFFFF0, "linen": 0xFAF0E6, "midnightblue": 0x191970,
+}
+
+// fill composites a solid colour onto the image through the coverage of
+// paths, using src-over on premultiplied RGBA.
+func (r *renderer) fill(paths []subpath, p paint, alpha float64, evenOdd bool) {
+	if alpha <= 0 {
+		return
+	}
+	rasterize(paths, r.img.Rect, evenOdd, func(x, y int, coverage float64) {
+		a := coverage * alpha
+		if a <= 0 {
+			return
+		}
+		i := r.img.PixOffset(x, y)
+		px := r.img.Pix[i : i+4 : i+4]
+		inv := 1 - a
+		px[0] = uint8(math.Min(255, p.r*a*255+float64(px[0])*inv+0.5))
+		px[1] = uint8(math.Min(255, p.g*a*255+float64(px[1])*inv+0.5))
+		px[2] = uint8(math.Min(255, p.b*a*255+float64(px[2])*inv+0.5))
+		px[3] = uint8(math.Min(255, a*255+float64(px[3])*inv+0.5))
+	})
+}
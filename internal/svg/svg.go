@@ -0,0 +1,277 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package svg reads the intrinsic size of SVG images and rasterizes a
+// practical subset of SVG 1.1: basic shapes, paths (including arcs),
+// transforms, <use> references, strokes and solid colours. Gradients are
+// approximated by the average of their stops. Text, filters, masks, clipping
+// paths and CSS style sheets are ignored.
+//
+// It exists to produce the bitmap fallback that Word stores next to SVG
+// pictures for viewers without SVG support, not as a general-purpose renderer.
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"image"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+const (
+	opSize      = "svg.Size"
+	opRasterize = "svg.Rasterize"
+
+	// Default viewport used by browsers when an SVG declares no size.
+	defaultWidth  = 300
+	defaultHeight = 150
+
+	// maxUseDepth bounds <use> indirection so reference cycles terminate.
+	maxUseDepth = 8
+)
+
+// node is a parsed SVG element. Attribute keys are local names.
+type node struct {
+	name     string
+	attrs    map[string]string
+	children []*node
+}
+
+// IsSVG reports whether data looks like an SVG document.
+func IsSVG(data []byte) bool {
+	head := data
+	if len(head) > 1024 {
+		head = head[:1024]
+	}
+	return bytes.Contains(head, []byte("<svg"))
+}
+
+// Size returns the intrinsic width and height of an SVG document in CSS
+// pixels (96 per inch). Sizes come from the width and height attributes,
+// falling back to the viewBox and finally to the 300x150 browser default.
+func Size(data []byte) (float64, float64, error) {
+	root, err := parse(data)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, opSize)
+	}
+	w, h := intrinsicSize(root)
+	return w, h, nil
+}
+
+// Rasterize renders an SVG document into a width x height image with a
+// transparent background.
+func Rasterize(data []byte, width, height int) (*image.RGBA, error) {
+	if width <= 0 || height <= 0 {
+		return nil, errors.InvalidArgument(opRasterize, "size", [2]int{width, height}, "raster size must be positive")
+	}
+
+	root, err := parse(data)
+	if err != nil {
+		return nil, errors.Wrap(err, opRasterize)
+	}
+
+	r := &renderer{
+		img: image.NewRGBA(image.Rect(0, 0, width, height)),
+		ids: make(map[string]*node),
+	}
+	r.index(root)
+
+	r.render(root, viewportTransform(root, float64(width), float64(height)), defaultStyle(), 0)
+	return r.img, nil
+}
+
+func parse(data []byte) (*node, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	var root *node
+	stack := make([]*node, 0, 16)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeXML, "svg.parse")
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: t.Name.Local, attrs: make(map[string]string, len(t.Attr))}
+			for _, attr := range t.Attr {
+				n.attrs[attr.Name.Local] = attr.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			} else if root == nil {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+
+	if root == nil || root.name != "svg" {
+		return nil, errors.InvalidArgument("svg.parse", "data", len(data), "not an SVG document")
+	}
+	return root, nil
+}
+
+func intrinsicSize(root *node) (float64, float64) {
+	vb, hasViewBox := parseViewBox(root.attrs["viewBox"])
+	w, hasWidth := parseLength(root.attrs["width"])
+	h, hasHeight := parseLength(root.attrs["height"])
+
+	switch {
+	case hasWidth && hasHeight:
+	case hasWidth && hasViewBox:
+		h = w * vb.h / vb.w
+	case hasHeight && hasViewBox:
+		w = h * vb.w / vb.h
+	case hasViewBox:
+		w, h = vb.w, vb.h
+	default:
+		if !hasWidth {
+			w = defaultWidth
+		}
+		if !hasHeight {
+			h = defaultHeight
+		}
+	}
+	return w, h
+}
+
+type viewBox struct {
+	x, y, w, h float64
+}
+
+func parseViewBox(value string) (viewBox, bool) {
+	nums := parseNumberList(value)
+	if len(nums) != 4 || nums[2] <= 0 || nums[3] <= 0 {
+		return viewBox{}, false
+	}
+	return viewBox{nums[0], nums[1], nums[2], nums[3]}, true
+}
+
+// viewportTransform maps root user units onto a width x height raster,
+// honouring viewBox and preserveAspectRatio.
+func viewportTransform(root *node, width, height float64) matrix {
+	vb, ok := parseViewBox(root.attrs["viewBox"])
+	if !ok {
+		w, h := intrinsicSize(root)
+		return scaleMatrix(width/w, height/h)
+	}
+
+	sx, sy := width/vb.w, height/vb.h
+	align, slice := "xMidYMid", false
+	if par := strings.Fields(root.attrs["preserveAspectRatio"]); len(par) > 0 {
+		align = par[0]
+		slice = len(par) > 1 && par[1] == "slice"
+	}
+	if align == "none" {
+		return scaleMatrix(sx, sy).mul(translateMatrix(-vb.x, -vb.y))
+	}
+
+	s := math.Min(sx, sy)
+	if slice {
+		s = math.Max(sx, sy)
+	}
+	tx, ty := 0.0, 0.0
+	switch {
+	case strings.Contains(align, "xMid"):
+		tx = (width - vb.w*s) / 2
+	case strings.Contains(align, "xMax"):
+		tx = width - vb.w*s
+	}
+	switch {
+	case strings.Contains(align, "YMid"):
+		ty = (height - vb.h*s) / 2
+	case strings.Contains(align, "YMax"):
+		ty = height - vb.h*s
+	}
+	return translateMatrix(tx, ty).mul(scaleMatrix(s, s)).mul(translateMatrix(-vb.x, -vb.y))
+}
+
+// parseLength converts an absolute length to CSS pixels. Percentages and
+// malformed values report false.
+func parseLength(value string) (float64, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" || strings.HasSuffix(value, "%") {
+		return 0, false
+	}
+
+	unit := 1.0
+	for _, u := range []struct {
+		suffix string
+		factor float64
+	}{
+		{"px", 1}, {"pt", 96.0 / 72}, {"pc", 16}, {"in", 96},
+		{"cm", 96 / 2.54}, {"mm", 96 / 25.4}, {"em", 16}, {"ex", 8},
+	} {
+		if strings.HasSuffix(value, u.suffix) {
+			value = strings.TrimSpace(strings.TrimSuffix(value, u.suffix))
+			unit = u.factor
+			break
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n * unit, true
+}
+
+// parseCoord reads a coordinate attribute, ignoring any unit suffix.
+func parseCoord(value string) float64 {
+	value = strings.TrimSpace(value)
+	if n, ok := parseLength(value); ok {
+		return n
+	}
+	n, _ := strconv.ParseFloat(strings.TrimRight(value, "abcdefghijklmnopqrstuvwxyz%"), 64)
+	return n
+}
+
+func parseNumberList(value string) []float64 {
+	s := &scanner{s: value}
+	nums := make([]float64, 0, 8)
+	for {
+		n, ok := s.number()
+		if !ok {
+			return nums
+		}
+		nums = append(nums, n)
+	}
+}
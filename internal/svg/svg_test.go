@@ -0,0 +1,118 @@
+package svg
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSize(t *testing.T) {
+	tests := []struct {
+		name string
+		svg  string
+		w, h float64
+	}{
+		{"pixels", `<svg xmlns="http://www.w3.org/2000/svg" width="120" height="80"/>`, 120, 80},
+		{"units", `<svg width="1in" height="72pt"/>`, 96, 96},
+		{"viewBox", `<svg viewBox="0 0 40 20"/>`, 40, 20},
+		{"width and viewBox", `<svg width="200" viewBox="0 0 40 20"/>`, 200, 100},
+		{"percent", `<svg width="100%" height="100%" viewBox="0 0 10 30"/>`, 10, 30},
+		{"default", `<?xml version="1.0"?><svg/>`, 300, 150},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w, h, err := Size([]byte(tt.svg))
+			if err != nil {
+				t.Fatalf("Size: %v", err)
+			}
+			if math.Abs(w-tt.w) > 0.01 || math.Abs(h-tt.h) > 0.01 {
+				t.Errorf("Size = %vx%v, want %vx%v", w, h, tt.w, tt.h)
+			}
+		})
+	}
+
+	if _, _, err := Size([]byte(`<html><body/></html>`)); err == nil {
+		t.Error("expected error for non-SVG root")
+	}
+}
+
+func TestRasterize(t *testing.T) {
+	src := `<svg xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink" viewBox="0 0 100 100">
+  <defs><circle id="dot" r="10" fill="#00ff00"/></defs>
+  <rect x="0" y="0" width="50" height="50" fill="red"/>
+  <g transform="translate(50 0)" style="fill: blue">
+    <path d="M0 0 h50 v50 h-50 z"/>
+  </g>
+  <use xlink:href="#dot" x="25" y="75"/>
+  <line x1="50" y1="60" x2="100" y2="60" stroke="black" stroke-width="4"/>
+  <rect x="60" y="80" width="30" height="10" fill="none" display="none"/>
+</svg>`
+
+	img, err := Rasterize([]byte(src), 200, 200)
+	if err != nil {
+		t.Fatalf("Rasterize: %v", err)
+	}
+
+	check := func(x, y int, r, g, b, a uint8) {
+		t.Helper()
+		c := img.RGBAAt(x, y)
+		if c.R != r || c.G != g || c.B != b || c.A != a {
+			t.Errorf("pixel (%d,%d) = %v, want {%d %d %d %d}", x, y, c, r, g, b, a)
+		}
+	}
+	check(40, 40, 255, 0, 0, 255)  // red rect, scaled 2x
+	check(150, 50, 0, 0, 255, 255) // blue path inherits group fill
+	check(50, 150, 0, 255, 0, 255) // <use> of the circle
+	check(150, 120, 0, 0, 0, 255)  // stroked line centre
+	check(150, 140, 0, 0, 0, 0)    // below the line stays transparent
+	check(10, 190, 0, 0, 0, 0)     // outside every shape
+}
+
+func TestRasterizeFillRuleAndArcs(t *testing.T) {
+	src := `<svg width="100" height="100">
+  <path fill-rule="evenodd" fill="black" d="M10 10 H90 V90 H10 Z M30 30 H70 V70 H30 Z"/>
+  <path fill="rgb(255,0,0)" d="M0 100 a10 10 0 0 1 20 0 z"/>
+</svg>`
+	img, err := Rasterize([]byte(src), 100, 100)
+	if err != nil {
+		t.Fatalf("Rasterize: %v", err)
+	}
+	if img.RGBAAt(20, 20).A != 255 {
+		t.Error("outer ring should be filled")
+	}
+	if img.RGBAAt(50, 50).A != 0 {
+		t.Error("evenodd hole should be empty")
+	}
+	if c := img.RGBAAt(10, 95); c.R != 255 || c.A != 255 {
+		t.Errorf("arc half-disc should be red, got %v", c)
+	}
+	if img.RGBAAt(5, 85).A != 0 {
+		t.Error("pixel above the arc should be empty")
+	}
+}
+
+func TestParseTransform(t *testing.T) {
+	m := parseTransform("translate(10,20) scale(2) rotate(90)")
+	p := m.apply(point{1, 0})
+	if math.Abs(p.x-10) > 1e-9 || math.Abs(p.y-22) > 1e-9 {
+		t.Errorf("transformed point = %+v, want {10 22}", p)
+	}
+}
+
+func TestParseColor(t *testing.T) {
+	for value, want := range map[string]paint{
+		"#f00":            {1, 0, 0},
+		"#00FF00":         {0, 1, 0},
+		"rgb(0, 0, 255)":  {0, 0, 1},
+		"rgba(0,0,0,0.5)": {0, 0, 0},
+		"White":           {1, 1, 1},
+		"currentColor":    {0.5, 0.5, 0.5},
+	} {
+		got, ok := parseColor(value, paint{0.5, 0.5, 0.5})
+		if !ok || got != want {
+			t.Errorf("parseColor(%q) = %v, %v; want %v", value, got, ok, want)
+		}
+	}
+	if _, ok := parseColor("bogus", paint{}); ok {
+		t.Error("unknown colour should not parse")
+	}
+}
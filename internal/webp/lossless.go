@@ -0,0 +1,675 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package webp
+
+import (
+	"image"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+const (
+	losslessSignature = 0x2f
+
+	transformPredictor     = 0
+	transformCrossColor    = 1
+	transformSubtractGreen = 2
+	transformColorIndexing = 3
+
+	numLiteralCodes = 256
+	numLengthCodes  = 24
+	numDistanceCode = 40
+	maxCacheBits    = 11
+	maxCodeLength   = 15
+)
+
+// codeLengthOrder is the order in which code length code lengths are stored.
+var codeLengthOrder = [19]int{17, 18, 0, 1, 2, 3, 4, 5, 16, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15}
+
+// distanceMap maps the first 120 distance codes to 2D offsets, packed as
+// yoffset<<4 | (8 - xoffset).
+var distanceMap = [120]uint8{
+	0x18, 0x07, 0x17, 0x19, 0x28, 0x06, 0x27, 0x29, 0x16, 0x1a,
+	0x26, 0x2a, 0x38, 0x05, 0x37, 0x39, 0x15, 0x1b, 0x36, 0x3a,
+	0x25, 0x2b, 0x48, 0x04, 0x47, 0x49, 0x14, 0x1c, 0x35, 0x3b,
+	0x46, 0x4a, 0x24, 0x2c, 0x58, 0x45, 0x4b, 0x34, 0x3c, 0x03,
+	0x57, 0x59, 0x13, 0x1d, 0x56, 0x5a, 0x23, 0x2d, 0x44, 0x4c,
+	0x55, 0x5b, 0x33, 0x3d, 0x68, 0x02, 0x67, 0x69, 0x12, 0x1e,
+	0x66, 0x6a, 0x22, 0x2e, 0x54, 0x5c, 0x43, 0x4d, 0x65, 0x6b,
+	0x32, 0x3e, 0x78, 0x01, 0x77, 0x79, 0x53, 0x5d, 0x11, 0x1f,
+	0x64, 0x6c, 0x42, 0x4e, 0x76, 0x7a, 0x21, 0x2f, 0x75, 0x7b,
+	0x31, 0x3f, 0x63, 0x6d, 0x52, 0x5e, 0x00, 0x74, 0x7c, 0x41,
+	0x4f, 0x10, 0x20, 0x62, 0x6e, 0x30, 0x73, 0x7d, 0x51, 0x5f,
+	0x40, 0x72, 0x7e, 0x61, 0x6f, 0x50, 0x71, 0x7f, 0x60, 0x70,
+}
+
+// bitReader reads the least-significant-bit-first bit stream of VP8L.
+type bitReader struct {
+	data  []byte
+	pos   int
+	val   uint64
+	nbits uint
+	eof   bool
+}
+
+func (b *bitReader) fill() {
+	for b.nbits <= 56 && b.pos < len(b.data) {
+		b.val |= uint64(b.data[b.pos]) << b.nbits
+		b.pos++
+		b.nbits += 8
+	}
+}
+
+// peek returns the next n bits without consuming them, padding with zeros
+// past the end of the data.
+func (b *bitReader) peek(n uint) uint32 {
+	if b.nbits < n {
+		b.fill()
+	}
+	return uint32(b.val & (1<<n - 1))
+}
+
+func (b *bitReader) skip(n uint) {
+	if b.nbits < n {
+		b.fill()
+		if b.nbits < n {
+			b.eof = true
+			b.val, b.nbits = 0, 0
+			return
+		}
+	}
+	b.val >>= n
+	b.nbits -= n
+}
+
+func (b *bitReader) read(n uint) uint32 {
+	v := b.peek(n)
+	b.skip(n)
+	return v
+}
+
+// huffman is a canonical prefix code.
+type huffman struct {
+	single  bool
+	symbol  uint16
+	fast    [256]uint32 // symbol<<8 | length for codes of at most 8 bits
+	counts  [maxCodeLength + 1]uint16
+	symbols []uint16 // ordered by code
+}
+
+func newHuffman(lengths []int) (*huffman, error) {
+	h := &huffman{}
+	nonZero := 0
+	for sym, l := range lengths {
+		if l > 0 {
+			nonZero++
+			h.symbol = uint16(sym)
+			h.counts[l]++
+		}
+	}
+	if nonZero == 0 {
+		return nil, errors.InvalidArgument("webp.newHuffman", "lengths", 0, "prefix code has no symbols")
+	}
+	if nonZero == 1 {
+		h.single = true
+		return h, nil
+	}
+
+	// Reject over-subscribed and incomplete codes.
+	left := 1
+	for l := 1; l <= maxCodeLength; l++ {
+		left = left<<1 - int(h.counts[l])
+		if left < 0 {
+			return nil, errors.InvalidArgument("webp.newHuffman", "lengths", l, "over-subscribed prefix code")
+		}
+	}
+	if left != 0 {
+		return nil, errors.InvalidArgument("webp.newHuffman", "lengths", left, "incomplete prefix code")
+	}
+
+	var offsets, next [maxCodeLength + 2]int
+	code := 0
+	for l := 1; l <= maxCodeLength; l++ {
+		offsets[l+1] = offsets[l] + int(h.counts[l])
+		code = (code + int(h.counts[l-1])) << 1
+		next[l] = code
+	}
+	h.symbols = make([]uint16, nonZero)
+	for sym, l := range lengths {
+		if l == 0 {
+			continue
+		}
+		h.symbols[offsets[l]] = uint16(sym)
+		offsets[l]++
+
+		c := next[l]
+		next[l]++
+		if l <= 8 {
+			rev := 0
+			for i := 0; i < l; i++ {
+				rev |= (c >> i & 1) << (l - 1 - i)
+			}
+			for i := rev; i < 256; i += 1 << l {
+				h.fast[i] = uint32(sym)<<8 | uint32(l)
+			}
+		}
+	}
+	return h, nil
+}
+
+func (h *huffman) decode(br *bitReader) int {
+	if h.single {
+		return int(h.symbol)
+	}
+	if e := h.fast[br.peek(8)]; e != 0 {
+		br.skip(uint(e & 0xff))
+		return int(e >> 8)
+	}
+
+	code, first, index := 0, 0, 0
+	for l := 1; l <= maxCodeLength; l++ {
+		code |= int(br.read(1))
+		count := int(h.counts[l])
+		if code-first < count {
+			return int(h.symbols[index+code-first])
+		}
+		index += count
+		first = (first + count) << 1
+		code <<= 1
+	}
+	br.eof = true
+	return 0
+}
+
+// huffmanGroup holds the five prefix codes used to decode one pixel.
+type huffmanGroup struct {
+	green, red, blue, alpha, distance *huffman
+}
+
+type transform struct {
+	kind  int
+	bits  int
+	xsize int
+	data  []uint32
+}
+
+type losslessDecoder struct {
+	br         *bitReader
+	transforms []transform
+}
+
+func readLosslessHeader(data []byte) (w, h int, br *bitReader, err error) {
+	if len(data) < 5 || data[0] != losslessSignature {
+		return 0, 0, nil, errors.InvalidArgument("webp.readLosslessHeader", "signature", len(data), "invalid VP8L header")
+	}
+	br = &bitReader{data: data[1:]}
+	w = int(br.read(14)) + 1
+	h = int(br.read(14)) + 1
+	br.read(1) // alpha hint
+	if version := br.read(3); version != 0 {
+		return 0, 0, nil, errors.Unsupported("webp.readLosslessHeader", "VP8L version")
+	}
+	return w, h, br, nil
+}
+
+func decodeLossless(data []byte) (*image.NRGBA, error) {
+	w, h, br, err := readLosslessHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	d := &losslessDecoder{br: br}
+	pix, err := d.decode(w, h)
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+	for i, p := range pix {
+		img.Pix[i*4+0] = byte(p >> 16)
+		img.Pix[i*4+1] = byte(p >> 8)
+		img.Pix[i*4+2] = byte(p)
+		img.Pix[i*4+3] = byte(p >> 24)
+	}
+	return img, nil
+}
+
+// decodeLosslessStream decodes a headerless VP8L stream, as used for
+// compressed alpha data.
+func decodeLosslessStream(data []byte, w, h int) ([]uint32, error) {
+	d := &losslessDecoder{br: &bitReader{data: data}}
+	return d.decode(w, h)
+}
+
+func (d *losslessDecoder) decode(w, h int) ([]uint32, error) {
+	xsize := w
+	var seen [4]bool
+	for d.br.read(1) == 1 {
+		kind := int(d.br.read(2))
+		if seen[kind] {
+			return nil, errors.InvalidArgument("webp.decodeLossless", "transform", kind, "transform used more than once")
+		}
+		seen[kind] = true
+
+		t := transform{kind: kind, xsize: xsize}
+		switch kind {
+		case transformPredictor, transformCrossColor:
+			t.bits = int(d.br.read(3)) + 2
+			data, err := d.decodeImage(subSampleSize(xsize, t.bits), subSampleSize(h, t.bits), false)
+			if err != nil {
+				return nil, err
+			}
+			t.data = data
+		case transformColorIndexing:
+			size := int(d.br.read(8)) + 1
+			switch {
+			case size > 16:
+				t.bits = 0
+			case size > 4:
+				t.bits = 1
+			case size > 2:
+				t.bits = 2
+			default:
+				t.bits = 3
+			}
+			palette, err := d.decodeImage(size, 1, false)
+			if err != nil {
+				return nil, err
+			}
+			for i := 1; i < len(palette); i++ {
+				palette[i] = addPixels(palette[i], palette[i-1])
+			}
+			t.data = palette
+			xsize = subSampleSize(xsize, t.bits)
+		}
+		d.transforms = append(d.transforms, t)
+	}
+
+	pix, err := d.decodeImage(xsize, h, true)
+	if err != nil {
+		return nil, err
+	}
+	for i := len(d.transforms) - 1; i >= 0; i-- {
+		pix = d.transforms[i].inverse(pix, h)
+	}
+	return pix, nil
+}
+
+func subSampleSize(size, bits int) int {
+	return (size + 1<<bits - 1) >> bits
+}
+
+func (d *losslessDecoder) readCode(alphabet int) (*huffman, error) {
+	br := d.br
+	lengths := make([]int, alphabet)
+
+	if br.read(1) == 1 {
+		count := br.read(1) + 1
+		firstBits := uint(1)
+		if br.read(1) == 1 {
+			firstBits = 8
+		}
+		symbols := []int{int(br.read(firstBits))}
+		if count == 2 {
+			symbols = append(symbols, int(br.read(8)))
+		}
+		for _, s := range symbols {
+			if s >= alphabet {
+				return nil, errors.InvalidArgument("webp.readCode", "symbol", s, "symbol outside alphabet")
+			}
+			lengths[s] = 1
+		}
+		return newHuffman(lengths)
+	}
+
+	var codeLengths [19]int
+	n := int(br.read(4)) + 4
+	for i := 0; i < n; i++ {
+		codeLengths[codeLengthOrder[i]] = int(br.read(3))
+	}
+	lengthCode, err := newHuffman(codeLengths[:])
+	if err != nil {
+		return nil, err
+	}
+
+	maxSymbol := alphabet
+	if br.read(1) == 1 {
+		nbits := 2 + 2*uint(br.read(3))
+		maxSymbol = 2 + int(br.read(nbits))
+		if maxSymbol > alphabet {
+			return nil, errors.InvalidArgument("webp.readCode", "max_symbol", maxSymbol, "exceeds alphabet size")
+		}
+	}
+
+	prev := 8
+	for sym := 0; sym < alphabet && maxSymbol > 0; maxSymbol-- {
+		c := lengthCode.decode(br)
+		if c < 16 {
+			lengths[sym] = c
+			sym++
+			if c != 0 {
+				prev = c
+			}
+			continue
+		}
+
+		var repeat, value int
+		switch c {
+		case 16:
+			repeat, value = 3+int(br.read(2)), prev
+		case 17:
+			repeat = 3 + int(br.read(3))
+		default:
+			repeat = 11 + int(br.read(7))
+		}
+		if sym+repeat > alphabet {
+			return nil, errors.InvalidArgument("webp.readCode", "repeat", repeat, "code lengths exceed alphabet")
+		}
+		for ; repeat > 0; repeat-- {
+			lengths[sym] = value
+			sym++
+		}
+	}
+	if br.eof {
+		return nil, errors.InvalidArgument("webp.readCode", "data", br.pos, "truncated VP8L data")
+	}
+	return newHuffman(lengths)
+}
+
+// decodeImage decodes an entropy-coded image. Only the main image may use
+// meta prefix codes.
+func (d *losslessDecoder) decodeImage(w, h int, main bool) ([]uint32, error) {
+	br := d.br
+
+	cacheBits := 0
+	if br.read(1) == 1 {
+		cacheBits = int(br.read(4))
+		if cacheBits < 1 || cacheBits > maxCacheBits {
+			return nil, errors.InvalidArgument("webp.decodeImage", "color_cache_bits", cacheBits, "out of range")
+		}
+	}
+
+	var (
+		entropy    []uint32
+		prefixBits int
+		entropyW   int
+		numGroups  = 1
+	)
+	if main && br.read(1) == 1 {
+		prefixBits = int(br.read(3)) + 2
+		entropyW = subSampleSize(w, prefixBits)
+		var err error
+		entropy, err = d.decodeImage(entropyW, subSampleSize(h, prefixBits), false)
+		if err != nil {
+			return nil, err
+		}
+		for i, e := range entropy {
+			g := int(e>>8) & 0xffff
+			entropy[i] = uint32(g)
+			numGroups = max(numGroups, g+1)
+		}
+	}
+
+	cacheSize := 0
+	if cacheBits > 0 {
+		cacheSize = 1 << cacheBits
+	}
+	groups := make([]huffmanGroup, numGroups)
+	for i := range groups {
+		var err error
+		g := &groups[i]
+		if g.green, err = d.readCode(numLiteralCodes + numLengthCodes + cacheSize); err != nil {
+			return nil, err
+		}
+		if g.red, err = d.readCode(numLiteralCodes); err != nil {
+			return nil, err
+		}
+		if g.blue, err = d.readCode(numLiteralCodes); err != nil {
+			return nil, err
+		}
+		if g.alpha, err = d.readCode(numLiteralCodes); err != nil {
+			return nil, err
+		}
+		if g.distance, err = d.readCode(numDistanceCode); err != nil {
+			return nil, err
+		}
+	}
+
+	var cache []uint32
+	if cacheSize > 0 {
+		cache = make([]uint32, cacheSize)
+	}
+	insert := func(p uint32) {
+		if cache != nil {
+			cache[(0x1e35a7bd*p)>>(32-cacheBits)] = p
+		}
+	}
+
+	total := w * h
+	pix := make([]uint32, total)
+	for pos := 0; pos < total; {
+		g := &groups[0]
+		if entropy != nil {
+			x, y := pos%w, pos/w
+			g = &groups[entropy[(y>>prefixBits)*entropyW+(x>>prefixBits)]]
+		}
+
+		code := g.green.decode(br)
+		switch {
+		case code < numLiteralCodes:
+			r := uint32(g.red.decode(br))
+			b := uint32(g.blue.decode(br))
+			a := uint32(g.alpha.decode(br))
+			pix[pos] = a<<24 | r<<16 | uint32(code)<<8 | b
+			insert(pix[pos])
+			pos++
+		case code < numLiteralCodes+numLengthCodes:
+			length := prefixValue(br, code-numLiteralCodes)
+			dist := planeDistance(w, prefixValue(br, g.distance.decode(br)))
+			if dist > pos || pos+length > total {
+				return nil, errors.InvalidArgument("webp.decodeImage", "distance", dist, "backward reference out of range")
+			}
+			for i := 0; i < length; i++ {
+				pix[pos] = pix[pos-dist]
+				insert(pix[pos])
+				pos++
+			}
+		default:
+			index := code - numLiteralCodes - numLengthCodes
+			if index >= cacheSize {
+				return nil, errors.InvalidArgument("webp.decodeImage", "cache index", index, "outside color cache")
+			}
+			pix[pos] = cache[index]
+			pos++
+		}
+		if br.eof {
+			return nil, errors.InvalidArgument("webp.decodeImage", "data", br.pos, "truncated VP8L data")
+		}
+	}
+	return pix, nil
+}
+
+func prefixValue(br *bitReader, prefix int) int {
+	if prefix < 4 {
+		return prefix + 1
+	}
+	extra := uint(prefix-2) >> 1
+	offset := (2 + prefix&1) << extra
+	return offset + int(br.read(extra)) + 1
+}
+
+func planeDistance(xsize, code int) int {
+	if code > len(distanceMap) {
+		return code - len(distanceMap)
+	}
+	m := int(distanceMap[code-1])
+	dist := (m>>4)*xsize + 8 - m&0xf
+	return max(dist, 1)
+}
+
+func (t *transform) inverse(pix []uint32, h int) []uint32 {
+	w := t.xsize
+	switch t.kind {
+	case transformPredictor:
+		tw := subSampleSize(w, t.bits)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				i := y*w + x
+				var pred uint32
+				switch {
+				case x == 0 && y == 0:
+					pred = 0xff000000
+				case y == 0:
+					pred = pix[i-1]
+				case x == 0:
+					pred = pix[i-w]
+				default:
+					mode := (t.data[(y>>t.bits)*tw+(x>>t.bits)] >> 8) & 0xf
+					pred = predict(mode, pix[i-1], pix[i-w], pix[i-w-1], pix[i-w+1])
+				}
+				pix[i] = addPixels(pix[i], pred)
+			}
+		}
+	case transformCrossColor:
+		tw := subSampleSize(w, t.bits)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				i := y*w + x
+				m := t.data[(y>>t.bits)*tw+(x>>t.bits)]
+				p := pix[i]
+				green := int8(p >> 8)
+				red := int(p>>16&0xff) + colorDelta(int8(m), green)
+				red &= 0xff
+				blue := int(p&0xff) + colorDelta(int8(m>>8), green)
+				blue += colorDelta(int8(m>>16), int8(red))
+				blue &= 0xff
+				pix[i] = p&0xff00ff00 | uint32(red)<<16 | uint32(blue)
+			}
+		}
+	case transformSubtractGreen:
+		for i, p := range pix {
+			g := p >> 8 & 0xff
+			r := (p>>16 + g) & 0xff
+			b := (p + g) & 0xff
+			pix[i] = p&0xff00ff00 | r<<16 | b
+		}
+	case transformColorIndexing:
+		pw := subSampleSize(w, t.bits)
+		perByte := 1 << t.bits
+		bitsPerPixel := uint(8 >> t.bits)
+		mask := uint32(1)<<bitsPerPixel - 1
+		out := make([]uint32, w*h)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				packed := pix[y*pw+x>>t.bits] >> 8
+				index := int(packed >> (uint(x&(perByte-1)) * bitsPerPixel) & mask)
+				if index < len(t.data) {
+					out[y*w+x] = t.data[index]
+				}
+			}
+		}
+		return out
+	}
+	return pix
+}
+
+func colorDelta(t, c int8) int {
+	return (int(t) * int(c)) >> 5
+}
+
+// addPixels adds two ARGB values channel by channel, modulo 256.
+func addPixels(a, b uint32) uint32 {
+	ag := (a & 0xff00ff00) + (b & 0xff00ff00)
+	rb := (a & 0x00ff00ff) + (b & 0x00ff00ff)
+	return ag&0xff00ff00 | rb&0x00ff00ff
+}
+
+func average2(a, b uint32) uint32 {
+	return ((a^b)&0xfefefefe)>>1 + a&b
+}
+
+func predict(mode, l, t, tl, tr uint32) uint32 {
+	switch mode {
+	case 1:
+		return l
+	case 2:
+		return t
+	case 3:
+		return tr
+	case 4:
+		return tl
+	case 5:
+		return average2(average2(l, tr), t)
+	case 6:
+		return average2(l, tl)
+	case 7:
+		return average2(l, t)
+	case 8:
+		return average2(tl, t)
+	case 9:
+		return average2(t, tr)
+	case 10:
+		return average2(average2(l, tl), average2(t, tr))
+	case 11:
+		return selectPixel(l, t, tl)
+	case 12:
+		return mapChannels(func(s uint) uint32 {
+			return clamp255(int(l>>s&0xff) + int(t>>s&0xff) - int(tl>>s&0xff))
+		})
+	case 13:
+		avg := average2(l, t)
+		return mapChannels(func(s uint) uint32 {
+			a := int(avg >> s & 0xff)
+			return clamp255(a + (a-int(tl>>s&0xff))/2)
+		})
+	}
+	return 0xff000000
+}
+
+func selectPixel(l, t, tl uint32) uint32 {
+	pl, pt := 0, 0
+	for s := uint(0); s < 32; s += 8 {
+		pl += abs(int(t>>s&0xff) - int(tl>>s&0xff))
+		pt += abs(int(l>>s&0xff) - int(tl>>s&0xff))
+	}
+	if pl < pt {
+		return l
+	}
+	return t
+}
+
+func mapChannels(f func(shift uint) uint32) uint32 {
+	return f(24)<<24 | f(16)<<16 | f(8)<<8 | f(0)
+}
+
+func clamp255(v int) uint32 {
+	return uint32(min(max(v, 0), 255))
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
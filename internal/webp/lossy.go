@@ -0,0 +1,576 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package webp
+
+import (
+	"encoding/binary"
+	"image"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+const (
+	numBModes     = 10
+	numBlockTypes = 4
+	numBands      = 8
+	numContexts   = 3
+	numTokenProbs = 11
+)
+
+// Macroblock and chroma prediction modes.
+const (
+	predDC uint8 = iota
+	predV
+	predH
+	predTM
+)
+
+// Sub-block prediction modes, in bitstream order.
+const (
+	bpredDC uint8 = iota
+	bpredTM
+	bpredVE
+	bpredHE
+	bpredLD
+	bpredRD
+	bpredVR
+	bpredVL
+	bpredHD
+	bpredHU
+)
+
+// boolDecoder is the boolean entropy decoder of RFC 6386 section 7.
+type boolDecoder struct {
+	data     []byte
+	pos      int
+	value    uint32
+	rng      uint32
+	bitCount int
+}
+
+func newBoolDecoder(data []byte) *boolDecoder {
+	d := &boolDecoder{data: data, rng: 255}
+	d.value = uint32(d.next())<<8 | uint32(d.next())
+	return d
+}
+
+func (d *boolDecoder) next() byte {
+	if d.pos >= len(d.data) {
+		d.pos++
+		return 0
+	}
+	b := d.data[d.pos]
+	d.pos++
+	return b
+}
+
+func (d *boolDecoder) readBool(prob uint8) bool {
+	split := 1 + ((d.rng-1)*uint32(prob))>>8
+	bigSplit := split << 8
+	bit := d.value >= bigSplit
+	if bit {
+		d.rng -= split
+		d.value -= bigSplit
+	} else {
+		d.rng = split
+	}
+	for d.rng < 128 {
+		d.value <<= 1
+		d.rng <<= 1
+		d.bitCount++
+		if d.bitCount == 8 {
+			d.bitCount = 0
+			d.value |= uint32(d.next())
+		}
+	}
+	return bit
+}
+
+func (d *boolDecoder) readFlag() bool {
+	return d.readBool(128)
+}
+
+func (d *boolDecoder) readLiteral(n int) int {
+	v := 0
+	for ; n > 0; n-- {
+		v <<= 1
+		if d.readFlag() {
+			v |= 1
+		}
+	}
+	return v
+}
+
+func (d *boolDecoder) readSigned(n int) int {
+	v := d.readLiteral(n)
+	if d.readFlag() {
+		return -v
+	}
+	return v
+}
+
+func (d *boolDecoder) readOptionalSigned(n int) int {
+	if !d.readFlag() {
+		return 0
+	}
+	return d.readSigned(n)
+}
+
+// overrun reports whether the decoder read well past the end of its data.
+func (d *boolDecoder) overrun() bool {
+	return d.pos > len(d.data)+2
+}
+
+type quantMatrix struct {
+	y1, y2, uv [2]int32
+}
+
+type filterStrength struct {
+	limit, ilevel, hevThresh int
+}
+
+type macroblock struct {
+	segment uint8
+	i4      bool
+	inner   bool
+}
+
+type lossyDecoder struct {
+	fp    *boolDecoder
+	parts []*boolDecoder
+
+	width, height int
+	mbw, mbh      int
+
+	segmentEnabled   bool
+	segmentMapUpdate bool
+	segmentAbsolute  bool
+	segmentQuant     [4]int
+	segmentFilter    [4]int
+	segmentProbs     [3]uint8
+
+	simpleFilter bool
+	filterLevel  int
+	sharpness    int
+	useLFDelta   bool
+	refLFDelta   [4]int
+	modeLFDelta  [4]int
+
+	quant       [4]quantMatrix
+	tokenProbs  [numBlockTypes][numBands][numContexts][numTokenProbs]uint8
+	useSkipProb bool
+	skipProb    uint8
+
+	y, u, v           []uint8
+	yStride, uvStride int
+
+	intraTop  []uint8 // sub-block modes along the bottom of the row above
+	intraLeft [4]uint8
+	nzTop     []uint8 // non-zero flags: 4 Y, 2 U, 2 V, 1 Y2 per column
+	nzLeft    [9]uint8
+	mbs       []macroblock
+}
+
+func readLossyHeader(data []byte) (w, h int, err error) {
+	if len(data) < 10 {
+		return 0, 0, errors.InvalidArgument("webp.readLossyHeader", "data", len(data), "truncated VP8 header")
+	}
+	if data[0]&1 != 0 {
+		return 0, 0, errors.Unsupported("webp.readLossyHeader", "VP8 inter frames")
+	}
+	if data[3] != 0x9d || data[4] != 0x01 || data[5] != 0x2a {
+		return 0, 0, errors.InvalidArgument("webp.readLossyHeader", "start code", data[3:6], "invalid VP8 start code")
+	}
+	w = int(binary.LittleEndian.Uint16(data[6:8]) & 0x3fff)
+	h = int(binary.LittleEndian.Uint16(data[8:10]) & 0x3fff)
+	if w == 0 || h == 0 {
+		return 0, 0, errors.InvalidArgument("webp.readLossyHeader", "size", [2]int{w, h}, "empty VP8 frame")
+	}
+	return w, h, nil
+}
+
+func decodeLossy(data []byte) (*image.NRGBA, error) {
+	w, h, err := readLossyHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	firstSize := int(uint32(data[0])|uint32(data[1])<<8|uint32(data[2])<<16) >> 5
+	if 10+firstSize > len(data) {
+		return nil, errors.InvalidArgument("webp.decodeLossy", "partition size", firstSize, "first partition extends beyond data")
+	}
+
+	d := &lossyDecoder{
+		fp:     newBoolDecoder(data[10 : 10+firstSize]),
+		width:  w,
+		height: h,
+		mbw:    (w + 15) / 16,
+		mbh:    (h + 15) / 16,
+	}
+	if err := d.parseHeader(data[10+firstSize:]); err != nil {
+		return nil, err
+	}
+
+	d.yStride, d.uvStride = d.mbw*16, d.mbw*8
+	d.y = make([]uint8, d.yStride*d.mbh*16)
+	d.u = make([]uint8, d.uvStride*d.mbh*8)
+	d.v = make([]uint8, d.uvStride*d.mbh*8)
+	d.intraTop = make([]uint8, d.mbw*4)
+	d.nzTop = make([]uint8, d.mbw*9)
+	d.mbs = make([]macroblock, d.mbw*d.mbh)
+
+	for mby := 0; mby < d.mbh; mby++ {
+		part := d.parts[mby%len(d.parts)]
+		d.intraLeft = [4]uint8{}
+		d.nzLeft = [9]uint8{}
+		for mbx := 0; mbx < d.mbw; mbx++ {
+			d.decodeMacroblock(mbx, mby, part)
+		}
+		if d.fp.overrun() || part.overrun() {
+			return nil, errors.InvalidArgument("webp.decodeLossy", "data", len(data), "truncated VP8 data")
+		}
+	}
+
+	d.filterFrame()
+	return d.toNRGBA(), nil
+}
+
+func (d *lossyDecoder) parseHeader(rest []byte) error {
+	fp := d.fp
+	fp.readLiteral(1) // color space
+	fp.readLiteral(1) // clamping type
+
+	d.segmentEnabled = fp.readFlag()
+	if d.segmentEnabled {
+		d.segmentMapUpdate = fp.readFlag()
+		if fp.readFlag() {
+			d.segmentAbsolute = fp.readFlag()
+			for i := range d.segmentQuant {
+				d.segmentQuant[i] = fp.readOptionalSigned(7)
+			}
+			for i := range d.segmentFilter {
+				d.segmentFilter[i] = fp.readOptionalSigned(6)
+			}
+		}
+		if d.segmentMapUpdate {
+			for i := range d.segmentProbs {
+				d.segmentProbs[i] = 255
+				if fp.readFlag() {
+					d.segmentProbs[i] = uint8(fp.readLiteral(8))
+				}
+			}
+		}
+	}
+
+	d.simpleFilter = fp.readFlag()
+	d.filterLevel = fp.readLiteral(6)
+	d.sharpness = fp.readLiteral(3)
+	d.useLFDelta = fp.readFlag()
+	if d.useLFDelta && fp.readFlag() {
+		for i := range d.refLFDelta {
+			if fp.readFlag() {
+				d.refLFDelta[i] = fp.readSigned(6)
+			}
+		}
+		for i := range d.modeLFDelta {
+			if fp.readFlag() {
+				d.modeLFDelta[i] = fp.readSigned(6)
+			}
+		}
+	}
+
+	numParts := 1 << fp.readLiteral(2)
+	if len(rest) < 3*(numParts-1) {
+		return errors.InvalidArgument("webp.parseHeader", "partitions", numParts, "truncated partition sizes")
+	}
+	sizes, part := rest[:3*(numParts-1)], rest[3*(numParts-1):]
+	for i := 0; i < numParts-1; i++ {
+		size := int(sizes[3*i]) | int(sizes[3*i+1])<<8 | int(sizes[3*i+2])<<16
+		size = min(size, len(part))
+		d.parts = append(d.parts, newBoolDecoder(part[:size]))
+		part = part[size:]
+	}
+	d.parts = append(d.parts, newBoolDecoder(part))
+
+	base := fp.readLiteral(7)
+	dy1dc := fp.readOptionalSigned(4)
+	dy2dc := fp.readOptionalSigned(4)
+	dy2ac := fp.readOptionalSigned(4)
+	duvdc := fp.readOptionalSigned(4)
+	duvac := fp.readOptionalSigned(4)
+	for s := range d.quant {
+		q := base
+		if d.segmentEnabled {
+			q = d.segmentQuant[s]
+			if !d.segmentAbsolute {
+				q += base
+			}
+		}
+		m := &d.quant[s]
+		m.y1[0] = dcTable[clampIndex(q+dy1dc, 127)]
+		m.y1[1] = acTable[clampIndex(q, 127)]
+		m.y2[0] = dcTable[clampIndex(q+dy2dc, 127)] * 2
+		m.y2[1] = max(acTable[clampIndex(q+dy2ac, 127)]*101581>>16, 8)
+		m.uv[0] = dcTable[clampIndex(q+duvdc, 117)]
+		m.uv[1] = acTable[clampIndex(q+duvac, 127)]
+	}
+
+	fp.readFlag() // refresh entropy probabilities
+
+	d.tokenProbs = defaultTokenProbs
+	for i := range d.tokenProbs {
+		for j := range d.tokenProbs[i] {
+			for k := range d.tokenProbs[i][j] {
+				for l := range d.tokenProbs[i][j][k] {
+					if fp.readBool(tokenUpdateProbs[i][j][k][l]) {
+						d.tokenProbs[i][j][k][l] = uint8(fp.readLiteral(8))
+					}
+				}
+			}
+		}
+	}
+
+	d.useSkipProb = fp.readFlag()
+	if d.useSkipProb {
+		d.skipProb = uint8(fp.readLiteral(8))
+	}
+	return nil
+}
+
+func clampIndex(v, hi int) int {
+	return min(max(v, 0), hi)
+}
+
+func (d *lossyDecoder) decodeMacroblock(mbx, mby int, part *boolDecoder) {
+	fp := d.fp
+	mb := &d.mbs[mby*d.mbw+mbx]
+
+	if d.segmentMapUpdate {
+		if !fp.readBool(d.segmentProbs[0]) {
+			mb.segment = boolToUint8(fp.readBool(d.segmentProbs[1]))
+		} else {
+			mb.segment = 2 + boolToUint8(fp.readBool(d.segmentProbs[2]))
+		}
+	}
+	skip := d.useSkipProb && fp.readBool(d.skipProb)
+
+	var ymode uint8
+	var bmodes [16]uint8
+	mb.i4 = !fp.readBool(145)
+	if mb.i4 {
+		for y := 0; y < 4; y++ {
+			for x := 0; x < 4; x++ {
+				m := readBMode(fp, &kfBModeProbs[d.intraTop[mbx*4+x]][d.intraLeft[y]])
+				d.intraTop[mbx*4+x] = m
+				d.intraLeft[y] = m
+				bmodes[y*4+x] = m
+			}
+		}
+	} else {
+		var implied uint8
+		if !fp.readBool(156) {
+			if !fp.readBool(163) {
+				ymode, implied = predDC, bpredDC
+			} else {
+				ymode, implied = predV, bpredVE
+			}
+		} else if !fp.readBool(128) {
+			ymode, implied = predH, bpredHE
+		} else {
+			ymode, implied = predTM, bpredTM
+		}
+		for i := 0; i < 4; i++ {
+			d.intraTop[mbx*4+i] = implied
+			d.intraLeft[i] = implied
+		}
+	}
+
+	uvmode := predDC
+	if fp.readBool(142) {
+		switch {
+		case !fp.readBool(114):
+			uvmode = predV
+		case !fp.readBool(183):
+			uvmode = predH
+		default:
+			uvmode = predTM
+		}
+	}
+
+	var coeffs [25 * 16]int32
+	hasCoeffs := false
+	if !skip {
+		hasCoeffs = d.parseResiduals(part, mbx, mb.i4, &d.quant[mb.segment], &coeffs)
+	} else {
+		top := d.nzTop[mbx*9 : mbx*9+9]
+		for i := 0; i < 8; i++ {
+			top[i], d.nzLeft[i] = 0, 0
+		}
+		if !mb.i4 {
+			top[8], d.nzLeft[8] = 0, 0
+		}
+	}
+	mb.inner = mb.i4 || hasCoeffs
+
+	d.reconstruct(mbx, mby, mb.i4, ymode, &bmodes, uvmode, &coeffs)
+}
+
+func readBMode(d *boolDecoder, p *[numBModes - 1]uint8) uint8 {
+	switch {
+	case !d.readBool(p[0]):
+		return bpredDC
+	case !d.readBool(p[1]):
+		return bpredTM
+	case !d.readBool(p[2]):
+		return bpredVE
+	case !d.readBool(p[3]):
+		switch {
+		case !d.readBool(p[4]):
+			return bpredHE
+		case !d.readBool(p[5]):
+			return bpredRD
+		default:
+			return bpredVR
+		}
+	case !d.readBool(p[6]):
+		return bpredLD
+	case !d.readBool(p[7]):
+		return bpredVL
+	case !d.readBool(p[8]):
+		return bpredHD
+	default:
+		return bpredHU
+	}
+}
+
+func boolToUint8(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// parseResiduals reads and dequantizes the DCT coefficients of one
+// macroblock. Blocks 0-15 are luma, 16-19 U, 20-23 V.
+func (d *lossyDecoder) parseResiduals(part *boolDecoder, mbx int, i4 bool, q *quantMatrix, coeffs *[25 * 16]int32) bool {
+	top := d.nzTop[mbx*9 : mbx*9+9]
+	left := &d.nzLeft
+	var any uint8
+
+	blockType, first := 3, 0
+	if !i4 {
+		var dc [16]int32
+		nz := d.parseBlock(part, 1, top[8]+left[8], q.y2, 0, dc[:])
+		top[8], left[8] = nz, nz
+		any |= nz
+		inverseWHT(&dc, coeffs)
+		blockType, first = 0, 1
+	}
+
+	for y := 0; y < 4; y++ {
+		l := left[y]
+		for x := 0; x < 4; x++ {
+			nz := d.parseBlock(part, blockType, top[x]+l, q.y1, first, coeffs[(y*4+x)*16:])
+			top[x], l = nz, nz
+			any |= nz
+		}
+		left[y] = l
+	}
+
+	for p := 0; p < 2; p++ {
+		base := 4 + p*2
+		for y := 0; y < 2; y++ {
+			l := left[base+y]
+			for x := 0; x < 2; x++ {
+				nz := d.parseBlock(part, 2, top[base+x]+l, q.uv, 0, coeffs[(16+p*4+y*2+x)*16:])
+				top[base+x], l = nz, nz
+				any |= nz
+			}
+			left[base+y] = l
+		}
+	}
+	return any != 0
+}
+
+// parseBlock decodes the tokens of one 4x4 block and reports whether it
+// had any.
+func (d *lossyDecoder) parseBlock(part *boolDecoder, blockType int, ctx uint8, q [2]int32, n int, out []int32) uint8 {
+	probs := &d.tokenProbs[blockType]
+	p := &probs[bands[n]][ctx]
+	if !part.readBool(p[0]) {
+		return 0
+	}
+	for n < 16 {
+		if !part.readBool(p[1]) {
+			n++
+			p = &probs[bands[n]][0]
+			continue
+		}
+
+		var v int32
+		next := 1
+		if !part.readBool(p[2]) {
+			v = 1
+		} else {
+			v = readLargeValue(part, p)
+			next = 2
+		}
+		if part.readFlag() {
+			v = -v
+		}
+		out[zigzag[n]] = v * q[min(n, 1)]
+
+		n++
+		p = &probs[bands[n]][next]
+		if n == 16 || !part.readBool(p[0]) {
+			return 1
+		}
+	}
+	return 1
+}
+
+func readLargeValue(d *boolDecoder, p *[numTokenProbs]uint8) int32 {
+	if !d.readBool(p[3]) {
+		if !d.readBool(p[4]) {
+			return 2
+		}
+		return 3 + int32(boolToUint8(d.readBool(p[5])))
+	}
+	if !d.readBool(p[6]) {
+		if !d.readBool(p[7]) {
+			return 5 + int32(boolToUint8(d.readBool(159)))
+		}
+		v := 7 + 2*int32(boolToUint8(d.readBool(165)))
+		return v + int32(boolToUint8(d.readBool(145)))
+	}
+
+	bit1 := boolToUint8(d.readBool(p[8]))
+	bit0 := boolToUint8(d.readBool(p[9+bit1]))
+	cat := 2*bit1 + bit0
+	var v int32
+	for _, prob := range cat3456[cat] {
+		v += v + int32(boolToUint8(d.readBool(prob)))
+	}
+	return v + 3 + 8<<cat
+}
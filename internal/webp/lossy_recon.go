@@ -0,0 +1,651 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package webp
+
+import "image"
+
+// inverseWHT transforms the second-order luma block into the DC coefficient
+// of each of the 16 luma blocks.
+func inverseWHT(in *[16]int32, coeffs *[25 * 16]int32) {
+	var tmp [16]int32
+	for i := 0; i < 4; i++ {
+		a0 := in[i] + in[12+i]
+		a1 := in[4+i] + in[8+i]
+		a2 := in[4+i] - in[8+i]
+		a3 := in[i] - in[12+i]
+		tmp[i] = a0 + a1
+		tmp[8+i] = a0 - a1
+		tmp[4+i] = a3 + a2
+		tmp[12+i] = a3 - a2
+	}
+	for i := 0; i < 4; i++ {
+		dc := tmp[i*4] + 3
+		a0 := dc + tmp[i*4+3]
+		a1 := tmp[i*4+1] + tmp[i*4+2]
+		a2 := tmp[i*4+1] - tmp[i*4+2]
+		a3 := dc - tmp[i*4+3]
+		coeffs[(i*4+0)*16] = (a0 + a1) >> 3
+		coeffs[(i*4+1)*16] = (a3 + a2) >> 3
+		coeffs[(i*4+2)*16] = (a0 - a1) >> 3
+		coeffs[(i*4+3)*16] = (a3 - a2) >> 3
+	}
+}
+
+func mul1(a int32) int32 { return (a*20091)>>16 + a }
+func mul2(a int32) int32 { return (a * 35468) >> 16 }
+
+// idctAdd inverse transforms a 4x4 block of coefficients and adds the
+// result to the prediction at dst[off].
+func idctAdd(in []int32, dst []uint8, off, stride int) {
+	nonZero := false
+	for _, c := range in[:16] {
+		if c != 0 {
+			nonZero = true
+			break
+		}
+	}
+	if !nonZero {
+		return
+	}
+
+	var tmp [16]int32
+	for i := 0; i < 4; i++ {
+		a := in[i] + in[8+i]
+		b := in[i] - in[8+i]
+		c := mul2(in[4+i]) - mul1(in[12+i])
+		d := mul1(in[4+i]) + mul2(in[12+i])
+		tmp[i*4+0] = a + d
+		tmp[i*4+1] = b + c
+		tmp[i*4+2] = b - c
+		tmp[i*4+3] = a - d
+	}
+	for i := 0; i < 4; i++ {
+		dc := tmp[i] + 4
+		a := dc + tmp[8+i]
+		b := dc - tmp[8+i]
+		c := mul2(tmp[4+i]) - mul1(tmp[12+i])
+		d := mul1(tmp[4+i]) + mul2(tmp[12+i])
+		row := dst[off+i*stride:]
+		row[0] = clip8(int32(row[0]) + (a+d)>>3)
+		row[1] = clip8(int32(row[1]) + (b+c)>>3)
+		row[2] = clip8(int32(row[2]) + (b-c)>>3)
+		row[3] = clip8(int32(row[3]) + (a-d)>>3)
+	}
+}
+
+func clip8(v int32) uint8 {
+	return uint8(min(max(v, 0), 255))
+}
+
+// Workspace strides: one row and column of edge pixels around the block,
+// plus four top-right pixels for luma.
+const (
+	lumaStride   = 1 + 16 + 4
+	chromaStride = 1 + 8
+)
+
+func (d *lossyDecoder) reconstruct(mbx, mby int, i4 bool, ymode uint8, bmodes *[16]uint8, uvmode uint8, coeffs *[25 * 16]int32) {
+	var ws [17 * lumaStride]uint8
+	d.loadEdges(ws[:], lumaStride, 16, d.y, d.yStride, mbx, mby)
+
+	if i4 {
+		// Sub-blocks on the right edge use the macroblock's top-right pixels.
+		for r := 4; r < 16; r += 4 {
+			copy(ws[r*lumaStride+17:r*lumaStride+21], ws[17:21])
+		}
+		for by := 0; by < 4; by++ {
+			for bx := 0; bx < 4; bx++ {
+				off := (1+by*4)*lumaStride + 1 + bx*4
+				predictSubBlock(ws[:], off, lumaStride, bmodes[by*4+bx])
+				idctAdd(coeffs[(by*4+bx)*16:], ws[:], off, lumaStride)
+			}
+		}
+	} else {
+		predictBlock(ws[:], lumaStride+1, lumaStride, 16, ymode, mbx > 0, mby > 0)
+		for b := 0; b < 16; b++ {
+			idctAdd(coeffs[b*16:], ws[:], (1+b/4*4)*lumaStride+1+b%4*4, lumaStride)
+		}
+	}
+	for r := 0; r < 16; r++ {
+		copy(d.y[(mby*16+r)*d.yStride+mbx*16:], ws[(1+r)*lumaStride+1:(1+r)*lumaStride+17])
+	}
+
+	for p, plane := range [2][]uint8{d.u, d.v} {
+		var cws [9 * chromaStride]uint8
+		d.loadEdges(cws[:], chromaStride, 8, plane, d.uvStride, mbx, mby)
+		predictBlock(cws[:], chromaStride+1, chromaStride, 8, uvmode, mbx > 0, mby > 0)
+		for b := 0; b < 4; b++ {
+			idctAdd(coeffs[(16+p*4+b)*16:], cws[:], (1+b/2*4)*chromaStride+1+b%2*4, chromaStride)
+		}
+		for r := 0; r < 8; r++ {
+			copy(plane[(mby*8+r)*d.uvStride+mbx*8:], cws[(1+r)*chromaStride+1:(1+r)*chromaStride+9])
+		}
+	}
+}
+
+// loadEdges fills the top row and left column of a prediction workspace
+// from already decoded neighbours, using 127 above and 129 to the left of
+// the frame as the format requires.
+func (d *lossyDecoder) loadEdges(ws []uint8, stride, size int, plane []uint8, planeStride, mbx, mby int) {
+	x0, y0 := mbx*size, mby*size
+
+	if mby == 0 {
+		for i := 0; i < stride; i++ {
+			ws[i] = 127
+		}
+	} else {
+		above := plane[(y0-1)*planeStride:]
+		copy(ws[1:1+size], above[x0:x0+size])
+		if mbx == 0 {
+			ws[0] = 129
+		} else {
+			ws[0] = above[x0-1]
+		}
+		if stride > size+1 {
+			if mbx < d.mbw-1 {
+				copy(ws[1+size:1+size+4], above[x0+size:x0+size+4])
+			} else {
+				for i := 0; i < 4; i++ {
+					ws[1+size+i] = above[x0+size-1]
+				}
+			}
+		}
+	}
+
+	for r := 0; r < size; r++ {
+		if mbx == 0 {
+			ws[(1+r)*stride] = 129
+		} else {
+			ws[(1+r)*stride] = plane[(y0+r)*planeStride+x0-1]
+		}
+	}
+}
+
+// predictBlock applies a whole-block prediction to a 16x16 luma or 8x8
+// chroma block.
+func predictBlock(ws []uint8, off, stride, size int, mode uint8, hasLeft, hasTop bool) {
+	top := ws[off-stride : off-stride+size]
+	switch mode {
+	case predDC:
+		shift := 3
+		if size == 16 {
+			shift = 4
+		}
+		sumTop, sumLeft := 0, 0
+		for i := 0; i < size; i++ {
+			sumTop += int(top[i])
+			sumLeft += int(ws[off+i*stride-1])
+		}
+		var dc int
+		switch {
+		case hasTop && hasLeft:
+			dc = (sumTop + sumLeft + size) >> (shift + 1)
+		case hasTop:
+			dc = (sumTop + size/2) >> shift
+		case hasLeft:
+			dc = (sumLeft + size/2) >> shift
+		default:
+			dc = 128
+		}
+		for r := 0; r < size; r++ {
+			row := ws[off+r*stride : off+r*stride+size]
+			for i := range row {
+				row[i] = uint8(dc)
+			}
+		}
+	case predV:
+		for r := 0; r < size; r++ {
+			copy(ws[off+r*stride:off+r*stride+size], top)
+		}
+	case predH:
+		for r := 0; r < size; r++ {
+			row := ws[off+r*stride : off+r*stride+size]
+			l := ws[off+r*stride-1]
+			for i := range row {
+				row[i] = l
+			}
+		}
+	case predTM:
+		predictTM(ws, off, stride, size)
+	}
+}
+
+func predictTM(ws []uint8, off, stride, size int) {
+	tl := int32(ws[off-stride-1])
+	for r := 0; r < size; r++ {
+		l := int32(ws[off+r*stride-1])
+		for i := 0; i < size; i++ {
+			ws[off+r*stride+i] = clip8(l + int32(ws[off-stride+i]) - tl)
+		}
+	}
+}
+
+func avg2(a, b uint8) uint8 {
+	return uint8((int(a) + int(b) + 1) >> 1)
+}
+
+func avg3(a, b, c uint8) uint8 {
+	return uint8((int(a) + 2*int(b) + int(c) + 2) >> 2)
+}
+
+// predictSubBlock applies one of the ten 4x4 intra predictions.
+func predictSubBlock(ws []uint8, off, stride int, mode uint8) {
+	t := ws[off-stride-1 : off-stride+8] // top-left, then 8 pixels above
+	x, a, b, c, dd := t[0], t[1], t[2], t[3], t[4]
+	e, f, g, h := t[5], t[6], t[7], t[8]
+	i, j, k, l := ws[off-1], ws[off+stride-1], ws[off+2*stride-1], ws[off+3*stride-1]
+
+	set := func(col, row int, v uint8) { ws[off+row*stride+col] = v }
+	fill := func(v [16]uint8) {
+		for r := 0; r < 4; r++ {
+			copy(ws[off+r*stride:off+r*stride+4], v[r*4:r*4+4])
+		}
+	}
+
+	switch mode {
+	case bpredDC:
+		sum := 4
+		for n := 1; n <= 4; n++ {
+			sum += int(t[n])
+		}
+		sum += int(i) + int(j) + int(k) + int(l)
+		v := uint8(sum >> 3)
+		fill([16]uint8{v, v, v, v, v, v, v, v, v, v, v, v, v, v, v, v})
+	case bpredTM:
+		predictTM(ws, off, stride, 4)
+	case bpredVE:
+		row := [4]uint8{avg3(x, a, b), avg3(a, b, c), avg3(b, c, dd), avg3(c, dd, e)}
+		for r := 0; r < 4; r++ {
+			copy(ws[off+r*stride:], row[:])
+		}
+	case bpredHE:
+		rows := [4]uint8{avg3(x, i, j), avg3(i, j, k), avg3(j, k, l), avg3(k, l, l)}
+		for r, v := range rows {
+			fill4 := ws[off+r*stride : off+r*stride+4]
+			for n := range fill4 {
+				fill4[n] = v
+			}
+		}
+	case bpredLD:
+		set(0, 0, avg3(a, b, c))
+		v := avg3(b, c, dd)
+		set(1, 0, v)
+		set(0, 1, v)
+		v = avg3(c, dd, e)
+		set(2, 0, v)
+		set(1, 1, v)
+		set(0, 2, v)
+		v = avg3(dd, e, f)
+		set(3, 0, v)
+		set(2, 1, v)
+		set(1, 2, v)
+		set(0, 3, v)
+		v = avg3(e, f, g)
+		set(3, 1, v)
+		set(2, 2, v)
+		set(1, 3, v)
+		v = avg3(f, g, h)
+		set(3, 2, v)
+		set(2, 3, v)
+		set(3, 3, avg3(g, h, h))
+	case bpredRD:
+		edge := [9]uint8{l, k, j, i, x, a, b, c, dd}
+		for row := 0; row < 4; row++ {
+			for col := 0; col < 4; col++ {
+				n := 4 - row + col
+				set(col, row, avg3(edge[n-1], edge[n], edge[n+1]))
+			}
+		}
+	case bpredVR:
+		v := avg2(x, a)
+		set(0, 0, v)
+		set(1, 2, v)
+		v = avg2(a, b)
+		set(1, 0, v)
+		set(2, 2, v)
+		v = avg2(b, c)
+		set(2, 0, v)
+		set(3, 2, v)
+		set(3, 0, avg2(c, dd))
+		set(0, 3, avg3(k, j, i))
+		set(0, 2, avg3(j, i, x))
+		v = avg3(i, x, a)
+		set(0, 1, v)
+		set(1, 3, v)
+		v = avg3(x, a, b)
+		set(1, 1, v)
+		set(2, 3, v)
+		v = avg3(a, b, c)
+		set(2, 1, v)
+		set(3, 3, v)
+		set(3, 1, avg3(b, c, dd))
+	case bpredVL:
+		set(0, 0, avg2(a, b))
+		v := avg2(b, c)
+		set(1, 0, v)
+		set(0, 2, v)
+		v = avg2(c, dd)
+		set(2, 0, v)
+		set(1, 2, v)
+		v = avg2(dd, e)
+		set(3, 0, v)
+		set(2, 2, v)
+		set(0, 1, avg3(a, b, c))
+		v = avg3(b, c, dd)
+		set(1, 1, v)
+		set(0, 3, v)
+		v = avg3(c, dd, e)
+		set(2, 1, v)
+		set(1, 3, v)
+		v = avg3(dd, e, f)
+		set(3, 1, v)
+		set(2, 3, v)
+		set(3, 2, avg3(e, f, g))
+		set(3, 3, avg3(f, g, h))
+	case bpredHD:
+		v := avg2(i, x)
+		set(0, 0, v)
+		set(2, 1, v)
+		v = avg2(j, i)
+		set(0, 1, v)
+		set(2, 2, v)
+		v = avg2(k, j)
+		set(0, 2, v)
+		set(2, 3, v)
+		set(0, 3, avg2(l, k))
+		set(3, 0, avg3(a, b, c))
+		set(2, 0, avg3(x, a, b))
+		v = avg3(i, x, a)
+		set(1, 0, v)
+		set(3, 1, v)
+		v = avg3(j, i, x)
+		set(1, 1, v)
+		set(3, 2, v)
+		v = avg3(k, j, i)
+		set(1, 2, v)
+		set(3, 3, v)
+		set(1, 3, avg3(l, k, j))
+	case bpredHU:
+		set(0, 0, avg2(i, j))
+		v := avg2(j, k)
+		set(2, 0, v)
+		set(0, 1, v)
+		v = avg2(k, l)
+		set(2, 1, v)
+		set(0, 2, v)
+		set(1, 0, avg3(i, j, k))
+		v = avg3(j, k, l)
+		set(3, 0, v)
+		set(1, 1, v)
+		v = avg3(k, l, l)
+		set(3, 1, v)
+		set(1, 2, v)
+		for _, p := range [][2]int{{3, 2}, {2, 2}, {0, 3}, {1, 3}, {2, 3}, {3, 3}} {
+			set(p[0], p[1], l)
+		}
+	}
+}
+
+// filterFrame applies the in-loop deblocking filter, macroblock by
+// macroblock in raster order.
+func (d *lossyDecoder) filterFrame() {
+	if d.filterLevel == 0 {
+		return
+	}
+
+	var strengths [4][2]filterStrength
+	for s := range strengths {
+		base := d.filterLevel
+		if d.segmentEnabled {
+			base = d.segmentFilter[s]
+			if !d.segmentAbsolute {
+				base += d.filterLevel
+			}
+		}
+		for i4 := 0; i4 < 2; i4++ {
+			level := base
+			if d.useLFDelta {
+				level += d.refLFDelta[0]
+				if i4 == 1 {
+					level += d.modeLFDelta[0]
+				}
+			}
+			level = min(max(level, 0), 63)
+			if level == 0 {
+				continue
+			}
+			ilevel := level
+			if d.sharpness > 0 {
+				if d.sharpness > 4 {
+					ilevel >>= 2
+				} else {
+					ilevel >>= 1
+				}
+				ilevel = min(ilevel, 9-d.sharpness)
+			}
+			ilevel = max(ilevel, 1)
+			hev := 0
+			if level >= 40 {
+				hev = 2
+			} else if level >= 15 {
+				hev = 1
+			}
+			strengths[s][i4] = filterStrength{limit: 2*level + ilevel, ilevel: ilevel, hevThresh: hev}
+		}
+	}
+
+	ys, uvs := d.yStride, d.uvStride
+	for mby := 0; mby < d.mbh; mby++ {
+		for mbx := 0; mbx < d.mbw; mbx++ {
+			mb := d.mbs[mby*d.mbw+mbx]
+			f := strengths[mb.segment][boolToUint8(mb.i4)]
+			if f.limit == 0 {
+				continue
+			}
+			yoff := mby*16*ys + mbx*16
+			uvoff := mby*8*uvs + mbx*8
+
+			if d.simpleFilter {
+				if mbx > 0 {
+					simpleFilter(d.y, yoff, 1, ys, f.limit+4)
+				}
+				if mb.inner {
+					for k := 4; k < 16; k += 4 {
+						simpleFilter(d.y, yoff+k, 1, ys, f.limit)
+					}
+				}
+				if mby > 0 {
+					simpleFilter(d.y, yoff, ys, 1, f.limit+4)
+				}
+				if mb.inner {
+					for k := 4; k < 16; k += 4 {
+						simpleFilter(d.y, yoff+k*ys, ys, 1, f.limit)
+					}
+				}
+				continue
+			}
+
+			if mbx > 0 {
+				normalFilter(d.y, yoff, 1, ys, 16, f.limit+4, f, true)
+				normalFilter(d.u, uvoff, 1, uvs, 8, f.limit+4, f, true)
+				normalFilter(d.v, uvoff, 1, uvs, 8, f.limit+4, f, true)
+			}
+			if mb.inner {
+				for k := 4; k < 16; k += 4 {
+					normalFilter(d.y, yoff+k, 1, ys, 16, f.limit, f, false)
+				}
+				normalFilter(d.u, uvoff+4, 1, uvs, 8, f.limit, f, false)
+				normalFilter(d.v, uvoff+4, 1, uvs, 8, f.limit, f, false)
+			}
+			if mby > 0 {
+				normalFilter(d.y, yoff, ys, 1, 16, f.limit+4, f, true)
+				normalFilter(d.u, uvoff, uvs, 1, 8, f.limit+4, f, true)
+				normalFilter(d.v, uvoff, uvs, 1, 8, f.limit+4, f, true)
+			}
+			if mb.inner {
+				for k := 4; k < 16; k += 4 {
+					normalFilter(d.y, yoff+k*ys, ys, 1, 16, f.limit, f, false)
+				}
+				normalFilter(d.u, uvoff+4*uvs, uvs, 1, 8, f.limit, f, false)
+				normalFilter(d.v, uvoff+4*uvs, uvs, 1, 8, f.limit, f, false)
+			}
+		}
+	}
+}
+
+func absDiff(a, b uint8) int {
+	if a > b {
+		return int(a - b)
+	}
+	return int(b - a)
+}
+
+func sclip1(v int) int  { return min(max(v, -128), 127) }
+func sclip2(v int) int  { return min(max(v, -16), 15) }
+func clip1(v int) uint8 { return uint8(min(max(v, 0), 255)) }
+
+// simpleFilter filters the 16 pixels along an edge starting at pix[p];
+// step crosses the edge and advance moves along it.
+func simpleFilter(pix []uint8, p, step, advance, limit int) {
+	t := 2*limit + 1
+	for n := 0; n < 16; n++ {
+		q := p + n*advance
+		if 4*absDiff(pix[q-step], pix[q])+absDiff(pix[q-2*step], pix[q+step]) <= t {
+			filter2(pix, q, step)
+		}
+	}
+}
+
+func normalFilter(pix []uint8, p, step, advance, size, limit int, f filterStrength, mbEdge bool) {
+	t := 2*limit + 1
+	for n := 0; n < size; n++ {
+		q := p + n*advance
+		p3, p2, p1, p0 := pix[q-4*step], pix[q-3*step], pix[q-2*step], pix[q-step]
+		q0, q1, q2, q3 := pix[q], pix[q+step], pix[q+2*step], pix[q+3*step]
+		if 4*absDiff(p0, q0)+absDiff(p1, q1) > t {
+			continue
+		}
+		it := f.ilevel
+		if absDiff(p3, p2) > it || absDiff(p2, p1) > it || absDiff(p1, p0) > it ||
+			absDiff(q3, q2) > it || absDiff(q2, q1) > it || absDiff(q1, q0) > it {
+			continue
+		}
+		switch {
+		case absDiff(p1, p0) > f.hevThresh || absDiff(q1, q0) > f.hevThresh:
+			filter2(pix, q, step)
+		case mbEdge:
+			filter6(pix, q, step)
+		default:
+			filter4(pix, q, step)
+		}
+	}
+}
+
+func filter2(pix []uint8, q, step int) {
+	p1, p0, q0, q1 := int(pix[q-2*step]), int(pix[q-step]), int(pix[q]), int(pix[q+step])
+	a := 3*(q0-p0) + sclip1(p1-q1)
+	a1 := sclip2((a + 4) >> 3)
+	a2 := sclip2((a + 3) >> 3)
+	pix[q-step] = clip1(p0 + a2)
+	pix[q] = clip1(q0 - a1)
+}
+
+func filter4(pix []uint8, q, step int) {
+	p1, p0, q0, q1 := int(pix[q-2*step]), int(pix[q-step]), int(pix[q]), int(pix[q+step])
+	a := 3 * (q0 - p0)
+	a1 := sclip2((a + 4) >> 3)
+	a2 := sclip2((a + 3) >> 3)
+	a3 := (a1 + 1) >> 1
+	pix[q-2*step] = clip1(p1 + a3)
+	pix[q-step] = clip1(p0 + a2)
+	pix[q] = clip1(q0 - a1)
+	pix[q+step] = clip1(q1 - a3)
+}
+
+func filter6(pix []uint8, q, step int) {
+	p2, p1, p0 := int(pix[q-3*step]), int(pix[q-2*step]), int(pix[q-step])
+	q0, q1, q2 := int(pix[q]), int(pix[q+step]), int(pix[q+2*step])
+	a := sclip1(3*(q0-p0) + sclip1(p1-q1))
+	a1 := (27*a + 63) >> 7
+	a2 := (18*a + 63) >> 7
+	a3 := (9*a + 63) >> 7
+	pix[q-3*step] = clip1(p2 + a3)
+	pix[q-2*step] = clip1(p1 + a2)
+	pix[q-step] = clip1(p0 + a1)
+	pix[q] = clip1(q0 - a1)
+	pix[q+step] = clip1(q1 - a2)
+	pix[q+2*step] = clip1(q2 - a3)
+}
+
+// toNRGBA converts the decoded planes to RGB, upsampling chroma with the
+// bilinear filter libwebp uses.
+func (d *lossyDecoder) toNRGBA() *image.NRGBA {
+	w, h := d.width, d.height
+	cw, ch := (w+1)/2, (h+1)/2
+	img := image.NewNRGBA(image.Rect(0, 0, w, h))
+
+	neighbour := func(pos, n int) (int, int) {
+		near := pos >> 1
+		far := near - 1
+		if pos&1 == 1 {
+			far = near + 1
+		}
+		return near, min(max(far, 0), n-1)
+	}
+	sample := func(plane []uint8, x, y int) int {
+		nx, fx := neighbour(x, cw)
+		ny, fy := neighbour(y, ch)
+		s := d.uvStride
+		return (9*int(plane[ny*s+nx]) + 3*int(plane[ny*s+fx]) + 3*int(plane[fy*s+nx]) + int(plane[fy*s+fx]) + 8) >> 4
+	}
+
+	for y := 0; y < h; y++ {
+		row := img.Pix[y*img.Stride:]
+		for x := 0; x < w; x++ {
+			luma := mulHi(int(d.y[y*d.yStride+x]), 19077)
+			u, v := sample(d.u, x, y), sample(d.v, x, y)
+			row[x*4+0] = yuvClip(luma + mulHi(v, 26149) - 14234)
+			row[x*4+1] = yuvClip(luma - mulHi(u, 6419) - mulHi(v, 13320) + 8708)
+			row[x*4+2] = yuvClip(luma + mulHi(u, 33050) - 17685)
+			row[x*4+3] = 255
+		}
+	}
+	return img
+}
+
+func mulHi(v, coeff int) int {
+	return (v * coeff) >> 8
+}
+
+// yuvClip converts a 14-bit fixed-point colour value to 8 bits.
+func yuvClip(v int) uint8 {
+	if v&^16383 == 0 {
+		return uint8(v >> 6)
+	}
+	if v < 0 {
+		return 0
+	}
+	return 255
+}
@@ -0,0 +1,236 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package webp decodes WebP images: lossy (VP8), lossless (VP8L) and lossy
+// with an alpha channel (VP8X + ALPH). Animated images are not supported.
+//
+// Word cannot display WebP, so images are converted to PNG on insertion.
+package webp
+
+import (
+	"encoding/binary"
+	"image"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+const (
+	opDecode       = "webp.Decode"
+	opDecodeConfig = "webp.DecodeConfig"
+)
+
+// chunks holds the parts of a WebP container needed for decoding.
+type chunks struct {
+	vp8, vp8l, alph []byte
+	width, height   int // canvas size from VP8X, if present
+	animated        bool
+}
+
+// IsWebP reports whether data starts with a WebP RIFF header.
+func IsWebP(data []byte) bool {
+	return len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WEBP"
+}
+
+// DecodeConfig returns the pixel dimensions of a WebP image.
+func DecodeConfig(data []byte) (int, int, error) {
+	c, err := readChunks(data)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, opDecodeConfig)
+	}
+	if c.width > 0 && c.height > 0 {
+		return c.width, c.height, nil
+	}
+
+	switch {
+	case c.vp8l != nil:
+		w, h, _, err := readLosslessHeader(c.vp8l)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, opDecodeConfig)
+		}
+		return w, h, nil
+	case c.vp8 != nil:
+		w, h, err := readLossyHeader(c.vp8)
+		if err != nil {
+			return 0, 0, errors.Wrap(err, opDecodeConfig)
+		}
+		return w, h, nil
+	}
+	return 0, 0, errors.InvalidArgument(opDecodeConfig, "data", len(data), "no image data in WebP file")
+}
+
+// Decode decodes a still WebP image.
+func Decode(data []byte) (*image.NRGBA, error) {
+	c, err := readChunks(data)
+	if err != nil {
+		return nil, errors.Wrap(err, opDecode)
+	}
+	if c.animated {
+		return nil, errors.Unsupported(opDecode, "animated WebP")
+	}
+
+	switch {
+	case c.vp8l != nil:
+		img, err := decodeLossless(c.vp8l)
+		if err != nil {
+			return nil, errors.Wrap(err, opDecode)
+		}
+		return img, nil
+	case c.vp8 != nil:
+		img, err := decodeLossy(c.vp8)
+		if err != nil {
+			return nil, errors.Wrap(err, opDecode)
+		}
+		if c.alph != nil {
+			if err := applyAlpha(img, c.alph); err != nil {
+				return nil, errors.Wrap(err, opDecode)
+			}
+		}
+		return img, nil
+	}
+	return nil, errors.InvalidArgument(opDecode, "data", len(data), "no image data in WebP file")
+}
+
+func readChunks(data []byte) (*chunks, error) {
+	if !IsWebP(data) {
+		return nil, errors.InvalidArgument("webp.readChunks", "data", len(data), "not a WebP file")
+	}
+
+	end := 8 + int(binary.LittleEndian.Uint32(data[4:8]))
+	if end > len(data) || end < 12 {
+		end = len(data)
+	}
+
+	c := &chunks{}
+	for pos := 12; pos+8 <= end; {
+		fourCC := string(data[pos : pos+4])
+		size := int(binary.LittleEndian.Uint32(data[pos+4 : pos+8]))
+		body := pos + 8
+		if size < 0 || body+size > end {
+			return nil, errors.InvalidArgument("webp.readChunks", fourCC, size, "chunk extends beyond end of file")
+		}
+		payload := data[body : body+size]
+
+		switch fourCC {
+		case "VP8 ":
+			if c.vp8 == nil {
+				c.vp8 = payload
+			}
+		case "VP8L":
+			if c.vp8l == nil {
+				c.vp8l = payload
+			}
+		case "ALPH":
+			if c.alph == nil {
+				c.alph = payload
+			}
+		case "VP8X":
+			if len(payload) < 10 {
+				return nil, errors.InvalidArgument("webp.readChunks", "VP8X", size, "truncated VP8X chunk")
+			}
+			c.animated = payload[0]&0x02 != 0
+			c.width = 1 + int(uint32(payload[4])|uint32(payload[5])<<8|uint32(payload[6])<<16)
+			c.height = 1 + int(uint32(payload[7])|uint32(payload[8])<<8|uint32(payload[9])<<16)
+		case "ANIM", "ANMF":
+			c.animated = true
+		}
+
+		// Chunks are padded to an even size.
+		pos = body + size + size&1
+	}
+	return c, nil
+}
+
+// applyAlpha decodes an ALPH chunk into the alpha channel of img.
+func applyAlpha(img *image.NRGBA, chunk []byte) error {
+	if len(chunk) < 1 {
+		return errors.InvalidArgument("webp.applyAlpha", "ALPH", 0, "empty alpha chunk")
+	}
+	header := chunk[0]
+	compression := header & 0x03
+	filter := (header >> 2) & 0x03
+
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	alpha := make([]byte, w*h)
+	switch compression {
+	case 0:
+		if len(chunk)-1 < len(alpha) {
+			return errors.InvalidArgument("webp.applyAlpha", "ALPH", len(chunk), "truncated alpha data")
+		}
+		copy(alpha, chunk[1:])
+	case 1:
+		pix, err := decodeLosslessStream(chunk[1:], w, h)
+		if err != nil {
+			return err
+		}
+		for i, p := range pix {
+			alpha[i] = byte(p >> 8) // alpha values are stored in the green channel
+		}
+	default:
+		return errors.Unsupported("webp.applyAlpha", "alpha compression method")
+	}
+
+	unfilterAlpha(alpha, w, h, filter)
+
+	for y := 0; y < h; y++ {
+		row := img.Pix[y*img.Stride:]
+		for x := 0; x < w; x++ {
+			row[x*4+3] = alpha[y*w+x]
+		}
+	}
+	return nil
+}
+
+// unfilterAlpha reverses the horizontal (1), vertical (2) or gradient (3)
+// prediction filter applied to alpha values.
+func unfilterAlpha(alpha []byte, w, h int, filter byte) {
+	if filter == 0 {
+		return
+	}
+	for y := 0; y < h; y++ {
+		row := alpha[y*w : (y+1)*w]
+		for x := 0; x < w; x++ {
+			var pred byte
+			switch {
+			case x == 0 && y == 0:
+				pred = 0
+			case y == 0:
+				pred = row[x-1]
+			case x == 0:
+				pred = alpha[(y-1)*w]
+			default:
+				left, top := row[x-1], alpha[(y-1)*w+x]
+				switch filter {
+				case 1:
+					pred = left
+				case 2:
+					pred = top
+				default:
+					g := int(left) + int(top) - int(alpha[(y-1)*w+x-1])
+					pred = byte(min(max(g, 0), 255))
+				}
+			}
+			row[x] += pred
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package webp
+
+import (
+	"encoding/hex"
+	"image/color"
+	"testing"
+)
+
+// Fixtures encoded with libwebp; expected pixels come from its decoder.
+var (
+	// 16x16 lossy gradient.
+	lossyWebP = mustHex("5249464650000000574542505650382044000000f001009d012a100010000200" +
+		"3425b00274010f0bfbcbd50000fefc6b515684d54f84732ebf7059d9fff05966" +
+		"f26d6bba4375c75764157ff8060bffff3c7ff3f432000000")
+	// 6x4 lossless image using a colour palette.
+	losslessWebP = mustHex("524946463a000000574542505650384c2d0000002f05c000101730ff028222ff" +
+		"47131014f93f9a80a0e8bae58277262868db8669bb3f884337d811fd8fd88f43" +
+		"2c00")
+	// 8x8 lossy image with a horizontal alpha ramp in an ALPH chunk.
+	alphaWebP = mustHex("524946467600000057454250565038580a00000010000000070000070000414c" +
+		"50481e000000013f20104882d99f728888481d84d9460a6319cb58ceff7b0611" +
+		"fd0f6c1f5650382032000000d001009d012a0800080000c01225a00274ba01f8" +
+		"0003b000feda26ffeef37ed3d7b4f5fd4cfff8ca9f203fe32a7fc5cc0000")
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func TestDecodeConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		w, h int
+	}{
+		{"lossy", lossyWebP, 16, 16},
+		{"lossless", losslessWebP, 6, 4},
+		{"extended", alphaWebP, 8, 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !IsWebP(tt.data) {
+				t.Fatal("IsWebP = false")
+			}
+			w, h, err := DecodeConfig(tt.data)
+			if err != nil {
+				t.Fatalf("DecodeConfig: %v", err)
+			}
+			if w != tt.w || h != tt.h {
+				t.Errorf("DecodeConfig = %dx%d, want %dx%d", w, h, tt.w, tt.h)
+			}
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	type pixel struct {
+		x, y int
+		c    color.NRGBA
+	}
+	tests := []struct {
+		name   string
+		data   []byte
+		pixels []pixel
+	}{
+		{"lossy", lossyWebP, []pixel{
+			{0, 0, color.NRGBA{9, 4, 117, 255}},
+			{15, 0, color.NRGBA{225, 9, 120, 255}},
+			{8, 8, color.NRGBA{132, 130, 128, 255}},
+			{15, 15, color.NRGBA{230, 238, 142, 255}},
+		}},
+		{"lossless", losslessWebP, []pixel{
+			{0, 0, color.NRGBA{255, 0, 0, 255}},
+			{5, 0, color.NRGBA{0, 255, 0, 128}},
+			{3, 2, color.NRGBA{255, 0, 0, 255}},
+			{2, 0, color.NRGBA{0, 0, 255, 255}},
+		}},
+		{"alpha", alphaWebP, []pixel{
+			{0, 0, color.NRGBA{201, 100, 50, 0}},
+			{4, 4, color.NRGBA{201, 100, 50, 144}},
+			{7, 7, color.NRGBA{201, 100, 50, 252}},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img, err := Decode(tt.data)
+			if err != nil {
+				t.Fatalf("Decode: %v", err)
+			}
+			for _, p := range tt.pixels {
+				if got := img.NRGBAAt(p.x, p.y); got != p.c {
+					t.Errorf("pixel (%d,%d) = %v, want %v", p.x, p.y, got, p.c)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	if _, err := Decode([]byte("GIF89a")); err == nil {
+		t.Error("expected error for non-WebP data")
+	}
+	if _, err := Decode(lossyWebP[:40]); err == nil {
+		t.Error("expected error for truncated data")
+	}
+
+	// An animated file: VP8X with the animation flag and an empty ANIM chunk.
+	animated := mustHex("5249464624000000574542505650385" +
+		"80a000000020000000300000300" +
+		"00414e494d06000000ffffffff0000")
+	if w, h, err := DecodeConfig(animated); err != nil || w != 4 || h != 4 {
+		t.Errorf("DecodeConfig(animated) = %d, %d, %v", w, h, err)
+	}
+	if _, err := Decode(animated); err == nil {
+		t.Error("expected error for animated WebP")
+	}
+}
+
+func TestUnfilterAlpha(t *testing.T) {
+	// 3x2 image, gradient filter: row 0 predicts from the left, column 0
+	// from above, the rest from left + top - top-left.
+	alpha := []byte{10, 5, 5, 20, 1, 2}
+	unfilterAlpha(alpha, 3, 2, 3)
+	want := []byte{10, 15, 20, 30, 36, 43}
+	for i := range want {
+		if alpha[i] != want[i] {
+			t.Fatalf("unfilterAlpha = %v, want %v", alpha, want)
+		}
+	}
+}
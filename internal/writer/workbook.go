@@ -0,0 +1,147 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package writer
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+)
+
+// Spreadsheet parts of the minimal workbook.
+const (
+	contentTypeWorkbook  = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"
+	contentTypeWorksheet = "application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"
+	namespaceSpreadsheet = "http://schemas.openxmlformats.org/spreadsheetml/2006/main"
+	relTypeWorksheet     = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+)
+
+// Workbook builds a single-sheet .xlsx package named Sheet1. Cells may be
+// strings, float64 values or nil for empty cells.
+func Workbook(rows [][]interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	sheet, err := worksheetXML(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	parts := []struct {
+		name string
+		data []byte
+	}{
+		{"[Content_Types].xml", []byte(xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="` + contentTypeWorkbook + `"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="` + contentTypeWorksheet + `"/>` +
+			`</Types>`)},
+		{"_rels/.rels", []byte(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`)},
+		{"xl/workbook.xml", []byte(xml.Header + `<workbook xmlns="` + namespaceSpreadsheet + `" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`)},
+		{"xl/_rels/workbook.xml.rels", []byte(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + relTypeWorksheet + `" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`)},
+		{"xl/worksheets/sheet1.xml", sheet},
+	}
+
+	for _, part := range parts {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(part.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+type xlsxWorksheet struct {
+	XMLName xml.Name  `xml:"worksheet"`
+	Xmlns   string    `xml:"xmlns,attr"`
+	Rows    []xlsxRow `xml:"sheetData>row"`
+}
+
+type xlsxRow struct {
+	R     int        `xml:"r,attr"`
+	Cells []xlsxCell `xml:"c"`
+}
+
+type xlsxCell struct {
+	R         string      `xml:"r,attr"`
+	T         string      `xml:"t,attr,omitempty"`
+	V         string      `xml:"v,omitempty"`
+	InlineStr *xlsxString `xml:"is,omitempty"`
+}
+
+type xlsxString struct {
+	T string `xml:"t"`
+}
+
+func worksheetXML(rows [][]interface{}) ([]byte, error) {
+	sheet := xlsxWorksheet{Xmlns: namespaceSpreadsheet}
+	for i, row := range rows {
+		out := xlsxRow{R: i + 1}
+		for j, value := range row {
+			ref := fmt.Sprintf("%s%d", columnName(j), i+1)
+			switch v := value.(type) {
+			case nil:
+				continue
+			case string:
+				out.Cells = append(out.Cells, xlsxCell{R: ref, T: "inlineStr", InlineStr: &xlsxString{T: v}})
+			case float64:
+				out.Cells = append(out.Cells, xlsxCell{R: ref, V: strconv.FormatFloat(v, 'f', -1, 64)})
+			default:
+				return nil, fmt.Errorf("unsupported cell value %T in %s", value, ref)
+			}
+		}
+		sheet.Rows = append(sheet.Rows, out)
+	}
+
+	data, err := xml.Marshal(sheet)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), data...), nil
+}
+
+// columnName converts a zero-based column index to its letters.
+func columnName(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
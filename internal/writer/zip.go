@@ -28,6 +28,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/mmonterroca/docxgo/v2/domain"
 	"github.com/mmonterroca/docxgo/v2/internal/manager"
 	"github.com/mmonterroca/docxgo/v2/internal/serializer"
 	xmlstructs "github.com/mmonterroca/docxgo/v2/internal/xml"
@@ -38,6 +39,17 @@ import (
 type ZipWriter struct {
 	zipWriter  *zip.Writer
 	serializer *serializer.DocumentSerializer
+	parts      []*Part
+	theme      *xmlstructs.Theme
+	thumbnail  bool
+}
+
+// Part is an additional package part such as a chart or an embedded
+// workbook.
+type Part struct {
+	Name        string // Path inside the package, e.g. "word/charts/chart1.xml"
+	ContentType string // Override content type; empty relies on the extension defaults
+	Data        []byte
 }
 
 // NumberingPart represents numbering.xml data that should be preserved in the DOCX package.
@@ -54,6 +66,23 @@ func NewZipWriter(w io.Writer) *ZipWriter {
 	}
 }
 
+// AddPart queues an extra part written by WriteDocument.
+func (zw *ZipWriter) AddPart(name, contentType string, data []byte) {
+	zw.parts = append(zw.parts, &Part{Name: name, ContentType: contentType, Data: data})
+}
+
+// SetThumbnail queues the JPEG preview of the first page that file
+// browsers show, written to docProps/thumbnail.jpeg.
+func (zw *ZipWriter) SetThumbnail(data []byte) {
+	zw.AddPart(constants.PathThumbnail, constants.ContentTypeJPEG, data)
+	zw.thumbnail = true
+}
+
+// SetTheme sets the theme part written by WriteDocument.
+func (zw *ZipWriter) SetTheme(theme *xmlstructs.Theme) {
+	zw.theme = theme
+}
+
 // WriteDocument writes a complete .docx document structure.
 func (zw *ZipWriter) WriteDocument(doc *xmlstructs.Document, rels *xmlstructs.Relationships, coreProps *xmlstructs.CoreProperties, appProps *xmlstructs.AppProperties, styles *xmlstructs.Styles, media []*manager.MediaFile, headers map[string]*xmlstructs.Header, footers map[string]*xmlstructs.Footer, numbering *NumberingPart) error {
 	numberingPart := sanitizeNumberingPart(numbering)
@@ -98,8 +127,8 @@ func (zw *ZipWriter) WriteDocument(doc *xmlstructs.Document, rels *xmlstructs.Re
 		return fmt.Errorf("write font table: %w", err)
 	}
 
-	// Write word/theme/theme1.xml (minimal default)
-	if err := zw.writeDefaultTheme(); err != nil {
+	// Write word/theme/theme1.xml
+	if err := zw.writeTheme(); err != nil {
 		return fmt.Errorf("write theme: %w", err)
 	}
 
@@ -138,6 +167,12 @@ func (zw *ZipWriter) WriteDocument(doc *xmlstructs.Document, rels *xmlstructs.Re
 		}
 	}
 
+	for _, part := range zw.parts {
+		if err := zw.writeRaw(part.Name, part.Data); err != nil {
+			return fmt.Errorf("write part %s: %w", part.Name, err)
+		}
+	}
+
 	return nil
 }
 
@@ -214,6 +249,12 @@ func (zw *ZipWriter) writeContentTypes(headers map[string]*xmlstructs.Header, fo
 		addOverride(fmt.Sprintf("/word/%s", numbering.Target), constants.ContentTypeNumbering)
 	}
 
+	for _, part := range zw.parts {
+		if part.ContentType != "" {
+			addOverride("/"+part.Name, part.ContentType)
+		}
+	}
+
 	return zw.writeXML("[Content_Types].xml", ct)
 }
 
@@ -239,6 +280,13 @@ func (zw *ZipWriter) writeRootRels() error {
 			},
 		},
 	}
+	if zw.thumbnail {
+		rels.Relationships = append(rels.Relationships, &xmlstructs.Relationship{
+			ID:     "rId4",
+			Type:   constants.RelTypeThumbnail,
+			Target: constants.PathThumbnail,
+		})
+	}
 
 	return zw.writeXML("_rels/.rels", rels)
 }
@@ -394,91 +442,13 @@ func (zw *ZipWriter) writeDefaultFontTable() error {
 	return zw.writeRaw("word/fontTable.xml", []byte(fontTable))
 }
 
-// writeDefaultTheme writes minimal word/theme/theme1.xml
-func (zw *ZipWriter) writeDefaultTheme() error {
-	theme := `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
-<a:theme xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" name="Office Theme">
-	<a:themeElements>
-		<a:clrScheme name="Office">
-			<a:dk1><a:sysClr val="windowText" lastClr="000000"/></a:dk1>
-			<a:lt1><a:sysClr val="window" lastClr="FFFFFF"/></a:lt1>
-			<a:dk2><a:srgbClr val="44546A"/></a:dk2>
-			<a:lt2><a:srgbClr val="E7E6E6"/></a:lt2>
-			<a:accent1><a:srgbClr val="4472C4"/></a:accent1>
-			<a:accent2><a:srgbClr val="ED7D31"/></a:accent2>
-			<a:accent3><a:srgbClr val="A5A5A5"/></a:accent3>
-			<a:accent4><a:srgbClr val="FFC000"/></a:accent4>
-			<a:accent5><a:srgbClr val="5B9BD5"/></a:accent5>
-			<a:accent6><a:srgbClr val="70AD47"/></a:accent6>
-			<a:hlink><a:srgbClr val="0563C1"/></a:hlink>
-			<a:folHlink><a:srgbClr val="954F72"/></a:folHlink>
-		</a:clrScheme>
-		<a:fontScheme name="Office">
-			<a:majorFont>
-				<a:latin typeface="Calibri Light"/>
-				<a:ea typeface=""/>
-				<a:cs typeface=""/>
-			</a:majorFont>
-			<a:minorFont>
-				<a:latin typeface="Calibri"/>
-				<a:ea typeface=""/>
-				<a:cs typeface=""/>
-			</a:minorFont>
-		</a:fontScheme>
-		<a:fmtScheme name="Office">
-			<a:fillStyleLst>
-				<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>
-				<a:gradFill rotWithShape="1">
-					<a:gsLst>
-						<a:gs pos="0"><a:schemeClr val="phClr"><a:tint val="50000"/><a:satMod val="300000"/></a:schemeClr></a:gs>
-						<a:gs pos="35000"><a:schemeClr val="phClr"><a:tint val="37000"/><a:satMod val="300000"/></a:schemeClr></a:gs>
-						<a:gs pos="100000"><a:schemeClr val="phClr"><a:tint val="15000"/><a:satMod val="350000"/></a:schemeClr></a:gs>
-					</a:gsLst>
-					<a:lin ang="16200000" scaled="1"/>
-				</a:gradFill>
-				<a:gradFill rotWithShape="1">
-					<a:gsLst>
-						<a:gs pos="0"><a:schemeClr val="phClr"><a:shade val="51000"/><a:satMod val="130000"/></a:schemeClr></a:gs>
-						<a:gs pos="80000"><a:schemeClr val="phClr"><a:shade val="93000"/><a:satMod val="130000"/></a:schemeClr></a:gs>
-						<a:gs pos="100000"><a:schemeClr val="phClr"><a:shade val="94000"/><a:satMod val="350000"/></a:schemeClr></a:gs>
-					</a:gsLst>
-					<a:lin ang="16200000" scaled="1"/>
-				</a:gradFill>
-			</a:fillStyleLst>
-			<a:lnStyleLst>
-				<a:ln w="9525" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill><a:prstDash val="solid"/><a:miter lim="800000"/></a:ln>
-				<a:ln w="25400" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill><a:prstDash val="solid"/><a:miter lim="800000"/></a:ln>
-				<a:ln w="38100" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill><a:prstDash val="solid"/><a:miter lim="800000"/></a:ln>
-			</a:lnStyleLst>
-			<a:effectStyleLst>
-				<a:effectStyle><a:effectLst/></a:effectStyle>
-				<a:effectStyle><a:effectLst/></a:effectStyle>
-				<a:effectStyle>
-					<a:effectLst>
-						<a:outerShdw blurRad="57150" dist="19050" dir="5400000" algn="ctr" rotWithShape="0">
-							<a:srgbClr val="000000"><a:alpha val="63000"/></a:srgbClr>
-						</a:outerShdw>
-					</a:effectLst>
-				</a:effectStyle>
-			</a:effectStyleLst>
-			<a:bgFillStyleLst>
-				<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>
-				<a:solidFill><a:schemeClr val="phClr"><a:tint val="95000"/><a:satMod val="170000"/></a:schemeClr></a:solidFill>
-				<a:gradFill rotWithShape="1">
-					<a:gsLst>
-						<a:gs pos="0"><a:schemeClr val="phClr"><a:tint val="93000"/><a:satMod val="150000"/><a:shade val="98000"/><a:lumMod val="102000"/></a:schemeClr></a:gs>
-						<a:gs pos="50000"><a:schemeClr val="phClr"><a:tint val="98000"/><a:satMod val="130000"/><a:shade val="90000"/><a:lumMod val="103000"/></a:schemeClr></a:gs>
-						<a:gs pos="100000"><a:schemeClr val="phClr"><a:shade val="63000"/><a:satMod val="120000"/></a:schemeClr></a:gs>
-					</a:gsLst>
-					<a:lin ang="16200000" scaled="1"/>
-				</a:gradFill>
-			</a:bgFillStyleLst>
-		</a:fmtScheme>
-	</a:themeElements>
-	<a:objectDefaults/>
-	<a:extraClrSchemeLst/>
-</a:theme>`
-	return zw.writeRaw("word/theme/theme1.xml", []byte(theme))
+// writeTheme writes word/theme/theme1.xml, falling back to the Office theme.
+func (zw *ZipWriter) writeTheme() error {
+	theme := zw.theme
+	if theme == nil {
+		theme = xmlstructs.NewTheme(domain.DefaultDocumentTheme())
+	}
+	return zw.writeXML("word/theme/theme1.xml", theme)
 }
 
 // writeDefaultSettings writes a baseline word/settings.xml part.
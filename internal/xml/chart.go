@@ -0,0 +1,248 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package xml
+
+import "encoding/xml"
+
+// ChartRef references a chart part from a drawing (c:chart).
+type ChartRef struct {
+	XMLName xml.Name `xml:"c:chart"`
+	XmlnsC  string   `xml:"xmlns:c,attr"`
+	XmlnsR  string   `xml:"xmlns:r,attr"`
+	ID      string   `xml:"r:id,attr"`
+}
+
+// ChartSpace is the root of a chart part (c:chartSpace).
+type ChartSpace struct {
+	XMLName        xml.Name      `xml:"c:chartSpace"`
+	XmlnsC         string        `xml:"xmlns:c,attr"`
+	XmlnsA         string        `xml:"xmlns:a,attr"`
+	XmlnsR         string        `xml:"xmlns:r,attr"`
+	Date1904       *ChartValue   `xml:"c:date1904"`
+	RoundedCorners *ChartValue   `xml:"c:roundedCorners"`
+	Chart          *Chart        `xml:"c:chart"`
+	ExternalData   *ExternalData `xml:"c:externalData,omitempty"`
+}
+
+// ChartValue is a chart element carrying a single val attribute.
+type ChartValue struct {
+	Val string `xml:"val,attr"`
+}
+
+// ChartEmpty is a chart element without content, such as c:layout.
+type ChartEmpty struct{}
+
+// Chart holds the title, plot area and legend of a chart.
+type Chart struct {
+	Title            *ChartTitle `xml:"c:title,omitempty"`
+	AutoTitleDeleted *ChartValue `xml:"c:autoTitleDeleted"`
+	PlotArea         *PlotArea   `xml:"c:plotArea"`
+	Legend           *Legend     `xml:"c:legend,omitempty"`
+	PlotVisOnly      *ChartValue `xml:"c:plotVisOnly"`
+	DispBlanksAs     *ChartValue `xml:"c:dispBlanksAs"`
+}
+
+// ChartTitle is the title of a chart or axis.
+type ChartTitle struct {
+	Tx      *ChartText  `xml:"c:tx"`
+	Overlay *ChartValue `xml:"c:overlay"`
+}
+
+// ChartText holds rich title text.
+type ChartText struct {
+	Rich *RichText `xml:"c:rich"`
+}
+
+// RichText is DrawingML text used in chart titles.
+type RichText struct {
+	BodyPr *ChartEmpty      `xml:"a:bodyPr"`
+	P      []*TextParagraph `xml:"a:p"`
+}
+
+// TextParagraph is a DrawingML text paragraph.
+type TextParagraph struct {
+	R *TextRun `xml:"a:r"`
+}
+
+// TextRun is a DrawingML text run.
+type TextRun struct {
+	T string `xml:"a:t"`
+}
+
+// PlotArea holds the chart groups and axes.
+type PlotArea struct {
+	Layout       *ChartEmpty  `xml:"c:layout"`
+	BarChart     *ChartGroup  `xml:"c:barChart,omitempty"`
+	LineChart    *ChartGroup  `xml:"c:lineChart,omitempty"`
+	PieChart     *ChartGroup  `xml:"c:pieChart,omitempty"`
+	ScatterChart *ChartGroup  `xml:"c:scatterChart,omitempty"`
+	CatAx        *ChartAxis   `xml:"c:catAx,omitempty"`
+	ValAx        []*ChartAxis `xml:"c:valAx"`
+}
+
+// ChartGroup is a bar, line, pie or scatter chart. Each kind uses a subset
+// of the fields, which are in schema order for all of them.
+type ChartGroup struct {
+	BarDir        *ChartValue    `xml:"c:barDir,omitempty"`
+	ScatterStyle  *ChartValue    `xml:"c:scatterStyle,omitempty"`
+	Grouping      *ChartValue    `xml:"c:grouping,omitempty"`
+	VaryColors    *ChartValue    `xml:"c:varyColors"`
+	Ser           []*ChartSeries `xml:"c:ser"`
+	DLbls         *DataLabels    `xml:"c:dLbls,omitempty"`
+	GapWidth      *ChartValue    `xml:"c:gapWidth,omitempty"`
+	Marker        *ChartValue    `xml:"c:marker,omitempty"`
+	FirstSliceAng *ChartValue    `xml:"c:firstSliceAng,omitempty"`
+	AxID          []*ChartValue  `xml:"c:axId"`
+}
+
+// ChartSeries is a data series. Each chart kind uses a subset of the
+// fields, which are in schema order for all of them.
+type ChartSeries struct {
+	Idx              *ChartValue       `xml:"c:idx"`
+	Order            *ChartValue       `xml:"c:order"`
+	Tx               *SeriesText       `xml:"c:tx,omitempty"`
+	SpPr             *ChartSpPr        `xml:"c:spPr,omitempty"`
+	InvertIfNegative *ChartValue       `xml:"c:invertIfNegative,omitempty"`
+	Marker           *ChartMarker      `xml:"c:marker,omitempty"`
+	DPt              []*ChartDataPoint `xml:"c:dPt"`
+	Cat              *ChartData        `xml:"c:cat,omitempty"`
+	Val              *ChartData        `xml:"c:val,omitempty"`
+	XVal             *ChartData        `xml:"c:xVal,omitempty"`
+	YVal             *ChartData        `xml:"c:yVal,omitempty"`
+	Smooth           *ChartValue       `xml:"c:smooth,omitempty"`
+}
+
+// SeriesText references the series name.
+type SeriesText struct {
+	StrRef *StrRef `xml:"c:strRef"`
+}
+
+// ChartSpPr holds the fill and outline of a chart element.
+type ChartSpPr struct {
+	NoFill    *NoFill    `xml:"a:noFill,omitempty"`
+	SolidFill *SolidFill `xml:"a:solidFill,omitempty"`
+	Ln        *Ln        `xml:"a:ln,omitempty"`
+}
+
+// ChartMarker is the marker drawn at line and scatter points.
+type ChartMarker struct {
+	Symbol *ChartValue `xml:"c:symbol"`
+	Size   *ChartValue `xml:"c:size,omitempty"`
+	SpPr   *ChartSpPr  `xml:"c:spPr,omitempty"`
+}
+
+// ChartDataPoint formats a single point, such as a pie slice.
+type ChartDataPoint struct {
+	Idx      *ChartValue `xml:"c:idx"`
+	Bubble3D *ChartValue `xml:"c:bubble3D"`
+	SpPr     *ChartSpPr  `xml:"c:spPr"`
+}
+
+// DataLabels controls the labels shown next to data points.
+type DataLabels struct {
+	ShowLegendKey  *ChartValue `xml:"c:showLegendKey"`
+	ShowVal        *ChartValue `xml:"c:showVal"`
+	ShowCatName    *ChartValue `xml:"c:showCatName"`
+	ShowSerName    *ChartValue `xml:"c:showSerName"`
+	ShowPercent    *ChartValue `xml:"c:showPercent"`
+	ShowBubbleSize *ChartValue `xml:"c:showBubbleSize"`
+}
+
+// ChartData references categories or values in the embedded workbook.
+type ChartData struct {
+	StrRef *StrRef `xml:"c:strRef,omitempty"`
+	NumRef *NumRef `xml:"c:numRef,omitempty"`
+}
+
+// StrRef is a text range with its cached values.
+type StrRef struct {
+	F        string     `xml:"c:f"`
+	StrCache *DataCache `xml:"c:strCache"`
+}
+
+// NumRef is a number range with its cached values.
+type NumRef struct {
+	F        string     `xml:"c:f"`
+	NumCache *DataCache `xml:"c:numCache"`
+}
+
+// DataCache holds the cached values of a range.
+type DataCache struct {
+	FormatCode string        `xml:"c:formatCode,omitempty"`
+	PtCount    *ChartValue   `xml:"c:ptCount"`
+	Pt         []*CachePoint `xml:"c:pt"`
+}
+
+// CachePoint is a single cached value.
+type CachePoint struct {
+	Idx int    `xml:"idx,attr"`
+	V   string `xml:"c:v"`
+}
+
+// ChartAxis is a category or value axis. Category-only and value-only
+// fields are left nil on the other kind.
+type ChartAxis struct {
+	AxID           *ChartValue   `xml:"c:axId"`
+	Scaling        *ChartScaling `xml:"c:scaling"`
+	Delete         *ChartValue   `xml:"c:delete"`
+	AxPos          *ChartValue   `xml:"c:axPos"`
+	MajorGridlines *ChartEmpty   `xml:"c:majorGridlines,omitempty"`
+	Title          *ChartTitle   `xml:"c:title,omitempty"`
+	NumFmt         *ChartNumFmt  `xml:"c:numFmt,omitempty"`
+	MajorTickMark  *ChartValue   `xml:"c:majorTickMark"`
+	MinorTickMark  *ChartValue   `xml:"c:minorTickMark"`
+	TickLblPos     *ChartValue   `xml:"c:tickLblPos"`
+	CrossAx        *ChartValue   `xml:"c:crossAx"`
+	Crosses        *ChartValue   `xml:"c:crosses"`
+	Auto           *ChartValue   `xml:"c:auto,omitempty"`
+	LblAlgn        *ChartValue   `xml:"c:lblAlgn,omitempty"`
+	LblOffset      *ChartValue   `xml:"c:lblOffset,omitempty"`
+	CrossBetween   *ChartValue   `xml:"c:crossBetween,omitempty"`
+}
+
+// ChartScaling sets the axis orientation and bounds.
+type ChartScaling struct {
+	Orientation *ChartValue `xml:"c:orientation"`
+	Max         *ChartValue `xml:"c:max,omitempty"`
+	Min         *ChartValue `xml:"c:min,omitempty"`
+}
+
+// ChartNumFmt is the number format of axis labels.
+type ChartNumFmt struct {
+	FormatCode   string `xml:"formatCode,attr"`
+	SourceLinked string `xml:"sourceLinked,attr"`
+}
+
+// Legend places the chart legend.
+type Legend struct {
+	LegendPos *ChartValue `xml:"c:legendPos"`
+	Overlay   *ChartValue `xml:"c:overlay"`
+}
+
+// ExternalData links a chart to its embedded workbook.
+type ExternalData struct {
+	ID         string      `xml:"r:id,attr"`
+	AutoUpdate *ChartValue `xml:"c:autoUpdate"`
+}
@@ -0,0 +1,335 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package xml
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// Axis IDs linking chart groups to their axes.
+const (
+	chartAxisX = "500000001"
+	chartAxisY = "500000002"
+)
+
+// NewChartDrawing creates a drawing referencing the chart part relID.
+func NewChartDrawing(chart domain.Chart, drawingID int) *Drawing {
+	docPr := &DocPr{
+		ID:    drawingID,
+		Name:  fmt.Sprintf("Chart %d", drawingID),
+		Descr: chart.Description(),
+	}
+	graphic := &Graphic{
+		Xmlns: namespaceDrawingML,
+		GraphicData: &GraphicData{
+			URI: constants.NamespaceChart,
+			Chart: &ChartRef{
+				XmlnsC: constants.NamespaceChart,
+				XmlnsR: constants.NamespaceRelationships,
+				ID:     chart.RelationshipID(),
+			},
+		},
+	}
+	return newPlacedDrawing(chart.Size(), chart.Position(), docPr, graphic)
+}
+
+// ChartSheetRows lays out the chart data as it is stored in the embedded
+// workbook. Cells are strings, float64 values or nil. Categories fill
+// column A and each series a column after it; scatter series take an X
+// and a Y column each.
+func ChartSheetRows(spec domain.ChartSpec) [][]interface{} {
+	if spec.Type == domain.ChartScatter {
+		points := 0
+		for _, series := range spec.Series {
+			points = max(points, len(series.Values))
+		}
+		rows := newSheetRows(points+1, 2*len(spec.Series))
+		for i, series := range spec.Series {
+			rows[0][2*i], rows[0][2*i+1] = "X", seriesName(series, i)
+			for j, y := range series.Values {
+				rows[j+1][2*i], rows[j+1][2*i+1] = series.XValues[j], y
+			}
+		}
+		return rows
+	}
+
+	rows := newSheetRows(len(spec.Categories)+1, len(spec.Series)+1)
+	rows[0][0] = ""
+	for j, category := range spec.Categories {
+		rows[j+1][0] = category
+	}
+	for i, series := range spec.Series {
+		rows[0][i+1] = seriesName(series, i)
+		for j, v := range series.Values {
+			rows[j+1][i+1] = v
+		}
+	}
+	return rows
+}
+
+func newSheetRows(rows, cols int) [][]interface{} {
+	out := make([][]interface{}, rows)
+	for i := range out {
+		out[i] = make([]interface{}, cols)
+	}
+	return out
+}
+
+func seriesName(series domain.ChartSeries, index int) string {
+	if series.Name != "" {
+		return series.Name
+	}
+	return fmt.Sprintf("Series %d", index+1)
+}
+
+// NewChartSpace builds a chart part. colors supplies the series (or pie
+// slice) colors in turn and workbookRelID links the embedded workbook.
+func NewChartSpace(spec domain.ChartSpec, colors []domain.Color, workbookRelID string) *ChartSpace {
+	plot := &PlotArea{Layout: &ChartEmpty{}}
+	group := &ChartGroup{VaryColors: chartVal("0")}
+	if spec.DataLabels {
+		group.DLbls = &DataLabels{
+			ShowLegendKey:  chartVal("0"),
+			ShowVal:        chartVal("1"),
+			ShowCatName:    chartVal("0"),
+			ShowSerName:    chartVal("0"),
+			ShowPercent:    chartVal("0"),
+			ShowBubbleSize: chartVal("0"),
+		}
+	}
+
+	categories := len(spec.Categories)
+	for i, series := range spec.Series {
+		ser := &ChartSeries{
+			Idx:   chartVal(strconv.Itoa(i)),
+			Order: chartVal(strconv.Itoa(i)),
+		}
+		fill := colorFill(colors[i%len(colors)])
+
+		if spec.Type == domain.ChartScatter {
+			xCol, yCol := sheetColumn(2*i), sheetColumn(2*i+1)
+			ser.Tx = &SeriesText{StrRef: strRef(fmt.Sprintf("Sheet1!$%s$1", yCol), []string{seriesName(series, i)})}
+			ser.SpPr = &ChartSpPr{Ln: &Ln{NoFill: &NoFill{}}}
+			ser.Marker = &ChartMarker{Symbol: chartVal("circle"), Size: chartVal("7"), SpPr: &ChartSpPr{SolidFill: fill}}
+			ser.XVal = &ChartData{NumRef: numRef(columnRange(xCol, len(series.XValues)), series.XValues)}
+			ser.YVal = &ChartData{NumRef: numRef(columnRange(yCol, len(series.Values)), series.Values)}
+			ser.Smooth = chartVal("0")
+			group.Ser = append(group.Ser, ser)
+			continue
+		}
+
+		col := sheetColumn(i + 1)
+		ser.Tx = &SeriesText{StrRef: strRef(fmt.Sprintf("Sheet1!$%s$1", col), []string{seriesName(series, i)})}
+		ser.Cat = &ChartData{StrRef: strRef(columnRange("A", categories), spec.Categories)}
+		ser.Val = &ChartData{NumRef: numRef(columnRange(col, categories), series.Values)}
+
+		switch spec.Type {
+		case domain.ChartPie:
+			for j := range spec.Categories {
+				ser.DPt = append(ser.DPt, &ChartDataPoint{
+					Idx:      chartVal(strconv.Itoa(j)),
+					Bubble3D: chartVal("0"),
+					SpPr:     &ChartSpPr{SolidFill: colorFill(colors[j%len(colors)])},
+				})
+			}
+		case domain.ChartLine:
+			ser.SpPr = &ChartSpPr{Ln: &Ln{W: 28575, SolidFill: fill}}
+			ser.Marker = &ChartMarker{Symbol: chartVal("none")}
+			ser.Smooth = chartVal("0")
+		default:
+			ser.SpPr = &ChartSpPr{SolidFill: fill}
+			ser.InvertIfNegative = chartVal("0")
+		}
+		group.Ser = append(group.Ser, ser)
+	}
+
+	xAxis := newChartAxis(chartAxisX, chartAxisY, "b", spec.XAxis)
+	yAxis := newChartAxis(chartAxisY, chartAxisX, "l", spec.YAxis)
+	yAxis.CrossBetween = chartVal("between")
+	group.AxID = []*ChartValue{chartVal(chartAxisX), chartVal(chartAxisY)}
+
+	switch spec.Type {
+	case domain.ChartPie:
+		group.VaryColors = chartVal("1")
+		group.FirstSliceAng = chartVal("0")
+		group.AxID = nil
+		plot.PieChart = group
+	case domain.ChartLine:
+		group.Grouping = chartVal("standard")
+		group.Marker = chartVal("1")
+		plot.LineChart = group
+	case domain.ChartScatter:
+		group.ScatterStyle = chartVal("lineMarker")
+		plot.ScatterChart = group
+		xAxis.CrossBetween = chartVal("midCat")
+		yAxis.CrossBetween = chartVal("midCat")
+	default:
+		group.BarDir = chartVal("col")
+		if spec.Type == domain.ChartBar {
+			group.BarDir = chartVal("bar")
+			xAxis.AxPos, yAxis.AxPos = chartVal("l"), chartVal("b")
+		}
+		group.Grouping = chartVal("clustered")
+		group.GapWidth = chartVal("150")
+		plot.BarChart = group
+	}
+
+	switch spec.Type {
+	case domain.ChartPie:
+	case domain.ChartScatter:
+		plot.ValAx = []*ChartAxis{xAxis, yAxis}
+	default:
+		xAxis.Scaling.Max, xAxis.Scaling.Min = nil, nil
+		xAxis.Auto = chartVal("1")
+		xAxis.LblAlgn = chartVal("ctr")
+		xAxis.LblOffset = chartVal("100")
+		plot.CatAx = xAxis
+		plot.ValAx = []*ChartAxis{yAxis}
+	}
+
+	chart := &Chart{
+		AutoTitleDeleted: chartVal("1"),
+		PlotArea:         plot,
+		PlotVisOnly:      chartVal("1"),
+		DispBlanksAs:     chartVal("gap"),
+	}
+	if spec.Title != "" {
+		chart.Title = newChartTitle(spec.Title)
+		chart.AutoTitleDeleted = chartVal("0")
+	}
+	if pos, ok := legendPositions[spec.Legend]; ok {
+		chart.Legend = &Legend{LegendPos: chartVal(pos), Overlay: chartVal("0")}
+	}
+
+	space := &ChartSpace{
+		XmlnsC:         constants.NamespaceChart,
+		XmlnsA:         constants.NamespaceDrawing,
+		XmlnsR:         constants.NamespaceRelationships,
+		Date1904:       chartVal("0"),
+		RoundedCorners: chartVal("0"),
+		Chart:          chart,
+	}
+	if workbookRelID != "" {
+		space.ExternalData = &ExternalData{ID: workbookRelID, AutoUpdate: chartVal("0")}
+	}
+	return space
+}
+
+// legendPositions maps legend positions to chart values; LegendNone is
+// absent.
+var legendPositions = map[domain.LegendPosition]string{
+	domain.LegendRight:  "r",
+	domain.LegendTop:    "t",
+	domain.LegendBottom: "b",
+	domain.LegendLeft:   "l",
+}
+
+func newChartAxis(id, crossID, pos string, axis domain.ChartAxis) *ChartAxis {
+	out := &ChartAxis{
+		AxID:          chartVal(id),
+		Scaling:       &ChartScaling{Orientation: chartVal("minMax")},
+		Delete:        chartVal("0"),
+		AxPos:         chartVal(pos),
+		MajorTickMark: chartVal("out"),
+		MinorTickMark: chartVal("none"),
+		TickLblPos:    chartVal("nextTo"),
+		CrossAx:       chartVal(crossID),
+		Crosses:       chartVal("autoZero"),
+	}
+	if axis.Max > axis.Min {
+		out.Scaling.Max = chartVal(formatChartNumber(axis.Max))
+		out.Scaling.Min = chartVal(formatChartNumber(axis.Min))
+	}
+	if axis.Hidden {
+		out.Delete = chartVal("1")
+	}
+	if axis.Gridlines {
+		out.MajorGridlines = &ChartEmpty{}
+	}
+	if axis.Title != "" {
+		out.Title = newChartTitle(axis.Title)
+	}
+	if axis.NumberFormat != "" {
+		out.NumFmt = &ChartNumFmt{FormatCode: axis.NumberFormat, SourceLinked: "0"}
+	}
+	return out
+}
+
+func newChartTitle(text string) *ChartTitle {
+	return &ChartTitle{
+		Tx: &ChartText{Rich: &RichText{
+			BodyPr: &ChartEmpty{},
+			P:      []*TextParagraph{{R: &TextRun{T: text}}},
+		}},
+		Overlay: chartVal("0"),
+	}
+}
+
+func strRef(ref string, values []string) *StrRef {
+	cache := &DataCache{PtCount: chartVal(strconv.Itoa(len(values)))}
+	for i, v := range values {
+		cache.Pt = append(cache.Pt, &CachePoint{Idx: i, V: v})
+	}
+	return &StrRef{F: ref, StrCache: cache}
+}
+
+func numRef(ref string, values []float64) *NumRef {
+	cache := &DataCache{FormatCode: "General", PtCount: chartVal(strconv.Itoa(len(values)))}
+	for i, v := range values {
+		cache.Pt = append(cache.Pt, &CachePoint{Idx: i, V: formatChartNumber(v)})
+	}
+	return &NumRef{F: ref, NumCache: cache}
+}
+
+// columnRange returns the sheet range of n values below a header row.
+func columnRange(col string, n int) string {
+	return fmt.Sprintf("Sheet1!$%s$2:$%s$%d", col, col, n+1)
+}
+
+// sheetColumn converts a zero-based column index to its letters.
+func sheetColumn(index int) string {
+	name := ""
+	for index++; index > 0; index = (index - 1) / 26 {
+		name = string(rune('A'+(index-1)%26)) + name
+	}
+	return name
+}
+
+func formatChartNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+func colorFill(c domain.Color) *SolidFill {
+	return &SolidFill{SrgbClr: &SrgbClr{Val: color.ToHex(c)}}
+}
+
+func chartVal(val string) *ChartValue {
+	return &ChartValue{Val: val}
+}
@@ -89,10 +89,18 @@ type EffectExtent struct {
 
 // DocPr represents non-visual drawing properties.
 type DocPr struct {
-	XMLName xml.Name `xml:"wp:docPr"`
-	ID      int      `xml:"id,attr"`
-	Name    string   `xml:"name,attr"`
-	Descr   string   `xml:"descr,attr,omitempty"` // Alt text
+	XMLName    xml.Name    `xml:"wp:docPr"`
+	ID         int         `xml:"id,attr"`
+	Name       string      `xml:"name,attr"`
+	Descr      string      `xml:"descr,attr,omitempty"` // Alt text
+	HlinkClick *HlinkClick `xml:"a:hlinkClick,omitempty"`
+}
+
+// HlinkClick represents the hyperlink followed when a drawing is clicked.
+type HlinkClick struct {
+	XMLName xml.Name `xml:"a:hlinkClick"`
+	XmlnsA  string   `xml:"xmlns:a,attr,omitempty"` // Needed outside a:graphic
+	ID      string   `xml:"r:id,attr"`
 }
 
 // CNvGraphicFramePr represents non-visual graphic frame properties.
@@ -147,9 +155,11 @@ type Graphic struct {
 
 // GraphicData represents the graphic data container.
 type GraphicData struct {
-	XMLName xml.Name `xml:"a:graphicData"`
-	URI     string   `xml:"uri,attr"` // Namespace URI
-	Pic     *Pic     `xml:"pic:pic,omitempty"`
+	XMLName xml.Name  `xml:"a:graphicData"`
+	URI     string    `xml:"uri,attr"` // Namespace URI
+	Pic     *Pic      `xml:"pic:pic,omitempty"`
+	Wsp     *Wsp      `xml:"wps:wsp,omitempty"`
+	Chart   *ChartRef `xml:"c:chart,omitempty"`
 }
 
 // Pic represents a picture element.
@@ -170,10 +180,11 @@ type NvPicPr struct {
 
 // CNvPr represents non-visual drawing properties.
 type CNvPr struct {
-	XMLName xml.Name `xml:"pic:cNvPr"`
-	ID      int      `xml:"id,attr"`
-	Name    string   `xml:"name,attr"`
-	Descr   string   `xml:"descr,attr,omitempty"`
+	XMLName    xml.Name    `xml:"pic:cNvPr"`
+	ID         int         `xml:"id,attr"`
+	Name       string      `xml:"name,attr"`
+	Descr      string      `xml:"descr,attr,omitempty"`
+	HlinkClick *HlinkClick `xml:"a:hlinkClick,omitempty"`
 }
 
 // CNvPicPr represents non-visual picture drawing properties.
@@ -193,14 +204,67 @@ type PicLocks struct {
 type BlipFill struct {
 	XMLName xml.Name `xml:"pic:blipFill"`
 	Blip    *Blip    `xml:"a:blip"`
+	SrcRect *SrcRect `xml:"a:srcRect,omitempty"`
 	Stretch *Stretch `xml:"a:stretch,omitempty"`
 }
 
+// SrcRect crops the image. Each edge is in thousandths of a percent.
+type SrcRect struct {
+	XMLName xml.Name `xml:"a:srcRect"`
+	L       int      `xml:"l,attr,omitempty"`
+	T       int      `xml:"t,attr,omitempty"`
+	R       int      `xml:"r,attr,omitempty"`
+	B       int      `xml:"b,attr,omitempty"`
+}
+
 // Blip represents the embedded or linked image.
 type Blip struct {
-	XMLName xml.Name `xml:"a:blip"`
-	Xmlns   string   `xml:"xmlns:r,attr"`
-	Embed   string   `xml:"r:embed,attr"` // Relationship ID
+	XMLName     xml.Name     `xml:"a:blip"`
+	Xmlns       string       `xml:"xmlns:r,attr"`
+	Embed       string       `xml:"r:embed,attr"` // Relationship ID
+	AlphaModFix *AlphaModFix `xml:"a:alphaModFix,omitempty"`
+	Grayscl     *Grayscl     `xml:"a:grayscl,omitempty"`
+	Lum         *Lum         `xml:"a:lum,omitempty"`
+	ExtLst      *BlipExtLst  `xml:"a:extLst,omitempty"`
+}
+
+// AlphaModFix scales the image opacity; Amt is in thousandths of a percent.
+type AlphaModFix struct {
+	XMLName xml.Name `xml:"a:alphaModFix"`
+	Amt     int      `xml:"amt,attr"`
+}
+
+// Grayscl renders the image in shades of gray.
+type Grayscl struct {
+	XMLName xml.Name `xml:"a:grayscl"`
+}
+
+// Lum adjusts brightness and contrast, in thousandths of a percent.
+type Lum struct {
+	XMLName  xml.Name `xml:"a:lum"`
+	Bright   int      `xml:"bright,attr,omitempty"`
+	Contrast int      `xml:"contrast,attr,omitempty"`
+}
+
+// BlipExtLst holds blip extensions such as the SVG image reference.
+type BlipExtLst struct {
+	XMLName xml.Name   `xml:"a:extLst"`
+	Ext     []*BlipExt `xml:"a:ext"`
+}
+
+// BlipExt is a single blip extension identified by its URI.
+type BlipExt struct {
+	XMLName xml.Name `xml:"a:ext"`
+	URI     string   `xml:"uri,attr"`
+	SVGBlip *SVGBlip `xml:"asvg:svgBlip,omitempty"`
+}
+
+// SVGBlip references the SVG part of a picture; the enclosing blip then
+// holds the bitmap fallback.
+type SVGBlip struct {
+	XMLName xml.Name `xml:"asvg:svgBlip"`
+	Xmlns   string   `xml:"xmlns:asvg,attr"`
+	Embed   string   `xml:"r:embed,attr"`
 }
 
 // Stretch represents stretch fill mode.
@@ -216,14 +280,19 @@ type FillRect struct {
 
 // SpPr represents shape properties.
 type SpPr struct {
-	XMLName  xml.Name  `xml:"pic:spPr"`
-	Xfrm     *Xfrm     `xml:"a:xfrm"`
-	PrstGeom *PrstGeom `xml:"a:prstGeom"`
+	XMLName   xml.Name   `xml:"pic:spPr"`
+	Xfrm      *Xfrm      `xml:"a:xfrm"`
+	PrstGeom  *PrstGeom  `xml:"a:prstGeom"`
+	Ln        *Ln        `xml:"a:ln,omitempty"`
+	EffectLst *EffectLst `xml:"a:effectLst,omitempty"`
 }
 
 // Xfrm represents 2D transform.
 type Xfrm struct {
 	XMLName xml.Name `xml:"a:xfrm"`
+	Rot     int      `xml:"rot,attr,omitempty"` // Clockwise, in 60000ths of a degree
+	FlipH   bool     `xml:"flipH,attr,omitempty"`
+	FlipV   bool     `xml:"flipV,attr,omitempty"`
 	Off     *Off     `xml:"a:off,omitempty"`
 	Ext     *Ext     `xml:"a:ext"`
 }
@@ -253,3 +322,123 @@ type PrstGeom struct {
 type AvLst struct {
 	XMLName xml.Name `xml:"a:avLst"`
 }
+
+// Ln represents a shape outline.
+type Ln struct {
+	XMLName   xml.Name   `xml:"a:ln"`
+	W         int        `xml:"w,attr,omitempty"` // Width in EMUs
+	NoFill    *NoFill    `xml:"a:noFill,omitempty"`
+	SolidFill *SolidFill `xml:"a:solidFill,omitempty"`
+	TailEnd   *LineEnd   `xml:"a:tailEnd,omitempty"`
+}
+
+// NoFill leaves a shape or line unpainted.
+type NoFill struct {
+	XMLName xml.Name `xml:"a:noFill"`
+}
+
+// LineEnd decorates the end of a line, e.g. with an arrowhead.
+type LineEnd struct {
+	Type string `xml:"type,attr"` // none, triangle, arrow, stealth, diamond, oval
+}
+
+// SolidFill represents a solid color fill.
+type SolidFill struct {
+	XMLName xml.Name `xml:"a:solidFill"`
+	SrgbClr *SrgbClr `xml:"a:srgbClr,omitempty"`
+}
+
+// SrgbClr represents an RGB color with an optional alpha.
+type SrgbClr struct {
+	XMLName xml.Name `xml:"a:srgbClr"`
+	Val     string   `xml:"val,attr"`
+	Alpha   *Alpha   `xml:"a:alpha,omitempty"`
+}
+
+// Alpha sets color opacity in thousandths of a percent.
+type Alpha struct {
+	XMLName xml.Name `xml:"a:alpha"`
+	Val     int      `xml:"val,attr"`
+}
+
+// EffectLst represents a list of shape effects.
+type EffectLst struct {
+	XMLName    xml.Name    `xml:"a:effectLst"`
+	OuterShdw  *Shadow     `xml:"a:outerShdw,omitempty"`
+	InnerShdw  *Shadow     `xml:"a:innerShdw,omitempty"`
+	Reflection *Reflection `xml:"a:reflection,omitempty"`
+}
+
+// Shadow represents an outer or inner shadow. Distances are in EMUs and
+// the direction in 60000ths of a degree.
+type Shadow struct {
+	BlurRad      int      `xml:"blurRad,attr,omitempty"`
+	Dist         int      `xml:"dist,attr,omitempty"`
+	Dir          int      `xml:"dir,attr,omitempty"`
+	Sx           int      `xml:"sx,attr,omitempty"`
+	Sy           int      `xml:"sy,attr,omitempty"`
+	Algn         string   `xml:"algn,attr,omitempty"`
+	RotWithShape *bool    `xml:"rotWithShape,attr,omitempty"`
+	SrgbClr      *SrgbClr `xml:"a:srgbClr"`
+}
+
+// Reflection represents a mirrored copy below the shape.
+type Reflection struct {
+	XMLName      xml.Name `xml:"a:reflection"`
+	BlurRad      int      `xml:"blurRad,attr,omitempty"`
+	StA          int      `xml:"stA,attr"`
+	EndA         int      `xml:"endA,attr"`
+	EndPos       int      `xml:"endPos,attr"`
+	Dir          int      `xml:"dir,attr"`
+	Sy           int      `xml:"sy,attr"`
+	Algn         string   `xml:"algn,attr"`
+	RotWithShape bool     `xml:"rotWithShape,attr"`
+}
+
+// Wsp represents a WordprocessingML shape (wps:wsp), used for text boxes and
+// preset shapes.
+type Wsp struct {
+	XMLName xml.Name   `xml:"wps:wsp"`
+	Xmlns   string     `xml:"xmlns:wps,attr"`
+	CNvSpPr *CNvSpPr   `xml:"wps:cNvSpPr"`
+	SpPr    *WspSpPr   `xml:"wps:spPr"`
+	Txbx    *Txbx      `xml:"wps:txbx,omitempty"`
+	BodyPr  *WspBodyPr `xml:"wps:bodyPr"`
+}
+
+// CNvSpPr represents non-visual shape properties.
+type CNvSpPr struct {
+	XMLName xml.Name `xml:"wps:cNvSpPr"`
+	TxBox   bool     `xml:"txBox,attr,omitempty"` // Shape is a text box
+}
+
+// WspSpPr represents the geometry, fill and outline of a shape.
+type WspSpPr struct {
+	XMLName   xml.Name   `xml:"wps:spPr"`
+	Xfrm      *Xfrm      `xml:"a:xfrm"`
+	PrstGeom  *PrstGeom  `xml:"a:prstGeom"`
+	NoFill    *NoFill    `xml:"a:noFill,omitempty"`
+	SolidFill *SolidFill `xml:"a:solidFill,omitempty"`
+	Ln        *Ln        `xml:"a:ln,omitempty"`
+}
+
+// Txbx holds the text of a shape.
+type Txbx struct {
+	XMLName xml.Name     `xml:"wps:txbx"`
+	Content *TxbxContent `xml:"w:txbxContent"`
+}
+
+// TxbxContent holds the paragraphs of a text box.
+type TxbxContent struct {
+	XMLName    xml.Name     `xml:"w:txbxContent"`
+	Paragraphs []*Paragraph `xml:"w:p"`
+}
+
+// WspBodyPr represents text layout inside a shape.
+type WspBodyPr struct {
+	XMLName xml.Name `xml:"wps:bodyPr"`
+	Rot     int      `xml:"rot,attr"`
+	Vert    string   `xml:"vert,attr"`
+	Wrap    string   `xml:"wrap,attr"`
+	Anchor  string   `xml:"anchor,attr"` // t, ctr, b
+}
@@ -24,7 +24,14 @@ SOFTWARE.
 
 package xml
 
-import "github.com/mmonterroca/docxgo/v2/domain"
+import (
+	"fmt"
+	"math"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
 
 // NewInlineDrawing creates an inline drawing (flows with text).
 func NewInlineDrawing(img domain.Image, drawingID int) *Drawing {
@@ -46,11 +53,7 @@ func NewInlineDrawing(img domain.Image, drawingID int) *Drawing {
 				R: 0,
 				B: 0,
 			},
-			DocPr: &DocPr{
-				ID:    drawingID,
-				Name:  "Picture " + img.ID(),
-				Descr: img.Description(),
-			},
+			DocPr:   newDocPr(img, drawingID),
 			Graphic: newGraphic(img, size),
 		},
 	}
@@ -59,9 +62,13 @@ func NewInlineDrawing(img domain.Image, drawingID int) *Drawing {
 // NewFloatingDrawing creates a floating drawing (absolute positioning).
 func NewFloatingDrawing(img domain.Image, drawingID int) *Drawing {
 	size := img.Size()
-	pos := img.Position()
+	return &Drawing{
+		Anchor: newAnchor(size, img.Position(), newDocPr(img, drawingID), newGraphic(img, size)),
+	}
+}
 
-	// Convert position to anchor
+// newAnchor places a graphic at the given position.
+func newAnchor(size domain.ImageSize, pos domain.ImagePosition, docPr *DocPr, graphic *Graphic) *Anchor {
 	anchor := &Anchor{
 		DistT:          114300, // Default distances (0.125 inch)
 		DistB:          114300,
@@ -87,12 +94,8 @@ func NewFloatingDrawing(img domain.Image, drawingID int) *Drawing {
 			R: 0,
 			B: 0,
 		},
-		DocPr: &DocPr{
-			ID:    drawingID,
-			Name:  "Picture " + img.ID(),
-			Descr: img.Description(),
-		},
-		Graphic: newGraphic(img, size),
+		DocPr:   docPr,
+		Graphic: graphic,
 	}
 
 	// Set horizontal position
@@ -126,15 +129,36 @@ func NewFloatingDrawing(img domain.Image, drawingID int) *Drawing {
 		}
 	}
 
-	return &Drawing{
-		Anchor: anchor,
+	return anchor
+}
+
+// newDocPr creates the drawing properties, including the click hyperlink.
+func newDocPr(img domain.Image, drawingID int) *DocPr {
+	docPr := &DocPr{
+		ID:    drawingID,
+		Name:  "Picture " + img.ID(),
+		Descr: img.Description(),
+	}
+	if relID := hyperlinkRelationshipID(img); relID != "" {
+		docPr.HlinkClick = &HlinkClick{XmlnsA: namespaceDrawingML, ID: relID}
+	}
+	return docPr
+}
+
+// hyperlinkRelationshipID returns the relationship of the picture link.
+func hyperlinkRelationshipID(img domain.Image) string {
+	if linked, ok := img.(interface{ HyperlinkRelationshipID() string }); ok {
+		return linked.HyperlinkRelationshipID()
 	}
+	return ""
 }
 
+const namespaceDrawingML = "http://schemas.openxmlformats.org/drawingml/2006/main"
+
 // newGraphic creates the graphic content for an image.
 func newGraphic(img domain.Image, size domain.ImageSize) *Graphic {
-	return &Graphic{
-		Xmlns: "http://schemas.openxmlformats.org/drawingml/2006/main",
+	graphic := &Graphic{
+		Xmlns: namespaceDrawingML,
 		GraphicData: &GraphicData{
 			URI: "http://schemas.openxmlformats.org/drawingml/2006/picture",
 			Pic: &Pic{
@@ -152,10 +176,7 @@ func newGraphic(img domain.Image, size domain.ImageSize) *Graphic {
 					},
 				},
 				BlipFill: &BlipFill{
-					Blip: &Blip{
-						Xmlns: "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
-						Embed: img.RelationshipID(),
-					},
+					Blip: newBlip(img),
 					Stretch: &Stretch{
 						FillRect: &FillRect{},
 					},
@@ -176,6 +197,226 @@ func newGraphic(img domain.Image, size domain.ImageSize) *Graphic {
 			},
 		},
 	}
+
+	pic := graphic.GraphicData.Pic
+	if relID := hyperlinkRelationshipID(img); relID != "" {
+		pic.NvPicPr.CNvPr.HlinkClick = &HlinkClick{ID: relID}
+	}
+	applyPictureFormat(pic, img)
+	return graphic
+}
+
+// applyPictureFormat writes cropping, transform, outline and effects.
+func applyPictureFormat(pic *Pic, img domain.Image) {
+	if crop := img.Crop(); crop != (domain.ImageCrop{}) {
+		pic.BlipFill.SrcRect = &SrcRect{
+			L: percentToThousandths(crop.Left),
+			T: percentToThousandths(crop.Top),
+			R: percentToThousandths(crop.Right),
+			B: percentToThousandths(crop.Bottom),
+		}
+	}
+
+	pic.SpPr.Xfrm.Rot = int(math.Round(img.Rotation() * 60000))
+	pic.SpPr.Xfrm.FlipH, pic.SpPr.Xfrm.FlipV = img.Flip()
+
+	if border := img.Border(); border.Width > 0 {
+		pic.SpPr.Ln = &Ln{
+			W:         int(math.Round(border.Width * 12700)),
+			SolidFill: &SolidFill{SrgbClr: &SrgbClr{Val: color.ToHex(border.Color)}},
+		}
+	}
+
+	effects := img.Effects()
+	blip := pic.BlipFill.Blip
+	if effects.Transparency > 0 {
+		blip.AlphaModFix = &AlphaModFix{Amt: percentToThousandths(100 - effects.Transparency)}
+	}
+	if effects.Grayscale {
+		blip.Grayscl = &Grayscl{}
+	}
+	if effects.Brightness != 0 || effects.Contrast != 0 {
+		blip.Lum = &Lum{
+			Bright:   percentToThousandths(effects.Brightness),
+			Contrast: percentToThousandths(effects.Contrast),
+		}
+	}
+
+	effectLst := &EffectLst{}
+	noRotate := false
+	switch effects.Shadow {
+	case domain.ShadowOuter:
+		effectLst.OuterShdw = &Shadow{
+			BlurRad: 50800, Dist: 38100, Dir: 2700000, Algn: "tl", RotWithShape: &noRotate,
+			SrgbClr: &SrgbClr{Val: "000000", Alpha: &Alpha{Val: 40000}},
+		}
+	case domain.ShadowCentered:
+		effectLst.OuterShdw = &Shadow{
+			BlurRad: 63500, Sx: 102000, Sy: 102000, Algn: "ctr", RotWithShape: &noRotate,
+			SrgbClr: &SrgbClr{Val: "000000", Alpha: &Alpha{Val: 40000}},
+		}
+	case domain.ShadowInner:
+		effectLst.InnerShdw = &Shadow{
+			BlurRad: 63500, Dist: 50800, Dir: 13500000,
+			SrgbClr: &SrgbClr{Val: "000000", Alpha: &Alpha{Val: 50000}},
+		}
+	}
+	if endPos, ok := reflectionEndPos[effects.Reflection]; ok {
+		effectLst.Reflection = &Reflection{
+			BlurRad: 6350, StA: 52000, EndA: 300, EndPos: endPos,
+			Dir: 5400000, Sy: -100000, Algn: "bl",
+		}
+	}
+	if *effectLst != (EffectLst{}) {
+		pic.SpPr.EffectLst = effectLst
+	}
+}
+
+// reflectionEndPos maps reflection presets to how much of the picture
+// (in thousandths of a percent) the reflection shows.
+var reflectionEndPos = map[domain.ImageReflection]int{
+	domain.ReflectionTight: 35000,
+	domain.ReflectionHalf:  55000,
+	domain.ReflectionFull:  90000,
+}
+
+func percentToThousandths(v float64) int {
+	return int(math.Round(v * 1000))
+}
+
+// newBlip references the image part. SVG images with a bitmap fallback
+// embed the fallback and point to the SVG through the asvg:svgBlip
+// extension, as Word does.
+func newBlip(img domain.Image) *Blip {
+	blip := &Blip{
+		Xmlns: "http://schemas.openxmlformats.org/officeDocument/2006/relationships",
+		Embed: img.RelationshipID(),
+	}
+
+	withFallback, ok := img.(interface{ FallbackRelationshipID() string })
+	if !ok || withFallback.FallbackRelationshipID() == "" {
+		return blip
+	}
+
+	blip.Embed = withFallback.FallbackRelationshipID()
+	blip.ExtLst = &BlipExtLst{
+		Ext: []*BlipExt{{
+			URI: constants.ExtURISVGBlip,
+			SVGBlip: &SVGBlip{
+				Xmlns: constants.NamespaceSVG,
+				Embed: img.RelationshipID(),
+			},
+		}},
+	}
+	return blip
+}
+
+// shapeGeometry maps shape types to DrawingML preset geometries.
+var shapeGeometry = map[domain.ShapeType]string{
+	domain.ShapeTextBox:          "rect",
+	domain.ShapeRectangle:        "rect",
+	domain.ShapeRoundedRectangle: "roundRect",
+	domain.ShapeEllipse:          "ellipse",
+	domain.ShapeLine:             "line",
+	domain.ShapeArrow:            "line",
+	domain.ShapeCallout:          "wedgeRoundRectCallout",
+}
+
+// textAnchors maps vertical alignment to shape text anchoring.
+var textAnchors = map[domain.VerticalAlign]string{
+	domain.VAlignTop:    "t",
+	domain.VAlignCenter: "ctr",
+	domain.VAlignBottom: "b",
+}
+
+// NewShapeDrawing creates a drawing for a text box or preset shape. content
+// holds the serialized shape paragraphs.
+func NewShapeDrawing(shape domain.Shape, drawingID int, content []*Paragraph) *Drawing {
+	size := shape.Size()
+	name := "Shape"
+	if shape.Type() == domain.ShapeTextBox {
+		name = "Text Box"
+	}
+	docPr := &DocPr{
+		ID:    drawingID,
+		Name:  fmt.Sprintf("%s %d", name, drawingID),
+		Descr: shape.Description(),
+	}
+
+	wsp := &Wsp{
+		Xmlns:   constants.NamespaceWordprocessingShape,
+		CNvSpPr: &CNvSpPr{TxBox: shape.Type() == domain.ShapeTextBox},
+		SpPr: &WspSpPr{
+			Xfrm: &Xfrm{
+				Off: &Off{X: 0, Y: 0},
+				Ext: &Ext{Cx: size.WidthEMU, Cy: size.HeightEMU},
+			},
+			PrstGeom: &PrstGeom{
+				Prst:  shapeGeometry[shape.Type()],
+				AvLst: &AvLst{},
+			},
+			Ln: newShapeOutline(shape),
+		},
+		BodyPr: &WspBodyPr{
+			Vert:   "horz",
+			Wrap:   "square",
+			Anchor: textAnchors[shape.TextAnchor()],
+		},
+	}
+	if fill, ok := shape.Fill(); ok {
+		wsp.SpPr.SolidFill = &SolidFill{SrgbClr: &SrgbClr{Val: color.ToHex(fill)}}
+	} else {
+		wsp.SpPr.NoFill = &NoFill{}
+	}
+
+	// Word expects a text box to hold at least one paragraph.
+	if len(content) == 0 && shape.Type() == domain.ShapeTextBox {
+		content = []*Paragraph{{}}
+	}
+	if len(content) > 0 {
+		wsp.Txbx = &Txbx{Content: &TxbxContent{Paragraphs: content}}
+	}
+
+	graphic := &Graphic{
+		Xmlns: namespaceDrawingML,
+		GraphicData: &GraphicData{
+			URI: constants.NamespaceWordprocessingShape,
+			Wsp: wsp,
+		},
+	}
+
+	return newPlacedDrawing(size, shape.Position(), docPr, graphic)
+}
+
+// newPlacedDrawing wraps a graphic in an inline or floating drawing.
+func newPlacedDrawing(size domain.ImageSize, pos domain.ImagePosition, docPr *DocPr, graphic *Graphic) *Drawing {
+	if pos.Type == domain.ImagePositionFloating {
+		return &Drawing{Anchor: newAnchor(size, pos, docPr, graphic)}
+	}
+	return &Drawing{
+		Inline: &Inline{
+			Extent:       &Extent{Cx: size.WidthEMU, Cy: size.HeightEMU},
+			EffectExtent: &EffectExtent{},
+			DocPr:        docPr,
+			Graphic:      graphic,
+		},
+	}
+}
+
+// newShapeOutline creates the shape outline, with an arrowhead for arrows.
+func newShapeOutline(shape domain.Shape) *Ln {
+	outline := shape.Outline()
+	if outline.Width <= 0 {
+		return &Ln{NoFill: &NoFill{}}
+	}
+	ln := &Ln{
+		W:         int(math.Round(outline.Width * 12700)),
+		SolidFill: &SolidFill{SrgbClr: &SrgbClr{Val: color.ToHex(outline.Color)}},
+	}
+	if shape.Type() == domain.ShapeArrow {
+		ln.TailEnd = &LineEnd{Type: "triangle"}
+	}
+	return ln
 }
 
 // convertHAlign converts domain horizontal alignment to XML relative from.
@@ -0,0 +1,64 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package xml
+
+import (
+	"encoding/xml"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// Footnotes represents the footnotes.xml part.
+type Footnotes struct {
+	XMLName   xml.Name    `xml:"w:footnotes"`
+	XmlnsW    string      `xml:"xmlns:w,attr"`
+	XmlnsR    string      `xml:"xmlns:r,attr"`
+	Footnotes []*Footnote `xml:"w:footnote"`
+}
+
+// Footnote represents a w:footnote element.
+type Footnote struct {
+	Type       string       `xml:"w:type,attr,omitempty"`
+	ID         int          `xml:"w:id,attr"`
+	Paragraphs []*Paragraph `xml:"w:p"`
+}
+
+// FootnoteReference represents a w:footnoteReference element.
+type FootnoteReference struct {
+	ID int `xml:"w:id,attr"`
+}
+
+// NewFootnotes creates a footnotes part holding the separator and
+// continuation separator notes Word expects before the first footnote.
+func NewFootnotes() *Footnotes {
+	return &Footnotes{
+		XmlnsW: constants.NamespaceMain,
+		XmlnsR: constants.NamespaceRelationships,
+		Footnotes: []*Footnote{
+			{Type: "separator", ID: -1, Paragraphs: []*Paragraph{{Elements: []interface{}{&Run{Separator: &struct{}{}}}}}},
+			{Type: "continuationSeparator", ID: 0, Paragraphs: []*Paragraph{{Elements: []interface{}{&Run{ContinuationSeparator: &struct{}{}}}}}},
+		},
+	}
+}
@@ -0,0 +1,172 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package xml
+
+import "encoding/xml"
+
+// OMathPara is a display equation (m:oMathPara).
+type OMathPara struct {
+	XMLName    xml.Name     `xml:"m:oMathPara"`
+	XmlnsM     string       `xml:"xmlns:m,attr,omitempty"`
+	Properties *OMathParaPr `xml:"m:oMathParaPr,omitempty"`
+	Math       []*OMath     `xml:"m:oMath"`
+}
+
+// OMathParaPr holds display equation properties.
+type OMathParaPr struct {
+	Justification *MathValue `xml:"m:jc,omitempty"`
+}
+
+// OMath is an equation (m:oMath). Raw holds the verbatim content of
+// equations read from a document.
+type OMath struct {
+	XMLName  xml.Name      `xml:"m:oMath"`
+	XmlnsM   string        `xml:"xmlns:m,attr,omitempty"`
+	Elements []interface{} `xml:",any"`
+	Raw      string        `xml:",innerxml"`
+}
+
+// MathValue is a math element carrying a single m:val attribute.
+type MathValue struct {
+	Val string `xml:"m:val,attr"`
+}
+
+// MathArg is an equation argument such as m:e, m:num or m:sub.
+type MathArg struct {
+	Elements []interface{} `xml:",any"`
+}
+
+// MathRun is a run of math text (m:r).
+type MathRun struct {
+	XMLName    xml.Name      `xml:"m:r"`
+	Properties *MathRunProps `xml:"m:rPr,omitempty"`
+	Text       *MathText     `xml:"m:t"`
+}
+
+// MathRunProps holds math run properties.
+type MathRunProps struct {
+	Style *MathValue `xml:"m:sty,omitempty"` // "p" for plain (upright) text
+}
+
+// MathText is the text of a math run (m:t).
+type MathText struct {
+	Space string `xml:"xml:space,attr,omitempty"`
+	Text  string `xml:",chardata"`
+}
+
+// MathFraction is a fraction (m:f).
+type MathFraction struct {
+	XMLName     xml.Name `xml:"m:f"`
+	Numerator   *MathArg `xml:"m:num"`
+	Denominator *MathArg `xml:"m:den"`
+}
+
+// MathRadical is a root (m:rad).
+type MathRadical struct {
+	XMLName    xml.Name       `xml:"m:rad"`
+	Properties *MathRadicalPr `xml:"m:radPr,omitempty"`
+	Degree     *MathArg       `xml:"m:deg"`
+	Base       *MathArg       `xml:"m:e"`
+}
+
+// MathRadicalPr holds radical properties.
+type MathRadicalPr struct {
+	DegreeHidden *MathValue `xml:"m:degHide,omitempty"`
+}
+
+// MathSub is a subscript (m:sSub).
+type MathSub struct {
+	XMLName xml.Name `xml:"m:sSub"`
+	Base    *MathArg `xml:"m:e"`
+	Sub     *MathArg `xml:"m:sub"`
+}
+
+// MathSup is a superscript (m:sSup).
+type MathSup struct {
+	XMLName xml.Name `xml:"m:sSup"`
+	Base    *MathArg `xml:"m:e"`
+	Sup     *MathArg `xml:"m:sup"`
+}
+
+// MathSubSup is a combined subscript and superscript (m:sSubSup).
+type MathSubSup struct {
+	XMLName xml.Name `xml:"m:sSubSup"`
+	Base    *MathArg `xml:"m:e"`
+	Sub     *MathArg `xml:"m:sub"`
+	Sup     *MathArg `xml:"m:sup"`
+}
+
+// MathNary is a large operator (m:nary).
+type MathNary struct {
+	XMLName    xml.Name    `xml:"m:nary"`
+	Properties *MathNaryPr `xml:"m:naryPr"`
+	Sub        *MathArg    `xml:"m:sub"`
+	Sup        *MathArg    `xml:"m:sup"`
+	Body       *MathArg    `xml:"m:e"`
+}
+
+// MathNaryPr holds large operator properties.
+type MathNaryPr struct {
+	Char          *MathValue `xml:"m:chr,omitempty"`
+	LimitLocation *MathValue `xml:"m:limLoc,omitempty"`
+	SubHidden     *MathValue `xml:"m:subHide,omitempty"`
+	SupHidden     *MathValue `xml:"m:supHide,omitempty"`
+}
+
+// MathMatrix is a matrix (m:m).
+type MathMatrix struct {
+	XMLName    xml.Name         `xml:"m:m"`
+	Properties *MathMatrixPr    `xml:"m:mPr,omitempty"`
+	Rows       []*MathMatrixRow `xml:"m:mr"`
+}
+
+// MathMatrixPr holds matrix properties.
+type MathMatrixPr struct {
+	Columns []*MathMatrixColumn `xml:"m:mcs>m:mc"`
+}
+
+// MathMatrixColumn describes a run of matrix columns.
+type MathMatrixColumn struct {
+	Count         *MathValue `xml:"m:mcPr>m:count"`
+	Justification *MathValue `xml:"m:mcPr>m:mcJc"`
+}
+
+// MathMatrixRow is a matrix row (m:mr).
+type MathMatrixRow struct {
+	Cells []*MathArg `xml:"m:e"`
+}
+
+// MathDelimiter is a bracketed expression (m:d).
+type MathDelimiter struct {
+	XMLName    xml.Name         `xml:"m:d"`
+	Properties *MathDelimiterPr `xml:"m:dPr,omitempty"`
+	Elements   []*MathArg       `xml:"m:e"`
+}
+
+// MathDelimiterPr holds the delimiter characters.
+type MathDelimiterPr struct {
+	Begin *MathValue `xml:"m:begChr,omitempty"`
+	End   *MathValue `xml:"m:endChr,omitempty"`
+}
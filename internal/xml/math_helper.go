@@ -0,0 +1,149 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package xml
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// NewOMath converts an equation tree to Office Math markup. MathLaTeX
+// nodes must be parsed beforehand; they are skipped here.
+func NewOMath(content domain.MathNode) *OMath {
+	return &OMath{XmlnsM: constants.NamespaceMath, Elements: mathElements(content)}
+}
+
+// NewOMathPara wraps an equation for display on its own line, centered.
+func NewOMathPara(math *OMath) *OMathPara {
+	return &OMathPara{
+		XmlnsM:     constants.NamespaceMath,
+		Properties: &OMathParaPr{Justification: &MathValue{Val: "centerGroup"}},
+		Math:       []*OMath{math},
+	}
+}
+
+// limitsUnderOver lists the operators whose limits go above and below;
+// integrals keep them to the side.
+var limitsUnderOver = map[string]bool{
+	"∑": true, "∏": true, "∐": true, "⋃": true, "⋂": true, "⋁": true, "⋀": true, "⨁": true, "⨂": true,
+}
+
+func mathElements(node domain.MathNode) []interface{} {
+	switch n := node.(type) {
+	case domain.MathText:
+		if n == "" {
+			return nil
+		}
+		return []interface{}{newMathRun(string(n), nil)}
+	case domain.MathPlainText:
+		if n == "" {
+			return nil
+		}
+		return []interface{}{newMathRun(string(n), &MathRunProps{Style: &MathValue{Val: "p"}})}
+	case domain.MathRow:
+		var out []interface{}
+		for _, child := range n {
+			out = append(out, mathElements(child)...)
+		}
+		return out
+	case domain.MathFraction:
+		return []interface{}{&MathFraction{Numerator: mathArg(n.Num), Denominator: mathArg(n.Den)}}
+	case domain.MathRadical:
+		rad := &MathRadical{Degree: mathArg(n.Degree), Base: mathArg(n.Base)}
+		if n.Degree == nil {
+			rad.Properties = &MathRadicalPr{DegreeHidden: &MathValue{Val: "1"}}
+		}
+		return []interface{}{rad}
+	case domain.MathScript:
+		switch {
+		case n.Sub != nil && n.Sup != nil:
+			return []interface{}{&MathSubSup{Base: mathArg(n.Base), Sub: mathArg(n.Sub), Sup: mathArg(n.Sup)}}
+		case n.Sub != nil:
+			return []interface{}{&MathSub{Base: mathArg(n.Base), Sub: mathArg(n.Sub)}}
+		case n.Sup != nil:
+			return []interface{}{&MathSup{Base: mathArg(n.Base), Sup: mathArg(n.Sup)}}
+		}
+		return mathElements(n.Base)
+	case domain.MathNary:
+		op := n.Operator
+		if op == "" {
+			op = "∫"
+		}
+		props := &MathNaryPr{LimitLocation: &MathValue{Val: "subSup"}}
+		if op != "∫" {
+			props.Char = &MathValue{Val: op}
+		}
+		if limitsUnderOver[op] {
+			props.LimitLocation.Val = "undOvr"
+		}
+		if n.Sub == nil {
+			props.SubHidden = &MathValue{Val: "1"}
+		}
+		if n.Sup == nil {
+			props.SupHidden = &MathValue{Val: "1"}
+		}
+		return []interface{}{&MathNary{Properties: props, Sub: mathArg(n.Sub), Sup: mathArg(n.Sup), Body: mathArg(n.Body)}}
+	case domain.MathMatrix:
+		matrix := &MathMatrix{}
+		columns := 0
+		for _, row := range n.Rows {
+			xmlRow := &MathMatrixRow{}
+			for _, cell := range row {
+				xmlRow.Cells = append(xmlRow.Cells, mathArg(cell))
+			}
+			columns = max(columns, len(row))
+			matrix.Rows = append(matrix.Rows, xmlRow)
+		}
+		if columns > 0 {
+			matrix.Properties = &MathMatrixPr{Columns: []*MathMatrixColumn{{
+				Count:         &MathValue{Val: strconv.Itoa(columns)},
+				Justification: &MathValue{Val: "center"},
+			}}}
+		}
+		return []interface{}{matrix}
+	case domain.MathDelimiter:
+		delim := &MathDelimiter{Elements: []*MathArg{mathArg(n.Body)}}
+		if n.Open != "" || n.Close != "" {
+			delim.Properties = &MathDelimiterPr{Begin: &MathValue{Val: n.Open}, End: &MathValue{Val: n.Close}}
+		}
+		return []interface{}{delim}
+	}
+	return nil
+}
+
+func mathArg(node domain.MathNode) *MathArg {
+	return &MathArg{Elements: mathElements(node)}
+}
+
+func newMathRun(text string, props *MathRunProps) *MathRun {
+	t := &MathText{Text: text}
+	if strings.TrimSpace(text) != text {
+		t.Space = "preserve"
+	}
+	return &MathRun{Properties: props, Text: t}
+}
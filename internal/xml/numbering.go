@@ -0,0 +1,286 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package xml
+
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// Numbering represents the numbering.xml part.
+type Numbering struct {
+	XMLName      xml.Name       `xml:"w:numbering"`
+	Xmlns        string         `xml:"xmlns:w,attr"`
+	AbstractNums []*AbstractNum `xml:"w:abstractNum"`
+	Nums         []*Num         `xml:"w:num"`
+}
+
+// AbstractNum represents w:abstractNum, a list definition with its levels.
+type AbstractNum struct {
+	XMLName        xml.Name          `xml:"w:abstractNum"`
+	ID             int               `xml:"w:abstractNumId,attr"`
+	MultiLevelType *NumberingValue   `xml:"w:multiLevelType"`
+	Levels         []*NumberingLevel `xml:"w:lvl"`
+}
+
+// NumberingLevel represents w:lvl, the format of one list level.
+type NumberingLevel struct {
+	Level      int             `xml:"w:ilvl,attr"`
+	Start      *DecimalNumber  `xml:"w:start"`
+	Format     *NumberingValue `xml:"w:numFmt"`
+	Text       *NumberingValue `xml:"w:lvlText"`
+	Alignment  *NumberingValue `xml:"w:lvlJc"`
+	Properties *LevelParagraph `xml:"w:pPr"`
+}
+
+// LevelParagraph holds the indentation of a list level.
+type LevelParagraph struct {
+	Indentation *Indentation `xml:"w:ind"`
+}
+
+// Num represents w:num, a list instance of an abstract definition.
+type Num struct {
+	XMLName       xml.Name       `xml:"w:num"`
+	ID            int            `xml:"w:numId,attr"`
+	AbstractNumID *DecimalNumber `xml:"w:abstractNumId"`
+}
+
+// NumberingValue is a string w:val element of a numbering definition.
+type NumberingValue struct {
+	Val string `xml:"w:val,attr"`
+}
+
+// bulletMarkers are the bullets of successive list levels.
+var bulletMarkers = []string{"•", "◦", "▪"}
+
+// decimalFormats are the number formats of successive list levels.
+var decimalFormats = []string{"decimal", "lowerLetter", "lowerRoman"}
+
+// NewAbstractNum builds a nine-level list definition. Each level indents
+// a further half inch with a quarter-inch hanging marker.
+func NewAbstractNum(id int, def domain.ListDefinition) *AbstractNum {
+	abstract := &AbstractNum{ID: id, MultiLevelType: &NumberingValue{Val: "hybridMultilevel"}}
+	for level := domain.NumberingLevelMin; level <= domain.NumberingLevelMax; level++ {
+		left, hanging := 720*(level+1), 360
+		lvl := &NumberingLevel{
+			Level:      level,
+			Start:      &DecimalNumber{Val: 1},
+			Alignment:  &NumberingValue{Val: "left"},
+			Properties: &LevelParagraph{Indentation: &Indentation{Left: &left, Hanging: &hanging}},
+		}
+		if def.Kind == domain.ListBullet {
+			lvl.Format = &NumberingValue{Val: "bullet"}
+			lvl.Text = &NumberingValue{Val: bulletMarkers[level%len(bulletMarkers)]}
+		} else {
+			lvl.Format = &NumberingValue{Val: decimalFormats[level%len(decimalFormats)]}
+			lvl.Text = &NumberingValue{Val: "%" + strconv.Itoa(level+1) + "."}
+			if level == 0 && def.Start > 0 {
+				lvl.Start.Val = def.Start
+			}
+		}
+		abstract.Levels = append(abstract.Levels, lvl)
+	}
+	return abstract
+}
+
+// NewNumbering builds a numbering part with one list per definition. The
+// abstract and instance IDs count up from firstAbstractID and firstNumID.
+func NewNumbering(defs []domain.ListDefinition, firstAbstractID, firstNumID int) *Numbering {
+	numbering := &Numbering{Xmlns: constants.NamespaceMain}
+	for i, def := range defs {
+		numbering.AbstractNums = append(numbering.AbstractNums, NewAbstractNum(firstAbstractID+i, def))
+		numbering.Nums = append(numbering.Nums, &Num{ID: firstNumID + i, AbstractNumID: &DecimalNumber{Val: firstAbstractID + i}})
+	}
+	return numbering
+}
+
+// MergeNumbering adds the definitions and instances of extra to an existing
+// numbering part. Word requires every w:abstractNum before the first w:num.
+func MergeNumbering(existing []byte, extra *Numbering) ([]byte, error) {
+	var abstracts, nums strings.Builder
+	for _, a := range extra.AbstractNums {
+		data, err := xml.Marshal(a)
+		if err != nil {
+			return nil, err
+		}
+		abstracts.Write(data)
+	}
+	for _, n := range extra.Nums {
+		data, err := xml.Marshal(n)
+		if err != nil {
+			return nil, err
+		}
+		nums.Write(data)
+	}
+
+	part := string(existing)
+	end := strings.LastIndex(part, "</w:numbering>")
+	if end < 0 {
+		return nil, xml.UnmarshalError("numbering part has no </w:numbering> end tag")
+	}
+	part = part[:end] + nums.String() + part[end:]
+	insert := end
+	if first := strings.Index(part, "<w:num "); first >= 0 && first < end {
+		insert = first
+	} else if first := strings.Index(part, "<w:num>"); first >= 0 && first < end {
+		insert = first
+	}
+	return []byte(part[:insert] + abstracts.String() + part[insert:]), nil
+}
+
+// NumberingIDs returns the largest w:abstractNumId and w:numId in a
+// numbering part, or zero when there are none.
+func NumberingIDs(data []byte) (maxAbstractID, maxNumID int) {
+	dec := xml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return maxAbstractID, maxNumID
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		for _, attr := range start.Attr {
+			n, err := strconv.Atoi(attr.Value)
+			if err != nil {
+				continue
+			}
+			switch {
+			case start.Name.Local == "abstractNum" && attr.Name.Local == "abstractNumId":
+				maxAbstractID = max(maxAbstractID, n)
+			case start.Name.Local == "num" && attr.Name.Local == "numId":
+				maxNumID = max(maxNumID, n)
+			}
+		}
+	}
+}
+
+// NumberingLevelFormat returns the w:numFmt and start number of a level of
+// the list numID in a numbering part, following w:num to its
+// w:abstractNum and applying any w:startOverride.
+func NumberingLevelFormat(data []byte, numID, level int) (format string, start int, ok bool) {
+	type levelFormat struct {
+		format string
+		start  int
+	}
+	var (
+		levels    = map[[2]int]*levelFormat{} // abstractNumId, ilvl
+		abstracts = map[int]int{}             // numId -> abstractNumId
+		overrides = map[int]int{}             // ilvl -> start of numID
+
+		abstractID, num, overrideLevel = -1, -1, -1
+		current                        *levelFormat
+	)
+	dec := xml.NewDecoder(strings.NewReader(string(data)))
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			break
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			val, hasVal := intAttr(t, "val")
+			switch t.Name.Local {
+			case "abstractNum":
+				abstractID, _ = intAttr(t, "abstractNumId")
+			case "lvl":
+				if ilvl, valid := intAttr(t, "ilvl"); valid && abstractID >= 0 {
+					current = &levelFormat{}
+					levels[[2]int{abstractID, ilvl}] = current
+				}
+			case "numFmt":
+				if current != nil {
+					current.format = attrValue(t, "val")
+				}
+			case "start":
+				if current != nil && hasVal {
+					current.start = val
+				}
+			case "num":
+				num, _ = intAttr(t, "numId")
+			case "abstractNumId":
+				if num >= 0 && hasVal {
+					abstracts[num] = val
+				}
+			case "lvlOverride":
+				overrideLevel, _ = intAttr(t, "ilvl")
+			case "startOverride":
+				if num == numID && overrideLevel >= 0 && hasVal {
+					overrides[overrideLevel] = val
+				}
+			}
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "abstractNum":
+				abstractID = -1
+			case "lvl":
+				current = nil
+			case "num":
+				num = -1
+			case "lvlOverride":
+				overrideLevel = -1
+			}
+		}
+	}
+
+	abstractNumID, found := abstracts[numID]
+	if !found {
+		return "", 0, false
+	}
+	lvl, found := levels[[2]int{abstractNumID, level}]
+	if !found {
+		return "", 0, false
+	}
+	start = lvl.start
+	if override, found := overrides[level]; found {
+		start = override
+	}
+	return lvl.format, start, true
+}
+
+// attrValue returns the value of the attribute with the local name, or "".
+func attrValue(start xml.StartElement, name string) string {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// intAttr returns the integer value of the attribute with the local name,
+// or -1 when it is missing or not a number.
+func intAttr(start xml.StartElement, name string) (int, bool) {
+	n, err := strconv.Atoi(attrValue(start, name))
+	if err != nil {
+		return -1, false
+	}
+	return n, true
+}
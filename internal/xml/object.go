@@ -0,0 +1,109 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package xml
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// Object is an embedded OLE object (w:object) shown through a VML shape.
+type Object struct {
+	XMLName   xml.Name   `xml:"w:object"`
+	XmlnsV    string     `xml:"xmlns:v,attr,omitempty"`
+	XmlnsO    string     `xml:"xmlns:o,attr,omitempty"`
+	DxaOrig   int        `xml:"w:dxaOrig,attr,omitempty"`
+	DyaOrig   int        `xml:"w:dyaOrig,attr,omitempty"`
+	Shape     *VMLShape  `xml:"v:shape"`
+	OLEObject *OLEObject `xml:"o:OLEObject"`
+}
+
+// VMLShape is the v:shape displaying an object's preview.
+type VMLShape struct {
+	ID        string        `xml:"id,attr"`
+	Style     string        `xml:"style,attr"`
+	OLE       string        `xml:"o:ole,attr"`
+	ImageData *VMLImageData `xml:"v:imagedata"`
+}
+
+// VMLImageData references the preview image (v:imagedata).
+type VMLImageData struct {
+	ID    string `xml:"r:id,attr"`
+	Title string `xml:"o:title,attr"`
+}
+
+// OLEObject links a shape to its embedded part (o:OLEObject).
+type OLEObject struct {
+	Type       string `xml:"Type,attr"`
+	ProgID     string `xml:"ProgID,attr"`
+	ShapeID    string `xml:"ShapeID,attr"`
+	DrawAspect string `xml:"DrawAspect,attr"`
+	ObjectID   string `xml:"ObjectID,attr"`
+	ID         string `xml:"r:id,attr"`
+}
+
+// AltChunk imports an alternative format part in place (w:altChunk).
+type AltChunk struct {
+	XMLName xml.Name `xml:"w:altChunk"`
+	ID      string   `xml:"r:id,attr"`
+}
+
+// NewObject builds the w:object for an embedded object shown as its icon.
+// objectID numbers the shape within the document.
+func NewObject(obj domain.EmbeddedObject, iconRelID string, objectID int) *Object {
+	size := obj.Size()
+	shapeID := fmt.Sprintf("_x0000_i%d", 1024+objectID)
+	object := &Object{
+		XmlnsV:  constants.NamespaceVML,
+		XmlnsO:  constants.NamespaceOffice,
+		DxaOrig: size.WidthEMU / 635,
+		DyaOrig: size.HeightEMU / 635,
+		Shape: &VMLShape{
+			ID:    shapeID,
+			Style: "width:" + points(size.WidthEMU) + ";height:" + points(size.HeightEMU),
+		},
+		OLEObject: &OLEObject{
+			Type:       "Embed",
+			ProgID:     obj.ProgID(),
+			ShapeID:    shapeID,
+			DrawAspect: "Icon",
+			ObjectID:   fmt.Sprintf("_%d", 1700000000+objectID),
+			ID:         obj.RelationshipID(),
+		},
+	}
+	if iconRelID != "" {
+		object.Shape.ImageData = &VMLImageData{ID: iconRelID}
+	}
+	return object
+}
+
+// points formats an EMU length as a CSS length in points.
+func points(emu int) string {
+	return strconv.FormatFloat(float64(emu)/12700, 'f', -1, 64) + "pt"
+}
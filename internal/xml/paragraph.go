@@ -74,9 +74,22 @@ type ParagraphProperties struct {
 	Spacing           *Spacing             `xml:"w:spacing,omitempty"`
 	Numbering         *NumberingProperties `xml:"w:numPr,omitempty"`
 	Borders           *ParagraphBorders    `xml:"w:pBdr,omitempty"`
+	Tabs              *Tabs                `xml:"w:tabs,omitempty"`
 	SectionProperties *SectionProperties   `xml:"w:sectPr,omitempty"`
 }
 
+// Tabs represents w:tabs element (custom tab stops).
+type Tabs struct {
+	Tabs []*TabStop `xml:"w:tab"`
+}
+
+// TabStop represents a w:tab element inside w:tabs.
+type TabStop struct {
+	Val    string `xml:"w:val,attr"`              // left, center, right, decimal
+	Leader string `xml:"w:leader,attr,omitempty"` // dot, hyphen, underscore, none
+	Pos    int    `xml:"w:pos,attr"`              // Position in twips
+}
+
 // ParagraphBorders represents w:pBdr element (paragraph borders).
 type ParagraphBorders struct {
 	XMLName xml.Name `xml:"w:pBdr"`
@@ -124,9 +137,11 @@ type DecimalNumber struct {
 	Val int `xml:"w:val,attr"`
 }
 
-// Hyperlink represents w:hyperlink element.
+// Hyperlink represents w:hyperlink element. External links use ID, links
+// to a bookmark of the document use Anchor.
 type Hyperlink struct {
 	XMLName xml.Name `xml:"w:hyperlink"`
-	ID      string   `xml:"r:id,attr"`
+	ID      string   `xml:"r:id,attr,omitempty"`
+	Anchor  string   `xml:"w:anchor,attr,omitempty"`
 	Runs    []*Run   `xml:"w:r"`
 }
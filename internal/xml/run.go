@@ -30,10 +30,18 @@ type Run struct {
 	Tab     *struct{} `xml:"w:tab,omitempty"`
 	Break   *Break    `xml:"w:br,omitempty"`
 	Drawing *Drawing  `xml:"w:drawing,omitempty"`
+	Object  *Object   `xml:"w:object,omitempty"`
 
 	// Field support - complex fields use multiple runs
 	FieldChar *FieldChar `xml:"w:fldChar,omitempty"`
 	InstrText *InstrText `xml:"w:instrText,omitempty"`
+
+	// Footnote support - a reference in the text, and the number and
+	// separators inside footnotes.xml
+	FootnoteReference     *FootnoteReference `xml:"w:footnoteReference,omitempty"`
+	FootnoteRef           *struct{}          `xml:"w:footnoteRef,omitempty"`
+	Separator             *struct{}          `xml:"w:separator,omitempty"`
+	ContinuationSeparator *struct{}          `xml:"w:continuationSeparator,omitempty"`
 }
 
 // RunProperties represents w:rPr element (run properties).
@@ -49,6 +57,7 @@ type RunProperties struct {
 	SizeCS    *HalfPt    `xml:"w:szCs,omitempty"` // Complex script size
 	Underline *Underline `xml:"w:u,omitempty"`
 	Highlight *Highlight `xml:"w:highlight,omitempty"`
+	VertAlign *VertAlign `xml:"w:vertAlign,omitempty"`
 	Lang      *Language  `xml:"w:lang,omitempty"`
 }
 
@@ -71,7 +80,10 @@ type Underline struct {
 
 // Color represents w:color element.
 type Color struct {
-	Val string `xml:"w:val,attr"`
+	Val        string `xml:"w:val,attr"`
+	ThemeColor string `xml:"w:themeColor,attr,omitempty"`
+	ThemeShade string `xml:"w:themeShade,attr,omitempty"`
+	ThemeTint  string `xml:"w:themeTint,attr,omitempty"`
 }
 
 // HalfPt represents font size in half-points.
@@ -85,6 +97,11 @@ type Font struct {
 	HAnsi    string `xml:"w:hAnsi,attr,omitempty"`
 	EastAsia string `xml:"w:eastAsia,attr,omitempty"`
 	CS       string `xml:"w:cs,attr,omitempty"`
+
+	ASCIITheme    string `xml:"w:asciiTheme,attr,omitempty"`
+	HAnsiTheme    string `xml:"w:hAnsiTheme,attr,omitempty"`
+	EastAsiaTheme string `xml:"w:eastAsiaTheme,attr,omitempty"`
+	CSTheme       string `xml:"w:cstheme,attr,omitempty"`
 }
 
 // Language represents w:lang element.
@@ -99,6 +116,11 @@ type Highlight struct {
 	Val string `xml:"w:val,attr"`
 }
 
+// VertAlign represents w:vertAlign element (superscript or subscript).
+type VertAlign struct {
+	Val string `xml:"w:val,attr"`
+}
+
 // RunStyle represents w:rStyle element (run style reference).
 type RunStyle struct {
 	Val string `xml:"w:val,attr"`
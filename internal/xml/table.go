@@ -36,12 +36,32 @@ type Table struct {
 }
 
 // TableProperties represents w:tblPr element.
+// Child order follows the CT_TblPr schema sequence.
 type TableProperties struct {
-	XMLName xml.Name       `xml:"w:tblPr"`
-	Style   *TableStyle    `xml:"w:tblStyle,omitempty"`
-	Width   *TableWidth    `xml:"w:tblW,omitempty"`
-	Look    *TableLook     `xml:"w:tblLook,omitempty"`
-	Jc      *Justification `xml:"w:jc,omitempty"`
+	XMLName  xml.Name                 `xml:"w:tblPr"`
+	Style    *TableStyle              `xml:"w:tblStyle,omitempty"`
+	Position *TablePositionProperties `xml:"w:tblpPr,omitempty"`
+	Overlap  *TableOverlap            `xml:"w:tblOverlap,omitempty"`
+	Width    *TableWidth              `xml:"w:tblW,omitempty"`
+	Jc       *Justification           `xml:"w:jc,omitempty"`
+	Look     *TableLook               `xml:"w:tblLook,omitempty"`
+}
+
+// TablePositionProperties represents w:tblpPr element for floating tables.
+type TablePositionProperties struct {
+	LeftFromText   int    `xml:"w:leftFromText,attr"`
+	RightFromText  int    `xml:"w:rightFromText,attr"`
+	TopFromText    int    `xml:"w:topFromText,attr"`
+	BottomFromText int    `xml:"w:bottomFromText,attr"`
+	VertAnchor     string `xml:"w:vertAnchor,attr,omitempty"`
+	HorzAnchor     string `xml:"w:horzAnchor,attr,omitempty"`
+	X              int    `xml:"w:tblpX,attr"`
+	Y              int    `xml:"w:tblpY,attr"`
+}
+
+// TableOverlap represents w:tblOverlap element.
+type TableOverlap struct {
+	Val string `xml:"w:val,attr"`
 }
 
 // TableStyle represents w:tblStyle element.
@@ -105,14 +125,15 @@ type TableCell struct {
 }
 
 // TableCellProperties represents w:tcPr element.
+// Child order follows the CT_TcPr schema sequence.
 type TableCellProperties struct {
 	XMLName  xml.Name       `xml:"w:tcPr"`
 	Width    *TableWidth    `xml:"w:tcW,omitempty"`
 	GridSpan *GridSpan      `xml:"w:gridSpan,omitempty"`
 	VMerge   *VMerge        `xml:"w:vMerge,omitempty"`
-	VAlign   *VerticalAlign `xml:"w:vAlign,omitempty"`
 	Borders  *TableBorders  `xml:"w:tcBorders,omitempty"`
 	Shading  *Shading       `xml:"w:shd,omitempty"`
+	VAlign   *VerticalAlign `xml:"w:vAlign,omitempty"`
 }
 
 // GridSpan represents w:gridSpan element for horizontal cell merging.
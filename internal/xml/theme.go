@@ -0,0 +1,199 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package xml
+
+import (
+	"encoding/xml"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// Theme is a DrawingML theme part (a:theme).
+type Theme struct {
+	XMLName           xml.Name       `xml:"a:theme"`
+	XmlnsA            string         `xml:"xmlns:a,attr"`
+	Name              string         `xml:"name,attr"`
+	Elements          *ThemeElements `xml:"a:themeElements"`
+	ObjectDefaults    struct{}       `xml:"a:objectDefaults"`
+	ExtraColorSchemes struct{}       `xml:"a:extraClrSchemeLst"`
+}
+
+// ThemeElements holds the color, font and format schemes.
+type ThemeElements struct {
+	ColorScheme  *ColorScheme  `xml:"a:clrScheme"`
+	FontScheme   *FontScheme   `xml:"a:fontScheme"`
+	FormatScheme *FormatScheme `xml:"a:fmtScheme"`
+}
+
+// ColorScheme lists the twelve theme colors (a:clrScheme).
+type ColorScheme struct {
+	Name              string      `xml:"name,attr"`
+	Dark1             *SchemeSlot `xml:"a:dk1"`
+	Light1            *SchemeSlot `xml:"a:lt1"`
+	Dark2             *SchemeSlot `xml:"a:dk2"`
+	Light2            *SchemeSlot `xml:"a:lt2"`
+	Accent1           *SchemeSlot `xml:"a:accent1"`
+	Accent2           *SchemeSlot `xml:"a:accent2"`
+	Accent3           *SchemeSlot `xml:"a:accent3"`
+	Accent4           *SchemeSlot `xml:"a:accent4"`
+	Accent5           *SchemeSlot `xml:"a:accent5"`
+	Accent6           *SchemeSlot `xml:"a:accent6"`
+	Hyperlink         *SchemeSlot `xml:"a:hlink"`
+	FollowedHyperlink *SchemeSlot `xml:"a:folHlink"`
+}
+
+// SchemeSlot is one theme color, either an RGB value or a system color.
+type SchemeSlot struct {
+	SrgbClr *SrgbClr `xml:"a:srgbClr,omitempty"`
+	SysClr  *SysClr  `xml:"a:sysClr,omitempty"`
+}
+
+// SysClr is a system color with its last known value (a:sysClr).
+type SysClr struct {
+	Val     string `xml:"val,attr"`
+	LastClr string `xml:"lastClr,attr"`
+}
+
+// FontScheme names the heading and body fonts (a:fontScheme).
+type FontScheme struct {
+	Name      string     `xml:"name,attr"`
+	MajorFont *ThemeFont `xml:"a:majorFont"`
+	MinorFont *ThemeFont `xml:"a:minorFont"`
+}
+
+// ThemeFont lists the typefaces of a theme font by script.
+type ThemeFont struct {
+	Latin    *TextFont `xml:"a:latin"`
+	EastAsia *TextFont `xml:"a:ea"`
+	Complex  *TextFont `xml:"a:cs"`
+}
+
+// TextFont is a typeface reference.
+type TextFont struct {
+	Typeface string `xml:"typeface,attr"`
+}
+
+// FormatScheme holds the fill, line and effect styles (a:fmtScheme).
+type FormatScheme struct {
+	Name  string `xml:"name,attr"`
+	Inner string `xml:",innerxml"`
+}
+
+// NewTheme builds the theme part for a document theme, with the Office
+// format scheme.
+func NewTheme(theme domain.DocumentTheme) *Theme {
+	c := theme.Colors
+	return &Theme{
+		XmlnsA: constants.NamespaceDrawing,
+		Name:   theme.Name,
+		Elements: &ThemeElements{
+			ColorScheme: &ColorScheme{
+				Name:              theme.Name,
+				Dark1:             newSchemeSlot(c.Dark1, "windowText", domain.ColorBlack),
+				Light1:            newSchemeSlot(c.Light1, "window", domain.ColorWhite),
+				Dark2:             newSchemeSlot(c.Dark2, "", domain.Color{}),
+				Light2:            newSchemeSlot(c.Light2, "", domain.Color{}),
+				Accent1:           newSchemeSlot(c.Accent1, "", domain.Color{}),
+				Accent2:           newSchemeSlot(c.Accent2, "", domain.Color{}),
+				Accent3:           newSchemeSlot(c.Accent3, "", domain.Color{}),
+				Accent4:           newSchemeSlot(c.Accent4, "", domain.Color{}),
+				Accent5:           newSchemeSlot(c.Accent5, "", domain.Color{}),
+				Accent6:           newSchemeSlot(c.Accent6, "", domain.Color{}),
+				Hyperlink:         newSchemeSlot(c.Hyperlink, "", domain.Color{}),
+				FollowedHyperlink: newSchemeSlot(c.FollowedHyperlink, "", domain.Color{}),
+			},
+			FontScheme: &FontScheme{
+				Name:      theme.Name,
+				MajorFont: newThemeFont(theme.MajorFont),
+				MinorFont: newThemeFont(theme.MinorFont),
+			},
+			FormatScheme: &FormatScheme{Name: "Office", Inner: officeFormatScheme},
+		},
+	}
+}
+
+// newSchemeSlot writes c as the system color sys when it has the system
+// color's usual value, as Word does for text and background.
+func newSchemeSlot(c domain.Color, sys string, sysValue domain.Color) *SchemeSlot {
+	if sys != "" && c == sysValue {
+		return &SchemeSlot{SysClr: &SysClr{Val: sys, LastClr: color.ToHex(c)}}
+	}
+	return &SchemeSlot{SrgbClr: &SrgbClr{Val: color.ToHex(c)}}
+}
+
+func newThemeFont(typeface string) *ThemeFont {
+	return &ThemeFont{Latin: &TextFont{Typeface: typeface}, EastAsia: &TextFont{}, Complex: &TextFont{}}
+}
+
+// officeFormatScheme is the fill, line and effect styles of the Office theme.
+const officeFormatScheme = `<a:fillStyleLst>` +
+	`<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>` +
+	`<a:gradFill rotWithShape="1">` +
+	`<a:gsLst>` +
+	`<a:gs pos="0"><a:schemeClr val="phClr"><a:tint val="50000"/><a:satMod val="300000"/></a:schemeClr></a:gs>` +
+	`<a:gs pos="35000"><a:schemeClr val="phClr"><a:tint val="37000"/><a:satMod val="300000"/></a:schemeClr></a:gs>` +
+	`<a:gs pos="100000"><a:schemeClr val="phClr"><a:tint val="15000"/><a:satMod val="350000"/></a:schemeClr></a:gs>` +
+	`</a:gsLst>` +
+	`<a:lin ang="16200000" scaled="1"/>` +
+	`</a:gradFill>` +
+	`<a:gradFill rotWithShape="1">` +
+	`<a:gsLst>` +
+	`<a:gs pos="0"><a:schemeClr val="phClr"><a:shade val="51000"/><a:satMod val="130000"/></a:schemeClr></a:gs>` +
+	`<a:gs pos="80000"><a:schemeClr val="phClr"><a:shade val="93000"/><a:satMod val="130000"/></a:schemeClr></a:gs>` +
+	`<a:gs pos="100000"><a:schemeClr val="phClr"><a:shade val="94000"/><a:satMod val="350000"/></a:schemeClr></a:gs>` +
+	`</a:gsLst>` +
+	`<a:lin ang="16200000" scaled="1"/>` +
+	`</a:gradFill>` +
+	`</a:fillStyleLst>` +
+	`<a:lnStyleLst>` +
+	`<a:ln w="9525" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill><a:prstDash val="solid"/><a:miter lim="800000"/></a:ln>` +
+	`<a:ln w="25400" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill><a:prstDash val="solid"/><a:miter lim="800000"/></a:ln>` +
+	`<a:ln w="38100" cap="flat" cmpd="sng" algn="ctr"><a:solidFill><a:schemeClr val="phClr"/></a:solidFill><a:prstDash val="solid"/><a:miter lim="800000"/></a:ln>` +
+	`</a:lnStyleLst>` +
+	`<a:effectStyleLst>` +
+	`<a:effectStyle><a:effectLst/></a:effectStyle>` +
+	`<a:effectStyle><a:effectLst/></a:effectStyle>` +
+	`<a:effectStyle>` +
+	`<a:effectLst>` +
+	`<a:outerShdw blurRad="57150" dist="19050" dir="5400000" algn="ctr" rotWithShape="0">` +
+	`<a:srgbClr val="000000"><a:alpha val="63000"/></a:srgbClr>` +
+	`</a:outerShdw>` +
+	`</a:effectLst>` +
+	`</a:effectStyle>` +
+	`</a:effectStyleLst>` +
+	`<a:bgFillStyleLst>` +
+	`<a:solidFill><a:schemeClr val="phClr"/></a:solidFill>` +
+	`<a:solidFill><a:schemeClr val="phClr"><a:tint val="95000"/><a:satMod val="170000"/></a:schemeClr></a:solidFill>` +
+	`<a:gradFill rotWithShape="1">` +
+	`<a:gsLst>` +
+	`<a:gs pos="0"><a:schemeClr val="phClr"><a:tint val="93000"/><a:satMod val="150000"/><a:shade val="98000"/><a:lumMod val="102000"/></a:schemeClr></a:gs>` +
+	`<a:gs pos="50000"><a:schemeClr val="phClr"><a:tint val="98000"/><a:satMod val="130000"/><a:shade val="90000"/><a:lumMod val="103000"/></a:schemeClr></a:gs>` +
+	`<a:gs pos="100000"><a:schemeClr val="phClr"><a:shade val="63000"/><a:satMod val="120000"/></a:schemeClr></a:gs>` +
+	`</a:gsLst>` +
+	`<a:lin ang="16200000" scaled="1"/>` +
+	`</a:gradFill>` +
+	`</a:bgFillStyleLst>`
@@ -375,6 +375,12 @@ type mockImage struct {
 	target         string
 	description    string
 	position       domain.ImagePosition
+	crop           domain.ImageCrop
+	rotation       float64
+	flipH, flipV   bool
+	border         domain.ImageBorder
+	effects        domain.ImageEffects
+	hyperlinkRelID string
 }
 
 func (m *mockImage) ID() string                          { return m.id }
@@ -387,6 +393,26 @@ func (m *mockImage) Target() string                      { return m.target }
 func (m *mockImage) Description() string                 { return m.description }
 func (m *mockImage) SetDescription(desc string) error    { m.description = desc; return nil }
 func (m *mockImage) Position() domain.ImagePosition      { return m.position }
+func (m *mockImage) Crop() domain.ImageCrop              { return m.crop }
+func (m *mockImage) SetCrop(c domain.ImageCrop) error    { m.crop = c; return nil }
+func (m *mockImage) Rotation() float64                   { return m.rotation }
+func (m *mockImage) SetRotation(deg float64) error       { m.rotation = deg; return nil }
+func (m *mockImage) Flip() (bool, bool)                  { return m.flipH, m.flipV }
+func (m *mockImage) SetFlip(h, v bool) error             { m.flipH, m.flipV = h, v; return nil }
+func (m *mockImage) Border() domain.ImageBorder          { return m.border }
+func (m *mockImage) SetBorder(b domain.ImageBorder) error {
+	m.border = b
+	return nil
+}
+func (m *mockImage) Effects() domain.ImageEffects { return m.effects }
+func (m *mockImage) SetEffects(e domain.ImageEffects) error {
+	m.effects = e
+	return nil
+}
+func (m *mockImage) Hyperlink() string               { return "" }
+func (m *mockImage) SetHyperlink(string) error       { return nil }
+func (m *mockImage) ReplaceData([]byte, bool) error  { return nil }
+func (m *mockImage) HyperlinkRelationshipID() string { return m.hyperlinkRelID }
 
 func TestNewInlineDrawing(t *testing.T) {
 	img := &mockImage{
@@ -428,6 +454,53 @@ func TestNewInlineDrawing(t *testing.T) {
 	}
 }
 
+func TestPictureFormatting(t *testing.T) {
+	img := &mockImage{
+		id:             "img2",
+		size:           domain.NewImageSize(100, 50),
+		relationshipID: "rId1",
+		crop:           domain.ImageCrop{Left: 10, Bottom: 25.5},
+		rotation:       90,
+		flipH:          true,
+		border:         domain.ImageBorder{Color: domain.ColorRed, Width: 1.5},
+		effects: domain.ImageEffects{
+			Shadow:       domain.ShadowOuter,
+			Reflection:   domain.ReflectionHalf,
+			Brightness:   20,
+			Contrast:     -10,
+			Grayscale:    true,
+			Transparency: 30,
+		},
+		hyperlinkRelID: "rId9",
+	}
+
+	data, err := xml.Marshal(NewInlineDrawing(img, 1))
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	out := string(data)
+	for _, want := range []string{
+		`<a:hlinkClick xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" r:id="rId9"></a:hlinkClick></wp:docPr>`,
+		`<a:alphaModFix amt="70000"></a:alphaModFix><a:grayscl></a:grayscl><a:lum bright="20000" contrast="-10000"></a:lum></a:blip>`,
+		`<a:srcRect l="10000" b="25500"></a:srcRect>`,
+		`<a:xfrm rot="5400000" flipH="true">`,
+		`<a:ln w="19050"><a:solidFill><a:srgbClr val="FF0000"></a:srgbClr></a:solidFill></a:ln>`,
+		`<a:outerShdw blurRad="50800" dist="38100" dir="2700000" algn="tl" rotWithShape="false">`,
+		`<a:reflection blurRad="6350" stA="52000" endA="300" endPos="55000"`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("drawing XML missing %s", want)
+		}
+	}
+
+	plain, _ := xml.Marshal(NewInlineDrawing(&mockImage{id: "img3", size: domain.NewImageSize(1, 1)}, 2))
+	for _, unwanted := range []string{"srcRect", "a:ln", "effectLst", "hlinkClick", "rot="} {
+		if strings.Contains(string(plain), unwanted) {
+			t.Errorf("plain picture should not contain %s", unwanted)
+		}
+	}
+}
+
 func TestNewFloatingDrawing(t *testing.T) {
 	img := &mockImage{
 		id:             "img2",
@@ -0,0 +1,485 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package markdown
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+// blockKind identifies a block-level element.
+type blockKind int
+
+const (
+	blockParagraph blockKind = iota
+	blockHeading
+	blockCode
+	blockQuote
+	blockList
+	blockTable
+	blockRule
+)
+
+// block is a parsed block-level element.
+type block struct {
+	kind     blockKind
+	level    int      // Heading level
+	text     string   // Inline source of paragraphs and headings, or code
+	children []*block // Block quote content
+	list     *list
+	table    *table
+}
+
+// list is a bullet or ordered list.
+type list struct {
+	ordered bool
+	start   int
+	items   []*listItem
+}
+
+// taskState is the check box of a task list item.
+type taskState int
+
+const (
+	taskNone taskState = iota
+	taskOpen
+	taskDone
+)
+
+// listItem is one list entry with its nested blocks.
+type listItem struct {
+	task   taskState
+	blocks []*block
+}
+
+// table is a GFM table; the first row is the header.
+type table struct {
+	align []domain.Alignment
+	rows  [][]string
+}
+
+// parseContext holds the link reference and footnote definitions, which
+// may appear anywhere in the document.
+type parseContext struct {
+	refs      map[string]string
+	footnotes map[string][]*block
+}
+
+var (
+	atxHeading    = regexp.MustCompile(`^(#{1,6})(?:[ \t]+(.*?))?(?:[ \t]+#+)?[ \t]*$`)
+	thematicBreak = regexp.MustCompile(`^(?:(?:\*[ \t]*){3,}|(?:-[ \t]*){3,}|(?:_[ \t]*){3,})$`)
+	setextLine    = regexp.MustCompile(`^(=+|-+)[ \t]*$`)
+	codeFence     = regexp.MustCompile("^(`{3,}|~{3,})[ \t]*([^`]*)$")
+	bulletItem    = regexp.MustCompile(`^([-+*])([ \t]+|$)`)
+	orderedItem   = regexp.MustCompile(`^(\d{1,9})([.)])([ \t]+|$)`)
+	taskMarker    = regexp.MustCompile(`^\[([ xX])\](?:[ \t]+|$)`)
+	tableDelim    = regexp.MustCompile(`^\|?[ \t]*:?-+:?[ \t]*(?:\|[ \t]*:?-+:?[ \t]*)*\|?[ \t]*$`)
+	footnoteDef   = regexp.MustCompile(`^\[\^([^\]\s]+)\]:[ \t]*(.*)$`)
+	linkRefDef    = regexp.MustCompile(`^\[([^\]]+)\]:[ \t]*<?([^\s>]+)>?(?:[ \t]+(?:"[^"]*"|'[^']*'|\([^)]*\)))?[ \t]*$`)
+)
+
+// parse splits Markdown source into blocks and collects definitions.
+func parse(src []byte) ([]*block, *parseContext) {
+	ctx := &parseContext{refs: map[string]string{}, footnotes: map[string][]*block{}}
+	text := strings.ReplaceAll(strings.ReplaceAll(string(src), "\r\n", "\n"), "\r", "\n")
+	text = strings.TrimPrefix(text, "\uFEFF")
+	return ctx.parseBlocks(strings.Split(text, "\n")), ctx
+}
+
+// parseBlocks parses lines whose container markers are already removed.
+func (ctx *parseContext) parseBlocks(lines []string) []*block {
+	var (
+		blocks []*block
+		para   []string
+	)
+	flush := func() {
+		if len(para) > 0 {
+			blocks = append(blocks, &block{kind: blockParagraph, text: strings.Join(para, "\n")})
+			para = nil
+		}
+	}
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		if isBlank(line) {
+			flush()
+			i++
+			continue
+		}
+
+		indent := indentWidth(line)
+		if indent >= 4 && para == nil {
+			var code []string
+			for ; i < len(lines) && (isBlank(lines[i]) || indentWidth(lines[i]) >= 4); i++ {
+				code = append(code, stripIndent(lines[i], 4))
+			}
+			for len(code) > 0 && isBlank(code[len(code)-1]) {
+				code = code[:len(code)-1]
+			}
+			blocks = append(blocks, &block{kind: blockCode, text: strings.Join(code, "\n")})
+			continue
+		}
+		trimmed := strings.TrimLeft(line, " \t")
+
+		if para != nil && indent < 4 {
+			if m := setextLine.FindStringSubmatch(trimmed); m != nil {
+				level := 1
+				if m[1][0] == '-' {
+					level = 2
+				}
+				blocks = append(blocks, &block{kind: blockHeading, level: level, text: strings.Join(para, "\n")})
+				para = nil
+				i++
+				continue
+			}
+		}
+
+		if indent < 4 {
+			if m := codeFence.FindStringSubmatch(trimmed); m != nil {
+				flush()
+				fence := m[1]
+				var code []string
+				for i++; i < len(lines); i++ {
+					closing := strings.TrimSpace(lines[i])
+					if indentWidth(lines[i]) < 4 && strings.HasPrefix(closing, fence) && strings.Trim(closing, fence[:1]) == "" {
+						i++
+						break
+					}
+					code = append(code, stripIndent(lines[i], indent))
+				}
+				blocks = append(blocks, &block{kind: blockCode, text: strings.Join(code, "\n")})
+				continue
+			}
+
+			if m := atxHeading.FindStringSubmatch(trimmed); m != nil {
+				flush()
+				blocks = append(blocks, &block{kind: blockHeading, level: len(m[1]), text: strings.TrimSpace(m[2])})
+				i++
+				continue
+			}
+
+			if thematicBreak.MatchString(trimmed) {
+				flush()
+				blocks = append(blocks, &block{kind: blockRule})
+				i++
+				continue
+			}
+
+			if strings.HasPrefix(trimmed, ">") {
+				flush()
+				var inner []string
+				for ; i < len(lines) && !isBlank(lines[i]); i++ {
+					t := strings.TrimLeft(lines[i], " \t")
+					if strings.HasPrefix(t, ">") {
+						t = strings.TrimPrefix(strings.TrimPrefix(t, ">"), " ")
+					} else if len(inner) == 0 || startsBlock(t) {
+						break
+					}
+					inner = append(inner, t)
+				}
+				blocks = append(blocks, &block{kind: blockQuote, children: ctx.parseBlocks(inner)})
+				continue
+			}
+
+			if m := footnoteDef.FindStringSubmatch(trimmed); m != nil {
+				flush()
+				body := []string{m[2]}
+				for i++; i < len(lines); i++ {
+					if isBlank(lines[i]) {
+						if i+1 < len(lines) && indentWidth(lines[i+1]) >= 4 {
+							body = append(body, "")
+							continue
+						}
+						break
+					}
+					if t := strings.TrimLeft(lines[i], " \t"); indentWidth(lines[i]) < 4 && (startsBlock(t) || linkRefDef.MatchString(t)) {
+						break
+					}
+					body = append(body, stripIndent(lines[i], 4))
+				}
+				label := normalizeLabel(m[1])
+				if _, ok := ctx.footnotes[label]; !ok {
+					ctx.footnotes[label] = ctx.parseBlocks(body)
+				}
+				continue
+			}
+
+			if m := linkRefDef.FindStringSubmatch(trimmed); m != nil && para == nil {
+				label := normalizeLabel(m[1])
+				if _, ok := ctx.refs[label]; !ok {
+					ctx.refs[label] = unescapeText(m[2])
+				}
+				i++
+				continue
+			}
+
+			if marker, _, ok := listMarker(trimmed); ok && (para == nil || marker.interrupts) {
+				flush()
+				var lst *list
+				lst, i = ctx.parseList(lines, i)
+				blocks = append(blocks, &block{kind: blockList, list: lst})
+				continue
+			}
+
+			if strings.Contains(trimmed, "|") && i+1 < len(lines) && tableDelim.MatchString(strings.TrimSpace(lines[i+1])) {
+				header := splitRow(trimmed)
+				align := parseAlignments(strings.TrimSpace(lines[i+1]))
+				if len(header) == len(align) {
+					flush()
+					tbl := &table{align: align, rows: [][]string{header}}
+					for i += 2; i < len(lines) && !isBlank(lines[i]); i++ {
+						row := strings.TrimLeft(lines[i], " \t")
+						if startsBlock(row) {
+							break
+						}
+						tbl.rows = append(tbl.rows, fitRow(splitRow(row), len(align)))
+					}
+					blocks = append(blocks, &block{kind: blockTable, table: tbl})
+					continue
+				}
+			}
+		}
+
+		para = append(para, trimmed)
+		i++
+	}
+	flush()
+	return blocks
+}
+
+// itemMarker describes a list item marker.
+type itemMarker struct {
+	ordered    bool
+	char       byte // Bullet character or ordered delimiter
+	start      int
+	width      int // Columns up to the item content
+	interrupts bool
+}
+
+// listMarker parses the marker at the start of a trimmed line.
+func listMarker(line string) (itemMarker, string, bool) {
+	if thematicBreak.MatchString(line) {
+		return itemMarker{}, "", false
+	}
+	if m := bulletItem.FindStringSubmatch(line); m != nil {
+		rest := line[len(m[0]):]
+		return itemMarker{char: m[1][0], width: markerWidth(m[0]), interrupts: !isBlank(rest)}, rest, true
+	}
+	if m := orderedItem.FindStringSubmatch(line); m != nil {
+		start, _ := strconv.Atoi(m[1])
+		rest := line[len(m[0]):]
+		return itemMarker{ordered: true, char: m[2][0], start: start, width: markerWidth(m[0]), interrupts: start == 1 && !isBlank(rest)}, rest, true
+	}
+	return itemMarker{}, "", false
+}
+
+// markerWidth returns the content offset of a marker with its spaces; a
+// marker followed by five or more spaces starts indented code after one.
+func markerWidth(marker string) int {
+	text := strings.TrimRight(marker, " \t")
+	spaces := len(marker) - len(text)
+	if spaces == 0 || spaces > 4 {
+		spaces = 1
+	}
+	return len(text) + spaces
+}
+
+// parseList parses consecutive items of one list starting at lines[i].
+func (ctx *parseContext) parseList(lines []string, i int) (*list, int) {
+	first, _, _ := listMarker(strings.TrimLeft(lines[i], " \t"))
+	lst := &list{ordered: first.ordered, start: first.start}
+
+	for i < len(lines) {
+		indent := indentWidth(lines[i])
+		marker, rest, ok := listMarker(strings.TrimLeft(lines[i], " \t"))
+		if !ok || indent >= 4 || marker.ordered != first.ordered || marker.char != first.char {
+			break
+		}
+		content := indent + marker.width
+
+		item := &listItem{}
+		if m := taskMarker.FindStringSubmatch(rest); m != nil {
+			item.task = taskOpen
+			if m[1] != " " {
+				item.task = taskDone
+			}
+			rest = rest[len(m[0]):]
+		}
+		body := []string{rest}
+		lastBlank := false
+		for i++; i < len(lines); i++ {
+			line := lines[i]
+			if isBlank(line) {
+				body = append(body, "")
+				lastBlank = true
+				continue
+			}
+			if indentWidth(line) >= content {
+				body = append(body, stripIndent(line, content))
+				lastBlank = false
+				continue
+			}
+			trimmed := strings.TrimLeft(line, " \t")
+			if _, _, ok := listMarker(trimmed); ok || lastBlank || startsBlock(trimmed) {
+				break
+			}
+			body = append(body, trimmed) // Lazy paragraph continuation
+		}
+		item.blocks = ctx.parseBlocks(body)
+		lst.items = append(lst.items, item)
+
+		// Blank lines between items keep the list going.
+		j := i
+		for j < len(lines) && isBlank(lines[j]) {
+			j++
+		}
+		if j < len(lines) {
+			if next, _, ok := listMarker(strings.TrimLeft(lines[j], " \t")); ok && indentWidth(lines[j]) < 4 &&
+				next.ordered == first.ordered && next.char == first.char {
+				i = j
+			}
+		}
+	}
+	return lst, i
+}
+
+// startsBlock reports whether a trimmed line opens a block that ends a
+// paragraph.
+func startsBlock(line string) bool {
+	if atxHeading.MatchString(line) || thematicBreak.MatchString(line) || codeFence.MatchString(line) ||
+		strings.HasPrefix(line, ">") || footnoteDef.MatchString(line) {
+		return true
+	}
+	marker, _, ok := listMarker(line)
+	return ok && marker.interrupts
+}
+
+// splitRow splits a table row on unescaped pipes outside code spans.
+func splitRow(row string) []string {
+	row = strings.TrimSpace(row)
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, `\|`) {
+		row = row[:len(row)-1]
+	}
+	var (
+		cells []string
+		cell  strings.Builder
+		code  bool
+	)
+	for i := 0; i < len(row); i++ {
+		switch c := row[i]; {
+		case c == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case c == '`':
+			code = !code
+			cell.WriteByte(c)
+		case c == '|' && !code:
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(c)
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// fitRow pads or truncates a body row to the header's column count.
+func fitRow(cells []string, n int) []string {
+	for len(cells) < n {
+		cells = append(cells, "")
+	}
+	return cells[:n]
+}
+
+// parseAlignments reads the column alignments of a delimiter row.
+func parseAlignments(row string) []domain.Alignment {
+	cells := splitRow(row)
+	align := make([]domain.Alignment, len(cells))
+	for i, cell := range cells {
+		left, right := strings.HasPrefix(cell, ":"), strings.HasSuffix(cell, ":")
+		switch {
+		case left && right:
+			align[i] = domain.AlignmentCenter
+		case right:
+			align[i] = domain.AlignmentRight
+		default:
+			align[i] = domain.AlignmentLeft
+		}
+	}
+	return align
+}
+
+// isBlank reports whether a line holds only whitespace.
+func isBlank(line string) bool {
+	return strings.TrimSpace(line) == ""
+}
+
+// indentWidth returns the leading whitespace in columns; tabs stop every
+// four columns.
+func indentWidth(line string) int {
+	width := 0
+	for _, c := range line {
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return width
+		}
+	}
+	return width
+}
+
+// stripIndent removes up to n columns of leading whitespace.
+func stripIndent(line string, n int) string {
+	width := 0
+	for i, c := range line {
+		if width >= n {
+			return line[i:]
+		}
+		switch c {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+			if width > n {
+				return strings.Repeat(" ", width-n) + line[i+1:]
+			}
+		default:
+			return line[i:]
+		}
+	}
+	return ""
+}
+
+// normalizeLabel folds a link or footnote label for matching.
+func normalizeLabel(label string) string {
+	return strings.ToLower(strings.Join(strings.Fields(label), " "))
+}
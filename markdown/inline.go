@@ -0,0 +1,562 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// format is the character formatting of a span.
+type format struct {
+	bold, italic, strike, code bool
+}
+
+// spanKind identifies an inline element.
+type spanKind int
+
+const (
+	spanText spanKind = iota
+	spanBreak
+	spanImage
+	spanFootnote
+)
+
+// span is a run of inline content with uniform formatting.
+type span struct {
+	kind   spanKind
+	text   string // Text, image alt text, or footnote label
+	format format
+	link   string // Hyperlink target of text, or image source
+}
+
+var (
+	autolink     = regexp.MustCompile(`^<((?:https?|ftp)://[^\s<>]*|mailto:[^\s<>]+|[^\s<>@]+@[^\s<>]+\.[^\s<>]+)>`)
+	bareURL      = regexp.MustCompile(`^(?:https?://|www\.)[^\s<]*`)
+	htmlBreak    = regexp.MustCompile(`^<br\s*/?>`)
+	htmlTag      = regexp.MustCompile(`^</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>`)
+	entity       = regexp.MustCompile(`^&(?:#[0-9]{1,7}|#[xX][0-9a-fA-F]{1,6}|[A-Za-z][A-Za-z0-9]{1,31});`)
+	footnoteRef  = regexp.MustCompile(`^\[\^([^\]\s]+)\]`)
+	urlTrailPunc = "?!.,:*_~'\""
+)
+
+// inlineParser parses the inline content of one block.
+type inlineParser struct {
+	ctx   *parseContext
+	spans []span
+	text  strings.Builder
+	fmt   format
+	link  string
+}
+
+// parseInline converts inline Markdown to spans.
+func (ctx *parseContext) parseInline(src string) []span {
+	p := &inlineParser{ctx: ctx}
+	p.parse(src)
+	p.flush()
+	return p.spans
+}
+
+// flush emits the pending text as a span.
+func (p *inlineParser) flush() {
+	if p.text.Len() == 0 {
+		return
+	}
+	p.spans = append(p.spans, span{kind: spanText, text: p.text.String(), format: p.fmt, link: p.link})
+	p.text.Reset()
+}
+
+// nested parses src with changed formatting or link target.
+func (p *inlineParser) nested(src string, f format, link string) {
+	p.flush()
+	savedFmt, savedLink := p.fmt, p.link
+	p.fmt, p.link = f, link
+	p.parse(src)
+	p.flush()
+	p.fmt, p.link = savedFmt, savedLink
+}
+
+func (p *inlineParser) parse(s string) {
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s) && s[i+1] == '\n':
+			p.lineBreak()
+			i += 2
+			continue
+		case c == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]):
+			p.text.WriteByte(s[i+1])
+			i += 2
+			continue
+		case c == '\n':
+			hard := strings.HasSuffix(p.text.String(), "  ")
+			p.trimTrailingSpace()
+			if hard {
+				p.lineBreak()
+			} else {
+				p.text.WriteByte(' ')
+			}
+			i++
+			for i < len(s) && (s[i] == ' ' || s[i] == '\t') {
+				i++
+			}
+			continue
+		case c == '`':
+			if n := p.codeSpan(s, i); n > 0 {
+				i += n
+				continue
+			}
+			n := runLength(s, i)
+			p.text.WriteString(s[i : i+n])
+			i += n
+			continue
+		case c == '!' && i+1 < len(s) && s[i+1] == '[':
+			if n := p.linkOrImage(s, i+1, true); n > 0 {
+				i += 1 + n
+				continue
+			}
+		case c == '[':
+			if m := footnoteRef.FindStringSubmatch(s[i:]); m != nil {
+				if _, ok := p.ctx.footnotes[normalizeLabel(m[1])]; ok {
+					p.flush()
+					p.spans = append(p.spans, span{kind: spanFootnote, text: normalizeLabel(m[1])})
+					i += len(m[0])
+					continue
+				}
+			}
+			if n := p.linkOrImage(s, i, false); n > 0 {
+				i += n
+				continue
+			}
+		case c == '<':
+			if m := autolink.FindStringSubmatch(s[i:]); m != nil && p.link == "" {
+				target := m[1]
+				if !strings.Contains(target, ":") {
+					target = "mailto:" + target
+				}
+				p.nestedText(m[1], target)
+				i += len(m[0])
+				continue
+			}
+			if m := htmlBreak.FindString(s[i:]); m != "" {
+				p.lineBreak()
+				i += len(m)
+				continue
+			}
+			if m := htmlTag.FindString(s[i:]); m != "" {
+				i += len(m)
+				continue
+			}
+		case c == '&':
+			if m := entity.FindString(s[i:]); m != "" {
+				p.text.WriteString(html.UnescapeString(m))
+				i += len(m)
+				continue
+			}
+		case (c == 'h' || c == 'w') && p.link == "" && (i == 0 || isBoundary(s[i-1])):
+			if m := bareURL.FindString(s[i:]); m != "" {
+				m = trimURL(m)
+				if strings.Contains(m, ".") {
+					target := m
+					if strings.HasPrefix(target, "www.") {
+						target = "http://" + target
+					}
+					p.nestedText(m, target)
+					i += len(m)
+					continue
+				}
+			}
+		case c == '*' || c == '_' || c == '~':
+			if n := p.emphasis(s, i); n > 0 {
+				i += n
+				continue
+			}
+			n := runLength(s, i)
+			p.text.WriteString(s[i : i+n])
+			i += n
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(s[i:])
+		p.text.WriteString(s[i : i+size])
+		i += size
+	}
+}
+
+// nestedText emits literal text as a link.
+func (p *inlineParser) nestedText(text, link string) {
+	p.flush()
+	p.spans = append(p.spans, span{kind: spanText, text: text, format: p.fmt, link: link})
+}
+
+// lineBreak emits a hard line break.
+func (p *inlineParser) lineBreak() {
+	p.trimTrailingSpace()
+	p.flush()
+	p.spans = append(p.spans, span{kind: spanBreak})
+}
+
+// trimTrailingSpace drops spaces before a line ending.
+func (p *inlineParser) trimTrailingSpace() {
+	text := strings.TrimRight(p.text.String(), " \t")
+	p.text.Reset()
+	p.text.WriteString(text)
+}
+
+// codeSpan parses a code span at s[i] and returns its length, or 0.
+func (p *inlineParser) codeSpan(s string, i int) int {
+	n := runLength(s, i)
+	for j := i + n; j < len(s); {
+		if s[j] != '`' {
+			j++
+			continue
+		}
+		m := runLength(s, j)
+		if m == n {
+			code := strings.ReplaceAll(s[i+n:j], "\n", " ")
+			if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.TrimSpace(code) != "" {
+				code = code[1 : len(code)-1]
+			}
+			p.flush()
+			f := p.fmt
+			f.code = true
+			p.spans = append(p.spans, span{kind: spanText, text: code, format: f, link: p.link})
+			return j + m - i
+		}
+		j += m
+	}
+	return 0
+}
+
+// linkOrImage parses a link or image whose label opens at s[i] and
+// returns the length consumed from i, or 0.
+func (p *inlineParser) linkOrImage(s string, i int, image bool) int {
+	end := closingBracket(s, i)
+	if end < 0 {
+		return 0
+	}
+	label := s[i+1 : end]
+	target, n := "", 0
+
+	switch rest := s[end+1:]; {
+	case strings.HasPrefix(rest, "("):
+		var ok bool
+		target, n, ok = parseDestination(rest)
+		if !ok {
+			return 0
+		}
+	case strings.HasPrefix(rest, "["):
+		refEnd := strings.IndexByte(rest, ']')
+		if refEnd < 0 {
+			return 0
+		}
+		ref := rest[1:refEnd]
+		if ref == "" {
+			ref = label
+		}
+		var ok bool
+		if target, ok = p.ctx.refs[normalizeLabel(ref)]; !ok {
+			return 0
+		}
+		n = refEnd + 1
+	default:
+		var ok bool
+		if target, ok = p.ctx.refs[normalizeLabel(label)]; !ok {
+			return 0
+		}
+	}
+
+	if image {
+		p.flush()
+		p.spans = append(p.spans, span{kind: spanImage, text: plainText(p.ctx.parseInline(label)), link: target})
+	} else if p.link != "" {
+		p.nested(label, p.fmt, p.link) // Links cannot nest
+	} else {
+		p.nested(label, p.fmt, target)
+	}
+	return end + 1 + n - i
+}
+
+// closingBracket finds the ']' matching the '[' at s[i], skipping code
+// spans and escapes.
+func closingBracket(s string, i int) int {
+	depth := 0
+	for j := i; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '`':
+			n := runLength(s, j)
+			if k := strings.Index(s[j+n:], s[j:j+n]); k >= 0 {
+				j += n + k + n - 1
+			} else {
+				j += n - 1
+			}
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// parseDestination parses "(dest "title")" and returns the destination
+// and the length consumed.
+func parseDestination(s string) (string, int, bool) {
+	i := 1
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n') {
+		i++
+	}
+	var dest string
+	if i < len(s) && s[i] == '<' {
+		end := strings.IndexAny(s[i+1:], ">\n")
+		if end < 0 || s[i+1+end] != '>' {
+			return "", 0, false
+		}
+		dest = s[i+1 : i+1+end]
+		i += end + 2
+	} else {
+		start, depth := i, 0
+		for ; i < len(s); i++ {
+			c := s[i]
+			if c == '\\' && i+1 < len(s) {
+				i++
+				continue
+			}
+			if c == '(' {
+				depth++
+			} else if c == ')' {
+				if depth == 0 {
+					break
+				}
+				depth--
+			} else if c == ' ' || c == '\t' || c == '\n' {
+				break
+			}
+		}
+		dest = s[start:i]
+	}
+	for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n') {
+		i++
+	}
+	if i < len(s) && (s[i] == '"' || s[i] == '\'' || s[i] == '(') {
+		closer := s[i]
+		if closer == '(' {
+			closer = ')'
+		}
+		end := strings.IndexByte(s[i+1:], closer)
+		if end < 0 {
+			return "", 0, false
+		}
+		i += end + 2
+		for i < len(s) && (s[i] == ' ' || s[i] == '\t' || s[i] == '\n') {
+			i++
+		}
+	}
+	if i >= len(s) || s[i] != ')' {
+		return "", 0, false
+	}
+	return unescapeText(dest), i + 1, true
+}
+
+// emphasis parses emphasis, strong emphasis or strikethrough opening at
+// s[i] and returns the length consumed, or 0.
+func (p *inlineParser) emphasis(s string, i int) int {
+	c := s[i]
+	n := runLength(s, i)
+	if !leftFlanking(s, i, n) {
+		return 0
+	}
+
+	widths := []int{1}
+	switch {
+	case c == '~' && n <= 2:
+		widths = []int{n}
+	case c == '~':
+		return 0
+	case n >= 3:
+		widths = []int{3, 2, 1}
+	case n == 2:
+		widths = []int{2, 1}
+	}
+
+	for _, w := range widths {
+		end := findCloser(s, i+n, c, w)
+		if end < 0 {
+			continue
+		}
+		f := p.fmt
+		switch {
+		case c == '~':
+			f.strike = true
+		case w == 3:
+			f.bold, f.italic = true, true
+		case w == 2:
+			f.bold = true
+		default:
+			f.italic = true
+		}
+		if n > w {
+			p.text.WriteString(s[i : i+n-w])
+		}
+		p.nested(s[i+n:end], f, p.link)
+		return end + w - i
+	}
+	return 0
+}
+
+// findCloser finds a closing delimiter run of width w at or after s[j].
+func findCloser(s string, j int, c byte, w int) int {
+	for j < len(s) {
+		switch s[j] {
+		case '\\':
+			j += 2
+			continue
+		case '`':
+			n := runLength(s, j)
+			if k := strings.Index(s[j+n:], s[j:j+n]); k >= 0 {
+				j += n + k + n
+			} else {
+				j += n
+			}
+			continue
+		case c:
+			n := runLength(s, j)
+			closes := rightFlanking(s, j, n)
+			if n >= w && closes && (c != '~' || n == w) {
+				return j + n - w
+			}
+			if !closes && leftFlanking(s, j, n) {
+				// Skip nested emphasis, as in *a **b** c*.
+				if inner := findCloser(s, j+n, c, n); inner >= 0 {
+					j = inner + n
+					continue
+				}
+			}
+			j += n
+			continue
+		}
+		j++
+	}
+	return -1
+}
+
+// leftFlanking reports whether the delimiter run s[i:i+n] can open.
+func leftFlanking(s string, i, n int) bool {
+	next, _ := utf8.DecodeRuneInString(s[i+n:])
+	prev, _ := utf8.DecodeLastRuneInString(s[:i])
+	if i+n >= len(s) || unicode.IsSpace(next) {
+		return false
+	}
+	if s[i] == '_' && i > 0 && (unicode.IsLetter(prev) || unicode.IsDigit(prev)) {
+		return false
+	}
+	return !unicode.IsPunct(next) && !unicode.IsSymbol(next) || i == 0 || unicode.IsSpace(prev) || unicode.IsPunct(prev) || unicode.IsSymbol(prev)
+}
+
+// rightFlanking reports whether the delimiter run s[j:j+n] can close.
+func rightFlanking(s string, j, n int) bool {
+	if j == 0 {
+		return false
+	}
+	prev, _ := utf8.DecodeLastRuneInString(s[:j])
+	next, _ := utf8.DecodeRuneInString(s[j+n:])
+	end := j+n >= len(s)
+	if unicode.IsSpace(prev) {
+		return false
+	}
+	if s[j] == '_' && !end && (unicode.IsLetter(next) || unicode.IsDigit(next)) {
+		return false
+	}
+	return !unicode.IsPunct(prev) && !unicode.IsSymbol(prev) || end || unicode.IsSpace(next) || unicode.IsPunct(next) || unicode.IsSymbol(next)
+}
+
+// runLength counts the repeats of s[i] starting at i.
+func runLength(s string, i int) int {
+	n := 1
+	for i+n < len(s) && s[i+n] == s[i] {
+		n++
+	}
+	return n
+}
+
+// trimURL drops trailing punctuation and unbalanced parentheses from a
+// bare URL.
+func trimURL(url string) string {
+	for len(url) > 0 {
+		last := url[len(url)-1]
+		switch {
+		case strings.IndexByte(urlTrailPunc, last) >= 0:
+			url = url[:len(url)-1]
+		case last == ')' && strings.Count(url, ")") > strings.Count(url, "("):
+			url = url[:len(url)-1]
+		default:
+			return url
+		}
+	}
+	return url
+}
+
+// isBoundary reports whether a bare URL may start after c.
+func isBoundary(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '(' || c == '*' || c == '_' || c == '~'
+}
+
+// isASCIIPunct reports whether c may be backslash-escaped.
+func isASCIIPunct(c byte) bool {
+	return strings.IndexByte("!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~", c) >= 0
+}
+
+// unescapeText resolves backslash escapes and entities in a destination.
+func unescapeText(s string) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && isASCIIPunct(s[i+1]) {
+			i++
+		}
+		sb.WriteByte(s[i])
+	}
+	return html.UnescapeString(sb.String())
+}
+
+// plainText joins the text of spans, as used for image descriptions.
+func plainText(spans []span) string {
+	var sb strings.Builder
+	for _, sp := range spans {
+		switch sp.kind {
+		case spanText, spanImage:
+			sb.WriteString(sp.text)
+		case spanBreak:
+			sb.WriteByte(' ')
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,111 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package markdown converts CommonMark with the GitHub Flavored Markdown
+// extensions into Word documents.
+//
+// Headings become the Heading 1-6 styles, block quotes the Quote style and
+// lists real Word numbering. Tables, task lists, strikethrough, fenced
+// code, images, links and footnotes are supported.
+//
+//	doc, err := markdown.Convert(notes, markdown.Options{Theme: themes.Corporate})
+//	if err != nil {
+//		return err
+//	}
+//	return doc.SaveAs("release-notes.docx")
+package markdown
+
+import (
+	"os"
+	"path/filepath"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+	"github.com/mmonterroca/docxgo/v2/themes"
+)
+
+// Options configures a conversion.
+type Options struct {
+	// Theme styles the document; nil keeps the default styles. Code uses
+	// the theme's monospace font.
+	Theme themes.Theme
+
+	// BaseDir is the directory relative image paths resolve against. Empty
+	// uses the working directory, or the Markdown file's directory with
+	// ConvertFile.
+	BaseDir string
+
+	// AllowLocalFiles lets images read files from disk. It is off by
+	// default, since Markdown from users could otherwise copy any file the
+	// process can read into the document. Paths that leave BaseDir, files
+	// without an image extension and files over 32 MB are refused even
+	// when it is set. Refused images are replaced by their alt text.
+	AllowLocalFiles bool
+}
+
+// Convert creates a document from Markdown source.
+func Convert(src []byte, opts Options) (domain.Document, error) {
+	doc := docx.NewDocument()
+	if opts.Theme != nil {
+		if err := opts.Theme.ApplyTo(doc); err != nil {
+			return nil, errors.Wrap(err, "markdown.Convert")
+		}
+	}
+	if err := Append(doc, src, opts); err != nil {
+		return nil, errors.Wrap(err, "markdown.Convert")
+	}
+	return doc, nil
+}
+
+// ConvertFile creates a document from a Markdown file. Images resolve
+// against the file's directory unless opts.BaseDir is set.
+func ConvertFile(path string, opts Options) (domain.Document, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "markdown.ConvertFile")
+	}
+	if opts.BaseDir == "" {
+		opts.BaseDir = filepath.Dir(path)
+	}
+	doc, err := Convert(src, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "markdown.ConvertFile")
+	}
+	return doc, nil
+}
+
+// Append adds the Markdown content to the end of doc. opts.Theme only
+// selects the code font; apply the theme to doc to restyle it.
+func Append(doc domain.Document, src []byte, opts Options) error {
+	if doc == nil {
+		return errors.InvalidArgument("markdown.Append", "doc", doc, "document cannot be nil")
+	}
+	blocks, ctx := parse(src)
+	r := newRenderer(doc, ctx, opts)
+	if err := r.renderBlocks(doc, blocks, renderState{}); err != nil {
+		return errors.Wrap(err, "markdown.Append")
+	}
+	return nil
+}
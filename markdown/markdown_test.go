@@ -0,0 +1,256 @@
+package markdown_test
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/markdown"
+	"github.com/mmonterroca/docxgo/v2/themes"
+)
+
+const releaseNotes = `# Release 2.4
+
+Setext heading
+--------------
+
+> Upgrade before **June**.
+
+1. First
+2. Second
+   - Nested bullet
+   - [x] Done task
+3. Third
+
+- [ ] Open task
+
+| Feature | Status |
+|:--------|-------:|
+| Themes  | *done* |
+
+Ship it[^ship].
+
+[^ship]: Deployed on *Friday*.
+`
+
+func TestConvertBlocks(t *testing.T) {
+	doc, err := markdown.Convert([]byte(releaseNotes), markdown.Options{})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	paras := doc.Paragraphs()
+	want := []struct {
+		text, style string
+		level       int // -1 for no numbering
+	}{
+		{"Release 2.4", domain.StyleIDHeading1, -1},
+		{"Setext heading", domain.StyleIDHeading2, -1},
+		{"Upgrade before June.", domain.StyleIDQuote, -1},
+		{"First", domain.StyleIDListParagraph, 0},
+		{"Second", domain.StyleIDListParagraph, 0},
+		{"Nested bullet", domain.StyleIDListParagraph, 1},
+		{"☒ Done task", domain.StyleIDListParagraph, 1},
+		{"Third", domain.StyleIDListParagraph, 0},
+		{"☐ Open task", domain.StyleIDListParagraph, 0},
+		{"Ship it.", "", -1},
+	}
+	if len(paras) != len(want) {
+		t.Fatalf("got %d paragraphs, want %d", len(paras), len(want))
+	}
+	ids := map[string]int{}
+	for i, w := range want {
+		p := paras[i]
+		style := ""
+		if named, ok := p.(interface{ StyleName() string }); ok {
+			style = named.StyleName()
+		}
+		if p.Text() != w.text || style != w.style {
+			t.Errorf("paragraph %d = %q (%s), want %q (%s)", i, p.Text(), style, w.text, w.style)
+		}
+		ref, numbered := p.Numbering()
+		if numbered != (w.level >= 0) || (numbered && ref.Level != w.level) {
+			t.Errorf("paragraph %d numbering = %+v, %v; want level %d", i, ref, numbered, w.level)
+		}
+		if numbered {
+			ids[w.text] = ref.ID
+		}
+	}
+	if ids["First"] != ids["Third"] || ids["First"] == ids["Nested bullet"] || ids["First"] == ids["☐ Open task"] {
+		t.Errorf("list IDs = %v; ordered items should share a list apart from the bullets", ids)
+	}
+
+	tables := doc.Tables()
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1", len(tables))
+	}
+	header, _ := tables[0].Row(0)
+	cell, _ := header.Cell(0)
+	if run := cell.Paragraphs()[0].Runs()[0]; run.Text() != "Feature" || !run.Bold() {
+		t.Errorf("header cell = %q bold=%v", run.Text(), run.Bold())
+	}
+	body, _ := tables[0].Row(1)
+	cell, _ = body.Cell(1)
+	if cell.Paragraphs()[0].Alignment() != domain.AlignmentRight {
+		t.Errorf("status column alignment = %v, want right", cell.Paragraphs()[0].Alignment())
+	}
+
+	notes := doc.Footnotes()
+	if len(notes) != 1 || notes[0].Text() != "Deployed on Friday." {
+		t.Fatalf("footnotes = %v", notes)
+	}
+}
+
+func TestConvertInlines(t *testing.T) {
+	src := "Plain **bold** *italic* ~~gone~~ `go test` and [docs](https://example.com/docs).  \nNext"
+	doc, err := markdown.Convert([]byte(src), markdown.Options{Theme: themes.Corporate})
+	if err != nil {
+		t.Fatalf("Convert() error = %v", err)
+	}
+
+	runs := doc.Paragraphs()[0].Runs()
+	find := func(text string) domain.Run {
+		for _, run := range runs {
+			if run.Text() == text {
+				return run
+			}
+		}
+		t.Fatalf("no run %q", text)
+		return nil
+	}
+	if !find("bold").Bold() || !find("italic").Italic() || !find("gone").Strike() {
+		t.Error("emphasis not applied")
+	}
+	if font := find("go test").Font().Name; font != themes.Corporate.Fonts().Monospace {
+		t.Errorf("code font = %q, want theme monospace %q", font, themes.Corporate.Fonts().Monospace)
+	}
+	if ref, ok := find("docs").ThemeColor(); !ok || ref.Color != domain.ThemeColorHyperlink {
+		t.Errorf("link color = %+v, %v", ref, ok)
+	}
+	if doc.Paragraphs()[0].Text() != "Plain bold italic gone go test and docs.Next" {
+		t.Errorf("text = %q", doc.Paragraphs()[0].Text())
+	}
+	if doc.Theme().Name != themes.Corporate.DocumentTheme().Name {
+		t.Errorf("theme %q not applied", doc.Theme().Name)
+	}
+}
+
+func TestConvertFileWithImage(t *testing.T) {
+	dir := t.TempDir()
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 2000, 100))); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "wide.png"), img.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	src := "![Architecture](wide.png)\n\n```sh\nmake\nmake test\n```\n\n---\n\nA note[^1].\n\n[^1]: See ![remote](https://example.com/a.png).\n"
+	path := filepath.Join(dir, "notes.md")
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := markdown.ConvertFile(path, markdown.Options{AllowLocalFiles: true})
+	if err != nil {
+		t.Fatalf("ConvertFile() error = %v", err)
+	}
+	images := doc.Images()
+	if len(images) != 1 || images[0].Image.Description() != "Architecture" {
+		t.Fatalf("images = %v", images)
+	}
+	section, _ := doc.DefaultSection()
+	width := (section.PageSize().Width - section.Margins().Left - section.Margins().Right) * 635
+	if size := images[0].Image.Size(); size.WidthEMU != width {
+		t.Errorf("image width = %d EMU, want page width %d", size.WidthEMU, width)
+	}
+	code := doc.Paragraphs()[1].Runs()[0]
+	if code.Text() != "make\nmake test" || code.Font().Name != "Courier New" {
+		t.Errorf("code block = %q in %q", code.Text(), code.Font().Name)
+	}
+	if doc.Paragraphs()[2].Borders().Bottom.Style != domain.BorderSingle {
+		t.Error("thematic break has no bottom border")
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	reopened, err := docx.OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("OpenDocumentFromBytes() error = %v", err)
+	}
+	notes := reopened.Footnotes()
+	if len(notes) != 1 || notes[0].Text() != "See remote." {
+		t.Errorf("reopened footnotes = %q", notes[0].Text())
+	}
+	if ref, ok := reopened.Paragraphs()[0].Numbering(); ok {
+		t.Errorf("image paragraph numbered: %+v", ref)
+	}
+
+	if _, err := markdown.Convert([]byte("![x](missing.png)"), markdown.Options{BaseDir: dir, AllowLocalFiles: true}); err == nil ||
+		!strings.Contains(err.Error(), "missing.png") {
+		t.Errorf("missing image error = %v", err)
+	}
+}
+
+func TestConvertLocalImagesStayInBaseDir(t *testing.T) {
+	root := t.TempDir()
+	dir := filepath.Join(root, "notes")
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{filepath.Join(root, "secret.png"), filepath.Join(dir, "ok.png"), filepath.Join(dir, "data.txt")} {
+		if err := os.WriteFile(path, img.Bytes(), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Symlink(filepath.Join(root, "secret.png"), filepath.Join(dir, "link.png")); err != nil {
+		t.Fatal(err)
+	}
+
+	hostile := []string{
+		"../secret.png",
+		filepath.ToSlash(filepath.Join(root, "secret.png")),
+		"%2e%2e/secret.png",
+		"link.png",
+		"data.txt",
+		"/dev/zero",
+	}
+	for _, src := range hostile {
+		doc, err := markdown.Convert([]byte("![refused]("+src+")"), markdown.Options{BaseDir: dir, AllowLocalFiles: true})
+		if err != nil {
+			t.Errorf("%s: Convert() error = %v", src, err)
+			continue
+		}
+		if n := len(doc.Images()); n != 0 || doc.Paragraphs()[0].Text() != "refused" {
+			t.Errorf("%s: %d images, text %q; want the alt text", src, n, doc.Paragraphs()[0].Text())
+		}
+	}
+
+	for _, uri := range []string{"data:image/png;base64,@@@", "data:text/plain;base64,aGk="} {
+		doc, err := markdown.Convert([]byte("![refused]("+uri+")"), markdown.Options{})
+		if err != nil || len(doc.Images()) != 0 || doc.Paragraphs()[0].Text() != "refused" {
+			t.Errorf("%s: want the alt text, got error %v", uri, err)
+		}
+	}
+
+	// Without AllowLocalFiles nothing is read from disk.
+	doc, err := markdown.Convert([]byte("![ok](ok.png)"), markdown.Options{BaseDir: dir})
+	if err != nil || len(doc.Images()) != 0 {
+		t.Errorf("default options read a local image: %v", err)
+	}
+	doc, err = markdown.Convert([]byte("![ok](ok.png)"), markdown.Options{BaseDir: dir, AllowLocalFiles: true})
+	if err != nil || len(doc.Images()) != 1 {
+		t.Errorf("image inside BaseDir: %d images, %v", len(doc.Images()), err)
+	}
+}
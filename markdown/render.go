@@ -0,0 +1,517 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package markdown
+
+import (
+	"encoding/base64"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// headingStyles maps heading levels to paragraph styles.
+var headingStyles = [...]string{
+	domain.StyleIDHeading1, domain.StyleIDHeading2, domain.StyleIDHeading3,
+	domain.StyleIDHeading4, domain.StyleIDHeading5, domain.StyleIDHeading6,
+}
+
+// Task list check boxes.
+const (
+	taskOpenBox = "☐ "
+	taskDoneBox = "☒ "
+)
+
+// paragraphAdder is a document, table cell or footnote.
+type paragraphAdder interface {
+	AddParagraph() (domain.Paragraph, error)
+}
+
+// tableAdder is a document or table cell.
+type tableAdder interface {
+	AddTable(rows, cols int) (domain.Table, error)
+}
+
+// renderState carries the enclosing quote, list and footnote.
+type renderState struct {
+	quote      bool
+	list       bool
+	level      int
+	listID     int
+	ordered    bool
+	item       *pendingItem
+	inFootnote bool
+}
+
+// pendingItem is the numbering of a list item, taken by its first
+// paragraph.
+type pendingItem struct {
+	ref  domain.NumberingReference
+	task taskState
+	used bool
+}
+
+// renderer writes parsed blocks into a document.
+type renderer struct {
+	doc        domain.Document
+	ctx        *parseContext
+	opts       Options
+	mono       string
+	ruleColor  domain.Color
+	imageWidth int // Widest image in EMUs
+}
+
+func newRenderer(doc domain.Document, ctx *parseContext, opts Options) *renderer {
+	r := &renderer{
+		doc:        doc,
+		ctx:        ctx,
+		opts:       opts,
+		mono:       "Courier New",
+		ruleColor:  domain.Color{R: 191, G: 191, B: 191},
+		imageWidth: 6 * 914400,
+	}
+	if opts.Theme != nil {
+		if mono := opts.Theme.Fonts().Monospace; mono != "" {
+			r.mono = mono
+		}
+		r.ruleColor = opts.Theme.Colors().Muted
+	}
+	if section, err := doc.DefaultSection(); err == nil {
+		size, margins := section.PageSize(), section.Margins()
+		if width := size.Width - margins.Left - margins.Right; width > 0 {
+			r.imageWidth = width * 635 // 914400 EMU / 1440 twips
+		}
+	}
+	return r
+}
+
+func (r *renderer) renderBlocks(c paragraphAdder, blocks []*block, st renderState) error {
+	for _, b := range blocks {
+		if err := r.renderBlock(c, b, st); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *renderer) renderBlock(c paragraphAdder, b *block, st renderState) error {
+	switch b.kind {
+	case blockHeading:
+		p, err := r.newParagraph(c, st)
+		if err != nil {
+			return err
+		}
+		if err := p.SetStyle(headingStyles[b.level-1]); err != nil {
+			return err
+		}
+		return r.renderInlines(p, r.ctx.parseInline(b.text), st)
+	case blockCode:
+		p, err := r.newParagraph(c, st)
+		if err != nil {
+			return err
+		}
+		return r.addText(p, span{text: b.text, format: format{code: true}})
+	case blockRule:
+		p, err := r.newParagraph(c, st)
+		if err != nil {
+			return err
+		}
+		return p.SetBorderBottom(domain.BorderStyle{Style: domain.BorderSingle, Width: 6, Color: r.ruleColor})
+	case blockQuote:
+		st.quote = true
+		return r.renderBlocks(c, b.children, st)
+	case blockList:
+		return r.renderList(c, b.list, st)
+	case blockTable:
+		return r.renderTable(c, b.table, st)
+	default:
+		p, err := r.newParagraph(c, st)
+		if err != nil {
+			return err
+		}
+		return r.renderInlines(p, r.ctx.parseInline(b.text), st)
+	}
+}
+
+// newParagraph adds a paragraph styled for its quote or list. The first
+// paragraph of a list item carries the item's number and check box.
+func (r *renderer) newParagraph(c paragraphAdder, st renderState) (domain.Paragraph, error) {
+	p, err := c.AddParagraph()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case st.quote:
+		err = p.SetStyle(domain.StyleIDQuote)
+	case st.list:
+		err = p.SetStyle(domain.StyleIDListParagraph)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !st.list {
+		return p, nil
+	}
+
+	if item := st.item; item != nil && !item.used {
+		item.used = true
+		if err := p.SetNumbering(item.ref); err != nil {
+			return nil, err
+		}
+		switch item.task {
+		case taskOpen:
+			err = r.addText(p, span{text: taskOpenBox})
+		case taskDone:
+			err = r.addText(p, span{text: taskDoneBox})
+		}
+		return p, err
+	}
+	return p, p.SetIndent(domain.Indentation{Left: 720 * (st.level + 1)})
+}
+
+// renderList numbers the items of a list. A nested list of the same kind
+// continues its parent's numbering one level down.
+func (r *renderer) renderList(c paragraphAdder, lst *list, st renderState) error {
+	level := 0
+	if st.list {
+		level = min(st.level+1, domain.NumberingLevelMax)
+	}
+	id := st.listID
+	if !st.list || st.ordered != lst.ordered || (lst.ordered && lst.start != 1) {
+		def := domain.ListDefinition{Kind: domain.ListBullet}
+		if lst.ordered {
+			def = domain.ListDefinition{Kind: domain.ListDecimal, Start: lst.start}
+		}
+		var err error
+		if id, err = r.doc.AddList(def); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range lst.items {
+		itemState := st
+		itemState.list, itemState.level, itemState.listID, itemState.ordered = true, level, id, lst.ordered
+		itemState.item = &pendingItem{ref: domain.NumberingReference{ID: id, Level: level}, task: item.task}
+
+		if len(item.blocks) == 0 || item.blocks[0].kind == blockList || item.blocks[0].kind == blockTable {
+			if _, err := r.newParagraph(c, itemState); err != nil {
+				return err
+			}
+		}
+		if err := r.renderBlocks(c, item.blocks, itemState); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renderTable adds a grid table with a bold header row. Containers that
+// cannot hold tables get one paragraph per row.
+func (r *renderer) renderTable(c paragraphAdder, t *table, st renderState) error {
+	adder, ok := c.(tableAdder)
+	if !ok {
+		for _, row := range t.rows {
+			p, err := r.newParagraph(c, st)
+			if err != nil {
+				return err
+			}
+			if err := r.renderInlines(p, r.ctx.parseInline(strings.Join(row, "\t")), st); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	tbl, err := adder.AddTable(len(t.rows), len(t.align))
+	if err != nil {
+		return err
+	}
+	if err := tbl.SetStyle(domain.TableStyleGrid); err != nil {
+		return err
+	}
+	for i, row := range tbl.Rows() {
+		for col, cell := range row.Cells() {
+			p, err := cell.AddParagraph()
+			if err != nil {
+				return err
+			}
+			if err := p.SetAlignment(t.align[col]); err != nil {
+				return err
+			}
+			spans := r.ctx.parseInline(t.rows[i][col])
+			if i == 0 {
+				for j := range spans {
+					spans[j].format.bold = true
+				}
+			}
+			if err := r.renderInlines(p, spans, renderState{inFootnote: st.inFootnote}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (r *renderer) renderInlines(p domain.Paragraph, spans []span, st renderState) error {
+	for _, sp := range spans {
+		var err error
+		switch sp.kind {
+		case spanBreak:
+			var run domain.Run
+			if run, err = p.AddRun(); err == nil {
+				err = run.AddBreak(domain.BreakTypeLine)
+			}
+		case spanImage:
+			err = r.addImage(p, sp)
+		case spanFootnote:
+			err = r.addFootnote(p, sp.text, st)
+		default:
+			err = r.addText(p, sp)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addText adds a formatted run, as a hyperlink when the span links.
+func (r *renderer) addText(p domain.Paragraph, sp span) error {
+	if sp.text == "" {
+		return nil
+	}
+	var (
+		run domain.Run
+		err error
+	)
+	if run, err = p.AddRun(); err != nil {
+		return err
+	}
+	if err = run.SetText(sp.text); err != nil {
+		return err
+	}
+	if sp.link != "" {
+		if err = run.AddField(docx.NewHyperlinkField(sp.link, sp.text)); err != nil {
+			return err
+		}
+		if err = run.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorHyperlink}); err != nil {
+			return err
+		}
+		if err = run.SetUnderline(domain.UnderlineSingle); err != nil {
+			return err
+		}
+	}
+
+	if sp.format.bold {
+		err = run.SetBold(true)
+	}
+	if sp.format.italic && err == nil {
+		err = run.SetItalic(true)
+	}
+	if sp.format.strike && err == nil {
+		err = run.SetStrike(true)
+	}
+	if sp.format.code && err == nil {
+		err = run.SetFont(domain.Font{Name: r.mono})
+	}
+	return err
+}
+
+// addImage embeds a local or data URI image, scaled down to the page
+// width. Remote images become links, since nothing is downloaded, and
+// refused local images and malformed data URIs their alt text.
+func (r *renderer) addImage(p domain.Paragraph, sp span) error {
+	src := sp.link
+	var (
+		img domain.Image
+		err error
+	)
+	switch {
+	case strings.HasPrefix(src, "data:"):
+		data, format, ok := decodeDataURI(src)
+		if !ok {
+			// Malformed or non-image data URIs are shown as their alt text.
+			return r.addText(p, span{text: sp.text, format: sp.format})
+		}
+		img, err = p.AddImageFromBytes(data, format, domain.ImageSize{}, domain.ImagePosition{})
+	case strings.Contains(src, "://"):
+		text := sp.text
+		if text == "" {
+			text = src
+		}
+		return r.addText(p, span{text: text, link: src})
+	default:
+		data, format, ok, rerr := r.localImage(src)
+		if rerr != nil {
+			return errors.WrapWithContext(rerr, "markdown.Append", map[string]interface{}{"image": truncate(src, 80)})
+		}
+		if !ok {
+			return r.addText(p, span{text: sp.text, format: sp.format})
+		}
+		img, err = p.AddImageFromBytes(data, format, domain.ImageSize{}, domain.ImagePosition{})
+	}
+	if err != nil {
+		return errors.WrapWithContext(err, "markdown.Append", map[string]interface{}{"image": truncate(src, 80)})
+	}
+
+	if sp.text != "" {
+		if err := img.SetDescription(sp.text); err != nil {
+			return err
+		}
+	}
+	if size := img.Size(); size.WidthEMU > r.imageWidth {
+		scaled := domain.ImageSize{
+			WidthPx:   size.WidthPx * r.imageWidth / size.WidthEMU,
+			HeightPx:  size.HeightPx * r.imageWidth / size.WidthEMU,
+			WidthEMU:  r.imageWidth,
+			HeightEMU: int(int64(size.HeightEMU) * int64(r.imageWidth) / int64(size.WidthEMU)),
+		}
+		return img.SetSize(scaled)
+	}
+	return nil
+}
+
+// maxLocalImageSize is the largest image file read from disk.
+const maxLocalImageSize = 32 << 20
+
+// localImageFormats are the extensions of image files read from disk.
+var localImageFormats = map[string]domain.ImageFormat{
+	".png": domain.ImageFormatPNG, ".jpg": domain.ImageFormatJPEG, ".jpeg": domain.ImageFormatJPEG,
+	".gif": domain.ImageFormatGIF, ".bmp": domain.ImageFormatBMP, ".tif": domain.ImageFormatTIFF,
+	".tiff": domain.ImageFormatTIFF, ".svg": domain.ImageFormatSVG, ".webp": domain.ImageFormatWEBP,
+}
+
+// localImage reads the image file of an image source. ok is false when
+// the image is refused: local files are not allowed, the path resolves
+// outside BaseDir (after symbolic links), it has no image extension or
+// it is not a regular file of at most maxLocalImageSize bytes. Files
+// that are allowed but cannot be read are errors.
+func (r *renderer) localImage(src string) (data []byte, format domain.ImageFormat, ok bool, err error) {
+	if !r.opts.AllowLocalFiles {
+		return nil, "", false, nil
+	}
+	base := r.opts.BaseDir
+	if base == "" {
+		base = "."
+	}
+	if base, err = filepath.Abs(base); err == nil {
+		base, err = filepath.EvalSymlinks(base)
+	}
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "markdown.localImage")
+	}
+
+	path := src
+	if unescaped, uerr := url.PathUnescape(path); uerr == nil {
+		path = unescaped
+	}
+	path = filepath.FromSlash(path)
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(base, path)
+	}
+	path = filepath.Clean(path)
+	if !within(base, path) {
+		return nil, "", false, nil
+	}
+	if format, ok = localImageFormats[strings.ToLower(filepath.Ext(path))]; !ok {
+		return nil, "", false, nil
+	}
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "markdown.localImage")
+	}
+	if !within(base, resolved) {
+		return nil, "", false, nil
+	}
+
+	file, err := os.Open(resolved)
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "markdown.localImage")
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "markdown.localImage")
+	}
+	if !info.Mode().IsRegular() || info.Size() > maxLocalImageSize {
+		return nil, "", false, nil
+	}
+	data, err = io.ReadAll(io.LimitReader(file, maxLocalImageSize+1))
+	if err != nil {
+		return nil, "", false, errors.WrapWithCode(err, errors.ErrCodeIO, "markdown.localImage")
+	}
+	if len(data) > maxLocalImageSize {
+		return nil, "", false, nil
+	}
+	return data, format, true, nil
+}
+
+// within reports whether path is base or inside it. Both are clean
+// absolute paths.
+func within(base, path string) bool {
+	rel, err := filepath.Rel(base, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && !filepath.IsAbs(rel)
+}
+
+// addFootnote adds a footnote with the text of its definition. Word
+// cannot nest footnotes, so references inside a footnote stay as text.
+func (r *renderer) addFootnote(p domain.Paragraph, label string, st renderState) error {
+	if st.inFootnote {
+		return r.addText(p, span{text: "[^" + label + "]"})
+	}
+	note, err := p.AddFootnote()
+	if err != nil {
+		return err
+	}
+	return r.renderBlocks(note, r.ctx.footnotes[label], renderState{inFootnote: true})
+}
+
+// decodeDataURI decodes a base64 image data URI.
+func decodeDataURI(src string) ([]byte, domain.ImageFormat, bool) {
+	header, payload, ok := strings.Cut(strings.TrimPrefix(src, "data:"), ",")
+	if !ok || !strings.HasSuffix(header, ";base64") || !strings.HasPrefix(header, "image/") {
+		return nil, "", false
+	}
+	subtype := strings.TrimSuffix(strings.TrimPrefix(header, "image/"), ";base64")
+	format := domain.ImageFormat(strings.TrimSuffix(subtype, "+xml"))
+	data, err := base64.StdEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, "", false
+	}
+	return data, format, true
+}
+
+// truncate shortens s for error messages.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,259 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package odt converts documents to and from OpenDocument Text (.odt),
+// the format of LibreOffice Writer.
+//
+// Paragraphs and their styles, character formatting, lists, tables with
+// merged cells, images, footnotes, sections with their page layouts and
+// columns, and headers and footers are converted both ways. Hyperlinks
+// and page number fields are kept. Other fields are written as their
+// results.
+//
+// Templates (.ott) open like documents, with the same API as
+// docx.OpenDocument:
+//
+//	doc, err := odt.OpenDocument("letter.ott")
+//	if err != nil {
+//		return err
+//	}
+//	// fill in the template...
+//	err = odt.WriteFile(doc, "letter.odt")
+package odt
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Media types of ODF text documents and templates.
+const (
+	mediaTypeText     = "application/vnd.oasis.opendocument.text"
+	mediaTypeTemplate = "application/vnd.oasis.opendocument.text-template"
+)
+
+// odfVersion is the ODF version written.
+const odfVersion = "1.3"
+
+// OpenDocument reads an .odt document or .ott template from disk.
+func OpenDocument(path string) (domain.Document, error) {
+	const op = "odt.OpenDocument"
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	doc, err := read(data)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return doc, nil
+}
+
+// OpenDocumentFromBytes reads an .odt document or .ott template held in
+// memory.
+func OpenDocumentFromBytes(data []byte) (domain.Document, error) {
+	doc, err := read(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "odt.OpenDocumentFromBytes")
+	}
+	return doc, nil
+}
+
+// OpenDocumentFromReader reads an .odt document or .ott template from r.
+// The stream is buffered in memory, since zip archives need random
+// access.
+func OpenDocumentFromReader(r io.Reader) (domain.Document, error) {
+	const op = "odt.OpenDocumentFromReader"
+	if r == nil {
+		return nil, errors.InvalidArgument(op, "r", r, "reader cannot be nil")
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	doc, err := read(data)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return doc, nil
+}
+
+// Write writes doc to w as an .odt document.
+func Write(doc domain.Document, w io.Writer) error {
+	const op = "odt.Write"
+	if doc == nil {
+		return errors.InvalidArgument(op, "doc", doc, "document cannot be nil")
+	}
+	if w == nil {
+		return errors.InvalidArgument(op, "w", w, "writer cannot be nil")
+	}
+	data, err := build(doc)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if _, err := w.Write(data); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	return nil
+}
+
+// WriteFile writes doc to an .odt file.
+func WriteFile(doc domain.Document, path string) error {
+	const op = "odt.WriteFile"
+	if doc == nil {
+		return errors.InvalidArgument(op, "doc", doc, "document cannot be nil")
+	}
+	data, err := build(doc)
+	if err != nil {
+		return errors.Wrap(err, op)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	return nil
+}
+
+// build returns the package of a document: the mimetype first and
+// uncompressed, as ODF requires, then the XML parts, the pictures and
+// the manifest.
+func build(doc domain.Document) ([]byte, error) {
+	w := newWriter(doc)
+	if err := w.document(); err != nil {
+		return nil, err
+	}
+
+	files := append([]entry{
+		{"content.xml", "text/xml", w.contentXML()},
+		{"styles.xml", "text/xml", w.stylesXML()},
+		{"meta.xml", "text/xml", metaXML(doc.Metadata())},
+	}, w.pictures...)
+
+	var manifest xmlWriter
+	manifest.start("manifest:manifest", append(namespaceAttrs("manifest"), "manifest:version", odfVersion)...)
+	manifest.empty("manifest:file-entry", "manifest:full-path", "/", "manifest:version", odfVersion, "manifest:media-type", mediaTypeText)
+	for _, f := range files {
+		manifest.empty("manifest:file-entry", "manifest:full-path", f.path, "manifest:media-type", f.mediaType)
+	}
+	manifest.end("manifest:manifest")
+
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	mw, err := zw.CreateHeader(&zip.FileHeader{Name: "mimetype", Method: zip.Store})
+	if err != nil {
+		return nil, err
+	}
+	if _, err := mw.Write([]byte(mediaTypeText)); err != nil {
+		return nil, err
+	}
+	files = append(files, entry{"META-INF/manifest.xml", "", manifest.document()})
+	for _, f := range files {
+		fw, err := zw.Create(f.path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := fw.Write(f.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// contentXML assembles content.xml.
+func (w *writer) contentXML() []byte {
+	var x xmlWriter
+	x.start("office:document-content", append(namespaceAttrs("office", "style", "text", "table", "draw", "fo", "svg", "xlink"),
+		"office:version", odfVersion)...)
+	w.fontFaces(&x)
+	x.start("office:automatic-styles")
+	x.raw(w.content.x.b.String())
+	x.end("office:automatic-styles")
+	x.start("office:body")
+	x.start("office:text")
+	x.raw(w.body.b.String())
+	x.end("office:text")
+	x.end("office:body")
+	x.end("office:document-content")
+	return x.document()
+}
+
+// stylesXML assembles styles.xml: named styles, page layouts and master
+// pages with their headers and footers.
+func (w *writer) stylesXML() []byte {
+	var styles xmlWriter
+	w.namedStyles(&styles)
+
+	var x xmlWriter
+	x.start("office:document-styles", append(namespaceAttrs("office", "style", "text", "table", "draw", "fo", "svg", "xlink"),
+		"office:version", odfVersion)...)
+	w.fontFaces(&x)
+	x.start("office:styles")
+	x.raw(styles.b.String())
+	x.end("office:styles")
+	x.start("office:automatic-styles")
+	x.raw(w.masters.x.b.String())
+	x.end("office:automatic-styles")
+	x.start("office:master-styles")
+	x.raw(w.pages.b.String())
+	x.end("office:master-styles")
+	x.end("office:document-styles")
+	return x.document()
+}
+
+// metaXML writes the document properties.
+func metaXML(meta *domain.Metadata) []byte {
+	var x xmlWriter
+	x.start("office:document-meta", append(namespaceAttrs("office", "meta", "dc"), "office:version", odfVersion)...)
+	x.start("office:meta")
+	element := func(name, value string) {
+		if value != "" {
+			x.start(name)
+			x.text(value)
+			x.end(name)
+		}
+	}
+	element("meta:generator", "docxgo")
+	if meta != nil {
+		element("dc:title", meta.Title)
+		element("dc:subject", meta.Subject)
+		element("dc:description", meta.Description)
+		for _, keyword := range meta.Keywords {
+			element("meta:keyword", keyword)
+		}
+		element("meta:initial-creator", meta.Creator)
+		element("dc:creator", meta.Creator)
+		element("meta:creation-date", meta.Created)
+		element("dc:date", meta.Modified)
+	}
+	x.end("office:meta")
+	x.end("office:document-meta")
+	return x.document()
+}
@@ -0,0 +1,251 @@
+package odt_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/odt"
+)
+
+// sample builds a document with the content ODT conversion covers.
+func sample(t *testing.T) domain.Document {
+	t.Helper()
+	doc := docx.NewDocument()
+	_ = doc.SetMetadata(&domain.Metadata{Title: "Annual report", Creator: "Finance"})
+
+	heading, _ := doc.AddParagraph()
+	_ = heading.SetStyle(domain.StyleIDHeading1)
+	run, _ := heading.AddRun()
+	_ = run.SetText("Overview")
+
+	para, _ := doc.AddParagraph()
+	_ = para.SetAlignment(domain.AlignmentCenter)
+	run, _ = para.AddRun()
+	_ = run.SetText("Revenue grew ")
+	run, _ = para.AddRun()
+	_ = run.SetText("twelve percent")
+	_ = run.SetBold(true)
+	_ = run.SetItalic(true)
+	_ = run.SetColor(domain.Color{R: 0xC0, G: 0x10, B: 0x20})
+	run, _ = para.AddRun()
+	_ = run.SetText(" - see ")
+	run, _ = para.AddRun()
+	_ = run.SetText("the site")
+	_ = run.AddField(docx.NewHyperlinkField("https://example.com", "the site"))
+
+	bullets, _ := doc.AddList(domain.ListDefinition{Kind: domain.ListBullet})
+	numbers, _ := doc.AddList(domain.ListDefinition{Kind: domain.ListDecimal, Start: 3})
+	for i, text := range []string{"First", "Second"} {
+		item, _ := doc.AddParagraph()
+		_ = item.SetNumbering(domain.NumberingReference{ID: bullets, Level: i})
+		run, _ := item.AddRun()
+		_ = run.SetText(text)
+	}
+	item, _ := doc.AddParagraph()
+	_ = item.SetNumbering(domain.NumberingReference{ID: numbers})
+	run, _ = item.AddRun()
+	_ = run.SetText("Third")
+
+	table, _ := doc.AddTable(2, 3)
+	_ = table.SetStyle(domain.TableStyleGrid)
+	for r := range 2 {
+		row, _ := table.Row(r)
+		for c := range 3 {
+			cell, _ := row.Cell(c)
+			p, _ := cell.AddParagraph()
+			run, _ := p.AddRun()
+			_ = run.SetText(string(rune('A'+c)) + string(rune('1'+r)))
+		}
+	}
+	row, _ := table.Row(0)
+	cell, _ := row.Cell(0)
+	_ = cell.Merge(2, 1)
+	_ = cell.SetShading(domain.Color{R: 0xDD, G: 0xEE, B: 0xFF})
+
+	var img bytes.Buffer
+	if err := png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 40, 20))); err != nil {
+		t.Fatal(err)
+	}
+	picture, _ := doc.AddParagraph()
+	if _, err := picture.AddImageFromBytes(img.Bytes(), domain.ImageFormatPNG, domain.NewImageSize(40, 20),
+		domain.ImagePosition{Type: domain.ImagePositionInline}); err != nil {
+		t.Fatal(err)
+	}
+
+	section, _ := doc.DefaultSection()
+	header, _ := section.Header(domain.HeaderDefault)
+	hp, _ := header.AddParagraph()
+	run, _ = hp.AddRun()
+	_ = run.SetText("Annual report")
+	footer, _ := section.Footer(domain.FooterDefault)
+	fp, _ := footer.AddParagraph()
+	run, _ = fp.AddRun()
+	_ = run.AddField(docx.NewPageNumberField())
+
+	landscape, _ := doc.AddSectionWithBreak(domain.SectionBreakTypeNextPage)
+	_ = landscape.SetPageSize(domain.PageSize{Width: 15840, Height: 12240})
+	_ = landscape.SetOrientation(domain.OrientationLandscape)
+	para, _ = doc.AddParagraph()
+	run, _ = para.AddRun()
+	_ = run.SetText("Appendix")
+	return doc
+}
+
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	if err := odt.Write(sample(t), &buf); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := zr.File[0]
+	if first.Name != "mimetype" || first.Method != zip.Store {
+		t.Fatalf("first entry = %s (method %d), want stored mimetype", first.Name, first.Method)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		data, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(data)
+	}
+	if parts["mimetype"] != "application/vnd.oasis.opendocument.text" {
+		t.Errorf("mimetype = %q", parts["mimetype"])
+	}
+	for _, name := range []string{"content.xml", "styles.xml", "meta.xml", "META-INF/manifest.xml", "Pictures/image1.png"} {
+		if _, ok := parts[name]; !ok {
+			t.Errorf("missing %s", name)
+		}
+	}
+	for _, want := range []string{"Pictures/image1.png", "content.xml", "styles.xml"} {
+		if !strings.Contains(parts["META-INF/manifest.xml"], want) {
+			t.Errorf("manifest does not list %s", want)
+		}
+	}
+	content := parts["content.xml"]
+	for _, want := range []string{`<text:h`, `text:outline-level="1"`, `table:number-columns-spanned="2"`,
+		`<table:covered-table-cell`, `xlink:href="https://example.com"`, `<text:list`} {
+		if !strings.Contains(content, want) {
+			t.Errorf("content.xml lacks %s", want)
+		}
+	}
+	if !strings.Contains(parts["styles.xml"], "<text:page-number") {
+		t.Error("styles.xml lacks the footer page number")
+	}
+	if !strings.Contains(parts["meta.xml"], "Annual report") {
+		t.Error("meta.xml lacks the title")
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	if err := odt.Write(sample(t), &buf); err != nil {
+		t.Fatal(err)
+	}
+	doc, err := odt.OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	paras := doc.Paragraphs()
+	var texts []string
+	for _, p := range paras {
+		texts = append(texts, p.Text())
+	}
+	want := []string{"Overview", "Revenue grew twelve percent - see the site", "First", "Second", "Third", "", "Appendix"}
+	if strings.Join(texts, "|") != strings.Join(want, "|") {
+		t.Fatalf("paragraphs = %q, want %q", texts, want)
+	}
+	if style, ok := paras[0].(interface{ StyleName() string }); !ok || style.StyleName() != domain.StyleIDHeading1 {
+		t.Errorf("heading style not kept")
+	}
+	if paras[1].Alignment() != domain.AlignmentCenter {
+		t.Errorf("alignment = %v, want center", paras[1].Alignment())
+	}
+	runs := paras[1].Runs()
+	if len(runs) != 4 {
+		t.Fatalf("got %d runs, want 4", len(runs))
+	}
+	if r := runs[1]; !r.Bold() || !r.Italic() || r.Color() != (domain.Color{R: 0xC0, G: 0x10, B: 0x20}) {
+		t.Errorf("formatting not kept: bold %v italic %v color %v", r.Bold(), r.Italic(), r.Color())
+	}
+	if fields, ok := runs[3].(interface{ Fields() []domain.Field }); !ok || len(fields.Fields()) != 1 {
+		t.Error("hyperlink not kept")
+	}
+
+	bullet, ok1 := paras[2].Numbering()
+	nested, ok2 := paras[3].Numbering()
+	number, ok3 := paras[4].Numbering()
+	if !ok1 || !ok2 || !ok3 || nested.Level != 1 || nested.ID != bullet.ID || number.ID == bullet.ID {
+		t.Fatal("list numbering not kept")
+	}
+	if lvl, _ := doc.ListLevel(bullet); lvl.Kind != domain.ListBullet {
+		t.Errorf("first list kind = %v, want bullet", lvl.Kind)
+	}
+	if lvl, _ := doc.ListLevel(number); lvl.Kind != domain.ListDecimal || lvl.Start != 3 {
+		t.Errorf("second list = %+v, want decimal from 3", lvl)
+	}
+
+	tables := doc.Tables()
+	if len(tables) != 1 {
+		t.Fatalf("got %d tables, want 1", len(tables))
+	}
+	grid := tables[0].Grid()
+	if grid[0][0].GridSpan != 2 {
+		t.Errorf("merged cell spans %d columns, want 2", grid[0][0].GridSpan)
+	}
+	row, _ := tables[0].Row(0)
+	cell, _ := row.Cell(0)
+	if cell.Shading() != (domain.Color{R: 0xDD, G: 0xEE, B: 0xFF}) {
+		t.Errorf("shading = %v", cell.Shading())
+	}
+	if len(paras[5].Images()) != 1 {
+		t.Error("image not kept")
+	}
+
+	first, _ := doc.DefaultSection()
+	footer, _ := first.Footer(domain.FooterDefault)
+	if fp := footer.Paragraphs(); len(fp) != 1 || len(fp[0].Runs()) == 0 {
+		t.Error("footer not kept")
+	}
+	header, _ := first.Header(domain.HeaderDefault)
+	if hp := header.Paragraphs(); len(hp) != 1 || hp[0].Text() != "Annual report" {
+		t.Error("header not kept")
+	}
+	sections := doc.Sections()
+	if len(sections) != 2 {
+		t.Fatalf("got %d sections, want 2", len(sections))
+	}
+	if last := sections[1]; last.Orientation() != domain.OrientationLandscape || last.PageSize().Width != 15840 {
+		t.Errorf("second section = %v %+v, want landscape", sections[1].Orientation(), sections[1].PageSize())
+	}
+	if meta := doc.Metadata(); meta == nil || meta.Title != "Annual report" {
+		t.Errorf("metadata = %+v", meta)
+	}
+}
+
+func TestErrors(t *testing.T) {
+	if err := odt.Write(nil, io.Discard); err == nil {
+		t.Error("Write(nil) succeeded")
+	}
+	if _, err := odt.OpenDocumentFromBytes([]byte("not a zip")); err == nil {
+		t.Error("OpenDocumentFromBytes(garbage) succeeded")
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, _ := zw.Create("mimetype")
+	_, _ = w.Write([]byte("application/vnd.oasis.opendocument.spreadsheet"))
+	_ = zw.Close()
+	if _, err := odt.OpenDocumentFromBytes(buf.Bytes()); err == nil {
+		t.Error("a spreadsheet opened as a text document")
+	}
+}
@@ -0,0 +1,875 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package odt
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strconv"
+	"strings"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// odfStyle is a style:style element.
+type odfStyle struct {
+	node      *node
+	automatic bool
+}
+
+// styleScope holds the automatic styles of one part: content.xml for the
+// body, styles.xml for headers and footers.
+type styleScope struct {
+	styles map[string]*odfStyle // keyed by family and name
+	lists  map[string]*node
+}
+
+// reader converts an ODF package to a document.
+type reader struct {
+	doc    domain.Document
+	files  map[string][]byte
+	named  styleScope // office:styles
+	body   styleScope // automatic styles of content.xml
+	master styleScope // automatic styles of styles.xml
+	scope  *styleScope
+
+	fonts   map[string]string // font face name to family
+	layouts map[string]*node
+	masters map[string]*node
+
+	lists   map[string]int // last list of each list style
+	started bool           // the first block set up the default section
+	columns int            // columns of the current master page
+	layout  *node          // page layout of the current master page
+	restore bool           // a text:section ended; go back to the page columns
+	space   bool           // the last character written was a space
+}
+
+// container holds paragraphs: the body, a table cell, a header, a footer
+// or a footnote.
+type container interface {
+	AddParagraph() (domain.Paragraph, error)
+}
+
+// tableAdder is a container that can hold tables.
+type tableAdder interface {
+	AddTable(rows, cols int) (domain.Table, error)
+}
+
+// read converts an .odt or .ott package.
+func read(data []byte) (domain.Document, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "odt.read")
+	}
+	files := map[string][]byte{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "odt.read")
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeIO, "odt.read")
+		}
+		files[f.Name] = content
+	}
+	if mt := strings.TrimSpace(string(files["mimetype"])); mt != mediaTypeText && mt != mediaTypeTemplate {
+		return nil, errors.InvalidArgument("odt.read", "mimetype", mt, "not an OpenDocument text document or template")
+	}
+
+	r := &reader{
+		doc:     docx.NewDocument(),
+		files:   files,
+		named:   newStyleScope(),
+		body:    newStyleScope(),
+		master:  newStyleScope(),
+		fonts:   map[string]string{},
+		layouts: map[string]*node{},
+		masters: map[string]*node{},
+		lists:   map[string]int{},
+		columns: 1,
+	}
+	var stylesRoot, contentRoot *node
+	if data, ok := files["styles.xml"]; ok {
+		if stylesRoot, err = parseXML(data); err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeXML, "odt.read styles.xml")
+		}
+	}
+	if contentRoot, err = parseXML(files["content.xml"]); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeXML, "odt.read content.xml")
+	}
+
+	r.collect(stylesRoot, &r.master)
+	r.collect(contentRoot, &r.body)
+	if err := r.namedStyles(); err != nil {
+		return nil, err
+	}
+	if data, ok := files["meta.xml"]; ok {
+		if root, err := parseXML(data); err == nil {
+			r.metadata(root.child("office:meta"))
+		}
+	}
+
+	r.scope = &r.body
+	text := contentRoot.child("office:body").child("office:text")
+	if err := r.blocks(r.doc, text.nodes(), true); err != nil {
+		return nil, err
+	}
+	if !r.started {
+		if err := r.startSection(r.firstMaster(), false); err != nil {
+			return nil, err
+		}
+	}
+	return r.doc, nil
+}
+
+func newStyleScope() styleScope {
+	return styleScope{styles: map[string]*odfStyle{}, lists: map[string]*node{}}
+}
+
+// collect reads the font faces, styles, page layouts and master pages of
+// a part. Automatic styles go to scope.
+func (r *reader) collect(root *node, scope *styleScope) {
+	if root == nil {
+		return
+	}
+	for _, face := range root.child("office:font-face-decls").elements("style:font-face") {
+		family := strings.Trim(face.attr("svg:font-family"), `'"`)
+		if family == "" {
+			family = face.attr("style:name")
+		}
+		r.fonts[face.attr("style:name")] = family
+	}
+	add := func(parent *node, into *styleScope, automatic bool) {
+		for _, n := range parent.nodes() {
+			switch n.name {
+			case "style:style":
+				into.styles[n.attr("style:family")+":"+n.attr("style:name")] = &odfStyle{node: n, automatic: automatic}
+			case "text:list-style":
+				into.lists[n.attr("style:name")] = n
+			case "style:page-layout":
+				r.layouts[n.attr("style:name")] = n
+			}
+		}
+	}
+	add(root.child("office:styles"), &r.named, false)
+	add(root.child("office:automatic-styles"), scope, true)
+	for _, m := range root.child("office:master-styles").elements("style:master-page") {
+		r.masters[m.attr("style:name")] = m
+	}
+}
+
+// style returns a style of the current scope or a named style, or nil.
+func (r *reader) style(family, name string) *odfStyle {
+	if name == "" {
+		return nil
+	}
+	if s, ok := r.scope.styles[family+":"+name]; ok {
+		return s
+	}
+	return r.named.styles[family+":"+name]
+}
+
+// properties merges the attributes of a properties element along a
+// style and its parents, parents first.
+func (r *reader) properties(family, name, element string) map[string]string {
+	var chain []*node
+	for s, depth := r.style(family, name), 0; s != nil && depth < 32; depth++ {
+		chain = append(chain, s.node)
+		s = r.style(family, s.node.attr("style:parent-style-name"))
+	}
+	merged := map[string]string{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		for k, v := range chain[i].child(element).attributes() {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// directProperties returns the attributes of a properties element of an
+// automatic style, without those of its parent, or nil for named styles.
+func (r *reader) directProperties(family, name, element string) (map[string]string, *odfStyle) {
+	s := r.style(family, name)
+	if s == nil || !s.automatic {
+		return nil, s
+	}
+	return s.node.child(element).attributes(), s
+}
+
+// listStyle returns a list style of the current scope or a named one.
+func (r *reader) listStyle(name string) *node {
+	if n, ok := r.scope.lists[name]; ok {
+		return n
+	}
+	return r.named.lists[name]
+}
+
+// namedStyles adds the paragraph styles of office:styles to the style
+// manager, or updates the built-in styles they match.
+func (r *reader) namedStyles() error {
+	manager := r.doc.StyleManager()
+	for key, s := range r.named.styles {
+		if !strings.HasPrefix(key, "paragraph:") {
+			continue
+		}
+		n := s.node
+		id := wordStyleID(n.attr("style:name"))
+		var style domain.ParagraphStyle
+		if existing, err := manager.GetStyle(id); err == nil {
+			style, _ = existing.(domain.ParagraphStyle)
+		}
+		if style == nil {
+			display := n.attr("style:display-name")
+			if display == "" {
+				display = decodeStyleName(n.attr("style:name"))
+			}
+			style = docx.NewParagraphStyle(id, display)
+			if err := manager.AddStyle(style); err != nil {
+				return errors.Wrap(err, "odt.read")
+			}
+		}
+		if parent := n.attr("style:parent-style-name"); parent != "" {
+			_ = style.SetBasedOn(wordStyleID(parent))
+		}
+		if next := n.attr("style:next-style-name"); next != "" {
+			_ = style.SetNext(wordStyleID(next))
+		}
+
+		para := n.child("style:paragraph-properties").attributes()
+		if align, ok := alignment(para["fo:text-align"]); ok {
+			_ = style.SetAlignment(align)
+		}
+		if v, ok := parseLength(para["fo:margin-top"]); ok {
+			_ = style.SetSpacingBefore(max(v, 0))
+		}
+		if v, ok := parseLength(para["fo:margin-bottom"]); ok {
+			_ = style.SetSpacingAfter(max(v, 0))
+		}
+		if pct, ok := parsePercent(para["fo:line-height"]); ok {
+			_ = style.SetLineSpacing(int(pct * 240 / 100))
+		}
+		if indent, ok := indentation(para, style.Indentation()); ok {
+			_ = style.SetIndentation(indent)
+		}
+		if v := para["fo:keep-with-next"]; v != "" {
+			_ = style.SetKeepNext(v == "always")
+		}
+		if v := para["fo:keep-together"]; v != "" {
+			_ = style.SetKeepLines(v == "always")
+		}
+		if v := para["fo:break-before"]; v != "" {
+			_ = style.SetPageBreakBefore(v == "page")
+		}
+
+		text := n.child("style:text-properties").attributes()
+		if font := r.fontFamily(text); font != "" {
+			_ = style.SetFont(domain.Font{Name: font})
+		}
+		if size, ok := fontSize(text); ok {
+			_ = style.SetSize(size)
+		}
+		if bold, ok := boldness(text); ok {
+			_ = style.SetBold(bold)
+		}
+		if v := text["fo:font-style"]; v != "" {
+			_ = style.SetItalic(v == "italic" || v == "oblique")
+		}
+		if u, ok := underline(text); ok {
+			_ = style.SetUnderline(u)
+		}
+		if c, ok := parseColor(text["fo:color"]); ok {
+			_ = style.SetColor(c)
+		}
+	}
+	return nil
+}
+
+// metadata reads the document properties.
+func (r *reader) metadata(meta *node) {
+	if meta == nil {
+		return
+	}
+	m := &domain.Metadata{}
+	value := func(name string) string {
+		return strings.TrimSpace(textContent(meta.child(name)))
+	}
+	m.Title = value("dc:title")
+	m.Subject = value("dc:subject")
+	m.Description = value("dc:description")
+	m.Creator = value("meta:initial-creator")
+	if m.Creator == "" {
+		m.Creator = value("dc:creator")
+	}
+	m.Created = value("meta:creation-date")
+	m.Modified = value("dc:date")
+	for _, k := range meta.elements("meta:keyword") {
+		if keyword := strings.TrimSpace(textContent(k)); keyword != "" {
+			m.Keywords = append(m.Keywords, keyword)
+		}
+	}
+	_ = r.doc.SetMetadata(m)
+}
+
+// textContent returns the text of a node and its descendants.
+func textContent(n *node) string {
+	if n == nil {
+		return ""
+	}
+	if n.name == "" {
+		return n.text
+	}
+	var b strings.Builder
+	for _, c := range n.children {
+		b.WriteString(textContent(c))
+	}
+	return b.String()
+}
+
+// firstMaster returns the master page of documents whose first block
+// names none: Standard, or the first one defined.
+func (r *reader) firstMaster() string {
+	if _, ok := r.masters["Standard"]; ok {
+		return "Standard"
+	}
+	for name := range r.masters {
+		return name
+	}
+	return ""
+}
+
+// blocks reads paragraphs, lists, tables and sections into c. body is
+// true for the document body, where master pages start sections.
+func (r *reader) blocks(c container, nodes []*node, body bool) error {
+	for _, n := range nodes {
+		var err error
+		switch n.name {
+		case "text:p", "text:h":
+			if body {
+				err = r.masterOf("paragraph", n.attr("text:style-name"))
+			}
+			if err == nil {
+				_, err = r.paragraph(c, n, nil)
+			}
+		case "text:list":
+			if body {
+				err = r.masterOf("paragraph", firstParagraphStyle(n))
+			}
+			if err == nil {
+				err = r.list(c, n, 0, 0)
+			}
+		case "table:table":
+			if body {
+				err = r.masterOf("table", n.attr("table:style-name"))
+			}
+			if err == nil {
+				err = r.table(c, n)
+			}
+		case "text:section":
+			if body {
+				err = r.section(n)
+			} else {
+				err = r.blocks(c, n.children, false)
+			}
+		case "text:table-of-content", "text:illustration-index", "text:table-index", "text:object-index",
+			"text:user-index", "text:alphabetical-index", "text:bibliography", "text:index-body", "text:index-title":
+			inner := n.child("text:index-body")
+			if n.name == "text:index-body" || n.name == "text:index-title" {
+				inner = n
+			}
+			if inner != nil {
+				err = r.blocks(c, inner.children, body)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// firstParagraphStyle returns the style of the first paragraph of a list.
+func firstParagraphStyle(list *node) string {
+	for _, item := range list.children {
+		for _, child := range item.children {
+			switch child.name {
+			case "text:p", "text:h":
+				return child.attr("text:style-name")
+			case "text:list":
+				return firstParagraphStyle(child)
+			}
+		}
+	}
+	return ""
+}
+
+// masterOf starts a section when a block's style names a master page,
+// and sets up the default section from the first block.
+func (r *reader) masterOf(family, styleName string) error {
+	master := ""
+	if s := r.style(family, styleName); s != nil {
+		master = s.node.attr("style:master-page-name")
+	}
+	switch {
+	case !r.started:
+		if master == "" {
+			master = r.firstMaster()
+		}
+		return r.startSection(master, false)
+	case master != "":
+		r.restore = false
+		return r.startSection(master, true)
+	case r.restore:
+		r.restore = false
+		return r.continuous(r.columns)
+	}
+	return nil
+}
+
+// startSection sets up the default section, or adds a section starting
+// on a new page, with the page layout, headers and footers of a master
+// page.
+func (r *reader) startSection(name string, next bool) error {
+	var section domain.Section
+	var err error
+	if next {
+		section, err = r.doc.AddSectionWithBreak(domain.SectionBreakTypeNextPage)
+	} else {
+		section, err = r.doc.DefaultSection()
+	}
+	if err != nil {
+		return errors.Wrap(err, "odt.read")
+	}
+	r.started = true
+	master := r.masters[name]
+	if master == nil {
+		return nil
+	}
+	r.layout = r.layouts[master.attr("style:page-layout-name")]
+	if err := r.pageLayout(section); err != nil {
+		return err
+	}
+	r.columns = max(section.Columns(), 1)
+
+	r.scope = &r.master
+	defer func() { r.scope = &r.body }()
+	headers := []struct {
+		elements []string
+		kind     domain.HeaderType
+	}{
+		{[]string{"style:header"}, domain.HeaderDefault},
+		{[]string{"style:header-left"}, domain.HeaderEven},
+		{[]string{"style:header-first", "loext:header-first"}, domain.HeaderFirst},
+	}
+	for _, h := range headers {
+		for _, element := range h.elements {
+			if n := master.child(element); n != nil && n.attr("style:display") != "false" {
+				header, err := section.Header(h.kind)
+				if err != nil {
+					return errors.Wrap(err, "odt.read")
+				}
+				if err := r.blocks(header, n.children, false); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	for _, h := range headers {
+		for _, element := range h.elements {
+			element = strings.Replace(element, "header", "footer", 1)
+			if n := master.child(element); n != nil && n.attr("style:display") != "false" {
+				footer, err := section.Footer(domain.FooterType(h.kind))
+				if err != nil {
+					return errors.Wrap(err, "odt.read")
+				}
+				if err := r.blocks(footer, n.children, false); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// pageLayout applies the current page layout to a section. The header
+// distance is the top margin of pages with a header; the body starts
+// below the header and its spacing.
+func (r *reader) pageLayout(section domain.Section) error {
+	if r.layout == nil {
+		return nil
+	}
+	page := r.layout.child("style:page-layout-properties")
+	size := section.PageSize()
+	if w, ok := parseLength(page.attr("fo:page-width")); ok && w > 0 {
+		size.Width = w
+	}
+	if h, ok := parseLength(page.attr("fo:page-height")); ok && h > 0 {
+		size.Height = h
+	}
+	if err := section.SetPageSize(size); err != nil {
+		return errors.Wrap(err, "odt.read")
+	}
+	if page.attr("style:print-orientation") == "landscape" || size.Width > size.Height {
+		_ = section.SetOrientation(domain.OrientationLandscape)
+	}
+
+	m := section.Margins()
+	margin := func(name string, into *int) {
+		if v, ok := parseLength(page.attr(name)); ok {
+			*into = max(v, 0)
+		}
+	}
+	margin("fo:margin-top", &m.Top)
+	margin("fo:margin-bottom", &m.Bottom)
+	margin("fo:margin-left", &m.Left)
+	margin("fo:margin-right", &m.Right)
+	m.Header, m.Footer = min(m.Top, domain.DefaultMargins.Header), min(m.Bottom, domain.DefaultMargins.Footer)
+	if hf := r.layout.child("style:header-style").child("style:header-footer-properties"); hf != nil {
+		height, _ := parseLength(hf.attr("fo:min-height"))
+		spacing, _ := parseLength(hf.attr("fo:margin-bottom"))
+		m.Header, m.Top = m.Top, m.Top+height+spacing
+	}
+	if hf := r.layout.child("style:footer-style").child("style:header-footer-properties"); hf != nil {
+		height, _ := parseLength(hf.attr("fo:min-height"))
+		spacing, _ := parseLength(hf.attr("fo:margin-top"))
+		m.Footer, m.Bottom = m.Bottom, m.Bottom+height+spacing
+	}
+	if err := section.SetMargins(m); err != nil {
+		return errors.Wrap(err, "odt.read")
+	}
+	if n := columnCount(page); n > 1 {
+		return errors.Wrap(section.SetColumns(n), "odt.read")
+	}
+	return nil
+}
+
+// columnCount returns the style:columns count of properties, or 1.
+func columnCount(props *node) int {
+	if n, err := strconv.Atoi(props.child("style:columns").attr("fo:column-count")); err == nil && n > 1 {
+		return n
+	}
+	return 1
+}
+
+// section reads a text:section. Sections with other columns than the
+// page become continuous sections.
+func (r *reader) section(n *node) error {
+	if !r.started {
+		if err := r.startSection(r.firstMaster(), false); err != nil {
+			return err
+		}
+	}
+	columns := r.columns
+	if s := r.style("section", n.attr("text:style-name")); s != nil {
+		columns = columnCount(s.node.child("style:section-properties"))
+	}
+	changed := columns != r.columns
+	if changed {
+		r.restore = false
+		if err := r.continuous(columns); err != nil {
+			return err
+		}
+	}
+	if err := r.blocks(r.doc, n.children, true); err != nil {
+		return err
+	}
+	if changed {
+		r.restore = true
+	}
+	return nil
+}
+
+// continuous adds a continuous section with the current page layout.
+func (r *reader) continuous(columns int) error {
+	section, err := r.doc.AddSectionWithBreak(domain.SectionBreakTypeContinuous)
+	if err != nil {
+		return errors.Wrap(err, "odt.read")
+	}
+	if err := r.pageLayout(section); err != nil {
+		return err
+	}
+	return errors.Wrap(section.SetColumns(max(columns, 1)), "odt.read")
+}
+
+// list reads a list and its nested lists. The first level picks the
+// domain list: a new one, or the previous list of the same style when
+// numbering continues.
+func (r *reader) list(c container, n *node, level, id int) error {
+	if level == 0 {
+		var err error
+		if id, err = r.listID(n); err != nil {
+			return err
+		}
+	}
+	for _, item := range n.children {
+		if item.name != "text:list-item" && item.name != "text:list-header" {
+			continue
+		}
+		for _, child := range item.children {
+			var err error
+			switch child.name {
+			case "text:p", "text:h":
+				ref := &domain.NumberingReference{ID: id, Level: min(level, domain.NumberingLevelMax)}
+				if item.name == "text:list-header" {
+					ref = nil
+				}
+				_, err = r.paragraph(c, child, ref)
+			case "text:list":
+				err = r.list(c, child, level+1, id)
+			case "table:table":
+				err = r.table(c, child)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// listID returns the domain list of a top-level text:list.
+func (r *reader) listID(n *node) (int, error) {
+	name := n.attr("text:style-name")
+	if id, ok := r.lists[name]; ok && (n.attr("text:continue-numbering") == "true" || n.attr("text:continue-list") != "") {
+		return id, nil
+	}
+	def := domain.ListDefinition{Kind: domain.ListBullet}
+	for _, lvl := range r.listStyle(name).nodes() {
+		if lvl.attr("text:level") != "1" {
+			continue
+		}
+		if lvl.name == "text:list-level-style-number" && lvl.attr("style:num-format") != "" {
+			def.Kind = domain.ListDecimal
+			if start, err := strconv.Atoi(lvl.attr("text:start-value")); err == nil && start > 0 {
+				def.Start = start
+			}
+		}
+	}
+	if start, err := strconv.Atoi(n.attr("text:start-value")); err == nil && start > 0 && def.Kind == domain.ListDecimal {
+		def.Start = start
+	}
+	id, err := r.doc.AddList(def)
+	if err != nil {
+		return 0, errors.Wrap(err, "odt.read")
+	}
+	r.lists[name] = id
+	return id, nil
+}
+
+// tableCellNode is a cell of a table row, expanded from repeats.
+type tableCellNode struct {
+	node    *node
+	covered bool
+}
+
+// table reads a table with its column widths, merged cells, shading and
+// borders. Containers that cannot hold tables get the cell paragraphs.
+func (r *reader) table(c container, n *node) error {
+	var rows []*node
+	var widths []int
+	var walk func(parent *node)
+	walk = func(parent *node) {
+		for _, child := range parent.children {
+			switch child.name {
+			case "table:table-row":
+				rows = append(rows, child)
+			case "table:table-column":
+				width := 0
+				if s := r.style("table-column", child.attr("table:style-name")); s != nil {
+					width, _ = parseLength(s.node.child("style:table-column-properties").attr("style:column-width"))
+				}
+				for range repeat(child.attr("table:number-columns-repeated")) {
+					widths = append(widths, width)
+				}
+			case "table:table-header-rows", "table:table-rows", "table:table-row-group",
+				"table:table-columns", "table:table-header-columns", "table:table-column-group":
+				walk(child)
+			}
+		}
+	}
+	walk(n)
+
+	grid := make([][]tableCellNode, 0, len(rows))
+	columns := len(widths)
+	for _, row := range rows {
+		var cells []tableCellNode
+		for _, cell := range row.children {
+			if cell.name != "table:table-cell" && cell.name != "table:covered-table-cell" {
+				continue
+			}
+			count := repeat(cell.attr("table:number-columns-repeated"))
+			if len(widths) > 0 {
+				count = min(count, max(len(widths)-len(cells), 1))
+			}
+			for range count {
+				cells = append(cells, tableCellNode{node: cell, covered: cell.name == "table:covered-table-cell"})
+			}
+		}
+		grid = append(grid, cells)
+		if len(widths) == 0 {
+			columns = max(columns, len(cells))
+		}
+	}
+	if len(grid) == 0 || columns == 0 {
+		return nil
+	}
+
+	adder, ok := c.(tableAdder)
+	if !ok {
+		for _, cells := range grid {
+			for _, cell := range cells {
+				if err := r.blocks(c, cell.node.children, false); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+	tbl, err := adder.AddTable(len(grid), columns)
+	if err != nil {
+		return errors.Wrap(err, "odt.read")
+	}
+	if s := r.style("table", n.attr("table:style-name")); s != nil {
+		props := s.node.child("style:table-properties")
+		if w, ok := parseLength(props.attr("style:width")); ok && w > 0 && props.attr("table:align") != "margins" {
+			_ = tbl.SetWidth(domain.TableWidth{Type: domain.WidthDXA, Value: w})
+		}
+		switch props.attr("table:align") {
+		case "center":
+			_ = tbl.SetAlignment(domain.AlignmentCenter)
+		case "right":
+			_ = tbl.SetAlignment(domain.AlignmentRight)
+		}
+	}
+
+	type origin struct {
+		cell    domain.TableCell
+		node    *node
+		borders domain.TableBorders
+	}
+	var origins []origin
+	grid1 := true
+	for ri, cells := range grid {
+		row, err := tbl.Row(ri)
+		if err != nil {
+			return errors.Wrap(err, "odt.read")
+		}
+		if s := r.style("table-row", rows[ri].attr("table:style-name")); s != nil {
+			props := s.node.child("style:table-row-properties")
+			h, ok := parseLength(props.attr("style:min-row-height"))
+			if !ok {
+				h, ok = parseLength(props.attr("style:row-height"))
+			}
+			if ok && h > 0 {
+				_ = row.SetHeight(h)
+			}
+		}
+		for ci, cn := range cells {
+			if ci >= columns {
+				break
+			}
+			cell, err := row.Cell(ci)
+			if err != nil {
+				return errors.Wrap(err, "odt.read")
+			}
+			if ci < len(widths) && widths[ci] > 0 {
+				_ = cell.SetWidth(widths[ci])
+			}
+			if cn.covered {
+				continue
+			}
+			cols := min(repeat(cn.node.attr("table:number-columns-spanned")), columns-ci)
+			spanRows := min(repeat(cn.node.attr("table:number-rows-spanned")), len(grid)-ri)
+			if cols > 1 || spanRows > 1 {
+				if err := cell.Merge(cols, spanRows); err != nil {
+					return errors.Wrap(err, "odt.read")
+				}
+			}
+			props := map[string]string{}
+			if s := r.style("table-cell", cn.node.attr("table:style-name")); s != nil {
+				props = s.node.child("style:table-cell-properties").attributes()
+			}
+			if c, ok := parseColor(props["fo:background-color"]); ok {
+				_ = cell.SetShading(c)
+			}
+			switch props["style:vertical-align"] {
+			case "middle":
+				_ = cell.SetVerticalAlignment(domain.VerticalAlignCenter)
+			case "bottom":
+				_ = cell.SetVerticalAlignment(domain.VerticalAlignBottom)
+			}
+			b := domain.TableBorders{
+				Top:    parseBorder(side(props, "top")),
+				Bottom: parseBorder(side(props, "bottom")),
+				Left:   parseBorder(side(props, "left")),
+				Right:  parseBorder(side(props, "right")),
+			}
+			for _, s := range []domain.BorderStyle{b.Top, b.Bottom, b.Left, b.Right} {
+				if s.Style != domain.BorderSingle || s.Width > 8 || s.Color != domain.ColorBlack {
+					grid1 = false
+				}
+			}
+			origins = append(origins, origin{cell: cell, node: cn.node, borders: b})
+		}
+	}
+
+	// Tables whose cells all have thin black borders get the grid style;
+	// others keep the borders of each cell.
+	if grid1 {
+		_ = tbl.SetStyle(domain.TableStyleGrid)
+	}
+	for _, o := range origins {
+		if !grid1 {
+			_ = o.cell.SetBorders(o.borders)
+		}
+		if err := r.blocks(o.cell, o.node.children, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repeat reads a repeat or span count, 1 when absent.
+func repeat(s string) int {
+	if n, err := strconv.Atoi(s); err == nil && n > 1 {
+		return n
+	}
+	return 1
+}
+
+// side returns the border of one side, or the border of all sides.
+func side(props map[string]string, name string) string {
+	if v, ok := props["fo:border-"+name]; ok {
+		return v
+	}
+	return props["fo:border"]
+}
@@ -0,0 +1,596 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package odt
+
+import (
+	"path"
+	"strconv"
+	"strings"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// line collects the runs of one paragraph. Text with the same formatting
+// is buffered and added as one run.
+type line struct {
+	r       *reader
+	p       domain.Paragraph
+	pending strings.Builder
+	props   map[string]string
+	link    string
+}
+
+// paragraph reads a text:p or text:h into c. ref puts the paragraph in
+// a list.
+func (r *reader) paragraph(c container, n *node, ref *domain.NumberingReference) (domain.Paragraph, error) {
+	p, err := c.AddParagraph()
+	if err != nil {
+		return nil, errors.Wrap(err, "odt.read")
+	}
+	name := n.attr("text:style-name")
+	direct, s := r.directProperties("paragraph", name, "style:paragraph-properties")
+
+	id := ""
+	if s != nil {
+		named := s.node.attr("style:name")
+		if s.automatic {
+			named = s.node.attr("style:parent-style-name")
+		}
+		if named != "" {
+			id = wordStyleID(named)
+		}
+	}
+	if id == "" && n.name == "text:h" {
+		if level, err := strconv.Atoi(n.attr("text:outline-level")); err == nil && level >= 1 && level <= 9 {
+			id = "Heading" + strconv.Itoa(level)
+		}
+	}
+	if id != "" && id != domain.StyleIDNormal {
+		if err := p.SetStyle(id); err != nil {
+			return nil, errors.Wrap(err, "odt.read")
+		}
+	}
+	if err := r.paragraphProperties(p, direct); err != nil {
+		return nil, err
+	}
+	if ref != nil {
+		if err := p.SetNumbering(*ref); err != nil {
+			return nil, errors.Wrap(err, "odt.read")
+		}
+	}
+
+	var textProps map[string]string
+	if s != nil && s.automatic {
+		textProps = s.node.child("style:text-properties").attributes()
+	}
+	l := &line{r: r, p: p, props: textProps}
+	r.space = true
+	if err := l.inline(n.children, textProps, ""); err != nil {
+		return nil, err
+	}
+	return p, l.flush()
+}
+
+// paragraphProperties applies the direct properties of an automatic
+// paragraph style.
+func (r *reader) paragraphProperties(p domain.Paragraph, props map[string]string) error {
+	if len(props) == 0 {
+		return nil
+	}
+	if align, ok := alignment(props["fo:text-align"]); ok {
+		_ = p.SetAlignment(align)
+	}
+	if indent, ok := indentation(props, p.Indent()); ok {
+		_ = p.SetIndent(indent)
+	}
+	if v, ok := parseLength(props["fo:margin-top"]); ok {
+		_ = p.SetSpacingBefore(max(v, 0))
+	}
+	if v, ok := parseLength(props["fo:margin-bottom"]); ok {
+		_ = p.SetSpacingAfter(max(v, 0))
+	}
+	if spacing, ok := lineSpacing(props); ok {
+		_ = p.SetLineSpacing(spacing)
+	}
+	b := domain.ParagraphBorders{
+		Top:    parseBorder(side(props, "top")),
+		Bottom: parseBorder(side(props, "bottom")),
+		Left:   parseBorder(side(props, "left")),
+		Right:  parseBorder(side(props, "right")),
+	}
+	if b != (domain.ParagraphBorders{}) {
+		_ = p.SetBorders(b)
+	}
+
+	breakType := domain.BreakType(-1)
+	switch props["fo:break-before"] {
+	case "page":
+		breakType = domain.BreakTypePage
+	case "column":
+		breakType = domain.BreakTypeColumn
+	}
+	if breakType >= 0 {
+		run, err := p.AddRun()
+		if err != nil {
+			return errors.Wrap(err, "odt.read")
+		}
+		return errors.Wrap(run.AddBreak(breakType), "odt.read")
+	}
+	return nil
+}
+
+// inline reads the content of a paragraph, a span or a link. props are
+// the text properties in effect and link the target of an enclosing
+// text:a.
+func (l *line) inline(nodes []*node, props map[string]string, link string) error {
+	for _, n := range nodes {
+		if n.name == "" {
+			l.text(collapse(n.text, &l.r.space), props, link)
+			continue
+		}
+		var err error
+		switch n.name {
+		case "text:span":
+			err = l.inline(n.children, mergeProps(props, l.r.properties("text", n.attr("text:style-name"), "style:text-properties")), link)
+		case "text:a":
+			merged := mergeProps(props, l.r.properties("text", n.attr("text:style-name"), "style:text-properties"))
+			err = l.inline(n.children, merged, n.attr("xlink:href"))
+		case "text:s":
+			l.text(strings.Repeat(" ", repeat(n.attr("text:c"))), props, link)
+			l.r.space = true
+		case "text:tab":
+			l.text("\t", props, link)
+			l.r.space = false
+		case "text:line-break":
+			err = l.addBreak(props)
+			l.r.space = true
+		case "text:page-number":
+			err = l.field(docx.NewPageNumberField(), props)
+		case "text:page-count":
+			err = l.field(docx.NewPageCountField(), props)
+		case "draw:frame":
+			err = l.frame(n)
+		case "text:note":
+			err = l.note(n)
+		case "text:bookmark", "text:bookmark-start", "text:bookmark-end", "text:soft-page-break",
+			"office:annotation", "office:annotation-end", "text:note-citation", "text:sequence-decls":
+		default:
+			err = l.inline(n.children, props, link)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collapse replaces runs of white space with one space, dropping a
+// space that follows another. space tracks the last character written.
+func collapse(s string, space *bool) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r == ' ' || r == '\t' || r == '\n' || r == '\r' {
+			if !*space {
+				b.WriteByte(' ')
+				*space = true
+			}
+			continue
+		}
+		b.WriteRune(r)
+		*space = false
+	}
+	return b.String()
+}
+
+// mergeProps returns the properties of outer overridden by inner.
+func mergeProps(outer, inner map[string]string) map[string]string {
+	if len(inner) == 0 {
+		return outer
+	}
+	merged := make(map[string]string, len(outer)+len(inner))
+	for k, v := range outer {
+		merged[k] = v
+	}
+	for k, v := range inner {
+		merged[k] = v
+	}
+	return merged
+}
+
+// text buffers text, adding the buffered text first when the formatting
+// or link changes.
+func (l *line) text(s string, props map[string]string, link string) {
+	if s == "" {
+		return
+	}
+	if l.pending.Len() > 0 && (link != l.link || !sameProps(props, l.props)) {
+		_ = l.flush()
+	}
+	l.props, l.link = props, link
+	l.pending.WriteString(s)
+}
+
+// sameProps reports whether two property maps are equal.
+func sameProps(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if w, ok := b[k]; !ok || w != v {
+			return false
+		}
+	}
+	return true
+}
+
+// flush adds the buffered text as a run.
+func (l *line) flush() error {
+	if l.pending.Len() == 0 {
+		return nil
+	}
+	text := l.pending.String()
+	l.pending.Reset()
+	run, err := l.run(l.props)
+	if err != nil {
+		return err
+	}
+	if err := run.SetText(text); err != nil {
+		return errors.Wrap(err, "odt.read")
+	}
+	if l.link != "" {
+		if err := run.AddField(docx.NewHyperlinkField(l.link, text)); err != nil {
+			return errors.Wrap(err, "odt.read")
+		}
+		if _, ok := l.props["fo:color"]; !ok {
+			_ = run.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorHyperlink})
+		}
+		if _, ok := l.props["style:text-underline-style"]; !ok {
+			_ = run.SetUnderline(domain.UnderlineSingle)
+		}
+	}
+	return nil
+}
+
+// run adds a run with text properties.
+func (l *line) run(props map[string]string) (domain.Run, error) {
+	run, err := l.p.AddRun()
+	if err != nil {
+		return nil, errors.Wrap(err, "odt.read")
+	}
+	if font := l.r.fontFamily(props); font != "" {
+		_ = run.SetFont(domain.Font{Name: font})
+	}
+	if size, ok := fontSize(props); ok {
+		_ = run.SetSize(size)
+	}
+	if bold, ok := boldness(props); ok {
+		_ = run.SetBold(bold)
+	}
+	if v := props["fo:font-style"]; v == "italic" || v == "oblique" {
+		_ = run.SetItalic(true)
+	}
+	if u, ok := underline(props); ok {
+		_ = run.SetUnderline(u)
+	}
+	if v := props["style:text-line-through-style"]; v != "" && v != "none" {
+		_ = run.SetStrike(true)
+	}
+	if c, ok := parseColor(props["fo:color"]); ok {
+		_ = run.SetColor(c)
+	}
+	if h, ok := highlight(props["fo:background-color"]); ok {
+		_ = run.SetHighlight(h)
+	}
+	switch position := props["style:text-position"]; {
+	case strings.HasPrefix(position, "super"):
+		_ = run.SetScript(domain.ScriptSuperscript)
+	case strings.HasPrefix(position, "sub"), strings.HasPrefix(position, "-"):
+		_ = run.SetScript(domain.ScriptSubscript)
+	case position != "" && !strings.HasPrefix(position, "0"):
+		_ = run.SetScript(domain.ScriptSuperscript)
+	}
+	return run, nil
+}
+
+// addBreak ends the current run with a line break.
+func (l *line) addBreak(props map[string]string) error {
+	if err := l.flush(); err != nil {
+		return err
+	}
+	run, err := l.run(props)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(run.AddBreak(domain.BreakTypeLine), "odt.read")
+}
+
+// field adds a page number or page count field.
+func (l *line) field(field domain.Field, props map[string]string) error {
+	if err := l.flush(); err != nil {
+		return err
+	}
+	run, err := l.run(props)
+	if err != nil {
+		return err
+	}
+	l.r.space = false
+	return errors.Wrap(run.AddField(field), "odt.read")
+}
+
+// frame adds the image of a draw:frame. Frames of other content, such as
+// text boxes and objects, are skipped.
+func (l *line) frame(n *node) error {
+	img := n.child("draw:image")
+	if img == nil {
+		return nil
+	}
+	href := strings.TrimPrefix(img.attr("xlink:href"), "./")
+	data, ok := l.r.files[href]
+	if !ok || len(data) == 0 {
+		return nil
+	}
+	if err := l.flush(); err != nil {
+		return err
+	}
+
+	width, _ := parseLength(n.attr("svg:width"))
+	height, _ := parseLength(n.attr("svg:height"))
+	size := domain.ImageSize{
+		WidthPx: width * 96 / 1440, HeightPx: height * 96 / 1440,
+		WidthEMU: width * 635, HeightEMU: height * 635,
+	}
+	pos := domain.ImagePosition{Type: domain.ImagePositionInline}
+	if anchor := n.attr("text:anchor-type"); anchor != "" && anchor != "as-char" {
+		x, _ := parseLength(n.attr("svg:x"))
+		y, _ := parseLength(n.attr("svg:y"))
+		pos = domain.ImagePosition{
+			Type: domain.ImagePositionFloating, OffsetX: x * 635, OffsetY: y * 635,
+			WrapText: domain.WrapSquare,
+		}
+	}
+	format := domain.ImageFormat(strings.ToLower(strings.TrimPrefix(path.Ext(href), ".")))
+	image, err := l.p.AddImageFromBytes(data, format, size, pos)
+	if err != nil {
+		return errors.Wrap(err, "odt.read")
+	}
+	if desc := strings.TrimSpace(textContent(n.child("svg:desc"))); desc != "" {
+		_ = image.SetDescription(desc)
+	}
+	l.r.space = false
+	return nil
+}
+
+// note adds a footnote with the paragraphs of its body. Endnotes become
+// footnotes.
+func (l *line) note(n *node) error {
+	if err := l.flush(); err != nil {
+		return err
+	}
+	footnote, err := l.p.AddFootnote()
+	if err != nil {
+		return errors.Wrap(err, "odt.read")
+	}
+	defer func() { l.r.space = false }()
+	if body := n.child("text:note-body"); body != nil {
+		return l.r.blocks(footnote, body.children, false)
+	}
+	return nil
+}
+
+// alignment maps fo:text-align to an alignment.
+func alignment(v string) (domain.Alignment, bool) {
+	switch v {
+	case "start", "left":
+		return domain.AlignmentLeft, true
+	case "center":
+		return domain.AlignmentCenter, true
+	case "end", "right":
+		return domain.AlignmentRight, true
+	case "justify":
+		return domain.AlignmentJustify, true
+	}
+	return domain.AlignmentLeft, false
+}
+
+// indentation reads margins and the first line indent over indent.
+func indentation(props map[string]string, indent domain.Indentation) (domain.Indentation, bool) {
+	set := false
+	if v, ok := parseLength(props["fo:margin-left"]); ok {
+		indent.Left, set = v, true
+	}
+	if v, ok := parseLength(props["fo:margin-right"]); ok {
+		indent.Right, set = v, true
+	}
+	if v, ok := parseLength(props["fo:text-indent"]); ok {
+		indent.FirstLine, indent.Hanging, set = max(v, 0), max(-v, 0), true
+	}
+	return indent, set
+}
+
+// lineSpacing reads the line height of a paragraph.
+func lineSpacing(props map[string]string) (domain.LineSpacing, bool) {
+	if pct, ok := parsePercent(props["fo:line-height"]); ok {
+		return domain.LineSpacing{Rule: domain.LineSpacingAuto, Value: int(pct * 240 / 100)}, true
+	}
+	if v, ok := parseLength(props["fo:line-height"]); ok && v > 0 {
+		return domain.LineSpacing{Rule: domain.LineSpacingExact, Value: v}, true
+	}
+	if v, ok := parseLength(props["style:line-height-at-least"]); ok && v > 0 {
+		return domain.LineSpacing{Rule: domain.LineSpacingAtLeast, Value: v}, true
+	}
+	return domain.LineSpacing{}, false
+}
+
+// fontFamily returns the font of text properties.
+func (r *reader) fontFamily(props map[string]string) string {
+	if name := props["style:font-name"]; name != "" {
+		if family, ok := r.fonts[name]; ok {
+			return family
+		}
+		return name
+	}
+	return strings.Trim(props["fo:font-family"], `'"`)
+}
+
+// fontSize returns fo:font-size in half-points. Relative sizes are
+// ignored.
+func fontSize(props map[string]string) (int, bool) {
+	v := props["fo:font-size"]
+	if !strings.HasSuffix(v, "pt") {
+		return 0, false
+	}
+	pt, err := strconv.ParseFloat(strings.TrimSuffix(v, "pt"), 64)
+	if err != nil || pt <= 0 {
+		return 0, false
+	}
+	return int(pt*2 + 0.5), true
+}
+
+// boldness reads fo:font-weight.
+func boldness(props map[string]string) (bool, bool) {
+	v := props["fo:font-weight"]
+	switch v {
+	case "":
+		return false, false
+	case "bold":
+		return true, true
+	case "normal":
+		return false, true
+	}
+	weight, err := strconv.Atoi(v)
+	return err == nil && weight >= 600, err == nil
+}
+
+// underline reads the underline style, width and type.
+func underline(props map[string]string) (domain.UnderlineStyle, bool) {
+	style := props["style:text-underline-style"]
+	switch style {
+	case "":
+		return domain.UnderlineNone, false
+	case "none":
+		return domain.UnderlineNone, true
+	case "dotted":
+		return domain.UnderlineDotted, true
+	case "dash", "long-dash", "dot-dash", "dot-dot-dash":
+		return domain.UnderlineDashed, true
+	case "wave":
+		return domain.UnderlineWave, true
+	}
+	if props["style:text-underline-type"] == "double" {
+		return domain.UnderlineDouble, true
+	}
+	if props["style:text-underline-width"] == "bold" || props["style:text-underline-width"] == "thick" {
+		return domain.UnderlineThick, true
+	}
+	return domain.UnderlineSingle, true
+}
+
+// parseColor reads a #rrggbb color.
+func parseColor(v string) (domain.Color, bool) {
+	if !strings.HasPrefix(v, "#") {
+		return domain.Color{}, false
+	}
+	c, err := color.FromHex(v)
+	return c, err == nil
+}
+
+// highlight maps a background color to the Word highlight of that color.
+func highlight(v string) (domain.HighlightColor, bool) {
+	v = strings.ToLower(v)
+	for h, hex := range highlightColors {
+		if hex == v {
+			return h, true
+		}
+	}
+	return domain.HighlightNone, false
+}
+
+// parseBorder reads a border such as "0.5pt solid #000000".
+func parseBorder(v string) domain.BorderStyle {
+	fields := strings.Fields(v)
+	if len(fields) < 2 || fields[0] == "none" {
+		return domain.BorderStyle{}
+	}
+	b := domain.BorderStyle{Style: domain.BorderSingle, Width: 4, Color: domain.ColorBlack}
+	for _, f := range fields {
+		switch f {
+		case "none", "hidden":
+			return domain.BorderStyle{}
+		case "solid":
+			b.Style = domain.BorderSingle
+		case "dotted":
+			b.Style = domain.BorderDotted
+		case "dashed":
+			b.Style = domain.BorderDashed
+		case "double":
+			b.Style = domain.BorderDouble
+		default:
+			if c, ok := parseColor(f); ok {
+				b.Color = c
+			} else if twips, ok := parseLength(f); ok {
+				b.Width = max(twips*2/5, 2)
+			}
+		}
+	}
+	return b
+}
+
+// wordStyleID returns the Word style ID of an ODF style name: the
+// inverse of odfStyleName.
+func wordStyleID(name string) string {
+	for id, odf := range wordStyleNames {
+		if odf == name {
+			return id
+		}
+	}
+	if rest, ok := strings.CutPrefix(name, "Heading_20_"); ok {
+		if level, err := strconv.Atoi(rest); err == nil && level >= 1 && level <= 9 {
+			return "Heading" + rest
+		}
+	}
+	return strings.ReplaceAll(decodeStyleName(name), " ", "")
+}
+
+// decodeStyleName replaces the _xx_ escapes of an ODF style name with
+// the characters they stand for.
+func decodeStyleName(name string) string {
+	var b strings.Builder
+	for i := 0; i < len(name); i++ {
+		if name[i] == '_' {
+			if end := strings.IndexByte(name[i+1:], '_'); end > 0 {
+				if r, err := strconv.ParseInt(name[i+1:i+1+end], 16, 32); err == nil {
+					b.WriteRune(rune(r))
+					i += end + 1
+					continue
+				}
+			}
+		}
+		b.WriteByte(name[i])
+	}
+	return b.String()
+}
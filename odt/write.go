@@ -0,0 +1,1207 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package odt
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// gridBorder is the border of cells in tables with a style, as the PDF
+// renderer draws them.
+var gridBorder = domain.BorderStyle{Style: domain.BorderSingle, Width: 4, Color: domain.ColorBlack}
+
+// entry is a file of the package, such as an image under Pictures/.
+type entry struct {
+	path      string
+	mediaType string
+	data      []byte
+}
+
+// writer converts a document to the parts of an ODF package.
+type writer struct {
+	doc   domain.Document
+	theme domain.DocumentTheme
+
+	body    xmlWriter  // office:text
+	content autoStyles // automatic styles of content.xml
+	masters autoStyles // automatic styles of styles.xml
+	pages   xmlWriter  // office:master-styles content
+
+	fonts    map[string]bool
+	pictures []entry
+	images   map[domain.Image]string
+	listSeen map[int]bool // lists whose numbering continues
+	tables   int
+	notes    int
+}
+
+func newWriter(doc domain.Document) *writer {
+	return &writer{
+		doc:      doc,
+		theme:    doc.Theme(),
+		content:  newAutoStyles(""),
+		masters:  newAutoStyles("M"),
+		fonts:    map[string]bool{},
+		images:   map[domain.Image]string{},
+		listSeen: map[int]bool{},
+	}
+}
+
+// autoStyles collects automatic styles, one per distinct set of
+// properties. Styles of styles.xml get a prefix so that their names
+// differ from those of content.xml.
+type autoStyles struct {
+	prefix string
+	x      xmlWriter
+	names  map[string]string
+	counts map[string]int
+	lists  map[int]string // list style of each list ID
+}
+
+func newAutoStyles(prefix string) autoStyles {
+	return autoStyles{prefix: prefix, names: map[string]string{}, counts: map[string]int{}, lists: map[int]string{}}
+}
+
+// add returns the name of a style with the given key, calling write to
+// define it the first time.
+func (a *autoStyles) add(key, letter string, write func(x *xmlWriter, name string)) string {
+	if name, ok := a.names[key]; ok {
+		return name
+	}
+	a.counts[letter]++
+	name := a.prefix + letter + strconv.Itoa(a.counts[letter])
+	a.names[key] = name
+	write(&a.x, name)
+	return name
+}
+
+// style adds a style:style of a family with extra attributes and the
+// given properties.
+func (a *autoStyles) style(family, letter string, attrs []string, body string) string {
+	key := family + "\x00" + strings.Join(attrs, "\x00") + "\x00" + body
+	return a.add(key, letter, func(x *xmlWriter, name string) {
+		x.start("style:style", append([]string{"style:name", name, "style:family", family}, attrs...)...)
+		x.raw(body)
+		x.end("style:style")
+	})
+}
+
+// props returns an empty element with the non-empty attributes, or ""
+// when all are empty.
+func props(name string, attrs ...string) string {
+	empty := true
+	for i := 1; i < len(attrs); i += 2 {
+		if attrs[i] != "" {
+			empty = false
+		}
+	}
+	if empty {
+		return ""
+	}
+	var x xmlWriter
+	x.empty(name, attrs...)
+	return x.b.String()
+}
+
+// sectionPart is the body of one section and how it starts.
+type sectionPart struct {
+	section domain.Section
+	blocks  []domain.Block
+	start   domain.SectionBreakType
+}
+
+// sectionParts splits the body at section breaks.
+func sectionParts(doc domain.Document) []sectionPart {
+	var parts []sectionPart
+	cur := sectionPart{start: domain.SectionBreakTypeNextPage}
+	for _, block := range doc.Blocks() {
+		if block.SectionBreak != nil {
+			cur.section = block.SectionBreak.Section
+			parts = append(parts, cur)
+			cur = sectionPart{start: block.SectionBreak.Type}
+			continue
+		}
+		cur.blocks = append(cur.blocks, block)
+	}
+	if sections := doc.Sections(); len(sections) > 0 {
+		cur.section = sections[len(sections)-1]
+	}
+	return append(parts, cur)
+}
+
+// document writes the body and the master pages of every section. A
+// section that starts on a new page gets its own master page; a
+// continuous section becomes a text:section with its columns.
+func (w *writer) document() error {
+	var headers map[domain.HeaderType]domain.Header
+	var footers map[domain.FooterType]domain.Footer
+	f := &flow{w: w, x: &w.body, styles: &w.content}
+	for i, part := range sectionParts(w.doc) {
+		h, ft := sectionHeaders(part.section)
+		if len(h) > 0 {
+			headers = h
+		}
+		if len(ft) > 0 {
+			footers = ft
+		}
+
+		master := ""
+		continuous := i > 0 && part.start == domain.SectionBreakTypeContinuous
+		if continuous {
+			f.closeList()
+			columns := 1
+			if part.section != nil {
+				columns = max(part.section.Columns(), 1)
+			}
+			style := w.content.style("section", "Sect", nil, columnsElement("style:section-properties", columns))
+			w.body.start("text:section", "text:style-name", style, "text:name", "Section"+strconv.Itoa(i+1))
+		} else {
+			master = "Standard"
+			if i > 0 {
+				master = "Section" + strconv.Itoa(i+1)
+			}
+			if err := w.masterPage(master, part.section, headers, footers); err != nil {
+				return err
+			}
+			if i == 0 {
+				master = ""
+			}
+		}
+
+		for _, block := range part.blocks {
+			var err error
+			switch {
+			case block.Paragraph != nil:
+				err = f.paragraph(block.Paragraph, master)
+			case block.Table != nil:
+				err = f.table(block.Table, master)
+			default:
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			master = ""
+		}
+		if master != "" {
+			// An empty section still starts a page.
+			w.body.empty("text:p", "text:style-name", f.paragraphStyle(nil, "", master))
+		}
+		f.closeList()
+		if continuous {
+			w.body.end("text:section")
+		}
+	}
+	return nil
+}
+
+// sectionHeaders returns the headers and footers a section defines.
+func sectionHeaders(section domain.Section) (map[domain.HeaderType]domain.Header, map[domain.FooterType]domain.Footer) {
+	var headers map[domain.HeaderType]domain.Header
+	var footers map[domain.FooterType]domain.Footer
+	if sec, ok := section.(interface {
+		HeadersAll() map[domain.HeaderType]domain.Header
+	}); ok {
+		headers = sec.HeadersAll()
+	}
+	if sec, ok := section.(interface {
+		FootersAll() map[domain.FooterType]domain.Footer
+	}); ok {
+		footers = sec.FootersAll()
+	}
+	return headers, footers
+}
+
+// masterPage writes a master page and its page layout. Sections without
+// headers or footers keep those of the previous section, as in Word.
+func (w *writer) masterPage(name string, section domain.Section, headers map[domain.HeaderType]domain.Header, footers map[domain.FooterType]domain.Footer) error {
+	size := domain.PageSizeLetter
+	margins := domain.DefaultMargins
+	columns := 1
+	orientation := "portrait"
+	if section != nil {
+		if s := section.PageSize(); s.Width > 0 && s.Height > 0 {
+			size = s
+		}
+		if m := section.Margins(); m != (domain.Margins{}) {
+			margins = m
+		}
+		if section.Orientation() == domain.OrientationLandscape {
+			orientation = "landscape"
+			if size.Width < size.Height {
+				size.Width, size.Height = size.Height, size.Width
+			}
+		}
+		columns = max(section.Columns(), 1)
+	}
+
+	hasHeader, hasFooter := headers[domain.HeaderDefault] != nil, footers[domain.FooterDefault] != nil
+	top, bottom := margins.Top, margins.Bottom
+	if hasHeader {
+		top = margins.Header
+	}
+	if hasFooter {
+		bottom = margins.Footer
+	}
+	background := ""
+	if c, ok := w.doc.BackgroundColor(); ok {
+		background = hexColor(c)
+	}
+
+	layout := w.masters.add("layout\x00"+name, "pm", func(x *xmlWriter, layoutName string) {
+		x.start("style:page-layout", "style:name", layoutName)
+		x.start("style:page-layout-properties",
+			"fo:page-width", length(size.Width), "fo:page-height", length(size.Height),
+			"style:print-orientation", orientation,
+			"fo:margin-top", length(top), "fo:margin-bottom", length(bottom),
+			"fo:margin-left", length(margins.Left), "fo:margin-right", length(margins.Right),
+			"fo:background-color", background)
+		x.raw(columnsElement("", columns))
+		x.end("style:page-layout-properties")
+		x.start("style:header-style")
+		if hasHeader {
+			x.empty("style:header-footer-properties", "fo:min-height", "0in", "fo:margin-bottom", length(max(margins.Top-margins.Header, 0)))
+		}
+		x.end("style:header-style")
+		x.start("style:footer-style")
+		if hasFooter {
+			x.empty("style:header-footer-properties", "fo:min-height", "0in", "fo:margin-top", length(max(margins.Bottom-margins.Footer, 0)))
+		}
+		x.end("style:footer-style")
+		x.end("style:page-layout")
+	})
+
+	w.pages.start("style:master-page", "style:name", name, "style:page-layout-name", layout)
+	parts := []struct {
+		element string
+		paras   func() []domain.Paragraph
+		ok      bool
+	}{
+		{"style:header", func() []domain.Paragraph { return headers[domain.HeaderDefault].Paragraphs() }, hasHeader},
+		{"style:header-left", func() []domain.Paragraph { return headers[domain.HeaderEven].Paragraphs() }, hasHeader && headers[domain.HeaderEven] != nil},
+		{"style:header-first", func() []domain.Paragraph { return headers[domain.HeaderFirst].Paragraphs() }, headers[domain.HeaderFirst] != nil},
+		{"style:footer", func() []domain.Paragraph { return footers[domain.FooterDefault].Paragraphs() }, hasFooter},
+		{"style:footer-left", func() []domain.Paragraph { return footers[domain.FooterEven].Paragraphs() }, hasFooter && footers[domain.FooterEven] != nil},
+		{"style:footer-first", func() []domain.Paragraph { return footers[domain.FooterFirst].Paragraphs() }, footers[domain.FooterFirst] != nil},
+	}
+	for _, p := range parts {
+		if !p.ok {
+			continue
+		}
+		w.pages.start(p.element)
+		f := &flow{w: w, x: &w.pages, styles: &w.masters}
+		for _, para := range p.paras() {
+			if err := f.paragraph(para, ""); err != nil {
+				return err
+			}
+		}
+		f.closeList()
+		w.pages.end(p.element)
+	}
+	w.pages.end("style:master-page")
+	return nil
+}
+
+// columnsElement returns properties with a style:columns element, or ""
+// for a single column. An empty name returns the columns element alone.
+func columnsElement(name string, columns int) string {
+	if columns <= 1 {
+		return ""
+	}
+	var x xmlWriter
+	if name != "" {
+		x.start(name)
+	}
+	x.empty("style:columns", "fo:column-count", strconv.Itoa(columns), "fo:column-gap", length(720))
+	if name != "" {
+		x.end(name)
+	}
+	return x.b.String()
+}
+
+// flow writes paragraphs and tables to one container: the body, a table
+// cell, a header or a footer.
+type flow struct {
+	w      *writer
+	x      *xmlWriter
+	styles *autoStyles
+
+	list  int // ID of the open list
+	depth int // open text:list elements
+	space bool
+}
+
+// listItem opens the list items that hold a paragraph at a list level.
+func (f *flow) listItem(ref domain.NumberingReference) {
+	level := min(max(ref.Level, domain.NumberingLevelMin), domain.NumberingLevelMax)
+	if f.depth > 0 && f.list != ref.ID {
+		f.closeList()
+	}
+	if f.depth == 0 {
+		continued := ""
+		if f.w.listSeen[ref.ID] {
+			continued = "true"
+		}
+		f.w.listSeen[ref.ID] = true
+		f.x.start("text:list", "text:style-name", f.listStyle(ref.ID), "text:continue-numbering", continued)
+		f.x.start("text:list-item")
+		f.list, f.depth = ref.ID, 1
+	} else {
+		for f.depth > level+1 {
+			f.x.end("text:list-item")
+			f.x.end("text:list")
+			f.depth--
+		}
+		if f.depth == level+1 {
+			f.x.end("text:list-item")
+			f.x.start("text:list-item")
+		}
+	}
+	for f.depth < level+1 {
+		f.x.start("text:list")
+		f.x.start("text:list-item")
+		f.depth++
+	}
+}
+
+// closeList closes the open list, if any.
+func (f *flow) closeList() {
+	for ; f.depth > 0; f.depth-- {
+		f.x.end("text:list-item")
+		f.x.end("text:list")
+	}
+}
+
+// listStyle defines the list style of a list ID: bullets or numbers for
+// each level as the document numbering defines them.
+func (f *flow) listStyle(id int) string {
+	if name, ok := f.styles.lists[id]; ok {
+		return name
+	}
+	var levels []domain.ListLevel
+	for level := domain.NumberingLevelMin; level <= domain.NumberingLevelMax; level++ {
+		def, ok := f.w.doc.ListLevel(domain.NumberingReference{ID: id, Level: level})
+		if !ok {
+			def = domain.ListLevel{Kind: domain.ListBullet, Format: "bullet"}
+		}
+		levels = append(levels, def)
+	}
+	key := "list"
+	for _, l := range levels {
+		key += "\x00" + l.Format + strconv.Itoa(l.Start)
+	}
+	name := f.styles.add(key, "L", func(x *xmlWriter, name string) {
+		x.start("text:list-style", "style:name", name)
+		for i, l := range levels {
+			indent := 720 * (i + 1)
+			if format, ok := numberFormats[l.Format]; ok && l.Kind == domain.ListDecimal {
+				start := ""
+				if l.Start > 1 {
+					start = strconv.Itoa(l.Start)
+				}
+				x.start("text:list-level-style-number", "text:level", strconv.Itoa(i+1),
+					"style:num-format", format, "style:num-suffix", ".", "text:start-value", start)
+			} else {
+				x.start("text:list-level-style-bullet", "text:level", strconv.Itoa(i+1),
+					"text:bullet-char", bulletChars[i%len(bulletChars)])
+			}
+			x.start("style:list-level-properties", "text:list-level-position-and-space-mode", "label-alignment")
+			x.empty("style:list-level-label-alignment", "text:label-followed-by", "listtab",
+				"text:list-tab-stop-position", length(indent), "fo:text-indent", length(-360), "fo:margin-left", length(indent))
+			x.end("style:list-level-properties")
+			if l.Kind == domain.ListDecimal && numberFormats[l.Format] != "" {
+				x.end("text:list-level-style-number")
+			} else {
+				x.end("text:list-level-style-bullet")
+			}
+		}
+		x.end("text:list-style")
+	})
+	f.styles.lists[id] = name
+	return name
+}
+
+// numberFormats maps Word number formats to ODF ones.
+var numberFormats = map[string]string{
+	"decimal":     "1",
+	"lowerLetter": "a",
+	"upperLetter": "A",
+	"lowerRoman":  "i",
+	"upperRoman":  "I",
+}
+
+// bulletChars are the bullets of list levels, as Word draws them.
+var bulletChars = []string{"•", "◦", "▪"}
+
+// paragraph writes a paragraph. Page and column breaks inside it split
+// it, since ODF breaks before paragraphs only. master starts a master
+// page at the paragraph.
+func (f *flow) paragraph(p domain.Paragraph, master string) error {
+	if ref, ok := p.Numbering(); ok {
+		f.listItem(ref)
+	} else {
+		f.closeList()
+	}
+
+	pieces, breaks := splitRuns(p.Runs())
+	if len(pieces) > 1 && !visible(pieces[0]) {
+		pieces, breaks = pieces[1:], breaks[1:]
+	}
+	element, level := "text:p", headingLevel(styleID(p))
+	if level > 0 {
+		element = "text:h"
+	}
+	for i, runs := range pieces {
+		attrs := []string{"text:style-name", f.paragraphStyle(p, breaks[i], master)}
+		if level > 0 {
+			attrs = append(attrs, "text:outline-level", strconv.Itoa(level))
+		}
+		master = ""
+		f.x.start(element, attrs...)
+		f.space = true
+		for _, run := range runs {
+			if err := f.run(run); err != nil {
+				return err
+			}
+		}
+		f.x.end(element)
+	}
+	return nil
+}
+
+// splitRuns splits runs after each page or column break. breaks holds
+// the break before each piece: "", "page" or "column".
+func splitRuns(runs []domain.Run) ([][]domain.Run, []string) {
+	pieces := [][]domain.Run{nil}
+	breaks := []string{""}
+	for _, run := range runs {
+		pieces[len(pieces)-1] = append(pieces[len(pieces)-1], run)
+		for _, br := range runBreaks(run) {
+			switch br {
+			case domain.BreakTypePage:
+				pieces, breaks = append(pieces, nil), append(breaks, "page")
+			case domain.BreakTypeColumn:
+				pieces, breaks = append(pieces, nil), append(breaks, "column")
+			}
+		}
+	}
+	return pieces, breaks
+}
+
+// visible reports whether runs show anything.
+func visible(runs []domain.Run) bool {
+	for _, run := range runs {
+		if run.Text() != "" || runImage(run) != nil || runFootnote(run) != nil || len(runFields(run)) > 0 ||
+			slices.Contains(runBreaks(run), domain.BreakTypeLine) {
+			return true
+		}
+	}
+	return false
+}
+
+// paragraphStyle returns the style of a paragraph: its named style, or
+// an automatic style based on it that holds direct formatting, a break
+// before it or the master page it starts.
+func (f *flow) paragraphStyle(p domain.Paragraph, breakBefore, master string) string {
+	parent := ""
+	var attrs []string
+	if p != nil {
+		parent = odfStyleName(styleID(p))
+		attrs = paragraphAttrs(p.Alignment(), p.Indent(), p.SpacingBefore(), p.SpacingAfter())
+		attrs = append(attrs, lineSpacingAttrs(p.LineSpacing())...)
+		b := p.Borders()
+		attrs = append(attrs,
+			"fo:border-top", border(b.Top), "fo:border-bottom", border(b.Bottom),
+			"fo:border-left", border(b.Left), "fo:border-right", border(b.Right))
+	}
+	attrs = append(attrs, "fo:break-before", breakBefore)
+	body := props("style:paragraph-properties", attrs...)
+	if body == "" && master == "" {
+		if parent == "" {
+			return "Standard"
+		}
+		return parent
+	}
+	return f.styles.style("paragraph", "P", []string{"style:parent-style-name", parent, "style:master-page-name", master}, body)
+}
+
+// paragraphAttrs returns paragraph properties that differ from the
+// defaults.
+func paragraphAttrs(align domain.Alignment, indent domain.Indentation, before, after int) []string {
+	var attrs []string
+	switch align {
+	case domain.AlignmentCenter:
+		attrs = append(attrs, "fo:text-align", "center")
+	case domain.AlignmentRight:
+		attrs = append(attrs, "fo:text-align", "end")
+	case domain.AlignmentJustify, domain.AlignmentDistribute:
+		attrs = append(attrs, "fo:text-align", "justify")
+	}
+	if indent.Left != 0 {
+		attrs = append(attrs, "fo:margin-left", length(indent.Left))
+	}
+	if indent.Right != 0 {
+		attrs = append(attrs, "fo:margin-right", length(indent.Right))
+	}
+	if indent.FirstLine > 0 {
+		attrs = append(attrs, "fo:text-indent", length(indent.FirstLine))
+	} else if indent.Hanging > 0 {
+		attrs = append(attrs, "fo:text-indent", length(-indent.Hanging))
+	}
+	if before > 0 {
+		attrs = append(attrs, "fo:margin-top", length(before))
+	}
+	if after > 0 {
+		attrs = append(attrs, "fo:margin-bottom", length(after))
+	}
+	return attrs
+}
+
+// lineSpacingAttrs returns the line height of a paragraph.
+func lineSpacingAttrs(spacing domain.LineSpacing) []string {
+	if spacing.Value <= 0 {
+		return nil
+	}
+	switch spacing.Rule {
+	case domain.LineSpacingExact:
+		return []string{"fo:line-height", length(spacing.Value)}
+	case domain.LineSpacingAtLeast:
+		return []string{"style:line-height-at-least", length(spacing.Value)}
+	}
+	if spacing.Value == 240 {
+		return nil
+	}
+	return []string{"fo:line-height", strconv.Itoa(spacing.Value*100/240) + "%"}
+}
+
+// border formats a border as "0.5pt solid #000000", or "" for none.
+func border(b domain.BorderStyle) string {
+	style := ""
+	switch b.Style {
+	case domain.BorderSingle, domain.BorderThick:
+		style = "solid"
+	case domain.BorderDotted:
+		style = "dotted"
+	case domain.BorderDashed:
+		style = "dashed"
+	case domain.BorderDouble, domain.BorderTriple:
+		style = "double"
+	default:
+		return ""
+	}
+	width := max(b.Width, 2)
+	return strconv.FormatFloat(float64(width)/8, 'f', -1, 64) + "pt " + style + " " + hexColor(b.Color)
+}
+
+func hexColor(c domain.Color) string {
+	return "#" + strings.ToLower(color.ToHex(c))
+}
+
+// run writes the content of a run: images, footnotes, fields, text and
+// line breaks, in a span with its formatting and a link for hyperlinks.
+func (f *flow) run(run domain.Run) error {
+	if img := runImage(run); img != nil {
+		f.image(img)
+	}
+	if note := runFootnote(run); note != nil {
+		if err := f.footnote(note); err != nil {
+			return err
+		}
+	}
+
+	link := ""
+	fields := runFields(run)
+	for _, field := range fields {
+		if url := fieldURL(field); url != "" && link == "" {
+			link = url
+		}
+	}
+	if link != "" {
+		f.x.start("text:a", "xlink:type", "simple", "xlink:href", link)
+	}
+	style := f.textStyle(run)
+	if style != "" {
+		f.x.start("text:span", "text:style-name", style)
+	}
+	for _, field := range fields {
+		switch {
+		case fieldURL(field) != "":
+			if field.Result() == "" {
+				f.text(run.Text())
+			}
+			f.text(field.Result())
+		case field.Type() == domain.FieldTypePageNumber:
+			f.x.start("text:page-number", "text:select-page", "current")
+			f.x.text(field.Result())
+			f.x.end("text:page-number")
+			f.space = false
+		case field.Type() == domain.FieldTypeNumPages || field.Type() == domain.FieldTypePageCount:
+			f.x.start("text:page-count")
+			f.x.text(field.Result())
+			f.x.end("text:page-count")
+			f.space = false
+		default:
+			f.text(field.Result())
+		}
+	}
+	if link == "" {
+		f.text(run.Text())
+	}
+	for _, br := range runBreaks(run) {
+		if br == domain.BreakTypeLine {
+			f.x.empty("text:line-break")
+			f.space = true
+		}
+	}
+	if style != "" {
+		f.x.end("text:span")
+	}
+	if link != "" {
+		f.x.end("text:a")
+	}
+	return nil
+}
+
+// text writes text, keeping repeated spaces, tabs and line breaks.
+func (f *flow) text(s string) {
+	var pending strings.Builder
+	flush := func() {
+		if pending.Len() > 0 {
+			f.x.text(pending.String())
+			pending.Reset()
+		}
+	}
+	for _, r := range s {
+		switch r {
+		case '\t':
+			flush()
+			f.x.empty("text:tab")
+			f.space = false
+		case '\n':
+			flush()
+			f.x.empty("text:line-break")
+			f.space = true
+		case ' ':
+			if f.space {
+				flush()
+				f.x.empty("text:s")
+				continue
+			}
+			pending.WriteRune(r)
+			f.space = true
+		default:
+			pending.WriteRune(r)
+			f.space = false
+		}
+	}
+	flush()
+}
+
+// textStyle returns the automatic style of a run's formatting, or "".
+func (f *flow) textStyle(run domain.Run) string {
+	c, colored := run.Color(), run.Color() != domain.ColorBlack
+	if ref, ok := run.ThemeColor(); ok {
+		c, colored = f.w.themeColor(ref), true
+	}
+	attrs := f.w.textAttrs(run.Font(), run.Size(), run.Bold(), run.Italic(), run.Underline(), c, colored)
+	if run.Strike() {
+		attrs = append(attrs, "style:text-line-through-style", "solid")
+	}
+	if hex, ok := highlightColors[run.Highlight()]; ok {
+		attrs = append(attrs, "fo:background-color", hex)
+	}
+	switch run.Script() {
+	case domain.ScriptSuperscript:
+		attrs = append(attrs, "style:text-position", "super 58%")
+	case domain.ScriptSubscript:
+		attrs = append(attrs, "style:text-position", "sub 58%")
+	}
+	body := props("style:text-properties", attrs...)
+	if body == "" {
+		return ""
+	}
+	return f.styles.style("text", "T", nil, body)
+}
+
+// textAttrs returns character properties that differ from the defaults.
+func (w *writer) textAttrs(font domain.Font, size int, bold, italic bool, underline domain.UnderlineStyle, c domain.Color, colored bool) []string {
+	var attrs []string
+	if name := w.fontName(font); name != "" {
+		attrs = append(attrs, "style:font-name", name)
+	}
+	if size > 0 && size != constants.DefaultFontSize {
+		attrs = append(attrs, "fo:font-size", points(size))
+	}
+	if bold {
+		attrs = append(attrs, "fo:font-weight", "bold")
+	}
+	if italic {
+		attrs = append(attrs, "fo:font-style", "italic")
+	}
+	if underline != domain.UnderlineNone {
+		style, kind, width := "solid", "", ""
+		switch underline {
+		case domain.UnderlineDouble:
+			kind = "double"
+		case domain.UnderlineThick:
+			width = "bold"
+		case domain.UnderlineDotted:
+			style = "dotted"
+		case domain.UnderlineDashed:
+			style = "dash"
+		case domain.UnderlineWave:
+			style = "wave"
+		}
+		attrs = append(attrs, "style:text-underline-style", style, "style:text-underline-type", kind,
+			"style:text-underline-width", width, "style:text-underline-color", "font-color")
+	}
+	if colored {
+		attrs = append(attrs, "fo:color", hexColor(c))
+	}
+	return attrs
+}
+
+// fontName declares the font of a run and returns its name, or "".
+func (w *writer) fontName(font domain.Font) string {
+	name := font.Name
+	switch font.Theme {
+	case domain.ThemeFontMajor:
+		name = w.theme.MajorFont
+	case domain.ThemeFontMinor:
+		name = w.theme.MinorFont
+	}
+	if name != "" {
+		w.fonts[name] = true
+	}
+	return name
+}
+
+// themeColor resolves a theme color reference against the document theme.
+func (w *writer) themeColor(ref domain.ThemeColorRef) domain.Color {
+	c, _ := w.theme.Colors.Color(ref.Color)
+	if ref.Shade != 0 {
+		c = color.Shade(c, ref.Shade)
+	}
+	if ref.Tint != 0 {
+		c = color.Tint(c, ref.Tint)
+	}
+	return c
+}
+
+// highlightColors are the colors of Word's highlights.
+var highlightColors = map[domain.HighlightColor]string{
+	domain.HighlightYellow:      "#ffff00",
+	domain.HighlightGreen:       "#00ff00",
+	domain.HighlightCyan:        "#00ffff",
+	domain.HighlightMagenta:     "#ff00ff",
+	domain.HighlightBlue:        "#0000ff",
+	domain.HighlightRed:         "#ff0000",
+	domain.HighlightDarkBlue:    "#000080",
+	domain.HighlightDarkCyan:    "#008080",
+	domain.HighlightDarkGreen:   "#008000",
+	domain.HighlightDarkMagenta: "#800080",
+	domain.HighlightDarkRed:     "#800000",
+	domain.HighlightDarkYellow:  "#808000",
+	domain.HighlightDarkGray:    "#808080",
+	domain.HighlightLightGray:   "#c0c0c0",
+}
+
+// image writes an image frame. Inline images are anchored as
+// characters; floating ones to the paragraph at their offsets.
+func (f *flow) image(img domain.Image) {
+	path, ok := f.w.images[img]
+	if !ok {
+		format := strings.ToLower(string(img.Format()))
+		mediaType, known := imageTypes[format]
+		if !known {
+			mediaType = "image/" + format
+		}
+		path = "Pictures/image" + strconv.Itoa(len(f.w.pictures)+1) + "." + format
+		f.w.pictures = append(f.w.pictures, entry{path: path, mediaType: mediaType, data: img.Data()})
+		f.w.images[img] = path
+	}
+
+	size := img.Size()
+	pos := img.Position()
+	attrs := []string{"draw:name", "Image" + img.ID(), "text:anchor-type", "as-char"}
+	if pos.Type == domain.ImagePositionFloating {
+		attrs = []string{"draw:name", "Image" + img.ID(), "text:anchor-type", "paragraph",
+			"svg:x", emuLength(pos.OffsetX), "svg:y", emuLength(pos.OffsetY)}
+	}
+	attrs = append(attrs, "svg:width", emuLength(size.WidthEMU), "svg:height", emuLength(size.HeightEMU))
+	f.x.start("draw:frame", attrs...)
+	f.x.start("draw:image", "xlink:href", path, "xlink:type", "simple", "xlink:show", "embed", "xlink:actuate", "onLoad")
+	f.x.end("draw:image")
+	if desc := img.Description(); desc != "" {
+		f.x.start("svg:desc")
+		f.x.text(desc)
+		f.x.end("svg:desc")
+	}
+	f.x.end("draw:frame")
+	f.space = false
+}
+
+// imageTypes maps image formats to media types.
+var imageTypes = map[string]string{
+	"png": "image/png", "jpeg": "image/jpeg", "jpg": "image/jpeg", "gif": "image/gif",
+	"bmp": "image/bmp", "tiff": "image/tiff", "tif": "image/tiff", "svg": "image/svg+xml",
+	"webp": "image/webp", "emf": "image/x-emf", "wmf": "image/x-wmf",
+}
+
+// footnote writes a footnote with its text.
+func (f *flow) footnote(note domain.Footnote) error {
+	f.w.notes++
+	n := strconv.Itoa(f.w.notes)
+	f.x.start("text:note", "text:id", "ftn"+n, "text:note-class", "footnote")
+	f.x.start("text:note-citation")
+	f.x.text(n)
+	f.x.end("text:note-citation")
+	f.x.start("text:note-body")
+	inner := &flow{w: f.w, x: f.x, styles: f.styles}
+	for _, para := range note.Paragraphs() {
+		if err := inner.paragraph(para, ""); err != nil {
+			return err
+		}
+	}
+	inner.closeList()
+	f.x.end("text:note-body")
+	f.x.end("text:note")
+	f.space = false
+	return nil
+}
+
+// table writes a table with its column widths, merged cells, shading
+// and borders. master starts a master page at the table.
+func (f *flow) table(t domain.Table, master string) error {
+	f.closeList()
+	f.w.tables++
+	name := "Table" + strconv.Itoa(f.w.tables)
+
+	grid := t.Grid()
+	columns := 0
+	for _, row := range grid {
+		columns = max(columns, len(row))
+	}
+	widths := columnWidths(t, grid, columns)
+	total := 0
+	for _, w := range widths {
+		total += w
+	}
+
+	align := "margins"
+	width := ""
+	if tw := t.Width(); tw.Type == domain.WidthDXA && tw.Value > 0 {
+		width = length(tw.Value)
+	} else if total > 0 {
+		width = length(total)
+	}
+	if width != "" {
+		align = "left"
+	}
+	switch t.Alignment() {
+	case domain.AlignmentCenter:
+		align = "center"
+	case domain.AlignmentRight:
+		align = "right"
+	}
+	style := f.styles.style("table", "Table", []string{"style:master-page-name", master},
+		props("style:table-properties", "style:width", width, "table:align", align))
+
+	f.x.start("table:table", "table:name", name, "table:style-name", style)
+	for _, w := range widths {
+		colStyle := ""
+		if w > 0 {
+			colStyle = f.styles.style("table-column", "Col", nil, props("style:table-column-properties", "style:column-width", length(w)))
+		}
+		f.x.empty("table:table-column", "table:style-name", colStyle)
+	}
+
+	styled := t.Style().Name != "" && t.Style().Name != domain.StyleIDTableNormal
+	for r, row := range t.Rows() {
+		rowStyle := ""
+		if h := row.Height(); h > 0 {
+			rowStyle = f.styles.style("table-row", "Row", nil, props("style:table-row-properties", "style:min-row-height", length(h)))
+		}
+		f.x.start("table:table-row", "table:style-name", rowStyle)
+		cells := row.Cells()
+		for c := range columns {
+			if c >= len(cells) || c >= len(grid[r]) {
+				f.x.start("table:table-cell")
+				f.x.empty("text:p")
+				f.x.end("table:table-cell")
+				continue
+			}
+			info := grid[r][c]
+			if !info.IsOrigin(r, c) {
+				f.x.empty("table:covered-table-cell")
+				continue
+			}
+			if err := f.cell(cells[c], info, styled); err != nil {
+				return err
+			}
+		}
+		f.x.end("table:table-row")
+	}
+	f.x.end("table:table")
+	return nil
+}
+
+// columnWidths returns the width of each grid column, from the cells
+// that span a single column, or 0 where none does.
+func columnWidths(t domain.Table, grid [][]domain.CellMergeInfo, columns int) []int {
+	widths := make([]int, columns)
+	for r, row := range t.Rows() {
+		for c, cell := range row.Cells() {
+			if c >= columns || widths[c] > 0 || c >= len(grid[r]) {
+				continue
+			}
+			if info := grid[r][c]; info.IsOrigin(r, c) && max(info.ColSpan, info.GridSpan, 1) == 1 {
+				widths[c] = cell.Width()
+			}
+		}
+	}
+	return widths
+}
+
+// cell writes a table cell with its paragraphs and nested tables.
+func (f *flow) cell(cell domain.TableCell, info domain.CellMergeInfo, styled bool) error {
+	b := cell.Borders()
+	side := func(s domain.BorderStyle) string {
+		if s.Style == domain.BorderNone && styled {
+			s = gridBorder
+		}
+		return border(s)
+	}
+	background := ""
+	if shading := cell.Shading(); shading != domain.ColorWhite && shading != (domain.Color{}) {
+		background = hexColor(shading)
+	}
+	valign := ""
+	switch cell.VerticalAlignment() {
+	case domain.VerticalAlignCenter:
+		valign = "middle"
+	case domain.VerticalAlignBottom:
+		valign = "bottom"
+	}
+	body := props("style:table-cell-properties",
+		"fo:background-color", background, "style:vertical-align", valign,
+		"fo:border-top", side(b.Top), "fo:border-bottom", side(b.Bottom),
+		"fo:border-left", side(b.Left), "fo:border-right", side(b.Right),
+		"fo:padding", length(108))
+	style := f.styles.style("table-cell", "Cell", nil, body)
+
+	spanCols, spanRows := "", ""
+	if n := max(info.ColSpan, info.GridSpan); n > 1 {
+		spanCols = strconv.Itoa(n)
+	}
+	if info.RowSpan > 1 {
+		spanRows = strconv.Itoa(info.RowSpan)
+	}
+	f.x.start("table:table-cell", "table:style-name", style,
+		"table:number-columns-spanned", spanCols, "table:number-rows-spanned", spanRows,
+		"office:value-type", "string")
+	inner := &flow{w: f.w, x: f.x, styles: f.styles}
+	paras := cell.Paragraphs()
+	for _, para := range paras {
+		if err := inner.paragraph(para, ""); err != nil {
+			return err
+		}
+	}
+	inner.closeList()
+	for _, nested := range cell.Tables() {
+		if err := inner.table(nested, ""); err != nil {
+			return err
+		}
+	}
+	if len(paras) == 0 && len(cell.Tables()) == 0 {
+		f.x.empty("text:p")
+	}
+	f.x.end("table:table-cell")
+	return nil
+}
+
+// namedStyles writes the paragraph styles of the document.
+func (w *writer) namedStyles(x *xmlWriter) {
+	styles := w.doc.StyleManager().ListStylesByType(domain.StyleTypeParagraph)
+	slices.SortFunc(styles, func(a, b domain.Style) int { return strings.Compare(a.ID(), b.ID()) })
+	for _, s := range styles {
+		ps, ok := s.(domain.ParagraphStyle)
+		if !ok {
+			continue
+		}
+		name := odfStyleName(ps.ID())
+		display := ps.Name()
+		if display == name {
+			display = ""
+		}
+		attrs := []string{"style:name", name, "style:display-name", display, "style:family", "paragraph",
+			"style:parent-style-name", odfStyleName(ps.BasedOn()), "style:next-style-name", odfStyleName(ps.Next())}
+		if level := headingLevel(ps.ID()); level > 0 {
+			attrs = append(attrs, "style:default-outline-level", strconv.Itoa(level))
+		}
+		x.start("style:style", attrs...)
+
+		para := paragraphAttrs(ps.Alignment(), ps.Indentation(), ps.SpacingBefore(), ps.SpacingAfter())
+		if ls := ps.LineSpacing(); ls > 0 && ls != 240 {
+			para = append(para, "fo:line-height", strconv.Itoa(ls*100/240)+"%")
+		}
+		if ps.KeepNext() {
+			para = append(para, "fo:keep-with-next", "always")
+		}
+		if ps.KeepLines() {
+			para = append(para, "fo:keep-together", "always")
+		}
+		if ps.PageBreakBefore() {
+			para = append(para, "fo:break-before", "page")
+		}
+		x.raw(props("style:paragraph-properties", para...))
+
+		c, colored := ps.Color(), ps.Color() != domain.ColorBlack
+		if ref, ok := ps.ThemeColor(); ok {
+			c, colored = w.themeColor(ref), true
+		}
+		x.raw(props("style:text-properties", w.textAttrs(ps.Font(), ps.Size(), ps.Bold(), ps.Italic(), ps.Underline(), c, colored)...))
+		x.end("style:style")
+	}
+}
+
+// fontFaces writes the declarations of the fonts used.
+func (w *writer) fontFaces(x *xmlWriter) {
+	x.start("office:font-face-decls")
+	for _, name := range slices.Sorted(maps.Keys(w.fonts)) {
+		family := name
+		if strings.ContainsAny(name, " ,") {
+			family = "'" + name + "'"
+		}
+		x.empty("style:font-face", "style:name", name, "svg:font-family", family)
+	}
+	x.end("office:font-face-decls")
+}
+
+// wordStyleNames maps Word style IDs to the names of the matching
+// LibreOffice styles.
+var wordStyleNames = map[string]string{
+	domain.StyleIDNormal:        "Standard",
+	domain.StyleIDBodyText:      "Text_20_body",
+	domain.StyleIDQuote:         "Quotations",
+	domain.StyleIDListParagraph: "List_20_Paragraph",
+}
+
+// odfStyleName returns the ODF name of a Word style ID, or "".
+func odfStyleName(id string) string {
+	if id == "" {
+		return ""
+	}
+	if name, ok := wordStyleNames[id]; ok {
+		return name
+	}
+	if level := headingLevel(id); level > 0 {
+		return "Heading_20_" + strconv.Itoa(level)
+	}
+	var b strings.Builder
+	for i, r := range id {
+		if r == '_' || r == '-' && i > 0 || r == '.' && i > 0 ||
+			'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' && i > 0 || r > 0x7f {
+			b.WriteRune(r)
+			continue
+		}
+		b.WriteString("_" + strings.ToLower(strconv.FormatInt(int64(r), 16)) + "_")
+	}
+	return b.String()
+}
+
+// headingLevel returns the level of a HeadingN style ID, or 0.
+func headingLevel(id string) int {
+	rest, ok := strings.CutPrefix(id, "Heading")
+	if !ok {
+		return 0
+	}
+	if level, err := strconv.Atoi(rest); err == nil && level >= 1 && level <= 9 {
+		return level
+	}
+	return 0
+}
+
+// styleID returns the style a paragraph was given, or "".
+func styleID(p domain.Paragraph) string {
+	if named, ok := p.(interface{ StyleName() string }); ok {
+		return named.StyleName()
+	}
+	return ""
+}
+
+// runFields returns the fields of a run.
+func runFields(run domain.Run) []domain.Field {
+	if withFields, ok := run.(interface{ Fields() []domain.Field }); ok {
+		return withFields.Fields()
+	}
+	return nil
+}
+
+// fieldURL returns the target of a hyperlink field, or "".
+func fieldURL(field domain.Field) string {
+	if field.Type() != domain.FieldTypeHyperlink {
+		return ""
+	}
+	accessor, ok := field.(interface {
+		GetProperty(string) (string, bool)
+	})
+	if !ok {
+		return ""
+	}
+	url, _ := accessor.GetProperty("url")
+	return url
+}
+
+// runImage returns the image a run holds, or nil.
+func runImage(run domain.Run) domain.Image {
+	if withImage, ok := run.(interface{ Image() domain.Image }); ok {
+		return withImage.Image()
+	}
+	return nil
+}
+
+// runFootnote returns the footnote a run references, or nil.
+func runFootnote(run domain.Run) domain.Footnote {
+	if withNote, ok := run.(interface{ Footnote() domain.Footnote }); ok {
+		return withNote.Footnote()
+	}
+	return nil
+}
+
+// runBreaks returns the breaks after the text of a run.
+func runBreaks(run domain.Run) []domain.BreakType {
+	if withBreaks, ok := run.(interface{ Breaks() []domain.BreakType }); ok {
+		return withBreaks.Breaks()
+	}
+	return nil
+}
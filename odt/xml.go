@@ -0,0 +1,282 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package odt
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ODF namespaces, keyed by the prefixes used in this package.
+var namespaces = []struct{ prefix, uri string }{
+	{"office", "urn:oasis:names:tc:opendocument:xmlns:office:1.0"},
+	{"style", "urn:oasis:names:tc:opendocument:xmlns:style:1.0"},
+	{"text", "urn:oasis:names:tc:opendocument:xmlns:text:1.0"},
+	{"table", "urn:oasis:names:tc:opendocument:xmlns:table:1.0"},
+	{"draw", "urn:oasis:names:tc:opendocument:xmlns:drawing:1.0"},
+	{"fo", "urn:oasis:names:tc:opendocument:xmlns:xsl-fo-compatible:1.0"},
+	{"svg", "urn:oasis:names:tc:opendocument:xmlns:svg-compatible:1.0"},
+	{"xlink", "http://www.w3.org/1999/xlink"},
+	{"dc", "http://purl.org/dc/elements/1.1/"},
+	{"meta", "urn:oasis:names:tc:opendocument:xmlns:meta:1.0"},
+	{"manifest", "urn:oasis:names:tc:opendocument:xmlns:manifest:1.0"},
+	{"loext", "urn:org:documentfoundation:names:experimental:office:xmlns:loext:1.0"},
+}
+
+// node is an element, or a text node when name is empty. Element and
+// attribute names carry the prefixes of namespaces, e.g. "text:p".
+type node struct {
+	name     string
+	attrs    map[string]string
+	text     string
+	children []*node
+}
+
+// parseXML reads a document into a node tree and returns its root
+// element. Elements of unknown namespaces keep their local name only.
+func parseXML(data []byte) (*node, error) {
+	prefixes := make(map[string]string, len(namespaces))
+	for _, ns := range namespaces {
+		prefixes[ns.uri] = ns.prefix
+	}
+	qualify := func(n xml.Name) string {
+		if p, ok := prefixes[n.Space]; ok {
+			return p + ":" + n.Local
+		}
+		return n.Local
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	root := &node{}
+	stack := []*node{root}
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		top := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{name: qualify(t.Name), attrs: make(map[string]string, len(t.Attr))}
+			for _, a := range t.Attr {
+				n.attrs[qualify(a.Name)] = a.Value
+			}
+			top.children = append(top.children, n)
+			stack = append(stack, n)
+		case xml.EndElement:
+			if len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case xml.CharData:
+			top.children = append(top.children, &node{text: string(t)})
+		}
+	}
+	for _, child := range root.children {
+		if child.name != "" {
+			return child, nil
+		}
+	}
+	return nil, io.ErrUnexpectedEOF
+}
+
+// child returns the first child element with the given name, or nil.
+func (n *node) child(name string) *node {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.children {
+		if c.name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+// elements returns the child elements with the given name.
+func (n *node) elements(name string) []*node {
+	if n == nil {
+		return nil
+	}
+	var out []*node
+	for _, c := range n.children {
+		if c.name == name {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// attr returns an attribute value, or "".
+func (n *node) attr(name string) string {
+	if n == nil {
+		return ""
+	}
+	return n.attrs[name]
+}
+
+// attributes returns the attributes of n, or nil.
+func (n *node) attributes() map[string]string {
+	if n == nil {
+		return nil
+	}
+	return n.attrs
+}
+
+// nodes returns the children of n, or nil.
+func (n *node) nodes() []*node {
+	if n == nil {
+		return nil
+	}
+	return n.children
+}
+
+// xmlWriter builds an XML document.
+type xmlWriter struct {
+	b    strings.Builder
+	open bool // a start tag waits for its closing bracket
+}
+
+// start opens an element. attrs are name and value pairs; empty values
+// are left out.
+func (w *xmlWriter) start(name string, attrs ...string) {
+	w.close()
+	w.b.WriteString("<" + name)
+	for i := 0; i+1 < len(attrs); i += 2 {
+		if attrs[i+1] == "" {
+			continue
+		}
+		w.b.WriteString(" " + attrs[i] + `="`)
+		xml.EscapeText(&w.b, []byte(attrs[i+1])) //nolint:errcheck // strings.Builder does not fail
+		w.b.WriteString(`"`)
+	}
+	w.open = true
+}
+
+// end closes the element opened last with name.
+func (w *xmlWriter) end(name string) {
+	if w.open {
+		w.b.WriteString("/>")
+		w.open = false
+		return
+	}
+	w.b.WriteString("</" + name + ">")
+}
+
+// empty writes an element without content.
+func (w *xmlWriter) empty(name string, attrs ...string) {
+	w.start(name, attrs...)
+	w.end(name)
+}
+
+// text writes escaped character data.
+func (w *xmlWriter) text(s string) {
+	w.close()
+	xml.EscapeText(&w.b, []byte(s)) //nolint:errcheck // strings.Builder does not fail
+}
+
+// raw writes markup as is.
+func (w *xmlWriter) raw(s string) {
+	w.close()
+	w.b.WriteString(s)
+}
+
+func (w *xmlWriter) close() {
+	if w.open {
+		w.b.WriteString(">")
+		w.open = false
+	}
+}
+
+// document returns the XML declaration followed by the content.
+func (w *xmlWriter) document() []byte {
+	w.close()
+	return []byte(xml.Header + w.b.String())
+}
+
+// namespaceAttrs returns the xmlns declarations of the given prefixes as
+// attribute pairs for start.
+func namespaceAttrs(prefixes ...string) []string {
+	var attrs []string
+	for _, p := range prefixes {
+		for _, ns := range namespaces {
+			if ns.prefix == p {
+				attrs = append(attrs, "xmlns:"+p, ns.uri)
+			}
+		}
+	}
+	return attrs
+}
+
+// length formats twips as inches.
+func length(twips int) string {
+	return strconv.FormatFloat(math.Round(float64(twips)/1440*10000)/10000, 'f', -1, 64) + "in"
+}
+
+// emuLength formats EMUs as inches.
+func emuLength(emu int) string {
+	return strconv.FormatFloat(math.Round(float64(emu)/914400*10000)/10000, 'f', -1, 64) + "in"
+}
+
+// points formats a size in half-points as points.
+func points(halfPoints int) string {
+	return strconv.FormatFloat(float64(halfPoints)/2, 'f', -1, 64) + "pt"
+}
+
+// unitTwips holds the twips per unit of the ODF length units.
+var unitTwips = map[string]float64{
+	"in": 1440, "cm": 1440 / 2.54, "mm": 144 / 2.54, "pt": 20, "pc": 240, "px": 15,
+}
+
+// parseLength reads an ODF length as twips.
+func parseLength(s string) (int, bool) {
+	s = strings.TrimSpace(s)
+	for unit, factor := range unitTwips {
+		if v, ok := strings.CutSuffix(s, unit); ok {
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return 0, false
+			}
+			return int(math.Round(f * factor)), true
+		}
+	}
+	return 0, false
+}
+
+// parsePercent reads a percentage such as "150%".
+func parsePercent(s string) (float64, bool) {
+	v, ok := strings.CutSuffix(strings.TrimSpace(s), "%")
+	if !ok {
+		return 0, false
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	return f, err == nil
+}
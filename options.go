@@ -39,6 +39,7 @@ type Config struct {
 	StrictValidation bool
 	Metadata         *domain.Metadata
 	Theme            interface{} // Theme to apply (using interface{} to avoid import cycle)
+	ImageCompression domain.ImageCompression
 }
 
 // PageSize represents paper dimensions.
@@ -244,3 +245,17 @@ func WithTheme(theme interface{}) Option {
 		c.Theme = theme
 	}
 }
+
+// WithImageCompression downsamples oversized images and re-encodes JPEGs
+// when the document is saved.
+//
+// Example:
+//
+//	builder := docx.NewDocumentBuilder(
+//	    docx.WithImageCompression(domain.ImageCompression{TargetDPI: 150, JPEGQuality: 80}),
+//	)
+func WithImageCompression(opts domain.ImageCompression) Option {
+	return func(c *Config) {
+		c.ImageCompression = opts
+	}
+}
@@ -28,6 +28,7 @@ package color
 
 import (
 	"fmt"
+	"math"
 	"strconv"
 
 	"github.com/mmonterroca/docxgo/v2/domain"
@@ -110,6 +111,85 @@ func FromHex(hex string) (domain.Color, error) {
 	return domain.Color{R: r, G: g, B: b}, nil
 }
 
+// Shade darkens c the way Word applies w:themeShade: the HSL luminance is
+// scaled to amount/255.
+func Shade(c domain.Color, amount uint8) domain.Color {
+	h, s, l := toHSL(c)
+	return fromHSL(h, s, l*float64(amount)/255)
+}
+
+// Tint lightens c the way Word applies w:themeTint: the HSL luminance moves
+// toward white, keeping amount/255 of the original color.
+func Tint(c domain.Color, amount uint8) domain.Color {
+	h, s, l := toHSL(c)
+	f := float64(amount) / 255
+	return fromHSL(h, s, l*f+1-f)
+}
+
+// toHSL converts c to hue (0-1), saturation and luminance.
+func toHSL(c domain.Color) (h, s, l float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	hi, lo := math.Max(r, math.Max(g, b)), math.Min(r, math.Min(g, b))
+	l = (hi + lo) / 2
+	if hi == lo {
+		return 0, 0, l
+	}
+	d := hi - lo
+	if l > 0.5 {
+		s = d / (2 - hi - lo)
+	} else {
+		s = d / (hi + lo)
+	}
+	switch hi {
+	case r:
+		h = (g - b) / d
+		if g < b {
+			h += 6
+		}
+	case g:
+		h = (b-r)/d + 2
+	default:
+		h = (r-g)/d + 4
+	}
+	return h / 6, s, l
+}
+
+// fromHSL converts hue, saturation and luminance back to a Color. Channels
+// are truncated, as Word does, so shades match the ones Word shows.
+func fromHSL(h, s, l float64) domain.Color {
+	l = math.Max(0, math.Min(1, l))
+	if s == 0 {
+		v := uint8(l*255 + 1e-9)
+		return domain.Color{R: v, G: v, B: v}
+	}
+	q := l * (1 + s)
+	if l >= 0.5 {
+		q = l + s - l*s
+	}
+	p := 2*l - q
+	channel := func(t float64) uint8 {
+		switch {
+		case t < 0:
+			t++
+		case t > 1:
+			t--
+		}
+		var v float64
+		switch {
+		case t < 1.0/6:
+			v = p + (q-p)*6*t
+		case t < 0.5:
+			v = q
+		case t < 2.0/3:
+			v = p + (q-p)*(2.0/3-t)*6
+		default:
+			v = p
+		}
+		return uint8(v*255 + 1e-9)
+	}
+	return domain.Color{R: channel(h + 1.0/3), G: channel(h), B: channel(h - 1.0/3)}
+}
+
 // Validate checks if a color is valid (all components in range 0-255).
 func Validate(_ domain.Color) error {
 	// uint8 automatically ensures 0-255 range, so this is always valid
@@ -249,3 +249,26 @@ func TestToHexFromHexRoundTrip(t *testing.T) {
 		})
 	}
 }
+
+func TestShadeAndTint(t *testing.T) {
+	accent1 := domain.Color{R: 0x44, G: 0x72, B: 0xC4}
+	tests := []struct {
+		name string
+		got  domain.Color
+		want string
+	}{
+		{"darker 25%", Shade(accent1, 0xBF), "2F5496"},
+		{"darker 50%", Shade(accent1, 0x80), "1F3864"},
+		{"lighter 40%", Tint(accent1, 0x99), "8EAADB"},
+		{"lighter 80%", Tint(accent1, 0x33), "D9E2F3"},
+		{"full shade", Shade(accent1, 0xFF), "4472C4"},
+		{"black tint", Tint(Black, 0x80), "7F7F7F"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if hex := ToHex(tt.got); hex != tt.want {
+				t.Errorf("got %s, want %s", hex, tt.want)
+			}
+		})
+	}
+}
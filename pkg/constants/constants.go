@@ -94,6 +94,28 @@ const (
 
 	// Dublin Core Terms namespace
 	NamespaceDCTerms = "http://purl.org/dc/terms/"
+
+	// Office 2016 SVG drawing extension namespace
+	NamespaceSVG = "http://schemas.microsoft.com/office/drawing/2016/SVG/main"
+
+	// DrawingML chart namespace
+	NamespaceChart = "http://schemas.openxmlformats.org/drawingml/2006/chart"
+
+	// Word 2010 shape namespace (text boxes and preset shapes)
+	NamespaceWordprocessingShape = "http://schemas.microsoft.com/office/word/2010/wordprocessingShape"
+
+	// Office Math namespace
+	NamespaceMath = "http://schemas.openxmlformats.org/officeDocument/2006/math"
+
+	// VML and Office namespaces (legacy OLE objects)
+	NamespaceVML    = "urn:schemas-microsoft-com:vml"
+	NamespaceOffice = "urn:schemas-microsoft-com:office:office"
+)
+
+// DrawingML extension URIs
+const (
+	// ExtURISVGBlip identifies the a:ext element holding an asvg:svgBlip.
+	ExtURISVGBlip = "{96DAC541-7B7A-43D3-8B79-37D633B846F1}"
 )
 
 // OOXML Relationship Types
@@ -115,8 +137,13 @@ const (
 	RelTypeCoreProperties      = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties"
 	RelTypeExtendedProperties  = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/extended-properties"
 	RelTypeCustomProperties    = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/custom-properties"
+	RelTypeThumbnail           = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail"
 	RelTypeCustomXML           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/customXml"
 	RelTypeCustomXMLProperties = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/customXmlProps"
+	RelTypeChart               = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/chart"
+	RelTypePackage             = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/package"
+	RelTypeOLEObject           = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/oleObject"
+	RelTypeAltChunk            = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/aFChunk"
 )
 
 // Content Types
@@ -138,6 +165,14 @@ const (
 	ContentTypeCustomProperties   = "application/vnd.openxmlformats-officedocument.custom-properties+xml"
 	ContentTypeCustomXML          = "application/xml"
 	ContentTypeRelationships      = "application/vnd.openxmlformats-package.relationships+xml"
+	ContentTypeChart              = "application/vnd.openxmlformats-officedocument.drawingml.chart+xml"
+	ContentTypeSpreadsheet        = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	ContentTypeWordprocessing     = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	ContentTypePresentation       = "application/vnd.openxmlformats-officedocument.presentationml.presentation"
+	ContentTypeOLEObject          = "application/vnd.openxmlformats-officedocument.oleObject"
+	ContentTypeHTML               = "text/html"
+	ContentTypeRTF                = "application/rtf"
+	ContentTypeText               = "text/plain"
 	ContentTypePNG                = "image/png"
 	ContentTypeJPEG               = "image/jpeg"
 	ContentTypeGIF                = "image/gif"
@@ -145,6 +180,7 @@ const (
 	ContentTypeTIFF               = "image/tiff"
 	ContentTypeWMF                = "image/x-wmf"
 	ContentTypeEMF                = "image/x-emf"
+	ContentTypeSVG                = "image/svg+xml"
 )
 
 // File paths within .docx archive
@@ -155,6 +191,7 @@ const (
 	PathDocument     = "word/document.xml"
 	PathStyles       = "word/styles.xml"
 	PathNumbering    = "word/numbering.xml"
+	PathFootnotes    = "word/footnotes.xml"
 	PathFontTable    = "word/fontTable.xml"
 	PathSettings     = "word/settings.xml"
 	PathWebSettings  = "word/webSettings.xml"
@@ -162,6 +199,7 @@ const (
 	PathCoreProps    = "docProps/core.xml"
 	PathAppProps     = "docProps/app.xml"
 	PathCustomProps  = "docProps/custom.xml"
+	PathThumbnail    = "docProps/thumbnail.jpeg"
 	PathMediaPrefix  = "word/media/"
 	PathHeaderPrefix = "word/header"
 	PathFooterPrefix = "word/footer"
@@ -296,6 +334,16 @@ const (
 	WidthTypePct  = "pct"
 )
 
+// OOXML string values for floating table anchors and overlap
+const (
+	TableAnchorValueText   = "text"
+	TableAnchorValueMargin = "margin"
+	TableAnchorValuePage   = "page"
+
+	TableOverlapValueNever   = "never"
+	TableOverlapValueOverlap = "overlap"
+)
+
 // OOXML string values for highlight colors
 const (
 	HighlightValueNone        = "none"
@@ -325,4 +373,7 @@ const (
 	FieldCodeStyleRef   = "STYLEREF"
 	FieldCodeRef        = "REF"
 	FieldCodeSeq        = "SEQ"
+
+	FieldCodeSectionPages = "SECTIONPAGES"
+	FieldCodePageRef      = "PAGEREF"
 )
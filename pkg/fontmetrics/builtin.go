@@ -0,0 +1,157 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package fontmetrics
+
+import "strings"
+
+// boldFactor widens regular advances when no bold table is available.
+const boldFactor = 1.05
+
+// Advance widths for U+0020..U+007E in 1/1000 em, from the Adobe Core 14 AFM files.
+var (
+	helveticaWidths = [95]int{
+		278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+		1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+		333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+		556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+	}
+	helveticaBoldWidths = [95]int{
+		278, 333, 474, 556, 556, 889, 722, 238, 333, 333, 389, 584, 278, 333, 278, 278,
+		556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 333, 333, 584, 584, 584, 611,
+		975, 722, 722, 722, 722, 667, 611, 778, 722, 278, 556, 722, 611, 833, 722, 778,
+		667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 333, 278, 333, 584, 556,
+		333, 556, 611, 556, 611, 556, 333, 611, 611, 278, 278, 556, 278, 889, 611, 611,
+		611, 611, 389, 556, 333, 611, 556, 778, 556, 556, 500, 389, 280, 389, 584,
+	}
+	timesWidths = [95]int{
+		250, 333, 408, 500, 500, 833, 778, 180, 333, 333, 500, 564, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 278, 278, 564, 564, 564, 444,
+		921, 722, 667, 667, 722, 611, 556, 722, 722, 333, 389, 722, 611, 889, 722, 722,
+		556, 722, 667, 556, 611, 722, 722, 944, 722, 722, 611, 333, 278, 333, 469, 500,
+		333, 444, 500, 444, 500, 444, 333, 500, 500, 278, 278, 500, 278, 778, 500, 500,
+		500, 500, 333, 389, 278, 500, 500, 722, 500, 500, 444, 480, 200, 480, 541,
+	}
+	timesBoldWidths = [95]int{
+		250, 333, 555, 500, 500, 1000, 833, 278, 333, 333, 500, 570, 250, 333, 250, 278,
+		500, 500, 500, 500, 500, 500, 500, 500, 500, 500, 333, 333, 570, 570, 570, 500,
+		930, 722, 667, 722, 722, 667, 611, 778, 778, 389, 500, 778, 667, 944, 722, 778,
+		611, 778, 722, 556, 667, 722, 722, 1000, 722, 722, 667, 333, 278, 333, 581, 500,
+		333, 500, 556, 444, 556, 444, 333, 500, 556, 278, 333, 556, 278, 833, 556, 500,
+		556, 556, 444, 389, 333, 556, 500, 722, 500, 500, 444, 394, 220, 394, 520,
+	}
+)
+
+func newAFMMetrics(widths [95]int, defaultWidth, ascent, descent, lineGap int) *Metrics {
+	return &Metrics{
+		UnitsPerEm:   1000,
+		Ascent:       ascent,
+		Descent:      descent,
+		LineGap:      lineGap,
+		DefaultWidth: defaultWidth,
+		ascii:        widths,
+	}
+}
+
+func monospaceMetrics(advance, ascent, descent, lineGap int) *Metrics {
+	m := &Metrics{UnitsPerEm: 1000, Ascent: ascent, Descent: descent, LineGap: lineGap, DefaultWidth: advance}
+	for i := range m.ascii {
+		m.ascii[i] = advance
+	}
+	return m
+}
+
+var (
+	sansRegular  = newAFMMetrics(helveticaWidths, 556, 905, 212, 33)
+	sansBold     = newAFMMetrics(helveticaBoldWidths, 611, 905, 212, 33)
+	serifRegular = newAFMMetrics(timesWidths, 500, 891, 216, 42)
+	serifBold    = newAFMMetrics(timesBoldWidths, 500, 891, 216, 42)
+	mono         = monospaceMetrics(600, 833, 300, 0)
+
+	// builtinFaces maps lower-case family names to regular/bold metrics.
+	// Faces without published AFM data are approximated by scaling a
+	// metric-compatible base font to the face's average advance.
+	builtinFaces = map[string][2]*Metrics{
+		"arial":           {sansRegular, sansBold},
+		"helvetica":       {sansRegular, sansBold},
+		"liberation sans": {sansRegular, sansBold},
+		"arimo":           {sansRegular, sansBold},
+		"calibri": {
+			sansRegular.scaled(0.9).withVertical(750, 250, 221),
+			sansBold.scaled(0.9).withVertical(750, 250, 221),
+		},
+		"carlito": {
+			sansRegular.scaled(0.9).withVertical(750, 250, 221),
+			sansBold.scaled(0.9).withVertical(750, 250, 221),
+		},
+		"aptos":    {sansRegular.scaled(0.95), sansBold.scaled(0.95)},
+		"segoe ui": {sansRegular.scaled(0.97), sansBold.scaled(0.97)},
+		"tahoma":   {sansRegular.scaled(0.98), sansBold.scaled(1.0)},
+		"verdana":  {sansRegular.scaled(1.12), sansBold.scaled(1.15)},
+
+		"times new roman":  {serifRegular, serifBold},
+		"times":            {serifRegular, serifBold},
+		"liberation serif": {serifRegular, serifBold},
+		"tinos":            {serifRegular, serifBold},
+		"cambria": {
+			serifRegular.scaled(1.05).withVertical(950, 222, 0),
+			serifBold.scaled(1.05).withVertical(950, 222, 0),
+		},
+		"georgia":  {serifRegular.scaled(1.1), serifBold.scaled(1.1)},
+		"garamond": {serifRegular.scaled(0.95), serifBold.scaled(0.95)},
+
+		"courier new":      {mono, mono},
+		"courier":          {mono, mono},
+		"liberation mono":  {mono, mono},
+		"consolas":         {monospaceMetrics(550, 743, 257, 172), monospaceMetrics(550, 743, 257, 172)},
+		"lucida console":   {mono, mono},
+		"dejavu sans mono": {mono, mono},
+	}
+)
+
+// builtinFace resolves a normalized family name to built-in metrics.
+func builtinFace(family string, bold bool) *Metrics {
+	pick := func(faces [2]*Metrics) *Metrics {
+		if bold {
+			return faces[1]
+		}
+		return faces[0]
+	}
+
+	if faces, ok := builtinFaces[family]; ok {
+		return pick(faces)
+	}
+
+	switch {
+	case strings.Contains(family, "mono") || strings.Contains(family, "courier") || strings.Contains(family, "code"):
+		return mono
+	case strings.Contains(family, "serif") && !strings.Contains(family, "sans"),
+		strings.Contains(family, "times"), strings.Contains(family, "roman"):
+		return pick([2]*Metrics{serifRegular, serifBold})
+	default:
+		return pick(builtinFaces["calibri"])
+	}
+}
@@ -0,0 +1,171 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package fontmetrics provides glyph advance widths used to estimate text
+// extents without a rendering engine.
+//
+// Metrics for the standard Office and PDF base fonts are built in (Arial and
+// Helvetica, Times New Roman, Courier New, plus close approximations for
+// Calibri, Cambria and other common faces). Fonts embedded by the caller can be
+// registered from their TrueType data with ParseTrueType and Register.
+package fontmetrics
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// Metrics holds the horizontal and vertical metrics of a font face.
+// All values are in font units; UnitsPerEm converts them to ems.
+type Metrics struct {
+	UnitsPerEm   int
+	Ascent       int // Distance from baseline to the top of the line box
+	Descent      int // Distance from baseline to the bottom of the line box (positive)
+	LineGap      int // Extra leading recommended between lines
+	DefaultWidth int // Advance used for runes without an explicit width
+
+	ascii [95]int // Advances for U+0020..U+007E
+	runes map[rune]int
+}
+
+// Advance returns the advance width of r in font units.
+func (m *Metrics) Advance(r rune) int {
+	if r >= 0x20 && r <= 0x7E {
+		if w := m.ascii[r-0x20]; w > 0 || r == ' ' {
+			return w
+		}
+	}
+	if w, ok := m.runes[r]; ok {
+		return w
+	}
+	if r == '\t' {
+		return m.Advance(' ') * 4
+	}
+	return m.DefaultWidth
+}
+
+// TextWidth returns the width of text set at sizePt points, in points.
+func (m *Metrics) TextWidth(text string, sizePt float64) float64 {
+	units := 0
+	for _, r := range text {
+		units += m.Advance(r)
+	}
+	return float64(units) * sizePt / float64(m.UnitsPerEm)
+}
+
+// LineHeight returns the default line height for a font size, in points.
+func (m *Metrics) LineHeight(sizePt float64) float64 {
+	return float64(m.Ascent+m.Descent+m.LineGap) * sizePt / float64(m.UnitsPerEm)
+}
+
+// scaled returns a copy of m whose advances are multiplied by factor.
+func (m *Metrics) scaled(factor float64) *Metrics {
+	out := *m
+	for i, w := range m.ascii {
+		out.ascii[i] = int(float64(w)*factor + 0.5)
+	}
+	out.DefaultWidth = int(float64(m.DefaultWidth)*factor + 0.5)
+	if len(m.runes) > 0 {
+		out.runes = make(map[rune]int, len(m.runes))
+		for r, w := range m.runes {
+			out.runes[r] = int(float64(w)*factor + 0.5)
+		}
+	}
+	return &out
+}
+
+// withVertical returns a copy of m with the given vertical metrics (per 1000 units).
+func (m *Metrics) withVertical(ascent, descent, lineGap int) *Metrics {
+	out := *m
+	out.Ascent = ascent * m.UnitsPerEm / 1000
+	out.Descent = descent * m.UnitsPerEm / 1000
+	out.LineGap = lineGap * m.UnitsPerEm / 1000
+	return &out
+}
+
+type registryKey struct {
+	family string
+	bold   bool
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[registryKey]*Metrics)
+)
+
+// Register makes metrics available for a font family. Registered fonts take
+// precedence over the built-in tables, which allows callers that embed fonts
+// to measure text accurately.
+func Register(family string, bold bool, m *Metrics) {
+	if m == nil || m.UnitsPerEm <= 0 {
+		return
+	}
+	registryMu.Lock()
+	registry[registryKey{family: normalizeFamily(family), bold: bold}] = m
+	registryMu.Unlock()
+}
+
+// Lookup returns metrics for a font family. Unknown families fall back to the
+// closest built-in face (sans-serif, serif or monospace) so the result is
+// never nil.
+func Lookup(family string, bold bool) *Metrics {
+	key := normalizeFamily(family)
+	if key == "" {
+		key = normalizeFamily(constants.DefaultFontName)
+	}
+
+	registryMu.RLock()
+	m, ok := registry[registryKey{family: key, bold: bold}]
+	if !ok && bold {
+		// A registered regular face is a better guess than a built-in one.
+		if regular, found := registry[registryKey{family: key}]; found {
+			m, ok = regular.scaled(boldFactor), true
+		}
+	}
+	registryMu.RUnlock()
+	if ok {
+		return m
+	}
+
+	return builtinFace(key, bold)
+}
+
+// MeasureTwips returns the width of text in twips for a run using the given
+// font family, size in half-points and weight.
+func MeasureTwips(text, family string, halfPoints int, bold bool) int {
+	if text == "" {
+		return 0
+	}
+	if halfPoints <= 0 {
+		halfPoints = constants.DefaultFontSize
+	}
+	points := Lookup(family, bold).TextWidth(text, float64(halfPoints)/2)
+	return int(points*constants.TwipsPerPoint + 0.5)
+}
+
+func normalizeFamily(family string) string {
+	return strings.ToLower(strings.TrimSpace(family))
+}
@@ -0,0 +1,163 @@
+package fontmetrics
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func TestLookupBuiltins(t *testing.T) {
+	arial := Lookup("Arial", false)
+	if got := arial.Advance('W'); got != 944 {
+		t.Errorf("Arial W advance = %d, want 944", got)
+	}
+	if got := Lookup("ARIAL ", false); got != arial {
+		t.Error("family lookup should be case and space insensitive")
+	}
+
+	if Lookup("Arial", true).Advance('b') <= arial.Advance('b') {
+		t.Error("bold face should be wider")
+	}
+
+	courier := Lookup("Courier New", false)
+	if courier.Advance('i') != courier.Advance('M') {
+		t.Error("monospace face should have uniform advances")
+	}
+
+	if Lookup("Some Unknown Mono", false) != courier {
+		t.Error("unknown monospace family should fall back to Courier metrics")
+	}
+	if Lookup("", false) != Lookup("Calibri", false) {
+		t.Error("empty family should use the default font")
+	}
+}
+
+func TestMeasureTwips(t *testing.T) {
+	// "Hello" in Arial: 722+556+222+222+556 = 2278 units; at 10pt that is
+	// 22.78pt, or 456 twips.
+	if got := MeasureTwips("Hello", "Arial", 20, false); got != 456 {
+		t.Errorf("MeasureTwips = %d, want 456", got)
+	}
+	if MeasureTwips("", "Arial", 20, false) != 0 {
+		t.Error("empty text should measure zero")
+	}
+	if MeasureTwips("Hello", "Calibri", 22, false) >= MeasureTwips("Hello", "Arial", 22, false) {
+		t.Error("Calibri should be narrower than Arial")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	custom := monospaceMetrics(1000, 800, 200, 0)
+	Register("Test Registered Font", false, custom)
+
+	if Lookup("test registered font", false) != custom {
+		t.Fatal("registered metrics should take precedence")
+	}
+	if got := Lookup("Test Registered Font", true).Advance('a'); got != 1050 {
+		t.Errorf("bold fallback of registered face = %d, want 1050", got)
+	}
+	if got := custom.LineHeight(10); got != 10 {
+		t.Errorf("LineHeight = %v, want 10", got)
+	}
+}
+
+// buildTestFont assembles a minimal TrueType font with three glyphs:
+// .notdef (500), 'A' (1200) and 'é' (900), at 2048 units per em.
+func buildTestFont() []byte {
+	be := binary.BigEndian
+
+	head := make([]byte, 54)
+	be.PutUint16(head[18:], 2048)
+
+	hhea := make([]byte, 36)
+	be.PutUint16(hhea[4:], 1900)
+	be.PutUint16(hhea[6:], uint16(0x10000-500)) // -500
+	be.PutUint16(hhea[34:], 3)
+
+	hmtx := make([]byte, 12)
+	be.PutUint16(hmtx[0:], 500)
+	be.PutUint16(hmtx[4:], 1200)
+	be.PutUint16(hmtx[8:], 900)
+
+	// cmap format 4 with segments [A..A] -> 1, [é..é] -> 2 and the 0xFFFF terminator.
+	segs := []struct{ start, end, delta uint16 }{
+		{'A', 'A', uint16(0x10000 + 1 - 'A')},
+		{0xE9, 0xE9, uint16(0x10000 + 2 - 0xE9)},
+		{0xFFFF, 0xFFFF, 1},
+	}
+	sub := make([]byte, 14+len(segs)*8+2)
+	be.PutUint16(sub[0:], 4)
+	be.PutUint16(sub[2:], uint16(len(sub)))
+	be.PutUint16(sub[6:], uint16(len(segs)*2))
+	for i, seg := range segs {
+		be.PutUint16(sub[14+i*2:], seg.end)
+		be.PutUint16(sub[14+len(segs)*2+2+i*2:], seg.start)
+		be.PutUint16(sub[14+len(segs)*4+2+i*2:], seg.delta)
+	}
+	cmap := make([]byte, 12+len(sub))
+	be.PutUint16(cmap[2:], 1)
+	be.PutUint16(cmap[4:], 3)
+	be.PutUint16(cmap[6:], 1)
+	be.PutUint32(cmap[8:], 12)
+	copy(cmap[12:], sub)
+
+	tables := []struct {
+		tag  string
+		data []byte
+	}{{"cmap", cmap}, {"head", head}, {"hhea", hhea}, {"hmtx", hmtx}}
+
+	offset := 12 + len(tables)*16
+	font := make([]byte, offset)
+	be.PutUint32(font[0:], 0x00010000)
+	be.PutUint16(font[4:], uint16(len(tables)))
+	for i, tbl := range tables {
+		rec := font[12+i*16:]
+		copy(rec, tbl.tag)
+		be.PutUint32(rec[8:], uint32(offset))
+		be.PutUint32(rec[12:], uint32(len(tbl.data)))
+		font = append(font, tbl.data...)
+		offset += len(tbl.data)
+	}
+	return font
+}
+
+func TestParseTrueType(t *testing.T) {
+	m, err := ParseTrueType(buildTestFont())
+	if err != nil {
+		t.Fatalf("ParseTrueType: %v", err)
+	}
+
+	if m.UnitsPerEm != 2048 || m.Ascent != 1900 || m.Descent != 500 {
+		t.Errorf("unexpected vertical metrics %+v", m)
+	}
+	if got := m.Advance('A'); got != 1200 {
+		t.Errorf("Advance('A') = %d, want 1200", got)
+	}
+	if got := m.Advance('é'); got != 900 {
+		t.Errorf("Advance('é') = %d, want 900", got)
+	}
+	if got := m.Advance('z'); got != 500 {
+		t.Errorf("unmapped rune should use the default width, got %d", got)
+	}
+
+	if _, err := ParseTrueType([]byte("not a font at all")); err == nil {
+		t.Error("expected error for invalid data")
+	}
+}
+
+func TestGlyphIndices(t *testing.T) {
+	glyphs, err := GlyphIndices(buildTestFont())
+	if err != nil {
+		t.Fatalf("GlyphIndices: %v", err)
+	}
+	if glyphs['A'] != 1 || glyphs['é'] != 2 || len(glyphs) != 2 {
+		t.Errorf("glyphs = %v", glyphs)
+	}
+
+	tables, err := Tables(buildTestFont())
+	if err != nil {
+		t.Fatalf("Tables: %v", err)
+	}
+	if len(tables) != 4 || len(tables["hmtx"]) != 12 {
+		t.Errorf("tables = %d, hmtx = %d bytes", len(tables), len(tables["hmtx"]))
+	}
+}
@@ -0,0 +1,281 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package fontmetrics
+
+import (
+	"encoding/binary"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+const opParseTrueType = "fontmetrics.ParseTrueType"
+
+// ParseTrueType extracts metrics from TrueType or OpenType (glyf or CFF) font
+// data. Only the head, hhea, hmtx and cmap tables are read.
+func ParseTrueType(data []byte) (*Metrics, error) {
+	tables, err := readTableDirectory(data)
+	if err != nil {
+		return nil, err
+	}
+
+	head, ok := tables["head"]
+	if !ok || len(head) < 20 {
+		return nil, errors.InvalidArgument(opParseTrueType, "data", len(data), "missing or truncated head table")
+	}
+	hhea, ok := tables["hhea"]
+	if !ok || len(hhea) < 36 {
+		return nil, errors.InvalidArgument(opParseTrueType, "data", len(data), "missing or truncated hhea table")
+	}
+	hmtx, ok := tables["hmtx"]
+	if !ok {
+		return nil, errors.InvalidArgument(opParseTrueType, "data", len(data), "missing hmtx table")
+	}
+	cmap, ok := tables["cmap"]
+	if !ok {
+		return nil, errors.InvalidArgument(opParseTrueType, "data", len(data), "missing cmap table")
+	}
+
+	m := &Metrics{
+		UnitsPerEm: int(binary.BigEndian.Uint16(head[18:])),
+		Ascent:     int(int16(binary.BigEndian.Uint16(hhea[4:]))),
+		Descent:    -int(int16(binary.BigEndian.Uint16(hhea[6:]))),
+		LineGap:    int(int16(binary.BigEndian.Uint16(hhea[8:]))),
+		runes:      make(map[rune]int),
+	}
+	if m.UnitsPerEm <= 0 {
+		return nil, errors.InvalidArgument(opParseTrueType, "unitsPerEm", m.UnitsPerEm, "invalid units per em")
+	}
+
+	numHMetrics := int(binary.BigEndian.Uint16(hhea[34:]))
+	if numHMetrics == 0 || len(hmtx) < numHMetrics*4 {
+		return nil, errors.InvalidArgument(opParseTrueType, "hmtx", len(hmtx), "truncated hmtx table")
+	}
+	advance := func(glyph int) int {
+		if glyph >= numHMetrics {
+			glyph = numHMetrics - 1
+		}
+		return int(binary.BigEndian.Uint16(hmtx[glyph*4:]))
+	}
+
+	mapping, err := parseCmap(cmap)
+	if err != nil {
+		return nil, err
+	}
+	for r, glyph := range mapping {
+		w := advance(glyph)
+		if r >= 0x20 && r <= 0x7E {
+			m.ascii[r-0x20] = w
+			continue
+		}
+		m.runes[r] = w
+	}
+
+	// The advance of ".notdef" is a poor default; prefer the digit zero or space.
+	m.DefaultWidth = advance(0)
+	if w := m.Advance('0'); w > 0 {
+		m.DefaultWidth = w
+	}
+
+	return m, nil
+}
+
+// Tables returns the tables of TrueType or OpenType font data keyed by
+// tag. The first font of a collection is used.
+func Tables(data []byte) (map[string][]byte, error) {
+	return readTableDirectory(data)
+}
+
+// GlyphIndices returns the mapping from Unicode code points to glyph
+// indices of TrueType or OpenType font data.
+func GlyphIndices(data []byte) (map[rune]int, error) {
+	tables, err := readTableDirectory(data)
+	if err != nil {
+		return nil, err
+	}
+	cmap, ok := tables["cmap"]
+	if !ok {
+		return nil, errors.InvalidArgument(opParseTrueType, "data", len(data), "missing cmap table")
+	}
+	return parseCmap(cmap)
+}
+
+func readTableDirectory(data []byte) (map[string][]byte, error) {
+	if len(data) < 12 {
+		return nil, errors.InvalidArgument(opParseTrueType, "data", len(data), "font data too short")
+	}
+
+	switch tag := string(data[:4]); tag {
+	case "\x00\x01\x00\x00", "OTTO", "true":
+	case "ttcf":
+		// Use the first font of a collection.
+		if len(data) < 16 {
+			return nil, errors.InvalidArgument(opParseTrueType, "data", len(data), "truncated font collection")
+		}
+		offset := int(binary.BigEndian.Uint32(data[12:]))
+		if offset <= 0 || offset+12 > len(data) {
+			return nil, errors.InvalidArgument(opParseTrueType, "data", offset, "invalid font collection offset")
+		}
+		return readTableDirectoryAt(data, offset)
+	default:
+		return nil, errors.InvalidArgument(opParseTrueType, "data", tag, "not a TrueType or OpenType font")
+	}
+
+	return readTableDirectoryAt(data, 0)
+}
+
+func readTableDirectoryAt(data []byte, base int) (map[string][]byte, error) {
+	numTables := int(binary.BigEndian.Uint16(data[base+4:]))
+	if base+12+numTables*16 > len(data) {
+		return nil, errors.InvalidArgument(opParseTrueType, "numTables", numTables, "truncated table directory")
+	}
+
+	tables := make(map[string][]byte, numTables)
+	for i := 0; i < numTables; i++ {
+		rec := data[base+12+i*16:]
+		tag := string(rec[:4])
+		offset := int(binary.BigEndian.Uint32(rec[8:]))
+		length := int(binary.BigEndian.Uint32(rec[12:]))
+		if offset < 0 || length < 0 || offset+length > len(data) {
+			return nil, errors.InvalidArgument(opParseTrueType, tag, offset, "table extends beyond font data")
+		}
+		tables[tag] = data[offset : offset+length]
+	}
+	return tables, nil
+}
+
+// parseCmap returns the Unicode to glyph mapping from the best cmap subtable.
+func parseCmap(cmap []byte) (map[rune]int, error) {
+	if len(cmap) < 4 {
+		return nil, errors.InvalidArgument(opParseTrueType, "cmap", len(cmap), "truncated cmap table")
+	}
+
+	numSubtables := int(binary.BigEndian.Uint16(cmap[2:]))
+	best, bestScore := -1, 0
+	for i := 0; i < numSubtables; i++ {
+		rec := 4 + i*8
+		if rec+8 > len(cmap) {
+			break
+		}
+		platform := binary.BigEndian.Uint16(cmap[rec:])
+		encoding := binary.BigEndian.Uint16(cmap[rec+2:])
+		offset := int(binary.BigEndian.Uint32(cmap[rec+4:]))
+
+		score := 0
+		switch {
+		case platform == 3 && encoding == 10, platform == 0 && encoding >= 4:
+			score = 3
+		case platform == 3 && encoding == 1, platform == 0:
+			score = 2
+		case platform == 3 && encoding == 0:
+			score = 1
+		}
+		if score > bestScore && offset+4 <= len(cmap) {
+			best, bestScore = offset, score
+		}
+	}
+	if best < 0 {
+		return nil, errors.InvalidArgument(opParseTrueType, "cmap", numSubtables, "no Unicode cmap subtable")
+	}
+
+	sub := cmap[best:]
+	switch format := binary.BigEndian.Uint16(sub); format {
+	case 4:
+		return parseCmapFormat4(sub)
+	case 12:
+		return parseCmapFormat12(sub)
+	default:
+		return nil, errors.Unsupported(opParseTrueType, "cmap subtable format")
+	}
+}
+
+func parseCmapFormat4(sub []byte) (map[rune]int, error) {
+	if len(sub) < 14 {
+		return nil, errors.InvalidArgument(opParseTrueType, "cmap", len(sub), "truncated format 4 subtable")
+	}
+	segCount := int(binary.BigEndian.Uint16(sub[6:])) / 2
+	endCodes := 14
+	startCodes := endCodes + segCount*2 + 2
+	idDeltas := startCodes + segCount*2
+	idRangeOffsets := idDeltas + segCount*2
+	if idRangeOffsets+segCount*2 > len(sub) {
+		return nil, errors.InvalidArgument(opParseTrueType, "cmap", segCount, "truncated format 4 segments")
+	}
+
+	mapping := make(map[rune]int)
+	for seg := 0; seg < segCount; seg++ {
+		end := int(binary.BigEndian.Uint16(sub[endCodes+seg*2:]))
+		start := int(binary.BigEndian.Uint16(sub[startCodes+seg*2:]))
+		delta := int(binary.BigEndian.Uint16(sub[idDeltas+seg*2:]))
+		rangeOffset := int(binary.BigEndian.Uint16(sub[idRangeOffsets+seg*2:]))
+		if start == 0xFFFF {
+			continue
+		}
+
+		for code := start; code <= end; code++ {
+			var glyph int
+			if rangeOffset == 0 {
+				glyph = (code + delta) & 0xFFFF
+			} else {
+				pos := idRangeOffsets + seg*2 + rangeOffset + (code-start)*2
+				if pos+2 > len(sub) {
+					break
+				}
+				glyph = int(binary.BigEndian.Uint16(sub[pos:]))
+				if glyph != 0 {
+					glyph = (glyph + delta) & 0xFFFF
+				}
+			}
+			if glyph != 0 {
+				mapping[rune(code)] = glyph
+			}
+		}
+	}
+	return mapping, nil
+}
+
+func parseCmapFormat12(sub []byte) (map[rune]int, error) {
+	if len(sub) < 16 {
+		return nil, errors.InvalidArgument(opParseTrueType, "cmap", len(sub), "truncated format 12 subtable")
+	}
+	numGroups := int(binary.BigEndian.Uint32(sub[12:]))
+	if 16+numGroups*12 > len(sub) {
+		return nil, errors.InvalidArgument(opParseTrueType, "cmap", numGroups, "truncated format 12 groups")
+	}
+
+	mapping := make(map[rune]int)
+	for g := 0; g < numGroups; g++ {
+		rec := sub[16+g*12:]
+		start := binary.BigEndian.Uint32(rec)
+		end := binary.BigEndian.Uint32(rec[4:])
+		glyph := int(binary.BigEndian.Uint32(rec[8:]))
+		if end < start || end > 0x10FFFF {
+			continue
+		}
+		for code := start; code <= end; code++ {
+			mapping[rune(code)] = glyph + int(code-start)
+		}
+	}
+	return mapping, nil
+}
@@ -0,0 +1,211 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+// canvas collects the content stream of a page. Coordinates are points
+// from the top-left corner of the page; they are flipped when written.
+type canvas struct {
+	buf    bytes.Buffer
+	height float64
+	number int // Page number, for PAGE fields
+	total  int // Page count, for NUMPAGES fields
+	fonts  map[*font]bool
+	images map[*pdfImage]bool
+	links  []link
+	source *imageSet
+	raster *raster // Also draws the page as an image, when set
+}
+
+// link is a URI annotation, in PDF coordinates.
+type link struct {
+	x0, y0, x1, y1 float64
+	url            string
+}
+
+func newCanvas(height float64, number, total int, source *imageSet) *canvas {
+	return &canvas{height: height, number: number, total: total, source: source,
+		fonts: map[*font]bool{}, images: map[*pdfImage]bool{}}
+}
+
+func (c *canvas) op(format string, args ...interface{}) {
+	fmt.Fprintf(&c.buf, format, args...)
+	c.buf.WriteByte('\n')
+}
+
+func colorOperands(col domain.Color) string {
+	return num(float64(col.R)/255) + " " + num(float64(col.G)/255) + " " + num(float64(col.B)/255)
+}
+
+// fillRect fills a rectangle whose top-left corner is x, y.
+func (c *canvas) fillRect(x, y, w, h float64, col domain.Color) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	c.op("%s rg %s %s %s %s re f", colorOperands(col), num(x), num(c.height-y-h), num(w), num(h))
+	if c.raster != nil {
+		c.raster.fillRect(x, y, w, h, col, 1)
+	}
+}
+
+// line strokes a line of width points.
+func (c *canvas) line(x1, y1, x2, y2, width float64, col domain.Color, dash string) {
+	if dash == "" {
+		dash = "[] 0"
+	}
+	c.op("%s RG %s w %s d %s %s m %s %s l S", colorOperands(col), num(width), dash,
+		num(x1), num(c.height-y1), num(x2), num(c.height-y2))
+	if c.raster != nil {
+		c.raster.line(x1, y1, x2, y2, width, col)
+	}
+}
+
+// border strokes a border between two points. Double and triple borders
+// draw parallel lines on the side given by the normal nx, ny.
+func (c *canvas) border(b domain.BorderStyle, x1, y1, x2, y2, nx, ny float64) {
+	if b.Style == domain.BorderNone {
+		return
+	}
+	width := float64(b.Width) / 8
+	if width <= 0 {
+		width = 0.5
+	}
+	var dash string
+	lines := 1
+	switch b.Style {
+	case domain.BorderDotted:
+		dash = "[" + num(width) + " " + num(width*2) + "] 0"
+	case domain.BorderDashed:
+		dash = "[" + num(width*4) + " " + num(width*3) + "] 0"
+	case domain.BorderDouble:
+		lines = 2
+	case domain.BorderTriple:
+		lines = 3
+	case domain.BorderThick:
+		width = math.Max(width, 1.5)
+	}
+	for i := 0; i < lines; i++ {
+		d := float64(i) * width * 2
+		c.line(x1+nx*d, y1+ny*d, x2+nx*d, y2+ny*d, width, b.Color, dash)
+	}
+}
+
+// text shows s with its baseline at y.
+func (c *canvas) text(f *font, size, x, y float64, col domain.Color, s string) {
+	if s == "" {
+		return
+	}
+	c.fonts[f] = true
+	c.op("BT %s rg /%s %s Tf %s %s Td %s Tj ET", colorOperands(col), f.resource, num(size),
+		num(x), num(c.height-y), f.encode(s))
+	if c.raster != nil {
+		c.raster.text(f, size, x, y, col, s)
+	}
+}
+
+// image draws img in the box whose top-left corner is x, y, rotated by
+// degrees clockwise about its center and flipped. Cropped edges are
+// clipped away.
+func (c *canvas) image(img *pdfImage, x, y, w, h, degrees float64, flipH, flipV bool, crop domain.ImageCrop) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	c.images[img] = true
+	cx, cy := x+w/2, c.height-y-h/2
+	c.op("q 1 0 0 1 %s %s cm", num(cx), num(cy))
+	if degrees != 0 {
+		rad := -degrees * math.Pi / 180
+		cos, sin := math.Cos(rad), math.Sin(rad)
+		c.op("%s %s %s %s 0 0 cm", num(cos), num(sin), num(-sin), num(cos))
+	}
+	sx, sy := 1.0, 1.0
+	if flipH {
+		sx = -1
+	}
+	if flipV {
+		sy = -1
+	}
+	if sx != 1 || sy != 1 {
+		c.op("%s 0 0 %s 0 0 cm", num(sx), num(sy))
+	}
+	c.op("%s %s %s %s re W n", num(-w/2), num(-h/2), num(w), num(h))
+
+	// The visible part of the picture fills the box; the cropped edges
+	// fall outside the clip.
+	visibleW := math.Max(1-(crop.Left+crop.Right)/100, 0.01)
+	visibleH := math.Max(1-(crop.Top+crop.Bottom)/100, 0.01)
+	fullW, fullH := w/visibleW, h/visibleH
+	left := -w/2 - fullW*crop.Left/100
+	bottom := -h/2 - fullH*crop.Bottom/100
+	c.op("%s 0 0 %s %s %s cm /%s Do Q", num(fullW), num(fullH), num(left), num(bottom), img.resource)
+}
+
+// drawImage draws a document image with its crop, rotation, flip and
+// border, or a placeholder when its format cannot be shown.
+func (c *canvas) drawImage(img domain.Image, x, y, w, h float64) {
+	converted := c.source.get(img)
+	if converted == nil {
+		c.placeholder(x, y, w, h)
+		return
+	}
+	flipH, flipV := img.Flip()
+	c.image(converted, x, y, w, h, img.Rotation(), flipH, flipV, img.Crop())
+	if c.raster != nil {
+		if pixels := c.source.decoded(img); pixels != nil {
+			c.raster.image(pixels, x, y, w, h, img.Rotation(), flipH, flipV, img.Crop())
+		}
+	}
+	if border := img.Border(); border.Width > 0 {
+		c.op("%s RG %s w [] 0 d %s %s %s %s re S", colorOperands(border.Color), num(border.Width),
+			num(x), num(c.height-y-h), num(w), num(h))
+		if c.raster != nil {
+			c.raster.outline(x, y, w, h, border.Width, border.Color)
+		}
+	}
+}
+
+// placeholder outlines the box of an image that cannot be shown.
+func (c *canvas) placeholder(x, y, w, h float64) {
+	gray := domain.Color{R: 160, G: 160, B: 160}
+	c.op("%s RG 0.5 w [] 0 d %s %s %s %s re S", colorOperands(gray), num(x), num(c.height-y-h), num(w), num(h))
+	if c.raster != nil {
+		c.raster.outline(x, y, w, h, 0.5, gray)
+	}
+}
+
+// link adds a link annotation over a box.
+func (c *canvas) link(x, y, w, h float64, url string) {
+	if url == "" || w <= 0 || h <= 0 {
+		return
+	}
+	c.links = append(c.links, link{x0: x, y0: c.height - y - h, x1: x + w, y1: c.height - y, url: url})
+}
@@ -0,0 +1,432 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+	"github.com/mmonterroca/docxgo/v2/pkg/fontmetrics"
+)
+
+// standardFamilies are the base-14 faces used for fonts that are not
+// embedded, by regular, bold, italic and bold italic.
+var standardFamilies = map[string][4]string{
+	"sans":  {"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Helvetica-BoldOblique"},
+	"serif": {"Times-Roman", "Times-Bold", "Times-Italic", "Times-BoldItalic"},
+	"mono":  {"Courier", "Courier-Bold", "Courier-Oblique", "Courier-BoldOblique"},
+}
+
+// standardMetrics names the fontmetrics faces matching standardFamilies.
+var standardMetrics = map[string]string{"sans": "helvetica", "serif": "times new roman", "mono": "courier new"}
+
+// winAnsiSpecial maps the characters of WinAnsiEncoding 0x80-0x9F.
+var winAnsiSpecial = map[rune]byte{
+	'€': 0x80, '‚': 0x82, 'ƒ': 0x83, '„': 0x84, '…': 0x85, '†': 0x86, '‡': 0x87,
+	'ˆ': 0x88, '‰': 0x89, 'Š': 0x8A, '‹': 0x8B, 'Œ': 0x8C, 'Ž': 0x8E, '‘': 0x91,
+	'’': 0x92, '“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '˜': 0x98,
+	'™': 0x99, 'š': 0x9A, '›': 0x9B, 'œ': 0x9C, 'ž': 0x9E, 'Ÿ': 0x9F,
+}
+
+// latin1Base approximates the advance of Latin-1 letters U+00C0-U+00FF by
+// an ASCII letter of similar width.
+const latin1Base = "AAAAAAACEEEEIIIIDNOOOOOxOUUUUYPsaaaaaaaceeeeiiiidnooooo/ouuuuypy"
+
+// widthSubstitutes measures punctuation without built-in widths.
+var widthSubstitutes = map[rune]string{
+	'‘': "'", '’': "'", '‚': ",", '“': "\"", '”': "\"", '„': "\"",
+	'–': "n", '—': "M", '…': "...", '•': "-", '€': "0", ' ': " ",
+}
+
+// font is a face used on the pages, either embedded TrueType or one of
+// the standard PDF fonts.
+type font struct {
+	resource string // Resource name, e.g. F1
+	standard string // Base-14 name when not embedded
+	metrics  *fontmetrics.Metrics
+	tt       *trueType
+	used     map[int]rune // Embedded glyphs in use, with their text
+
+	bold, italic bool // Requested style, for fallback faces
+}
+
+// advance returns the advance of r in 1/1000 em.
+func (f *font) advance(r rune) float64 {
+	if f.tt != nil {
+		return f.tt.advance(f.tt.glyph(r))
+	}
+	scale := 1000 / float64(f.metrics.UnitsPerEm)
+	switch {
+	case r >= 0xC0 && r <= 0xFF:
+		r = rune(latin1Base[r-0xC0])
+	case widthSubstitutes[r] != "":
+		total := 0.0
+		for _, s := range widthSubstitutes[r] {
+			total += float64(f.metrics.Advance(s))
+		}
+		return total * scale
+	}
+	return float64(f.metrics.Advance(r)) * scale
+}
+
+// width returns the width of text at size points.
+func (f *font) width(text string, size float64) float64 {
+	total := 0.0
+	for _, r := range text {
+		total += f.advance(r)
+	}
+	return total * size / 1000
+}
+
+// ascent returns the height above the baseline at size points.
+func (f *font) ascent(size float64) float64 {
+	if f.tt != nil {
+		return float64(f.tt.ascent) * size / float64(f.tt.unitsPerEm)
+	}
+	return float64(f.metrics.Ascent) * size / float64(f.metrics.UnitsPerEm)
+}
+
+// descent returns the depth below the baseline at size points, positive.
+func (f *font) descent(size float64) float64 {
+	if f.tt != nil {
+		return float64(-f.tt.descent) * size / float64(f.tt.unitsPerEm)
+	}
+	return float64(f.metrics.Descent) * size / float64(f.metrics.UnitsPerEm)
+}
+
+// lineGap returns the recommended extra leading at size points.
+func (f *font) lineGap(size float64) float64 {
+	if f.tt != nil {
+		return float64(f.tt.lineGap) * size / float64(f.tt.unitsPerEm)
+	}
+	return float64(f.metrics.LineGap) * size / float64(f.metrics.UnitsPerEm)
+}
+
+// has reports whether the font can show r.
+func (f *font) has(r rune) bool {
+	if f.tt != nil {
+		return f.tt.glyph(r) != 0
+	}
+	_, ok := winAnsi(r)
+	return ok
+}
+
+// encode returns text as a string operand for Tj. Characters the font
+// cannot show become .notdef, or '?' in standard fonts.
+func (f *font) encode(text string) string {
+	if f.tt == nil {
+		b := make([]byte, 0, len(text))
+		for _, r := range text {
+			c, ok := winAnsi(r)
+			if !ok {
+				c = '?'
+			}
+			b = append(b, c)
+		}
+		return literal(b)
+	}
+
+	var b strings.Builder
+	b.WriteByte('<')
+	for _, r := range text {
+		g := f.tt.glyph(r)
+		if _, seen := f.used[g]; !seen || f.used[g] == 0 {
+			f.used[g] = r
+		}
+		fmt.Fprintf(&b, "%04X", g)
+	}
+	b.WriteByte('>')
+	return b.String()
+}
+
+// winAnsi returns the WinAnsiEncoding byte for r.
+func winAnsi(r rune) (byte, bool) {
+	switch {
+	case r == ' ':
+		return ' ', true
+	case r >= 0x20 && r < 0x7F, r >= 0xA0 && r <= 0xFF:
+		return byte(r), true
+	}
+	c, ok := winAnsiSpecial[r]
+	return c, ok
+}
+
+// fontKey identifies a requested face.
+type fontKey struct {
+	family       string
+	bold, italic bool
+}
+
+// fontSet resolves the fonts of a document to embedded or standard faces.
+type fontSet struct {
+	available     []*trueType
+	defaultFamily string
+	fonts         map[fontKey]*font
+	ordered       []*font
+
+	// measure measures the fonts that are not available with their own
+	// metrics rather than those of the standard font drawn in their place.
+	measure bool
+}
+
+// newFontSet loads the TrueType fonts named by the options.
+func newFontSet(opts Options) (*fontSet, error) {
+	const op = "pdf.Render"
+	s := &fontSet{defaultFamily: opts.DefaultFont, fonts: map[fontKey]*font{}}
+	for i, data := range opts.Fonts {
+		tt, err := parseTrueType(data)
+		if err != nil {
+			return nil, errors.WrapWithContext(err, op, map[string]interface{}{"font": i})
+		}
+		s.available = append(s.available, tt)
+	}
+	for _, dir := range opts.FontDirs {
+		err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".ttf", ".ttc":
+			default:
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			// Fonts that cannot be embedded, such as CFF outlines, are skipped.
+			if tt, err := parseTrueType(data); err == nil {
+				s.available = append(s.available, tt)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, errors.WrapWithCode(err, errors.ErrCodeIO, op)
+		}
+	}
+	return s, nil
+}
+
+// get returns the face for a family and style: an embedded font of that
+// family, else of the default family, else a standard font.
+func (s *fontSet) get(family string, bold, italic bool) *font {
+	key := fontKey{strings.ToLower(strings.TrimSpace(family)), bold, italic}
+	if f, ok := s.fonts[key]; ok {
+		return f
+	}
+
+	f := &font{resource: fmt.Sprintf("F%d", len(s.ordered)+1), bold: bold, italic: italic}
+	tt := s.find(key.family, bold, italic)
+	if tt == nil && s.defaultFamily != "" {
+		tt = s.find(strings.ToLower(s.defaultFamily), bold, italic)
+	}
+	if tt != nil {
+		f.tt, f.used = tt, map[int]rune{}
+	} else {
+		class := fontClass(key.family)
+		style := 0
+		if bold {
+			style |= 1
+		}
+		if italic {
+			style |= 2
+		}
+		f.standard = standardFamilies[class][style]
+		f.metrics = fontmetrics.Lookup(standardMetrics[class], bold)
+		if s.measure {
+			f.metrics = fontmetrics.Lookup(key.family, bold)
+		}
+	}
+
+	// Faces that resolve alike share one PDF font.
+	for _, existing := range s.ordered {
+		if existing.tt == f.tt && existing.standard == f.standard && existing.metrics == f.metrics {
+			s.fonts[key] = existing
+			return existing
+		}
+	}
+	s.fonts[key] = f
+	s.ordered = append(s.ordered, f)
+	return f
+}
+
+// fallback returns a face of the given style that can show r, preferring
+// the default family, or nil when no available font has it.
+func (s *fontSet) fallback(r rune, bold, italic bool) *font {
+	families := make([]string, 0, len(s.available)+1)
+	if s.defaultFamily != "" {
+		families = append(families, s.defaultFamily)
+	}
+	for _, tt := range s.available {
+		if tt.glyph(r) != 0 {
+			families = append(families, tt.family)
+		}
+	}
+	for _, family := range families {
+		if f := s.get(family, bold, italic); f.has(r) {
+			return f
+		}
+	}
+	return nil
+}
+
+// find returns the available font of family closest to the style.
+func (s *fontSet) find(family string, bold, italic bool) *trueType {
+	var best *trueType
+	bestScore := -1
+	for _, tt := range s.available {
+		if strings.ToLower(tt.family) != family {
+			continue
+		}
+		score := 0
+		if tt.bold == bold {
+			score += 2
+		}
+		if tt.italic == italic {
+			score++
+		}
+		if score > bestScore {
+			best, bestScore = tt, score
+		}
+	}
+	return best
+}
+
+// fontClass picks the standard family closest to a font family.
+func fontClass(family string) string {
+	switch {
+	case strings.Contains(family, "mono"), strings.Contains(family, "courier"),
+		strings.Contains(family, "consol"), strings.Contains(family, "code"):
+		return "mono"
+	case strings.Contains(family, "serif") && !strings.Contains(family, "sans"),
+		strings.Contains(family, "times"), strings.Contains(family, "roman"),
+		strings.Contains(family, "cambria"), strings.Contains(family, "georgia"),
+		strings.Contains(family, "garamond"), strings.Contains(family, "palatino"),
+		strings.Contains(family, "book antiqua"):
+		return "serif"
+	}
+	return "sans"
+}
+
+// write adds the font objects and returns their numbers.
+func (s *fontSet) write(w *objectWriter) map[*font]int {
+	ids := map[*font]int{}
+	for _, f := range s.ordered {
+		if f.tt == nil {
+			ids[f] = w.add(fmt.Sprintf("<< /Type /Font /Subtype /Type1 /BaseFont %s /Encoding /WinAnsiEncoding >>", name(f.standard)))
+			continue
+		}
+		ids[f] = f.writeTrueType(w)
+	}
+	return ids
+}
+
+// writeTrueType embeds a subset of the font as a CIDFontType2 font with
+// Identity-H encoding and a ToUnicode map for text extraction.
+func (f *font) writeTrueType(w *objectWriter) int {
+	tt := f.tt
+	glyphs := make([]int, 0, len(f.used))
+	used := map[int]bool{}
+	for g := range f.used {
+		glyphs = append(glyphs, g)
+		used[g] = true
+	}
+	sort.Ints(glyphs)
+
+	h := fnv.New32a()
+	fmt.Fprint(h, tt.postscriptName, glyphs)
+	tag := make([]byte, 6)
+	for i, v := 0, h.Sum32(); i < 6; i, v = i+1, v/26 {
+		tag[i] = byte('A' + v%26)
+	}
+	base := name(string(tag) + "+" + tt.postscriptName)
+
+	data := tt.subset(used)
+	file := w.addStream(fmt.Sprintf("/Length1 %d", len(data)), data)
+
+	flags := 32 // Nonsymbolic
+	if tt.fixedPitch {
+		flags |= 1
+	}
+	if tt.italic {
+		flags |= 64
+	}
+	stemV := 80
+	if tt.bold {
+		stemV = 140
+	}
+	descriptor := w.add(fmt.Sprintf("<< /Type /FontDescriptor /FontName %s /Flags %d /FontBBox [%d %d %d %d] "+
+		"/ItalicAngle %s /Ascent %d /Descent %d /CapHeight %d /StemV %d /FontFile2 %s >>",
+		base, flags, tt.scale(tt.bbox[0]), tt.scale(tt.bbox[1]), tt.scale(tt.bbox[2]), tt.scale(tt.bbox[3]),
+		num(tt.italicAngle), tt.scale(tt.ascent), tt.scale(tt.descent), tt.scale(tt.capHeight), stemV, ref(file)))
+
+	var widths strings.Builder
+	for _, g := range glyphs {
+		fmt.Fprintf(&widths, "%d [%s] ", g, num(tt.advance(g)))
+	}
+	cid := w.add(fmt.Sprintf("<< /Type /Font /Subtype /CIDFontType2 /BaseFont %s "+
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (Identity) /Supplement 0 >> "+
+		"/FontDescriptor %s /W [%s] /CIDToGIDMap /Identity >>", base, ref(descriptor), widths.String()))
+
+	toUnicode := w.addStream("", toUnicodeCMap(glyphs, f.used))
+	return w.add(fmt.Sprintf("<< /Type /Font /Subtype /Type0 /BaseFont %s /Encoding /Identity-H "+
+		"/DescendantFonts [%s] /ToUnicode %s >>", base, ref(cid), ref(toUnicode)))
+}
+
+// toUnicodeCMap maps glyph indices back to text.
+func toUnicodeCMap(glyphs []int, text map[int]rune) []byte {
+	var b strings.Builder
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n" +
+		"/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n" +
+		"/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n" +
+		"1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	var mapped []int
+	for _, g := range glyphs {
+		if text[g] != 0 {
+			mapped = append(mapped, g)
+		}
+	}
+	for start := 0; start < len(mapped); start += 100 {
+		chunk := mapped[start:min(start+100, len(mapped))]
+		fmt.Fprintf(&b, "%d beginbfchar\n", len(chunk))
+		for _, g := range chunk {
+			fmt.Fprintf(&b, "<%04X> <", g)
+			for _, u := range utf16.Encode([]rune{text[g]}) {
+				fmt.Fprintf(&b, "%04X", u)
+			}
+			b.WriteString(">\n")
+		}
+		b.WriteString("endbfchar\n")
+	}
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return []byte(b.String())
+}
@@ -0,0 +1,200 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Registers the GIF decoder
+	"image/jpeg"
+	_ "image/png" // Registers the PNG decoder
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/svg"
+	"github.com/mmonterroca/docxgo/v2/internal/webp"
+)
+
+// maxSVGPixels bounds the longer side of rasterized SVG images.
+const maxSVGPixels = 2048
+
+// pdfImage is an image XObject.
+type pdfImage struct {
+	resource      string
+	width, height int
+	colorSpace    string
+	decode        string // Decode array, for inverted CMYK JPEGs
+	data          []byte
+	jpeg          bool   // data is a JPEG file, embedded as is
+	alpha         []byte // Soft mask samples, nil when opaque
+}
+
+// imageSet converts the images of a document once each.
+type imageSet struct {
+	images  map[domain.Image]*pdfImage
+	ordered []*pdfImage
+	pixels  map[domain.Image]image.Image // Decoded, for page images
+}
+
+// get returns the XObject for img, or nil for formats that cannot be
+// shown, such as EMF, WMF, BMP and TIFF.
+func (s *imageSet) get(img domain.Image) *pdfImage {
+	if cached, ok := s.images[img]; ok {
+		return cached
+	}
+	converted := convertImage(img)
+	if converted != nil {
+		converted.resource = fmt.Sprintf("Im%d", len(s.ordered)+1)
+		s.ordered = append(s.ordered, converted)
+	}
+	if s.images == nil {
+		s.images = map[domain.Image]*pdfImage{}
+	}
+	s.images[img] = converted
+	return converted
+}
+
+// decoded returns the pixels of img, or nil for formats that cannot be
+// shown.
+func (s *imageSet) decoded(img domain.Image) image.Image {
+	if cached, ok := s.pixels[img]; ok {
+		return cached
+	}
+	if s.pixels == nil {
+		s.pixels = map[domain.Image]image.Image{}
+	}
+	s.pixels[img] = decodeImage(img)
+	return s.pixels[img]
+}
+
+// convertImage converts the data of img by its content rather than its
+// declared format. JPEG files are embedded as they are.
+func convertImage(img domain.Image) *pdfImage {
+	data := img.Data()
+	if bytes.HasPrefix(data, []byte{0xFF, 0xD8}) {
+		cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+		if err != nil {
+			return nil
+		}
+		out := &pdfImage{width: cfg.Width, height: cfg.Height, data: data, jpeg: true, colorSpace: "/DeviceRGB"}
+		switch cfg.ColorModel {
+		case color.GrayModel:
+			out.colorSpace = "/DeviceGray"
+		case color.CMYKModel:
+			// Adobe applications write CMYK JPEGs inverted.
+			out.colorSpace, out.decode = "/DeviceCMYK", "[1 0 1 0 1 0 1 0]"
+		}
+		return out
+	}
+	decoded := decodeImage(img)
+	if decoded == nil {
+		return nil
+	}
+	return rasterImage(decoded)
+}
+
+// decodeImage decodes the data of img by its content. SVG images are
+// rasterized at twice their size.
+func decodeImage(img domain.Image) image.Image {
+	data := img.Data()
+	switch {
+	case len(data) == 0:
+		return nil
+	case webp.IsWebP(data):
+		decoded, err := webp.Decode(data)
+		if err != nil {
+			return nil
+		}
+		return decoded
+	case svg.IsSVG(data):
+		size := img.Size()
+		w, h := size.WidthPx*2, size.HeightPx*2
+		if w <= 0 || h <= 0 {
+			sw, sh, err := svg.Size(data)
+			if err != nil {
+				return nil
+			}
+			w, h = int(sw*2), int(sh*2)
+		}
+		if longer := max(w, h); longer > maxSVGPixels {
+			w, h = w*maxSVGPixels/longer, h*maxSVGPixels/longer
+		}
+		decoded, err := svg.Rasterize(data, max(w, 1), max(h, 1))
+		if err != nil {
+			return nil
+		}
+		return decoded
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil
+	}
+	return decoded
+}
+
+// rasterImage converts decoded pixels to RGB samples and, when some are
+// transparent, a soft mask.
+func rasterImage(img image.Image) *pdfImage {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= 0 || h <= 0 {
+		return nil
+	}
+	rgb := make([]byte, 0, w*h*3)
+	alpha := make([]byte, 0, w*h)
+	opaque := true
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA)
+			rgb = append(rgb, c.R, c.G, c.B)
+			alpha = append(alpha, c.A)
+			opaque = opaque && c.A == 0xFF
+		}
+	}
+	out := &pdfImage{width: w, height: h, colorSpace: "/DeviceRGB", data: rgb}
+	if !opaque {
+		out.alpha = alpha
+	}
+	return out
+}
+
+// write adds the image, and its soft mask, and returns its number.
+func (img *pdfImage) write(out *objectWriter) int {
+	dict := fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8",
+		img.width, img.height, img.colorSpace)
+	if img.decode != "" {
+		dict += " /Decode " + img.decode
+	}
+	if img.alpha != nil {
+		mask := out.addStream(fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace /DeviceGray /BitsPerComponent 8",
+			img.width, img.height), img.alpha)
+		dict += " /SMask " + ref(mask)
+	}
+	if img.jpeg {
+		return out.addRawStream(dict+" /Filter /DCTDecode", img.data)
+	}
+	return out.addStream(dict, img.data)
+}
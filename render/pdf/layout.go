@@ -0,0 +1,503 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+// columnGap separates text columns, as written by Section.SetColumns.
+const columnGap = 36.0
+
+// band is a horizontal slice of content that is never split across
+// columns: a line of a paragraph or a group of table rows.
+type band struct {
+	height      float64
+	draw        func(c *canvas, x, y, width float64)
+	pageBreak   bool               // Starts on a new page
+	columnBreak bool               // Starts in a new column
+	keepNext    bool               // Stays in the column of the next band
+	paragraphs  []domain.Paragraph // Paragraphs that start in the band
+}
+
+// placement is a band at its position on a page.
+type placement struct {
+	band        band
+	x, y, width float64
+	section     int
+}
+
+// page is a laid out page.
+type page struct {
+	width, height float64
+	geometry      *geometry
+	number        int
+	first         bool // First page of its section
+	placed        []placement
+}
+
+// geometry is the page setup of a section, in points.
+type geometry struct {
+	width, height            float64
+	top, right, bottom, left float64
+	headerDist, footerDist   float64
+	columns                  int
+	columnWidth              float64
+	headers                  map[domain.HeaderType]domain.Header
+	footers                  map[domain.FooterType]domain.Footer
+}
+
+// renderer lays out a document.
+type renderer struct {
+	doc         domain.Document
+	fonts       *fontSet
+	images      imageSet
+	theme       domain.DocumentTheme
+	styles      map[string][]domain.ParagraphStyle
+	lists       listCounter
+	notes       []domain.Footnote
+	noteNumbers map[domain.Footnote]int
+	sections    []PageRange // Pages of each section, set by layout
+
+	// Page number and count used to measure PAGE and NUMPAGES fields.
+	pageNumber, pageCount int
+
+	// Pixels per point of the page images drawn, or 0.
+	pixelScale float64
+}
+
+func newRenderer(doc domain.Document, fonts *fontSet) *renderer {
+	return &renderer{
+		doc:         doc,
+		fonts:       fonts,
+		theme:       doc.Theme(),
+		styles:      map[string][]domain.ParagraphStyle{},
+		lists:       listCounter{},
+		noteNumbers: map[domain.Footnote]int{},
+	}
+}
+
+// sectionPart is the body of one section and how it starts.
+type sectionPart struct {
+	section domain.Section
+	blocks  []domain.Block
+	start   domain.SectionBreakType
+}
+
+// layout places the body of every section on pages. Footnotes are set
+// after the body of the last section.
+func (r *renderer) layout() []*page {
+	var parts []sectionPart
+	cur := sectionPart{start: domain.SectionBreakTypeNextPage}
+	for _, block := range r.doc.Blocks() {
+		if block.SectionBreak != nil {
+			cur.section = block.SectionBreak.Section
+			parts = append(parts, cur)
+			cur = sectionPart{start: block.SectionBreak.Type}
+			continue
+		}
+		cur.blocks = append(cur.blocks, block)
+	}
+	if sections := r.doc.Sections(); len(sections) > 0 {
+		cur.section = sections[len(sections)-1]
+	}
+	parts = append(parts, cur)
+
+	f := &flow{r: r}
+	var prev *geometry
+	r.sections = nil
+	for i, part := range parts {
+		g := newGeometry(part.section, prev)
+		f.startSection(g, part.start, i == 0)
+		f.section = i
+		first := f.page.number
+		bands := r.blockBands(part.blocks, g.columnWidth)
+		if i == len(parts)-1 {
+			bands = append(bands, r.noteBands(g.columnWidth)...)
+		}
+		for j, b := range bands {
+			f.place(b, keptHeight(bands[j:]))
+		}
+		r.sections = append(r.sections, PageRange{First: first, Last: f.page.number})
+		prev = g
+	}
+	r.pageCount = len(f.pages)
+	return f.pages
+}
+
+// newGeometry reads the page setup of a section with the defaults of the
+// section serializer. Sections without headers or footers inherit those
+// of the previous section, as in Word.
+func newGeometry(section domain.Section, prev *geometry) *geometry {
+	size := domain.PageSizeLetter
+	margins := domain.DefaultMargins
+	columns := 1
+	g := &geometry{}
+	if section != nil {
+		if s := section.PageSize(); s.Width > 0 && s.Height > 0 {
+			size = s
+		}
+		if m := section.Margins(); m != (domain.Margins{}) {
+			margins = m
+		}
+		if section.Orientation() == domain.OrientationLandscape && size.Width < size.Height {
+			size.Width, size.Height = size.Height, size.Width
+		}
+		columns = max(section.Columns(), 1)
+		if sec, ok := section.(interface {
+			HeadersAll() map[domain.HeaderType]domain.Header
+		}); ok {
+			g.headers = sec.HeadersAll()
+		}
+		if sec, ok := section.(interface {
+			FootersAll() map[domain.FooterType]domain.Footer
+		}); ok {
+			g.footers = sec.FootersAll()
+		}
+	}
+	if prev != nil && len(g.headers) == 0 {
+		g.headers = prev.headers
+	}
+	if prev != nil && len(g.footers) == 0 {
+		g.footers = prev.footers
+	}
+
+	g.width, g.height = twips(size.Width), twips(size.Height)
+	g.top, g.right, g.bottom, g.left = twips(margins.Top), twips(margins.Right), twips(margins.Bottom), twips(margins.Left)
+	g.headerDist, g.footerDist = twips(margins.Header), twips(margins.Footer)
+	g.columns = columns
+	g.columnWidth = max((g.width-g.left-g.right-float64(columns-1)*columnGap)/float64(columns), 36)
+	return g
+}
+
+// headerFooter returns the paragraphs of the header, or footer, of a
+// page: the first page header on the first page of a section, the even
+// page header on even pages, and the default header otherwise.
+func (g *geometry) headerFooter(pg *page, header bool) []domain.Paragraph {
+	kind := domain.HeaderDefault
+	switch {
+	case pg.first && g.has(domain.HeaderFirst, header):
+		kind = domain.HeaderFirst
+	case pg.number%2 == 0 && g.has(domain.HeaderEven, header):
+		kind = domain.HeaderEven
+	}
+	if header {
+		if h := g.headers[kind]; h != nil {
+			return h.Paragraphs()
+		}
+		return nil
+	}
+	if f := g.footers[domain.FooterType(kind)]; f != nil {
+		return f.Paragraphs()
+	}
+	return nil
+}
+
+func (g *geometry) has(kind domain.HeaderType, header bool) bool {
+	if header {
+		return g.headers[kind] != nil
+	}
+	return g.footers[domain.FooterType(kind)] != nil
+}
+
+// blockBands lays out body blocks in a column of width points.
+func (r *renderer) blockBands(blocks []domain.Block, width float64) []band {
+	var bands []band
+	for _, block := range blocks {
+		switch {
+		case block.Paragraph != nil:
+			bands = append(bands, r.paragraphBands(block.Paragraph, width, "")...)
+		case block.Table != nil:
+			bands = append(bands, r.tableBands(block.Table, width)...)
+		}
+	}
+	return bands
+}
+
+// partBands lays out a header or footer. Lists in it are numbered on
+// their own, since it is laid out again for every page.
+func (r *renderer) partBands(paras []domain.Paragraph, width float64) ([]band, float64) {
+	saved := r.lists
+	r.lists = listCounter{}
+	defer func() { r.lists = saved }()
+
+	var bands []band
+	height := 0.0
+	for _, p := range paras {
+		for _, b := range r.paragraphBands(p, width, "") {
+			bands = append(bands, b)
+			height += b.height
+		}
+	}
+	return bands, height
+}
+
+// noteBands lays out the footnotes referenced so far below a short
+// separator line.
+func (r *renderer) noteBands(width float64) []band {
+	if len(r.notes) == 0 {
+		return nil
+	}
+	bands := []band{{height: 12, draw: func(c *canvas, x, y, _ float64) {
+		c.line(x, y+6, x+width/3, y+6, 0.5, domain.ColorBlack, "")
+	}}}
+	for _, note := range r.notes {
+		for i, p := range note.Paragraphs() {
+			marker := ""
+			if i == 0 {
+				marker = strconv.Itoa(r.noteNumbers[note])
+			}
+			bands = append(bands, r.paragraphBands(p, width, marker)...)
+		}
+	}
+	return bands
+}
+
+// noteNumber returns the number of a footnote, numbering footnotes in
+// the order they are referenced.
+func (r *renderer) noteNumber(note domain.Footnote) int {
+	if n, ok := r.noteNumbers[note]; ok {
+		return n
+	}
+	r.notes = append(r.notes, note)
+	r.noteNumbers[note] = len(r.notes)
+	return len(r.notes)
+}
+
+// flow places bands in the columns of successive pages.
+type flow struct {
+	r            *renderer
+	pages        []*page
+	page         *page
+	g            *geometry
+	column       int
+	top, bottom  float64 // Body area of the current page
+	regionTop    float64 // Top of the columns of the current section
+	y            float64
+	empty        bool // Nothing placed in the current column
+	sectionStart bool
+	section      int // Index of the section being placed
+}
+
+// newPage starts a page. The body area leaves room for the header and
+// footer of the page when they are taller than the margins.
+func (f *flow) newPage() {
+	g := f.g
+	pg := &page{width: g.width, height: g.height, geometry: g, number: len(f.pages) + 1, first: f.sectionStart}
+	f.sectionStart = false
+	f.pages = append(f.pages, pg)
+	f.page = pg
+
+	width := g.width - g.left - g.right
+	f.r.pageNumber = pg.number
+	_, headerHeight := f.r.partBands(g.headerFooter(pg, true), width)
+	_, footerHeight := f.r.partBands(g.headerFooter(pg, false), width)
+	f.r.pageNumber = 0
+	f.top = max(g.top, g.headerDist+headerHeight)
+	f.bottom = g.height - max(g.bottom, g.footerDist+footerHeight)
+	f.regionTop, f.y = f.top, f.top
+	f.column, f.empty = 0, true
+}
+
+// startSection starts a section on a new page, on the next odd or even
+// page, or below the previous section on the same page.
+func (f *flow) startSection(g *geometry, start domain.SectionBreakType, first bool) {
+	prev := f.g
+	f.g = g
+	f.sectionStart = true
+	if first || f.page == nil {
+		f.newPage()
+		return
+	}
+	switch start {
+	case domain.SectionBreakTypeContinuous:
+		if prev.width == g.width && prev.height == g.height {
+			f.sectionStart = false
+			f.regionTop = f.y
+			f.column, f.empty = 0, true
+			return
+		}
+		f.newPage()
+	case domain.SectionBreakTypeEvenPage, domain.SectionBreakTypeOddPage:
+		f.newPage()
+		if (f.page.number%2 == 0) != (start == domain.SectionBreakTypeEvenPage) {
+			f.sectionStart = true
+			f.newPage()
+		}
+	default:
+		f.newPage()
+	}
+}
+
+// nextColumn moves to the next column, or the next page after the last.
+func (f *flow) nextColumn() {
+	if f.column+1 < f.g.columns {
+		f.column++
+		f.y, f.empty = f.regionTop, true
+		return
+	}
+	f.newPage()
+}
+
+// keptHeight returns the height of the first band together with the
+// bands it is kept with.
+func keptHeight(bands []band) float64 {
+	height := 0.0
+	for _, b := range bands {
+		height += b.height
+		if !b.keepNext {
+			break
+		}
+	}
+	return height
+}
+
+// place puts a band in the current column, moving to the next column
+// when it does not fit, or when the bands it is kept with (kept, its own
+// height included) do not fit but would fit in an empty column.
+func (f *flow) place(b band, kept float64) {
+	const epsilon = 0.01
+	switch {
+	case b.pageBreak && len(f.page.placed) > 0:
+		f.newPage()
+	case b.columnBreak && !f.empty:
+		f.nextColumn()
+	}
+	if !f.empty {
+		switch {
+		case f.y+b.height > f.bottom+epsilon:
+			f.nextColumn()
+		case kept > b.height && f.y+kept > f.bottom+epsilon && kept <= f.bottom-f.regionTop:
+			f.nextColumn()
+		}
+	}
+	x := f.g.left + float64(f.column)*(f.g.columnWidth+columnGap)
+	f.page.placed = append(f.page.placed, placement{band: b, x: x, y: f.y, width: f.g.columnWidth, section: f.section})
+	f.y += b.height
+	f.empty = false
+}
+
+// draw draws a page with its background, header and footer.
+func (r *renderer) draw(pg *page, total int) *canvas {
+	c := newCanvas(pg.height, pg.number, total, &r.images)
+	if r.pixelScale > 0 {
+		c.raster = newRaster(pg.width, pg.height, r.pixelScale)
+	}
+	if background, ok := r.doc.BackgroundColor(); ok {
+		c.fillRect(0, 0, pg.width, pg.height, background)
+	}
+
+	g := pg.geometry
+	width := g.width - g.left - g.right
+	r.pageNumber, r.pageCount = pg.number, total
+	headers, _ := r.partBands(g.headerFooter(pg, true), width)
+	footers, footerHeight := r.partBands(g.headerFooter(pg, false), width)
+	drawBands(c, headers, g.left, g.headerDist, width)
+	drawBands(c, footers, g.left, g.height-g.footerDist-footerHeight, width)
+
+	for _, p := range pg.placed {
+		if p.band.draw != nil {
+			p.band.draw(c, p.x, p.y, p.width)
+		}
+	}
+	return c
+}
+
+// drawBands draws bands one below the other from y.
+func drawBands(c *canvas, bands []band, x, y, width float64) {
+	for _, b := range bands {
+		if b.draw != nil {
+			b.draw(c, x, y, width)
+		}
+		y += b.height
+	}
+}
+
+// listCounter numbers list items per list and level. Like Word, a list
+// keeps counting across other paragraphs, and deeper levels restart after
+// each item.
+type listCounter map[int][]int
+
+// next counts an item and returns its list level and number. Lists the
+// document does not define are bullets.
+func (c listCounter) next(doc domain.Document, ref domain.NumberingReference) (domain.ListLevel, int) {
+	def, ok := doc.ListLevel(ref)
+	if !ok {
+		def = domain.ListLevel{Kind: domain.ListBullet, Format: "bullet"}
+	}
+	counts := c[ref.ID]
+	if counts == nil {
+		counts = make([]int, domain.NumberingLevelMax+1)
+		c[ref.ID] = counts
+	}
+	level := min(max(ref.Level, domain.NumberingLevelMin), domain.NumberingLevelMax)
+	counts[level]++
+	clear(counts[level+1:])
+	return def, def.Start + counts[level] - 1
+}
+
+// formatNumber writes a list number in a Word number format.
+func formatNumber(n int, format string) string {
+	switch format {
+	case "lowerLetter", "upperLetter":
+		var letters string
+		for n > 0 {
+			n--
+			letters = string(rune('a'+n%26)) + letters
+			n /= 26
+		}
+		if format == "upperLetter" {
+			return strings.ToUpper(letters)
+		}
+		return letters
+	case "lowerRoman", "upperRoman":
+		roman := romanNumeral(n)
+		if format == "lowerRoman" {
+			return strings.ToLower(roman)
+		}
+		return roman
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+func romanNumeral(n int) string {
+	if n <= 0 {
+		return strconv.Itoa(n)
+	}
+	values := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	symbols := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+	var b strings.Builder
+	for i, v := range values {
+		for n >= v {
+			b.WriteString(symbols[i])
+			n -= v
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,115 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/layout"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Pagination tells on which pages the content of a document falls.
+type Pagination struct {
+	// Pages is the number of pages.
+	Pages int
+
+	// Sections holds the pages of each section, in document order.
+	// Sections that start without a page break share a page with the
+	// previous one.
+	Sections []PageRange
+
+	paragraphs map[domain.Paragraph]position
+}
+
+// position is where a paragraph starts.
+type position struct {
+	page, section int
+}
+
+// PageRange is a range of pages, counted from 1.
+type PageRange struct {
+	First, Last int
+}
+
+// Page returns the page a paragraph of the body, of a table in it or of
+// a footnote starts on, and false for paragraphs not laid out, such as
+// those of headers and footers.
+func (p *Pagination) Page(para domain.Paragraph) (int, bool) {
+	pos, ok := p.paragraphs[para]
+	return pos.page, ok
+}
+
+// Section returns the index in Sections of the section a paragraph
+// starts in, for the paragraphs Page knows.
+func (p *Pagination) Section(para domain.Paragraph) (int, bool) {
+	pos, ok := p.paragraphs[para]
+	return pos.section, ok
+}
+
+// PageCount returns the number of pages.
+func (p *Pagination) PageCount() int {
+	return p.Pages
+}
+
+// SectionPages returns the first and last page of Sections[i].
+func (p *Pagination) SectionPages(i int) (first, last int, ok bool) {
+	if i < 0 || i >= len(p.Sections) {
+		return 0, 0, false
+	}
+	return p.Sections[i].First, p.Sections[i].Last, true
+}
+
+var _ layout.Pagination = (*Pagination)(nil)
+
+// Paginate lays out doc as Render does and reports where its content
+// falls, without writing PDF. Fonts that are not available are measured
+// with the metrics of the font itself, as Word would show it, rather
+// than those of the standard font Render draws instead.
+func Paginate(doc domain.Document, opts Options) (*Pagination, error) {
+	const op = "pdf.Paginate"
+	if doc == nil {
+		return nil, errors.InvalidArgument(op, "doc", doc, "document cannot be nil")
+	}
+
+	fonts, err := newFontSet(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	fonts.measure = true
+	r := newRenderer(doc, fonts)
+	pages := r.layout()
+
+	p := &Pagination{Pages: len(pages), Sections: r.sections, paragraphs: map[domain.Paragraph]position{}}
+	for _, pg := range pages {
+		for _, placed := range pg.placed {
+			for _, para := range placed.band.paragraphs {
+				if _, ok := p.paragraphs[para]; !ok {
+					p.paragraphs[para] = position{page: pg.number, section: placed.section}
+				}
+			}
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,652 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+const (
+	// tabStop is the distance between default tab stops.
+	tabStop = 36.0
+	// emuPerPoint converts image sizes to points.
+	emuPerPoint = 12700.0
+	// borderSpace separates paragraph borders from the text.
+	borderSpace = 1.0
+)
+
+// bulletMarkers are the list bullets by level.
+var bulletMarkers = []string{"•", "◦", "▪"}
+
+type tokenKind int
+
+const (
+	tokenText tokenKind = iota
+	tokenSpace
+	tokenTab
+	tokenImage
+	tokenField
+	tokenBreak
+)
+
+// token is an unbreakable piece of a paragraph: text without spaces, a
+// space, a tab, an inline image, a page field or a break.
+type token struct {
+	kind       tokenKind
+	text       string
+	style      textStyle
+	width      float64
+	breakAfter bool // A line may end after the token, e.g. after a hyphen
+	image      domain.Image
+	height     float64 // Image height
+	field      domain.FieldType
+	brk        domain.BreakType
+}
+
+// ascent and descent return the extent of the token about the baseline.
+func (t *token) ascent() float64 {
+	if t.kind == tokenImage {
+		return t.height
+	}
+	return t.style.font.ascent(t.style.size) + math.Max(t.style.rise, 0)
+}
+
+func (t *token) descent() float64 {
+	if t.kind == tokenImage {
+		return 0
+	}
+	return t.style.font.descent(t.style.size) + math.Max(-t.style.rise, 0)
+}
+
+// line is a laid out line of a paragraph.
+type line struct {
+	tokens    []token
+	offsets   []float64 // Token positions from the start of the line
+	width     float64   // Width without trailing spaces
+	start     float64   // Indentation of the line from the left
+	available float64   // Width between the indents
+	ascent    float64
+	height    float64
+	baseline  float64 // Distance from the top of the line
+	last      bool    // Last line of the paragraph or ends with a break
+	brk       *domain.BreakType
+}
+
+// floating is an image anchored to a paragraph.
+type floating struct {
+	image domain.Image
+	w, h  float64
+}
+
+// fieldText returns the value of a page field.
+func fieldText(field domain.FieldType, number, total int) string {
+	if field == domain.FieldTypePageNumber {
+		return strconv.Itoa(max(number, 1))
+	}
+	return strconv.Itoa(max(total, 1))
+}
+
+// paragraphBands lays out a paragraph in width points. marker, when not
+// empty, precedes the text like a list number.
+func (r *renderer) paragraphBands(p domain.Paragraph, width float64, marker string) []band {
+	f := r.paragraphFormat(p)
+	base := r.textStyle(f.run)
+
+	var tokens []token
+	if ref, ok := p.Numbering(); ok {
+		level, n := r.lists.next(r.doc, ref)
+		text := bulletMarkers[min(max(ref.Level, 0), 8)%len(bulletMarkers)]
+		if !base.font.has([]rune(text)[0]) {
+			text = bulletMarkers[0]
+		}
+		if level.Kind == domain.ListDecimal {
+			text = formatNumber(n, level.Format) + "."
+		}
+		if p.Indent() == (domain.Indentation{}) {
+			f.left, f.firstLine = float64(ref.Level+1)*36, -18
+		}
+		tokens = r.appendText(tokens, text, base)
+		tokens = append(tokens, token{kind: tokenTab, style: base})
+	}
+	if marker != "" {
+		noteStyle := base
+		noteStyle.size, noteStyle.rise = base.size*0.65, base.size*0.33
+		tokens = r.appendText(tokens, marker, noteStyle)
+		tokens = r.appendText(tokens, " ", base)
+	}
+
+	var floats []floating
+	for _, run := range p.Runs() {
+		style := r.textStyle(r.runFormat(f.run, run))
+		tokens, floats = r.runTokens(tokens, floats, run, style, width-f.left-f.right)
+	}
+
+	lines := r.breakLines(tokens, f, width)
+	for i := range lines {
+		lines[i].measure(f.line, base)
+	}
+	bands := paragraphLineBands(lines, f, floats)
+	bands[0].paragraphs = []domain.Paragraph{p}
+	return bands
+}
+
+// runTokens appends the tokens of a run: field results, text, an inline
+// image or footnote reference, and breaks.
+func (r *renderer) runTokens(tokens []token, floats []floating, run domain.Run, style textStyle, available float64) ([]token, []floating) {
+	var fields []domain.Field
+	if withFields, ok := run.(interface{ Fields() []domain.Field }); ok {
+		fields = withFields.Fields()
+	}
+	hyperlink := false
+	for _, field := range fields {
+		switch field.Type() {
+		case domain.FieldTypePageNumber, domain.FieldTypeNumPages, domain.FieldTypePageCount:
+			text := fieldText(field.Type(), r.pageNumber, r.pageCount)
+			tokens = append(tokens, token{kind: tokenField, field: field.Type(), style: style, width: style.font.width(text, style.size)})
+		case domain.FieldTypeHyperlink:
+			hyperlink = true
+			linked := style
+			if accessor, ok := field.(interface {
+				GetProperty(string) (string, bool)
+			}); ok {
+				linked.link, _ = accessor.GetProperty("url")
+			}
+			text := field.Result()
+			if text == "" {
+				text = run.Text()
+			}
+			tokens = r.appendText(tokens, text, linked)
+		default:
+			tokens = r.appendText(tokens, field.Result(), style)
+		}
+	}
+	if !hyperlink {
+		tokens = r.appendText(tokens, run.Text(), style)
+	}
+
+	if withImage, ok := run.(interface{ Image() domain.Image }); ok {
+		if img := withImage.Image(); img != nil {
+			size := img.Size()
+			w, h := float64(size.WidthEMU)/emuPerPoint, float64(size.HeightEMU)/emuPerPoint
+			if img.Position().Type == domain.ImagePositionFloating {
+				floats = append(floats, floating{image: img, w: w, h: h})
+			} else if w > 0 && h > 0 {
+				if w > available && available > 0 {
+					w, h = available, h*available/w
+				}
+				tokens = append(tokens, token{kind: tokenImage, image: img, width: w, height: h, style: style})
+			}
+		}
+	}
+	if withNote, ok := run.(interface{ Footnote() domain.Footnote }); ok {
+		if note := withNote.Footnote(); note != nil {
+			ref := style
+			ref.size, ref.rise = style.size*0.65, style.size*0.33
+			tokens = r.appendText(tokens, strconv.Itoa(r.noteNumber(note)), ref)
+		}
+	}
+	if withBreaks, ok := run.(interface{ Breaks() []domain.BreakType }); ok {
+		for _, brk := range withBreaks.Breaks() {
+			tokens = append(tokens, token{kind: tokenBreak, brk: brk, style: style})
+		}
+	}
+	return tokens, floats
+}
+
+// appendText splits text into words, spaces, tabs and line breaks.
+func (r *renderer) appendText(tokens []token, text string, style textStyle) []token {
+	var word strings.Builder
+	flush := func(breakAfter bool) {
+		if word.Len() == 0 {
+			return
+		}
+		// Characters the font lacks are set in a fallback font; the pieces
+		// stay one word.
+		pieces := r.splitByFont(word.String(), style)
+		for i, piece := range pieces {
+			piece.breakAfter = breakAfter && i == len(pieces)-1
+			tokens = append(tokens, piece)
+		}
+		word.Reset()
+	}
+	for _, c := range text {
+		switch c {
+		case ' ':
+			flush(false)
+			tokens = append(tokens, token{kind: tokenSpace, text: " ", style: style, width: style.font.width(" ", style.size)})
+		case '\t':
+			flush(false)
+			tokens = append(tokens, token{kind: tokenTab, style: style})
+		case '\n', '\r', '\v':
+			flush(false)
+			tokens = append(tokens, token{kind: tokenBreak, brk: domain.BreakTypeLine, style: style})
+		case '-', '/', '–', '—':
+			word.WriteRune(c)
+			flush(true)
+		default:
+			word.WriteRune(c)
+		}
+	}
+	flush(false)
+	return tokens
+}
+
+// splitByFont returns text as tokens, switching to a fallback font for
+// characters the style's font cannot show.
+func (r *renderer) splitByFont(text string, style textStyle) []token {
+	var tokens []token
+	var piece strings.Builder
+	current := style
+	flush := func() {
+		if piece.Len() > 0 {
+			s := piece.String()
+			tokens = append(tokens, token{kind: tokenText, text: s, style: current, width: current.font.width(s, current.size)})
+			piece.Reset()
+		}
+	}
+	for _, c := range text {
+		next := style
+		if !style.font.has(c) {
+			if f := r.fonts.fallback(c, style.font.bold, style.font.italic); f != nil {
+				next.font = f
+			}
+		}
+		if next != current {
+			flush()
+			current = next
+		}
+		piece.WriteRune(c)
+	}
+	flush()
+	return tokens
+}
+
+// breakLines fills lines greedily, breaking at spaces and after hyphens.
+// Words longer than a line are broken between characters.
+func (r *renderer) breakLines(tokens []token, f paraFormat, width float64) []line {
+	var lines []line
+	cur := line{}
+	x := 0.0
+	begin := func() {
+		cur = line{start: f.left}
+		if len(lines) == 0 {
+			cur.start += f.firstLine
+		}
+		cur.start = math.Max(cur.start, math.Min(f.left, 0))
+		cur.available = math.Max(width-cur.start-f.right, 1)
+		x = 0
+	}
+	hasContent := func() bool {
+		for _, t := range cur.tokens {
+			if t.kind != tokenSpace {
+				return true
+			}
+		}
+		return false
+	}
+	finish := func(last bool, brk *domain.BreakType) {
+		cur.last, cur.brk = last, brk
+		cur.width = 0
+		for i, t := range cur.tokens {
+			if t.kind != tokenSpace {
+				cur.width = cur.offsets[i] + t.width
+			}
+		}
+		lines = append(lines, cur)
+		begin()
+	}
+	place := func(t token) {
+		cur.tokens = append(cur.tokens, t)
+		cur.offsets = append(cur.offsets, x)
+		x += t.width
+	}
+
+	begin()
+	for i := 0; i < len(tokens); {
+		t := tokens[i]
+		switch t.kind {
+		case tokenSpace:
+			place(t)
+			i++
+		case tokenTab:
+			abs := cur.start + x
+			stop := (math.Floor(abs/tabStop+0.01) + 1) * tabStop
+			if len(lines) == 0 && f.firstLine < 0 && abs < f.left-0.01 {
+				stop = f.left
+			}
+			if stop-cur.start > cur.available && hasContent() {
+				finish(false, nil)
+				abs = cur.start
+				stop = (math.Floor(abs/tabStop+0.01) + 1) * tabStop
+			}
+			t.width = math.Max(stop-cur.start-x, 0)
+			place(t)
+			i++
+		case tokenBreak:
+			brk := t.brk
+			finish(true, &brk)
+			i++
+		default:
+			j, groupWidth := i, 0.0
+			for j < len(tokens) && tokens[j].kind != tokenSpace && tokens[j].kind != tokenTab && tokens[j].kind != tokenBreak {
+				groupWidth += tokens[j].width
+				j++
+				if tokens[j-1].breakAfter {
+					break
+				}
+			}
+			if x+groupWidth > cur.available && hasContent() {
+				finish(false, nil)
+			}
+			for _, g := range tokens[i:j] {
+				if x+g.width <= cur.available || g.kind != tokenText {
+					if x+g.width > cur.available && hasContent() {
+						finish(false, nil)
+					}
+					place(g)
+					continue
+				}
+				for _, c := range g.text {
+					piece := g
+					piece.text = string(c)
+					piece.width = g.style.font.width(piece.text, g.style.size)
+					if x+piece.width > cur.available && hasContent() {
+						finish(false, nil)
+					}
+					place(piece)
+				}
+			}
+			i = j
+		}
+	}
+	if len(cur.tokens) > 0 || len(lines) == 0 || lines[len(lines)-1].brk != nil {
+		finish(true, nil)
+	}
+	return lines
+}
+
+// measure sets the height and baseline of a line from its tokens and the
+// line spacing rule. Empty lines take the size of the paragraph mark.
+func (l *line) measure(spacing domain.LineSpacing, mark textStyle) {
+	ascent := mark.font.ascent(mark.size)
+	descent := mark.font.descent(mark.size)
+	gap := mark.font.lineGap(mark.size)
+	if len(l.tokens) > 0 {
+		ascent, descent, gap = 0, 0, 0
+		for i := range l.tokens {
+			t := &l.tokens[i]
+			ascent = math.Max(ascent, t.ascent())
+			descent = math.Max(descent, t.descent())
+			if t.kind != tokenImage {
+				gap = math.Max(gap, t.style.font.lineGap(t.style.size))
+			}
+		}
+	}
+	natural := ascent + descent + gap
+	l.ascent = ascent
+	switch spacing.Rule {
+	case domain.LineSpacingExact:
+		l.height = twips(spacing.Value)
+		l.baseline = l.height - descent
+	case domain.LineSpacingAtLeast:
+		l.height = math.Max(natural, twips(spacing.Value))
+		l.baseline = l.height - descent - gap
+	default:
+		l.height = natural * float64(spacing.Value) / 240
+		l.baseline = l.height - descent - gap
+	}
+	if l.height <= 0 {
+		l.height, l.baseline = natural, ascent
+	}
+}
+
+// paragraphLineBands turns lines into bands. The first band carries the
+// space before, the top border and floating images; the last the bottom
+// border and the space after.
+func paragraphLineBands(lines []line, f paraFormat, floats []floating) []band {
+	top := f.before
+	if f.borders.Top.Style != domain.BorderNone {
+		top += borderWidth(f.borders.Top) + borderSpace
+	}
+	bottom := f.after
+	if f.borders.Bottom.Style != domain.BorderNone {
+		bottom += borderWidth(f.borders.Bottom) + borderSpace
+	}
+
+	var bands []band
+	if len(floats) > 0 {
+		bands = append(bands, floatingBand(floats, top))
+	}
+	for i := range lines {
+		l := lines[i]
+		first, last := i == 0, i == len(lines)-1
+		// Like Word's widow and orphan control, the first line stays with
+		// the second and the last with the one before.
+		keep := f.keepLines || (len(lines) > 1 && (i == 0 || i == len(lines)-2))
+		b := band{height: l.height, keepNext: (last && f.keepNext) || (!last && keep)}
+		offset := 0.0
+		if first {
+			b.height += top
+			offset = top
+			b.pageBreak = f.pageBreakBefore
+		}
+		if last {
+			b.height += f.after
+			if f.borders.Bottom.Style != domain.BorderNone {
+				b.height += borderWidth(f.borders.Bottom) + borderSpace
+			}
+		}
+		if i > 0 && lines[i-1].brk != nil {
+			switch *lines[i-1].brk {
+			case domain.BreakTypePage:
+				b.pageBreak = true
+			case domain.BreakTypeColumn:
+				b.columnBreak = true
+			}
+		}
+		b.draw = func(c *canvas, x, y, width float64) {
+			textTop := y + offset
+			left, right := x+f.left-4, x+width-f.right+4
+			if first && f.borders.Top.Style != domain.BorderNone {
+				by := textTop - borderSpace - borderWidth(f.borders.Top)/2
+				c.border(f.borders.Top, left, by, right, by, 0, -1)
+			}
+			if last && f.borders.Bottom.Style != domain.BorderNone {
+				by := textTop + l.height + borderSpace + borderWidth(f.borders.Bottom)/2
+				c.border(f.borders.Bottom, left, by, right, by, 0, 1)
+			}
+			if f.borders.Left.Style != domain.BorderNone {
+				c.border(f.borders.Left, left, textTop, left, textTop+l.height, -1, 0)
+			}
+			if f.borders.Right.Style != domain.BorderNone {
+				c.border(f.borders.Right, right, textTop, right, textTop+l.height, 1, 0)
+			}
+			l.draw(c, x, textTop, f.align)
+		}
+		bands = append(bands, b)
+	}
+	if len(lines) == 0 {
+		bands = append(bands, band{height: top + bottom})
+	}
+	return bands
+}
+
+// floatingBand draws the floating images of a paragraph relative to the
+// column and the top of the paragraph. Images that text wraps around push
+// the text below them; the others take no space.
+func floatingBand(floats []floating, top float64) band {
+	height := 0.0
+	for _, fl := range floats {
+		pos := fl.image.Position()
+		switch {
+		case pos.BehindText, pos.WrapText == domain.WrapNone, pos.WrapText == domain.WrapBehindText, pos.WrapText == domain.WrapInFrontText:
+		default:
+			height = math.Max(height, top+float64(pos.OffsetY)/emuPerPoint+fl.h)
+		}
+	}
+	return band{height: height, draw: func(c *canvas, x, y, width float64) {
+		for _, fl := range floats {
+			pos := fl.image.Position()
+			ix := x + float64(pos.OffsetX)/emuPerPoint
+			switch pos.HAlign {
+			case domain.HAlignCenter:
+				ix = x + (width-fl.w)/2
+			case domain.HAlignRight, domain.HAlignOutside:
+				ix = x + width - fl.w
+			case domain.HAlignLeft, domain.HAlignInside:
+				ix = x
+			}
+			c.drawImage(fl.image, ix, y+top+float64(pos.OffsetY)/emuPerPoint, fl.w, fl.h)
+		}
+	}}
+}
+
+// draw shows the line whose top is y in a column starting at x.
+func (l *line) draw(c *canvas, x, y float64, align domain.Alignment) {
+	free := l.available - l.width
+	shift, extra := 0.0, 0.0
+	switch align {
+	case domain.AlignmentCenter:
+		shift = free / 2
+	case domain.AlignmentRight:
+		shift = free
+	case domain.AlignmentJustify, domain.AlignmentDistribute:
+		if !l.last || align == domain.AlignmentDistribute {
+			spaces := 0
+			for i, t := range l.tokens {
+				if t.kind == tokenSpace && l.offsets[i] < l.width && i > 0 {
+					spaces++
+				}
+			}
+			if spaces > 0 && free > 0 {
+				extra = free / float64(spaces)
+			}
+		}
+	}
+
+	baseline := y + l.baseline
+	start := x + l.start + shift
+	added := 0.0
+	var seg *segment
+	flush := func() {
+		if seg != nil {
+			seg.draw(c, baseline)
+			seg = nil
+		}
+	}
+	for i, t := range l.tokens {
+		if t.kind == tokenSpace && i > 0 && l.offsets[i] < l.width {
+			added += extra
+		}
+		pos := start + l.offsets[i] + added
+		if l.offsets[i] >= l.width && t.kind == tokenSpace {
+			continue
+		}
+		switch t.kind {
+		case tokenImage:
+			flush()
+			c.drawImage(t.image, pos, baseline-t.height, t.width, t.height)
+			c.link(pos, baseline-t.height, t.width, t.height, t.image.Hyperlink())
+		case tokenTab:
+			flush()
+		case tokenField:
+			flush()
+			text := fieldText(t.field, c.number, c.total)
+			(&segment{style: t.style, text: text, x: pos, width: t.style.font.width(text, t.style.size)}).draw(c, baseline)
+		case tokenText, tokenSpace:
+			width := t.width
+			if t.kind == tokenSpace && extra > 0 {
+				// Stretched spaces are only drawn for their decoration.
+				flush()
+				if t.style.underline != domain.UnderlineNone || t.style.hasHighlight {
+					(&segment{style: t.style, text: t.text, x: pos, width: width + extra}).draw(c, baseline)
+				}
+				continue
+			}
+			if seg != nil && seg.style == t.style && math.Abs(seg.x+seg.width-pos) < 0.01 {
+				seg.text += t.text
+				seg.width += width
+				continue
+			}
+			flush()
+			seg = &segment{style: t.style, text: t.text, x: pos, width: width}
+		}
+	}
+	flush()
+}
+
+// segment is text drawn with one style.
+type segment struct {
+	style textStyle
+	text  string
+	x     float64
+	width float64
+}
+
+// draw shows the segment with its highlight, underline and link.
+func (s *segment) draw(c *canvas, baseline float64) {
+	st := s.style
+	y := baseline - st.rise
+	ascent, descent := st.font.ascent(st.size), st.font.descent(st.size)
+	if st.hasHighlight {
+		c.fillRect(s.x, y-ascent, s.width, ascent+descent, st.highlight)
+	}
+	c.text(st.font, st.size, s.x, y, st.color, s.text)
+
+	if st.underline != domain.UnderlineNone && strings.TrimSpace(s.text) != "" {
+		thickness := math.Max(st.size*0.06, 0.5)
+		uy := y + st.size*0.12
+		dash := ""
+		switch st.underline {
+		case domain.UnderlineThick:
+			thickness *= 2
+		case domain.UnderlineDotted:
+			dash = "[" + num(thickness) + " " + num(thickness*2) + "] 0"
+		case domain.UnderlineDashed, domain.UnderlineWave:
+			dash = "[" + num(thickness*4) + " " + num(thickness*3) + "] 0"
+		}
+		c.line(s.x, uy, s.x+s.width, uy, thickness, st.color, dash)
+		if st.underline == domain.UnderlineDouble {
+			c.line(s.x, uy+thickness*2, s.x+s.width, uy+thickness*2, thickness, st.color, "")
+		}
+	}
+	if st.strike {
+		sy := y - st.size*0.28
+		c.line(s.x, sy, s.x+s.width, sy, math.Max(st.size*0.05, 0.5), st.color, "")
+	}
+	c.link(s.x, y-ascent, s.width, ascent+descent, st.link)
+}
+
+// borderWidth returns the line width of a border in points.
+func borderWidth(b domain.BorderStyle) float64 {
+	if b.Width <= 0 {
+		return 0.5
+	}
+	return float64(b.Width) / 8
+}
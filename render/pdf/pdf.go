@@ -0,0 +1,224 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package pdf renders documents to PDF in pure Go, without LibreOffice or
+// Word.
+//
+// Each section is laid out on pages of its size, margins and columns.
+// Paragraphs are broken into lines and aligned or justified, tables are
+// drawn with their borders, shading and merged cells, and inline and
+// floating images are placed in the text. Headers and footers repeat on
+// every page with PAGE and NUMPAGES fields evaluated, and hyperlinks
+// become link annotations.
+//
+// Text uses the TrueType fonts named by Options, embedded as subsets.
+// Fonts that are not available fall back to the standard PDF fonts,
+// which only show Latin text; other characters are set in any embedded
+// font that has them. The layout approximates Word's; it does not
+// match it line for line.
+//
+//	doc, err := docx.OpenDocument("report.docx")
+//	if err != nil {
+//		return err
+//	}
+//	err = pdf.RenderFile(doc, "report.pdf", pdf.Options{FontDirs: []string{"/usr/share/fonts"}})
+package pdf
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Options configures rendering.
+type Options struct {
+	// FontDirs are searched recursively for TrueType fonts (.ttf and
+	// .ttc) to embed. Fonts with CFF outlines are ignored.
+	FontDirs []string
+
+	// Fonts holds TrueType font files to embed, in addition to the ones
+	// found in FontDirs.
+	Fonts [][]byte
+
+	// DefaultFont is the family used for fonts that are not available,
+	// before falling back to the standard PDF fonts.
+	DefaultFont string
+}
+
+// Render lays out doc and writes it to w as PDF.
+func Render(doc domain.Document, w io.Writer, opts Options) error {
+	const op = "pdf.Render"
+	if doc == nil {
+		return errors.InvalidArgument(op, "doc", doc, "document cannot be nil")
+	}
+	if w == nil {
+		return errors.InvalidArgument(op, "w", w, "writer cannot be nil")
+	}
+
+	fonts, err := newFontSet(opts)
+	if err != nil {
+		return err
+	}
+	r := newRenderer(doc, fonts)
+	pages := r.layout()
+
+	out := &objectWriter{}
+	catalog := r.write(out, pages)
+	if err := out.writeTo(w, catalog, r.info(out)); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	return nil
+}
+
+// RenderFile lays out doc and writes it to the PDF file at path.
+func RenderFile(doc domain.Document, path string, opts Options) error {
+	const op = "pdf.RenderFile"
+	f, err := os.Create(path)
+	if err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	if err := Render(doc, f, opts); err != nil {
+		_ = f.Close()
+		return errors.Wrap(err, op)
+	}
+	if err := f.Close(); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	return nil
+}
+
+// write draws the pages and adds the page tree, fonts and images. It
+// returns the number of the catalog.
+func (r *renderer) write(out *objectWriter, pages []*page) int {
+	canvases := make([]*canvas, len(pages))
+	for i, pg := range pages {
+		canvases[i] = r.draw(pg, len(pages))
+	}
+
+	fontIDs := r.fonts.write(out)
+	imageIDs := map[*pdfImage]int{}
+	for _, img := range r.images.ordered {
+		imageIDs[img] = img.write(out)
+	}
+
+	tree := out.reserve()
+	kids := make([]string, len(pages))
+	for i, pg := range pages {
+		c := canvases[i]
+		content := out.addStream("", c.buf.Bytes())
+
+		var resources strings.Builder
+		resources.WriteString("<< /ProcSet [/PDF /Text /ImageB /ImageC]")
+		if len(c.fonts) > 0 {
+			resources.WriteString(" /Font <<")
+			for _, f := range sortedFonts(c.fonts) {
+				fmt.Fprintf(&resources, " /%s %s", f.resource, ref(fontIDs[f]))
+			}
+			resources.WriteString(" >>")
+		}
+		if len(c.images) > 0 {
+			resources.WriteString(" /XObject <<")
+			for _, img := range sortedImages(c.images) {
+				fmt.Fprintf(&resources, " /%s %s", img.resource, ref(imageIDs[img]))
+			}
+			resources.WriteString(" >>")
+		}
+		resources.WriteString(" >>")
+
+		var annots string
+		if len(c.links) > 0 {
+			ids := make([]string, len(c.links))
+			for j, l := range c.links {
+				ids[j] = ref(out.add(fmt.Sprintf("<< /Type /Annot /Subtype /Link /Rect [%s %s %s %s] /Border [0 0 0] "+
+					"/A << /S /URI /URI %s >> >>", num(l.x0), num(l.y0), num(l.x1), num(l.y1), literal([]byte(l.url)))))
+			}
+			annots = " /Annots [" + strings.Join(ids, " ") + "]"
+		}
+
+		kids[i] = ref(out.add(fmt.Sprintf("<< /Type /Page /Parent %s /MediaBox [0 0 %s %s] /Resources %s /Contents %s%s >>",
+			ref(tree), num(pg.width), num(pg.height), resources.String(), ref(content), annots)))
+	}
+	out.set(tree, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	return out.add(fmt.Sprintf("<< /Type /Catalog /Pages %s >>", ref(tree)))
+}
+
+// info adds the document information dictionary from the metadata.
+func (r *renderer) info(out *objectWriter) int {
+	entries := []string{"/Producer (docxgo)"}
+	if meta := r.doc.Metadata(); meta != nil {
+		add := func(key, value string) {
+			if value != "" {
+				entries = append(entries, "/"+key+" "+textString(value))
+			}
+		}
+		add("Title", meta.Title)
+		add("Subject", meta.Subject)
+		add("Author", meta.Creator)
+		add("Keywords", strings.Join(meta.Keywords, ", "))
+		if date := pdfDate(meta.Created); date != "" {
+			entries = append(entries, "/CreationDate "+literal([]byte(date)))
+		}
+		if date := pdfDate(meta.Modified); date != "" {
+			entries = append(entries, "/ModDate "+literal([]byte(date)))
+		}
+	}
+	return out.add("<< " + strings.Join(entries, " ") + " >>")
+}
+
+// pdfDate converts an ISO 8601 date to a PDF date, or returns "".
+func pdfDate(iso string) string {
+	digits := strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, iso)
+	if len(digits) < 8 {
+		return ""
+	}
+	return "D:" + digits[:min(len(digits), 14)] + "Z"
+}
+
+func sortedFonts(set map[*font]bool) []*font {
+	fonts := make([]*font, 0, len(set))
+	for f := range set {
+		fonts = append(fonts, f)
+	}
+	sort.Slice(fonts, func(i, j int) bool { return fonts[i].resource < fonts[j].resource })
+	return fonts
+}
+
+func sortedImages(set map[*pdfImage]bool) []*pdfImage {
+	images := make([]*pdfImage, 0, len(set))
+	for img := range set {
+		images = append(images, img)
+	}
+	sort.Slice(images, func(i, j int) bool { return images[i].resource < images[j].resource })
+	return images
+}
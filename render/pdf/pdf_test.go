@@ -0,0 +1,544 @@
+package pdf_test
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"unicode/utf16"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/fontmetrics"
+	"github.com/mmonterroca/docxgo/v2/render/pdf"
+)
+
+// pdfFile is a parsed PDF file with decompressed streams.
+type pdfFile struct {
+	objects map[int]string
+	streams map[int]string
+}
+
+var objectPattern = regexp.MustCompile(`(?s)(\d+) 0 obj\n(.*?)\nendobj\n`)
+
+// parsePDF checks the cross-reference table and reads every object.
+func parsePDF(t *testing.T, data []byte) *pdfFile {
+	t.Helper()
+	if !bytes.HasPrefix(data, []byte("%PDF-1.7")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatalf("not a PDF file: %q", data[:min(len(data), 16)])
+	}
+	startxref := bytes.LastIndex(data, []byte("startxref\n"))
+	xref, err := strconv.Atoi(strings.Fields(string(data[startxref+10:]))[0])
+	if err != nil || !bytes.HasPrefix(data[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref does not point to the xref table")
+	}
+	entries := strings.Split(string(data[xref:]), "\n")[3:]
+
+	f := &pdfFile{objects: map[int]string{}, streams: map[int]string{}}
+	for _, m := range objectPattern.FindAllSubmatchIndex(data, -1) {
+		id, _ := strconv.Atoi(string(data[m[2]:m[3]]))
+		offset, _ := strconv.Atoi(entries[id-1][:10])
+		if offset != m[0] {
+			t.Fatalf("object %d at offset %d, xref says %d", id, m[0], offset)
+		}
+		body := string(data[m[4]:m[5]])
+		f.objects[id] = body
+		if i := strings.Index(body, "\nstream\n"); i >= 0 {
+			raw := body[i+8 : len(body)-len("\nendstream")]
+			if !strings.Contains(body[:i], "/FlateDecode") {
+				f.streams[id] = raw
+				continue
+			}
+			zr, err := zlib.NewReader(strings.NewReader(raw))
+			if err != nil {
+				t.Fatalf("object %d: %v", id, err)
+			}
+			decoded, err := io.ReadAll(zr)
+			if err != nil {
+				t.Fatalf("object %d: %v", id, err)
+			}
+			f.streams[id] = string(decoded)
+		}
+	}
+	return f
+}
+
+// pages returns the page dictionaries and their content in order.
+func (f *pdfFile) pages(t *testing.T) (dicts, contents []string) {
+	t.Helper()
+	var tree string
+	for _, body := range f.objects {
+		if strings.HasPrefix(body, "<< /Type /Pages ") {
+			tree = body
+		}
+	}
+	for _, kid := range regexp.MustCompile(`(\d+) 0 R`).FindAllStringSubmatch(tree, -1) {
+		id, _ := strconv.Atoi(kid[1])
+		page := f.objects[id]
+		content, _ := strconv.Atoi(regexp.MustCompile(`/Contents (\d+)`).FindStringSubmatch(page)[1])
+		dicts = append(dicts, page)
+		contents = append(contents, f.streams[content])
+	}
+	if len(dicts) == 0 {
+		t.Fatal("no pages")
+	}
+	return dicts, contents
+}
+
+func render(t *testing.T, doc domain.Document, opts pdf.Options) *pdfFile {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := pdf.Render(doc, &buf, opts); err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	return parsePDF(t, buf.Bytes())
+}
+
+func addText(t *testing.T, doc domain.Document, text string) domain.Paragraph {
+	t.Helper()
+	para, err := doc.AddParagraph()
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, err := para.AddRun()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := run.SetText(text); err != nil {
+		t.Fatal(err)
+	}
+	return para
+}
+
+func TestRenderPagesAndFields(t *testing.T) {
+	doc := docx.NewDocument()
+	section, _ := doc.DefaultSection()
+	header, _ := section.Header(domain.HeaderDefault)
+	para, _ := header.AddParagraph()
+	for _, part := range []interface{}{"Page ", docx.NewPageNumberField(), " of ", docx.NewPageCountField()} {
+		run, _ := para.AddRun()
+		if field, ok := part.(domain.Field); ok {
+			_ = run.AddField(field)
+		} else {
+			_ = run.SetText(part.(string))
+		}
+	}
+	for i := 0; i < 40; i++ {
+		addText(t, doc, strings.Repeat("The quick brown fox jumps over the lazy dog. ", 6))
+	}
+	_ = doc.SetMetadata(&domain.Metadata{Title: "Quarterly report", Creator: "Finance"})
+
+	f := render(t, doc, pdf.Options{})
+	dicts, contents := f.pages(t)
+	if len(dicts) != 3 {
+		t.Fatalf("pages = %d, want 3", len(dicts))
+	}
+	if !strings.Contains(dicts[0], "/MediaBox [0 0 595.3 841.9]") {
+		t.Errorf("page 1 = %s, want an A4 media box", dicts[0])
+	}
+	for i, content := range contents {
+		want := "(" + strconv.Itoa(i+1) + ") Tj ET\nBT 0 0 0 rg /F1 11 Tf"
+		if !strings.Contains(content, "(Page ) Tj") || !strings.Contains(content, want) || !strings.Contains(content, "(3) Tj") {
+			t.Errorf("page %d header missing \"Page %d of 3\":\n%.400s", i+1, i+1, content)
+		}
+	}
+	if !strings.Contains(contents[0], "(The quick brown fox jumps over the lazy dog. The quick brown fox jumps over the lazy dog.) Tj ET\nBT 0 0 0 rg /F1 11 Tf 72 ") {
+		t.Errorf("first line not broken at a space:\n%.600s", contents[0])
+	}
+
+	var info string
+	for _, body := range f.objects {
+		if strings.HasPrefix(body, "<< /Producer") {
+			info = body
+		}
+	}
+	if !strings.Contains(info, "/Title (Quarterly report)") || !strings.Contains(info, "/Author (Finance)") {
+		t.Errorf("info = %s", info)
+	}
+}
+
+func TestRenderTablesImagesAndLinks(t *testing.T) {
+	doc := docx.NewDocument()
+	table, _ := doc.AddTable(2, 2)
+	_ = table.SetStyle(domain.TableStyleGrid)
+	origin, _ := table.Rows()[0].Cell(0)
+	_ = origin.Merge(1, 2)
+	_ = origin.SetShading(domain.Color{R: 255, G: 204})
+	p, _ := origin.AddParagraph()
+	run, _ := p.AddRun()
+	_ = run.SetText("Merged")
+
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for x := 0; x < 4; x++ {
+		img.Set(x, 0, color.NRGBA{R: 255, A: 255})
+		img.Set(x, 1, color.NRGBA{B: 255, A: 128})
+	}
+	var png64 bytes.Buffer
+	_ = png.Encode(&png64, img)
+	para, _ := doc.AddParagraph()
+	if _, err := para.AddImageFromBytes(png64.Bytes(), domain.ImageFormatPNG, domain.NewImageSize(96, 48), domain.DefaultImagePosition()); err != nil {
+		t.Fatal(err)
+	}
+	linkPara, _ := doc.AddParagraph()
+	linkRun, _ := linkPara.AddRun()
+	if err := linkRun.AddField(docx.NewHyperlinkField("https://example.com/docs", "the docs")); err != nil {
+		t.Fatal(err)
+	}
+
+	f := render(t, doc, pdf.Options{})
+	dicts, contents := f.pages(t)
+	if !strings.Contains(contents[0], "1 0.8 0 rg") || !strings.Contains(contents[0], "(Merged) Tj") {
+		t.Errorf("merged cell not shaded or missing:\n%s", contents[0])
+	}
+	// The merged cell spans both rows: one shading rectangle as tall as two rows.
+	shading := regexp.MustCompile(`1 0\.8 0 rg [\d.]+ [\d.]+ ([\d.]+) ([\d.]+) re f`).FindStringSubmatch(contents[0])
+	if shading == nil {
+		t.Fatal("no shading rectangle")
+	}
+	if h, _ := strconv.ParseFloat(shading[2], 64); h < 25 {
+		t.Errorf("shading height = %v, want two rows", h)
+	}
+	if strings.Count(contents[0], " RG 0.5 w [] 0 d") < 12 {
+		t.Errorf("grid borders missing:\n%s", contents[0])
+	}
+	if !strings.Contains(contents[0], "72 0 0 36 ") || !strings.Contains(contents[0], "/Im1 Do") {
+		t.Errorf("image not drawn at 72x36 points:\n%s", contents[0])
+	}
+
+	var imageDict string
+	for _, body := range f.objects {
+		if strings.Contains(body, "/Subtype /Image /Width 4 /Height 2 /ColorSpace /DeviceRGB") {
+			imageDict = body
+		}
+	}
+	if !strings.Contains(imageDict, "/SMask") {
+		t.Errorf("translucent image has no soft mask: %.200s", imageDict)
+	}
+	if !strings.Contains(dicts[0], "/Annots") {
+		t.Errorf("page has no link annotation: %s", dicts[0])
+	}
+	found := false
+	for _, body := range f.objects {
+		found = found || strings.Contains(body, "/URI (https://example.com/docs)")
+	}
+	if !found {
+		t.Error("link annotation missing")
+	}
+}
+
+func TestRenderSectionsAndColumns(t *testing.T) {
+	doc := docx.NewDocument()
+	addText(t, doc, "Portrait page")
+	landscape, err := doc.AddSectionWithBreak(domain.SectionBreakTypeNextPage)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = landscape.SetOrientation(domain.OrientationLandscape)
+	_ = landscape.SetColumns(2)
+	for i := 0; i < 30; i++ {
+		addText(t, doc, "Column text that flows down the first column and on into the second one.")
+	}
+	// The last section of the body is the landscape one; the first ends
+	// at the break.
+	f := render(t, doc, pdf.Options{})
+	dicts, contents := f.pages(t)
+	if len(dicts) != 2 {
+		t.Fatalf("pages = %d, want 2", len(dicts))
+	}
+	if !strings.Contains(dicts[0], "/MediaBox [0 0 595.3 841.9]") || !strings.Contains(dicts[1], "/MediaBox [0 0 841.9 595.3]") {
+		t.Errorf("pages = %s, %s, want A4 portrait then landscape", dicts[0], dicts[1])
+	}
+	if strings.Contains(contents[0], "Column text") {
+		t.Error("second section did not start on a new page")
+	}
+	// Columns of (841.9 - 144 - 36) / 2 points start at 72 and 438.95.
+	if !strings.Contains(contents[1], "Tf 72 ") || !strings.Contains(contents[1], "Tf 438.95 ") {
+		t.Errorf("text not set in two columns:\n%.800s", contents[1])
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	// Find how many one-line paragraphs fill the first page.
+	probe := docx.NewDocument()
+	lines := 0
+	for {
+		para := addText(t, probe, "Line")
+		p, err := pdf.Paginate(probe, pdf.Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if page, _ := p.Page(para); page == 2 {
+			break
+		}
+		lines++
+	}
+
+	// A two-line paragraph whose first line would end the page moves to
+	// the next page with it.
+	doc := docx.NewDocument()
+	for i := 0; i < lines-1; i++ {
+		addText(t, doc, "Line")
+	}
+	long := addText(t, doc, strings.Repeat("Words that wrap onto a second line. ", 3))
+	next, _ := doc.AddSectionWithBreak(domain.SectionBreakTypeNextPage)
+	_ = next.SetOrientation(domain.OrientationLandscape)
+	last := addText(t, doc, "Landscape")
+
+	p, err := pdf.Paginate(doc, pdf.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page, ok := p.Page(long); !ok || page != 2 {
+		t.Errorf("Page(two-line paragraph) = %d, %v; want 2", page, ok)
+	}
+	if page, _ := p.Page(last); page != 3 || p.Pages != 3 {
+		t.Errorf("Page(last) = %d of %d, want 3 of 3", page, p.Pages)
+	}
+	want := []pdf.PageRange{{First: 1, Last: 2}, {First: 3, Last: 3}}
+	if len(p.Sections) != 2 || p.Sections[0] != want[0] || p.Sections[1] != want[1] {
+		t.Errorf("Sections = %v, want %v", p.Sections, want)
+	}
+	if section, _ := p.Section(last); section != 1 {
+		t.Errorf("Section(last) = %d, want 1", section)
+	}
+	if _, err := pdf.Paginate(nil, pdf.Options{}); err == nil {
+		t.Error("Paginate(nil) succeeded")
+	}
+}
+
+func TestDrawPages(t *testing.T) {
+	doc := docx.NewDocument()
+	addText(t, doc, "Preview text")
+	table, _ := doc.AddTable(1, 1)
+	cell, _ := table.Rows()[0].Cell(0)
+	_ = cell.SetShading(domain.Color{R: 255})
+	_, _ = doc.AddSectionWithBreak(domain.SectionBreakTypeNextPage)
+	addText(t, doc, "Second page")
+
+	pages, err := pdf.DrawPages(doc, 1, 5, 72, pdf.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pages) != 2 {
+		t.Fatalf("DrawPages() returned %d pages, want 2", len(pages))
+	}
+	// A4 is 595.3 x 841.9 points.
+	if size := pages[0].Bounds().Size(); size.X != 596 || size.Y != 842 {
+		t.Errorf("page size = %v, want 596x842", size)
+	}
+	var text, shading, white int
+	b := pages[0].Bounds()
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := pages[0].RGBAAt(x, y)
+			switch {
+			case c.R == 255 && c.G == 255 && c.B == 255:
+				white++
+			case c.R == 255 && c.G < 64 && c.B < 64:
+				shading++
+			case c.R == c.G && c.G == c.B:
+				text++
+			}
+		}
+	}
+	if text == 0 || shading == 0 || white < b.Dx()*b.Dy()*9/10 {
+		t.Errorf("page 1 has %d text, %d shading and %d white pixels", text, shading, white)
+	}
+
+	large, err := pdf.DrawPages(doc, 2, 1, 144, pdf.Options{})
+	if err != nil || len(large) != 1 || large[0].Bounds().Dx() != 1191 {
+		t.Errorf("DrawPages(page 2, 144 dpi) = %d pages, %v", len(large), err)
+	}
+	if _, err := pdf.DrawPages(doc, 0, 1, 72, pdf.Options{}); err == nil {
+		t.Error("DrawPages(page 0) succeeded")
+	}
+	if _, err := pdf.DrawPages(doc, 1, 1, 0, pdf.Options{}); err == nil {
+		t.Error("DrawPages(0 dpi) succeeded")
+	}
+}
+
+func TestRenderEmbedsTrueTypeSubset(t *testing.T) {
+	doc := docx.NewDocument()
+	para := addText(t, doc, "AB")
+	_ = para.Runs()[0].SetFont(domain.Font{Name: "Test Sans"})
+
+	f := render(t, doc, pdf.Options{Fonts: [][]byte{buildFont("Test Sans")}})
+	var typ0, cid, toUnicode string
+	var file int
+	for id, body := range f.objects {
+		switch {
+		case strings.Contains(body, "/Subtype /Type0"):
+			typ0 = body
+		case strings.Contains(body, "/Subtype /CIDFontType2"):
+			cid = body
+		case strings.Contains(body, "/Length1 "):
+			file = id
+		}
+		if strings.Contains(f.streams[id], "beginbfchar") {
+			toUnicode = f.streams[id]
+		}
+	}
+	if !regexp.MustCompile(`/BaseFont /[A-Z]{6}\+TestSans /Encoding /Identity-H`).MatchString(typ0) {
+		t.Errorf("Type0 font = %s", typ0)
+	}
+	// Advances of 1200 and 900 units at 2048 units per em.
+	if !strings.Contains(cid, "/W [1 [585.938] 2 [439.453] ]") {
+		t.Errorf("CIDFont = %s", cid)
+	}
+	if !strings.Contains(toUnicode, "<0001> <0041>") || !strings.Contains(toUnicode, "<0002> <0042>") {
+		t.Errorf("ToUnicode = %s", toUnicode)
+	}
+	_, contents := f.pages(t)
+	if !strings.Contains(contents[0], "<00010002> Tj") {
+		t.Errorf("content = %s", contents[0])
+	}
+
+	// The subset keeps A, the composite B and the component A it uses,
+	// and drops C.
+	tables, err := fontmetrics.Tables([]byte(f.streams[file]))
+	if err != nil {
+		t.Fatalf("subset: %v", err)
+	}
+	loca := tables["loca"]
+	offset := func(g int) uint32 { return binary.BigEndian.Uint32(loca[g*4:]) }
+	if offset(2) == offset(1) || offset(3) == offset(2) || offset(4) != offset(3) {
+		t.Errorf("loca = %v", loca)
+	}
+	if _, ok := tables["cmap"]; ok {
+		t.Error("subset keeps cmap")
+	}
+}
+
+func TestRenderFallsBackForMissingGlyphs(t *testing.T) {
+	doc := docx.NewDocument()
+	addText(t, doc, "Done ✓")
+
+	f := render(t, doc, pdf.Options{Fonts: [][]byte{buildFont("Test Sans")}})
+	var helvetica, embedded bool
+	for _, body := range f.objects {
+		helvetica = helvetica || strings.Contains(body, "/BaseFont /Helvetica")
+		embedded = embedded || strings.Contains(body, "+TestSans")
+	}
+	if !helvetica || !embedded {
+		t.Errorf("fonts: Helvetica %v, embedded %v", helvetica, embedded)
+	}
+	_, contents := f.pages(t)
+	if !strings.Contains(contents[0], "(Done ) Tj") || !strings.Contains(contents[0], "<0003> Tj") {
+		t.Errorf("content = %s", contents[0])
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := pdf.Render(nil, &buf, pdf.Options{}); err == nil {
+		t.Error("Render(nil) succeeded")
+	}
+	if err := pdf.Render(docx.NewDocument(), &buf, pdf.Options{Fonts: [][]byte{[]byte("not a font")}}); err == nil {
+		t.Error("Render with an invalid font succeeded")
+	}
+}
+
+// buildFont assembles a TrueType font with glyphs .notdef, 'A' (1200
+// units), 'B' (900 units, a composite of A) and 'C', also mapped from '✓',
+// at 2048 units per em.
+func buildFont(family string) []byte {
+	be := binary.BigEndian
+
+	head := make([]byte, 54)
+	be.PutUint16(head[18:], 2048)
+	be.PutUint16(head[50:], 1) // Long loca offsets
+
+	hhea := make([]byte, 36)
+	be.PutUint16(hhea[4:], 1900)
+	be.PutUint16(hhea[6:], uint16(0x10000-500))
+	be.PutUint16(hhea[34:], 4)
+
+	maxp := make([]byte, 6)
+	be.PutUint32(maxp, 0x00005000)
+	be.PutUint16(maxp[4:], 4)
+
+	hmtx := make([]byte, 16)
+	for g, advance := range []uint16{500, 1200, 900, 1000} {
+		be.PutUint16(hmtx[g*4:], advance)
+	}
+
+	simple := []byte{0, 1, 0, 0, 0, 0, 0, 100, 0, 100, 0, 0, 0, 0, 1, 0, 0, 0, 0, 0}
+	composite := make([]byte, 16)
+	be.PutUint16(composite, 0xFFFF) // numberOfContours -1
+	be.PutUint16(composite[10:], 1) // ARG_1_AND_2_ARE_WORDS
+	be.PutUint16(composite[12:], 1) // Glyph A
+	glyphs := [][]byte{nil, simple, composite, simple}
+	var glyf []byte
+	loca := make([]byte, 0, 20)
+	for _, g := range glyphs {
+		loca = be.AppendUint32(loca, uint32(len(glyf)))
+		glyf = append(glyf, g...)
+	}
+	loca = be.AppendUint32(loca, uint32(len(glyf)))
+
+	segs := []struct{ start, end, delta uint16 }{
+		{'A', 'C', uint16(0x10000 + 1 - 'A')},
+		{'✓', '✓', uint16(0x10000 + 3 - '✓')},
+		{0xFFFF, 0xFFFF, 1},
+	}
+	sub := make([]byte, 14+len(segs)*8+2)
+	be.PutUint16(sub[0:], 4)
+	be.PutUint16(sub[2:], uint16(len(sub)))
+	be.PutUint16(sub[6:], uint16(len(segs)*2))
+	for i, seg := range segs {
+		be.PutUint16(sub[14+i*2:], seg.end)
+		be.PutUint16(sub[14+len(segs)*2+2+i*2:], seg.start)
+		be.PutUint16(sub[14+len(segs)*4+2+i*2:], seg.delta)
+	}
+	cmap := make([]byte, 12+len(sub))
+	be.PutUint16(cmap[2:], 1)
+	be.PutUint16(cmap[4:], 3)
+	be.PutUint16(cmap[6:], 1)
+	be.PutUint32(cmap[8:], 12)
+	copy(cmap[12:], sub)
+
+	names := []string{family, strings.ReplaceAll(family, " ", "")}
+	name := make([]byte, 6+len(names)*12)
+	be.PutUint16(name[2:], uint16(len(names)))
+	be.PutUint16(name[4:], uint16(len(name)))
+	var strs []byte
+	for i, id := range []uint16{1, 6} {
+		encoded := utf16.Encode([]rune(names[i]))
+		rec := name[6+i*12:]
+		be.PutUint16(rec[0:], 3)
+		be.PutUint16(rec[2:], 1)
+		be.PutUint16(rec[4:], 0x409)
+		be.PutUint16(rec[6:], id)
+		be.PutUint16(rec[8:], uint16(len(encoded)*2))
+		be.PutUint16(rec[10:], uint16(len(strs)))
+		for _, u := range encoded {
+			strs = be.AppendUint16(strs, u)
+		}
+	}
+	name = append(name, strs...)
+
+	tables := []struct {
+		tag  string
+		data []byte
+	}{{"cmap", cmap}, {"glyf", glyf}, {"head", head}, {"hhea", hhea}, {"hmtx", hmtx}, {"loca", loca}, {"maxp", maxp}, {"name", name}}
+	offset := 12 + len(tables)*16
+	font := make([]byte, offset)
+	be.PutUint32(font[0:], 0x00010000)
+	be.PutUint16(font[4:], uint16(len(tables)))
+	for i, tbl := range tables {
+		rec := font[12+i*16:]
+		copy(rec, tbl.tag)
+		be.PutUint32(rec[8:], uint32(offset))
+		be.PutUint32(rec[12:], uint32(len(tbl.data)))
+		font = append(font, tbl.data...)
+		offset += len(tbl.data)
+	}
+	return font
+}
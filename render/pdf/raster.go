@@ -0,0 +1,203 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// DrawPages lays out doc as Render does and draws count pages from page
+// first (counted from 1) as images of dpi pixels per inch, for previews.
+// Text is shown as bars rather than letters. Fewer images are returned
+// when the document ends earlier.
+func DrawPages(doc domain.Document, first, count int, dpi float64, opts Options) ([]*image.RGBA, error) {
+	const op = "pdf.DrawPages"
+	if doc == nil {
+		return nil, errors.InvalidArgument(op, "doc", doc, "document cannot be nil")
+	}
+	if first < 1 {
+		return nil, errors.InvalidArgument(op, "first", first, "pages are counted from 1")
+	}
+	if !(dpi > 0 && dpi <= 1200) {
+		return nil, errors.InvalidArgument(op, "dpi", dpi, "resolution must be between 0 and 1200 dpi")
+	}
+
+	fonts, err := newFontSet(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	r := newRenderer(doc, fonts)
+	r.pixelScale = dpi / 72
+	pages := r.layout()
+
+	var images []*image.RGBA
+	for i := first - 1; i < len(pages) && i < first-1+count; i++ {
+		images = append(images, r.draw(pages[i], len(pages)).raster.img)
+	}
+	return images, nil
+}
+
+// raster draws a page as an image for previews. Text is greeked: each
+// word is a bar the height of lowercase letters, lighter than the text
+// color, which is how text reads at thumbnail sizes.
+type raster struct {
+	img   *image.RGBA
+	scale float64 // Pixels per point
+}
+
+func newRaster(width, height, scale float64) *raster {
+	w, h := max(int(math.Ceil(width*scale)), 1), max(int(math.Ceil(height*scale)), 1)
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	return &raster{img: img, scale: scale}
+}
+
+// blend paints the pixel at x, y with col at opacity alpha.
+func (r *raster) blend(x, y int, col color.NRGBA, alpha float64) {
+	if !(image.Point{X: x, Y: y}).In(r.img.Rect) || alpha <= 0 {
+		return
+	}
+	alpha *= float64(col.A) / 255
+	i := r.img.PixOffset(x, y)
+	px := r.img.Pix[i : i+3 : i+3]
+	px[0] = uint8(float64(px[0])*(1-alpha) + float64(col.R)*alpha + 0.5)
+	px[1] = uint8(float64(px[1])*(1-alpha) + float64(col.G)*alpha + 0.5)
+	px[2] = uint8(float64(px[2])*(1-alpha) + float64(col.B)*alpha + 0.5)
+}
+
+// fillRect fills a rectangle in points with col at opacity alpha. Pixels
+// the rectangle covers in part are blended by coverage, and rectangles
+// thinner than a pixel still show.
+func (r *raster) fillRect(x, y, w, h float64, col domain.Color, alpha float64) {
+	if w <= 0 || h <= 0 {
+		return
+	}
+	x0, y0, x1, y1 := x*r.scale, y*r.scale, (x+w)*r.scale, (y+h)*r.scale
+	if x1-x0 < 1 {
+		mid := (x0 + x1) / 2
+		x0, x1 = mid-0.5, mid+0.5
+	}
+	if y1-y0 < 1 {
+		mid := (y0 + y1) / 2
+		y0, y1 = mid-0.5, mid+0.5
+	}
+	c := color.NRGBA{R: col.R, G: col.G, B: col.B, A: 0xFF}
+	for py := int(math.Floor(y0)); float64(py) < y1; py++ {
+		cy := math.Min(y1, float64(py+1)) - math.Max(y0, float64(py))
+		for px := int(math.Floor(x0)); float64(px) < x1; px++ {
+			cx := math.Min(x1, float64(px+1)) - math.Max(x0, float64(px))
+			r.blend(px, py, c, alpha*cx*cy)
+		}
+	}
+}
+
+// line strokes a line of width points. Dashes are not drawn.
+func (r *raster) line(x1, y1, x2, y2, width float64, col domain.Color) {
+	switch {
+	case y1 == y2:
+		r.fillRect(math.Min(x1, x2), y1-width/2, math.Abs(x2-x1), width, col, 1)
+	case x1 == x2:
+		r.fillRect(x1-width/2, math.Min(y1, y2), width, math.Abs(y2-y1), col, 1)
+	default:
+		// Diagonal lines are stamped with squares along their length.
+		steps := int(math.Hypot(x2-x1, y2-y1)*r.scale) + 1
+		for i := 0; i <= steps; i++ {
+			t := float64(i) / float64(steps)
+			x, y := x1+(x2-x1)*t, y1+(y2-y1)*t
+			r.fillRect(x-width/2, y-width/2, width, width, col, 1)
+		}
+	}
+}
+
+// text greeks s with its baseline at y.
+func (r *raster) text(f *font, size, x, y float64, col domain.Color, s string) {
+	height := size * 0.5
+	start, end := x, x
+	for _, c := range s {
+		advance := f.advance(c) * size / 1000
+		if c == ' ' || c == '\u00a0' {
+			r.fillRect(start, y-height, end-start, height, col, 0.55)
+			start = end + advance
+		}
+		end += advance
+	}
+	r.fillRect(start, y-height, end-start, height, col, 0.55)
+}
+
+// image draws pixels in the box whose top-left corner is x, y, rotated by
+// degrees clockwise about its center, flipped and cropped, sampling the
+// nearest source pixel.
+func (r *raster) image(src image.Image, x, y, w, h, degrees float64, flipH, flipV bool, crop domain.ImageCrop) {
+	bounds := src.Bounds()
+	if w <= 0 || h <= 0 || bounds.Empty() {
+		return
+	}
+	rad := degrees * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	cx, cy := (x+w/2)*r.scale, (y+h/2)*r.scale
+	hw, hh := w/2*r.scale, h/2*r.scale
+	reach := math.Hypot(hw, hh)
+
+	visibleW := math.Max(1-(crop.Left+crop.Right)/100, 0.01)
+	visibleH := math.Max(1-(crop.Top+crop.Bottom)/100, 0.01)
+	for py := int(cy - reach); float64(py) <= cy+reach; py++ {
+		for px := int(cx - reach); float64(px) <= cx+reach; px++ {
+			// Undo the rotation to find the point in the unrotated box.
+			dx, dy := float64(px)+0.5-cx, float64(py)+0.5-cy
+			lx, ly := dx*cos+dy*sin, -dx*sin+dy*cos
+			if math.Abs(lx) > hw || math.Abs(ly) > hh {
+				continue
+			}
+			u, v := (lx+hw)/(2*hw), (ly+hh)/(2*hh)
+			if flipH {
+				u = 1 - u
+			}
+			if flipV {
+				v = 1 - v
+			}
+			u = crop.Left/100 + u*visibleW
+			v = crop.Top/100 + v*visibleH
+			sx := bounds.Min.X + min(int(u*float64(bounds.Dx())), bounds.Dx()-1)
+			sy := bounds.Min.Y + min(int(v*float64(bounds.Dy())), bounds.Dy()-1)
+			c := color.NRGBAModel.Convert(src.At(sx, sy)).(color.NRGBA)
+			r.blend(px, py, c, 1)
+		}
+	}
+}
+
+// outline strokes the edges of a box.
+func (r *raster) outline(x, y, w, h, width float64, col domain.Color) {
+	r.line(x, y, x+w, y, width, col)
+	r.line(x, y+h, x+w, y+h, width, col)
+	r.line(x, y, x, y+h, width, col)
+	r.line(x+w, y, x+w, y+h, width, col)
+}
@@ -0,0 +1,277 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"slices"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/constants"
+)
+
+// highlightColors are the colors of the highlight palette.
+var highlightColors = map[domain.HighlightColor]domain.Color{
+	domain.HighlightYellow:      {R: 255, G: 255},
+	domain.HighlightGreen:       {G: 255},
+	domain.HighlightCyan:        {G: 255, B: 255},
+	domain.HighlightMagenta:     {R: 255, B: 255},
+	domain.HighlightBlue:        {B: 255},
+	domain.HighlightRed:         {R: 255},
+	domain.HighlightDarkBlue:    {B: 128},
+	domain.HighlightDarkCyan:    {G: 128, B: 128},
+	domain.HighlightDarkGreen:   {G: 128},
+	domain.HighlightDarkMagenta: {R: 128, B: 128},
+	domain.HighlightDarkRed:     {R: 128},
+	domain.HighlightDarkYellow:  {R: 128, G: 128},
+	domain.HighlightDarkGray:    {R: 128, G: 128, B: 128},
+	domain.HighlightLightGray:   {R: 192, G: 192, B: 192},
+}
+
+// runFormat is character formatting before fonts are resolved.
+type runFormat struct {
+	font      domain.Font
+	size      int // Half-points
+	bold      bool
+	italic    bool
+	underline domain.UnderlineStyle
+	strike    bool
+	color     domain.Color
+	highlight domain.HighlightColor
+	script    domain.Script
+}
+
+// paraFormat is the resolved formatting of a paragraph, in points.
+type paraFormat struct {
+	align           domain.Alignment
+	before, after   float64
+	line            domain.LineSpacing
+	left, right     float64
+	firstLine       float64 // Negative for a hanging indent
+	keepNext        bool
+	keepLines       bool
+	pageBreakBefore bool
+	borders         domain.ParagraphBorders
+	run             runFormat // Formatting of the paragraph mark
+}
+
+// textStyle is the resolved formatting of a piece of text.
+type textStyle struct {
+	font         *font
+	size         float64
+	color        domain.Color
+	underline    domain.UnderlineStyle
+	strike       bool
+	highlight    domain.Color
+	hasHighlight bool
+	rise         float64 // Baseline shift, up
+	link         string
+}
+
+// styleChain returns a paragraph style and the styles it is based on,
+// the root first.
+func (r *renderer) styleChain(id string) []domain.ParagraphStyle {
+	if id == "" {
+		id = domain.StyleIDNormal
+	}
+	if chain, ok := r.styles[id]; ok {
+		return chain
+	}
+	var chain []domain.ParagraphStyle
+	manager := r.doc.StyleManager()
+	for next := id; next != "" && len(chain) < 10; {
+		style, err := manager.GetStyle(next)
+		if err != nil {
+			break
+		}
+		ps, ok := style.(domain.ParagraphStyle)
+		if !ok {
+			break
+		}
+		chain = append(chain, ps)
+		next = ps.BasedOn()
+	}
+	slices.Reverse(chain)
+	r.styles[id] = chain
+	return chain
+}
+
+// paragraphFormat resolves the formatting of p from its style chain and
+// direct formatting. As in the other exporters, default values do not
+// override inherited ones.
+func (r *renderer) paragraphFormat(p domain.Paragraph) paraFormat {
+	var id string
+	if named, ok := p.(interface{ StyleName() string }); ok {
+		id = named.StyleName()
+	}
+	f, indent := r.styleFormat(id)
+
+	if p.Alignment() != domain.AlignmentLeft {
+		f.align = p.Alignment()
+	}
+	if before := p.SpacingBefore(); before > 0 {
+		f.before = twips(before)
+	}
+	if after := p.SpacingAfter(); after > 0 {
+		f.after = twips(after)
+	}
+	if line := p.LineSpacing(); line.Value > 0 {
+		f.line = line
+	}
+	if in := p.Indent(); in != (domain.Indentation{}) {
+		indent = in
+	}
+	f.left, f.right = twips(indent.Left), twips(indent.Right)
+	f.firstLine = twips(indent.FirstLine - indent.Hanging)
+	f.borders = p.Borders()
+	return f
+}
+
+// styleFormat resolves the formatting of a paragraph style and returns
+// its indentation, which direct indentation replaces as a whole.
+func (r *renderer) styleFormat(id string) (paraFormat, domain.Indentation) {
+	f := paraFormat{
+		line: domain.LineSpacing{Rule: domain.LineSpacingAuto, Value: constants.DefaultLineSpacing},
+		run:  runFormat{font: domain.Font{Name: constants.DefaultFontName}, size: constants.DefaultFontSize},
+	}
+	var indent domain.Indentation
+	for _, style := range r.styleChain(id) {
+		if style.Alignment() != domain.AlignmentLeft {
+			f.align = style.Alignment()
+		}
+		if before := style.SpacingBefore(); before > 0 {
+			f.before = twips(before)
+		}
+		if after := style.SpacingAfter(); after > 0 {
+			f.after = twips(after)
+		}
+		if line := style.LineSpacing(); line > 0 && line != constants.DefaultLineSpacing {
+			f.line = domain.LineSpacing{Rule: domain.LineSpacingAuto, Value: line}
+		}
+		if in := style.Indentation(); in != (domain.Indentation{}) {
+			indent = in
+		}
+		f.keepNext = f.keepNext || style.KeepNext()
+		f.keepLines = f.keepLines || style.KeepLines()
+		f.pageBreakBefore = f.pageBreakBefore || style.PageBreakBefore()
+
+		if font := style.Font(); font.Theme != "" || (font.Name != "" && font.Name != constants.DefaultFontName) {
+			f.run.font = font
+		}
+		if size := style.Size(); size > 0 && size != constants.DefaultFontSize {
+			f.run.size = size
+		}
+		f.run.bold = f.run.bold || style.Bold()
+		f.run.italic = f.run.italic || style.Italic()
+		if u := style.Underline(); u != domain.UnderlineNone {
+			f.run.underline = u
+		}
+		if ref, ok := style.ThemeColor(); ok {
+			f.run.color = r.themeColor(ref)
+		} else if c := style.Color(); c != domain.ColorBlack {
+			f.run.color = c
+		}
+	}
+	return f, indent
+}
+
+// runFormat applies the direct formatting of run to the paragraph's.
+func (r *renderer) runFormat(base runFormat, run domain.Run) runFormat {
+	f := base
+	if font := run.Font(); font.Theme != "" || (font.Name != "" && font.Name != constants.DefaultFontName) {
+		f.font = font
+	}
+	if size := run.Size(); size > 0 && size != constants.DefaultFontSize {
+		f.size = size
+	}
+	f.bold = f.bold || run.Bold()
+	f.italic = f.italic || run.Italic()
+	if u := run.Underline(); u != domain.UnderlineNone {
+		f.underline = u
+	}
+	f.strike = f.strike || run.Strike()
+	if ref, ok := run.ThemeColor(); ok {
+		f.color = r.themeColor(ref)
+	} else if c := run.Color(); c != domain.ColorBlack {
+		f.color = c
+	}
+	if h := run.Highlight(); h != domain.HighlightNone {
+		f.highlight = h
+	}
+	if s := run.Script(); s != domain.ScriptBaseline {
+		f.script = s
+	}
+	return f
+}
+
+// textStyle resolves the font and size of a run format.
+func (r *renderer) textStyle(f runFormat) textStyle {
+	family := f.font.Name
+	switch f.font.Theme {
+	case domain.ThemeFontMajor:
+		family = r.theme.MajorFont
+	case domain.ThemeFontMinor:
+		family = r.theme.MinorFont
+	}
+	if family == "" {
+		family = constants.DefaultFontName
+	}
+	size := float64(f.size) / 2
+	if size <= 0 {
+		size = constants.DefaultFontSize / 2
+	}
+	s := textStyle{
+		font:      r.fonts.get(family, f.bold, f.italic),
+		size:      size,
+		color:     f.color,
+		underline: f.underline,
+		strike:    f.strike,
+	}
+	s.highlight, s.hasHighlight = highlightColors[f.highlight]
+	switch f.script {
+	case domain.ScriptSuperscript:
+		s.size, s.rise = size*0.65, size*0.33
+	case domain.ScriptSubscript:
+		s.size, s.rise = size*0.65, -size*0.14
+	}
+	return s
+}
+
+// themeColor resolves a theme color reference against the document theme.
+func (r *renderer) themeColor(ref domain.ThemeColorRef) domain.Color {
+	c, _ := r.theme.Colors.Color(ref.Color)
+	if ref.Shade != 0 {
+		c = color.Shade(c, ref.Shade)
+	}
+	if ref.Tint != 0 {
+		c = color.Tint(c, ref.Tint)
+	}
+	return c
+}
+
+// twips converts twips to points.
+func twips(v int) float64 {
+	return float64(v) / 20
+}
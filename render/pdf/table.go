@@ -0,0 +1,260 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"math"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/internal/layout"
+)
+
+// gridBorder is drawn around the cells of tables with a style other than
+// Table Normal when the cells have no borders of their own.
+var gridBorder = domain.BorderStyle{Style: domain.BorderSingle, Width: 4, Color: domain.ColorBlack}
+
+// cellBox is a laid out cell, spanning merged rows and columns.
+type cellBox struct {
+	cell      domain.TableCell
+	row, col  int
+	rows      int
+	cols      int
+	bands     []band
+	content   float64
+	x, width  float64
+	alignment domain.VerticalAlignment
+}
+
+// tableBands lays out a table in width points. Rows joined by vertically
+// merged cells form one band, so a merged cell is never split across
+// pages.
+func (r *renderer) tableBands(t domain.Table, width float64) []band {
+	rows := t.Rows()
+	grid := t.Grid()
+	if len(rows) == 0 || len(grid) == 0 {
+		return nil
+	}
+
+	margin := twips(layout.DefaultCellMargin)
+	widths := r.columnWidths(t, grid, width, margin)
+	offsets := make([]float64, len(widths)+1)
+	for i, w := range widths {
+		offsets[i+1] = offsets[i] + w
+	}
+	shift := 0.0
+	switch t.Alignment() {
+	case domain.AlignmentCenter:
+		shift = math.Max((width-offsets[len(widths)])/2, 0)
+	case domain.AlignmentRight:
+		shift = math.Max(width-offsets[len(widths)], 0)
+	}
+
+	heights := make([]float64, len(rows))
+	var cells []*cellBox
+	for ri, row := range rows {
+		heights[ri] = twips(row.Height())
+		if ri >= len(grid) {
+			continue
+		}
+		for ci, info := range grid[ri] {
+			if !info.IsOrigin(ri, ci) || ci >= len(widths) {
+				continue
+			}
+			cell, err := row.Cell(ci)
+			if err != nil || cell == nil {
+				continue
+			}
+			box := &cellBox{cell: cell, row: ri, col: ci, rows: max(info.RowSpan, 1), cols: max(info.GridSpan, 1),
+				alignment: cell.VerticalAlignment()}
+			end := min(ci+box.cols, len(widths))
+			box.x, box.width = offsets[ci], offsets[end]-offsets[ci]
+			box.rows = min(box.rows, len(rows)-ri)
+			box.bands = r.cellBands(cell, math.Max(box.width-2*margin, 1))
+			for _, b := range box.bands {
+				box.content += b.height
+			}
+			if box.rows == 1 {
+				heights[ri] = math.Max(heights[ri], box.content)
+			}
+			cells = append(cells, box)
+		}
+	}
+	for _, box := range cells {
+		if box.rows > 1 {
+			have := 0.0
+			for _, h := range heights[box.row : box.row+box.rows] {
+				have += h
+			}
+			if box.content > have {
+				heights[box.row+box.rows-1] += box.content - have
+			}
+		}
+	}
+
+	styled := t.Style().Name != "" && t.Style().Name != domain.StyleIDTableNormal
+	var bands []band
+	for start := 0; start < len(rows); {
+		end := start + 1
+		for ri := start; ri < end; ri++ {
+			for _, box := range cells {
+				if box.row == ri {
+					end = max(end, box.row+box.rows)
+				}
+			}
+		}
+		tops := make([]float64, end-start+1)
+		for ri := start; ri < end; ri++ {
+			tops[ri-start+1] = tops[ri-start] + heights[ri]
+		}
+		var group []*cellBox
+		for _, box := range cells {
+			if box.row >= start && box.row < end {
+				group = append(group, box)
+			}
+		}
+		var paragraphs []domain.Paragraph
+		for _, box := range group {
+			for _, b := range box.bands {
+				paragraphs = append(paragraphs, b.paragraphs...)
+			}
+		}
+		first := start
+		bands = append(bands, band{height: tops[end-start], paragraphs: paragraphs, draw: func(c *canvas, x, y, _ float64) {
+			for _, box := range group {
+				drawCell(c, box, x+shift+box.x, y+tops[box.row-first], tops[box.row-first+box.rows]-tops[box.row-first], margin, styled)
+			}
+		}})
+		start = end
+	}
+	return bands
+}
+
+// columnWidths returns the grid column widths in points. The widths of
+// auto tables are measured with Word's metrics; columns are widened to
+// fit their longest word in the fonts actually used, within the
+// available width.
+func (r *renderer) columnWidths(t domain.Table, grid [][]domain.CellMergeInfo, available, margin float64) []float64 {
+	twipWidths := layout.ColumnWidths(t, int(available*20))
+	widths := make([]float64, len(twipWidths))
+	total := 0.0
+	for i, w := range twipWidths {
+		widths[i] = twips(w)
+		total += widths[i]
+	}
+	if t.Width().Type == domain.WidthDXA {
+		return widths
+	}
+
+	grown := false
+	for ri, row := range t.Rows() {
+		if ri >= len(grid) {
+			break
+		}
+		for ci, info := range grid[ri] {
+			if !info.IsOrigin(ri, ci) || max(info.GridSpan, 1) != 1 || ci >= len(widths) {
+				continue
+			}
+			cell, err := row.Cell(ci)
+			if err != nil || cell == nil {
+				continue
+			}
+			if need := r.longestWord(cell) + 2*margin; need > widths[ci] {
+				total += need - widths[ci]
+				widths[ci] = need
+				grown = true
+			}
+		}
+	}
+	if grown && total > available {
+		for i := range widths {
+			widths[i] *= available / total
+		}
+	}
+	return widths
+}
+
+// longestWord returns the width of the longest word in a cell.
+func (r *renderer) longestWord(cell domain.TableCell) float64 {
+	longest := 0.0
+	for _, p := range cell.Paragraphs() {
+		base := r.paragraphFormat(p).run
+		for _, run := range p.Runs() {
+			style := r.textStyle(r.runFormat(base, run))
+			for _, word := range strings.Fields(run.Text()) {
+				longest = math.Max(longest, style.font.width(word, style.size))
+			}
+		}
+	}
+	return longest
+}
+
+// cellBands lays out the paragraphs and nested tables of a cell.
+func (r *renderer) cellBands(cell domain.TableCell, width float64) []band {
+	var bands []band
+	for _, p := range cell.Paragraphs() {
+		bands = append(bands, r.paragraphBands(p, width, "")...)
+	}
+	for _, nested := range cell.Tables() {
+		bands = append(bands, r.tableBands(nested, width)...)
+	}
+	if len(bands) == 0 {
+		// Empty cells hold the empty paragraph the serializer writes.
+		f, _ := r.styleFormat("")
+		l := line{}
+		l.measure(f.line, r.textStyle(f.run))
+		bands = append(bands, band{height: f.before + l.height + f.after})
+	}
+	return bands
+}
+
+// drawCell draws the shading, content and borders of a cell.
+func drawCell(c *canvas, box *cellBox, x, y, height, margin float64, styled bool) {
+	if shading := box.cell.Shading(); shading != domain.ColorWhite && shading != (domain.Color{}) {
+		c.fillRect(x, y, box.width, height, shading)
+	}
+
+	offset := 0.0
+	switch box.alignment {
+	case domain.VerticalAlignCenter:
+		offset = (height - box.content) / 2
+	case domain.VerticalAlignBottom:
+		offset = height - box.content
+	}
+	drawBands(c, box.bands, x+margin, y+math.Max(offset, 0), math.Max(box.width-2*margin, 1))
+
+	borders := box.cell.Borders()
+	side := func(b domain.BorderStyle) domain.BorderStyle {
+		if b.Style == domain.BorderNone && styled {
+			return gridBorder
+		}
+		return b
+	}
+	right, bottom := x+box.width, y+height
+	c.border(side(borders.Top), x, y, right, y, 0, -1)
+	c.border(side(borders.Bottom), x, bottom, right, bottom, 0, 1)
+	c.border(side(borders.Left), x, y, x, bottom, -1, 0)
+	c.border(side(borders.Right), right, y, right, bottom, 1, 0)
+}
@@ -0,0 +1,373 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"encoding/binary"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+	"github.com/mmonterroca/docxgo/v2/pkg/fontmetrics"
+)
+
+const opParseFont = "pdf.parseTrueType"
+
+// subsetTables are the TrueType tables a CIDFontType2 subset keeps;
+// glyphs are addressed by index, so cmap is not needed.
+var subsetTables = []string{"cvt ", "fpgm", "glyf", "head", "hhea", "hmtx", "loca", "maxp", "prep"}
+
+// trueType is a parsed TrueType font with glyph outlines (glyf).
+type trueType struct {
+	family         string
+	postscriptName string
+	bold, italic   bool
+
+	tables     map[string][]byte
+	glyphs     map[rune]int
+	unitsPerEm int
+	advances   []int // By glyph index
+	numGlyphs  int
+
+	ascent, descent, lineGap, capHeight int
+	bbox                                [4]int
+	italicAngle                         float64
+	fixedPitch                          bool
+}
+
+// parseTrueType reads a TrueType font or the first font of a collection.
+// CFF-based OpenType fonts are not supported.
+func parseTrueType(data []byte) (*trueType, error) {
+	tables, err := fontmetrics.Tables(data)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range []string{"glyf", "head", "hhea", "hmtx", "loca", "maxp"} {
+		if _, ok := tables[tag]; !ok {
+			return nil, errors.InvalidArgument(opParseFont, "data", tag, "missing "+tag+" table; only TrueType outlines are supported")
+		}
+	}
+	head, hhea, maxp := tables["head"], tables["hhea"], tables["maxp"]
+	if len(head) < 54 || len(hhea) < 36 || len(maxp) < 6 {
+		return nil, errors.InvalidArgument(opParseFont, "data", len(data), "truncated font tables")
+	}
+	glyphs, err := fontmetrics.GlyphIndices(data)
+	if err != nil {
+		return nil, err
+	}
+
+	be := binary.BigEndian
+	t := &trueType{
+		tables:     tables,
+		glyphs:     glyphs,
+		unitsPerEm: int(be.Uint16(head[18:])),
+		numGlyphs:  int(be.Uint16(maxp[4:])),
+		ascent:     int(int16(be.Uint16(hhea[4:]))),
+		descent:    int(int16(be.Uint16(hhea[6:]))),
+		lineGap:    int(int16(be.Uint16(hhea[8:]))),
+		bbox: [4]int{
+			int(int16(be.Uint16(head[36:]))), int(int16(be.Uint16(head[38:]))),
+			int(int16(be.Uint16(head[40:]))), int(int16(be.Uint16(head[42:]))),
+		},
+	}
+	if t.unitsPerEm <= 0 {
+		return nil, errors.InvalidArgument(opParseFont, "unitsPerEm", t.unitsPerEm, "invalid units per em")
+	}
+	macStyle := be.Uint16(head[44:])
+	t.bold, t.italic = macStyle&1 != 0, macStyle&2 != 0
+
+	hmtx := tables["hmtx"]
+	numHMetrics := int(be.Uint16(hhea[34:]))
+	if numHMetrics == 0 || len(hmtx) < numHMetrics*4 {
+		return nil, errors.InvalidArgument(opParseFont, "hmtx", len(hmtx), "truncated hmtx table")
+	}
+	t.advances = make([]int, max(t.numGlyphs, numHMetrics))
+	for g := range t.advances {
+		t.advances[g] = int(be.Uint16(hmtx[min(g, numHMetrics-1)*4:]))
+	}
+
+	t.capHeight = t.ascent * 7 / 10
+	if os2 := tables["OS/2"]; len(os2) >= 90 && be.Uint16(os2) >= 2 {
+		t.capHeight = int(int16(be.Uint16(os2[88:])))
+	}
+	if os2 := tables["OS/2"]; len(os2) >= 64 {
+		selection := be.Uint16(os2[62:])
+		t.bold = t.bold || selection&0x20 != 0
+		t.italic = t.italic || selection&0x01 != 0
+	}
+	if post := tables["post"]; len(post) >= 16 {
+		t.italicAngle = float64(int32(be.Uint32(post[4:]))) / 65536
+		t.fixedPitch = be.Uint32(post[12:]) != 0
+	}
+
+	t.family, t.postscriptName = fontNames(tables["name"])
+	if t.family == "" {
+		return nil, errors.InvalidArgument(opParseFont, "name", len(tables["name"]), "font has no family name")
+	}
+	if t.postscriptName == "" {
+		t.postscriptName = strings.ReplaceAll(t.family, " ", "")
+	}
+	return t, nil
+}
+
+// fontNames returns the family and PostScript names from a name table,
+// preferring Windows English records. The legacy family (name 1) is the
+// one Word documents refer to.
+func fontNames(table []byte) (family, postscript string) {
+	if len(table) < 6 {
+		return "", ""
+	}
+	be := binary.BigEndian
+	count := int(be.Uint16(table[2:]))
+	storage := int(be.Uint16(table[4:]))
+	scores := map[int]int{}
+	for i := 0; i < count; i++ {
+		rec := 6 + i*12
+		if rec+12 > len(table) {
+			break
+		}
+		platform, encoding := be.Uint16(table[rec:]), be.Uint16(table[rec+2:])
+		language, id := be.Uint16(table[rec+4:]), int(be.Uint16(table[rec+6:]))
+		length, offset := int(be.Uint16(table[rec+8:])), int(be.Uint16(table[rec+10:]))
+		if (id != 1 && id != 6) || storage+offset+length > len(table) {
+			continue
+		}
+		raw := table[storage+offset : storage+offset+length]
+
+		var value string
+		score := 0
+		switch {
+		case platform == 3 && (encoding == 1 || encoding == 10), platform == 0:
+			units := make([]uint16, len(raw)/2)
+			for j := range units {
+				units[j] = be.Uint16(raw[j*2:])
+			}
+			value = string(utf16.Decode(units))
+			score = 2
+			if platform == 3 && language == 0x409 {
+				score = 3
+			}
+		case platform == 1 && encoding == 0:
+			value = string(raw)
+			score = 1
+		default:
+			continue
+		}
+		if score <= scores[id] || strings.TrimSpace(value) == "" {
+			continue
+		}
+		scores[id] = score
+		if id == 1 {
+			family = value
+		} else {
+			postscript = value
+		}
+	}
+	return strings.TrimSpace(family), strings.TrimSpace(postscript)
+}
+
+// glyph returns the glyph index for r, or 0 (.notdef).
+func (t *trueType) glyph(r rune) int {
+	return t.glyphs[r]
+}
+
+// advance returns the advance of glyph g in 1/1000 em.
+func (t *trueType) advance(g int) float64 {
+	if g < 0 || g >= len(t.advances) {
+		return 0
+	}
+	return float64(t.advances[g]) * 1000 / float64(t.unitsPerEm)
+}
+
+// scale converts font units to 1/1000 em.
+func (t *trueType) scale(v int) int {
+	return v * 1000 / t.unitsPerEm
+}
+
+// subset returns a TrueType font holding only the used glyphs, plus
+// the components of composite glyphs. Glyph indices are unchanged;
+// unused glyphs are left empty.
+func (t *trueType) subset(used map[int]bool) []byte {
+	be := binary.BigEndian
+	head, loca, glyf := t.tables["head"], t.tables["loca"], t.tables["glyf"]
+	long := be.Uint16(head[50:]) == 1
+	offset := func(g int) (int, int) {
+		if long {
+			if (g+2)*4 > len(loca) {
+				return 0, 0
+			}
+			return int(be.Uint32(loca[g*4:])), int(be.Uint32(loca[g*4+4:]))
+		}
+		if (g+2)*2 > len(loca) {
+			return 0, 0
+		}
+		return int(be.Uint16(loca[g*2:])) * 2, int(be.Uint16(loca[g*2+2:])) * 2
+	}
+	data := func(g int) []byte {
+		start, end := offset(g)
+		if start >= end || end > len(glyf) {
+			return nil
+		}
+		return glyf[start:end]
+	}
+
+	keep := map[int]bool{0: true}
+	pending := make([]int, 0, len(used)+1)
+	pending = append(pending, 0)
+	for g := range used {
+		pending = append(pending, g)
+	}
+	for len(pending) > 0 {
+		g := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		keep[g] = true
+		for _, component := range components(data(g)) {
+			if !keep[component] && component < t.numGlyphs {
+				keep[component] = true
+				pending = append(pending, component)
+			}
+		}
+	}
+
+	var newGlyf []byte
+	newLoca := make([]byte, (t.numGlyphs+1)*4)
+	for g := 0; g < t.numGlyphs; g++ {
+		be.PutUint32(newLoca[g*4:], uint32(len(newGlyf)))
+		if keep[g] {
+			newGlyf = append(newGlyf, data(g)...)
+			for len(newGlyf)%4 != 0 {
+				newGlyf = append(newGlyf, 0)
+			}
+		}
+	}
+	be.PutUint32(newLoca[t.numGlyphs*4:], uint32(len(newGlyf)))
+
+	newHead := append([]byte(nil), head...)
+	be.PutUint32(newHead[8:], 0)  // checkSumAdjustment, set below
+	be.PutUint16(newHead[50:], 1) // Long loca offsets
+
+	tables := map[string][]byte{}
+	for _, tag := range subsetTables {
+		if table, ok := t.tables[tag]; ok {
+			tables[tag] = table
+		}
+	}
+	tables["glyf"], tables["loca"], tables["head"] = newGlyf, newLoca, newHead
+	font := assembleFont(tables)
+
+	sum := uint32(0)
+	for i := 0; i+4 <= len(font); i += 4 {
+		sum += be.Uint32(font[i:])
+	}
+	headOffset := 0
+	for i := 0; i < len(tables); i++ {
+		rec := font[12+i*16:]
+		if string(rec[:4]) == "head" {
+			headOffset = int(be.Uint32(rec[8:]))
+		}
+	}
+	be.PutUint32(font[headOffset+8:], 0xB1B0AFBA-sum)
+	return font
+}
+
+// components returns the glyphs a composite glyph is built from.
+func components(glyph []byte) []int {
+	be := binary.BigEndian
+	if len(glyph) < 10 || int16(be.Uint16(glyph)) >= 0 {
+		return nil
+	}
+	var out []int
+	for i := 10; i+4 <= len(glyph); {
+		flags := be.Uint16(glyph[i:])
+		out = append(out, int(be.Uint16(glyph[i+2:])))
+		i += 4
+		if flags&0x0001 != 0 { // ARG_1_AND_2_ARE_WORDS
+			i += 4
+		} else {
+			i += 2
+		}
+		switch {
+		case flags&0x0008 != 0: // WE_HAVE_A_SCALE
+			i += 2
+		case flags&0x0040 != 0: // WE_HAVE_AN_X_AND_Y_SCALE
+			i += 4
+		case flags&0x0080 != 0: // WE_HAVE_A_TWO_BY_TWO
+			i += 8
+		}
+		if flags&0x0020 == 0 { // MORE_COMPONENTS
+			break
+		}
+	}
+	return out
+}
+
+// assembleFont writes an sfnt file from its tables.
+func assembleFont(tables map[string][]byte) []byte {
+	be := binary.BigEndian
+	tags := make([]string, 0, len(tables))
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	n := len(tags)
+	entrySelector := 0
+	for 1<<(entrySelector+1) <= n {
+		entrySelector++
+	}
+	searchRange := (1 << entrySelector) * 16
+
+	font := make([]byte, 12+n*16)
+	be.PutUint32(font, 0x00010000)
+	be.PutUint16(font[4:], uint16(n))
+	be.PutUint16(font[6:], uint16(searchRange))
+	be.PutUint16(font[8:], uint16(entrySelector))
+	be.PutUint16(font[10:], uint16(n*16-searchRange))
+	for i, tag := range tags {
+		data := tables[tag]
+		rec := font[12+i*16:]
+		copy(rec, tag)
+		be.PutUint32(rec[4:], checksum(data))
+		be.PutUint32(rec[8:], uint32(len(font)))
+		be.PutUint32(rec[12:], uint32(len(data)))
+		font = append(font, data...)
+		for len(font)%4 != 0 {
+			font = append(font, 0)
+		}
+	}
+	return font
+}
+
+// checksum is the TrueType table checksum.
+func checksum(data []byte) uint32 {
+	sum := uint32(0)
+	for i := 0; i < len(data); i += 4 {
+		var word [4]byte
+		copy(word[:], data[i:])
+		sum += binary.BigEndian.Uint32(word[:])
+	}
+	return sum
+}
@@ -0,0 +1,193 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// objectWriter collects numbered PDF objects and writes them with a
+// cross-reference table.
+type objectWriter struct {
+	objects [][]byte // Object n is objects[n-1]
+}
+
+// reserve allocates an object number to be filled in with set.
+func (w *objectWriter) reserve() int {
+	w.objects = append(w.objects, nil)
+	return len(w.objects)
+}
+
+// set stores the body of a reserved object.
+func (w *objectWriter) set(id int, body string) {
+	w.objects[id-1] = []byte(body)
+}
+
+// add stores a new object and returns its number.
+func (w *objectWriter) add(body string) int {
+	id := w.reserve()
+	w.set(id, body)
+	return id
+}
+
+// addStream stores a Flate-compressed stream. dict holds the entries
+// besides /Length and /Filter.
+func (w *objectWriter) addStream(dict string, data []byte) int {
+	var compressed bytes.Buffer
+	zw, _ := zlib.NewWriterLevel(&compressed, zlib.BestCompression)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<< %s /Length %d /Filter /FlateDecode >>\nstream\n", dict, compressed.Len())
+	b.Write(compressed.Bytes())
+	b.WriteString("\nendstream")
+	id := w.reserve()
+	w.objects[id-1] = b.Bytes()
+	return id
+}
+
+// addRawStream stores a stream whose data is already encoded by the
+// filters named in dict.
+func (w *objectWriter) addRawStream(dict string, data []byte) int {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "<< %s /Length %d >>\nstream\n", dict, len(data))
+	b.Write(data)
+	b.WriteString("\nendstream")
+	id := w.reserve()
+	w.objects[id-1] = b.Bytes()
+	return id
+}
+
+// writeTo writes the file with root as the catalog and info as the
+// document information dictionary (0 for none).
+func (w *objectWriter) writeTo(out io.Writer, root, info int) error {
+	bw := bufio.NewWriter(out)
+	offset := 0
+	write := func(s string) {
+		n, _ := bw.WriteString(s)
+		offset += n
+	}
+
+	write("%PDF-1.7\n%\xE2\xE3\xCF\xD3\n")
+	offsets := make([]int, len(w.objects))
+	for i, body := range w.objects {
+		offsets[i] = offset
+		write(strconv.Itoa(i+1) + " 0 obj\n")
+		n, _ := bw.Write(body)
+		offset += n
+		write("\nendobj\n")
+	}
+
+	xref := offset
+	write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", len(w.objects)+1))
+	for _, o := range offsets {
+		write(fmt.Sprintf("%010d 00000 n \n", o))
+	}
+	trailer := fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R", len(w.objects)+1, root)
+	if info > 0 {
+		trailer += fmt.Sprintf(" /Info %d 0 R", info)
+	}
+	write(trailer + " >>\nstartxref\n" + strconv.Itoa(xref) + "\n%%EOF\n")
+	return bw.Flush()
+}
+
+// ref formats an indirect reference.
+func ref(id int) string {
+	return strconv.Itoa(id) + " 0 R"
+}
+
+// num formats a number with at most three decimals.
+func num(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 3, 64)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" || s == "" {
+		return "0"
+	}
+	return s
+}
+
+// name formats a PDF name, escaping characters outside the regular set.
+func name(s string) string {
+	var b strings.Builder
+	b.WriteByte('/')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= ' ' || c >= 0x7F || strings.IndexByte("#()<>[]{}/%", c) >= 0 {
+			fmt.Fprintf(&b, "#%02X", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+// literal formats bytes as a PDF literal string.
+func literal(s []byte) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, c := range s {
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < ' ' || c >= 0x7F:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+// textString formats a text string for the document information and
+// annotations: PDFDocEncoding for ASCII, UTF-16BE otherwise.
+func textString(s string) string {
+	ascii := true
+	for _, r := range s {
+		if r >= 0x7F {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return literal([]byte(s))
+	}
+	var b strings.Builder
+	b.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&b, "%04X", u)
+	}
+	b.WriteByte('>')
+	return b.String()
+}
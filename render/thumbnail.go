@@ -0,0 +1,119 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+// Package render produces previews of documents.
+//
+// Pages are laid out by the pdf package and drawn approximately: text is
+// shown as grey bars, while tables, shading, borders and images keep
+// their place and colors. Previews are meant for file browsers and
+// document lists, not for reading.
+//
+//	png, err := render.Thumbnail(doc, 1, 48)
+//	if err != nil {
+//		return err
+//	}
+//	err = render.EmbedThumbnail(doc, 48) // shown by file browsers
+package render
+
+import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"image/png"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+	"github.com/mmonterroca/docxgo/v2/render/pdf"
+)
+
+// thumbnailQuality is the JPEG quality of embedded previews.
+const thumbnailQuality = 85
+
+// Thumbnail returns page (counted from 1) of doc as a PNG image of dpi
+// pixels per inch.
+func Thumbnail(doc domain.Document, page int, dpi float64) ([]byte, error) {
+	const op = "render.Thumbnail"
+	img, err := drawPage(op, doc, page, dpi)
+	if err != nil {
+		return nil, err
+	}
+	return encodePNG(op, img)
+}
+
+// Thumbnails returns up to pages first pages of doc as PNG images of dpi
+// pixels per inch. Fewer images are returned for shorter documents.
+func Thumbnails(doc domain.Document, pages int, dpi float64) ([][]byte, error) {
+	const op = "render.Thumbnails"
+	if pages < 1 {
+		return nil, errors.InvalidArgument(op, "pages", pages, "at least one page is required")
+	}
+	images, err := pdf.DrawPages(doc, 1, pages, dpi, pdf.Options{})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	out := make([][]byte, 0, len(images))
+	for _, img := range images {
+		data, err := encodePNG(op, img)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, data)
+	}
+	return out, nil
+}
+
+// EmbedThumbnail draws the first page of doc at dpi pixels per inch and
+// stores it as the document preview, saved to docProps/thumbnail.jpeg.
+// The preview is not updated when the document changes afterwards.
+func EmbedThumbnail(doc domain.Document, dpi float64) error {
+	const op = "render.EmbedThumbnail"
+	img, err := drawPage(op, doc, 1, dpi)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: thumbnailQuality}); err != nil {
+		return errors.Wrap(err, op)
+	}
+	return errors.Wrap(doc.SetThumbnail(buf.Bytes()), op)
+}
+
+func drawPage(op string, doc domain.Document, page int, dpi float64) (image.Image, error) {
+	images, err := pdf.DrawPages(doc, page, 1, dpi, pdf.Options{})
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if len(images) == 0 {
+		return nil, errors.InvalidArgument(op, "page", page, "document has fewer pages")
+	}
+	return images[0], nil
+}
+
+func encodePNG(op string, img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,66 @@
+package render_test
+
+import (
+	"bytes"
+	"image/png"
+	"math"
+	"testing"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/render"
+)
+
+func TestThumbnails(t *testing.T) {
+	doc := docx.NewDocument()
+	para, _ := doc.AddParagraph()
+	run, _ := para.AddRun()
+	_ = run.SetText("Quarterly report")
+
+	data, err := render.Thumbnail(doc, 1, 36)
+	if err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Thumbnail() is not a PNG: %v", err)
+	}
+	if size := img.Bounds().Size(); size.X != 298 || size.Y != 421 {
+		t.Errorf("thumbnail size = %v, want 298x421", size)
+	}
+	if _, err := render.Thumbnail(doc, 2, 36); err == nil {
+		t.Error("Thumbnail(page 2) of a one-page document succeeded")
+	}
+	if all, err := render.Thumbnails(doc, 3, 36); err != nil || len(all) != 1 {
+		t.Errorf("Thumbnails(3) = %d images, %v; want 1", len(all), err)
+	}
+	for _, dpi := range []float64{0, math.NaN(), math.Inf(1)} {
+		if _, err := render.Thumbnail(doc, 1, dpi); err == nil {
+			t.Errorf("Thumbnail(%v dpi) succeeded", dpi)
+		}
+	}
+}
+
+func TestEmbedThumbnail(t *testing.T) {
+	doc := docx.NewDocument()
+	para, _ := doc.AddParagraph()
+	run, _ := para.AddRun()
+	_ = run.SetText("Preview")
+
+	if err := render.EmbedThumbnail(doc, 24); err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	reopened, err := docx.OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(reopened.Thumbnail(), doc.Thumbnail()) || len(doc.Thumbnail()) == 0 {
+		t.Errorf("thumbnail not kept on reopen: %d bytes, want %d", len(reopened.Thumbnail()), len(doc.Thumbnail()))
+	}
+	if err := render.EmbedThumbnail(nil, 24); err == nil {
+		t.Error("EmbedThumbnail(nil) succeeded")
+	}
+}
@@ -0,0 +1,660 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+Copyright (c) 2020-2023 fumiama (original go-docx)
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package docx
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// DataTableOptions controls how TableFromStructs, TableFromCSV and
+// TableFromRecords render their data. The zero value produces a grid-styled
+// table with a bold header row and no shading.
+type DataTableOptions struct {
+	// Style is the table style. Defaults to domain.TableStyleGrid.
+	Style domain.TableStyle
+
+	// Width is the overall table width. Defaults to auto.
+	Width domain.TableWidth
+
+	// NoHeader treats the first CSV record / [][]string row as data instead of
+	// column headers. Ignored by TableFromStructs.
+	NoHeader bool
+
+	// PlainHeader disables bold text in the header row.
+	PlainHeader bool
+
+	// HeaderShading is the background color of the header row, if any.
+	HeaderShading *domain.Color
+
+	// HeaderTextColor is the text color of the header row, if any.
+	HeaderTextColor *domain.Color
+
+	// ZebraShading shades every other data row with the given color.
+	ZebraShading *domain.Color
+
+	// ColumnWidths sets column widths in twips, overriding struct tags.
+	// A zero entry leaves the column width unchanged.
+	ColumnWidths []int
+
+	// NoNumericAlign disables automatic right alignment of numeric columns.
+	NoNumericAlign bool
+
+	// TotalsRow appends a bold row summing numeric columns.
+	TotalsRow bool
+
+	// TotalsLabel is written in the first non-summed column of the totals row,
+	// or before the first sum when every column is summed. Defaults to "Total".
+	TotalsLabel string
+
+	// TotalColumns lists the zero-based columns summed in the totals row.
+	// When empty, columns tagged `total` are summed, or every numeric column
+	// when no column is tagged.
+	TotalColumns []int
+}
+
+// dataColumn describes a single column of a data-driven table.
+type dataColumn struct {
+	header   string
+	width    int
+	align    domain.Alignment
+	alignSet bool
+	format   string
+	numeric  bool
+	integer  bool
+	total    bool
+	field    []int // struct field index path (TableFromStructs only)
+}
+
+// dataCell holds the rendered text and, for numeric values, the parsed number.
+type dataCell struct {
+	text    string
+	number  float64
+	numeric bool
+}
+
+// TableFromStructs appends a table to doc with one row per element of rows.
+// T must be a struct or a pointer to a struct. Columns come from exported
+// fields and are configured with the `docx` struct tag:
+//
+//	type Sale struct {
+//		Region string  `docx:"Region,width=2400"`
+//		Amount float64 `docx:"Amount,align=right,format=%.2f,total"`
+//		Units  int     `docx:"Units" docxformat:"%d units, boxed"`
+//		Notes  string  `docx:"-"`
+//	}
+//
+// The first tag element is the header text (defaults to the field name).
+// Supported options are width (twips), align (left, center, right, justify),
+// format (a fmt verb applied to the value) and total (sum in the totals row).
+// A format containing commas goes in its own docxformat tag, which takes
+// precedence over the format option.
+// Numeric fields are right-aligned unless an explicit alignment is given.
+func TableFromStructs[T any](doc domain.Document, rows []T, opts *DataTableOptions) (domain.Table, error) {
+	const op = "docx.TableFromStructs"
+
+	if doc == nil {
+		return nil, errors.InvalidArgument(op, "doc", nil, "document cannot be nil")
+	}
+
+	elemType := reflect.TypeOf((*T)(nil)).Elem()
+	structType := elemType
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, errors.InvalidArgument(op, "rows", elemType.String(),
+			"element type must be a struct or pointer to struct")
+	}
+
+	columns, err := structColumns(structType)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if len(columns) == 0 {
+		return nil, errors.InvalidArgument(op, "rows", structType.String(),
+			"struct has no exported fields to render")
+	}
+
+	data := make([][]dataCell, 0, len(rows))
+	for _, item := range rows {
+		value := reflect.ValueOf(item)
+		if value.Kind() == reflect.Ptr {
+			if value.IsNil() {
+				continue
+			}
+			value = value.Elem()
+		}
+
+		cells := make([]dataCell, len(columns))
+		for i, col := range columns {
+			cells[i] = formatStructValue(value.FieldByIndex(col.field), col)
+		}
+		data = append(data, cells)
+	}
+
+	return buildDataTable(doc, op, columns, data, opts, true)
+}
+
+// TableFromCSV reads CSV data from r and appends it to doc as a table.
+// Unless opts.NoHeader is set, the first record supplies the column headers.
+func TableFromCSV(doc domain.Document, r io.Reader, opts *DataTableOptions) (domain.Table, error) {
+	const op = "docx.TableFromCSV"
+
+	if r == nil {
+		return nil, errors.InvalidArgument(op, "r", nil, "reader cannot be nil")
+	}
+
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+	records, err := csvReader.ReadAll()
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+
+	table, err := TableFromRecords(doc, records, opts)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return table, nil
+}
+
+// TableFromRecords appends a table built from rows of strings to doc.
+// Unless opts.NoHeader is set, the first record supplies the column headers.
+// Columns whose values all parse as numbers are right-aligned and can be
+// summed in a totals row. Short records are padded with empty cells.
+func TableFromRecords(doc domain.Document, records [][]string, opts *DataTableOptions) (domain.Table, error) {
+	const op = "docx.TableFromRecords"
+
+	if doc == nil {
+		return nil, errors.InvalidArgument(op, "doc", nil, "document cannot be nil")
+	}
+	if len(records) == 0 {
+		return nil, errors.InvalidArgument(op, "records", 0, "at least one record is required")
+	}
+
+	cols := 0
+	for _, record := range records {
+		if len(record) > cols {
+			cols = len(record)
+		}
+	}
+	if cols == 0 {
+		return nil, errors.InvalidArgument(op, "records", records, "records contain no columns")
+	}
+
+	header := !(opts != nil && opts.NoHeader)
+	body := records
+	columns := make([]dataColumn, cols)
+	if header {
+		for i, text := range records[0] {
+			columns[i].header = text
+		}
+		body = records[1:]
+	}
+
+	data := make([][]dataCell, len(body))
+	for r, record := range body {
+		cells := make([]dataCell, cols)
+		for c := range cells {
+			if c < len(record) {
+				cells[c] = parseDataCell(record[c])
+			}
+		}
+		data[r] = cells
+	}
+
+	// A column is numeric when every non-empty value parses as a number.
+	for c := range columns {
+		seen := false
+		numeric := true
+		for _, cells := range data {
+			if strings.TrimSpace(cells[c].text) == "" {
+				continue
+			}
+			seen = true
+			if !cells[c].numeric {
+				numeric = false
+				break
+			}
+		}
+		columns[c].numeric = seen && numeric
+	}
+
+	return buildDataTable(doc, op, columns, data, opts, header)
+}
+
+// buildDataTable creates and fills the table shared by the data helpers.
+func buildDataTable(doc domain.Document, op string, columns []dataColumn, data [][]dataCell, opts *DataTableOptions, header bool) (domain.Table, error) {
+	if opts == nil {
+		opts = &DataTableOptions{}
+	}
+
+	for i, width := range opts.ColumnWidths {
+		if i >= len(columns) {
+			break
+		}
+		if width < 0 {
+			return nil, errors.InvalidArgument(op, "ColumnWidths", width, "column width cannot be negative")
+		}
+		if width > 0 {
+			columns[i].width = width
+		}
+	}
+
+	totals := opts.TotalsRow && len(data) > 0
+	if totals {
+		if err := markTotalColumns(op, columns, opts.TotalColumns); err != nil {
+			return nil, err
+		}
+	}
+
+	rowCount := len(data)
+	if header {
+		rowCount++
+	}
+	if totals {
+		rowCount++
+	}
+	if rowCount == 0 {
+		return nil, errors.InvalidArgument(op, "rows", 0, "no rows to render")
+	}
+
+	table, err := doc.AddTable(rowCount, len(columns))
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	style := opts.Style
+	if style.Name == "" {
+		style = domain.TableStyleGrid
+	}
+	if err := table.SetStyle(style); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	if err := table.SetWidth(opts.Width); err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+
+	rowIndex := 0
+	if header {
+		texts := make([]dataCell, len(columns))
+		for i, col := range columns {
+			texts[i] = dataCell{text: col.header}
+		}
+		err := fillDataRow(table, rowIndex, columns, texts, dataRowStyle{
+			bold:      !opts.PlainHeader,
+			shading:   opts.HeaderShading,
+			textColor: opts.HeaderTextColor,
+		}, opts)
+		if err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		rowIndex++
+	}
+
+	for i, cells := range data {
+		rowStyle := dataRowStyle{}
+		if i%2 == 1 {
+			rowStyle.shading = opts.ZebraShading
+		}
+		if err := fillDataRow(table, rowIndex, columns, cells, rowStyle, opts); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+		rowIndex++
+	}
+
+	if totals {
+		cells := totalsRow(columns, data, opts.TotalsLabel)
+		if err := fillDataRow(table, rowIndex, columns, cells, dataRowStyle{bold: true}, opts); err != nil {
+			return nil, errors.Wrap(err, op)
+		}
+	}
+
+	return table, nil
+}
+
+// dataRowStyle carries the row-level formatting applied by fillDataRow.
+type dataRowStyle struct {
+	bold      bool
+	shading   *domain.Color
+	textColor *domain.Color
+}
+
+func fillDataRow(table domain.Table, index int, columns []dataColumn, cells []dataCell, style dataRowStyle, opts *DataTableOptions) error {
+	row, err := table.Row(index)
+	if err != nil {
+		return err
+	}
+
+	for i, col := range columns {
+		cell, err := row.Cell(i)
+		if err != nil {
+			return err
+		}
+
+		if col.width > 0 {
+			if err := cell.SetWidth(col.width); err != nil {
+				return err
+			}
+		}
+		if style.shading != nil {
+			if err := cell.SetShading(*style.shading); err != nil {
+				return err
+			}
+		}
+
+		para, err := cell.AddParagraph()
+		if err != nil {
+			return err
+		}
+
+		if align, ok := columnAlignment(col, opts); ok {
+			if err := para.SetAlignment(align); err != nil {
+				return err
+			}
+		}
+
+		if cells[i].text == "" {
+			continue
+		}
+
+		run, err := para.AddRun()
+		if err != nil {
+			return err
+		}
+		if err := run.SetText(cells[i].text); err != nil {
+			return err
+		}
+		if style.bold {
+			if err := run.SetBold(true); err != nil {
+				return err
+			}
+		}
+		if style.textColor != nil {
+			if err := run.SetColor(*style.textColor); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// columnAlignment returns the paragraph alignment for a column, if it differs
+// from the default. Header cells use it too so headers line up with numbers.
+func columnAlignment(col dataColumn, opts *DataTableOptions) (domain.Alignment, bool) {
+	if col.alignSet {
+		return col.align, true
+	}
+	if col.numeric && !opts.NoNumericAlign {
+		return domain.AlignmentRight, true
+	}
+	return domain.AlignmentLeft, false
+}
+
+// markTotalColumns decides which columns are summed in the totals row.
+func markTotalColumns(op string, columns []dataColumn, explicit []int) error {
+	if len(explicit) > 0 {
+		for i := range columns {
+			columns[i].total = false
+		}
+		for _, idx := range explicit {
+			if idx < 0 || idx >= len(columns) {
+				return errors.InvalidArgument(op, "TotalColumns", idx, "column index out of bounds")
+			}
+			columns[idx].total = true
+		}
+		return nil
+	}
+
+	for _, col := range columns {
+		if col.total {
+			return nil
+		}
+	}
+	for i := range columns {
+		columns[i].total = columns[i].numeric
+	}
+	return nil
+}
+
+// totalsRow sums the total columns and places the label in the first free
+// column, or before the sum in the first column when none is free.
+func totalsRow(columns []dataColumn, data [][]dataCell, label string) []dataCell {
+	if label == "" {
+		label = "Total"
+	}
+
+	cells := make([]dataCell, len(columns))
+	labelPlaced := false
+	for c, col := range columns {
+		if !col.total {
+			if !labelPlaced {
+				cells[c] = dataCell{text: label}
+				labelPlaced = true
+			}
+			continue
+		}
+
+		sum := 0.0
+		decimals := 0
+		for _, row := range data {
+			if !row[c].numeric {
+				continue
+			}
+			sum += row[c].number
+			if d := decimalPlaces(row[c].text); d > decimals {
+				decimals = d
+			}
+		}
+		cells[c] = dataCell{text: formatTotal(col, sum, decimals), number: sum, numeric: true}
+	}
+	if !labelPlaced && len(cells) > 0 {
+		cells[0].text = label + " " + cells[0].text
+	}
+	return cells
+}
+
+func formatTotal(col dataColumn, sum float64, decimals int) string {
+	switch {
+	case col.integer && col.format != "":
+		return fmt.Sprintf(col.format, int64(sum))
+	case col.integer:
+		return strconv.FormatInt(int64(sum), 10)
+	case col.format != "":
+		return fmt.Sprintf(col.format, sum)
+	default:
+		return strconv.FormatFloat(sum, 'f', decimals, 64)
+	}
+}
+
+// decimalPlaces counts the digits after the decimal point in a rendered number.
+func decimalPlaces(text string) int {
+	text = strings.TrimSpace(text)
+	idx := strings.LastIndex(text, ".")
+	if idx == -1 {
+		return 0
+	}
+	count := 0
+	for _, r := range text[idx+1:] {
+		if r < '0' || r > '9' {
+			break
+		}
+		count++
+	}
+	return count
+}
+
+// parseDataCell parses a record value, recognising numbers with optional
+// thousands separators.
+func parseDataCell(text string) dataCell {
+	cell := dataCell{text: text}
+	trimmed := strings.ReplaceAll(strings.TrimSpace(text), ",", "")
+	if trimmed == "" {
+		return cell
+	}
+	if n, err := strconv.ParseFloat(trimmed, 64); err == nil {
+		cell.number = n
+		cell.numeric = true
+	}
+	return cell
+}
+
+// structColumns builds column descriptors from the exported fields of t.
+func structColumns(t reflect.Type) ([]dataColumn, error) {
+	const op = "docx.structColumns"
+
+	columns := make([]dataColumn, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag, hasTag := field.Tag.Lookup("docx")
+		if tag == "-" {
+			continue
+		}
+
+		col := dataColumn{header: field.Name, field: field.Index}
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		switch fieldType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			col.numeric = true
+			col.integer = true
+		case reflect.Float32, reflect.Float64:
+			col.numeric = true
+		}
+
+		if hasTag {
+			parts := strings.Split(tag, ",")
+			if name := strings.TrimSpace(parts[0]); name != "" {
+				col.header = name
+			}
+			for _, part := range parts[1:] {
+				key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+				switch key {
+				case "width":
+					width, err := strconv.Atoi(value)
+					if err != nil || width < 0 {
+						return nil, errors.InvalidArgument(op, field.Name, value, "width must be a non-negative integer (twips)")
+					}
+					col.width = width
+				case "align":
+					align, ok := parseAlignmentTag(value)
+					if !ok {
+						return nil, errors.InvalidArgument(op, field.Name, value, "align must be left, center, right or justify")
+					}
+					col.align = align
+					col.alignSet = true
+				case "format":
+					col.format = value
+				case "total":
+					col.total = true
+				case "":
+				default:
+					return nil, errors.InvalidArgument(op, field.Name, key, "unknown docx tag option")
+				}
+			}
+		}
+		if format, ok := field.Tag.Lookup("docxformat"); ok {
+			col.format = format
+		}
+
+		columns = append(columns, col)
+	}
+
+	return columns, nil
+}
+
+func parseAlignmentTag(value string) (domain.Alignment, bool) {
+	switch strings.ToLower(value) {
+	case "left":
+		return domain.AlignmentLeft, true
+	case "center":
+		return domain.AlignmentCenter, true
+	case "right":
+		return domain.AlignmentRight, true
+	case "justify", "both":
+		return domain.AlignmentJustify, true
+	default:
+		return domain.AlignmentLeft, false
+	}
+}
+
+// formatStructValue renders a struct field according to its column settings.
+func formatStructValue(v reflect.Value, col dataColumn) dataCell {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return dataCell{}
+		}
+		v = v.Elem()
+	}
+
+	cell := dataCell{}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		cell.number = float64(v.Int())
+		cell.numeric = true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		cell.number = float64(v.Uint())
+		cell.numeric = true
+	case reflect.Float32, reflect.Float64:
+		cell.number = v.Float()
+		cell.numeric = true
+	}
+
+	iface := v.Interface()
+	switch {
+	case col.format != "":
+		cell.text = fmt.Sprintf(col.format, iface)
+	case v.Kind() == reflect.Float32:
+		cell.text = strconv.FormatFloat(v.Float(), 'f', -1, 32)
+	case v.Kind() == reflect.Float64:
+		cell.text = strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		if t, ok := iface.(time.Time); ok {
+			cell.text = t.Format("2006-01-02")
+		} else {
+			cell.text = fmt.Sprint(iface)
+		}
+	}
+
+	return cell
+}
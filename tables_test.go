@@ -0,0 +1,281 @@
+package docx
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+)
+
+func cellText(t *testing.T, table domain.Table, row, col int) string {
+	t.Helper()
+	r, err := table.Row(row)
+	if err != nil {
+		t.Fatalf("Row(%d): %v", row, err)
+	}
+	c, err := r.Cell(col)
+	if err != nil {
+		t.Fatalf("Cell(%d): %v", col, err)
+	}
+	var sb strings.Builder
+	for _, para := range c.Paragraphs() {
+		sb.WriteString(para.Text())
+	}
+	return sb.String()
+}
+
+func cellAlignment(t *testing.T, table domain.Table, row, col int) domain.Alignment {
+	t.Helper()
+	r, _ := table.Row(row)
+	c, _ := r.Cell(col)
+	paras := c.Paragraphs()
+	if len(paras) == 0 {
+		t.Fatalf("cell (%d,%d) has no paragraphs", row, col)
+	}
+	return paras[0].Alignment()
+}
+
+type saleRow struct {
+	Region   string  `docx:"Region,width=2400"`
+	Units    int     `docx:"Units"`
+	Amount   float64 `docx:"Amount,format=%.2f,total"`
+	Comment  string  `docx:"-"`
+	internal string
+}
+
+func TestTableFromStructs(t *testing.T) {
+	doc := NewDocument()
+	gray := domain.Color{R: 0xD9, G: 0xD9, B: 0xD9}
+	light := domain.Color{R: 0xF2, G: 0xF2, B: 0xF2}
+
+	rows := []saleRow{
+		{Region: "North", Units: 3, Amount: 1200.5, Comment: "hidden"},
+		{Region: "South", Units: 7, Amount: 99.25},
+		{Region: "East", Units: 1, Amount: 10},
+	}
+
+	table, err := TableFromStructs(doc, rows, &DataTableOptions{
+		HeaderShading: &gray,
+		ZebraShading:  &light,
+		TotalsRow:     true,
+	})
+	if err != nil {
+		t.Fatalf("TableFromStructs: %v", err)
+	}
+
+	if table.ColumnCount() != 3 {
+		t.Fatalf("expected 3 columns, got %d", table.ColumnCount())
+	}
+	if table.RowCount() != 5 {
+		t.Fatalf("expected header + 3 rows + totals, got %d rows", table.RowCount())
+	}
+	if table.Style() != domain.TableStyleGrid {
+		t.Errorf("expected grid style by default, got %+v", table.Style())
+	}
+
+	for col, want := range []string{"Region", "Units", "Amount"} {
+		if got := cellText(t, table, 0, col); got != want {
+			t.Errorf("header %d = %q, want %q", col, got, want)
+		}
+	}
+	if got := cellText(t, table, 1, 2); got != "1200.50" {
+		t.Errorf("formatted amount = %q", got)
+	}
+
+	// Only the tagged column is summed; the label goes in the first free column.
+	if got := cellText(t, table, 4, 0); got != "Total" {
+		t.Errorf("totals label = %q", got)
+	}
+	if got := cellText(t, table, 4, 1); got != "" {
+		t.Errorf("untagged column should not be summed, got %q", got)
+	}
+	if got := cellText(t, table, 4, 2); got != "1309.75" {
+		t.Errorf("amount total = %q", got)
+	}
+
+	header, _ := table.Row(0)
+	headerCell, _ := header.Cell(0)
+	if headerCell.Shading() != gray {
+		t.Error("expected header shading")
+	}
+	if headerCell.Width() != 2400 {
+		t.Errorf("expected tagged width 2400, got %d", headerCell.Width())
+	}
+	if runs := headerCell.Paragraphs()[0].Runs(); len(runs) != 1 || !runs[0].Bold() {
+		t.Error("expected bold header run")
+	}
+
+	first, _ := table.Row(1)
+	firstCell, _ := first.Cell(0)
+	second, _ := table.Row(2)
+	secondCell, _ := second.Cell(0)
+	if firstCell.Shading() != domain.ColorWhite || secondCell.Shading() != light {
+		t.Error("expected zebra shading on every other data row")
+	}
+
+	if cellAlignment(t, table, 1, 1) != domain.AlignmentRight {
+		t.Error("numeric column should be right-aligned")
+	}
+	if cellAlignment(t, table, 0, 2) != domain.AlignmentRight {
+		t.Error("numeric header should follow column alignment")
+	}
+	if cellAlignment(t, table, 1, 0) != domain.AlignmentLeft {
+		t.Error("text column should keep default alignment")
+	}
+}
+
+func TestTableFromStructs_Pointers(t *testing.T) {
+	type item struct {
+		Name  string
+		Price *float64 `docx:",align=center"`
+	}
+	price := 4.5
+
+	doc := NewDocument()
+	table, err := TableFromStructs(doc, []*item{{Name: "a", Price: &price}, nil, {Name: "b"}}, nil)
+	if err != nil {
+		t.Fatalf("TableFromStructs: %v", err)
+	}
+	if table.RowCount() != 3 {
+		t.Fatalf("nil elements should be skipped, got %d rows", table.RowCount())
+	}
+	if got := cellText(t, table, 0, 1); got != "Price" {
+		t.Errorf("empty tag name should fall back to field name, got %q", got)
+	}
+	if got := cellText(t, table, 1, 1); got != "4.5" {
+		t.Errorf("price = %q", got)
+	}
+	if got := cellText(t, table, 2, 1); got != "" {
+		t.Errorf("nil pointer should render empty, got %q", got)
+	}
+	if cellAlignment(t, table, 1, 1) != domain.AlignmentCenter {
+		t.Error("explicit align should override numeric alignment")
+	}
+}
+
+func TestTableFromStructs_FormatsAndLabel(t *testing.T) {
+	doc := NewDocument()
+
+	// Every column is summed, so the label goes before the first sum.
+	type weights struct {
+		Net   int     `docx:"Net,total" docxformat:"%d kg, net"`
+		Gross float64 `docx:"Gross,total"`
+	}
+	table, err := TableFromStructs(doc, []weights{{Net: 2, Gross: 2.5}, {Net: 3, Gross: 1}}, &DataTableOptions{TotalsRow: true})
+	if err != nil {
+		t.Fatalf("TableFromStructs: %v", err)
+	}
+	if got := cellText(t, table, 1, 0); got != "2 kg, net" {
+		t.Errorf("formatted value = %q", got)
+	}
+	if got := cellText(t, table, 3, 0); got != "Total 5 kg, net" {
+		t.Errorf("totals label = %q, want it before the first sum", got)
+	}
+	if got := cellText(t, table, 3, 1); got != "3.5" {
+		t.Errorf("gross total = %q", got)
+	}
+}
+
+func TestTableFromStructs_Errors(t *testing.T) {
+	doc := NewDocument()
+
+	if _, err := TableFromStructs(doc, []int{1, 2}, nil); err == nil {
+		t.Error("expected error for non-struct element type")
+	}
+
+	type badWidth struct {
+		A string `docx:"A,width=wide"`
+	}
+	if _, err := TableFromStructs(doc, []badWidth{{}}, nil); err == nil {
+		t.Error("expected error for invalid width")
+	}
+
+	type badOption struct {
+		A string `docx:"A,bogus"`
+	}
+	if _, err := TableFromStructs(doc, []badOption{{}}, nil); err == nil {
+		t.Error("expected error for unknown tag option")
+	}
+
+	if _, err := TableFromStructs[saleRow](nil, nil, nil); err == nil {
+		t.Error("expected error for nil document")
+	}
+}
+
+func TestTableFromCSV(t *testing.T) {
+	doc := NewDocument()
+	input := "Product,Qty,Price\nWidget,2,\"1,250.5\"\nGadget,10,3.25\nGizmo,,x\n"
+
+	table, err := TableFromCSV(doc, strings.NewReader(input), &DataTableOptions{
+		TotalsRow:    true,
+		ColumnWidths: []int{3000},
+	})
+	if err != nil {
+		t.Fatalf("TableFromCSV: %v", err)
+	}
+
+	if table.RowCount() != 5 || table.ColumnCount() != 3 {
+		t.Fatalf("unexpected table size %dx%d", table.RowCount(), table.ColumnCount())
+	}
+
+	// Qty is numeric (blank values ignored); Price is not because of "x".
+	if cellAlignment(t, table, 1, 1) != domain.AlignmentRight {
+		t.Error("Qty should be right-aligned")
+	}
+	if cellAlignment(t, table, 1, 2) != domain.AlignmentLeft {
+		t.Error("Price should not be treated as numeric")
+	}
+	if got := cellText(t, table, 4, 1); got != "12" {
+		t.Errorf("Qty total = %q", got)
+	}
+	if got := cellText(t, table, 4, 0); got != "Total" {
+		t.Errorf("totals label = %q", got)
+	}
+
+	row, _ := table.Row(2)
+	cell, _ := row.Cell(0)
+	if cell.Width() != 3000 {
+		t.Errorf("expected column width 3000, got %d", cell.Width())
+	}
+}
+
+func TestTableFromRecords(t *testing.T) {
+	doc := NewDocument()
+	records := [][]string{
+		{"a", "1.5"},
+		{"b", "2.25", "extra"},
+	}
+
+	table, err := TableFromRecords(doc, records, &DataTableOptions{
+		NoHeader:     true,
+		TotalsRow:    true,
+		TotalsLabel:  "Sum",
+		TotalColumns: []int{1},
+	})
+	if err != nil {
+		t.Fatalf("TableFromRecords: %v", err)
+	}
+
+	if table.RowCount() != 3 || table.ColumnCount() != 3 {
+		t.Fatalf("unexpected table size %dx%d", table.RowCount(), table.ColumnCount())
+	}
+	if got := cellText(t, table, 0, 0); got != "a" {
+		t.Errorf("first record should be data, got %q", got)
+	}
+	if got := cellText(t, table, 0, 2); got != "" {
+		t.Errorf("short record should be padded, got %q", got)
+	}
+	if got := cellText(t, table, 2, 0); got != "Sum" {
+		t.Errorf("totals label = %q", got)
+	}
+	if got := cellText(t, table, 2, 1); got != "3.75" {
+		t.Errorf("total = %q", got)
+	}
+
+	if _, err := TableFromRecords(doc, nil, nil); err == nil {
+		t.Error("expected error for empty records")
+	}
+	if _, err := TableFromRecords(doc, records, &DataTableOptions{TotalsRow: true, TotalColumns: []int{7}}); err == nil {
+		t.Error("expected error for out-of-range total column")
+	}
+}
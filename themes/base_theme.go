@@ -112,6 +112,36 @@ func (t *baseTheme) WithSpacing(spacing ThemeSpacing) Theme {
 	return cloned
 }
 
+// DocumentTheme maps the palette onto the twelve theme colors: text and
+// background become dark 1 and light 1, headings and muted dark 2 and
+// light 2, the chart colors the accents, and the accent color hyperlinks.
+func (t *baseTheme) DocumentTheme() domain.DocumentTheme {
+	accents := t.colors.ChartColors()
+	name := t.displayName
+	if name == "" {
+		name = t.name
+	}
+	return domain.DocumentTheme{
+		Name: name,
+		Colors: domain.ThemeColorScheme{
+			Dark1:             t.colors.Text,
+			Light1:            t.colors.Background,
+			Dark2:             t.colors.Heading,
+			Light2:            t.colors.Muted,
+			Accent1:           accents[0],
+			Accent2:           accents[1],
+			Accent3:           accents[2],
+			Accent4:           accents[3],
+			Accent5:           accents[4],
+			Accent6:           accents[5],
+			Hyperlink:         t.colors.Accent,
+			FollowedHyperlink: t.colors.TextLight,
+		},
+		MajorFont: t.fonts.Heading,
+		MinorFont: t.fonts.Body,
+	}
+}
+
 // ApplyTo applies the theme to a document by configuring all relevant styles.
 func (t *baseTheme) ApplyTo(doc domain.Document) error {
 	const op = "Theme.ApplyTo"
@@ -129,6 +159,14 @@ func (t *baseTheme) ApplyTo(doc domain.Document) error {
 		return errors.Wrap(err, op)
 	}
 
+	if err := doc.SetChartColors(t.colors.ChartColors()); err != nil {
+		return errors.Wrap(err, op)
+	}
+
+	if err := doc.SetTheme(t.DocumentTheme()); err != nil {
+		return errors.Wrap(err, op)
+	}
+
 	// Apply styles in order: Normal, Headings, Title, Quote, etc.
 	if err := t.applyNormalStyle(styleMgr); err != nil {
 		return errors.Wrap(err, op)
@@ -158,7 +196,7 @@ func (t *baseTheme) applyNormalStyle(styleMgr domain.StyleManager) error {
 	}
 
 	// Set font
-	if err := paraStyle.SetFont(domain.Font{Name: t.fonts.Body}); err != nil {
+	if err := paraStyle.SetFont(domain.Font{Name: t.fonts.Body, Theme: domain.ThemeFontMinor}); err != nil {
 		return err
 	}
 
@@ -170,6 +208,9 @@ func (t *baseTheme) applyNormalStyle(styleMgr domain.StyleManager) error {
 	if err := paraStyle.SetColor(t.colors.Text); err != nil {
 		return err
 	}
+	if err := paraStyle.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorText1}); err != nil {
+		return err
+	}
 
 	// Set spacing
 	if err := paraStyle.SetSpacingBefore(t.spacing.ParagraphBefore); err != nil {
@@ -183,6 +224,13 @@ func (t *baseTheme) applyNormalStyle(styleMgr domain.StyleManager) error {
 	if err := paraStyle.SetLineSpacing(t.spacing.LineSpacing); err != nil {
 		return err
 	}
+	if rs, ok := paraStyle.(interface {
+		SetLineSpacingRule(domain.LineSpacingRule) error
+	}); ok {
+		if err := rs.SetLineSpacingRule(domain.LineSpacingAuto); err != nil {
+			return err
+		}
+	}
 
 	// Note: Size and Color are properties of runs, not paragraph styles in domain.
 	// These will be applied when creating actual paragraphs/runs.
@@ -214,7 +262,7 @@ func (t *baseTheme) applyHeadingStyles(styleMgr domain.StyleManager) error {
 		}
 
 		// Set font
-		if err := paraStyle.SetFont(domain.Font{Name: t.fonts.Heading}); err != nil {
+		if err := paraStyle.SetFont(domain.Font{Name: t.fonts.Heading, Theme: domain.ThemeFontMajor}); err != nil {
 			return err
 		}
 
@@ -233,6 +281,9 @@ func (t *baseTheme) applyHeadingStyles(styleMgr domain.StyleManager) error {
 			if err := paraStyle.SetColor(t.colors.Heading); err != nil {
 				return err
 			}
+			if err := paraStyle.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorText2}); err != nil {
+				return err
+			}
 		}
 
 		// Set spacing
@@ -258,11 +309,12 @@ func (t *baseTheme) applyTitleStyles(styleMgr domain.StyleManager) {
 	// Title style
 	if titleStyle, err := styleMgr.GetStyle(domain.StyleIDTitle); err == nil {
 		if paraStyle, ok := titleStyle.(domain.ParagraphStyle); ok {
-			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Heading})
+			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Heading, Theme: domain.ThemeFontMajor})
 			_ = paraStyle.SetSize(t.headings.H1Size + 8) // Slightly larger than H1
 			_ = paraStyle.SetBold(true)
 			if t.headings.UseColor {
 				_ = paraStyle.SetColor(t.colors.Primary)
+				_ = paraStyle.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorAccent1})
 			}
 			_ = paraStyle.SetAlignment(domain.AlignmentCenter)
 			_ = paraStyle.SetSpacingAfter(t.spacing.HeadingAfter * 2)
@@ -272,7 +324,7 @@ func (t *baseTheme) applyTitleStyles(styleMgr domain.StyleManager) {
 	// Subtitle style
 	if subtitleStyle, err := styleMgr.GetStyle(domain.StyleIDSubtitle); err == nil {
 		if paraStyle, ok := subtitleStyle.(domain.ParagraphStyle); ok {
-			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Body})
+			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Body, Theme: domain.ThemeFontMinor})
 			_ = paraStyle.SetSize(t.fonts.BodySize + 4) // Slightly larger than body
 			_ = paraStyle.SetColor(t.colors.TextLight)
 			_ = paraStyle.SetAlignment(domain.AlignmentCenter)
@@ -286,7 +338,7 @@ func (t *baseTheme) applyQuoteStyles(styleMgr domain.StyleManager) {
 	// Quote style
 	if quoteStyle, err := styleMgr.GetStyle(domain.StyleIDQuote); err == nil {
 		if paraStyle, ok := quoteStyle.(domain.ParagraphStyle); ok {
-			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Body})
+			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Body, Theme: domain.ThemeFontMinor})
 			_ = paraStyle.SetColor(t.colors.TextLight)
 			_ = paraStyle.SetItalic(true)
 			_ = paraStyle.SetIndentation(domain.Indentation{Left: 720, Right: 720}) // 0.5 inch
@@ -296,9 +348,10 @@ func (t *baseTheme) applyQuoteStyles(styleMgr domain.StyleManager) {
 	// Intense Quote style
 	if intenseQuoteStyle, err := styleMgr.GetStyle(domain.StyleIDIntenseQuote); err == nil {
 		if paraStyle, ok := intenseQuoteStyle.(domain.ParagraphStyle); ok {
-			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Body})
+			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Body, Theme: domain.ThemeFontMinor})
 			_ = paraStyle.SetSize(t.fonts.BodySize + 2)
 			_ = paraStyle.SetColor(t.colors.Secondary)
+			_ = paraStyle.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorAccent2})
 			_ = paraStyle.SetBold(true)
 			_ = paraStyle.SetAlignment(domain.AlignmentCenter)
 		}
@@ -309,9 +362,10 @@ func (t *baseTheme) applyQuoteStyles(styleMgr domain.StyleManager) {
 func (t *baseTheme) applyListStyle(styleMgr domain.StyleManager) {
 	if listStyle, err := styleMgr.GetStyle(domain.StyleIDListParagraph); err == nil {
 		if paraStyle, ok := listStyle.(domain.ParagraphStyle); ok {
-			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Body})
+			_ = paraStyle.SetFont(domain.Font{Name: t.fonts.Body, Theme: domain.ThemeFontMinor})
 			_ = paraStyle.SetSize(t.fonts.BodySize)
 			_ = paraStyle.SetColor(t.colors.Text)
+			_ = paraStyle.SetThemeColor(domain.ThemeColorRef{Color: domain.ThemeColorText1})
 			_ = paraStyle.SetSpacingAfter(t.spacing.ParagraphAfter / 2) // Tighter spacing for lists
 		}
 	}
@@ -0,0 +1,286 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package themes
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Format is a theme file format.
+type Format string
+
+// Theme file formats.
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// themeFile is the declarative theme format. Colors are hex strings
+// ("#2F5496"), sizes half-points and spacing twips, as in the Go types.
+// Omitted fields keep the defaults of NewTheme.
+type themeFile struct {
+	Name        string       `json:"name"`
+	DisplayName string       `json:"displayName"`
+	Description string       `json:"description"`
+	Colors      fileColors   `json:"colors"`
+	Fonts       fileFonts    `json:"fonts"`
+	Spacing     fileSpacing  `json:"spacing"`
+	Headings    fileHeadings `json:"headings"`
+}
+
+type fileColors struct {
+	Primary    string `json:"primary"`
+	Secondary  string `json:"secondary"`
+	Accent     string `json:"accent"`
+	Background string `json:"background"`
+	Text       string `json:"text"`
+	TextLight  string `json:"textLight"`
+	Heading    string `json:"heading"`
+	Muted      string `json:"muted"`
+	Success    string `json:"success"`
+	Warning    string `json:"warning"`
+	Error      string `json:"error"`
+}
+
+type fileFonts struct {
+	Body      string `json:"body"`
+	Heading   string `json:"heading"`
+	Monospace string `json:"monospace"`
+	BodySize  int    `json:"bodySize"`
+	SmallSize int    `json:"smallSize"`
+}
+
+type fileSpacing struct {
+	ParagraphBefore int `json:"paragraphBefore"`
+	ParagraphAfter  int `json:"paragraphAfter"`
+	LineSpacing     int `json:"lineSpacing"`
+	HeadingBefore   int `json:"headingBefore"`
+	HeadingAfter    int `json:"headingAfter"`
+	SectionSpacing  int `json:"sectionSpacing"`
+}
+
+type fileHeadings struct {
+	H1Size      int  `json:"h1Size"`
+	H2Size      int  `json:"h2Size"`
+	H3Size      int  `json:"h3Size"`
+	H1Bold      bool `json:"h1Bold"`
+	H2Bold      bool `json:"h2Bold"`
+	H3Bold      bool `json:"h3Bold"`
+	H1Uppercase bool `json:"h1Uppercase"`
+	UseColor    bool `json:"useColor"`
+}
+
+// LoadFile reads a theme from a .json, .yaml or .yml file.
+func LoadFile(path string) (Theme, error) {
+	const op = "themes.LoadFile"
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	var theme Theme
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		theme, err = decode(data, FormatJSON)
+	case ".yaml", ".yml":
+		theme, err = decode(data, FormatYAML)
+	default:
+		return nil, errors.NewValidationError(op, "path", path, "theme files must be .json, .yaml or .yml")
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return theme, nil
+}
+
+// Parse reads a theme in JSON or YAML; a document starting with "{" is
+// read as JSON.
+func Parse(r io.Reader) (Theme, error) {
+	const op = "themes.Parse"
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	format := FormatYAML
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		format = FormatJSON
+	}
+	theme, err := decode(data, format)
+	if err != nil {
+		return nil, errors.Wrap(err, op)
+	}
+	return theme, nil
+}
+
+// Save writes a theme to path, as YAML for .yaml and .yml files and JSON
+// otherwise.
+func Save(theme Theme, path string) error {
+	const op = "themes.Save"
+	format := FormatJSON
+	if ext := strings.ToLower(filepath.Ext(path)); ext == ".yaml" || ext == ".yml" {
+		format = FormatYAML
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, theme, format); err != nil {
+		return errors.Wrap(err, op)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	return nil
+}
+
+// Encode writes a theme to w in the given format.
+func Encode(w io.Writer, theme Theme, format Format) error {
+	const op = "themes.Encode"
+	if theme == nil {
+		return errors.NewValidationError(op, "theme", nil, "theme cannot be nil")
+	}
+	file := newThemeFile(theme)
+	var data []byte
+	switch format {
+	case FormatJSON:
+		out, err := json.MarshalIndent(file, "", "  ")
+		if err != nil {
+			return errors.WrapWithCode(err, errors.ErrCodeInternal, op)
+		}
+		data = append(out, '\n')
+	case FormatYAML:
+		data = marshalYAML(file)
+	default:
+		return errors.NewValidationError(op, "format", format, "format must be json or yaml")
+	}
+	if _, err := w.Write(data); err != nil {
+		return errors.WrapWithCode(err, errors.ErrCodeIO, op)
+	}
+	return nil
+}
+
+// decode parses a theme file over the defaults and validates it.
+func decode(data []byte, format Format) (Theme, error) {
+	const op = "themes.decode"
+	file := newThemeFile(NewTheme("", "", ""))
+	file.Name = ""
+	switch format {
+	case FormatJSON:
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&file); err != nil {
+			return nil, errors.Errorf(errors.ErrCodeValidation, op, "invalid JSON theme: %v", err)
+		}
+	case FormatYAML:
+		if err := unmarshalYAML(bufio.NewScanner(bytes.NewReader(data)), &file); err != nil {
+			return nil, err
+		}
+	}
+	return file.theme()
+}
+
+func newThemeFile(theme Theme) themeFile {
+	c, f, s, h := theme.Colors(), theme.Fonts(), theme.Spacing(), theme.Headings()
+	hex := func(c domain.Color) string { return "#" + color.ToHex(c) }
+	return themeFile{
+		Name:        theme.Name(),
+		DisplayName: theme.DisplayName(),
+		Description: theme.Description(),
+		Colors: fileColors{
+			Primary: hex(c.Primary), Secondary: hex(c.Secondary), Accent: hex(c.Accent),
+			Background: hex(c.Background), Text: hex(c.Text), TextLight: hex(c.TextLight),
+			Heading: hex(c.Heading), Muted: hex(c.Muted), Success: hex(c.Success),
+			Warning: hex(c.Warning), Error: hex(c.Error),
+		},
+		Fonts:    fileFonts(f),
+		Spacing:  fileSpacing(s),
+		Headings: fileHeadings(h),
+	}
+}
+
+// theme validates the file and builds the theme.
+func (f themeFile) theme() (Theme, error) {
+	const op = "themes.Parse"
+	if strings.TrimSpace(f.Name) == "" {
+		return nil, errors.NewValidationError(op, "name", f.Name, "theme name is required")
+	}
+
+	var colors ThemeColors
+	fields := []struct {
+		name  string
+		value string
+		dest  *domain.Color
+	}{
+		{"primary", f.Colors.Primary, &colors.Primary},
+		{"secondary", f.Colors.Secondary, &colors.Secondary},
+		{"accent", f.Colors.Accent, &colors.Accent},
+		{"background", f.Colors.Background, &colors.Background},
+		{"text", f.Colors.Text, &colors.Text},
+		{"textLight", f.Colors.TextLight, &colors.TextLight},
+		{"heading", f.Colors.Heading, &colors.Heading},
+		{"muted", f.Colors.Muted, &colors.Muted},
+		{"success", f.Colors.Success, &colors.Success},
+		{"warning", f.Colors.Warning, &colors.Warning},
+		{"error", f.Colors.Error, &colors.Error},
+	}
+	for _, field := range fields {
+		c, err := color.FromHex(field.value)
+		if err != nil {
+			return nil, errors.NewValidationError(op, "colors."+field.name, field.value, "color must be a hex value such as \"#2F5496\"")
+		}
+		*field.dest = c
+	}
+
+	if f.Fonts.Body == "" || f.Fonts.Heading == "" {
+		return nil, errors.NewValidationError(op, "fonts", f.Fonts, "body and heading fonts are required")
+	}
+	for name, size := range map[string]int{
+		"fonts.bodySize": f.Fonts.BodySize, "fonts.smallSize": f.Fonts.SmallSize,
+		"headings.h1Size": f.Headings.H1Size, "headings.h2Size": f.Headings.H2Size, "headings.h3Size": f.Headings.H3Size,
+	} {
+		if size <= 0 {
+			return nil, errors.NewValidationError(op, name, size, "size must be positive (in half-points)")
+		}
+	}
+	if f.Spacing.LineSpacing <= 0 {
+		return nil, errors.NewValidationError(op, "spacing.lineSpacing", f.Spacing.LineSpacing, "line spacing must be positive (240 = single)")
+	}
+
+	return &baseTheme{
+		name:        f.Name,
+		displayName: f.DisplayName,
+		description: f.Description,
+		colors:      colors,
+		fonts:       ThemeFonts(f.Fonts),
+		spacing:     ThemeSpacing(f.Spacing),
+		headings:    ThemeHeadings(f.Headings),
+	}, nil
+}
@@ -0,0 +1,123 @@
+package themes_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	docx "github.com/mmonterroca/docxgo/v2"
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/themes"
+)
+
+func TestSaveAndLoadFile(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"fintech.json", "fintech.yaml"} {
+		path := filepath.Join(dir, name)
+		if err := themes.Save(themes.Fintech, path); err != nil {
+			t.Fatalf("Save(%s) error = %v", name, err)
+		}
+		loaded, err := themes.LoadFile(path)
+		if err != nil {
+			t.Fatalf("LoadFile(%s) error = %v", name, err)
+		}
+		if loaded.Name() != "fintech" || loaded.Colors() != themes.Fintech.Colors() ||
+			loaded.Fonts() != themes.Fintech.Fonts() || loaded.Spacing() != themes.Fintech.Spacing() ||
+			loaded.Headings() != themes.Fintech.Headings() {
+			t.Errorf("%s did not round-trip: %+v", name, loaded)
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	const brand = `# Brand theme
+name: brand
+displayName: 'Brand'
+colors:
+  primary: "#1A73E8"   # blue
+  heading: 202124
+fonts:
+  heading: Georgia
+  bodySize: 24
+headings:
+  h1Size: 40
+  useColor: false
+`
+	theme, err := themes.Parse(strings.NewReader(brand))
+	if err != nil {
+		t.Fatalf("Parse(yaml) error = %v", err)
+	}
+	if theme.Colors().Primary != (domain.Color{R: 0x1A, G: 0x73, B: 0xE8}) || theme.Colors().Heading != (domain.Color{R: 0x20, G: 0x21, B: 0x24}) {
+		t.Errorf("colors = %+v", theme.Colors())
+	}
+	if theme.Fonts().Heading != "Georgia" || theme.Fonts().Body != "Calibri" || theme.Fonts().BodySize != 24 {
+		t.Errorf("fonts = %+v", theme.Fonts())
+	}
+	if h := theme.Headings(); h.H1Size != 40 || h.H2Size != 26 || h.UseColor {
+		t.Errorf("headings = %+v", h)
+	}
+
+	theme, err = themes.Parse(strings.NewReader(`{"name": "brand", "colors": {"accent": "#C00"}}`))
+	if err != nil {
+		t.Fatalf("Parse(json) error = %v", err)
+	}
+	if theme.Colors().Accent != (domain.Color{R: 0xCC}) {
+		t.Errorf("accent = %+v", theme.Colors().Accent)
+	}
+
+	for _, bad := range []string{
+		"colors:\n  primary: blue\n",
+		"name: x\ncolours:\n  primary: \"#000\"\n",
+		"name: x\nfonts:\n  bodySize: large\n",
+		"name: x\nfonts:\n  bodySize: 0\n",
+		`{"name": "x", "fonts": {"body": "Arial", "size": 1}}`,
+	} {
+		if _, err := themes.Parse(strings.NewReader(bad)); err == nil {
+			t.Errorf("Parse(%q) expected error", bad)
+		}
+	}
+}
+
+func TestFromDocument(t *testing.T) {
+	doc := docx.NewDocument()
+	if err := themes.Startup.ApplyTo(doc); err != nil {
+		t.Fatalf("ApplyTo() error = %v", err)
+	}
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo() error = %v", err)
+	}
+	opened, err := docx.OpenDocumentFromBytes(buf.Bytes())
+	if err != nil {
+		t.Fatalf("OpenDocumentFromBytes() error = %v", err)
+	}
+
+	derived, err := themes.FromDocument(opened)
+	if err != nil {
+		t.Fatalf("FromDocument() error = %v", err)
+	}
+	want := themes.Startup
+	got := derived.Colors()
+	got.Error = want.Colors().Error
+	if got != want.Colors() {
+		t.Errorf("colors = %+v, want %+v", got, want.Colors())
+	}
+	if f := derived.Fonts(); f.Body != want.Fonts().Body || f.Heading != want.Fonts().Heading || f.BodySize != want.Fonts().BodySize {
+		t.Errorf("fonts = %+v, want %+v", f, want.Fonts())
+	}
+	if s := derived.Spacing(); s.ParagraphAfter != want.Spacing().ParagraphAfter || s.HeadingBefore != want.Spacing().HeadingBefore || s.LineSpacing != want.Spacing().LineSpacing {
+		t.Errorf("spacing = %+v, want %+v", s, want.Spacing())
+	}
+	h, wh := derived.Headings(), want.Headings()
+	if h.H1Size != wh.H1Size || h.H2Size != wh.H2Size || h.H3Size != wh.H3Size || h.UseColor != wh.UseColor {
+		t.Errorf("headings = %+v, want %+v", h, wh)
+	}
+	if derived.DisplayName() != want.DisplayName() {
+		t.Errorf("display name = %q", derived.DisplayName())
+	}
+
+	if _, err := themes.FromDocument(nil); err == nil {
+		t.Error("expected error for nil document")
+	}
+}
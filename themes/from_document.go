@@ -0,0 +1,195 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package themes
+
+import (
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/domain"
+	"github.com/mmonterroca/docxgo/v2/pkg/color"
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// FromDocument derives a theme from a document, typically a template
+// opened with docxgo.OpenDocument. Colors and fonts come from the document
+// theme (theme1.xml), sizes and spacing from the Normal, Heading 1-3 and
+// Subtitle styles (styles.xml). Calling it on a document saved after
+// ApplyTo recovers the applied theme's palette (except Error), fonts,
+// sizes and spacing.
+func FromDocument(doc domain.Document) (Theme, error) {
+	const op = "themes.FromDocument"
+	if doc == nil {
+		return nil, errors.NewValidationError(op, "document", nil, "document cannot be nil")
+	}
+	styleMgr := doc.StyleManager()
+	if styleMgr == nil {
+		return nil, errors.InvalidState(op, "style manager is nil")
+	}
+
+	dt := doc.Theme()
+	scheme := dt.Colors
+	t := &baseTheme{
+		name:        themeSlug(dt.Name),
+		displayName: dt.Name,
+		description: "Derived from document theme " + dt.Name,
+		colors: ThemeColors{
+			Primary:    scheme.Accent1,
+			Secondary:  scheme.Accent2,
+			Accent:     scheme.Hyperlink,
+			Background: scheme.Light1,
+			Text:       scheme.Dark1,
+			TextLight:  scheme.FollowedHyperlink,
+			Heading:    scheme.Dark2,
+			Muted:      scheme.Light2,
+			Success:    scheme.Accent4,
+			Warning:    scheme.Accent5,
+			Error:      DefaultThemeColors().Error,
+		},
+		fonts: ThemeFonts{
+			Body:      dt.MinorFont,
+			Heading:   dt.MajorFont,
+			Monospace: DefaultThemeFonts().Monospace,
+			SmallSize: DefaultThemeFonts().SmallSize,
+		},
+		spacing:  DefaultThemeSpacing(),
+		headings: DefaultThemeHeadings(),
+	}
+	t.headings.UseColor = false
+
+	if bg, ok := doc.BackgroundColor(); ok {
+		t.colors.Background = bg
+	}
+
+	if normal := paragraphStyle(styleMgr, domain.StyleIDNormal); normal != nil {
+		t.fonts.Body = styleFont(normal, dt)
+		t.fonts.BodySize = normal.Size()
+		t.spacing.ParagraphBefore = normal.SpacingBefore()
+		t.spacing.ParagraphAfter = normal.SpacingAfter()
+		// Theme line spacing is proportional; exact and minimum heights
+		// in twips have no equivalent.
+		rule := domain.LineSpacingAuto
+		if rs, ok := normal.(interface {
+			LineSpacingRule() domain.LineSpacingRule
+		}); ok {
+			rule = rs.LineSpacingRule()
+		}
+		if line := normal.LineSpacing(); line > 0 && rule == domain.LineSpacingAuto {
+			t.spacing.LineSpacing = line
+		}
+	}
+	if t.fonts.BodySize <= 0 {
+		t.fonts.BodySize = DefaultThemeFonts().BodySize
+	}
+
+	headings := []struct {
+		id   string
+		size *int
+		bold *bool
+	}{
+		{domain.StyleIDHeading1, &t.headings.H1Size, &t.headings.H1Bold},
+		{domain.StyleIDHeading2, &t.headings.H2Size, &t.headings.H2Bold},
+		{domain.StyleIDHeading3, &t.headings.H3Size, &t.headings.H3Bold},
+	}
+	for i, h := range headings {
+		style := paragraphStyle(styleMgr, h.id)
+		if style == nil {
+			continue
+		}
+		if size := style.Size(); size > 0 {
+			*h.size = size
+		}
+		*h.bold = style.Bold()
+		if i > 0 {
+			continue
+		}
+		t.fonts.Heading = styleFont(style, dt)
+		t.spacing.HeadingBefore = style.SpacingBefore()
+		t.spacing.HeadingAfter = style.SpacingAfter()
+		if c, ok := styleColor(style, scheme); ok {
+			t.colors.Heading = c
+			t.headings.UseColor = true
+		}
+	}
+
+	if subtitle := paragraphStyle(styleMgr, domain.StyleIDSubtitle); subtitle != nil {
+		if c, ok := styleColor(subtitle, scheme); ok {
+			t.colors.TextLight = c
+		}
+	}
+
+	return t, nil
+}
+
+func paragraphStyle(styleMgr domain.StyleManager, id string) domain.ParagraphStyle {
+	style, err := styleMgr.GetStyle(id)
+	if err != nil {
+		return nil
+	}
+	ps, _ := style.(domain.ParagraphStyle)
+	return ps
+}
+
+// styleFont returns the font of a style, resolving theme fonts.
+func styleFont(style domain.ParagraphStyle, dt domain.DocumentTheme) string {
+	font := style.Font()
+	switch font.Theme {
+	case domain.ThemeFontMajor:
+		return dt.MajorFont
+	case domain.ThemeFontMinor:
+		return dt.MinorFont
+	}
+	return font.Name
+}
+
+// styleColor returns the text color of a style, resolving theme colors.
+// Black counts as no color.
+func styleColor(style domain.ParagraphStyle, scheme domain.ThemeColorScheme) (domain.Color, bool) {
+	if ref, ok := style.ThemeColor(); ok {
+		c, ok := scheme.Color(ref.Color)
+		if ref.Shade != 0 {
+			c = color.Shade(c, ref.Shade)
+		}
+		if ref.Tint != 0 {
+			c = color.Tint(c, ref.Tint)
+		}
+		return c, ok
+	}
+	if c := style.Color(); c != domain.ColorBlack {
+		return c, true
+	}
+	return domain.Color{}, false
+}
+
+// themeSlug turns a display name into a theme name ("Office Theme" becomes
+// "office-theme").
+func themeSlug(name string) string {
+	fields := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9')
+	})
+	if len(fields) == 0 {
+		return "document"
+	}
+	return strings.Join(fields, "-")
+}
@@ -51,6 +51,10 @@ type Theme interface {
 	// Headings returns the heading styles configuration for the theme.
 	Headings() ThemeHeadings
 
+	// DocumentTheme returns the theme part written to the document, whose
+	// color and font scheme Word shows on its Design tab.
+	DocumentTheme() domain.DocumentTheme
+
 	// ApplyTo applies the theme to a document, configuring all styles.
 	ApplyTo(doc domain.Document) error
 
@@ -104,6 +108,11 @@ type ThemeColors struct {
 	Error domain.Color
 }
 
+// ChartColors returns the series colors charts use under this theme.
+func (c ThemeColors) ChartColors() []domain.Color {
+	return []domain.Color{c.Primary, c.Secondary, c.Accent, c.Success, c.Warning, c.Muted}
+}
+
 // ThemeFonts defines the font configuration for a theme.
 type ThemeFonts struct {
 	// Body is the font family for body text (e.g., "Calibri", "Arial").
@@ -0,0 +1,238 @@
+/*
+MIT License
+
+Copyright (c) 2025 Misael Monterroca
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+*/
+
+package themes
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/mmonterroca/docxgo/v2/pkg/errors"
+)
+
+// Theme files use a small YAML subset: nested "key: value" mappings
+// indented with spaces, scalar values (plain, single or double quoted) and
+// "#" comments. Lists, anchors and multi-line strings are not supported.
+
+const opParseYAML = "themes.parseYAML"
+
+// yamlNode is a mapping entry: either a scalar or a nested mapping.
+type yamlNode struct {
+	line     int
+	scalar   string
+	children map[string]*yamlNode
+}
+
+// unmarshalYAML decodes a YAML theme into v, a pointer to a struct whose
+// fields are named by their json tags.
+func unmarshalYAML(sc *bufio.Scanner, v interface{}) error {
+	root, err := parseYAML(sc)
+	if err != nil {
+		return err
+	}
+	return decodeYAMLNode(root, reflect.ValueOf(v).Elem(), "")
+}
+
+func parseYAML(sc *bufio.Scanner) (*yamlNode, error) {
+	type level struct {
+		indent int
+		node   *yamlNode
+	}
+	root := &yamlNode{children: map[string]*yamlNode{}}
+	stack := []level{{indent: -1, node: root}}
+	var pending *yamlNode // mapping key awaiting nested entries
+	pendingIndent := 0
+	var err error
+
+	for lineNo := 1; sc.Scan(); lineNo++ {
+		raw := sc.Text()
+		if strings.HasPrefix(raw, "---") && lineNo == 1 {
+			continue
+		}
+		line := stripYAMLComment(raw)
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		if strings.HasPrefix(trimmed, "\t") {
+			return nil, errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: indent with spaces, not tabs", lineNo)
+		}
+		indent := len(line) - len(trimmed)
+
+		if pending != nil {
+			if indent > pendingIndent {
+				pending.children = map[string]*yamlNode{}
+				stack = append(stack, level{indent: indent, node: pending})
+			}
+			pending = nil
+		}
+		for len(stack) > 1 && indent < stack[len(stack)-1].indent {
+			stack = stack[:len(stack)-1]
+		}
+		if indent != stack[len(stack)-1].indent && stack[len(stack)-1].indent >= 0 {
+			return nil, errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: inconsistent indentation", lineNo)
+		}
+		if len(stack) == 1 {
+			stack[0].indent = indent
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" || strings.HasPrefix(key, "-") {
+			return nil, errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: expected \"key: value\"", lineNo)
+		}
+		parent := stack[len(stack)-1].node
+		if _, dup := parent.children[key]; dup {
+			return nil, errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: duplicate key %q", lineNo, key)
+		}
+		node := &yamlNode{line: lineNo}
+		parent.children[key] = node
+
+		value = strings.TrimSpace(value)
+		if value == "" {
+			pending, pendingIndent = node, indent
+			continue
+		}
+		if node.scalar, err = unquoteYAML(value); err != nil {
+			return nil, errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: %v", lineNo, err)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, errors.WrapWithCode(err, errors.ErrCodeIO, opParseYAML)
+	}
+	return root, nil
+}
+
+// stripYAMLComment removes a "#" comment that starts the line or follows
+// whitespace outside quotes.
+func stripYAMLComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			} else if c == '\\' && quote == '"' {
+				i++
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+func unquoteYAML(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		return strconv.Unquote(value)
+	case strings.HasPrefix(value, "'"):
+		if len(value) < 2 || !strings.HasSuffix(value, "'") {
+			return "", fmt.Errorf("unterminated string %s", value)
+		}
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	case value == "~" || value == "null":
+		return "", nil
+	}
+	return value, nil
+}
+
+// decodeYAMLNode stores the entries of a mapping node in the struct v.
+func decodeYAMLNode(node *yamlNode, v reflect.Value, path string) error {
+	fields := make(map[string]reflect.Value, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		fields[v.Type().Field(i).Tag.Get("json")] = v.Field(i)
+	}
+	for key, child := range node.children {
+		field, ok := fields[key]
+		if !ok {
+			return errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: unknown field %q", child.line, path+key)
+		}
+		if field.Kind() == reflect.Struct {
+			if child.children == nil {
+				if child.scalar != "" {
+					return errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: %s must be a mapping", child.line, path+key)
+				}
+				continue
+			}
+			if err := decodeYAMLNode(child, field, path+key+"."); err != nil {
+				return err
+			}
+			continue
+		}
+		if child.children != nil {
+			return errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: %s must be a value", child.line, path+key)
+		}
+		if child.scalar == "" && field.Kind() != reflect.String {
+			continue
+		}
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(child.scalar)
+		case reflect.Int:
+			n, err := strconv.Atoi(child.scalar)
+			if err != nil {
+				return errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: %s must be a whole number", child.line, path+key)
+			}
+			field.SetInt(int64(n))
+		case reflect.Bool:
+			b, err := strconv.ParseBool(child.scalar)
+			if err != nil {
+				return errors.Errorf(errors.ErrCodeValidation, opParseYAML, "line %d: %s must be true or false", child.line, path+key)
+			}
+			field.SetBool(b)
+		}
+	}
+	return nil
+}
+
+// marshalYAML writes the struct v as YAML in field order.
+func marshalYAML(v interface{}) []byte {
+	var buf bytes.Buffer
+	writeYAMLStruct(&buf, reflect.ValueOf(v), "")
+	return buf.Bytes()
+}
+
+func writeYAMLStruct(buf *bytes.Buffer, v reflect.Value, indent string) {
+	for i := 0; i < v.NumField(); i++ {
+		key := v.Type().Field(i).Tag.Get("json")
+		field := v.Field(i)
+		switch field.Kind() {
+		case reflect.Struct:
+			fmt.Fprintf(buf, "%s%s:\n", indent, key)
+			writeYAMLStruct(buf, field, indent+"  ")
+		case reflect.String:
+			fmt.Fprintf(buf, "%s%s: %s\n", indent, key, strconv.Quote(field.String()))
+		default:
+			fmt.Fprintf(buf, "%s%s: %v\n", indent, key, field.Interface())
+		}
+	}
+}